@@ -0,0 +1,86 @@
+package app
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"ccw/types"
+)
+
+// GitHub Actions workflow command annotations for validation errors
+
+// runningInGitHubActions reports whether ccw runs inside a GitHub Actions job
+func runningInGitHubActions() bool {
+	return os.Getenv("GITHUB_ACTIONS") == "true"
+}
+
+// escapeAnnotationData escapes an annotation message the way @actions/core does
+func escapeAnnotationData(value string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A").Replace(value)
+}
+
+// escapeAnnotationProperty escapes an annotation property value, which also
+// must not contain the ':' and ',' that delimit properties
+func escapeAnnotationProperty(value string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A", ":", "%3A", ",", "%2C").Replace(value)
+}
+
+// formatActionsAnnotation renders err as an ::error workflow command. Absolute
+// file paths under the repository root are made relative so Actions can place
+// them inline;
+// errors without a file become job-level annotations.
+func formatActionsAnnotation(err types.ValidationError, root string) string {
+	var properties []string
+	if file := err.File; file != "" {
+		if root != "" && filepath.IsAbs(file) {
+			if rel, relErr := filepath.Rel(root, file); relErr == nil && !strings.HasPrefix(rel, "..") {
+				file = rel
+			}
+		}
+		properties = append(properties, "file="+escapeAnnotationProperty(filepath.ToSlash(file)))
+		if err.Line > 0 {
+			properties = append(properties, fmt.Sprintf("line=%d", err.Line))
+		}
+	}
+	if err.Type != "" {
+		properties = append(properties, "title="+escapeAnnotationProperty("CCW "+err.Type))
+	}
+
+	message := err.Message
+	if err.Occurrences() > 1 {
+		message = fmt.Sprintf("%s (x%d)", message, err.Occurrences())
+	}
+
+	command := "::error"
+	if len(properties) > 0 {
+		command += " " + strings.Join(properties, ",")
+	}
+	return command + "::" + escapeAnnotationData(message)
+}
+
+// writeActionsAnnotations writes one annotation per distinct error, grouped
+// like the console summary
+func writeActionsAnnotations(w io.Writer, errors []types.ValidationError, root string) {
+	for _, group := range types.GroupErrorsByType(types.DedupeErrors(errors)) {
+		for _, err := range group.Errors {
+			fmt.Fprintln(w, formatActionsAnnotation(err, root))
+		}
+	}
+}
+
+// emitActionsAnnotations surfaces the final validation errors inline in the
+// Actions UI. Annotations go to stdout, where the runner reads workflow
+// commands, even in quiet mode.
+func (app *CCWApp) emitActionsAnnotations(result *types.ValidationResult) {
+	if result == nil || !runningInGitHubActions() {
+		return
+	}
+	var root string
+	if app.worktreeConfig != nil {
+		root = app.worktreeConfig.WorktreePath
+	}
+	writeActionsAnnotations(os.Stdout, result.Errors, root)
+}
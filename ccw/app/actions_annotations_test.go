@@ -0,0 +1,84 @@
+package app
+
+import (
+	"bytes"
+	"testing"
+
+	"ccw/types"
+)
+
+func TestFormatActionsAnnotation(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      types.ValidationError
+		expected string
+	}{
+		{
+			"file and line",
+			types.ValidationError{Type: "build", Message: "cannot find 'x' in scope", File: "Sources/Parser.swift", Line: 12},
+			"::error file=Sources/Parser.swift,line=12,title=CCW build::cannot find 'x' in scope",
+		},
+		{
+			"absolute path under the worktree",
+			types.ValidationError{Type: "lint", Message: "Line too long", File: "/work/issue-7/Sources/A.swift", Line: 3},
+			"::error file=Sources/A.swift,line=3,title=CCW lint::Line too long",
+		},
+		{
+			"absolute path outside the worktree",
+			types.ValidationError{Type: "lint", Message: "bad", File: "/elsewhere/A.swift"},
+			"::error file=/elsewhere/A.swift,title=CCW lint::bad",
+		},
+		{
+			"no location",
+			types.ValidationError{Type: "test", Message: "Swift tests failed"},
+			"::error title=CCW test::Swift tests failed",
+		},
+		{
+			"escaping",
+			types.ValidationError{Type: "build", Message: "100% broken\nsee log", File: "dir,x/a:b.swift", Line: 1},
+			"::error file=dir%2Cx/a%3Ab.swift,line=1,title=CCW build::100%25 broken%0Asee log",
+		},
+		{
+			"repeated error",
+			types.ValidationError{Type: "lint", Message: "Trailing whitespace", File: "A.swift", Line: 4, Count: 3},
+			"::error file=A.swift,line=4,title=CCW lint::Trailing whitespace (x3)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := formatActionsAnnotation(tt.err, "/work/issue-7")
+			if result != tt.expected {
+				t.Errorf("Expected '%s', got '%s'", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestWriteActionsAnnotations(t *testing.T) {
+	errors := []types.ValidationError{
+		{Type: "test", Message: "Swift tests failed"},
+		{Type: "build", Message: "missing import", File: "A.swift", Line: 2},
+		{Type: "build", Message: "missing import", File: "A.swift", Line: 2},
+	}
+
+	var out bytes.Buffer
+	writeActionsAnnotations(&out, errors, "")
+
+	expected := "::error file=A.swift,line=2,title=CCW build::missing import (x2)\n" +
+		"::error title=CCW test::Swift tests failed\n"
+	if out.String() != expected {
+		t.Errorf("Expected '%s', got '%s'", expected, out.String())
+	}
+}
+
+func TestRunningInGitHubActions(t *testing.T) {
+	t.Setenv("GITHUB_ACTIONS", "true")
+	if !runningInGitHubActions() {
+		t.Error("Expected GitHub Actions to be detected")
+	}
+	t.Setenv("GITHUB_ACTIONS", "")
+	if runningInGitHubActions() {
+		t.Error("Expected no GitHub Actions outside a job")
+	}
+}
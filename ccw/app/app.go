@@ -11,6 +11,7 @@ import (
 	"ccw/config"
 	"ccw/git"
 	"ccw/github"
+	"ccw/hooks"
 	"ccw/logging"
 	"ccw/pr"
 	"ccw/types"
@@ -20,16 +21,28 @@ import (
 // CCWApp represents the main application structure
 type CCWApp struct {
 	config         *config.Config
+	ccwConfig      *config.CCWConfig
 	gitOps         *git.Operations
 	validator      *git.QualityValidator
 	worktreeConfig *git.WorktreeConfig
 	sessionID      string
 
+	// Workflow state
+	options                 *WorkflowOptions
+	implementationCommitted bool             // Set once changes are committed ahead of recovery
+	ciRetriggered           bool             // Set once failed CI has been re-triggered
+	conflictRebased         bool             // Set once the branch was rebased to resolve PR conflicts
+	mergeability            *pr.Mergeability // PR mergeability from the last conflict check
+	protectionChecks        []string         // Required checks read from branch protection
+	protectionChecksLoaded  bool             // Set once branch protection has been looked up
+	runSummary              *RunSummary      // Outcome of the current run for summary.md
+
 	// Component integrations
 	githubClient      *github.GitHubClient
 	claudeIntegration *claude.ClaudeIntegration
 	commitGenerator   *commit.CommitMessageGenerator
 	prManager         *pr.PRManager
+	hookRunner        *hooks.Runner
 	ui                *ui.UIManager
 	logger            *logging.Logger
 	errorStore        *types.ErrorStore
@@ -54,6 +67,12 @@ func NewCCWApp() (*CCWApp, error) {
 	// Convert to legacy config format for backward compatibility
 	legacyConfig := ccwConfig.ToLegacyConfig()
 
+	// Configure gh path, host and token, honoring the selected account profile
+	tokenSource, err := configureGitHubAccess(ccwConfig)
+	if err != nil {
+		return nil, err
+	}
+
 	// Check if gh CLI is available and authenticated
 	if err := github.CheckGHCLI(); err != nil {
 		return nil, fmt.Errorf("GitHub CLI (gh) is required: %w", err)
@@ -64,30 +83,79 @@ func NewCCWApp() (*CCWApp, error) {
 		Timeout:       parseTimeoutFromConfig(ccwConfig.Git.Timeout),
 		RetryAttempts: ccwConfig.Git.RetryAttempts,
 		RetryDelay:    parseTimeoutFromConfig(ccwConfig.Git.RetryDelay),
+		AuthorName:    ccwConfig.Commit.AuthorName,
+		AuthorEmail:   ccwConfig.Commit.AuthorEmail,
+		PushRemote:    ccwConfig.Git.PushRemote,
+		RemoteName:    ccwConfig.Git.RemoteName,
+		DefaultBranch: ccwConfig.Git.DefaultBranch,
+
+		WorktreeCreateRetries: ccwConfig.Git.WorktreeCreateRetries,
+		WorktreeRemoveRetries: ccwConfig.Git.WorktreeRemoveRetries,
+		NoForceRemove:         !ccwConfig.Git.WorktreeForceRemove,
 	}
 	gitOps := git.NewOperations(ccwConfig.WorktreeBase, gitConfig, legacyConfig)
 
-	// Initialize git validator
+	// Initialize git validator, running inside a container when configured
 	validator := git.NewQualityValidator()
+	if ccwConfig.Validation.ContainerImage != "" {
+		validator = git.NewContainerQualityValidator(ccwConfig.Validation.ContainerImage)
+	}
+	validator.SetParallel(ccwConfig.Validation.Parallel)
 
 	// Initialize components using packages
 	githubClient := &github.GitHubClient{}
 
 	timeout, _ := time.ParseDuration(ccwConfig.ClaudeTimeout)
+	idleTimeout, _ := time.ParseDuration(ccwConfig.Claude.IdleTimeout)
 	claudeIntegration := &claude.ClaudeIntegration{
-		Timeout:    timeout,
-		MaxRetries: ccwConfig.MaxRetries,
-		DebugMode:  ccwConfig.DebugMode,
+		Timeout:          timeout,
+		IdleTimeout:      idleTimeout,
+		MaxRetries:       ccwConfig.MaxRetries,
+		DebugMode:        ccwConfig.DebugMode,
+		MaxContextChars:  ccwConfig.Claude.MaxContextChars,
+		AllowedTools:     ccwConfig.Claude.AllowedTools,
+		DisallowedTools:  ccwConfig.Claude.DisallowedTools,
+		WorkingDirOnly:   ccwConfig.Claude.WorkingDirOnly,
+		ResumeTranscript: ccwConfig.Claude.ResumeTranscript,
+	}
+	prelude, err := claude.LoadPrelude(ccwConfig.Claude.Prelude)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load claude.prelude: %w", err)
 	}
+	claudeIntegration.Prelude = prelude
 
 	// Create UI manager with Bubble Tea enabled by default
+	if ccwConfig.UI.ConsoleMode {
+		os.Setenv("CCW_CONSOLE_MODE", "true")
+	}
 	uiManager := ui.NewUIManager(ccwConfig.UI.Theme, true, ccwConfig.DebugMode) // Force animations=true for Bubble Tea
+	uiManager.SetLogsPanelVisible(ccwConfig.UI.ShowLogs)
+	uiManager.SetLogBuffer(ccwConfig.UI.LogBufferSize)
+	if len(ccwConfig.Workflow.Steps) > 0 {
+		uiManager.SetProgressSteps(progressStepsFromConfig(ccwConfig.Workflow.Steps))
+	}
+
+	// Warn when the installed gh is older than the configured minimum
+	ghVersion, ghVersionOK, ghVersionErr := github.CheckGHVersion(ccwConfig.GitHub.MinGHVersion)
+	if ghVersionErr == nil && !ghVersionOK {
+		uiManager.Warning(fmt.Sprintf("gh %s is older than the minimum supported version %s; some features may not work", ghVersion, ccwConfig.GitHub.MinGHVersion))
+	}
 
 	// Initialize commit generator
 	commitGenerator := &commit.CommitMessageGenerator{}
 
 	// Initialize PR manager
-	prManager := pr.NewPRManager(timeout, ccwConfig.MaxRetries, ccwConfig.DebugMode)
+	// gh commands use github.timeout, separate from the Claude and git timeouts
+	githubTimeout := parseTimeoutFromConfig(ccwConfig.GitHub.Timeout)
+	github.SetTimeout(githubTimeout)
+	prManager := pr.NewPRManager(githubTimeout, ccwConfig.MaxRetries, ccwConfig.DebugMode)
+	prManager.SetCheckAliases(checkAliasesFromConfig(ccwConfig.CI.CheckAliases))
+	ciDelay, _ := time.ParseDuration(ccwConfig.CI.InitialDelay)
+	ciDelayJitter, _ := time.ParseDuration(ccwConfig.CI.InitialDelayJitter)
+	prManager.SetInitialDelay(ciDelay, ciDelayJitter)
+
+	// Initialize lifecycle hook runner
+	hookRunner := newHookRunner(ccwConfig.Hooks)
 
 	// Initialize logger
 	enableFileLogging := ccwConfig.DebugMode || getEnvWithDefault("CCW_LOG_FILE", "false") == "true"
@@ -103,20 +171,27 @@ func NewCCWApp() (*CCWApp, error) {
 		"session_id": sessionID,
 		"debug_mode": ccwConfig.DebugMode,
 		"theme":      ccwConfig.UI.Theme,
+		"token_from": string(tokenSource),
+		"profile":    ccwConfig.Profile,
+		"gh_path":    github.GHPath(),
+		"gh_version": ghVersion,
 	})
 
 	return &CCWApp{
 		config:            legacyConfig,
+		ccwConfig:         ccwConfig,
 		gitOps:            gitOps,
 		validator:         validator,
 		githubClient:      githubClient,
 		claudeIntegration: claudeIntegration,
 		commitGenerator:   commitGenerator,
 		prManager:         prManager,
+		hookRunner:        hookRunner,
 		ui:                uiManager,
 		logger:            logger,
 		errorStore:        errorStore,
 		sessionID:         sessionID,
+		options:           &WorkflowOptions{},
 	}, nil
 }
 
@@ -143,6 +218,34 @@ func parseTimeoutFromConfig(timeoutStr string) time.Duration {
 	return 30 * time.Second // default fallback
 }
 
+// newHookRunner converts hook configuration into a runner keyed by phase
+func newHookRunner(hooksConfig config.HooksConfiguration) *hooks.Runner {
+	toHooks := func(configured []config.HookConfiguration) []hooks.Hook {
+		result := make([]hooks.Hook, len(configured))
+		for i, hook := range configured {
+			result[i] = hooks.Hook{Command: hook.Command, ContinueOnError: hook.ContinueOnError}
+		}
+		return result
+	}
+
+	timeout, _ := time.ParseDuration(hooksConfig.Timeout)
+	return hooks.NewRunner(map[hooks.Phase][]hooks.Hook{
+		hooks.PhasePreImplementation: toHooks(hooksConfig.PreImplementation),
+		hooks.PhasePostValidation:    toHooks(hooksConfig.PostValidation),
+		hooks.PhasePrePush:           toHooks(hooksConfig.PrePush),
+		hooks.PhasePostPR:            toHooks(hooksConfig.PostPR),
+	}, timeout)
+}
+
+// checkAliasesFromConfig converts configured CI check aliases for the PR manager
+func checkAliasesFromConfig(configured []config.CheckAliasConfiguration) []pr.CheckAlias {
+	aliases := make([]pr.CheckAlias, len(configured))
+	for i, alias := range configured {
+		aliases[i] = pr.CheckAlias{Pattern: alias.Pattern, Category: types.CIFailureType(alias.Category)}
+	}
+	return aliases
+}
+
 func generateRandomID(length int) string {
 	const charset = "abcdefghijklmnopqrstuvwxyz0123456789"
 	result := make([]byte, length)
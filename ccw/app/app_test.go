@@ -8,6 +8,8 @@ import (
 	"strings"
 	"testing"
 	"time"
+
+	"ccw/types"
 )
 
 // Test helper functions and mock data structures
@@ -210,7 +212,7 @@ func TestIssueURLConstruction(t *testing.T) {
 }
 
 func TestConsoleCharSelection(t *testing.T) {
-	// Test console character selection logic similar to getConsoleCharWorkflow
+	// Test console character selection logic similar to consoleui.Char
 	fancy := "✓"
 	simple := "OK"
 	
@@ -218,7 +220,7 @@ func TestConsoleCharSelection(t *testing.T) {
 	os.Setenv("CI", "true")
 	defer os.Unsetenv("CI")
 	
-	// Simulate the logic from getConsoleCharWorkflow
+	// Simulate the logic from consoleui.Char
 	isCIMode := os.Getenv("CI") == "true" || 
 		        os.Getenv("GITHUB_ACTIONS") == "true" ||
 		        os.Getenv("GITLAB_CI") == "true"
@@ -306,4 +308,87 @@ func TestEnvironmentVariableHandling(t *testing.T) {
 	if nonExistent != "" {
 		t.Error("Non-existent env var should return empty string")
 	}
-}
\ No newline at end of file
+}
+
+func TestParseWorkflowArgs(t *testing.T) {
+	tests := []struct {
+		name         string
+		args         []string
+		expectedURL  string
+		expectedWait bool
+		expectError  bool
+	}{
+		{"url only", []string{"https://github.com/o/r/issues/1"}, "https://github.com/o/r/issues/1", false, false},
+		{"wait-lock after url", []string{"https://github.com/o/r/issues/1", "--wait-lock"}, "https://github.com/o/r/issues/1", true, false},
+		{"wait-lock before url", []string{"--wait-lock", "https://github.com/o/r/issues/1"}, "https://github.com/o/r/issues/1", true, false},
+		{"missing url", []string{"--wait-lock"}, "", false, true},
+		{"unknown option", []string{"https://github.com/o/r/issues/1", "--bogus"}, "", false, true},
+		{"extra argument", []string{"https://github.com/o/r/issues/1", "extra"}, "", false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issueURL, options, err := ParseWorkflowArgs(tt.args)
+			if tt.expectError {
+				if err == nil {
+					t.Error("Expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if issueURL != tt.expectedURL {
+				t.Errorf("Expected URL '%s', got '%s'", tt.expectedURL, issueURL)
+			}
+			if options.WaitLock != tt.expectedWait {
+				t.Errorf("Expected WaitLock %v, got %v", tt.expectedWait, options.WaitLock)
+			}
+		})
+	}
+}
+
+func TestParseWorkflowArgsPRTemplate(t *testing.T) {
+	_, options, err := ParseWorkflowArgs([]string{"--pr-template", "https://github.com/o/r/issues/1"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !options.PRTemplate {
+		t.Error("Expected PRTemplate to be set")
+	}
+
+	_, options, _ = ParseWorkflowArgs([]string{"https://github.com/o/r/issues/1"})
+	if options.PRTemplate {
+		t.Error("Expected PRTemplate to default to false")
+	}
+}
+
+func TestParseWorkflowArgsAllowProtected(t *testing.T) {
+	_, options, err := ParseWorkflowArgs([]string{"https://github.com/o/r/issues/1", "--allow-protected"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !options.AllowProtected {
+		t.Error("Expected AllowProtected to be set")
+	}
+}
+
+func TestFormatValidationErrorsForDisplayIsStable(t *testing.T) {
+	app := &CCWApp{}
+	result := &types.ValidationResult{Errors: []types.ValidationError{
+		{Type: "test", File: "b_test.go", Line: 2, Message: "fail"},
+		{Type: "lint", File: "z.go", Line: 9, Message: "long line"},
+		{Type: "build", File: "main.go", Line: 7, Message: "undefined: y"},
+		{Type: "lint", File: "a.go", Line: 1, Message: "unused import"},
+		{Type: "build", File: "main.go", Line: 3, Message: "undefined: x"},
+	}}
+
+	expected := "  BUILD (2 errors):\n    - main.go:3: undefined: x\n    - main.go:7: undefined: y\n\n" +
+		"  LINT (2 errors):\n    - a.go:1: unused import\n    - z.go:9: long line\n\n" +
+		"  TEST (1 errors):\n    - b_test.go:2: fail\n\n"
+	for i := 0; i < 20; i++ {
+		if output := app.formatValidationErrorsForDisplay(result); output != expected {
+			t.Fatalf("Call %d: expected:\n%s\ngot:\n%s", i, expected, output)
+		}
+	}
+}
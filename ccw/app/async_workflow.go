@@ -1,25 +1,19 @@
 package app
 
 import (
-	"context"
+	"errors"
 	"fmt"
-	"os"
 	"time"
 
+	"ccw/claude"
+	"ccw/consoleui"
+	"ccw/hooks"
+	"ccw/pr"
 	"ccw/types"
 )
 
-// getConsoleChar returns console-safe characters based on CI environment
-func getConsoleChar(fancy, simple string) string {
-	if os.Getenv("CCW_CONSOLE_MODE") == "true" || 
-	   os.Getenv("CI") == "true" || 
-	   os.Getenv("GITHUB_ACTIONS") == "true" ||
-	   os.Getenv("GITLAB_CI") == "true" ||
-	   os.Getenv("JENKINS_URL") != "" {
-		return simple
-	}
-	return fancy
-}
+// slowestChecksReported is how many of the longest-running checks the CI summary lists
+const slowestChecksReported = 3
 
 // ExecuteAsyncPRWorkflow handles the async PR creation workflow
 func (app *CCWApp) ExecuteAsyncPRWorkflow(issue *types.Issue, worktreePath, branchName string, validationResult *types.ValidationResult) error {
@@ -30,21 +24,26 @@ func (app *CCWApp) ExecuteAsyncPRWorkflow(issue *types.Issue, worktreePath, bran
 
 	// Step 1: Start async operations concurrently
 	app.ui.Info("Starting async analysis and PR generation...")
+	app.setPhase("analysis")
 	app.ui.UpdateProgress("analysis", "in_progress")
 
 	// Start implementation summary generation (async)
 	summaryResultChan := app.claudeIntegration.GenerateImplementationSummaryAsync(worktreePath)
 
 	// Display progress while waiting for async operations
-	loadingIcon := getConsoleChar("⏳", "[GENERATING]")
+	loadingIcon := consoleui.Char("⏳", "[GENERATING]")
 	app.ui.Info(fmt.Sprintf("%s Generating implementation summary...", loadingIcon))
 
 	// Wait for implementation summary with timeout
 	implementationSummary := app.waitForImplementationSummary(summaryResultChan)
+	app.recordImplementationSummary(implementationSummary)
 
 	app.ui.UpdateProgress("analysis", "completed")
 
 	// Step 2: Push changes to remote
+	if err := app.runHooks(hooks.PhasePrePush, issue, nil); err != nil {
+		return err
+	}
 	if err := app.pushChangesToRemote(branchName, worktreePath); err != nil {
 		return err
 	}
@@ -68,7 +67,7 @@ func (app *CCWApp) waitForImplementationSummary(summaryResultChan <-chan types.I
 			select {
 			case <-ticker.C:
 				elapsed := time.Since(startTime).Round(time.Second)
-				timerIcon := getConsoleChar("⏱️", "[TIMER]")
+				timerIcon := consoleui.Char("⏱️", "[TIMER]")
 				app.ui.Info(fmt.Sprintf("%s Implementation summary generation: %s elapsed", timerIcon, elapsed.String()))
 			case <-timerDone:
 				return
@@ -87,7 +86,7 @@ func (app *CCWApp) waitForImplementationSummary(summaryResultChan <-chan types.I
 			app.ui.Warning(fmt.Sprintf("Implementation summary generation failed after %s: %v", elapsed.String(), summaryResult.Error))
 			return "Implementation completed with changes."
 		} else {
-			successIcon := getConsoleChar("✅", "[SUCCESS]")
+			successIcon := consoleui.Char("✅", "[SUCCESS]")
 			app.ui.Success(fmt.Sprintf("%s Implementation summary generated in %s", successIcon, elapsed.String()))
 			return summaryResult.Summary
 		}
@@ -96,7 +95,7 @@ func (app *CCWApp) waitForImplementationSummary(summaryResultChan <-chan types.I
 		timerDone <- true
 		
 		elapsed := time.Since(startTime).Round(time.Second)
-		warningIcon := getConsoleChar("⚠️", "[WARNING]")
+		warningIcon := consoleui.Char("⚠️", "[WARNING]")
 		app.ui.Warning(fmt.Sprintf("%s Implementation summary generation timed out after %s", warningIcon, elapsed.String()))
 		return "Implementation completed with changes."
 	}
@@ -112,9 +111,14 @@ func (app *CCWApp) pushChangesToRemote(branchName, worktreePath string) error {
 	
 	// Start push progress tracking
 	startTime := time.Now()
+	app.setPhase("push")
 	app.ui.UpdateProgress("push", "in_progress")
-	pushIcon := getConsoleChar("📤", "[PUSHING]")
-	app.ui.Info(fmt.Sprintf("%s Pushing changes to remote...", pushIcon))
+	if err := app.validatePushRemote(worktreePath); err != nil {
+		app.ui.UpdateProgress("push", "failed")
+		return err
+	}
+	pushIcon := consoleui.Char("📤", "[PUSHING]")
+	app.ui.Info(fmt.Sprintf("%s Pushing changes to %s...", pushIcon, app.gitOps.PushRemote()))
 	
 	// Push with timer (git push is usually fast, so no need for ticker updates)
 	if err := app.gitOps.PushBranch(worktreePath, branchName); err != nil {
@@ -131,7 +135,7 @@ func (app *CCWApp) pushChangesToRemote(branchName, worktreePath string) error {
 	
 	elapsed := time.Since(startTime).Round(time.Second)
 	app.ui.UpdateProgress("push", "completed")
-	successIcon := getConsoleChar("✅", "[SUCCESS]")
+	successIcon := consoleui.Char("✅", "[SUCCESS]")
 	app.ui.Success(fmt.Sprintf("%s Changes pushed successfully in %s!", successIcon, elapsed.String()))
 	app.debugStep("step7", "Branch pushed successfully", map[string]interface{}{
 		"elapsed_time": elapsed.String(),
@@ -142,8 +146,9 @@ func (app *CCWApp) pushChangesToRemote(branchName, worktreePath string) error {
 // createPullRequestAsync generates PR description and creates PR asynchronously
 func (app *CCWApp) createPullRequestAsync(issue *types.Issue, validationResult *types.ValidationResult, implementationSummary, branchName, worktreePath string) error {
 	// Step 3: Start PR description generation (async)
+	app.setPhase("pr_creation")
 	app.ui.UpdateProgress("pr_creation", "in_progress")
-	loadingIcon := getConsoleChar("⏳", "[GENERATING]")
+	loadingIcon := consoleui.Char("⏳", "[GENERATING]")
 	app.ui.Info(fmt.Sprintf("%s Generating PR description...", loadingIcon))
 
 	prDescRequest := &types.PRDescriptionRequest{
@@ -161,11 +166,14 @@ func (app *CCWApp) createPullRequestAsync(issue *types.Issue, validationResult *
 		ValidationResult:      validationResult,
 		ImplementationSummary: implementationSummary,
 	}
+	app.applyPRTemplate(prDescRequest)
 
 	prDescResultChan := app.claudeIntegration.GeneratePRDescriptionAsync(prDescRequest)
 
 	// Wait for PR description with progress indicator
 	prDescription := app.waitForPRDescription(prDescResultChan, prDescRequest)
+	prDescription = app.withWarningsFooter(prDescription, validationResult)
+	prDescription = withGeneratedByFooter(prDescription, Version)
 
 	// Step 4: Create PR (async)
 	return app.createAndMonitorPR(issue, prDescription, branchName, worktreePath)
@@ -186,7 +194,7 @@ func (app *CCWApp) waitForPRDescription(prDescResultChan <-chan types.PRDescript
 			select {
 			case <-ticker.C:
 				elapsed := time.Since(startTime).Round(time.Second)
-				timerIcon := getConsoleChar("⏱️", "[TIMER]")
+				timerIcon := consoleui.Char("⏱️", "[TIMER]")
 				app.ui.Info(fmt.Sprintf("%s PR description generation: %s elapsed", timerIcon, elapsed.String()))
 			case <-timerDone:
 				return
@@ -205,7 +213,7 @@ func (app *CCWApp) waitForPRDescription(prDescResultChan <-chan types.PRDescript
 			app.ui.Warning(fmt.Sprintf("PR description generation failed after %s: %v", elapsed.String(), prDescResult.Error))
 			return app.claudeIntegration.CreateEnhancedPRDescription(prDescRequest)
 		} else {
-			successIcon := getConsoleChar("✅", "[SUCCESS]")
+			successIcon := consoleui.Char("✅", "[SUCCESS]")
 			app.ui.Success(fmt.Sprintf("%s PR description generated in %s", successIcon, elapsed.String()))
 			return prDescResult.Description
 		}
@@ -214,7 +222,7 @@ func (app *CCWApp) waitForPRDescription(prDescResultChan <-chan types.PRDescript
 		timerDone <- true
 		
 		elapsed := time.Since(startTime).Round(time.Second)
-		warningIcon := getConsoleChar("⚠️", "[WARNING]")
+		warningIcon := consoleui.Char("⚠️", "[WARNING]")
 		app.ui.Warning(fmt.Sprintf("%s PR description generation timed out after %s, using fallback", warningIcon, elapsed.String()))
 		return app.claudeIntegration.CreateEnhancedPRDescription(prDescRequest)
 	}
@@ -222,41 +230,49 @@ func (app *CCWApp) waitForPRDescription(prDescResultChan <-chan types.PRDescript
 
 // createAndMonitorPR creates PR and monitors CI checks
 func (app *CCWApp) createAndMonitorPR(issue *types.Issue, prDescription, branchName, worktreePath string) error {
-	loadingIcon := getConsoleChar("⏳", "[CREATING]")
+	loadingIcon := consoleui.Char("⏳", "[CREATING]")
 	app.ui.Info(fmt.Sprintf("%s Creating pull request...", loadingIcon))
 	prRequest := &types.PRRequest{
 		Title: fmt.Sprintf("Resolve #%d: %s", issue.Number, issue.Title),
 		Body:  prDescription,
 		Head:  branchName,
 		Base:  "master", // or "main"
-		MaintainerCanModify: true,
 	}
+	app.targetPRAtBaseRepo(prRequest, worktreePath, app.worktreeConfig.Owner, app.worktreeConfig.Repository)
+	app.applyMaintainerCanModify(prRequest)
+	app.applyPRParticipants(prRequest)
 
-	prResultChan := app.prManager.CreatePullRequestAsync(prRequest, worktreePath)
-
-	// Wait for PR creation
-	select {
-	case prResult := <-prResultChan:
-		if prResult.Error != nil {
-			app.ui.UpdateProgress("pr_creation", "failed")
-			return fmt.Errorf("failed to create PR: %w", prResult.Error)
-		}
-		
-		app.ui.UpdateProgress("pr_creation", "completed")
-		successIcon := getConsoleChar("✅", "[SUCCESS]")
-		app.ui.Success(fmt.Sprintf("%s Pull request created: %s", successIcon, prResult.PullRequest.HTMLURL))
-		
-		// Step 5: Monitor CI checks with enhanced Goroutine implementation
-		app.monitorCIChecksWithGoroutines(prResult.PullRequest.HTMLURL)
-		
-	case <-time.After(1 * time.Minute):
+	pullRequest, err := app.createPullRequestWithRetry(prRequest, worktreePath)
+	if err != nil {
 		app.ui.UpdateProgress("pr_creation", "failed")
-		return fmt.Errorf("PR creation timed out")
+		if errors.Is(err, errPRCreationTimedOut) {
+			return err
+		}
+		return fmt.Errorf("failed to create PR: %w", err)
+	}
+
+	app.ui.UpdateProgress("pr_creation", "completed")
+	successIcon := consoleui.Char("✅", "[SUCCESS]")
+	app.ui.Success(fmt.Sprintf("%s Pull request created: %s", successIcon, pullRequest.HTMLURL))
+	if app.runSummary != nil {
+		app.runSummary.PRURL = pullRequest.HTMLURL
+	}
+	app.applyPRLabels(issue, pullRequest.HTMLURL)
+
+	if err := app.runHooks(hooks.PhasePostPR, issue, map[string]string{
+		"CCW_PR_URL": pullRequest.HTMLURL,
+	}); err != nil {
+		return err
 	}
+	app.runPostPRSmoke(issue, pullRequest.HTMLURL)
+
+	// Step 5: Monitor CI checks with enhanced Goroutine implementation
+	app.monitorCIChecksWithGoroutines(pullRequest.HTMLURL)
+	app.deleteBranchIfMerged(pullRequest.HTMLURL, branchName, worktreePath)
 
 	app.ui.UpdateProgress("complete", "completed")
-	celebrationIcon := getConsoleChar("🎉", "[COMPLETE]")
-	app.ui.Success(fmt.Sprintf("%s Async workflow completed successfully!", celebrationIcon))
+	celebrationIcon := consoleui.Char("🎉", "[COMPLETE]")
+	app.ui.Success(fmt.Sprintf("%s %s", celebrationIcon, app.finalSuccessMessage()))
 	
 	// Cleanup worktree
 	app.cleanupWorktree(worktreePath)
@@ -266,68 +282,62 @@ func (app *CCWApp) createAndMonitorPR(issue *types.Issue, prDescription, branchN
 
 // monitorCIChecksWithGoroutines monitors CI checks with enhanced Goroutine implementation
 func (app *CCWApp) monitorCIChecksWithGoroutines(prURL string) {
-	loadingIcon := getConsoleChar("⏳", "[MONITORING]")
+	app.setPhase("ci_monitoring")
+	loadingIcon := consoleui.Char("⏳", "[MONITORING]")
 	app.ui.Info(fmt.Sprintf("%s Starting enhanced CI monitoring...", loadingIcon))
 	
-	// Create context with configurable timeout (default: 30 minutes)
-	timeout := 30 * time.Minute
-	
-	ctx, cancel := context.WithTimeout(context.Background(), timeout)
-	defer cancel()
-
-	// Start CI monitoring with Goroutines
-	watchChannel := app.prManager.WatchPRChecksWithGoroutine(ctx, prURL)
-	
-	go func() {
-		// Process real-time updates
-		for update := range watchChannel.Updates {
-			app.handleCIUpdate(update)
-		}
-	}()
-
-	// Wait for completion or timeout
-	select {
-	case result := <-watchChannel.Completion:
-		app.handleCICompletion(result, prURL)
-	case <-ctx.Done():
-		app.ui.Warning("CI monitoring timed out - workflow completed but CI may still be running")
-		// Send cancel signal
-		select {
-		case watchChannel.Cancel <- struct{}{}:
-		default:
-		}
+	// Watch for at most ci.max_monitor_duration, handling real-time updates as they arrive
+	maxDuration := app.maxMonitorDuration()
+	result, timedOut := watchCI(app.prManager.WatchPRChecksWithGoroutine, prURL, maxDuration, app.handleCIUpdate)
+	if timedOut {
+		app.logger.Warn("ci_monitoring", "CI monitoring gave up", map[string]interface{}{
+			"pr_url":       prURL,
+			"max_duration": maxDuration.String(),
+		})
+		app.ui.Warning(fmt.Sprintf("CI monitoring gave up after %v - the PR is left open and CI may still be running", maxDuration))
+		app.explain(fmt.Sprintf("feedback loop stopped: CI monitoring gave up after %v (ci.max_monitor_duration)", maxDuration))
+		return
 	}
+	app.handleCICompletion(result, prURL)
 }
 
 // handleCIUpdate processes real-time CI status updates
 func (app *CCWApp) handleCIUpdate(update types.CIWatchUpdate) {
 	switch update.EventType {
 	case "monitoring_started":
-		clockIcon := getConsoleChar("🕐", "[STARTED]")
+		clockIcon := consoleui.Char("🕐", "[STARTED]")
 		app.ui.Info(fmt.Sprintf("%s %s", clockIcon, update.Message))
+
+	case "initial_delay":
+		waitIcon := consoleui.Char("⏳", "[WAITING]")
+		app.ui.Info(fmt.Sprintf("%s %s", waitIcon, update.Message))
 		
 	case "status_change":
-		progressIcon := getConsoleChar("📈", "[UPDATE]")
+		progressIcon := consoleui.Char("📈", "[UPDATE]")
 		app.ui.Info(fmt.Sprintf("%s %s", progressIcon, update.Message))
 		
 		if update.Status != nil && update.Status.FailedChecks > 0 {
-			failureIcon := getConsoleChar("❌", "[FAILED]")
+			failureIcon := consoleui.Char("❌", "[FAILED]")
 			app.ui.Warning(fmt.Sprintf("%s CI failures detected - analyzing for recovery options", failureIcon))
 			app.analyzeCIFailuresForRecovery(update.Status)
 		}
 		
 	case "all_complete":
 		if update.Status != nil && update.Status.Conclusion == "success" {
-			successIcon := getConsoleChar("✅", "[SUCCESS]")
+			successIcon := consoleui.Char("✅", "[SUCCESS]")
 			app.ui.Success(fmt.Sprintf("%s All CI checks passed!", successIcon))
 		} else {
-			failureIcon := getConsoleChar("❌", "[FAILED]")
+			failureIcon := consoleui.Char("❌", "[FAILED]")
 			app.ui.Error(fmt.Sprintf("%s CI checks failed", failureIcon))
 		}
 		
 	case "error":
-		errorIcon := getConsoleChar("⚠️", "[ERROR]")
+		errorIcon := consoleui.Char("⚠️", "[ERROR]")
 		app.ui.Warning(fmt.Sprintf("%s %s", errorIcon, update.Message))
+
+	case "warning":
+		warningIcon := consoleui.Char("⚠️", "[WARNING]")
+		app.ui.Warning(fmt.Sprintf("%s CI status: %s", warningIcon, update.Message))
 	}
 }
 
@@ -336,34 +346,71 @@ func (app *CCWApp) handleCICompletion(result types.CIWatchResult, prURL string)
 	duration := result.Duration.Truncate(time.Second)
 	
 	if result.Error != nil {
-		errorIcon := getConsoleChar("⚠️", "[ERROR]")
+		errorIcon := consoleui.Char("⚠️", "[ERROR]")
 		app.ui.Error(fmt.Sprintf("%s CI monitoring failed after %v: %v", errorIcon, duration, result.Error))
 		return
 	}
 
 	if result.FinalStatus == nil {
-		warningIcon := getConsoleChar("⚠️", "[WARNING]")
+		warningIcon := consoleui.Char("⚠️", "[WARNING]")
 		app.ui.Warning(fmt.Sprintf("%s CI monitoring completed after %v but no final status available", warningIcon, duration))
 		return
 	}
 
+	_, ciReason := pr.ExplainChecksComplete(result.FinalStatus)
+	app.explain(ciReason)
+	if app.runSummary != nil {
+		app.runSummary.CIConclusion = result.FinalStatus.Conclusion
+	}
+
 	// Report final results
 	if result.FinalStatus.Conclusion == "success" {
-		successIcon := getConsoleChar("🎉", "[COMPLETE]")
+		successIcon := consoleui.Char("🎉", "[COMPLETE]")
 		app.ui.Success(fmt.Sprintf("%s CI monitoring completed successfully after %v", successIcon, duration))
 		app.ui.Success(fmt.Sprintf("Final status: %d checks passed, %d failed", 
 			result.FinalStatus.PassedChecks, result.FinalStatus.FailedChecks))
+		app.reportSlowestChecks(result.FinalStatus)
+
+		// A conflicting PR is rebased once and CI watched again
+		if app.handleMergeConflicts(prURL) {
+			app.monitorCIChecksWithGoroutines(prURL)
+			return
+		}
 		
 		// After CI passes, check for PR comments and address them
-		app.handlePRCommentsAfterSuccess(prURL)
+		comments := app.handlePRCommentsAfterSuccess(prURL)
+		app.reportDefinitionOfDone(prURL, result.FinalStatus, comments)
 	} else {
-		failureIcon := getConsoleChar("❌", "[FAILED]")
+		failureIcon := consoleui.Char("❌", "[FAILED]")
 		app.ui.Error(fmt.Sprintf("%s CI monitoring completed with failures after %v", failureIcon, duration))
 		app.ui.Error(fmt.Sprintf("Final status: %d checks passed, %d failed", 
 			result.FinalStatus.PassedChecks, result.FinalStatus.FailedChecks))
+		app.reportSlowestChecks(result.FinalStatus)
+
+		// Conflicts with the base can fail CI; rebase before re-running it
+		if app.handleMergeConflicts(prURL) {
+			app.monitorCIChecksWithGoroutines(prURL)
+			return
+		}
+
+		// Re-run possibly flaky CI once before giving up on it
+		if app.retriggerFailedCI(result.FinalStatus) {
+			app.monitorCIChecksWithGoroutines(prURL)
+			return
+		}
 			
 		// Analyze failures for potential recovery
 		app.analyzeCIFailuresForRecovery(result.FinalStatus)
+		app.reportCIFailureLogs(prURL, result.FinalStatus)
+		app.explain("feedback loop stopped: CI failed, so PR comments were not addressed")
+		app.reportDefinitionOfDone(prURL, result.FinalStatus, nil)
+	}
+}
+
+// reportSlowestChecks lists the longest-running checks so slow CI jobs stand out
+func (app *CCWApp) reportSlowestChecks(status *types.CIStatus) {
+	if slowest := pr.FormatSlowestChecks(app.prManager.SlowestChecks(status, slowestChecksReported)); slowest != "" {
+		app.ui.Info(slowest)
 	}
 }
 
@@ -378,7 +425,7 @@ func (app *CCWApp) analyzeCIFailuresForRecovery(status *types.CIStatus) {
 	
 	for _, failure := range failures {
 		if failure.Recoverable {
-			recoveryIcon := getConsoleChar("🔧", "[RECOVERY]")
+			recoveryIcon := consoleui.Char("🔧", "[RECOVERY]")
 			app.ui.Info(fmt.Sprintf("%s %s failure detected: %s", recoveryIcon, failure.Type, failure.CheckName))
 			
 			switch failure.Type {
@@ -394,23 +441,25 @@ func (app *CCWApp) analyzeCIFailuresForRecovery(status *types.CIStatus) {
 				app.ui.Info(fmt.Sprintf("  → Details: %s", failure.DetailsURL))
 			}
 		} else {
-			warningIcon := getConsoleChar("⚠️", "[MANUAL]")
+			warningIcon := consoleui.Char("⚠️", "[MANUAL]")
 			app.ui.Warning(fmt.Sprintf("%s Manual intervention required for: %s", warningIcon, failure.CheckName))
 		}
 	}
 }
 
-// handlePRCommentsAfterSuccess handles PR comment analysis and addressing after CI success
-func (app *CCWApp) handlePRCommentsAfterSuccess(prURL string) {
-	commentIcon := getConsoleChar("💬", "[COMMENTS]")
+// handlePRCommentsAfterSuccess handles PR comment analysis and addressing after CI success.
+// It returns the analysis, or nil when comments could not be fetched.
+func (app *CCWApp) handlePRCommentsAfterSuccess(prURL string) *types.PRCommentAnalysis {
+	commentIcon := consoleui.Char("💬", "[COMMENTS]")
 	app.ui.Info(fmt.Sprintf("%s Checking PR comments for actionable items...", commentIcon))
 	
 	// Fetch PR comments
 	comments, err := app.prManager.GetPRComments(prURL)
 	if err != nil {
 		app.ui.Warning(fmt.Sprintf("Failed to fetch PR comments: %v", err))
-		return
+		return nil
 	}
+	comments = append(comments, app.reviewThreadComments(prURL)...)
 	
 	// Analyze comments for actionable items
 	analysis := app.prManager.AnalyzePRComments(comments)
@@ -418,10 +467,15 @@ func (app *CCWApp) handlePRCommentsAfterSuccess(prURL string) {
 	app.ui.Info(fmt.Sprintf("Found %d total comments, %d actionable", 
 		analysis.TotalComments, len(analysis.ActionableComments)))
 	
+	for _, actionable := range analysis.ActionableComments {
+		app.explain(pr.ExplainComment(actionable))
+	}
+
 	if !analysis.HasUnaddressedComments {
-		checkIcon := getConsoleChar("✅", "[COMPLETE]")
+		app.explain("feedback loop stopped: no actionable comments remain")
+		checkIcon := consoleui.Char("✅", "[COMPLETE]")
 		app.ui.Success(fmt.Sprintf("%s No actionable comments found - PR is ready!", checkIcon))
-		return
+		return analysis
 	}
 	
 	// Display actionable comments
@@ -431,6 +485,7 @@ func (app *CCWApp) handlePRCommentsAfterSuccess(prURL string) {
 	if app.shouldAddressComments(analysis) {
 		app.addressPRCommentsWithFeedbackLoop(prURL, analysis)
 	}
+	return analysis
 }
 
 // displayActionableComments shows actionable comments to the user
@@ -458,6 +513,13 @@ func (app *CCWApp) displayActionableComments(analysis *types.PRCommentAnalysis)
 
 // shouldAddressComments determines if comments should be automatically addressed
 func (app *CCWApp) shouldAddressComments(analysis *types.PRCommentAnalysis) bool {
+	address, reason := explainAddressComments(analysis)
+	app.explain(reason)
+	return address
+}
+
+// explainAddressComments decides whether to address comments and why
+func explainAddressComments(analysis *types.PRCommentAnalysis) (bool, string) {
 	// Count high priority actionable comments
 	highPriorityCount := 0
 	for _, actionable := range analysis.ActionableComments {
@@ -465,27 +527,43 @@ func (app *CCWApp) shouldAddressComments(analysis *types.PRCommentAnalysis) bool
 			highPriorityCount++
 		}
 	}
-	
+
 	// Address if there are high priority comments or multiple medium priority ones
-	return highPriorityCount > 0 || len(analysis.ActionableComments) >= 2
+	switch {
+	case highPriorityCount > 0:
+		return true, fmt.Sprintf("addressing comments: %d high-priority comment(s) found", highPriorityCount)
+	case len(analysis.ActionableComments) >= 2:
+		return true, fmt.Sprintf("addressing comments: %d actionable comments found (at least 2 needed without a high-priority one)",
+			len(analysis.ActionableComments))
+	default:
+		return false, fmt.Sprintf("feedback loop stopped: %d actionable comment(s), none high priority (at least 2 needed)",
+			len(analysis.ActionableComments))
+	}
 }
 
 // addressPRCommentsWithFeedbackLoop addresses comments and creates feedback loop
 func (app *CCWApp) addressPRCommentsWithFeedbackLoop(prURL string, analysis *types.PRCommentAnalysis) {
-	workIcon := getConsoleChar("🔧", "[ADDRESSING]")
+	if analysis = app.reviewCommentsInteractively(analysis); analysis == nil {
+		return
+	}
+
+	workIcon := consoleui.Char("🔧", "[ADDRESSING]")
 	app.ui.Info(fmt.Sprintf("%s Addressing PR comments with Claude Code...", workIcon))
 	
 	// Address comments using Claude Code
 	if err := app.addressCommentsWithClaudeCode(prURL, analysis); err != nil {
 		app.ui.Warning(fmt.Sprintf("Failed to address comments: %v", err))
+		app.explain("feedback loop stopped: Claude Code could not address the comments")
 		return
 	}
 	
 	// Push changes after addressing comments
 	if err := app.pushCommentAddressingChanges(prURL); err != nil {
 		app.ui.Warning(fmt.Sprintf("Failed to push comment addressing changes: %v", err))
+		app.explain("feedback loop stopped: changes addressing the comments could not be pushed")
 		return
 	}
+	app.replyToAddressedThreads(prURL, analysis)
 	
 	// Create feedback loop - go back to CI monitoring
 	app.startFeedbackLoop(prURL)
@@ -493,12 +571,12 @@ func (app *CCWApp) addressPRCommentsWithFeedbackLoop(prURL string, analysis *typ
 
 // addressCommentsWithClaudeCode uses Claude Code to address PR comments
 func (app *CCWApp) addressCommentsWithClaudeCode(prURL string, analysis *types.PRCommentAnalysis) error {
-	claudeIcon := getConsoleChar("🤖", "[CLAUDE]")
+	claudeIcon := consoleui.Char("🤖", "[CLAUDE]")
 	app.ui.Info(fmt.Sprintf("%s Running Claude Code to address comments...", claudeIcon))
 	
 	// Prepare Claude context with comment information
 	claudeContext := &types.ClaudeContext{
-		ProjectPath:      app.worktreeConfig.WorktreePath,
+		ProjectPath:      app.projectPath(),
 		TaskType:        "comment_addressing",
 		PRCommentAnalysis: analysis,
 		PRURL:           prURL,
@@ -520,13 +598,11 @@ func (app *CCWApp) pushCommentAddressingChanges(prURL string) error {
 
 // startFeedbackLoop creates a feedback loop back to CI monitoring
 func (app *CCWApp) startFeedbackLoop(prURL string) {
-	loopIcon := getConsoleChar("🔄", "[FEEDBACK]")
+	loopIcon := consoleui.Char("🔄", "[FEEDBACK]")
 	app.ui.Info(fmt.Sprintf("%s Starting feedback loop - returning to CI monitoring...", loopIcon))
 	
-	// Add a short delay to allow CI to start
-	time.Sleep(30 * time.Second)
-	
-	// Restart CI monitoring for the same PR
+	// Restart CI monitoring for the same PR; it waits ci.initial_delay for
+	// the new checks to register before polling
 	app.ui.Info("Changes pushed - restarting CI monitoring...")
 	app.monitorCIChecksWithGoroutines(prURL)
 }
@@ -535,34 +611,34 @@ func (app *CCWApp) startFeedbackLoop(prURL string) {
 func (app *CCWApp) getPriorityIcon(priority types.CommentPriority) string {
 	switch priority {
 	case types.CommentPriorityHigh:
-		return getConsoleChar("🔴", "[HIGH]")
+		return consoleui.Char("🔴", "[HIGH]")
 	case types.CommentPriorityMedium:
-		return getConsoleChar("🟡", "[MEDIUM]")
+		return consoleui.Char("🟡", "[MEDIUM]")
 	case types.CommentPriorityLow:
-		return getConsoleChar("🟢", "[LOW]")
+		return consoleui.Char("🟢", "[LOW]")
 	default:
-		return getConsoleChar("⚪", "[UNKNOWN]")
+		return consoleui.Char("⚪", "[UNKNOWN]")
 	}
 }
 
 func (app *CCWApp) getCategoryIcon(category types.CommentCategory) string {
 	switch category {
 	case types.CommentCodeReview:
-		return getConsoleChar("👨‍💻", "[CODE]")
+		return consoleui.Char("👨‍💻", "[CODE]")
 	case types.CommentSuggestion:
-		return getConsoleChar("💡", "[SUGGEST]")
+		return consoleui.Char("💡", "[SUGGEST]")
 	case types.CommentQuestion:
-		return getConsoleChar("❓", "[QUESTION]")
+		return consoleui.Char("❓", "[QUESTION]")
 	case types.CommentRequest:
-		return getConsoleChar("📝", "[REQUEST]")
+		return consoleui.Char("📝", "[REQUEST]")
 	case types.CommentApproval:
-		return getConsoleChar("👍", "[APPROVAL]")
+		return consoleui.Char("👍", "[APPROVAL]")
 	case types.CommentDiscussion:
-		return getConsoleChar("💭", "[DISCUSS]")
+		return consoleui.Char("💭", "[DISCUSS]")
 	case types.CommentBotGenerated:
-		return getConsoleChar("🤖", "[BOT]")
+		return consoleui.Char("🤖", "[BOT]")
 	default:
-		return getConsoleChar("💬", "[COMMENT]")
+		return consoleui.Char("💬", "[COMMENT]")
 	}
 }
 
@@ -574,6 +650,11 @@ func (app *CCWApp) monitorCIChecks(prURL string) {
 
 // cleanupWorktree removes the temporary worktree
 func (app *CCWApp) cleanupWorktree(worktreePath string) {
+	// --in-place runs use the caller's checkout, which must be left alone
+	if app.inPlace() {
+		return
+	}
+
 	app.debugStep("step8", "Cleaning up worktree", map[string]interface{}{
 		"worktree_path": worktreePath,
 	})
@@ -584,7 +665,29 @@ func (app *CCWApp) cleanupWorktree(worktreePath string) {
 			"worktree_path": worktreePath,
 			"error":         err.Error(),
 		})
+		app.ui.Warning(err.Error())
 	} else {
 		app.debugStep("step8", "Worktree cleaned up successfully", nil)
 	}
-}
\ No newline at end of file
+}
+
+// applyPRTemplate seeds the PR description request with the repository's filled
+// PR template when --pr-template is given
+func (app *CCWApp) applyPRTemplate(req *types.PRDescriptionRequest) {
+	if app.options == nil || !app.options.PRTemplate {
+		return
+	}
+
+	templateBody, err := claude.LoadPRTemplate(req)
+	if err != nil {
+		app.ui.Warning(fmt.Sprintf("Failed to load PR template, using default description: %v", err))
+		return
+	}
+	if templateBody == "" {
+		app.ui.Warning("No pull request template found in the repository, using default description")
+		return
+	}
+
+	req.TemplateBody = templateBody
+	app.ui.Info("Using the repository's pull request template for the PR body")
+}
@@ -0,0 +1,89 @@
+package app
+
+import (
+	"testing"
+
+	"ccw/config"
+)
+
+func TestRunAttempts(t *testing.T) {
+	tests := []struct {
+		name          string
+		maxAttempts   int
+		succeedOn     int
+		expectedCalls int
+		expectedOK    bool
+	}{
+		{"first attempt succeeds", 3, 1, 1, true},
+		{"later attempt succeeds", 3, 2, 2, true},
+		{"all attempts fail", 3, 0, 3, false},
+		{"zero attempts", 0, 1, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			used, ok := runAttempts(tt.maxAttempts, func(n int) bool {
+				calls++
+				if n != calls {
+					t.Errorf("Expected attempt number %d, got %d", calls, n)
+				}
+				return n == tt.succeedOn
+			})
+
+			if calls != tt.expectedCalls {
+				t.Errorf("Expected %d calls, got %d", tt.expectedCalls, calls)
+			}
+			if used != tt.expectedCalls {
+				t.Errorf("Expected %d attempts used, got %d", tt.expectedCalls, used)
+			}
+			if ok != tt.expectedOK {
+				t.Errorf("Expected ok %v, got %v", tt.expectedOK, ok)
+			}
+		})
+	}
+}
+
+func TestAttemptLimitsAreIndependent(t *testing.T) {
+	tests := []struct {
+		name                   string
+		workflow               config.WorkflowConfiguration
+		maxRetries             int
+		expectedImplementation int
+		expectedRecovery       int
+	}{
+		{"defaults", config.WorkflowConfiguration{}, 3, 1, 3},
+		{"implementation only", config.WorkflowConfiguration{MaxImplementationAttempts: 4}, 3, 4, 3},
+		{"recovery only", config.WorkflowConfiguration{MaxRecoveryAttempts: intPtr(5)}, 3, 1, 5},
+		{"both set", config.WorkflowConfiguration{MaxImplementationAttempts: 2, MaxRecoveryAttempts: intPtr(6)}, 3, 2, 6},
+		{"recovery disabled", config.WorkflowConfiguration{MaxRecoveryAttempts: intPtr(0)}, 3, 1, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := &CCWApp{
+				ccwConfig: &config.CCWConfig{Workflow: tt.workflow},
+				config:    &config.Config{MaxRetries: tt.maxRetries},
+			}
+
+			if got := app.implementationAttempts(); got != tt.expectedImplementation {
+				t.Errorf("Expected %d implementation attempts, got %d", tt.expectedImplementation, got)
+			}
+			if got := app.recoveryAttempts(); got != tt.expectedRecovery {
+				t.Errorf("Expected %d recovery attempts, got %d", tt.expectedRecovery, got)
+			}
+
+			// Exhausting one budget must not consume the other
+			implCalls, recoveryCalls := 0, 0
+			runAttempts(app.implementationAttempts(), func(int) bool { implCalls++; return false })
+			runAttempts(app.recoveryAttempts(), func(int) bool { recoveryCalls++; return false })
+			if implCalls != tt.expectedImplementation || recoveryCalls != tt.expectedRecovery {
+				t.Errorf("Expected %d/%d calls, got %d/%d", tt.expectedImplementation, tt.expectedRecovery, implCalls, recoveryCalls)
+			}
+		})
+	}
+}
+
+func intPtr(n int) *int {
+	return &n
+}
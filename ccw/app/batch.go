@@ -0,0 +1,75 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	"ccw/types"
+)
+
+// IssueFailure records why one issue of a multi-issue run failed
+type IssueFailure struct {
+	Number int
+	Err    error
+}
+
+// BatchError aggregates the failures of a multi-issue run
+type BatchError struct {
+	Total    int            // Number of issues in the batch
+	Failures []IssueFailure // Issues that failed, in processing order
+	Skipped  []int          // Issues never attempted because --fail-fast aborted the batch
+}
+
+// Error summarizes which issues failed and, after an abort, which were skipped
+func (e *BatchError) Error() string {
+	failed := make([]string, len(e.Failures))
+	for i, failure := range e.Failures {
+		failed[i] = fmt.Sprintf("#%d (%v)", failure.Number, failure.Err)
+	}
+
+	msg := fmt.Sprintf("%d of %d issue(s) failed: %s", len(e.Failures), e.Total, strings.Join(failed, ", "))
+	if len(e.Skipped) > 0 {
+		skipped := make([]string, len(e.Skipped))
+		for i, number := range e.Skipped {
+			skipped[i] = fmt.Sprintf("#%d", number)
+		}
+		msg += fmt.Sprintf("; aborted before %s", strings.Join(skipped, ", "))
+	}
+	return msg
+}
+
+// Unwrap exposes the individual issue errors to errors.Is and errors.As
+func (e *BatchError) Unwrap() []error {
+	errs := make([]error, len(e.Failures))
+	for i, failure := range e.Failures {
+		errs[i] = failure.Err
+	}
+	return errs
+}
+
+// runIssueBatch processes issues in order. With failFast the batch stops at the
+// first failure; otherwise every issue is attempted. Failures are returned as a
+// *BatchError, or nil when every issue succeeded.
+func runIssueBatch(issues []*types.Issue, failFast bool, process func(*types.Issue) error) error {
+	batchErr := &BatchError{Total: len(issues)}
+
+	for i, issue := range issues {
+		err := process(issue)
+		if err == nil {
+			continue
+		}
+
+		batchErr.Failures = append(batchErr.Failures, IssueFailure{Number: issue.Number, Err: err})
+		if failFast {
+			for _, skipped := range issues[i+1:] {
+				batchErr.Skipped = append(batchErr.Skipped, skipped.Number)
+			}
+			break
+		}
+	}
+
+	if len(batchErr.Failures) == 0 {
+		return nil
+	}
+	return batchErr
+}
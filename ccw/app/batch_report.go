@@ -0,0 +1,148 @@
+package app
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"ccw/git"
+	"ccw/lock"
+	"ccw/types"
+)
+
+// End-of-batch report of per-issue outcomes
+
+// Batch issue outcomes
+const (
+	BatchSucceeded = "succeeded"
+	BatchFailed    = "failed"
+	BatchSkipped   = "skipped"
+)
+
+// Reason codes for issues that did not succeed
+const (
+	ReasonFailFast      = "fail_fast"      // Not attempted because --fail-fast aborted the batch
+	ReasonLockHeld      = "lock_held"      // Another CCW process holds the issue lock
+	ReasonWorktreeLimit = "worktree_limit" // git.max_worktrees issue worktrees already exist
+	ReasonLowDiskSpace  = "low_disk_space" // Less than git.min_free_space free at the worktree base
+	ReasonError         = "error"          // The workflow failed
+)
+
+// BatchIssueResult is the outcome of one issue in a batch run
+type BatchIssueResult struct {
+	Number  int    `json:"number"`
+	Title   string `json:"title"`
+	Outcome string `json:"outcome"`
+	Reason  string `json:"reason,omitempty"`
+	Detail  string `json:"detail,omitempty"`
+}
+
+// BatchReport accumulates per-issue outcomes in processing order
+type BatchReport struct {
+	Issues []BatchIssueResult `json:"issues"`
+}
+
+// newBatchReport assembles the report of a runIssueBatch call from its issues
+// and returned error. Issues neither failed nor skipped succeeded.
+func newBatchReport(issues []*types.Issue, err error) *BatchReport {
+	failures := map[int]error{}
+	skipped := map[int]bool{}
+	var batchErr *BatchError
+	if errors.As(err, &batchErr) {
+		for _, failure := range batchErr.Failures {
+			failures[failure.Number] = failure.Err
+		}
+		for _, number := range batchErr.Skipped {
+			skipped[number] = true
+		}
+	}
+
+	report := &BatchReport{Issues: make([]BatchIssueResult, 0, len(issues))}
+	for _, issue := range issues {
+		result := BatchIssueResult{Number: issue.Number, Title: issue.Title, Outcome: BatchSucceeded}
+		if failErr, failed := failures[issue.Number]; failed {
+			result.Outcome, result.Reason = classifyBatchError(failErr)
+			result.Detail = strings.ReplaceAll(failErr.Error(), "\n", " ")
+		} else if skipped[issue.Number] {
+			result.Outcome, result.Reason = BatchSkipped, ReasonFailFast
+			result.Detail = "batch aborted by --fail-fast"
+		}
+		report.Issues = append(report.Issues, result)
+	}
+	return report
+}
+
+// classifyBatchError maps an issue's workflow error to an outcome and reason
+// code. Errors raised before any work started count as skips.
+func classifyBatchError(err error) (outcome, reason string) {
+	var lockedErr *lock.LockedError
+	var limitErr *git.WorktreeLimitError
+	var diskErr *git.LowDiskSpaceError
+	switch {
+	case errors.As(err, &lockedErr):
+		return BatchSkipped, ReasonLockHeld
+	case errors.As(err, &limitErr):
+		return BatchSkipped, ReasonWorktreeLimit
+	case errors.As(err, &diskErr):
+		return BatchSkipped, ReasonLowDiskSpace
+	default:
+		return BatchFailed, ReasonError
+	}
+}
+
+// Count returns how many issues had outcome
+func (r *BatchReport) Count(outcome string) int {
+	count := 0
+	for _, result := range r.Issues {
+		if result.Outcome == outcome {
+			count++
+		}
+	}
+	return count
+}
+
+// FormatTable renders the issues that did not succeed as a text table with a
+// totals line, or "" when every issue succeeded
+func (r *BatchReport) FormatTable() string {
+	if r.Count(BatchSucceeded) == len(r.Issues) {
+		return ""
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Batch report: %d succeeded, %d failed, %d skipped\n",
+		r.Count(BatchSucceeded), r.Count(BatchFailed), r.Count(BatchSkipped))
+	fmt.Fprintf(&b, "%-7s %-9s %-15s %s\n", "ISSUE", "OUTCOME", "REASON", "DETAIL")
+	for _, result := range r.Issues {
+		if result.Outcome == BatchSucceeded {
+			continue
+		}
+		detail := result.Detail
+		if len(detail) > 80 {
+			detail = detail[:77] + "..."
+		}
+		fmt.Fprintf(&b, "%-7s %-9s %-15s %s\n", fmt.Sprintf("#%d", result.Number), result.Outcome, result.Reason, detail)
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
+
+// JSON renders the full report as a single JSON object
+func (r *BatchReport) JSON() string {
+	data, _ := json.Marshal(r)
+	return string(data)
+}
+
+// printBatchReport prints the report at the end of a batch: as JSON for
+// --quiet runs with JSON logs, otherwise as a table unless --quiet
+func (app *CCWApp) printBatchReport(report *BatchReport) {
+	if app.ui.Quiet() {
+		if getEnvWithDefault("CCW_LOG_JSON", "false") == "true" {
+			fmt.Println(report.JSON())
+		}
+		return
+	}
+	if table := report.FormatTable(); table != "" {
+		fmt.Println()
+		fmt.Println(table)
+	}
+}
@@ -0,0 +1,125 @@
+package app
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"ccw/git"
+	"ccw/lock"
+	"ccw/types"
+)
+
+func TestNewBatchReport(t *testing.T) {
+	issues := []*types.Issue{
+		{Number: 1, Title: "Done"},
+		{Number: 2, Title: "Locked"},
+		{Number: 3, Title: "Too many worktrees"},
+		{Number: 4, Title: "Broken"},
+		{Number: 5, Title: "Never reached"},
+	}
+	batchErr := &BatchError{
+		Total: len(issues),
+		Failures: []IssueFailure{
+			{Number: 2, Err: fmt.Errorf("%w; use --wait-lock to wait for it to finish", &lock.LockedError{Holder: lock.LockInfo{IssueNumber: 2}})},
+			{Number: 3, Err: &git.WorktreeLimitError{Limit: 2, Count: 2}},
+			{Number: 4, Err: errors.New("validation failed:\nlint errors")},
+		},
+		Skipped: []int{5},
+	}
+
+	report := newBatchReport(issues, batchErr)
+
+	type outcome struct {
+		Number  int
+		Outcome string
+		Reason  string
+	}
+	var got []outcome
+	for _, result := range report.Issues {
+		got = append(got, outcome{result.Number, result.Outcome, result.Reason})
+	}
+	expected := []outcome{
+		{1, BatchSucceeded, ""},
+		{2, BatchSkipped, ReasonLockHeld},
+		{3, BatchSkipped, ReasonWorktreeLimit},
+		{4, BatchFailed, ReasonError},
+		{5, BatchSkipped, ReasonFailFast},
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, got)
+	}
+
+	if detail := report.Issues[3].Detail; detail != "validation failed: lint errors" {
+		t.Errorf("Expected the error on one line, got '%s'", detail)
+	}
+	if report.Count(BatchSucceeded) != 1 || report.Count(BatchFailed) != 1 || report.Count(BatchSkipped) != 3 {
+		t.Errorf("Unexpected counts: %d succeeded, %d failed, %d skipped",
+			report.Count(BatchSucceeded), report.Count(BatchFailed), report.Count(BatchSkipped))
+	}
+}
+
+func TestNewBatchReportAllSucceeded(t *testing.T) {
+	report := newBatchReport([]*types.Issue{{Number: 1}, {Number: 2}}, nil)
+
+	if report.Count(BatchSucceeded) != 2 {
+		t.Errorf("Expected both issues to succeed, got %+v", report.Issues)
+	}
+	if table := report.FormatTable(); table != "" {
+		t.Errorf("Expected no table when every issue succeeded, got:\n%s", table)
+	}
+}
+
+func TestBatchReportFormatTable(t *testing.T) {
+	report := &BatchReport{Issues: []BatchIssueResult{
+		{Number: 1, Outcome: BatchSucceeded},
+		{Number: 2, Outcome: BatchSkipped, Reason: ReasonLockHeld, Detail: "issue #2 is locked"},
+		{Number: 3, Outcome: BatchFailed, Reason: ReasonError, Detail: strings.Repeat("x", 100)},
+	}}
+
+	table := report.FormatTable()
+	lines := strings.Split(table, "\n")
+	if len(lines) != 4 {
+		t.Fatalf("Expected totals, header and two rows, got:\n%s", table)
+	}
+	if lines[0] != "Batch report: 1 succeeded, 1 failed, 1 skipped" {
+		t.Errorf("Unexpected totals line '%s'", lines[0])
+	}
+	if !strings.HasPrefix(lines[2], "#2") || !strings.Contains(lines[2], ReasonLockHeld) {
+		t.Errorf("Expected the skipped issue row, got '%s'", lines[2])
+	}
+	if !strings.HasSuffix(lines[3], "...") {
+		t.Errorf("Expected the long detail to be truncated, got '%s'", lines[3])
+	}
+	if strings.Contains(table, "#1 ") {
+		t.Errorf("Expected succeeded issues to be left out, got:\n%s", table)
+	}
+}
+
+func TestBatchReportJSON(t *testing.T) {
+	report := &BatchReport{Issues: []BatchIssueResult{
+		{Number: 1, Title: "Done", Outcome: BatchSucceeded},
+		{Number: 2, Title: "Locked", Outcome: BatchSkipped, Reason: ReasonLockHeld},
+	}}
+
+	var decoded BatchReport
+	if err := json.Unmarshal([]byte(report.JSON()), &decoded); err != nil {
+		t.Fatalf("Expected valid JSON, got %v", err)
+	}
+	if !reflect.DeepEqual(&decoded, report) {
+		t.Errorf("Expected %+v, got %+v", report, decoded)
+	}
+	if strings.Contains(report.JSON(), `"reason":""`) {
+		t.Errorf("Expected empty reasons to be omitted, got %s", report.JSON())
+	}
+}
+
+func TestClassifyBatchErrorLowDiskSpace(t *testing.T) {
+	err := fmt.Errorf("setup: %w", &git.LowDiskSpaceError{Free: 1, Required: 2})
+	if outcome, reason := classifyBatchError(err); outcome != BatchSkipped || reason != ReasonLowDiskSpace {
+		t.Errorf("Expected %s/%s, got %s/%s", BatchSkipped, ReasonLowDiskSpace, outcome, reason)
+	}
+}
@@ -0,0 +1,94 @@
+package app
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"ccw/types"
+)
+
+func TestRunIssueBatch(t *testing.T) {
+	errValidation := errors.New("validation failed")
+	issues := []*types.Issue{{Number: 1}, {Number: 2}, {Number: 3}}
+
+	tests := []struct {
+		name          string
+		failFast      bool
+		wantProcessed []int
+		wantSkipped   []int
+		wantMessage   string
+	}{
+		{
+			name:          "keep going processes every issue",
+			failFast:      false,
+			wantProcessed: []int{1, 2, 3},
+			wantMessage:   "1 of 3 issue(s) failed: #2 (validation failed)",
+		},
+		{
+			name:          "fail fast stops at first failure",
+			failFast:      true,
+			wantProcessed: []int{1, 2},
+			wantSkipped:   []int{3},
+			wantMessage:   "1 of 3 issue(s) failed: #2 (validation failed); aborted before #3",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var processed []int
+			err := runIssueBatch(issues, tt.failFast, func(issue *types.Issue) error {
+				processed = append(processed, issue.Number)
+				if issue.Number == 2 {
+					return errValidation
+				}
+				return nil
+			})
+
+			if !reflect.DeepEqual(processed, tt.wantProcessed) {
+				t.Errorf("Expected processed %v, got %v", tt.wantProcessed, processed)
+			}
+
+			var batchErr *BatchError
+			if !errors.As(err, &batchErr) {
+				t.Fatalf("Expected *BatchError, got %v", err)
+			}
+			if len(batchErr.Failures) != 1 || batchErr.Failures[0].Number != 2 {
+				t.Errorf("Expected issue #2 to be the only failure, got %+v", batchErr.Failures)
+			}
+			if !reflect.DeepEqual(batchErr.Skipped, tt.wantSkipped) {
+				t.Errorf("Expected skipped %v, got %v", tt.wantSkipped, batchErr.Skipped)
+			}
+			if !errors.Is(err, errValidation) {
+				t.Error("Expected batch error to wrap the issue error")
+			}
+			if err.Error() != tt.wantMessage {
+				t.Errorf("Expected '%s', got '%s'", tt.wantMessage, err.Error())
+			}
+		})
+	}
+}
+
+func TestRunIssueBatchAllSucceed(t *testing.T) {
+	issues := []*types.Issue{{Number: 1}, {Number: 2}}
+	if err := runIssueBatch(issues, true, func(*types.Issue) error { return nil }); err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+}
+
+func TestBatchErrorListsEveryFailure(t *testing.T) {
+	issues := []*types.Issue{{Number: 4}, {Number: 5}, {Number: 6}}
+	err := runIssueBatch(issues, false, func(issue *types.Issue) error {
+		if issue.Number != 5 {
+			return errors.New("boom")
+		}
+		return nil
+	})
+	if err == nil {
+		t.Fatal("Expected an error")
+	}
+	if !strings.Contains(err.Error(), "2 of 3") || !strings.Contains(err.Error(), "#4") || !strings.Contains(err.Error(), "#6") {
+		t.Errorf("Expected both failed issues in '%s'", err.Error())
+	}
+}
@@ -0,0 +1,50 @@
+package app
+
+import (
+	"strings"
+
+	"ccw/types"
+)
+
+// Choosing conventional branch prefixes from issue labels
+
+// defaultBranchTypeKey is the git.branch_type_map key used when no label matches
+const defaultBranchTypeKey = "default"
+
+// branchTypePrefix returns the branch prefix for an issue with labels: the
+// mapping of the first label found in typeMap (case-insensitively), else the
+// "default" mapping, else "". Prefixes always end in a slash.
+func branchTypePrefix(labels []types.Label, typeMap map[string]string) string {
+	if len(typeMap) == 0 {
+		return ""
+	}
+
+	lookup := make(map[string]string, len(typeMap))
+	for label, prefix := range typeMap {
+		lookup[strings.ToLower(strings.TrimSpace(label))] = prefix
+	}
+
+	prefix, ok := "", false
+	for _, label := range labels {
+		if prefix, ok = lookup[strings.ToLower(label.Name)]; ok {
+			break
+		}
+	}
+	if !ok {
+		prefix = lookup[defaultBranchTypeKey]
+	}
+
+	prefix = strings.TrimSuffix(strings.TrimSpace(prefix), "/")
+	if prefix == "" {
+		return ""
+	}
+	return prefix + "/"
+}
+
+// issueBranchPrefix returns the git.branch_type_map prefix for issue
+func (app *CCWApp) issueBranchPrefix(issue *types.Issue) string {
+	if app.ccwConfig == nil || issue == nil {
+		return ""
+	}
+	return branchTypePrefix(issue.Labels, app.ccwConfig.Git.BranchTypeMap)
+}
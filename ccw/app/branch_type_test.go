@@ -0,0 +1,46 @@
+package app
+
+import (
+	"testing"
+
+	"ccw/types"
+)
+
+func TestBranchTypePrefix(t *testing.T) {
+	typeMap := map[string]string{
+		"bug":         "fix",
+		"Enhancement": "feat/",
+		"default":     "chore",
+	}
+	labels := func(names ...string) []types.Label {
+		var result []types.Label
+		for _, name := range names {
+			result = append(result, types.Label{Name: name})
+		}
+		return result
+	}
+
+	tests := []struct {
+		name     string
+		labels   []types.Label
+		typeMap  map[string]string
+		expected string
+	}{
+		{"bug label", labels("bug"), typeMap, "fix/"},
+		{"case-insensitive label", labels("enhancement"), typeMap, "feat/"},
+		{"first mapped label wins", labels("ui", "enhancement", "bug"), typeMap, "feat/"},
+		{"default fallback", labels("question"), typeMap, "chore/"},
+		{"no labels uses default", nil, typeMap, "chore/"},
+		{"no default", labels("question"), map[string]string{"bug": "fix"}, ""},
+		{"empty map", labels("bug"), nil, ""},
+		{"empty mapping means no prefix", labels("docs"), map[string]string{"docs": "", "default": "chore"}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if prefix := branchTypePrefix(tt.labels, tt.typeMap); prefix != tt.expected {
+				t.Errorf("Expected '%s', got '%s'", tt.expected, prefix)
+			}
+		})
+	}
+}
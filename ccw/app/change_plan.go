@@ -0,0 +1,85 @@
+package app
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"ccw/claude"
+	"ccw/types"
+)
+
+// changePlanPath is where the change plan for an issue is persisted, outside the worktree
+func changePlanPath(issueNumber int) string {
+	return filepath.Join(".ccw", fmt.Sprintf("change-plan-issue-%d.json", issueNumber))
+}
+
+// captureChangePlan asks Claude for its intended file changes before implementation
+// when workflow.change_plan is enabled. Failures only disable reconciliation.
+func (app *CCWApp) captureChangePlan(claudeCtx *types.ClaudeContext) *claude.ChangePlan {
+	if app.ccwConfig == nil || !app.ccwConfig.Workflow.ChangePlan {
+		return nil
+	}
+
+	app.ui.Info("Requesting change plan...")
+	plan, err := app.claudeIntegration.GenerateChangePlan(claudeCtx)
+	if err != nil {
+		app.logger.Warn("workflow", "Failed to generate change plan", map[string]interface{}{
+			"error": err.Error(),
+		})
+		app.ui.Warning(fmt.Sprintf("Could not capture change plan: %v", err))
+		return nil
+	}
+
+	path := changePlanPath(claudeCtx.IssueData.Number)
+	if err := claude.SaveChangePlan(path, plan); err != nil {
+		app.logger.Warn("workflow", "Failed to save change plan", map[string]interface{}{
+			"path":  path,
+			"error": err.Error(),
+		})
+	} else {
+		app.ui.Info(fmt.Sprintf("Change plan (%d file(s)) saved to %s", len(plan.Files), path))
+	}
+	return plan
+}
+
+// reconcileChangePlan compares the plan with the files changed in the worktree
+// and flags edits that were not planned
+func (app *CCWApp) reconcileChangePlan(plan *claude.ChangePlan) {
+	if plan == nil {
+		return
+	}
+
+	baseRef := "HEAD"
+	if app.runSummary != nil && app.runSummary.BaseCommit != "" {
+		baseRef = app.runSummary.BaseCommit
+	}
+	changed, err := app.gitOps.DiffNameOnly(app.worktreeConfig.WorktreePath, baseRef)
+	if err != nil {
+		app.logger.Warn("workflow", "Failed to list changed files for change plan", map[string]interface{}{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	result := claude.ReconcileChangePlan(plan, changed)
+	app.logger.Info("workflow", "Change plan reconciled", map[string]interface{}{
+		"planned":   result.Planned,
+		"unplanned": result.Unplanned,
+		"untouched": result.Untouched,
+	})
+	if app.runSummary != nil {
+		app.runSummary.UnplannedFiles = result.Unplanned
+	}
+
+	if !result.HasDiscrepancies() {
+		app.ui.Success("All changed files were in the change plan")
+		return
+	}
+	if len(result.Unplanned) > 0 {
+		app.ui.Warning(fmt.Sprintf("Changed files not in the change plan: %s", strings.Join(result.Unplanned, ", ")))
+	}
+	if len(result.Untouched) > 0 {
+		app.ui.Info(fmt.Sprintf("Planned files left unchanged: %s", strings.Join(result.Untouched, ", ")))
+	}
+}
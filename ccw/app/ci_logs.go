@@ -0,0 +1,66 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+
+	"ccw/consoleui"
+	"ccw/pr"
+	"ccw/types"
+)
+
+// failureLogLines returns the configured ci.failure_log_lines; 0 means logs are not fetched
+func (app *CCWApp) failureLogLines() int {
+	if app.ccwConfig == nil {
+		return pr.DefaultLogExcerptLines
+	}
+	return app.ccwConfig.CI.FailureLogLines
+}
+
+// reportCIFailureLogs prints an excerpt of each failed check's log, so the
+// failure can be read without opening the Actions page, and posts them to the
+// PR when ci.comment_failure_logs is set
+func (app *CCWApp) reportCIFailureLogs(prURL string, status *types.CIStatus) {
+	lines := app.failureLogLines()
+	if lines <= 0 || status == nil {
+		return
+	}
+
+	logIcon := consoleui.Char("📜", "[LOGS]")
+	var excerpts []*pr.LogExcerpt
+	for _, check := range status.Checks {
+		if check.Conclusion != "failure" && check.Conclusion != "error" {
+			continue
+		}
+
+		excerpt, err := app.prManager.GetCheckLogs(check, lines)
+		if err != nil {
+			if !errors.Is(err, pr.ErrCheckLogsUnavailable) {
+				app.ui.Warning(fmt.Sprintf("Could not fetch logs for %s: %v", check.Name, err))
+			}
+			app.logger.Warn("ci_monitoring", "Failed check logs unavailable", map[string]interface{}{
+				"check": check.Name,
+				"error": err.Error(),
+			})
+			continue
+		}
+		if len(excerpt.Lines) == 0 {
+			continue
+		}
+
+		app.ui.Info(fmt.Sprintf("%s Log excerpt for %s (%d error line(s) in %d lines):", logIcon, check.Name, excerpt.ErrorLines, excerpt.TotalLines))
+		app.ui.Info(excerpt.String())
+		excerpts = append(excerpts, excerpt)
+	}
+
+	if len(excerpts) == 0 || app.ccwConfig == nil || !app.ccwConfig.CI.CommentFailureLogs {
+		return
+	}
+	if err := app.prManager.PostComment(prURL, pr.FormatCheckLogsComment(excerpts)); err != nil {
+		app.logger.Warn("ci_monitoring", "Failed to post CI failure logs", map[string]interface{}{
+			"pr_url": prURL,
+			"error":  err.Error(),
+		})
+		app.ui.Warning(fmt.Sprintf("Could not post CI failure logs: %v", err))
+	}
+}
@@ -0,0 +1,87 @@
+package app
+
+import (
+	"fmt"
+
+	"ccw/consoleui"
+	"ccw/pr"
+	"ccw/types"
+)
+
+// retriggerCommitMessage is the message of empty commits pushed to re-run CI
+const retriggerCommitMessage = "chore: re-trigger CI"
+
+// retriggerStrategy returns the configured ci.retrigger_strategy, or none
+func (app *CCWApp) retriggerStrategy() string {
+	if app.ccwConfig == nil || app.ccwConfig.CI.RetriggerStrategy == "" {
+		return pr.RetriggerNone
+	}
+	return app.ccwConfig.CI.RetriggerStrategy
+}
+
+// selectRetrigger decides how failed CI is re-triggered and why. rerun needs
+// failed Actions runs, since checks from other CI systems cannot be re-run
+// through gh; teams using those pick empty-commit instead.
+func selectRetrigger(strategy string, runs []pr.WorkflowRun, alreadyRetriggered bool) (string, string) {
+	switch {
+	case strategy == pr.RetriggerNone:
+		return pr.RetriggerNone, "CI not re-triggered: ci.retrigger_strategy is none"
+	case alreadyRetriggered:
+		return pr.RetriggerNone, "CI not re-triggered: it was already re-triggered once in this run"
+	case strategy == pr.RetriggerRerun && len(runs) == 0:
+		return pr.RetriggerNone, "CI not re-triggered: no failed check is a GitHub Actions run that gh run rerun can restart"
+	case strategy == pr.RetriggerRerun:
+		return pr.RetriggerRerun, fmt.Sprintf("CI re-triggered with gh run rerun for %d failed run(s) because ci.retrigger_strategy is rerun", len(runs))
+	case strategy == pr.RetriggerEmptyCommit:
+		return pr.RetriggerEmptyCommit, "CI re-triggered with an empty commit because ci.retrigger_strategy is empty-commit"
+	}
+	return pr.RetriggerNone, fmt.Sprintf("CI not re-triggered: unknown ci.retrigger_strategy %q", strategy)
+}
+
+// retriggerFailedCI re-runs failed CI once per run using the configured
+// strategy, for flaky checks. It returns true when CI was re-triggered and
+// should be watched again.
+func (app *CCWApp) retriggerFailedCI(status *types.CIStatus) bool {
+	runs := pr.FailedWorkflowRuns(status)
+	strategy, reason := selectRetrigger(app.retriggerStrategy(), runs, app.ciRetriggered)
+	app.explain(reason)
+	if strategy == pr.RetriggerNone {
+		return false
+	}
+	app.ciRetriggered = true
+
+	retryIcon := consoleui.Char("🔁", "[RETRY]")
+	switch strategy {
+	case pr.RetriggerRerun:
+		rerun := 0
+		for _, run := range runs {
+			if err := app.prManager.RerunFailedJobs(run); err != nil {
+				app.ui.Warning(fmt.Sprintf("Failed to re-run workflow run %s: %v", run.ID, err))
+				continue
+			}
+			rerun++
+		}
+		if rerun == 0 {
+			return false
+		}
+		app.ui.Info(fmt.Sprintf("%s Re-running failed jobs of %d workflow run(s)", retryIcon, rerun))
+
+	case pr.RetriggerEmptyCommit:
+		worktreePath, branchName := app.worktreeConfig.WorktreePath, app.worktreeConfig.BranchName
+		if err := app.gitOps.CommitEmpty(worktreePath, withGeneratedByTrailer(retriggerCommitMessage, Version)); err != nil {
+			app.ui.Warning(fmt.Sprintf("Failed to re-trigger CI: %v", err))
+			return false
+		}
+		if err := app.gitOps.PushBranch(worktreePath, branchName); err != nil {
+			app.ui.Warning(fmt.Sprintf("Failed to push empty commit to re-trigger CI: %v", err))
+			return false
+		}
+		app.ui.Info(fmt.Sprintf("%s Pushed an empty commit to %s to re-trigger CI", retryIcon, branchName))
+	}
+
+	app.logger.Info("ci_monitoring", "Re-triggered failed CI", map[string]interface{}{
+		"strategy": strategy,
+		"runs":     len(runs),
+	})
+	return true
+}
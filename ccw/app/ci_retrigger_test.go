@@ -0,0 +1,53 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	"ccw/config"
+	"ccw/pr"
+)
+
+func TestSelectRetrigger(t *testing.T) {
+	runs := []pr.WorkflowRun{{Repository: "o/r", ID: "101"}}
+
+	tests := []struct {
+		name               string
+		strategy           string
+		runs               []pr.WorkflowRun
+		alreadyRetriggered bool
+		expected           string
+		reason             string
+	}{
+		{"none", pr.RetriggerNone, runs, false, pr.RetriggerNone, "is none"},
+		{"rerun with actions runs", pr.RetriggerRerun, runs, false, pr.RetriggerRerun, "1 failed run(s)"},
+		{"rerun without actions runs", pr.RetriggerRerun, nil, false, pr.RetriggerNone, "no failed check is a GitHub Actions run"},
+		{"empty commit", pr.RetriggerEmptyCommit, nil, false, pr.RetriggerEmptyCommit, "empty commit"},
+		{"only once", pr.RetriggerEmptyCommit, runs, true, pr.RetriggerNone, "already re-triggered"},
+		{"unknown", "sometimes", runs, false, pr.RetriggerNone, "unknown"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			strategy, reason := selectRetrigger(tt.strategy, tt.runs, tt.alreadyRetriggered)
+			if strategy != tt.expected {
+				t.Errorf("Expected '%s', got '%s'", tt.expected, strategy)
+			}
+			if !strings.Contains(reason, tt.reason) {
+				t.Errorf("Expected reason containing '%s', got '%s'", tt.reason, reason)
+			}
+		})
+	}
+}
+
+func TestRetriggerStrategyDefault(t *testing.T) {
+	app := &CCWApp{}
+	if strategy := app.retriggerStrategy(); strategy != pr.RetriggerNone {
+		t.Errorf("Expected '%s', got '%s'", pr.RetriggerNone, strategy)
+	}
+
+	app.ccwConfig = &config.CCWConfig{CI: config.CIConfiguration{RetriggerStrategy: pr.RetriggerRerun}}
+	if strategy := app.retriggerStrategy(); strategy != pr.RetriggerRerun {
+		t.Errorf("Expected '%s', got '%s'", pr.RetriggerRerun, strategy)
+	}
+}
@@ -0,0 +1,59 @@
+package app
+
+import (
+	"context"
+	"time"
+
+	"ccw/types"
+)
+
+// Bounded CI monitoring
+
+// defaultMaxMonitorDuration caps CI monitoring when ci.max_monitor_duration is unset
+const defaultMaxMonitorDuration = 30 * time.Minute
+
+// ciWatchSource starts watching the checks of a PR, like PRManager.WatchPRChecksWithGoroutine.
+// The source must close Updates once ctx is done or Cancel is signalled.
+type ciWatchSource func(ctx context.Context, prURL string) *types.CIWatchChannel
+
+// maxMonitorDuration returns the configured ci.max_monitor_duration
+func (app *CCWApp) maxMonitorDuration() time.Duration {
+	if app.ccwConfig != nil {
+		if duration, err := time.ParseDuration(app.ccwConfig.CI.MaxMonitorDuration); err == nil && duration > 0 {
+			return duration
+		}
+	}
+	return defaultMaxMonitorDuration
+}
+
+// watchCI watches the checks of prURL for at most maxDuration, passing each
+// update to onUpdate. It returns the completion result, or timedOut when
+// monitoring gave up first. Either way it returns only after every update has
+// been handled, so no update goroutine outlives the call.
+func watchCI(watch ciWatchSource, prURL string, maxDuration time.Duration, onUpdate func(types.CIWatchUpdate)) (result types.CIWatchResult, timedOut bool) {
+	ctx, cancel := context.WithTimeout(context.Background(), maxDuration)
+	defer cancel()
+
+	watchChannel := watch(ctx, prURL)
+
+	updatesDone := make(chan struct{})
+	go func() {
+		defer close(updatesDone)
+		for update := range watchChannel.Updates {
+			onUpdate(update)
+		}
+	}()
+
+	select {
+	case result = <-watchChannel.Completion:
+	case <-ctx.Done():
+		timedOut = true
+		select {
+		case watchChannel.Cancel <- struct{}{}:
+		default:
+		}
+	}
+
+	<-updatesDone
+	return result, timedOut
+}
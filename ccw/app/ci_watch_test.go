@@ -0,0 +1,124 @@
+package app
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"ccw/config"
+	"ccw/types"
+)
+
+// fakeWatch emits updates until ctx is done or a cancel arrives, then closes
+// its channels like PRManager.WatchPRChecksWithGoroutine. stopped is closed
+// once its goroutine has exited.
+func fakeWatch(complete *types.CIWatchResult, stopped chan struct{}) ciWatchSource {
+	return func(ctx context.Context, prURL string) *types.CIWatchChannel {
+		updates := make(chan types.CIWatchUpdate, 10)
+		completion := make(chan types.CIWatchResult, 1)
+		cancel := make(chan struct{}, 1)
+
+		go func() {
+			defer close(stopped)
+			defer close(updates)
+			defer close(completion)
+
+			updates <- types.CIWatchUpdate{EventType: "monitoring_started"}
+			if complete != nil {
+				updates <- types.CIWatchUpdate{EventType: "all_complete"}
+				completion <- *complete
+				return
+			}
+
+			ticker := time.NewTicker(5 * time.Millisecond)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-cancel:
+					return
+				case <-ticker.C:
+					updates <- types.CIWatchUpdate{EventType: "status_change"}
+				}
+			}
+		}()
+
+		return &types.CIWatchChannel{Updates: updates, Completion: completion, Cancel: cancel}
+	}
+}
+
+func TestWatchCITimesOut(t *testing.T) {
+	stopped := make(chan struct{})
+	var mu sync.Mutex
+	var handled int
+	returned := false
+
+	start := time.Now()
+	_, timedOut := watchCI(fakeWatch(nil, stopped), "https://github.com/owner/repo/pull/1", 50*time.Millisecond, func(types.CIWatchUpdate) {
+		mu.Lock()
+		defer mu.Unlock()
+		if returned {
+			t.Error("Update handled after watchCI returned")
+		}
+		handled++
+	})
+	mu.Lock()
+	returned = true
+	mu.Unlock()
+
+	if !timedOut {
+		t.Fatal("Expected monitoring to time out")
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Expected to give up after about 50ms, took %v", elapsed)
+	}
+	if handled == 0 {
+		t.Error("Expected updates to be handled before giving up")
+	}
+
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Fatal("Expected the watch goroutine to stop after the timeout")
+	}
+	// Leave time for a leaked handler to fire before the test ends
+	time.Sleep(20 * time.Millisecond)
+}
+
+func TestWatchCICompletes(t *testing.T) {
+	stopped := make(chan struct{})
+	final := &types.CIWatchResult{FinalStatus: &types.CIStatus{Conclusion: "success"}}
+	var events []string
+
+	result, timedOut := watchCI(fakeWatch(final, stopped), "https://github.com/owner/repo/pull/1", time.Minute, func(update types.CIWatchUpdate) {
+		events = append(events, update.EventType)
+	})
+
+	if timedOut {
+		t.Fatal("Expected monitoring to complete")
+	}
+	if result.FinalStatus == nil || result.FinalStatus.Conclusion != "success" {
+		t.Errorf("Expected the final status to be returned, got %+v", result)
+	}
+	// Every update is handled before watchCI returns
+	if len(events) != 2 || events[1] != "all_complete" {
+		t.Errorf("Expected both updates to be handled, got %v", events)
+	}
+	<-stopped
+}
+
+func TestMaxMonitorDuration(t *testing.T) {
+	app := &CCWApp{}
+	if got := app.maxMonitorDuration(); got != defaultMaxMonitorDuration {
+		t.Errorf("Expected default %v without config, got %v", defaultMaxMonitorDuration, got)
+	}
+
+	cfg := config.GetDefaultCCWConfig()
+	cfg.CI.MaxMonitorDuration = "90m"
+	app.ccwConfig = cfg
+	if got := app.maxMonitorDuration(); got != 90*time.Minute {
+		t.Errorf("Expected 90m, got %v", got)
+	}
+}
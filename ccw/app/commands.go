@@ -1,32 +1,31 @@
 package app
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"os"
 	"os/exec"
+	"os/signal"
 	"runtime"
 	"runtime/debug"
 	"strconv"
 	"strings"
+	"syscall"
 	"time"
 
 	"ccw/config"
+	"ccw/consoleui"
 	"ccw/github"
+	"ccw/logging"
 	"ccw/ui"
 )
 
-// getConsoleCharCmd returns console-safe characters based on CI environment
-func getConsoleCharCmd(fancy, simple string) string {
-	if os.Getenv("CCW_CONSOLE_MODE") == "true" ||
-		os.Getenv("CI") == "true" ||
-		os.Getenv("GITHUB_ACTIONS") == "true" ||
-		os.Getenv("GITLAB_CI") == "true" ||
-		os.Getenv("JENKINS_URL") != "" {
-		return simple
-	}
-	return fancy
-}
+// List watch intervals; the minimum keeps refreshes clear of GitHub rate limits
+const (
+	defaultListWatchInterval = 30 * time.Second
+	minListWatchInterval     = 5 * time.Second
+)
 
 // HandleListCommand processes the list command with argument parsing
 func HandleListCommand() {
@@ -65,8 +64,14 @@ func HandleListCommand() {
 	}
 
 	state := "open"      // default state
+	stateSet := false    // --state given explicitly
 	labels := []string{} // default no label filter
+	search := ""         // default no raw search query
 	limit := 20          // default limit
+	failFast := false    // default keep going after a failed issue
+	jsonOutput := false  // default interactive selector
+	var watch time.Duration
+	ranking := ListRanking{}
 
 	// Parse additional arguments
 	for i := startArgIndex; i < len(os.Args); i++ {
@@ -74,6 +79,7 @@ func HandleListCommand() {
 		case "--state":
 			if i+1 < len(os.Args) {
 				state = os.Args[i+1]
+				stateSet = true
 				i++ // skip next argument
 			} else {
 				fmt.Println("Error: --state requires a value")
@@ -92,6 +98,18 @@ func HandleListCommand() {
 				fmt.Println("Error: --labels requires a value")
 				os.Exit(1)
 			}
+		case "--search":
+			if i+1 < len(os.Args) {
+				search = os.Args[i+1]
+				if err := github.ValidateSearchQuery(search); err != nil {
+					fmt.Printf("Error: --search: %v\n", err)
+					os.Exit(1)
+				}
+				i++ // skip next argument
+			} else {
+				fmt.Println("Error: --search requires a query")
+				os.Exit(1)
+			}
 		case "--limit":
 			if i+1 < len(os.Args) {
 				var err error
@@ -109,6 +127,52 @@ func HandleListCommand() {
 				fmt.Println("Error: --limit requires a value")
 				os.Exit(1)
 			}
+		case "--fail-fast":
+			failFast = true
+		case "--keep-going":
+			failFast = false
+		case "--json":
+			jsonOutput = true
+		case "--watch":
+			watch = defaultListWatchInterval
+		case "--watch-interval":
+			if i+1 < len(os.Args) {
+				var err error
+				watch, err = time.ParseDuration(os.Args[i+1])
+				if err != nil || watch < minListWatchInterval {
+					fmt.Printf("Error: --watch-interval requires a duration of at least %s, got: %s\n", minListWatchInterval, os.Args[i+1])
+					os.Exit(1)
+				}
+				i++ // skip next argument
+			} else {
+				fmt.Println("Error: --watch-interval requires a value")
+				os.Exit(1)
+			}
+		case "--sort":
+			if i+1 < len(os.Args) {
+				if os.Args[i+1] != "reactions" {
+					fmt.Printf("Error: invalid sort '%s'. Must be: reactions\n", os.Args[i+1])
+					os.Exit(1)
+				}
+				ranking.SortByReactions = true
+				i++ // skip next argument
+			} else {
+				fmt.Println("Error: --sort requires a value")
+				os.Exit(1)
+			}
+		case "--min-reactions":
+			if i+1 < len(os.Args) {
+				var err error
+				ranking.MinReactions, err = strconv.Atoi(os.Args[i+1])
+				if err != nil || ranking.MinReactions < 0 {
+					fmt.Printf("Error: --min-reactions requires a non-negative number, got: %s\n", os.Args[i+1])
+					os.Exit(1)
+				}
+				i++ // skip next argument
+			} else {
+				fmt.Println("Error: --min-reactions requires a value")
+				os.Exit(1)
+			}
 		default:
 			fmt.Printf("Error: unknown option %s\n", os.Args[i])
 			os.Exit(1)
@@ -120,6 +184,9 @@ func HandleListCommand() {
 		fmt.Printf("Error: invalid state '%s'. Must be: open, closed, or all\n", state)
 		os.Exit(1)
 	}
+	if search != "" {
+		state = searchState(state, stateSet)
+	}
 
 	// Initialize app and execute list workflow
 	app, err := NewCCWApp()
@@ -128,13 +195,84 @@ func HandleListCommand() {
 	}
 	defer app.Cleanup()
 
-	if err := app.ExecuteListWorkflow(repoURL, state, labels, limit); err != nil {
+	if jsonOutput && watch > 0 {
+		fmt.Println("Error: --watch cannot be combined with --json")
+		os.Exit(1)
+	}
+	if jsonOutput {
+		if err := app.ExecuteListJSON(repoURL, state, labels, search, limit, ranking); err != nil {
+			log.Fatalf("List failed: %v", err)
+		}
+		return
+	}
+
+	if err := app.ExecuteListWorkflow(repoURL, state, labels, search, limit, ranking, failFast, watch); err != nil {
 		log.Fatalf("List workflow failed: %v", err)
 	}
 }
 
+// HandleLogsCommand prints the log file of a session, optionally following new output
+func HandleLogsCommand() {
+	sessionID := ""
+	follow := false
+
+	for i := 2; i < len(os.Args); i++ {
+		switch os.Args[i] {
+		case "--session":
+			if i+1 < len(os.Args) {
+				sessionID = os.Args[i+1]
+				i++ // skip next argument
+			} else {
+				fmt.Println("Error: --session requires a value")
+				os.Exit(1)
+			}
+		case "--follow", "-f":
+			follow = true
+		default:
+			fmt.Printf("Error: unknown option %s\n", os.Args[i])
+			printLogsUsage()
+			os.Exit(1)
+		}
+	}
+
+	logFile, err := logging.ResolveSessionLogFile(logging.DefaultLogDir, sessionID)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	if err := logging.PrintSessionLog(logFile, os.Stdout); err != nil {
+		log.Fatalf("Failed to print log file: %v", err)
+	}
+
+	if !follow {
+		return
+	}
+
+	info, err := os.Stat(logFile)
+	if err != nil {
+		log.Fatalf("Failed to stat log file: %v", err)
+	}
+
+	// Follow until interrupted
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if err := logging.FollowFile(ctx, logFile, info.Size(), os.Stdout, 500*time.Millisecond); err != nil {
+		log.Fatalf("Failed to follow log file: %v", err)
+	}
+}
+
 // HandleDoctorCommand performs system diagnostic checks
 func HandleDoctorCommand() {
+	// Check the configured gh executable rather than the one on PATH
+	if ccwConfig, err := config.LoadConfiguration(); err == nil {
+		github.SetGHPath(ccwConfig.GitHub.GHPath)
+		if _, profile, ok := ccwConfig.ActiveProfile(); ok {
+			github.SetHost(profile.Host)
+		}
+	}
+
 	// Check if we should use Bubble Tea UI
 	if shouldUseBubbleTeaForDoctor() {
 		// Use beautiful interactive Bubble Tea UI
@@ -164,7 +302,7 @@ func shouldUseBubbleTeaForDoctor() bool {
 
 // runConsoleDoctorCommand runs the original console-based doctor command
 func runConsoleDoctorCommand() {
-	title := getConsoleCharCmd("🩺 CCW Doctor - System Diagnostic", "CCW Doctor - System Diagnostic")
+	title := consoleui.Char("🩺 CCW Doctor - System Diagnostic", "CCW Doctor - System Diagnostic")
 	fmt.Println(title)
 	fmt.Println("==================================")
 	fmt.Println()
@@ -174,7 +312,7 @@ func runConsoleDoctorCommand() {
 	// Load current configuration to display settings
 	ccwConfig, configErr := config.LoadConfiguration()
 
-	checkIcon := getConsoleCharCmd("✓", "[CHECK]")
+	checkIcon := consoleui.Char("✓", "[CHECK]")
 
 	// Check Go version
 	fmt.Printf("%s Checking Go version... ", checkIcon)
@@ -190,21 +328,21 @@ func runConsoleDoctorCommand() {
 			fmt.Println("available")
 		}
 	} else {
-		errorIcon := getConsoleCharCmd("❌", "[ERROR]")
+		errorIcon := consoleui.Char("❌", "[ERROR]")
 		fmt.Printf("%s NOT FOUND\n", errorIcon)
 		allGood = false
 	}
 
 	// Check GitHub CLI availability
 	fmt.Printf("%s Checking GitHub CLI (gh)... ", checkIcon)
-	if checkCommandAvailable("gh") {
-		if ghVersion := getCommandVersion("gh", "--version"); ghVersion != "" {
+	if checkCommandAvailable(github.GHPath()) {
+		if ghVersion := getCommandVersion(github.GHPath(), "--version"); ghVersion != "" {
 			fmt.Printf("%s\n", strings.Split(ghVersion, "\n")[0])
 		} else {
 			fmt.Println("available")
 		}
 	} else {
-		errorIcon := getConsoleCharCmd("❌", "[ERROR]")
+		errorIcon := consoleui.Char("❌", "[ERROR]")
 		fmt.Printf("%s NOT FOUND\n", errorIcon)
 		allGood = false
 	}
@@ -214,7 +352,7 @@ func runConsoleDoctorCommand() {
 	if checkCommandAvailable("claude") {
 		fmt.Println("available")
 	} else {
-		warningIcon := getConsoleCharCmd("⚠️", "[WARNING]")
+		warningIcon := consoleui.Char("⚠️", "[WARNING]")
 		fmt.Printf("%s NOT FOUND (optional)\n", warningIcon)
 	}
 
@@ -227,10 +365,31 @@ func runConsoleDoctorCommand() {
 			fmt.Println("available")
 		}
 	} else {
-		warningIcon := getConsoleCharCmd("⚠️", "[WARNING]")
+		warningIcon := consoleui.Char("⚠️", "[WARNING]")
 		fmt.Printf("%s NOT FOUND (optional for Swift projects)\n", warningIcon)
 	}
 
+	// Check Docker availability (required for containerized validation)
+	fmt.Printf("%s Checking Docker... ", checkIcon)
+	containerImage := ""
+	if ccwConfig != nil {
+		containerImage = ccwConfig.Validation.ContainerImage
+	}
+	if checkCommandAvailable("docker") {
+		if dockerVersion := getCommandVersion("docker", "--version"); dockerVersion != "" {
+			fmt.Printf("%s\n", dockerVersion)
+		} else {
+			fmt.Println("available")
+		}
+	} else if containerImage != "" {
+		errorIcon := consoleui.Char("❌", "[ERROR]")
+		fmt.Printf("%s NOT FOUND (required for validation.container_image %s)\n", errorIcon, containerImage)
+		allGood = false
+	} else {
+		warningIcon := consoleui.Char("⚠️", "[WARNING]")
+		fmt.Printf("%s NOT FOUND (optional for containerized validation)\n", warningIcon)
+	}
+
 	// Check current directory is a Git repository
 	fmt.Printf("%s Checking Git repository... ", checkIcon)
 	if isGitRepository() {
@@ -240,7 +399,7 @@ func runConsoleDoctorCommand() {
 			fmt.Println("valid (local)")
 		}
 	} else {
-		errorIcon := getConsoleCharCmd("❌", "[ERROR]")
+		errorIcon := consoleui.Char("❌", "[ERROR]")
 		fmt.Printf("%s Current directory is not a Git repository\n", errorIcon)
 		allGood = false
 	}
@@ -254,7 +413,7 @@ func runConsoleDoctorCommand() {
 	}
 
 	if len(envIssues) > 0 {
-		warningIcon := getConsoleCharCmd("⚠️", "[WARNING]")
+		warningIcon := consoleui.Char("⚠️", "[WARNING]")
 		fmt.Printf("%s %s\n", warningIcon, strings.Join(envIssues, ", "))
 	} else {
 		fmt.Println("good")
@@ -267,16 +426,16 @@ func runConsoleDoctorCommand() {
 	} else if _, err := os.Stat("ccw.json"); err == nil {
 		fmt.Println("ccw.json found")
 	} else {
-		warningIcon := getConsoleCharCmd("⚠️", "[WARNING]")
+		warningIcon := consoleui.Char("⚠️", "[WARNING]")
 		fmt.Printf("%s no config file (will use defaults)\n", warningIcon)
 	}
 
 	// UI Configuration Section
 	fmt.Println()
-	uiConfigTitle := getConsoleCharCmd("🎨 UI Configuration:", "UI Configuration:")
+	uiConfigTitle := consoleui.Char("🎨 UI Configuration:", "UI Configuration:")
 	fmt.Println(uiConfigTitle)
 	if configErr != nil {
-		warningIcon := getConsoleCharCmd("⚠️", "[WARNING]")
+		warningIcon := consoleui.Char("⚠️", "[WARNING]")
 		fmt.Printf("   %s Could not load configuration, showing detected values\n", warningIcon)
 	}
 
@@ -372,7 +531,7 @@ func runConsoleDoctorCommand() {
 
 	// System information
 	fmt.Println()
-	systemInfoTitle := getConsoleCharCmd("📊 System Information:", "System Information:")
+	systemInfoTitle := consoleui.Char("📊 System Information:", "System Information:")
 	fmt.Println(systemInfoTitle)
 	fmt.Printf("   OS: %s %s\n", runtime.GOOS, runtime.GOARCH)
 	fmt.Printf("   CPUs: %d\n", runtime.NumCPU())
@@ -384,7 +543,7 @@ func runConsoleDoctorCommand() {
 	// Configuration summary
 	if ccwConfig != nil {
 		fmt.Println()
-		configTitle := getConsoleCharCmd("⚙️ Current Configuration:", "Current Configuration:")
+		configTitle := consoleui.Char("⚙️ Current Configuration:", "Current Configuration:")
 		fmt.Println(configTitle)
 		fmt.Printf("   Debug Mode: %v\n", ccwConfig.DebugMode)
 		fmt.Printf("   Worktree Base: %s\n", ccwConfig.WorktreeBase)
@@ -409,17 +568,17 @@ func runConsoleDoctorCommand() {
 	// Summary
 	fmt.Println()
 	if allGood {
-		successIcon := getConsoleCharCmd("🎉", "[SUCCESS]")
+		successIcon := consoleui.Char("🎉", "[SUCCESS]")
 		fmt.Printf("%s All critical dependencies are available!\n", successIcon)
 		fmt.Println("   CCW should work correctly in this environment.")
 	} else {
-		errorIcon := getConsoleCharCmd("❌", "[ERROR]")
+		errorIcon := consoleui.Char("❌", "[ERROR]")
 		fmt.Printf("%s Some critical dependencies are missing.\n", errorIcon)
 		fmt.Println("   Please install missing tools before using CCW.")
 	}
 
 	fmt.Println()
-	tipsIcon := getConsoleCharCmd("💡", "[TIPS]")
+	tipsIcon := consoleui.Char("💡", "[TIPS]")
 	fmt.Printf("%s Tips:\n", tipsIcon)
 	fmt.Println("   - Install GitHub CLI: brew install gh")
 	fmt.Println("   - Install Claude Code: https://claude.ai/code")
@@ -548,19 +707,69 @@ func PrintUsage() {
 	fmt.Printf(`CCW - Claude Code Worktree Automation Tool
 
 Usage: 
-  ccw <github-issue-url>                  Process a specific GitHub issue
+  ccw <github-issue-url> [options]        Process a specific GitHub issue
   ccw list [repo-url] [options]           List and select issues interactively
   ccw doctor                              Run system diagnostic checks
+  ccw logs [--session ID] [--follow]      Show a session log file (default: latest)
+  ccw version                             Print the ccw version
+  ccw reauth                              Check and re-authenticate gh and Claude Code
+  ccw ship [--title TITLE]                Validate, commit, push and open a PR for the current branch
+  ccw profiles list                       List GitHub account profiles from ccw.yaml
+  ccw open <issue|url|path>               Launch Claude Code interactively in an existing worktree
+  ccw diff <issue|url|path> [--stat]      Print a worktree's changes against its base branch
+  ccw prune-branches [--merged] [--older-than AGE]
+                                          Delete merged or stale CCW branches from the push remote
+  ccw replay <crash-report>               Re-run a crashed run's issue with its options and trace logging
 
 Arguments:
   github-issue-url    GitHub issue URL (e.g., https://github.com/owner/repo/issues/123)
   repo-url           Repository URL (e.g., https://github.com/owner/repo or owner/repo)
                      If not provided, uses current repository's GitHub remote
 
+Global Options:
+  --profile NAME     Use the GitHub account profile NAME from ccw.yaml
+  --no-emoji         Use plain text markers instead of emoji (also CCW_NO_EMOJI=true)
+
+Issue Options:
+  --wait-lock        Wait for another CCW run on the same issue instead of exiting
+  --summary-out PATH Write the post-run summary.md report to PATH
+                     (default: .ccw/summary.md in the worktree, or .ccw/ once cleaned up)
+  --implementation-summary-out PATH
+                     Also write Claude's implementation summary to PATH
+  --pr-template      Fill the repository's PULL_REQUEST_TEMPLATE.md as the PR body
+  --reviewers LIST   Request PR reviews from comma-separated users or org/team slugs
+  --assignees LIST   Assign the PR to comma-separated users (added to github.auto_assign)
+  --allow-protected  Commit changes to commit.protected_paths with a warning
+  --explain          Print why validation, CI, comment and done decisions were made
+  --in-place         Work in the current checkout instead of creating a worktree
+  --allow-dirty      Let --in-place start with uncommitted changes in the tree
+  --quiet            Print only errors and a one-line result (for cron jobs)
+  --no-commit        Stop after validation and print a suggested commit message;
+                     nothing is committed, pushed or opened as a PR
+  --interactive-review
+                     Choose which PR comments Claude addresses and add guidance to each
+  --context-file PATH
+                     Add a reference file (spec, design doc) to Claude's context; repeatable
+  --since-commit REF Lint only files changed since REF (overrides validation.since)
+  --trace-file[=PATH]
+                     Trace function calls to PATH (default: .ccw/trace-<session>.log)
+                     instead of the main log
+  --progress-fd N    Write newline-delimited JSON progress events (step, status,
+                     timestamp) to inherited file descriptor N (3 or higher)
+
 List Command Options:
   --state            Issue state: open, closed, all (default: open)
   --labels           Comma-separated list of labels to filter by
+  --search QUERY     Raw GitHub search query, e.g. "is:open label:bug sort:updated-desc"
+                     (searches all states unless --state is given)
   --limit            Maximum number of issues to fetch (default: 20)
+  --fail-fast        Stop the batch at the first failed issue
+  --keep-going       Process every selected issue even if some fail (default)
+  --json             Print the matching issues as JSON and exit (no selector)
+  --sort reactions   Order issues by 👍 reactions, most first
+  --min-reactions N  Only show issues with at least N 👍 reactions
+  --watch            Keep the selector open and re-fetch issues every %s
+  --watch-interval D Re-fetch interval for --watch, e.g. 2m (min %s; implies --watch)
 
 Examples:
   ccw https://github.com/owner/repo/issues/123
@@ -569,6 +778,10 @@ Examples:
   ccw list --state open --limit 10                  # Use current repository with options
   ccw list https://github.com/owner/repo --state open --limit 10
   ccw list owner/repo --labels bug,enhancement --state all
+  ccw list --labels bug --json | jq '.[].number'
+  ccw list --search "is:open label:bug sort:updated-desc"
+  ccw list --sort reactions --min-reactions 3      # Most requested issues first
+  ccw list --labels bug --watch-interval 2m        # Keep watching for new bugs
 
 General Options:
   -h, --help         Show this help message
@@ -593,7 +806,14 @@ Features:
 - Package-based architecture for maintainability
 
 For configuration help: ccw --init-config
-`)
+`, defaultListWatchInterval, minListWatchInterval)
+}
+
+// printLogsUsage displays usage for the logs command
+func printLogsUsage() {
+	fmt.Println("Usage: ccw logs [--session ID] [--follow]")
+	fmt.Println("  --session     Session ID to show (default: most recent session)")
+	fmt.Println("  --follow, -f  Keep printing new log lines until interrupted")
 }
 
 // printListUsage displays usage for the list command
@@ -603,7 +823,15 @@ func printListUsage() {
 	fmt.Println("                If not provided, uses current repository's GitHub remote")
 	fmt.Println("  --state       Issue state: open, closed, all (default: open)")
 	fmt.Println("  --labels      Comma-separated list of labels to filter by")
+	fmt.Println("  --search      Raw GitHub search query, e.g. \"is:open label:bug\" (all states unless --state is given)")
 	fmt.Println("  --limit       Maximum number of issues to fetch (default: 20)")
+	fmt.Println("  --fail-fast   Stop the batch at the first failed issue")
+	fmt.Println("  --keep-going  Process every selected issue even if some fail (default)")
+	fmt.Println("  --json        Print the matching issues as JSON instead of the selector")
+	fmt.Println("  --sort        Issue order: reactions (most 👍 first; default: GitHub's order)")
+	fmt.Println("  --min-reactions  Only show issues with at least this many 👍 reactions")
+	fmt.Printf("  --watch       Keep the selector open and re-fetch issues every %s\n", defaultListWatchInterval)
+	fmt.Printf("  --watch-interval  Re-fetch interval for --watch, e.g. 2m (min %s; implies --watch)\n", minListWatchInterval)
 }
 
 // saveCrashReport saves detailed crash information
@@ -632,6 +860,19 @@ func (app *CCWApp) saveCrashReport(panicValue interface{}, stackTrace, issueURL
 
 	// Log crash report using logger
 	if app.logger != nil {
-		app.logger.Error("crash_report", fmt.Sprintf("Application crash: %v", panicValue), crashReport)
+		app.logger.Error(crashReportComponent, fmt.Sprintf("Application crash: %v", panicValue), crashReport)
+	}
+
+	// Save it on its own for ccw replay
+	path := crashReportPath(logging.DefaultLogDir, app.sessionID)
+	if err := writeCrashReport(path, crashReport); err != nil {
+		if app.logger != nil {
+			app.logger.Warn(crashReportComponent, "Failed to save crash report", map[string]interface{}{
+				"path":  path,
+				"error": err.Error(),
+			})
+		}
+		return
 	}
+	app.ui.Error(fmt.Sprintf("Crash report saved to %s; reproduce with: ccw replay %s", path, path))
 }
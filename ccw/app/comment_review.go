@@ -0,0 +1,35 @@
+package app
+
+import (
+	"fmt"
+
+	"ccw/types"
+	"ccw/ui"
+)
+
+// commentReviewer runs the interactive comment review; replaced in tests
+var commentReviewer = ui.RunCommentReviewUI
+
+// reviewCommentsInteractively lets the user choose, with --interactive-review,
+// which actionable comments Claude addresses and with what guidance. It
+// returns the analysis to address, or nil when nothing should be addressed.
+func (app *CCWApp) reviewCommentsInteractively(analysis *types.PRCommentAnalysis) *types.PRCommentAnalysis {
+	if app.options == nil || !app.options.InteractiveReview {
+		return analysis
+	}
+
+	reviewed, err := commentReviewer(analysis)
+	if err != nil {
+		app.ui.Warning(fmt.Sprintf("Interactive comment review failed: %v", err))
+		app.explain("feedback loop stopped: the interactive comment review did not complete")
+		return nil
+	}
+	if len(reviewed.ActionableComments) == 0 {
+		app.explain("feedback loop stopped: no comments were selected in the interactive review")
+		return nil
+	}
+
+	app.ui.Info(fmt.Sprintf("Addressing %d of %d actionable comment(s) selected in review",
+		len(reviewed.ActionableComments), len(analysis.ActionableComments)))
+	return reviewed
+}
@@ -0,0 +1,48 @@
+package app
+
+import (
+	"errors"
+	"testing"
+
+	"ccw/types"
+	"ccw/ui"
+)
+
+func TestReviewCommentsInteractively(t *testing.T) {
+	analysis := &types.PRCommentAnalysis{ActionableComments: []types.ActionableComment{
+		{Comment: types.PRComment{ID: 1}}, {Comment: types.PRComment{ID: 2}},
+	}}
+	defer func(original func(*types.PRCommentAnalysis) (*types.PRCommentAnalysis, error)) {
+		commentReviewer = original
+	}(commentReviewer)
+
+	app := &CCWApp{ui: ui.NewUIManager("default", false, false), options: &WorkflowOptions{}}
+	if result := app.reviewCommentsInteractively(analysis); result != analysis {
+		t.Error("Expected comments to pass through without --interactive-review")
+	}
+
+	app.options.InteractiveReview = true
+	commentReviewer = func(a *types.PRCommentAnalysis) (*types.PRCommentAnalysis, error) {
+		selected := a.ActionableComments[1]
+		selected.Guidance = "keep the API"
+		return &types.PRCommentAnalysis{HasUnaddressedComments: true, ActionableComments: []types.ActionableComment{selected}}, nil
+	}
+	result := app.reviewCommentsInteractively(analysis)
+	if result == nil || len(result.ActionableComments) != 1 || result.ActionableComments[0].Guidance != "keep the API" {
+		t.Errorf("Expected the reviewed selection, got %+v", result)
+	}
+
+	commentReviewer = func(*types.PRCommentAnalysis) (*types.PRCommentAnalysis, error) {
+		return &types.PRCommentAnalysis{}, nil
+	}
+	if result := app.reviewCommentsInteractively(analysis); result != nil {
+		t.Errorf("Expected nothing to address when no comment is selected, got %+v", result)
+	}
+
+	commentReviewer = func(*types.PRCommentAnalysis) (*types.PRCommentAnalysis, error) {
+		return nil, errors.New("no terminal")
+	}
+	if result := app.reviewCommentsInteractively(analysis); result != nil {
+		t.Errorf("Expected nothing to address when the review fails, got %+v", result)
+	}
+}
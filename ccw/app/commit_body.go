@@ -0,0 +1,47 @@
+package app
+
+import (
+	"strings"
+
+	"ccw/commit"
+	"ccw/types"
+)
+
+// Bodies for commit messages ccw writes without Claude
+
+// commitIssue converts issue to the commit package's issue
+func commitIssue(issue *types.Issue) *commit.Issue {
+	labels := make([]string, 0, len(issue.Labels))
+	for _, label := range issue.Labels {
+		labels = append(labels, label.Name)
+	}
+	url := issue.HTMLURL
+	if url == "" {
+		url = issue.URL
+	}
+	return &commit.Issue{Number: issue.Number, Title: issue.Title, Body: issue.Body, URL: url, Labels: labels}
+}
+
+// withCommitBodyTemplate inserts commit.body_template rendered for issue
+// between the subject of message and the rest of its body. Without a template
+// message is returned unchanged.
+func (app *CCWApp) withCommitBodyTemplate(message string, issue *types.Issue) string {
+	if app.ccwConfig == nil || strings.TrimSpace(app.ccwConfig.Commit.BodyTemplate) == "" || issue == nil {
+		return message
+	}
+
+	details := commit.RenderCommitBody(app.ccwConfig.Commit.BodyTemplate, commitIssue(issue), app.ccwConfig.Commit.BodyLines)
+	if details == "" {
+		return message
+	}
+
+	parts := strings.SplitN(message, "\n", 2)
+	rest := ""
+	if len(parts) == 2 {
+		rest = strings.TrimLeft(parts[1], "\n")
+	}
+	if rest == "" {
+		return parts[0] + "\n\n" + details
+	}
+	return parts[0] + "\n\n" + details + "\n\n" + rest
+}
@@ -0,0 +1,43 @@
+package app
+
+import (
+	"testing"
+
+	"ccw/config"
+	"ccw/types"
+)
+
+func TestWithCommitBodyTemplate(t *testing.T) {
+	issue := &types.Issue{
+		Number:  12,
+		Title:   "Fix lexer",
+		Body:    "first\nsecond\nthird",
+		HTMLURL: "https://github.com/o/r/issues/12",
+		Labels:  []types.Label{{Name: "bug"}},
+	}
+
+	tests := []struct {
+		name     string
+		template string
+		message  string
+		expected string
+	}{
+		{"no template", "", "feat: fix lexer\n\nResolves #12", "feat: fix lexer\n\nResolves #12"},
+		{"inserted after subject", "{issue_body}\n\nLabels: {issue_labels}", "feat: fix lexer\n\nResolves #12",
+			"feat: fix lexer\n\nfirst\nsecond\n…\n\nLabels: bug\n\nResolves #12"},
+		{"subject only", "See {issue_url}", "feat: fix lexer", "feat: fix lexer\n\nSee https://github.com/o/r/issues/12"},
+		{"unknown token kept", "{issue_milestone}", "feat: fix lexer", "feat: fix lexer\n\n{issue_milestone}"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ccwConfig := config.GetDefaultCCWConfig()
+			ccwConfig.Commit.BodyTemplate = tt.template
+			ccwConfig.Commit.BodyLines = 2
+			app := &CCWApp{ccwConfig: ccwConfig}
+			if got := app.withCommitBodyTemplate(tt.message, issue); got != tt.expected {
+				t.Errorf("Expected '%s', got '%s'", tt.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package app
+
+import "ccw/types"
+
+// Checks and message handling shared by every commit ccw makes
+
+// checkCommitGuards runs the checks shared by the implementation, recovery and
+// ship commits before their changes are staged: the worktree must still reach
+// its repository, the changes must not hold large or binary files, protected
+// paths or conflict markers, and they must satisfy policy.require_tests
+func (app *CCWApp) checkCommitGuards() error {
+	// A worktree cut off from its base repository fails every git command
+	if err := app.gitOps.CheckWorktreeLinkage(app.worktreeConfig.WorktreePath); err != nil {
+		return err
+	}
+
+	guards := []func() error{
+		app.checkChangedFiles,
+		app.checkProtectedPaths,
+		app.checkConflictMarkers,
+		app.checkTestPolicy,
+	}
+	for _, guard := range guards {
+		if err := guard(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// finishCommitMessage applies commit.body_template and commit.lint to a
+// generated message and adds the Generated-By trailer. issue is nil for
+// commits not made for an issue, such as ccw ship.
+func (app *CCWApp) finishCommitMessage(issue *types.Issue, message string) string {
+	message = app.withCommitBodyTemplate(message, issue)
+	message = app.lintCommitMessage(issue, message)
+	return withGeneratedByTrailer(message, Version)
+}
@@ -0,0 +1,190 @@
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"ccw/commit"
+	"ccw/config"
+	"ccw/git"
+	"ccw/logging"
+	"ccw/types"
+	"ccw/ui"
+)
+
+// newGuardedApp returns an app committing in repoDir with the default commit config
+func newGuardedApp(t *testing.T, repoDir string) *CCWApp {
+	t.Helper()
+	logger, err := logging.NewLogger("commit-guards-test", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &CCWApp{
+		ui:              ui.NewUIManager("default", false, false),
+		logger:          logger,
+		ccwConfig:       config.GetDefaultCCWConfig(),
+		gitOps:          git.NewOperations(repoDir, nil, nil),
+		commitGenerator: &commit.CommitMessageGenerator{},
+		options:         &WorkflowOptions{},
+		worktreeConfig:  &git.WorktreeConfig{WorktreePath: repoDir},
+	}
+}
+
+func TestCheckCommitGuardsBlocksProtectedPaths(t *testing.T) {
+	repoDir, _ := setupInPlaceRepo(t, "issue-3")
+	app := newGuardedApp(t, repoDir)
+
+	if err := app.checkCommitGuards(); err != nil {
+		t.Fatalf("Expected a clean tree to pass, got %v", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(repoDir, ".env"), []byte("TOKEN=secret\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := app.checkCommitGuards(); err == nil || !strings.Contains(err.Error(), ".env") {
+		t.Errorf("Expected the protected .env to be refused, got %v", err)
+	}
+}
+
+func TestCommitRecoveryChangesRunsCommitGuards(t *testing.T) {
+	repoDir, runGit := setupInPlaceRepo(t, "issue-3")
+	app := newGuardedApp(t, repoDir)
+
+	if err := os.WriteFile(filepath.Join(repoDir, ".env"), []byte("TOKEN=secret\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	issue := &types.Issue{Number: 3, Title: "Add lexer"}
+	if err := app.commitRecoveryChanges(issue, &types.ValidationResult{}, 1); err == nil || !strings.Contains(err.Error(), ".env") {
+		t.Errorf("Expected the blocked recovery commit to be reported, got %v", err)
+	}
+
+	if count := runGit("rev-list", "--count", "HEAD"); count != "1" {
+		t.Errorf("Expected the protected path not to be committed, got %s commits", count)
+	}
+}
+
+func TestCommitRecoveryChangesEnforcesTestPolicy(t *testing.T) {
+	repoDir, runGit := setupInPlaceRepo(t, "issue-3")
+	app := newGuardedApp(t, repoDir)
+	app.ccwConfig.Policy.RequireTests = true
+
+	if err := os.WriteFile(filepath.Join(repoDir, "lexer.go"), []byte("package lexer\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := app.checkCommitGuards(); err == nil || !strings.Contains(err.Error(), "without tests") {
+		t.Errorf("Expected the test policy to refuse the commit, got %v", err)
+	}
+
+	if err := app.commitRecoveryChanges(&types.Issue{Number: 3, Title: "Add lexer"}, &types.ValidationResult{}, 1); err == nil {
+		t.Error("Expected the recovery commit without tests to be refused")
+	}
+	if count := runGit("rev-list", "--count", "HEAD"); count != "1" {
+		t.Errorf("Expected no recovery commit without tests, got %s commits", count)
+	}
+}
+
+func TestCommitShipChangesUsesCommitGuardsAndMessagePipeline(t *testing.T) {
+	repoDir, runGit := setupInPlaceRepo(t, "feature/lexer")
+	app := newGuardedApp(t, repoDir)
+	app.ccwConfig.Policy.RequireTests = true
+
+	if err := os.WriteFile(filepath.Join(repoDir, "lexer.go"), []byte("package lexer\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := app.commitShipChanges("feat: add lexer"); err == nil || !strings.Contains(err.Error(), "without tests") {
+		t.Errorf("Expected ccw ship to enforce the test policy, got %v", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(repoDir, "lexer_test.go"), []byte("package lexer\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := app.commitShipChanges("feat: add lexer"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	message := runGit("log", "-1", "--format=%B")
+	if !strings.HasPrefix(message, "feat: add lexer") || !strings.Contains(message, "Generated-By: ccw ") {
+		t.Errorf("Expected the ship message with a Generated-By trailer, got '%s'", message)
+	}
+}
+
+func TestCommitRecoveryChangesWithoutChanges(t *testing.T) {
+	repoDir, runGit := setupInPlaceRepo(t, "issue-3")
+	app := newGuardedApp(t, repoDir)
+
+	// The worktree config is never committed, so it alone is not a change
+	if err := os.WriteFile(filepath.Join(repoDir, "."+git.WorktreeConfigFile), []byte("{}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := app.commitRecoveryChanges(&types.Issue{Number: 3, Title: "Add lexer"}, &types.ValidationResult{}, 1); err != nil {
+		t.Errorf("Expected nothing to commit, got %v", err)
+	}
+	if count := runGit("rev-list", "--count", "HEAD"); count != "1" {
+		t.Errorf("Expected no recovery commit, got %s commits", count)
+	}
+}
+
+func TestCommitRecoveryChangesUsesCommitMessagePipeline(t *testing.T) {
+	repoDir, runGit := setupInPlaceRepo(t, "issue-3")
+	app := newGuardedApp(t, repoDir)
+	app.ccwConfig.Commit.BodyTemplate = "Issue: {issue_url}"
+
+	if err := os.WriteFile(filepath.Join(repoDir, "lexer_test.go"), []byte("package lexer\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	issue := &types.Issue{Number: 3, Title: "Add lexer", HTMLURL: "https://github.com/owner/repo/issues/3"}
+	if err := app.commitRecoveryChanges(issue, &types.ValidationResult{}, 1); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	message := runGit("log", "-1", "--format=%B")
+	if !strings.Contains(message, "Issue: https://github.com/owner/repo/issues/3") {
+		t.Errorf("Expected the body template in the recovery commit, got '%s'", message)
+	}
+	if !strings.Contains(message, "Generated-By: ccw ") {
+		t.Errorf("Expected a Generated-By trailer, got '%s'", message)
+	}
+}
+
+func TestCommitValidationFixesCommitsLeftoverChanges(t *testing.T) {
+	repoDir, runGit := setupInPlaceRepo(t, "issue-3")
+	app := newGuardedApp(t, repoDir)
+	issue := &types.Issue{Number: 3, Title: "Add lexer"}
+
+	if err := app.commitValidationFixes(issue); err != nil {
+		t.Fatalf("Expected nothing to commit, got %v", err)
+	}
+	if count := runGit("rev-list", "--count", "HEAD"); count != "1" {
+		t.Fatalf("Expected no commit for a clean tree, got %s commits", count)
+	}
+
+	// The lint fix pass rewrites sources after the last recovery commit
+	if err := os.WriteFile(filepath.Join(repoDir, "README.md"), []byte("# fixed\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := app.commitValidationFixes(issue); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if count := runGit("rev-list", "--count", "HEAD"); count != "2" {
+		t.Errorf("Expected the validation fixes to be committed, got %s commits", count)
+	}
+	if status := runGit("status", "--porcelain"); status != "" {
+		t.Errorf("Expected a clean tree after committing, got '%s'", status)
+	}
+}
+
+func TestCommitValidationFixesRunsCommitGuards(t *testing.T) {
+	repoDir, runGit := setupInPlaceRepo(t, "issue-3")
+	app := newGuardedApp(t, repoDir)
+
+	if err := os.WriteFile(filepath.Join(repoDir, ".env"), []byte("TOKEN=secret\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := app.commitValidationFixes(&types.Issue{Number: 3, Title: "Add lexer"}); err == nil || !strings.Contains(err.Error(), ".env") {
+		t.Errorf("Expected the protected path to be refused, got %v", err)
+	}
+	if count := runGit("rev-list", "--count", "HEAD"); count != "1" {
+		t.Errorf("Expected nothing committed, got %s commits", count)
+	}
+}
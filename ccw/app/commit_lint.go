@@ -0,0 +1,85 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	"ccw/commit"
+	"ccw/config"
+	"ccw/types"
+)
+
+// Checking generated commit messages against commit.lint
+
+// lintCommitMessage returns message when it passes commit.lint. Otherwise
+// Claude rewrites it once, and if the rewrite still fails a conforming
+// fallback message for the issue is used instead.
+func (app *CCWApp) lintCommitMessage(issue *types.Issue, message string) string {
+	if app.ccwConfig == nil {
+		return message
+	}
+	violations := app.commitLintViolations(message)
+	if len(violations) == 0 {
+		return message
+	}
+	app.ui.Warning(fmt.Sprintf("Commit message fails commit.lint: %s", strings.Join(violations, "; ")))
+
+	rewritten, err := app.claudeIntegration.RewriteCommitMessage(app.worktreeConfig.WorktreePath, message, violations)
+	if err == nil {
+		remaining := app.commitLintViolations(rewritten)
+		if len(remaining) == 0 {
+			app.ui.Info("Using the commit message rewritten by Claude")
+			return rewritten
+		}
+		err = fmt.Errorf("rewrite still fails: %s", strings.Join(remaining, "; "))
+	}
+
+	app.ui.Warning(fmt.Sprintf("Using a fallback commit message: %v", err))
+	return app.withCommitBodyTemplate(lintFallbackCommitMessage(issue, app.ccwConfig.Commit.Lint), issue)
+}
+
+// commitLintViolations checks message with the configured commit.lint mode.
+// When commitlint cannot run, the built-in rules are used instead.
+func (app *CCWApp) commitLintViolations(message string) []string {
+	lint := app.ccwConfig.Commit.Lint
+	rules := commit.LintRules{MaxSubjectLength: lint.MaxSubjectLength, Types: lint.Types}
+
+	switch lint.Mode {
+	case "builtin":
+		return commit.LintMessage(message, rules)
+	case "commitlint":
+		violations, err := commit.RunCommitlint(app.worktreeConfig.WorktreePath, message)
+		if err != nil {
+			app.ui.Warning(fmt.Sprintf("commitlint unavailable, using built-in rules: %v", err))
+			return commit.LintMessage(message, rules)
+		}
+		return violations
+	default:
+		return nil
+	}
+}
+
+// lintFallbackCommitMessage builds a "type: title" message for the issue that
+// passes the built-in rules in lint. Without an issue the local changes are
+// described instead.
+func lintFallbackCommitMessage(issue *types.Issue, lint config.CommitLintConfiguration) string {
+	title := "ship local changes"
+	if issue != nil {
+		title = issue.Title
+	}
+
+	commitType := "feat"
+	if len(lint.Types) > 0 && !commit.ContainsFold(lint.Types, commitType) {
+		commitType = lint.Types[0]
+	}
+
+	subject := fmt.Sprintf("%s: %s", commitType, strings.ToLower(title))
+	if runes := []rune(subject); lint.MaxSubjectLength > 3 && len(runes) > lint.MaxSubjectLength {
+		subject = strings.TrimSpace(string(runes[:lint.MaxSubjectLength-3])) + "..."
+	}
+	if issue == nil {
+		return subject
+	}
+
+	return fmt.Sprintf("%s\n\nResolves #%d", subject, issue.Number)
+}
@@ -0,0 +1,52 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	"ccw/commit"
+	"ccw/config"
+	"ccw/types"
+)
+
+func TestLintFallbackCommitMessagePassesBuiltinRules(t *testing.T) {
+	issue := &types.Issue{Number: 42, Title: "Support Unicode identifiers in the lexer and parser for all keywords"}
+	shortIssue := &types.Issue{Number: 42, Title: "Fix lexer"}
+
+	tests := []struct {
+		name            string
+		issue           *types.Issue
+		lint            config.CommitLintConfiguration
+		expectedSubject string
+	}{
+		{"short title", shortIssue, config.CommitLintConfiguration{MaxSubjectLength: 72, Types: []string{"feat", "fix"}}, "feat: fix lexer"},
+		{"long title truncated", issue, config.CommitLintConfiguration{MaxSubjectLength: 72}, "feat: support unicode identifiers in the lexer and parser for all key..."},
+		{"short limit", issue, config.CommitLintConfiguration{MaxSubjectLength: 30}, "feat: support unicode ident..."},
+		{"feat not allowed", shortIssue, config.CommitLintConfiguration{Types: []string{"chore"}}, "chore: fix lexer"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			message := lintFallbackCommitMessage(tt.issue, tt.lint)
+			subject := strings.SplitN(message, "\n", 2)[0]
+			if subject != tt.expectedSubject {
+				t.Errorf("Expected subject '%s', got '%s'", tt.expectedSubject, subject)
+			}
+			if !strings.HasSuffix(message, "\n\nResolves #42") {
+				t.Errorf("Expected message to resolve the issue, got '%s'", message)
+			}
+			rules := commit.LintRules{MaxSubjectLength: tt.lint.MaxSubjectLength, Types: tt.lint.Types}
+			if violations := commit.LintMessage(message, rules); violations != nil {
+				t.Errorf("Expected fallback to pass lint, got %v", violations)
+			}
+		})
+	}
+}
+
+func TestLintFallbackCommitMessageWithoutIssue(t *testing.T) {
+	lint := config.CommitLintConfiguration{MaxSubjectLength: 72, Types: []string{"chore"}}
+	message := lintFallbackCommitMessage(nil, lint)
+	if message != "chore: ship local changes" {
+		t.Errorf("Expected 'chore: ship local changes', got '%s'", message)
+	}
+}
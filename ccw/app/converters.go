@@ -17,6 +17,7 @@ func ConvertValidationResult(gitResult *git.ValidationResult) *types.ValidationR
 		BuildResult: convertBuildResult(gitResult.BuildResult),
 		TestResult:  convertTestResult(gitResult.TestResult),
 		Errors:      convertValidationErrors(gitResult.Errors),
+		Warnings:    gitResult.Warnings,
 		Duration:    gitResult.Duration,
 		Timestamp:   gitResult.Timestamp,
 	}
@@ -42,9 +43,10 @@ func convertBuildResult(gitResult *git.BuildResult) *types.BuildResult {
 		return nil
 	}
 	return &types.BuildResult{
-		Success: gitResult.Success,
-		Output:  gitResult.Output,
-		Error:   gitResult.Error,
+		Success:  gitResult.Success,
+		Output:   gitResult.Output,
+		Error:    gitResult.Error,
+		Warnings: gitResult.Warnings,
 	}
 }
 
@@ -59,6 +61,8 @@ func convertTestResult(gitResult *git.TestResult) *types.TestResult {
 		TestCount: gitResult.TestCount,
 		Passed:    gitResult.Passed,
 		Failed:    gitResult.Failed,
+
+		FailedTests: gitResult.FailedTests,
 	}
 }
 
@@ -0,0 +1,91 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	"ccw/config"
+	"ccw/consoleui"
+	"ccw/pr"
+	"ccw/types"
+)
+
+// donePolicyFromConfig converts the configured definition of done for the PR manager
+func donePolicyFromConfig(configured config.DoneConfiguration) pr.DonePolicy {
+	return pr.DonePolicy{
+		RequireValidation:       configured.RequireValidation,
+		RequireCI:               configured.RequireCI,
+		RequiredChecks:          configured.RequiredChecks,
+		MaxHighPriorityComments: configured.MaxHighPriorityComments,
+		BlockOnChangesRequested: configured.BlockOnChangesRequested,
+	}
+}
+
+// donePolicy returns the configured definition of done. Without configured
+// required checks, the checks branch protection requires are used when known.
+func (app *CCWApp) donePolicy() pr.DonePolicy {
+	if app.ccwConfig == nil {
+		return pr.DefaultDonePolicy()
+	}
+	policy := donePolicyFromConfig(app.ccwConfig.Workflow.Done)
+	if len(policy.RequiredChecks) == 0 && policy.RequireCI {
+		policy.RequiredChecks = app.requiredChecksFromProtection()
+	}
+	return policy
+}
+
+// evaluateDefinitionOfDone decides whether the run is done from the validation
+// result recorded for this run, the final CI status, the PR comment analysis
+// and the PR's review decision
+func (app *CCWApp) evaluateDefinitionOfDone(ci *types.CIStatus, comments *types.PRCommentAnalysis, review pr.ReviewDecision) pr.DoneDecision {
+	var validation *types.ValidationResult
+	if app.runSummary != nil {
+		validation = app.runSummary.Validation
+	}
+	return pr.NewDoneEvaluator(app.donePolicy()).EvaluateWithReview(validation, ci, comments, review)
+}
+
+// fetchReviewDecision returns the review decision of prURL when the policy
+// uses it. A failed lookup is logged and treated as no decision.
+func (app *CCWApp) fetchReviewDecision(prURL string) pr.ReviewDecision {
+	if !app.donePolicy().BlockOnChangesRequested || prURL == "" {
+		return pr.ReviewDecisionNone
+	}
+
+	review, err := app.prManager.GetReviewDecision(prURL)
+	if err != nil {
+		if app.logger != nil {
+			app.logger.Warn("workflow", "Failed to fetch PR review decision", map[string]interface{}{
+				"pr_url": prURL,
+				"error":  err.Error(),
+			})
+		}
+		return pr.ReviewDecisionNone
+	}
+	return review
+}
+
+// reportDefinitionOfDone reports whether the run meets the definition of done
+func (app *CCWApp) reportDefinitionOfDone(prURL string, ci *types.CIStatus, comments *types.PRCommentAnalysis) {
+	decision := app.evaluateDefinitionOfDone(ci, comments, app.fetchReviewDecision(prURL))
+	if blocker := app.conflictBlocker(); blocker != "" {
+		decision.Done = false
+		decision.Reasons = append(decision.Reasons, blocker)
+	}
+
+	if app.logger != nil {
+		app.logger.Info("workflow", "Definition of done evaluated", map[string]interface{}{
+			"done":    decision.Done,
+			"reasons": decision.Reasons,
+		})
+	}
+
+	app.explain(decision.Explanation())
+
+	if decision.Done {
+		doneIcon := consoleui.Char("✅", "[DONE]")
+		app.ui.Success(fmt.Sprintf("%s Definition of done met", doneIcon))
+		return
+	}
+	app.ui.Warning(fmt.Sprintf("Not done yet: %s", strings.Join(decision.Reasons, "; ")))
+}
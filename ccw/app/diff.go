@@ -0,0 +1,114 @@
+package app
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"ccw/config"
+	"ccw/git"
+	"ccw/ui"
+)
+
+// Printing the changes made in an existing worktree
+
+// diffCommandOptions holds the parsed arguments of ccw diff
+type diffCommandOptions struct {
+	Target string
+	Base   string // Empty resolves to the merge base with the default branch
+	Stat   bool
+	Color  string // "auto", "always" or "never"
+}
+
+// HandleDiffCommand prints the diff of an issue's worktree against its base
+func HandleDiffCommand() {
+	options, err := parseDiffArgs(os.Args[2:])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n\n", err)
+		printDiffUsage()
+		os.Exit(1)
+	}
+
+	ccwConfig, err := config.LoadConfiguration()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to load configuration: %v\n", err)
+		os.Exit(1)
+	}
+
+	if err := runDiff(options, ccwConfig, os.Stdout); err != nil {
+		fmt.Fprintf(os.Stderr, "Diff failed: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// parseDiffArgs parses `ccw diff <issue|url|path> [--stat] [--base REF]
+// [--color|--no-color]`
+func parseDiffArgs(args []string) (diffCommandOptions, error) {
+	options := diffCommandOptions{Color: "auto"}
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		switch {
+		case arg == "--stat":
+			options.Stat = true
+		case arg == "--color":
+			options.Color = "always"
+		case arg == "--no-color":
+			options.Color = "never"
+		case arg == "--base":
+			if i+1 >= len(args) {
+				return options, fmt.Errorf("--base requires a ref")
+			}
+			i++
+			options.Base = args[i]
+		case strings.HasPrefix(arg, "--base="):
+			options.Base = strings.TrimPrefix(arg, "--base=")
+		case strings.HasPrefix(arg, "-"):
+			return options, fmt.Errorf("unknown option %s", arg)
+		case options.Target != "":
+			return options, fmt.Errorf("unexpected argument %s", arg)
+		default:
+			options.Target = arg
+		}
+	}
+	if options.Target == "" {
+		return options, fmt.Errorf("an issue number, issue URL or worktree path is required")
+	}
+	return options, nil
+}
+
+// gitDiffOptions turns command options into git diff options, coloring "auto"
+// output only when it goes to a terminal that accepts colors
+func (o diffCommandOptions) gitDiffOptions(plainOutput bool) git.DiffOptions {
+	color := o.Color == "always" || (o.Color == "auto" && !plainOutput)
+	return git.DiffOptions{Stat: o.Stat, Color: color}
+}
+
+// runDiff resolves the worktree named by options.Target and writes its diff to w
+func runDiff(options diffCommandOptions, ccwConfig *config.CCWConfig, w io.Writer) error {
+	worktreePath, err := resolveOpenWorktree(options.Target, ccwConfig.WorktreeBase)
+	if err != nil {
+		return err
+	}
+
+	gitOps := git.NewOperations(ccwConfig.WorktreeBase, &git.GitOperationConfig{
+		RemoteName:    ccwConfig.Git.RemoteName,
+		DefaultBranch: ccwConfig.Git.DefaultBranch,
+	}, nil)
+	if err := gitOps.CheckWorktreeLinkage(worktreePath); err != nil {
+		return err
+	}
+
+	output, err := gitOps.DiffWithOptions(worktreePath, options.Base, options.gitDiffOptions(ui.PlainOutput()))
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(w, output)
+	return err
+}
+
+// printDiffUsage displays usage for the diff command
+func printDiffUsage() {
+	fmt.Println("Usage: ccw diff <issue-number|issue-url|worktree-path> [--stat] [--base REF] [--color|--no-color]")
+	fmt.Println("  Print the changes in an issue's worktree against its base branch")
+}
@@ -0,0 +1,105 @@
+package app
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"ccw/config"
+	"ccw/git"
+)
+
+func TestParseDiffArgs(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		expected diffCommandOptions
+		wantErr  bool
+	}{
+		{"issue number", []string{"12"}, diffCommandOptions{Target: "12", Color: "auto"}, false},
+		{"stat", []string{"#12", "--stat"}, diffCommandOptions{Target: "#12", Stat: true, Color: "auto"}, false},
+		{"forced color", []string{"--color", "12"}, diffCommandOptions{Target: "12", Color: "always"}, false},
+		{"no color", []string{"12", "--no-color"}, diffCommandOptions{Target: "12", Color: "never"}, false},
+		{"base", []string{"12", "--base", "origin/dev"}, diffCommandOptions{Target: "12", Base: "origin/dev", Color: "auto"}, false},
+		{"base equals", []string{"12", "--base=HEAD~2"}, diffCommandOptions{Target: "12", Base: "HEAD~2", Color: "auto"}, false},
+		{"missing target", []string{"--stat"}, diffCommandOptions{}, true},
+		{"missing base ref", []string{"12", "--base"}, diffCommandOptions{}, true},
+		{"unknown option", []string{"12", "--word-diff"}, diffCommandOptions{}, true},
+		{"two targets", []string{"12", "13"}, diffCommandOptions{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := parseDiffArgs(tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Expected error %v, got %v", tt.wantErr, err)
+			}
+			if !tt.wantErr && result != tt.expected {
+				t.Errorf("Expected %+v, got %+v", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestDiffCommandGitDiffOptions(t *testing.T) {
+	tests := []struct {
+		name        string
+		options     diffCommandOptions
+		plainOutput bool
+		expected    git.DiffOptions
+	}{
+		{"auto on terminal", diffCommandOptions{Color: "auto"}, false, git.DiffOptions{Color: true}},
+		{"auto when piped", diffCommandOptions{Color: "auto", Stat: true}, true, git.DiffOptions{Stat: true}},
+		{"always when piped", diffCommandOptions{Color: "always"}, true, git.DiffOptions{Color: true}},
+		{"never on terminal", diffCommandOptions{Color: "never", Stat: true}, false, git.DiffOptions{Stat: true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := tt.options.gitDiffOptions(tt.plainOutput); result != tt.expected {
+				t.Errorf("Expected %+v, got %+v", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestRunDiffResolvesIssueWorktree(t *testing.T) {
+	base := t.TempDir()
+	dir := filepath.Join(base, "issue-12-20240101-090000")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	runGit := initTestRepo(t, dir)
+	if err := os.WriteFile(filepath.Join(dir, "parser.go"), []byte("package parser\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	runGit("add", ".")
+	runGit("commit", "-q", "-m", "initial")
+	if err := os.WriteFile(filepath.Join(dir, "parser.go"), []byte("package parser\n\nfunc Parse() {}\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	ccwConfig := &config.CCWConfig{WorktreeBase: base}
+
+	var out bytes.Buffer
+	if err := runDiff(diffCommandOptions{Target: "12", Color: "never"}, ccwConfig, &out); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !strings.Contains(out.String(), "+func Parse() {}") {
+		t.Errorf("Expected patch output, got:\n%s", out.String())
+	}
+
+	out.Reset()
+	if err := runDiff(diffCommandOptions{Target: "#12", Stat: true, Color: "never"}, ccwConfig, &out); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !strings.Contains(out.String(), "parser.go | 2 ++") || strings.Contains(out.String(), "+func") {
+		t.Errorf("Expected stat output, got:\n%s", out.String())
+	}
+
+	if err := runDiff(diffCommandOptions{Target: "99", Color: "never"}, ccwConfig, &out); err == nil {
+		t.Error("Expected error for an issue without a worktree")
+	}
+}
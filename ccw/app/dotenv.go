@@ -0,0 +1,88 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"ccw/config"
+)
+
+// dotEnvPaths returns the .env files env.dotenv reads, in increasing
+// precedence: the working directory, then the worktree
+func dotEnvPaths(cwd, worktreePath string) []string {
+	var paths []string
+	for _, dir := range []string{cwd, worktreePath} {
+		if dir == "" {
+			continue
+		}
+		path := filepath.Join(dir, config.DotEnvFile)
+		if len(paths) == 0 || paths[len(paths)-1] != path {
+			paths = append(paths, path)
+		}
+	}
+	return paths
+}
+
+// loadDotEnv reads the .env files at paths, skipping missing ones, and
+// returns the merged KEY=value list with later files overriding earlier ones.
+// Keys keep the order they first appear in.
+func loadDotEnv(paths []string) ([]string, error) {
+	values := make(map[string]string)
+	var keys []string
+	for _, path := range paths {
+		vars, err := config.LoadDotEnv(path)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		for _, v := range vars {
+			if _, seen := values[v.Key]; !seen {
+				keys = append(keys, v.Key)
+			}
+			values[v.Key] = v.Value
+		}
+	}
+
+	env := make([]string, 0, len(keys))
+	for _, key := range keys {
+		env = append(env, config.DotEnvVar{Key: key, Value: values[key]}.String())
+	}
+	return env, nil
+}
+
+// applyDotEnv passes .env variables to validation and Claude Code when
+// env.dotenv is set. Only variable names are logged, never values.
+func (app *CCWApp) applyDotEnv() {
+	if app.ccwConfig == nil || !app.ccwConfig.Env.DotEnv || app.worktreeConfig == nil {
+		return
+	}
+
+	cwd, _ := os.Getwd()
+	env, err := loadDotEnv(dotEnvPaths(cwd, app.worktreeConfig.WorktreePath))
+	if err != nil {
+		app.ui.Warning(fmt.Sprintf("Ignoring .env: %v", err))
+		return
+	}
+	if len(env) == 0 {
+		return
+	}
+
+	app.validator.SetEnv(env)
+	if app.claudeIntegration != nil {
+		app.claudeIntegration.Env = env
+	}
+
+	names := make([]string, 0, len(env))
+	for _, variable := range env {
+		name, _, _ := strings.Cut(variable, "=")
+		names = append(names, name)
+	}
+	app.logger.Info("workflow", "Loaded .env variables for validation and Claude Code", map[string]interface{}{
+		"variables": names,
+	})
+}
@@ -0,0 +1,60 @@
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestDotEnvPaths(t *testing.T) {
+	tests := []struct {
+		name     string
+		cwd      string
+		worktree string
+		expected []string
+	}{
+		{"cwd then worktree", "/repo", "/work/issue-1", []string{"/repo/.env", "/work/issue-1/.env"}},
+		{"in place", "/repo", "/repo", []string{"/repo/.env"}},
+		{"no cwd", "", "/work/issue-1", []string{"/work/issue-1/.env"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if paths := dotEnvPaths(tt.cwd, tt.worktree); !reflect.DeepEqual(paths, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, paths)
+			}
+		})
+	}
+}
+
+func TestLoadDotEnvMergesFiles(t *testing.T) {
+	repo, worktree := t.TempDir(), t.TempDir()
+	if err := os.WriteFile(filepath.Join(repo, ".env"), []byte("A=repo\nB=repo\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(worktree, ".env"), []byte("B=worktree\nC=worktree\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	env, err := loadDotEnv(dotEnvPaths(repo, worktree))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := []string{"A=repo", "B=worktree", "C=worktree"}
+	if !reflect.DeepEqual(env, expected) {
+		t.Errorf("Expected %v, got %v", expected, env)
+	}
+
+	env, err = loadDotEnv([]string{filepath.Join(t.TempDir(), ".env")})
+	if err != nil || len(env) != 0 {
+		t.Errorf("Expected missing files to be skipped, got %v, %v", env, err)
+	}
+
+	if err := os.WriteFile(filepath.Join(worktree, ".env"), []byte("BROKEN\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := loadDotEnv(dotEnvPaths(repo, worktree)); err == nil {
+		t.Error("Expected a parse error")
+	}
+}
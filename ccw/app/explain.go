@@ -0,0 +1,21 @@
+package app
+
+import (
+	"fmt"
+
+	"ccw/consoleui"
+)
+
+// explainEnabled reports whether --explain was given for this run
+func (app *CCWApp) explainEnabled() bool {
+	return app.options != nil && app.options.Explain
+}
+
+// explain prints the rationale for a workflow decision when --explain is enabled
+func (app *CCWApp) explain(reason string) {
+	if !app.explainEnabled() || reason == "" {
+		return
+	}
+	whyIcon := consoleui.Char("💡", "[WHY]")
+	app.ui.Info(fmt.Sprintf("%s %s", whyIcon, reason))
+}
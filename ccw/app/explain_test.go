@@ -0,0 +1,51 @@
+package app
+
+import (
+	"testing"
+
+	"ccw/types"
+)
+
+func TestParseWorkflowArgsExplain(t *testing.T) {
+	_, options, err := ParseWorkflowArgs([]string{"--explain", "https://github.com/o/r/issues/1"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !options.Explain {
+		t.Error("Expected Explain to be set")
+	}
+
+	app := &CCWApp{options: &WorkflowOptions{}}
+	if app.explainEnabled() {
+		t.Error("Expected explanations to be disabled by default")
+	}
+}
+
+func TestExplainAddressComments(t *testing.T) {
+	comment := func(priority types.CommentPriority) types.ActionableComment {
+		return types.ActionableComment{Priority: priority, Actionable: true}
+	}
+
+	tests := []struct {
+		name     string
+		comments []types.ActionableComment
+		address  bool
+		expected string
+	}{
+		{"high priority", []types.ActionableComment{comment(types.CommentPriorityHigh)}, true, "addressing comments: 1 high-priority comment(s) found"},
+		{"several medium", []types.ActionableComment{comment(types.CommentPriorityMedium), comment(types.CommentPriorityMedium)}, true, "addressing comments: 2 actionable comments found (at least 2 needed without a high-priority one)"},
+		{"single medium", []types.ActionableComment{comment(types.CommentPriorityMedium)}, false, "feedback loop stopped: 1 actionable comment(s), none high priority (at least 2 needed)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			address, reason := explainAddressComments(&types.PRCommentAnalysis{ActionableComments: tt.comments})
+			if address != tt.address {
+				t.Errorf("Expected address=%v, got %v", tt.address, address)
+			}
+			if reason != tt.expected {
+				t.Errorf("Expected '%s', got '%s'", tt.expected, reason)
+			}
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Configurable messages printed when a run ends
+
+// defaultSuccessMessage is printed when workflow.messages.success is empty
+const defaultSuccessMessage = "Async workflow completed successfully!"
+
+// renderFinalMessage replaces the {token} placeholders in template with
+// details of the run in summary. Unknown tokens are left as written, and
+// details that are not known yet render as "n/a".
+func renderFinalMessage(template string, summary *RunSummary, now time.Time) string {
+	if summary == nil {
+		summary = &RunSummary{}
+	}
+	orNA := func(value string) string {
+		if value == "" {
+			return "n/a"
+		}
+		return value
+	}
+
+	issueNumber, issueTitle := "", ""
+	if summary.Issue != nil {
+		issueNumber = fmt.Sprintf("%d", summary.Issue.Number)
+		issueTitle = summary.Issue.Title
+	}
+	duration := ""
+	if !summary.StartedAt.IsZero() {
+		duration = now.Sub(summary.StartedAt).Round(time.Second).String()
+	}
+	errorText := ""
+	if summary.Error != nil {
+		errorText = strings.ReplaceAll(summary.Error.Error(), "\n", " ")
+	}
+
+	return strings.NewReplacer(
+		"{issue_number}", orNA(issueNumber),
+		"{issue_title}", orNA(issueTitle),
+		"{issue_url}", orNA(summary.IssueURL),
+		"{branch}", orNA(summary.BranchName),
+		"{pr_url}", orNA(summary.PRURL),
+		"{duration}", orNA(duration),
+		"{ci_conclusion}", orNA(summary.CIConclusion),
+		"{error}", orNA(errorText),
+	).Replace(template)
+}
+
+// finalSuccessMessage returns the message printed when the PR workflow completes
+func (app *CCWApp) finalSuccessMessage() string {
+	if app.ccwConfig == nil || app.ccwConfig.Workflow.Messages.Success == "" {
+		return defaultSuccessMessage
+	}
+	return renderFinalMessage(app.ccwConfig.Workflow.Messages.Success, app.runSummary, time.Now())
+}
+
+// reportFinalFailure prints workflow.messages.failure when a run fails
+func (app *CCWApp) reportFinalFailure(runErr error) {
+	if runErr == nil || app.ccwConfig == nil || app.ccwConfig.Workflow.Messages.Failure == "" {
+		return
+	}
+	app.ui.Error(renderFinalMessage(app.ccwConfig.Workflow.Messages.Failure, app.runSummary, time.Now()))
+}
@@ -0,0 +1,76 @@
+package app
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"ccw/config"
+	"ccw/types"
+)
+
+func TestRenderFinalMessage(t *testing.T) {
+	started := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	now := started.Add(12*time.Minute + 34*time.Second)
+	success := &RunSummary{
+		Issue:        &types.Issue{Number: 42, Title: "Fix the parser"},
+		IssueURL:     "https://github.com/o/r/issues/42",
+		BranchName:   "fix/issue-42-20240501-090000",
+		PRURL:        "https://github.com/o/r/pull/7",
+		CIConclusion: "success",
+		StartedAt:    started,
+	}
+
+	tests := []struct {
+		name     string
+		template string
+		summary  *RunSummary
+		expected string
+	}{
+		{
+			"success tokens",
+			"#{issue_number} {issue_title} done in {duration}: {pr_url} (CI: {ci_conclusion}) on {branch}",
+			success,
+			"#42 Fix the parser done in 12m34s: https://github.com/o/r/pull/7 (CI: success) on fix/issue-42-20240501-090000",
+		},
+		{
+			"failure tokens",
+			"{issue_url} failed after {duration}: {error}",
+			&RunSummary{IssueURL: "https://github.com/o/r/issues/42", Error: errors.New("push failed\nOutput: denied"), StartedAt: started},
+			"https://github.com/o/r/issues/42 failed after 12m34s: push failed Output: denied",
+		},
+		{
+			"unknown details",
+			"PR {pr_url}, CI {ci_conclusion}, issue {issue_number}",
+			&RunSummary{},
+			"PR n/a, CI n/a, issue n/a",
+		},
+		{
+			"unknown tokens kept",
+			"{pr_url} {not_a_token}",
+			success,
+			"https://github.com/o/r/pull/7 {not_a_token}",
+		},
+		{"nil summary", "done in {duration}", nil, "done in n/a"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if message := renderFinalMessage(tt.template, tt.summary, now); message != tt.expected {
+				t.Errorf("Expected '%s', got '%s'", tt.expected, message)
+			}
+		})
+	}
+}
+
+func TestFinalSuccessMessageDefault(t *testing.T) {
+	app := &CCWApp{ccwConfig: &config.CCWConfig{}, runSummary: &RunSummary{PRURL: "https://github.com/o/r/pull/7"}}
+	if message := app.finalSuccessMessage(); message != defaultSuccessMessage {
+		t.Errorf("Expected '%s', got '%s'", defaultSuccessMessage, message)
+	}
+
+	app.ccwConfig.Workflow.Messages.Success = "Opened {pr_url}"
+	if message := app.finalSuccessMessage(); message != "Opened https://github.com/o/r/pull/7" {
+		t.Errorf("Expected 'Opened https://github.com/o/r/pull/7', got '%s'", message)
+	}
+}
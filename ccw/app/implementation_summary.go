@@ -0,0 +1,44 @@
+package app
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Writing the implementation summary outside the PR body
+
+// WriteImplementationSummary writes the implementation summary to path,
+// creating parent directories as needed
+func WriteImplementationSummary(path, summary string) error {
+	if dir := filepath.Dir(path); dir != "." {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("failed to create implementation summary directory: %w", err)
+		}
+	}
+
+	if err := os.WriteFile(path, []byte(strings.TrimRight(summary, "\n")+"\n"), 0644); err != nil {
+		return fmt.Errorf("failed to write implementation summary: %w", err)
+	}
+
+	return nil
+}
+
+// recordImplementationSummary keeps the summary for the run result and writes
+// it to --implementation-summary-out when given
+func (app *CCWApp) recordImplementationSummary(summary string) {
+	if app.runSummary != nil {
+		app.runSummary.ImplementationSummary = summary
+	}
+	if app.options == nil || app.options.ImplementationSummaryOut == "" {
+		return
+	}
+
+	path := app.options.ImplementationSummaryOut
+	if err := WriteImplementationSummary(path, summary); err != nil {
+		app.ui.Warning(fmt.Sprintf("Failed to write implementation summary: %v", err))
+		return
+	}
+	app.ui.Info(fmt.Sprintf("Implementation summary written to %s", path))
+}
@@ -0,0 +1,81 @@
+package app
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestParseWorkflowArgsImplementationSummaryOut(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        []string
+		expected    string
+		expectError bool
+	}{
+		{"separate value", []string{"https://github.com/o/r/issues/1", "--implementation-summary-out", "out/impl.md"}, "out/impl.md", false},
+		{"equals value", []string{"--implementation-summary-out=impl.md", "https://github.com/o/r/issues/1"}, "impl.md", false},
+		{"missing value", []string{"https://github.com/o/r/issues/1", "--implementation-summary-out"}, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, options, err := ParseWorkflowArgs(tt.args)
+			if tt.expectError {
+				if err == nil {
+					t.Error("Expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if options.ImplementationSummaryOut != tt.expected {
+				t.Errorf("Expected '%s', got '%s'", tt.expected, options.ImplementationSummaryOut)
+			}
+		})
+	}
+}
+
+func TestWriteImplementationSummary(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "reports", "impl.md")
+
+	if err := WriteImplementationSummary(path, "## Changes\n- Fixed the parser\n\n"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Expected summary file to be written: %v", err)
+	}
+	expected := "## Changes\n- Fixed the parser\n"
+	if string(data) != expected {
+		t.Errorf("Expected '%s', got '%s'", expected, string(data))
+	}
+}
+
+func TestFormatQuietResultJSONIncludesImplementationSummary(t *testing.T) {
+	started := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	summary := &RunSummary{
+		IssueURL:              "https://github.com/o/r/issues/42",
+		PRURL:                 "https://github.com/o/r/pull/7",
+		ImplementationSummary: "Fixed the parser\nAdded tests",
+		StartedAt:             started,
+		FinishedAt:            started.Add(time.Minute),
+	}
+
+	var result map[string]string
+	if err := json.Unmarshal([]byte(formatQuietResult(summary, true)), &result); err != nil {
+		t.Fatalf("Expected a JSON line: %v", err)
+	}
+	if result["implementation_summary"] != summary.ImplementationSummary {
+		t.Errorf("Expected '%s', got '%s'", summary.ImplementationSummary, result["implementation_summary"])
+	}
+
+	expected := "ccw: https://github.com/o/r/issues/42 succeeded in 1m0s: https://github.com/o/r/pull/7"
+	if line := formatQuietResult(summary, false); line != expected {
+		t.Errorf("Expected '%s', got '%s'", expected, line)
+	}
+}
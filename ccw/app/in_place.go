@@ -0,0 +1,109 @@
+package app
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"ccw/git"
+)
+
+// Running the issue workflow in the current checkout instead of a worktree
+
+// inPlace reports whether --in-place was given for this run
+func (app *CCWApp) inPlace() bool {
+	return app.options != nil && app.options.InPlace
+}
+
+// setupInPlaceCheckout points the workflow at the repository containing dir.
+// The current branch is used unless HEAD is detached or on the default branch,
+// in which case an issue branch starting with branchPrefix is created in place.
+// A dirty tree is refused unless --allow-dirty was given. The .ccw directory
+// with this run's lock, logs and history is excluded from the checkout first,
+// so it neither counts as a change nor gets committed.
+func (app *CCWApp) setupInPlaceCheckout(dir, branchPrefix string, issueNumber int, owner, repo, issueURL string) error {
+	rootOutput, err := git.CreateGitCommand([]string{"rev-parse", "--show-toplevel"}, dir).Output()
+	if err != nil {
+		return fmt.Errorf("--in-place must be run inside a git repository: %w", err)
+	}
+	repoPath := strings.TrimSpace(string(rootOutput))
+
+	if err := git.ExcludeFromCommits(repoPath, git.RuntimeStateExclude); err != nil {
+		return fmt.Errorf("failed to exclude ccw runtime files from %s: %w", repoPath, err)
+	}
+
+	if !app.options.AllowDirty {
+		dirty, err := app.gitOps.HasUncommittedChanges(repoPath)
+		if err != nil {
+			return fmt.Errorf("failed to check for uncommitted changes: %w", err)
+		}
+		if dirty {
+			return fmt.Errorf("working tree %s has uncommitted changes; commit or stash them, or pass --allow-dirty", repoPath)
+		}
+	}
+
+	branchName, err := app.gitOps.GetCurrentBranch(repoPath)
+	if err != nil {
+		return err
+	}
+	if branchName == "" || branchName == app.shipBaseBranch() {
+		branchName = branchPrefix + generateBranchName(issueNumber)
+		if output, err := git.CreateGitCommand([]string{"checkout", "-b", branchName}, repoPath).CombinedOutput(); err != nil {
+			return fmt.Errorf("failed to create branch %s: %w\nOutput: %s", branchName, err, string(output))
+		}
+	}
+
+	app.worktreeConfig = &git.WorktreeConfig{
+		BasePath:     repoPath,
+		BranchName:   branchName,
+		WorktreePath: repoPath,
+		IssueNumber:  issueNumber,
+		CreatedAt:    time.Now(),
+		Owner:        owner,
+		Repository:   repo,
+		IssueURL:     issueURL,
+	}
+	return nil
+}
+
+// setupInPlaceEnvironment prepares the current checkout for the workflow. No
+// worktree is created and no issue or worktree data files are written into it.
+func (app *CCWApp) setupInPlaceEnvironment(branchPrefix string, issueNumber int, owner, repo, issueURL string) error {
+	app.ui.Info("Using the current checkout (--in-place)...")
+	if err := app.setupInPlaceCheckout(".", branchPrefix, issueNumber, owner, repo, issueURL); err != nil {
+		app.ui.UpdateProgress("setup", "failed")
+		return err
+	}
+	app.ui.Info(fmt.Sprintf("Working on branch %s in %s", app.worktreeConfig.BranchName, app.worktreeConfig.WorktreePath))
+
+	written, err := app.setupInPlaceClaudePermissions(app.worktreeConfig.WorktreePath)
+	if err != nil {
+		app.ui.Warning("Claude permissions setup failed, Claude Code may require manual permission confirmations")
+	} else if !written {
+		app.ui.Info(fmt.Sprintf("Keeping the existing %s", git.ClaudeSettingsExclude))
+	}
+
+	app.ui.UpdateProgress("setup", "completed")
+	return nil
+}
+
+// setupInPlaceClaudePermissions writes the Claude Code permissions into the
+// user's checkout only when it has none of its own, and keeps the file out of
+// the workflow's commits. It reports whether a file was written.
+func (app *CCWApp) setupInPlaceClaudePermissions(repoPath string) (bool, error) {
+	if _, err := os.Stat(filepath.Join(repoPath, git.ClaudeSettingsExclude)); err == nil {
+		return false, nil
+	} else if !os.IsNotExist(err) {
+		return false, err
+	}
+
+	if err := git.ExcludeFromCommits(repoPath, git.ClaudeSettingsExclude); err != nil {
+		return false, fmt.Errorf("failed to exclude %s from %s: %w", git.ClaudeSettingsExclude, repoPath, err)
+	}
+	if err := app.setupClaudePermissions(repoPath); err != nil {
+		return false, err
+	}
+	return true, nil
+}
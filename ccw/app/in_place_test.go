@@ -0,0 +1,243 @@
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"ccw/config"
+	"ccw/git"
+	"ccw/lock"
+)
+
+func TestParseWorkflowArgsInPlace(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        []string
+		inPlace     bool
+		allowDirty  bool
+		expectError bool
+	}{
+		{"default", []string{"https://github.com/o/r/issues/1"}, false, false, false},
+		{"in place", []string{"--in-place", "https://github.com/o/r/issues/1"}, true, false, false},
+		{"in place allowing dirty tree", []string{"https://github.com/o/r/issues/1", "--in-place", "--allow-dirty"}, true, true, false},
+		{"allow-dirty alone", []string{"https://github.com/o/r/issues/1", "--allow-dirty"}, false, false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, options, err := ParseWorkflowArgs(tt.args)
+			if tt.expectError {
+				if err == nil {
+					t.Error("Expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if options.InPlace != tt.inPlace || options.AllowDirty != tt.allowDirty {
+				t.Errorf("Expected InPlace=%v AllowDirty=%v, got %v %v", tt.inPlace, tt.allowDirty, options.InPlace, options.AllowDirty)
+			}
+		})
+	}
+}
+
+// setupInPlaceRepo creates a repository with one commit on branch
+func setupInPlaceRepo(t *testing.T, branch string) (string, func(args ...string) string) {
+	t.Helper()
+	repoDir, err := filepath.EvalSymlinks(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	runGit := initTestRepo(t, repoDir)
+	if err := os.WriteFile(filepath.Join(repoDir, "README.md"), []byte("hello\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	runGit("add", "README.md")
+	runGit("commit", "-q", "-m", "initial")
+	runGit("branch", "-M", branch)
+	return repoDir, runGit
+}
+
+func newInPlaceApp(allowDirty bool) *CCWApp {
+	return &CCWApp{
+		ccwConfig: &config.CCWConfig{Git: config.GitConfiguration{DefaultBranch: "main"}},
+		gitOps:    git.NewOperations("", nil, nil),
+		options:   &WorkflowOptions{InPlace: true, AllowDirty: allowDirty},
+	}
+}
+
+func TestSetupInPlaceCheckoutUsesCurrentBranch(t *testing.T) {
+	repoDir, runGit := setupInPlaceRepo(t, "feature/ci")
+	app := newInPlaceApp(false)
+
+	if err := app.setupInPlaceCheckout(repoDir, "", 7, "owner", "repo", "https://github.com/owner/repo/issues/7"); err != nil {
+		t.Fatalf("setupInPlaceCheckout failed: %v", err)
+	}
+
+	if app.worktreeConfig.WorktreePath != repoDir {
+		t.Errorf("Expected worktree path '%s', got '%s'", repoDir, app.worktreeConfig.WorktreePath)
+	}
+	if app.worktreeConfig.BranchName != "feature/ci" {
+		t.Errorf("Expected branch 'feature/ci', got '%s'", app.worktreeConfig.BranchName)
+	}
+	if worktrees := runGit("worktree", "list"); strings.Count(worktrees, "\n") != 0 {
+		t.Errorf("Expected no worktree to be created, got:\n%s", worktrees)
+	}
+}
+
+func TestSetupInPlaceCheckoutBranchesOffDefaultBranch(t *testing.T) {
+	repoDir, runGit := setupInPlaceRepo(t, "main")
+	app := newInPlaceApp(false)
+
+	if err := app.setupInPlaceCheckout(repoDir, "", 7, "owner", "repo", ""); err != nil {
+		t.Fatalf("setupInPlaceCheckout failed: %v", err)
+	}
+
+	current := runGit("branch", "--show-current")
+	if !strings.HasPrefix(current, "issue-7-") || current != app.worktreeConfig.BranchName {
+		t.Errorf("Expected a new issue-7 branch to be checked out, got '%s' (config '%s')", current, app.worktreeConfig.BranchName)
+	}
+}
+
+func TestSetupInPlaceCheckoutUsesBranchPrefix(t *testing.T) {
+	repoDir, runGit := setupInPlaceRepo(t, "main")
+	app := newInPlaceApp(false)
+
+	if err := app.setupInPlaceCheckout(repoDir, "fix/", 7, "owner", "repo", ""); err != nil {
+		t.Fatalf("setupInPlaceCheckout failed: %v", err)
+	}
+
+	current := runGit("branch", "--show-current")
+	if !strings.HasPrefix(current, "fix/issue-7-") || current != app.worktreeConfig.BranchName {
+		t.Errorf("Expected a new fix/issue-7 branch to be checked out, got '%s' (config '%s')", current, app.worktreeConfig.BranchName)
+	}
+}
+
+func TestSetupInPlaceCheckoutRefusesDirtyTree(t *testing.T) {
+	repoDir, _ := setupInPlaceRepo(t, "feature/ci")
+	if err := os.WriteFile(filepath.Join(repoDir, "README.md"), []byte("changed\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	err := newInPlaceApp(false).setupInPlaceCheckout(repoDir, "", 7, "owner", "repo", "")
+	if err == nil || !strings.Contains(err.Error(), "--allow-dirty") {
+		t.Errorf("Expected dirty tree error mentioning --allow-dirty, got %v", err)
+	}
+
+	if err := newInPlaceApp(true).setupInPlaceCheckout(repoDir, "", 7, "owner", "repo", ""); err != nil {
+		t.Errorf("Expected --allow-dirty to accept a dirty tree, got %v", err)
+	}
+}
+
+func TestInPlaceSkipsWorktreeCleanupAndSummaryFile(t *testing.T) {
+	repoDir, _ := setupInPlaceRepo(t, "feature/ci")
+	app := newInPlaceApp(false)
+	if err := app.setupInPlaceCheckout(repoDir, "", 7, "owner", "repo", ""); err != nil {
+		t.Fatal(err)
+	}
+
+	app.cleanupWorktree(repoDir)
+	if _, err := os.Stat(filepath.Join(repoDir, "README.md")); err != nil {
+		t.Errorf("Expected the checkout to be left in place: %v", err)
+	}
+
+	path := app.runSummaryPath(&RunSummary{WorktreePath: repoDir})
+	if strings.HasPrefix(path, repoDir) {
+		t.Errorf("Expected summary outside the checkout, got '%s'", path)
+	}
+}
+
+func TestInPlaceRunStateIsNeverCommitted(t *testing.T) {
+	repoDir, runGit := setupInPlaceRepo(t, "feature/ci")
+
+	// The workflow takes its issue lock in the checkout before setup runs
+	issueLock, err := lock.Acquire(filepath.Join(repoDir, lock.DefaultLockDir), 7, "session-1")
+	if err != nil {
+		t.Fatalf("Failed to acquire lock: %v", err)
+	}
+	defer issueLock.Release()
+	if err := os.MkdirAll(filepath.Join(repoDir, ".ccw", "logs"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(repoDir, ".ccw", "logs", "ccw.log"), []byte("log\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	app := newInPlaceApp(false)
+	if err := app.setupInPlaceCheckout(repoDir, "", 7, "owner", "repo", ""); err != nil {
+		t.Fatalf("Expected the lock and logs not to make the tree dirty, got %v", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(repoDir, "README.md"), []byte("changed\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := app.gitOps.CommitChanges(repoDir, "docs: update readme"); err != nil {
+		t.Fatalf("CommitChanges failed: %v", err)
+	}
+
+	if files := runGit("show", "--name-only", "--format=", "HEAD"); files != "README.md" {
+		t.Errorf("Expected only README.md in the commit, got:\n%s", files)
+	}
+
+	// Setup again with --allow-dirty does not list the exclusion twice
+	if err := newInPlaceApp(true).setupInPlaceCheckout(repoDir, "", 7, "owner", "repo", ""); err != nil {
+		t.Fatal(err)
+	}
+	exclude, err := os.ReadFile(filepath.Join(repoDir, ".git", "info", "exclude"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Count(string(exclude), git.RuntimeStateExclude+"\n") != 1 {
+		t.Errorf("Expected one %s exclusion, got:\n%s", git.RuntimeStateExclude, exclude)
+	}
+}
+
+func TestInPlaceClaudeSettingsAreNeverCommitted(t *testing.T) {
+	repoDir, runGit := setupInPlaceRepo(t, "feature/ci")
+	app := newInPlaceApp(false)
+	if err := app.setupInPlaceCheckout(repoDir, "", 7, "owner", "repo", ""); err != nil {
+		t.Fatal(err)
+	}
+
+	written, err := app.setupInPlaceClaudePermissions(repoDir)
+	if err != nil || !written {
+		t.Fatalf("Expected the permissions file to be written, got %v, %v", written, err)
+	}
+	if err := os.WriteFile(filepath.Join(repoDir, "README.md"), []byte("changed\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := app.gitOps.CommitChanges(repoDir, "docs: update readme"); err != nil {
+		t.Fatalf("CommitChanges failed: %v", err)
+	}
+
+	if files := runGit("show", "--name-only", "--format=", "HEAD"); files != "README.md" {
+		t.Errorf("Expected no .claude/ changes in the commit, got:\n%s", files)
+	}
+}
+
+func TestInPlaceKeepsExistingClaudeSettings(t *testing.T) {
+	repoDir, _ := setupInPlaceRepo(t, "feature/ci")
+	settingsPath := filepath.Join(repoDir, ".claude", "settings.local.json")
+	if err := os.MkdirAll(filepath.Dir(settingsPath), 0755); err != nil {
+		t.Fatal(err)
+	}
+	userSettings := `{"permissions":{"allow":["Bash(go test:*)"]}}`
+	if err := os.WriteFile(settingsPath, []byte(userSettings), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	written, err := newInPlaceApp(false).setupInPlaceClaudePermissions(repoDir)
+	if err != nil || written {
+		t.Fatalf("Expected the existing file to be kept, got %v, %v", written, err)
+	}
+	data, err := os.ReadFile(settingsPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != userSettings {
+		t.Errorf("Expected the user's settings to be left alone, got:\n%s", data)
+	}
+}
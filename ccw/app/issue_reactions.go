@@ -0,0 +1,33 @@
+package app
+
+import (
+	"sort"
+
+	"ccw/types"
+)
+
+// Ranking listed issues by community demand
+
+// ListRanking controls how fetched issues are ordered and trimmed by reactions
+type ListRanking struct {
+	SortByReactions bool // Most 👍 reactions first; ties keep GitHub's order
+	MinReactions    int  // Drop issues with fewer 👍 reactions
+}
+
+// rankIssues applies ranking to issues, returning a new slice
+func rankIssues(issues []*types.Issue, ranking ListRanking) []*types.Issue {
+	ranked := make([]*types.Issue, 0, len(issues))
+	for _, issue := range issues {
+		if issue.ThumbsUp() >= ranking.MinReactions {
+			ranked = append(ranked, issue)
+		}
+	}
+
+	if ranking.SortByReactions {
+		sort.SliceStable(ranked, func(i, j int) bool {
+			return ranked[i].ThumbsUp() > ranked[j].ThumbsUp()
+		})
+	}
+
+	return ranked
+}
@@ -0,0 +1,48 @@
+package app
+
+import (
+	"reflect"
+	"testing"
+
+	"ccw/types"
+)
+
+func TestRankIssues(t *testing.T) {
+	withThumbs := func(number, thumbsUp int) *types.Issue {
+		return &types.Issue{Number: number, Reactions: &types.Reactions{ThumbsUp: thumbsUp, TotalCount: thumbsUp}}
+	}
+	issues := []*types.Issue{
+		withThumbs(1, 2),
+		{Number: 2}, // reactions not fetched
+		withThumbs(3, 7),
+		withThumbs(4, 2),
+		withThumbs(5, 0),
+	}
+
+	tests := []struct {
+		name     string
+		ranking  ListRanking
+		expected []int
+	}{
+		{"no ranking keeps order", ListRanking{}, []int{1, 2, 3, 4, 5}},
+		{"sort by reactions is stable", ListRanking{SortByReactions: true}, []int{3, 1, 4, 2, 5}},
+		{"minimum reactions", ListRanking{MinReactions: 2}, []int{1, 3, 4}},
+		{"sort and minimum", ListRanking{SortByReactions: true, MinReactions: 3}, []int{3}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			numbers := []int{}
+			for _, issue := range rankIssues(issues, tt.ranking) {
+				numbers = append(numbers, issue.Number)
+			}
+			if !reflect.DeepEqual(numbers, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, numbers)
+			}
+		})
+	}
+
+	if issues[0].Number != 1 || issues[2].Number != 3 {
+		t.Error("Expected the input slice to be left unchanged")
+	}
+}
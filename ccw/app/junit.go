@@ -0,0 +1,23 @@
+package app
+
+import (
+	"fmt"
+
+	"ccw/git"
+)
+
+// exportJUnit writes result as JUnit XML to validation.junit_out, when set.
+// Each validation overwrites the file, so it ends up holding the final result.
+func (app *CCWApp) exportJUnit(result *git.ValidationResult) {
+	if app.ccwConfig == nil || app.ccwConfig.Validation.JUnitOut == "" || result == nil {
+		return
+	}
+	path := app.ccwConfig.Validation.JUnitOut
+	if err := git.ExportJUnit(result, path); err != nil {
+		app.ui.Warning(fmt.Sprintf("Failed to export JUnit results: %v", err))
+		return
+	}
+	app.logger.Info("validation", "Exported JUnit results", map[string]interface{}{
+		"path": path,
+	})
+}
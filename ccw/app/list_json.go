@@ -0,0 +1,86 @@
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"ccw/github"
+	"ccw/types"
+)
+
+// Printing the issue list as JSON for scripts
+
+// issueLister fetches the issues of a repository, like GitHubClient.ListIssues
+type issueLister func(owner, repo string, state string, labels []string, limit int) ([]*types.Issue, error)
+
+// ExecuteListJSON prints the issues matching the list filters as a JSON array
+// instead of showing the interactive selector. A non-empty search is passed to
+// GitHub's issue search.
+func (app *CCWApp) ExecuteListJSON(repoURL string, state string, labels []string, search string, limit int, ranking ListRanking) error {
+	app.ui.SetQuiet(true)
+	return listIssuesJSON(os.Stdout, repoURL, state, labels, limit, ranking, app.listerFor(search))
+}
+
+// listIssuesJSON fetches the issues of repoURL, applies the filters and ranking
+// and writes them to w as indented JSON. No matches produce an empty array.
+func listIssuesJSON(w io.Writer, repoURL string, state string, labels []string, limit int, ranking ListRanking, list issueLister) error {
+	owner, repo, err := github.ExtractRepoInfo(repoURL)
+	if err != nil {
+		return fmt.Errorf("failed to extract repository info: %w", err)
+	}
+
+	issues, err := list(owner, repo, state, labels, limit)
+	if err != nil {
+		return fmt.Errorf("failed to fetch issues: %w", err)
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(filterListedIssues(rankIssues(issues, ranking), state, labels, limit)); err != nil {
+		return fmt.Errorf("failed to encode issues: %w", err)
+	}
+	return nil
+}
+
+// filterListedIssues keeps the issues in state (unless "all") carrying every
+// label in labels, matched case-insensitively, up to limit issues. The API
+// applies the same filters; repeating them keeps the output exact.
+func filterListedIssues(issues []*types.Issue, state string, labels []string, limit int) []*types.Issue {
+	filtered := []*types.Issue{}
+	for _, issue := range issues {
+		if limit > 0 && len(filtered) >= limit {
+			break
+		}
+		if state != "" && state != "all" && !strings.EqualFold(issue.State, state) {
+			continue
+		}
+		if !hasAllLabels(issue, labels) {
+			continue
+		}
+		filtered = append(filtered, issue)
+	}
+	return filtered
+}
+
+// hasAllLabels reports whether issue carries every non-blank label in labels
+func hasAllLabels(issue *types.Issue, labels []string) bool {
+	for _, want := range labels {
+		if strings.TrimSpace(want) == "" {
+			continue
+		}
+		found := false
+		for _, label := range issue.Labels {
+			if strings.EqualFold(label.Name, strings.TrimSpace(want)) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
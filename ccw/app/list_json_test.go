@@ -0,0 +1,101 @@
+package app
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"ccw/types"
+)
+
+func TestFilterListedIssues(t *testing.T) {
+	issues := []*types.Issue{
+		{Number: 1, State: "open", Labels: []types.Label{{Name: "bug"}, {Name: "ui"}}},
+		{Number: 2, State: "closed", Labels: []types.Label{{Name: "bug"}}},
+		{Number: 3, State: "open", Labels: []types.Label{{Name: "Bug"}}},
+		{Number: 4, State: "open"},
+	}
+
+	tests := []struct {
+		name     string
+		state    string
+		labels   []string
+		limit    int
+		expected []int
+	}{
+		{"no filters", "all", nil, 0, []int{1, 2, 3, 4}},
+		{"state", "open", nil, 0, []int{1, 3, 4}},
+		{"label matched case-insensitively", "all", []string{"bug"}, 0, []int{1, 2, 3}},
+		{"every label required", "open", []string{"bug", "ui"}, 0, []int{1}},
+		{"limit", "open", nil, 2, []int{1, 3}},
+		{"no matches", "open", []string{"docs"}, 0, []int{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			numbers := []int{}
+			for _, issue := range filterListedIssues(issues, tt.state, tt.labels, tt.limit) {
+				numbers = append(numbers, issue.Number)
+			}
+			if !reflect.DeepEqual(numbers, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, numbers)
+			}
+		})
+	}
+}
+
+func TestListIssuesJSON(t *testing.T) {
+	var gotOwner, gotRepo, gotState string
+	var gotLabels []string
+	list := func(owner, repo string, state string, labels []string, limit int) ([]*types.Issue, error) {
+		gotOwner, gotRepo, gotState, gotLabels = owner, repo, state, labels
+		return []*types.Issue{
+			{Number: 7, Title: "Fix parser", State: "open", Labels: []types.Label{{Name: "bug"}}},
+			{Number: 8, Title: "Unlabeled", State: "open"},
+		}, nil
+	}
+
+	var out bytes.Buffer
+	if err := listIssuesJSON(&out, "owner/repo", "open", []string{"bug"}, 20, ListRanking{}, list); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if gotOwner != "owner" || gotRepo != "repo" || gotState != "open" || !reflect.DeepEqual(gotLabels, []string{"bug"}) {
+		t.Errorf("Expected filters to be passed to the lister, got %s/%s %s %v", gotOwner, gotRepo, gotState, gotLabels)
+	}
+
+	var decoded []*types.Issue
+	if err := json.Unmarshal(out.Bytes(), &decoded); err != nil {
+		t.Fatalf("Expected valid JSON, got %v: %s", err, out.String())
+	}
+	if len(decoded) != 1 || decoded[0].Number != 7 || decoded[0].Title != "Fix parser" {
+		t.Errorf("Expected only issue #7, got %+v", decoded)
+	}
+
+	// No matches print an empty array rather than null
+	out.Reset()
+	if err := listIssuesJSON(&out, "owner/repo", "open", []string{"docs"}, 20, ListRanking{}, list); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if strings.TrimSpace(out.String()) != "[]" {
+		t.Errorf("Expected '[]', got '%s'", strings.TrimSpace(out.String()))
+	}
+
+	failing := func(string, string, string, []string, int) ([]*types.Issue, error) {
+		return nil, fmt.Errorf("gh not authenticated")
+	}
+	if err := listIssuesJSON(&out, "owner/repo", "open", nil, 20, ListRanking{}, failing); err == nil {
+		t.Error("Expected fetch error to be returned")
+	}
+}
+
+func TestSearchState(t *testing.T) {
+	if state := searchState("open", false); state != "all" {
+		t.Errorf("Expected 'all' without --state, got '%s'", state)
+	}
+	if state := searchState("closed", true); state != "closed" {
+		t.Errorf("Expected 'closed' with --state, got '%s'", state)
+	}
+}
@@ -0,0 +1,25 @@
+package app
+
+import (
+	"ccw/types"
+)
+
+// listerFor returns how ccw list fetches issues: the REST listing, or a raw
+// GitHub search query given with --search
+func (app *CCWApp) listerFor(search string) issueLister {
+	if search == "" {
+		return app.githubClient.ListIssues
+	}
+	return func(owner, repo string, state string, labels []string, limit int) ([]*types.Issue, error) {
+		return app.githubClient.SearchIssues(owner, repo, state, labels, search, limit)
+	}
+}
+
+// searchState is the --state passed with --search: the given state, or all
+// when none was given so is: qualifiers in the query decide
+func searchState(state string, stateSet bool) string {
+	if stateSet {
+		return state
+	}
+	return "all"
+}
@@ -0,0 +1,100 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"ccw/consoleui"
+	"ccw/git"
+	"ccw/pr"
+)
+
+// rebaseOnConflict returns the configured workflow.done.rebase_on_conflict
+func (app *CCWApp) rebaseOnConflict() bool {
+	return app.ccwConfig != nil && app.ccwConfig.Workflow.Done.RebaseOnConflict
+}
+
+// conflictBlocker returns the definition-of-done reason for the PR's last
+// known conflict with its base branch, or "" when there is none
+func (app *CCWApp) conflictBlocker() string {
+	if app.mergeability == nil || !app.mergeability.HasConflicts() {
+		return ""
+	}
+	if app.mergeability.BaseRefName == "" {
+		return "the PR conflicts with its base branch"
+	}
+	return fmt.Sprintf("the PR conflicts with %s", app.mergeability.BaseRefName)
+}
+
+// handleMergeConflicts checks whether the PR conflicts with its base branch
+// and rebases onto it once per run when configured, or reports the conflict.
+// It returns true when a rebased branch was pushed and CI must be watched again.
+func (app *CCWApp) handleMergeConflicts(prURL string) bool {
+	if prURL == "" || app.worktreeConfig == nil {
+		return false
+	}
+
+	mergeability, err := app.prManager.GetMergeability(prURL)
+	if err != nil {
+		app.logger.Warn("workflow", "Failed to fetch PR mergeability", map[string]interface{}{
+			"pr_url": prURL,
+			"error":  err.Error(),
+		})
+		return false
+	}
+	app.mergeability = &mergeability
+
+	action, reason := pr.DecideConflictHandling(mergeability, app.rebaseOnConflict(), app.conflictRebased)
+	app.explain(reason)
+	if action == pr.ConflictNone {
+		return false
+	}
+
+	conflictIcon := consoleui.Char("⚔️", "[CONFLICT]")
+	worktreePath, branchName := app.worktreeConfig.WorktreePath, app.worktreeConfig.BranchName
+	if action == pr.ConflictReport {
+		app.reportMergeConflict(conflictIcon, mergeability.BaseRefName, nil)
+		return false
+	}
+
+	app.conflictRebased = true
+	app.ui.Info(fmt.Sprintf("%s PR conflicts with %s; rebasing %s onto it", conflictIcon, mergeability.BaseRefName, branchName))
+	if err := app.gitOps.RebaseOntoRemoteBase(worktreePath, mergeability.BaseRefName); err != nil {
+		app.logger.Warn("workflow", "Rebase onto PR base failed", map[string]interface{}{
+			"base":  mergeability.BaseRefName,
+			"error": err.Error(),
+		})
+		var conflict *git.RebaseConflictError
+		if errors.As(err, &conflict) {
+			app.reportMergeConflict(conflictIcon, mergeability.BaseRefName, conflict.Files)
+		} else {
+			app.ui.Warning(fmt.Sprintf("Could not rebase onto %s: %v", mergeability.BaseRefName, err))
+		}
+		return false
+	}
+	if err := app.gitOps.ForcePushBranch(worktreePath, branchName); err != nil {
+		app.ui.Warning(fmt.Sprintf("Rebased onto %s but could not push: %v", mergeability.BaseRefName, err))
+		return false
+	}
+
+	app.logger.Info("workflow", "Rebased PR branch onto base to resolve conflicts", map[string]interface{}{
+		"base":   mergeability.BaseRefName,
+		"branch": branchName,
+	})
+	app.ui.Success(fmt.Sprintf("Rebased onto %s and pushed; watching CI again", mergeability.BaseRefName))
+	app.mergeability = nil
+	return true
+}
+
+// reportMergeConflict tells the user which files conflict and how to resolve them
+func (app *CCWApp) reportMergeConflict(icon, base string, files []string) {
+	message := fmt.Sprintf("%s PR conflicts with %s and needs a manual rebase", icon, base)
+	if len(files) > 0 {
+		message += fmt.Sprintf(": %s", strings.Join(files, ", "))
+	}
+	app.ui.Warning(message)
+	if app.worktreeConfig != nil && base != "" {
+		app.ui.Info(fmt.Sprintf("  → cd %s && git fetch %s %s && git rebase %s/%s", app.worktreeConfig.WorktreePath, app.gitOps.BaseRemote(), base, app.gitOps.BaseRemote(), base))
+	}
+}
@@ -0,0 +1,80 @@
+package app
+
+import (
+	"fmt"
+	"time"
+)
+
+// Deleting branches of merged pull requests
+
+// mergePollInterval is how often the PR is checked while waiting for its merge
+const mergePollInterval = 15 * time.Second
+
+// mergeWaitSleep sleeps between merge checks; replaced in tests
+var mergeWaitSleep = time.Sleep
+
+// waitForMerge calls isMerged until it reports the PR merged or wait has
+// passed, checking every interval; a wait of 0 checks once
+func waitForMerge(isMerged func() (bool, error), wait, interval time.Duration) (bool, error) {
+	checks := 1
+	if wait > 0 && interval > 0 {
+		checks += int(wait / interval)
+	}
+	for check := 1; ; check++ {
+		merged, err := isMerged()
+		if err != nil || merged || check >= checks {
+			return merged, err
+		}
+		mergeWaitSleep(interval)
+	}
+}
+
+// shouldDeleteMergedBranch decides whether the PR branch may be deleted: the
+// option must be on, the PR merged, and the branch must not be a base branch
+func shouldDeleteMergedBranch(enabled, merged bool, branchName, defaultBranch string) bool {
+	if !enabled || !merged || branchName == "" {
+		return false
+	}
+	switch branchName {
+	case defaultBranch, "main", "master":
+		return false
+	}
+	return true
+}
+
+// deleteBranchIfMerged deletes the PR's remote branch when pr.delete_branch_after_merge
+// is set and the PR is merged, e.g. by auto-merge, within pr.merge_wait of CI
+// monitoring ending. The local worktree is removed by the usual cleanup afterwards.
+func (app *CCWApp) deleteBranchIfMerged(prURL, branchName, dir string) {
+	if app.ccwConfig == nil || !app.ccwConfig.PR.DeleteBranchAfterMerge {
+		return
+	}
+
+	wait, _ := time.ParseDuration(app.ccwConfig.PR.MergeWait)
+	if wait > 0 {
+		app.ui.Info(fmt.Sprintf("Waiting up to %s for the pull request to be merged...", wait))
+	}
+	merged, err := waitForMerge(func() (bool, error) {
+		return app.prManager.IsMerged(prURL)
+	}, wait, mergePollInterval)
+	if err != nil {
+		app.ui.Warning(fmt.Sprintf("Failed to check whether the PR is merged: %v", err))
+		return
+	}
+	if !shouldDeleteMergedBranch(true, merged, branchName, app.ccwConfig.Git.DefaultBranch) {
+		if !merged {
+			app.ui.Info(fmt.Sprintf("Pull request is not merged yet; keeping branch %s", branchName))
+		}
+		return
+	}
+
+	if err := app.gitOps.DeleteRemoteBranch(dir, branchName); err != nil {
+		app.ui.Warning(fmt.Sprintf("Failed to delete merged branch: %v", err))
+		return
+	}
+	app.ui.Success(fmt.Sprintf("Pull request merged; deleted remote branch %s", branchName))
+	app.logger.Info("pr", "Deleted branch of merged pull request", map[string]interface{}{
+		"pr_url": prURL,
+		"branch": branchName,
+	})
+}
@@ -0,0 +1,89 @@
+package app
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestShouldDeleteMergedBranch(t *testing.T) {
+	tests := []struct {
+		name          string
+		enabled       bool
+		merged        bool
+		branchName    string
+		defaultBranch string
+		expected      bool
+	}{
+		{"merged with option on", true, true, "issue-12-20240101-090000", "master", true},
+		{"option off", false, true, "issue-12-20240101-090000", "master", false},
+		{"not merged", true, false, "issue-12-20240101-090000", "master", false},
+		{"empty branch", true, true, "", "master", false},
+		{"configured default branch", true, true, "develop", "develop", false},
+		{"main", true, true, "main", "master", false},
+		{"master", true, true, "master", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := shouldDeleteMergedBranch(tt.enabled, tt.merged, tt.branchName, tt.defaultBranch); result != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestWaitForMergeNotYetMergedThenMerged(t *testing.T) {
+	originalSleep := mergeWaitSleep
+	defer func() { mergeWaitSleep = originalSleep }()
+	var sleeps []time.Duration
+	mergeWaitSleep = func(d time.Duration) { sleeps = append(sleeps, d) }
+
+	// Auto-merge lands on the third check, after CI monitoring has ended
+	checks := 0
+	merged, err := waitForMerge(func() (bool, error) {
+		checks++
+		return checks == 3, nil
+	}, time.Minute, 15*time.Second)
+	if err != nil || !merged {
+		t.Fatalf("Expected the later merge to be found, got %v, %v", merged, err)
+	}
+	if checks != 3 || len(sleeps) != 2 || sleeps[0] != 15*time.Second {
+		t.Errorf("Expected 3 checks 15s apart, got %d checks and sleeps %v", checks, sleeps)
+	}
+}
+
+func TestWaitForMergeGivesUpAfterWait(t *testing.T) {
+	originalSleep := mergeWaitSleep
+	defer func() { mergeWaitSleep = originalSleep }()
+	mergeWaitSleep = func(time.Duration) {}
+
+	checks := 0
+	merged, err := waitForMerge(func() (bool, error) {
+		checks++
+		return false, nil
+	}, time.Minute, 15*time.Second)
+	if err != nil || merged {
+		t.Fatalf("Expected an unmerged PR, got %v, %v", merged, err)
+	}
+	if checks != 5 {
+		t.Errorf("Expected a check now and every 15s for a minute, got %d checks", checks)
+	}
+
+	// Without a wait the PR is checked once
+	checks = 0
+	waitForMerge(func() (bool, error) { checks++; return false, nil }, 0, 15*time.Second)
+	if checks != 1 {
+		t.Errorf("Expected one check without pr.merge_wait, got %d", checks)
+	}
+}
+
+func TestWaitForMergeStopsOnError(t *testing.T) {
+	originalSleep := mergeWaitSleep
+	defer func() { mergeWaitSleep = originalSleep }()
+	mergeWaitSleep = func(time.Duration) { t.Error("Expected no wait after an error") }
+
+	if _, err := waitForMerge(func() (bool, error) { return false, errors.New("gh failed") }, time.Minute, time.Second); err == nil {
+		t.Error("Expected the error to be returned")
+	}
+}
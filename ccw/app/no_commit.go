@@ -0,0 +1,83 @@
+package app
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"ccw/consoleui"
+	"ccw/types"
+)
+
+// Stopping after validation so the user commits by hand (--no-commit)
+
+// noCommitOut receives the --no-commit summary; tests capture it
+var noCommitOut io.Writer = os.Stdout
+
+// noCommit reports whether --no-commit was given for this run
+func (app *CCWApp) noCommit() bool {
+	return app.options != nil && app.options.NoCommit
+}
+
+// finishValidatedImplementation runs the steps after a successful validation:
+// commit, then the PR workflow. With --no-commit it stops before committing
+// and prints a suggested commit message instead.
+func (app *CCWApp) finishValidatedImplementation(issue *types.Issue, validationResult *types.ValidationResult) error {
+	if app.noCommit() {
+		app.stopBeforeCommit(issue)
+		return nil
+	}
+
+	// Recovery commits its own changes, so only commit when that has not
+	// happened; what re-validation changed after the last recovery commit
+	// still has to be committed
+	if !app.implementationCommitted {
+		if err := app.commitChanges(issue); err != nil {
+			return err
+		}
+	} else if err := app.commitValidationFixes(issue); err != nil {
+		return err
+	}
+	app.recordCommittedChanges()
+	app.updateIssueTaskList(issue)
+
+	// Step 7: Execute async PR workflow after successful commit
+	// Convert back to git.ValidationResult for async workflow
+	gitValidationForAsync := convertTypesToGitValidationResult(validationResult)
+	return app.executeAsyncWorkflow(issue, gitValidationForAsync)
+}
+
+// stopBeforeCommit leaves the validated changes uncommitted in the worktree
+// and prints where they are with a commit message to start from
+func (app *CCWApp) stopBeforeCommit(issue *types.Issue) {
+	app.explain("--no-commit: stopping after validation; nothing was committed, pushed or opened as a PR")
+
+	message := fmt.Sprintf("feat: %s\n\nResolves #%d", issue.Title, issue.Number)
+	if app.commitGenerator != nil {
+		if generated, err := app.commitGenerator.GenerateEnhancedCommitMessage(app.worktreeConfig.WorktreePath, commitIssue(issue)); err == nil && strings.TrimSpace(generated) != "" {
+			message = generated
+		}
+	}
+	message = app.withCommitBodyTemplate(message, issue)
+
+	app.logger.Info("workflow", "Stopped before commit (--no-commit)", map[string]interface{}{
+		"worktree_path": app.worktreeConfig.WorktreePath,
+		"issue_number":  issue.Number,
+	})
+	app.ui.Success(fmt.Sprintf("%s Validation passed; changes left uncommitted (--no-commit)", consoleui.Char("✅", "[SUCCESS]")))
+	fmt.Fprintln(noCommitOut, noCommitSummary(app.worktreeConfig.WorktreePath, message))
+}
+
+// noCommitSummary tells the user where the uncommitted changes are and how to
+// commit them with the suggested message
+func noCommitSummary(worktreePath, message string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Worktree: %s\n\n", worktreePath)
+	b.WriteString("Suggested commit message:\n\n")
+	for _, line := range strings.Split(strings.TrimRight(message, "\n"), "\n") {
+		b.WriteString(strings.TrimRight("    "+line, " ") + "\n")
+	}
+	fmt.Fprintf(&b, "\nTo commit:\n    cd %s\n    git add -A && git commit\n", worktreePath)
+	return b.String()
+}
@@ -0,0 +1,82 @@
+package app
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"ccw/commit"
+	"ccw/git"
+	"ccw/logging"
+	"ccw/types"
+	"ccw/ui"
+)
+
+func TestParseWorkflowArgsNoCommit(t *testing.T) {
+	_, options, err := ParseWorkflowArgs([]string{"https://github.com/o/r/issues/1", "--no-commit"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !options.NoCommit {
+		t.Error("Expected --no-commit to set NoCommit")
+	}
+}
+
+func TestFinishValidatedImplementationStopsBeforeCommit(t *testing.T) {
+	repoDir, runGit := setupInPlaceRepo(t, "issue-3")
+	if err := os.WriteFile(filepath.Join(repoDir, "lexer.go"), []byte("package lexer\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	logger, err := logging.NewLogger("no-commit-test", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// No gitOps or PR manager: reaching the commit or PR steps would panic
+	app := &CCWApp{
+		ui:              ui.NewUIManager("default", false, false),
+		logger:          logger,
+		commitGenerator: &commit.CommitMessageGenerator{},
+		options:         &WorkflowOptions{NoCommit: true},
+		worktreeConfig:  &git.WorktreeConfig{WorktreePath: repoDir},
+	}
+
+	var out bytes.Buffer
+	noCommitOut = &out
+	defer func() { noCommitOut = os.Stdout }()
+
+	issue := &types.Issue{Number: 3, Title: "Add lexer"}
+	if err := app.finishValidatedImplementation(issue, &types.ValidationResult{Success: true}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if count := runGit("rev-list", "--count", "HEAD"); count != "1" {
+		t.Errorf("Expected no new commits, got %s commits", count)
+	}
+	if status := runGit("status", "--porcelain"); !strings.Contains(status, "lexer.go") {
+		t.Errorf("Expected lexer.go left uncommitted, got status '%s'", status)
+	}
+	for _, expected := range []string{"Worktree: " + repoDir, "Suggested commit message:", "Resolves #3"} {
+		if !strings.Contains(out.String(), expected) {
+			t.Errorf("Expected output to contain '%s', got:\n%s", expected, out.String())
+		}
+	}
+}
+
+func TestNoCommitSummary(t *testing.T) {
+	summary := noCommitSummary("/work/issue-3", "feat: add lexer\n\nResolves #3\n")
+
+	expected := []string{
+		"Worktree: /work/issue-3",
+		"Suggested commit message:\n\n    feat: add lexer\n\n    Resolves #3\n",
+		"cd /work/issue-3",
+		"git add -A && git commit",
+	}
+	for _, text := range expected {
+		if !strings.Contains(summary, text) {
+			t.Errorf("Expected summary to contain '%s', got:\n%s", text, summary)
+		}
+	}
+}
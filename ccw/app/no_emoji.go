@@ -0,0 +1,16 @@
+package app
+
+// ExtractNoEmojiFlag removes --no-emoji from args (the full os.Args) and
+// reports whether it was given
+func ExtractNoEmojiFlag(args []string) ([]string, bool) {
+	var rest []string
+	noEmoji := false
+	for i, arg := range args {
+		if i > 0 && arg == "--no-emoji" {
+			noEmoji = true
+			continue
+		}
+		rest = append(rest, arg)
+	}
+	return rest, noEmoji
+}
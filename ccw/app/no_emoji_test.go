@@ -0,0 +1,31 @@
+package app
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExtractNoEmojiFlag(t *testing.T) {
+	tests := []struct {
+		name            string
+		args            []string
+		expectedArgs    []string
+		expectedNoEmoji bool
+	}{
+		{"absent", []string{"ccw", "list"}, []string{"ccw", "list"}, false},
+		{"before command", []string{"ccw", "--no-emoji", "list", "--limit", "5"}, []string{"ccw", "list", "--limit", "5"}, true},
+		{"after issue url", []string{"ccw", "https://github.com/o/r/issues/1", "--no-emoji"}, []string{"ccw", "https://github.com/o/r/issues/1"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args, noEmoji := ExtractNoEmojiFlag(tt.args)
+			if !reflect.DeepEqual(args, tt.expectedArgs) {
+				t.Errorf("Expected args %v, got %v", tt.expectedArgs, args)
+			}
+			if noEmoji != tt.expectedNoEmoji {
+				t.Errorf("Expected noEmoji %v, got %v", tt.expectedNoEmoji, noEmoji)
+			}
+		})
+	}
+}
@@ -0,0 +1,186 @@
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"ccw/claude"
+	"ccw/config"
+	"ccw/git"
+	"ccw/github"
+	claudecli "ccw/pkg/claude"
+	"ccw/types"
+)
+
+// Attaching Claude Code interactively to an existing worktree
+
+// issueFetcher fetches an issue when a worktree has no saved issue data
+type issueFetcher func(owner, repo string, issueNumber int) (*types.Issue, error)
+
+// sessionLauncher starts an interactive Claude Code session in workdir with
+// contextContent written to .claude-context.md for the length of the session
+type sessionLauncher func(workdir, contextContent string) error
+
+// HandleOpenCommand resolves a worktree and launches Claude Code in it with the
+// issue context loaded, without running the automated pipeline
+func HandleOpenCommand() {
+	if len(os.Args) != 3 {
+		printOpenUsage()
+		os.Exit(1)
+	}
+
+	ccwConfig, err := config.LoadConfiguration()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to load configuration: %v\n", err)
+		os.Exit(1)
+	}
+
+	fetch := func(owner, repo string, issueNumber int) (*types.Issue, error) {
+		if _, err := configureGitHubAccess(ccwConfig); err != nil {
+			return nil, err
+		}
+		return (&github.GitHubClient{}).GetIssue(owner, repo, issueNumber)
+	}
+	timeout, _ := time.ParseDuration(ccwConfig.ClaudeTimeout)
+	launch := func(workdir, contextContent string) error {
+		return claudecli.NewClient(timeout).LaunchInteractive(workdir, contextContent)
+	}
+
+	if err := runOpen(os.Args[2], ccwConfig, fetch, launch); err != nil {
+		fmt.Fprintf(os.Stderr, "Open failed: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// runOpen resolves target to a worktree, rebuilds its Claude context and hands
+// the rendered markdown to launch
+func runOpen(target string, ccwConfig *config.CCWConfig, fetch issueFetcher, launch sessionLauncher) error {
+	worktreePath, err := resolveOpenWorktree(target, ccwConfig.WorktreeBase)
+	if err != nil {
+		return err
+	}
+	if err := git.NewOperations(ccwConfig.WorktreeBase, nil, nil).CheckWorktreeLinkage(worktreePath); err != nil {
+		return err
+	}
+
+	claudeCtx, err := loadOpenContext(worktreePath, ccwConfig.Git.MetadataDir, fetch)
+	if err != nil {
+		return err
+	}
+	claudeCtx.ProjectPath = projectSubdirPath(worktreePath, ccwConfig.Claude.Subdir)
+
+	prelude, err := claude.LoadPrelude(ccwConfig.Claude.Prelude)
+	if err != nil {
+		return fmt.Errorf("failed to load claude.prelude: %w", err)
+	}
+	integration := &claude.ClaudeIntegration{MaxContextChars: ccwConfig.Claude.MaxContextChars, Prelude: prelude}
+	contextContent, err := integration.MarkdownContext(claudeCtx)
+	if err != nil {
+		return fmt.Errorf("failed to generate markdown context: %w", err)
+	}
+
+	fmt.Printf("Opening Claude Code in %s for issue #%d: %s\n", worktreePath, claudeCtx.IssueData.Number, claudeCtx.IssueData.Title)
+	return launch(claudeCtx.ProjectPath, contextContent)
+}
+
+// resolveOpenWorktree returns the worktree named by target: an existing
+// directory, or an issue number ("123", "#123") or issue URL whose most recent
+// issue-<number>-<timestamp> worktree under worktreeBase is used
+func resolveOpenWorktree(target, worktreeBase string) (string, error) {
+	if info, err := os.Stat(target); err == nil && info.IsDir() {
+		return filepath.Abs(target)
+	}
+
+	issueNumber, err := parseOpenIssueNumber(target)
+	if err != nil {
+		return "", err
+	}
+
+	entries, err := os.ReadDir(worktreeBase)
+	if err != nil {
+		return "", fmt.Errorf("failed to read worktree base %s: %w", worktreeBase, err)
+	}
+
+	// Branch timestamps sort chronologically, so the last match is the newest
+	prefix := fmt.Sprintf("issue-%d-", issueNumber)
+	var matches []string
+	for _, entry := range entries {
+		if entry.IsDir() && strings.HasPrefix(entry.Name(), prefix) {
+			matches = append(matches, entry.Name())
+		}
+	}
+	if len(matches) == 0 {
+		return "", fmt.Errorf("no worktree for issue #%d in %s", issueNumber, worktreeBase)
+	}
+	sort.Strings(matches)
+
+	return filepath.Abs(filepath.Join(worktreeBase, matches[len(matches)-1]))
+}
+
+// parseOpenIssueNumber accepts "123", "#123" or a GitHub issue URL
+func parseOpenIssueNumber(target string) (int, error) {
+	if n, err := strconv.Atoi(strings.TrimPrefix(target, "#")); err == nil && n > 0 {
+		return n, nil
+	}
+	if _, _, n, err := github.ExtractIssueInfo(target); err == nil {
+		return n, nil
+	}
+	return 0, fmt.Errorf("%s is not a worktree directory, issue number or issue URL", target)
+}
+
+// loadOpenContext rebuilds the Claude context from the issue-data.json and
+// worktree-config.json files saved when the worktree was set up, looking in
+// metadataDir or, when it is empty, the worktree root. The issue is fetched
+// from GitHub when its data file is missing.
+func loadOpenContext(worktreePath, metadataDir string, fetch issueFetcher) (*types.ClaudeContext, error) {
+	worktreeConfig := &git.WorktreeConfig{}
+	if data, err := git.ReadMetadata(worktreePath, metadataDir, git.WorktreeConfigFile); err == nil {
+		if err := json.Unmarshal(data, worktreeConfig); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", git.WorktreeConfigFile, err)
+		}
+	}
+	if worktreeConfig.BranchName == "" {
+		output, err := git.CreateGitCommand([]string{"rev-parse", "--abbrev-ref", "HEAD"}, worktreePath).Output()
+		if err != nil {
+			return nil, fmt.Errorf("%s is not a git worktree: %w", worktreePath, err)
+		}
+		worktreeConfig.BranchName = strings.TrimSpace(string(output))
+	}
+	// The worktree may have been moved since its config was saved
+	worktreeConfig.WorktreePath = worktreePath
+
+	issue := &types.Issue{}
+	data, err := git.ReadMetadata(worktreePath, metadataDir, git.IssueDataFile)
+	switch {
+	case err == nil:
+		if err := json.Unmarshal(data, issue); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", git.IssueDataFile, err)
+		}
+	case worktreeConfig.Owner != "" && worktreeConfig.Repository != "" && worktreeConfig.IssueNumber > 0:
+		issue, err = fetch(worktreeConfig.Owner, worktreeConfig.Repository, worktreeConfig.IssueNumber)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch issue #%d: %w", worktreeConfig.IssueNumber, err)
+		}
+	default:
+		return nil, fmt.Errorf("no issue data found in %s; it was not created by ccw", worktreePath)
+	}
+
+	return &types.ClaudeContext{
+		IssueData:      issue,
+		WorktreeConfig: convertGitWorktreeConfigToTypes(worktreeConfig),
+		ProjectPath:    worktreePath,
+		TaskType:       "interactive",
+	}, nil
+}
+
+// printOpenUsage displays usage for the open command
+func printOpenUsage() {
+	fmt.Println("Usage: ccw open <issue-number|issue-url|worktree-path>")
+	fmt.Println("  Launch Claude Code interactively in an existing worktree with its issue context")
+}
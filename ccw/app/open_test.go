@@ -0,0 +1,220 @@
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"ccw/config"
+	"ccw/git"
+	"ccw/types"
+)
+
+// writeOpenWorktree creates a worktree directory with the data files ccw saves
+// during setup; a nil issue leaves .issue-data.json out
+func writeOpenWorktree(t *testing.T, dir string, worktreeConfig *git.WorktreeConfig, issue *types.Issue) {
+	t.Helper()
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	writeJSON := func(name string, v interface{}) {
+		data, err := json.Marshal(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, name), data, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeJSON(".worktree-config.json", worktreeConfig)
+	if issue != nil {
+		writeJSON(".issue-data.json", issue)
+	}
+}
+
+func TestResolveOpenWorktree(t *testing.T) {
+	base := t.TempDir()
+	for _, name := range []string{"issue-12-20240101-090000", "issue-12-20240301-090000", "issue-123-20240501-090000"} {
+		if err := os.MkdirAll(filepath.Join(base, name), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(base, "issue-7-20240101-090000"), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name        string
+		target      string
+		expected    string
+		expectError bool
+	}{
+		{"issue number picks newest worktree", "12", "issue-12-20240301-090000", false},
+		{"hash issue number", "#123", "issue-123-20240501-090000", false},
+		{"issue url", "https://github.com/o/r/issues/123", "issue-123-20240501-090000", false},
+		{"worktree path", filepath.Join(base, "issue-12-20240101-090000"), "issue-12-20240101-090000", false},
+		{"no worktree for issue", "99", "", true},
+		{"files are not worktrees", "7", "", true},
+		{"unrecognized target", "not-an-issue", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path, err := resolveOpenWorktree(tt.target, base)
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("Expected error, got %s", path)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if filepath.Base(path) != tt.expected || !filepath.IsAbs(path) {
+				t.Errorf("Expected absolute path to '%s', got '%s'", tt.expected, path)
+			}
+		})
+	}
+}
+
+func TestLoadOpenContextFromSavedData(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "issue-5-20240101-090000")
+	writeOpenWorktree(t, dir,
+		&git.WorktreeConfig{BranchName: "issue-5-20240101-090000", WorktreePath: "/old/location", IssueNumber: 5, Owner: "o", Repository: "r"},
+		&types.Issue{Number: 5, Title: "Saved title"})
+
+	fetch := func(owner, repo string, issueNumber int) (*types.Issue, error) {
+		t.Error("Expected saved issue data to be used without fetching")
+		return nil, fmt.Errorf("unexpected fetch")
+	}
+
+	ctx, err := loadOpenContext(dir, "", fetch)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if ctx.IssueData.Title != "Saved title" {
+		t.Errorf("Expected 'Saved title', got '%s'", ctx.IssueData.Title)
+	}
+	if ctx.ProjectPath != dir || ctx.WorktreeConfig.WorktreePath != dir {
+		t.Errorf("Expected project and worktree path '%s', got '%s' and '%s'", dir, ctx.ProjectPath, ctx.WorktreeConfig.WorktreePath)
+	}
+	if ctx.WorktreeConfig.BranchName != "issue-5-20240101-090000" {
+		t.Errorf("Expected saved branch name, got '%s'", ctx.WorktreeConfig.BranchName)
+	}
+	if ctx.TaskType != "interactive" {
+		t.Errorf("Expected task type 'interactive', got '%s'", ctx.TaskType)
+	}
+}
+
+func TestLoadOpenContextMigratesToMetadataDir(t *testing.T) {
+	dir := t.TempDir()
+	writeOpenWorktree(t, dir,
+		&git.WorktreeConfig{BranchName: "issue-5-x", IssueNumber: 5, Owner: "o", Repository: "r"},
+		&types.Issue{Number: 5, Title: "Saved title"})
+
+	fetch := func(owner, repo string, issueNumber int) (*types.Issue, error) {
+		t.Error("Expected legacy issue data to be used without fetching")
+		return nil, fmt.Errorf("unexpected fetch")
+	}
+
+	ctx, err := loadOpenContext(dir, ".ccw", fetch)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if ctx.IssueData.Title != "Saved title" || ctx.WorktreeConfig.BranchName != "issue-5-x" {
+		t.Errorf("Expected the legacy data, got '%s' on '%s'", ctx.IssueData.Title, ctx.WorktreeConfig.BranchName)
+	}
+	for _, name := range []string{git.IssueDataFile, git.WorktreeConfigFile} {
+		if _, err := os.Stat(filepath.Join(dir, ".ccw", name)); err != nil {
+			t.Errorf("Expected %s moved under .ccw: %v", name, err)
+		}
+	}
+
+	// A second load reads the migrated files
+	if ctx, err := loadOpenContext(dir, ".ccw", fetch); err != nil || ctx.IssueData.Title != "Saved title" {
+		t.Errorf("Expected the migrated data to load, got %v", err)
+	}
+}
+
+func TestLoadOpenContextFetchesMissingIssue(t *testing.T) {
+	dir := t.TempDir()
+	writeOpenWorktree(t, dir, &git.WorktreeConfig{BranchName: "issue-5-x", IssueNumber: 5, Owner: "o", Repository: "r"}, nil)
+
+	var fetched string
+	fetch := func(owner, repo string, issueNumber int) (*types.Issue, error) {
+		fetched = fmt.Sprintf("%s/%s#%d", owner, repo, issueNumber)
+		return &types.Issue{Number: issueNumber, Title: "Fetched title"}, nil
+	}
+
+	ctx, err := loadOpenContext(dir, "", fetch)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if fetched != "o/r#5" {
+		t.Errorf("Expected fetch of 'o/r#5', got '%s'", fetched)
+	}
+	if ctx.IssueData.Title != "Fetched title" {
+		t.Errorf("Expected 'Fetched title', got '%s'", ctx.IssueData.Title)
+	}
+
+	// Without repository details there is nothing to fetch
+	writeOpenWorktree(t, dir, &git.WorktreeConfig{BranchName: "issue-5-x"}, nil)
+	if _, err := loadOpenContext(dir, "", fetch); err == nil {
+		t.Error("Expected error for a worktree without issue data")
+	}
+}
+
+func TestRunOpenLaunchesWithContext(t *testing.T) {
+	base := t.TempDir()
+	dir := filepath.Join(base, "issue-8-20240101-090000")
+	writeOpenWorktree(t, dir,
+		&git.WorktreeConfig{BranchName: "issue-8-20240101-090000", IssueNumber: 8},
+		&types.Issue{Number: 8, Title: "Fix the widget", Body: "The widget is broken."})
+
+	ccwConfig := &config.CCWConfig{WorktreeBase: base}
+	var launchedDir, launchedContext string
+	launch := func(workdir, contextContent string) error {
+		launchedDir = workdir
+		launchedContext = contextContent
+		return nil
+	}
+
+	if err := runOpen("#8", ccwConfig, nil, launch); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if launchedDir != dir {
+		t.Errorf("Expected launch in '%s', got '%s'", dir, launchedDir)
+	}
+	for _, expected := range []string{"# Claude Code Context", "Fix the widget", "The widget is broken.", "issue-8-20240101-090000", "interactive"} {
+		if !strings.Contains(launchedContext, expected) {
+			t.Errorf("Expected context to contain '%s'", expected)
+		}
+	}
+
+	launchErr := fmt.Errorf("claude not installed")
+	if err := runOpen("8", ccwConfig, nil, func(string, string) error { return launchErr }); err != launchErr {
+		t.Errorf("Expected launch error to be returned, got %v", err)
+	}
+}
+
+func TestRunOpenRefusesDetachedWorktree(t *testing.T) {
+	base := t.TempDir()
+	dir := filepath.Join(base, "issue-9-20240101-090000")
+	writeOpenWorktree(t, dir, &git.WorktreeConfig{BranchName: "issue-9-20240101-090000", IssueNumber: 9}, &types.Issue{Number: 9})
+	gitDir := filepath.Join(base, "moved-repo", ".git", "worktrees", "issue-9")
+	if err := os.WriteFile(filepath.Join(dir, ".git"), []byte("gitdir: "+gitDir+"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	launch := func(string, string) error {
+		t.Error("Expected no launch for a detached worktree")
+		return nil
+	}
+	err := runOpen("9", &config.CCWConfig{WorktreeBase: base}, nil, launch)
+	if err == nil || !strings.Contains(err.Error(), "detached from its base repository") {
+		t.Errorf("Expected detached worktree error, got %v", err)
+	}
+}
@@ -0,0 +1,255 @@
+package app
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"ccw/claude"
+	"ccw/types"
+	"ccw/ui"
+)
+
+// WorkflowOptions holds per-run flags given alongside the issue URL
+type WorkflowOptions struct {
+	WaitLock       bool   // Wait for a concurrent run on the same issue instead of refusing
+	SummaryOut     string // Path for the post-run summary.md report
+	PRTemplate     bool   // Seed the PR body from the repository's pull request template
+	AllowProtected bool   // Commit changes to commit.protected_paths with a warning instead of refusing
+	Explain        bool   // Print the rationale behind workflow decisions
+	InPlace        bool   // Work in the current checkout instead of creating a worktree
+	AllowDirty     bool   // Let --in-place start from a tree with uncommitted changes
+	Quiet          bool   // Print only errors and a one-line result
+	NoCommit       bool   // Stop after validation, leaving the changes uncommitted
+
+	InteractiveReview bool // Choose and annotate the PR comments Claude addresses
+
+	ImplementationSummaryOut string // Path to also write the implementation summary to
+
+	Reviewers []string // PR reviewers for this run: logins or org/team slugs
+	Assignees []string // PR assignees for this run, added to github.auto_assign
+
+	ContextFiles []claude.ContextFile // Reference files from --context-file, read while parsing
+
+	SinceCommit string // Lint only files changed since this ref, overriding validation.since
+
+	TraceToFile bool   // Enable function tracing into a dedicated trace file
+	TraceFile   string // Trace file path; empty means .ccw/trace-<session>.log
+
+	ProgressOut *os.File // Descriptor from --progress-fd, opened while parsing
+}
+
+// githubLoginPattern matches GitHub user logins
+var githubLoginPattern = regexp.MustCompile(`^[A-Za-z0-9](?:[A-Za-z0-9-]{0,38})$`)
+
+// parseUserList splits a comma-separated --reviewers/--assignees value into
+// GitHub logins. Teams (org/team) are accepted when allowTeams is set and
+// "@me" when it is not, matching what gh pr create accepts for each flag.
+func parseUserList(flag, value string, allowTeams bool) ([]string, error) {
+	var users []string
+	seen := make(map[string]bool)
+	for _, entry := range strings.Split(value, ",") {
+		user := strings.TrimPrefix(strings.TrimSpace(entry), "@")
+		if user == "" {
+			continue
+		}
+		if user == "me" && !allowTeams {
+			user = "@me"
+		} else if owner, team, isTeam := strings.Cut(user, "/"); isTeam {
+			if !allowTeams || !githubLoginPattern.MatchString(owner) || team == "" || strings.ContainsAny(team, "/ ") {
+				return nil, fmt.Errorf("%s: invalid team %q", flag, entry)
+			}
+		} else if !githubLoginPattern.MatchString(user) {
+			return nil, fmt.Errorf("%s: invalid GitHub login %q", flag, entry)
+		}
+
+		if key := strings.ToLower(user); !seen[key] {
+			seen[key] = true
+			users = append(users, user)
+		}
+	}
+	if len(users) == 0 {
+		return nil, fmt.Errorf("%s requires at least one name", flag)
+	}
+	return users, nil
+}
+
+// openProgressFD opens an inherited file descriptor for --progress-fd. Standard
+// input, output and error are refused so events never mix with the terminal UI.
+func openProgressFD(value string) (*os.File, error) {
+	fd, err := strconv.Atoi(value)
+	if err != nil || fd < 0 {
+		return nil, fmt.Errorf("--progress-fd requires a file descriptor number, got %q", value)
+	}
+	if fd <= 2 {
+		return nil, fmt.Errorf("--progress-fd %d is a standard stream; use 3 or higher", fd)
+	}
+
+	file := os.NewFile(uintptr(fd), fmt.Sprintf("progress-fd-%d", fd))
+	if file == nil {
+		return nil, fmt.Errorf("--progress-fd %d is not a valid file descriptor", fd)
+	}
+	if _, err := file.Stat(); err != nil {
+		file.Close()
+		return nil, fmt.Errorf("--progress-fd %d is not open: %w", fd, err)
+	}
+	return file, nil
+}
+
+// ParseWorkflowArgs splits issue workflow arguments into the issue URL and options.
+// Flags may appear before or after the URL.
+func ParseWorkflowArgs(args []string) (string, *WorkflowOptions, error) {
+	options := &WorkflowOptions{}
+	issueURL := ""
+
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		switch {
+		case arg == "--wait-lock":
+			options.WaitLock = true
+		case arg == "--pr-template":
+			options.PRTemplate = true
+		case arg == "--allow-protected":
+			options.AllowProtected = true
+		case arg == "--explain":
+			options.Explain = true
+		case arg == "--in-place":
+			options.InPlace = true
+		case arg == "--allow-dirty":
+			options.AllowDirty = true
+		case arg == "--quiet":
+			options.Quiet = true
+		case arg == "--no-commit":
+			options.NoCommit = true
+		case arg == "--interactive-review":
+			options.InteractiveReview = true
+		case arg == "--summary-out":
+			if i+1 >= len(args) {
+				return "", nil, fmt.Errorf("--summary-out requires a path")
+			}
+			i++
+			options.SummaryOut = args[i]
+		case strings.HasPrefix(arg, "--summary-out="):
+			options.SummaryOut = strings.TrimPrefix(arg, "--summary-out=")
+		case arg == "--implementation-summary-out":
+			if i+1 >= len(args) {
+				return "", nil, fmt.Errorf("--implementation-summary-out requires a path")
+			}
+			i++
+			options.ImplementationSummaryOut = args[i]
+		case strings.HasPrefix(arg, "--implementation-summary-out="):
+			options.ImplementationSummaryOut = strings.TrimPrefix(arg, "--implementation-summary-out=")
+		case arg == "--reviewers", arg == "--assignees", strings.HasPrefix(arg, "--reviewers="), strings.HasPrefix(arg, "--assignees="):
+			flag, value, hasValue := strings.Cut(arg, "=")
+			if !hasValue {
+				if i+1 >= len(args) {
+					return "", nil, fmt.Errorf("%s requires a comma-separated list", flag)
+				}
+				i++
+				value = args[i]
+			}
+			users, err := parseUserList(flag, value, flag == "--reviewers")
+			if err != nil {
+				return "", nil, err
+			}
+			if flag == "--reviewers" {
+				options.Reviewers = append(options.Reviewers, users...)
+			} else {
+				options.Assignees = append(options.Assignees, users...)
+			}
+		case arg == "--context-file", strings.HasPrefix(arg, "--context-file="):
+			path, hasValue := strings.CutPrefix(arg, "--context-file=")
+			if !hasValue {
+				if i+1 >= len(args) {
+					return "", nil, fmt.Errorf("--context-file requires a path")
+				}
+				i++
+				path = args[i]
+			}
+			files, err := claude.LoadContextFiles([]string{path})
+			if err != nil {
+				return "", nil, err
+			}
+			options.ContextFiles = append(options.ContextFiles, files...)
+		case arg == "--since-commit", strings.HasPrefix(arg, "--since-commit="):
+			ref, hasValue := strings.CutPrefix(arg, "--since-commit=")
+			if !hasValue {
+				if i+1 >= len(args) {
+					return "", nil, fmt.Errorf("--since-commit requires a ref")
+				}
+				i++
+				ref = args[i]
+			}
+			if ref == "" || strings.HasPrefix(ref, "-") {
+				return "", nil, fmt.Errorf("--since-commit requires a ref, got %q", ref)
+			}
+			options.SinceCommit = ref
+		case arg == "--trace-file", strings.HasPrefix(arg, "--trace-file="):
+			// A bare flag picks the default path, so the path must be attached with =
+			path, hasValue := strings.CutPrefix(arg, "--trace-file=")
+			if hasValue && path == "" {
+				return "", nil, fmt.Errorf("--trace-file= requires a path")
+			}
+			options.TraceToFile = true
+			if hasValue {
+				options.TraceFile = path
+			}
+		case arg == "--progress-fd", strings.HasPrefix(arg, "--progress-fd="):
+			value, hasValue := strings.CutPrefix(arg, "--progress-fd=")
+			if !hasValue {
+				if i+1 >= len(args) {
+					return "", nil, fmt.Errorf("--progress-fd requires a file descriptor number")
+				}
+				i++
+				value = args[i]
+			}
+			file, err := openProgressFD(value)
+			if err != nil {
+				return "", nil, err
+			}
+			options.ProgressOut = file
+		case strings.HasPrefix(arg, "--"):
+			return "", nil, fmt.Errorf("unknown option %s", arg)
+		case issueURL == "":
+			issueURL = arg
+		default:
+			return "", nil, fmt.Errorf("unexpected argument %s", arg)
+		}
+	}
+
+	if issueURL == "" {
+		return "", nil, fmt.Errorf("an issue URL is required")
+	}
+	if options.AllowDirty && !options.InPlace {
+		return "", nil, fmt.Errorf("--allow-dirty requires --in-place")
+	}
+	if options.InteractiveReview && options.Quiet {
+		return "", nil, fmt.Errorf("--interactive-review cannot be combined with --quiet")
+	}
+
+	return issueURL, options, nil
+}
+
+// SetWorkflowOptions applies per-run options to the application
+func (app *CCWApp) SetWorkflowOptions(options *WorkflowOptions) {
+	if options == nil {
+		options = &WorkflowOptions{}
+	}
+	app.options = options
+	if app.claudeIntegration != nil {
+		app.claudeIntegration.ContextFiles = options.ContextFiles
+	}
+
+	if options.Quiet {
+		app.ui.SetQuiet(true)
+		app.logger.SetLevel(types.LogLevelError)
+	}
+	if options.TraceToFile {
+		app.applyTraceFile(options.TraceFile)
+	}
+	if options.ProgressOut != nil {
+		app.ui.SetProgressStream(ui.NewProgressStream(options.ProgressOut))
+	}
+}
@@ -0,0 +1,120 @@
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"testing"
+
+	"ccw/claude"
+)
+
+func TestParseWorkflowArgsContextFiles(t *testing.T) {
+	tmpDir := t.TempDir()
+	spec := filepath.Join(tmpDir, "spec.md")
+	design := filepath.Join(tmpDir, "design.md")
+	for path, content := range map[string]string{spec: "spec\n", design: "design\n"} {
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	_, options, err := ParseWorkflowArgs([]string{"--context-file", spec, "https://github.com/o/r/issues/1", "--context-file=" + design})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := []claude.ContextFile{{Path: spec, Content: "spec"}, {Path: design, Content: "design"}}
+	if !reflect.DeepEqual(options.ContextFiles, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, options.ContextFiles)
+	}
+
+	for name, args := range map[string][]string{
+		"missing value": {"https://github.com/o/r/issues/1", "--context-file"},
+		"missing file":  {"https://github.com/o/r/issues/1", "--context-file", filepath.Join(tmpDir, "missing.md")},
+	} {
+		if _, _, err := ParseWorkflowArgs(args); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestParseWorkflowArgsSinceCommit(t *testing.T) {
+	for _, args := range [][]string{
+		{"--since-commit", "origin/main", "https://github.com/o/r/issues/1"},
+		{"https://github.com/o/r/issues/1", "--since-commit=origin/main"},
+	} {
+		_, options, err := ParseWorkflowArgs(args)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if options.SinceCommit != "origin/main" {
+			t.Errorf("Expected 'origin/main', got '%s'", options.SinceCommit)
+		}
+	}
+
+	for name, args := range map[string][]string{
+		"missing value": {"https://github.com/o/r/issues/1", "--since-commit"},
+		"empty value":   {"https://github.com/o/r/issues/1", "--since-commit="},
+		"flag as value": {"--since-commit", "--quiet", "https://github.com/o/r/issues/1"},
+	} {
+		if _, _, err := ParseWorkflowArgs(args); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestParseWorkflowArgsInteractiveReview(t *testing.T) {
+	_, options, err := ParseWorkflowArgs([]string{"https://github.com/o/r/issues/1", "--interactive-review"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !options.InteractiveReview {
+		t.Error("Expected interactive review to be enabled")
+	}
+
+	if _, _, err := ParseWorkflowArgs([]string{"https://github.com/o/r/issues/1", "--interactive-review", "--quiet"}); err == nil {
+		t.Error("Expected --interactive-review with --quiet to be rejected")
+	}
+}
+
+func TestParseWorkflowArgsProgressFD(t *testing.T) {
+	for _, form := range []string{"separate", "equals"} {
+		r, w, err := os.Pipe()
+		if err != nil {
+			t.Fatal(err)
+		}
+		fd := strconv.Itoa(int(w.Fd()))
+		args := []string{"--progress-fd", fd, "https://github.com/o/r/issues/1"}
+		if form == "equals" {
+			args = []string{"https://github.com/o/r/issues/1", "--progress-fd=" + fd}
+		}
+
+		_, options, err := ParseWorkflowArgs(args)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", form, err)
+		}
+		if options.ProgressOut == nil || options.ProgressOut.Fd() != w.Fd() {
+			t.Errorf("%s: expected descriptor %s, got %v", form, fd, options.ProgressOut)
+		}
+
+		// ProgressOut and w share the descriptor; close both right away so
+		// neither finalizer later closes a number another test has reused
+		if options.ProgressOut != nil {
+			options.ProgressOut.Close()
+		}
+		w.Close()
+		r.Close()
+	}
+
+	for name, args := range map[string][]string{
+		"missing value":   {"https://github.com/o/r/issues/1", "--progress-fd"},
+		"not a number":    {"https://github.com/o/r/issues/1", "--progress-fd=three"},
+		"standard stream": {"https://github.com/o/r/issues/1", "--progress-fd", "1"},
+		"closed":          {"https://github.com/o/r/issues/1", "--progress-fd", "987"},
+	} {
+		if _, _, err := ParseWorkflowArgs(args); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
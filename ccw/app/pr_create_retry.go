@@ -0,0 +1,66 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"ccw/consoleui"
+	"ccw/pr"
+	"ccw/types"
+)
+
+// Retrying PR creation through GitHub secondary rate limits
+
+// prCreationTimeout bounds each gh pr create attempt
+const prCreationTimeout = 1 * time.Minute
+
+// errPRCreationTimedOut is returned when an attempt exceeds prCreationTimeout
+var errPRCreationTimedOut = errors.New("PR creation timed out")
+
+// prRetryWait sleeps between rate-limited attempts; replaced in tests
+var prRetryWait = time.Sleep
+
+// createPullRequestWithRetry creates the PR, backing off and retrying while
+// GitHub reports a secondary rate limit
+func (app *CCWApp) createPullRequestWithRetry(prRequest *types.PRRequest, worktreePath string) (*types.PullRequest, error) {
+	create := func() (*types.PullRequest, error) {
+		select {
+		case prResult := <-app.prManager.CreatePullRequestAsync(prRequest, worktreePath):
+			return prResult.PullRequest, prResult.Error
+		case <-time.After(prCreationTimeout):
+			return nil, errPRCreationTimedOut
+		}
+	}
+
+	return createWithRateLimitRetry(create, func(attempt int, delay time.Duration) {
+		waitIcon := consoleui.Char("⏳", "[RATE LIMITED]")
+		app.ui.Warning(fmt.Sprintf("%s GitHub secondary rate limit hit while creating the PR; this is temporary. Retrying in %s (retry %d/%d)...",
+			waitIcon, delay, attempt, pr.MaxRateLimitRetries))
+		app.logger.Warn("pr", "PR creation rate limited, backing off", map[string]interface{}{
+			"attempt": attempt,
+			"delay":   delay.String(),
+		})
+	})
+}
+
+// createWithRateLimitRetry calls create until it succeeds or fails for a
+// reason pr.RateLimitBackoff does not retry, calling onRetry before each wait
+func createWithRateLimitRetry(create func() (*types.PullRequest, error), onRetry func(attempt int, delay time.Duration)) (*types.PullRequest, error) {
+	for attempt := 1; ; attempt++ {
+		pullRequest, err := create()
+		if err == nil {
+			return pullRequest, nil
+		}
+
+		delay, retry := pr.RateLimitBackoff(err, attempt)
+		if !retry {
+			if pr.IsSecondaryRateLimit(err) {
+				return nil, fmt.Errorf("GitHub secondary rate limit still in effect after %d attempt(s); wait a few minutes and retry: %w", attempt, err)
+			}
+			return nil, err
+		}
+		onRetry(attempt, delay)
+		prRetryWait(delay)
+	}
+}
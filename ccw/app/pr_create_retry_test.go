@@ -0,0 +1,73 @@
+package app
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"ccw/types"
+)
+
+func TestCreateWithRateLimitRetry(t *testing.T) {
+	originalWait := prRetryWait
+	defer func() { prRetryWait = originalWait }()
+
+	limited := errors.New("GraphQL: was submitted too quickly (createPullRequest)")
+
+	t.Run("retries through a secondary rate limit", func(t *testing.T) {
+		var waits []time.Duration
+		prRetryWait = func(delay time.Duration) { waits = append(waits, delay) }
+
+		calls := 0
+		pullRequest, err := createWithRateLimitRetry(func() (*types.PullRequest, error) {
+			calls++
+			if calls < 3 {
+				return nil, limited
+			}
+			return &types.PullRequest{HTMLURL: "https://github.com/owner/repo/pull/1"}, nil
+		}, func(int, time.Duration) {})
+
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if pullRequest.HTMLURL != "https://github.com/owner/repo/pull/1" {
+			t.Errorf("Expected the created PR, got %+v", pullRequest)
+		}
+		if len(waits) != 2 || waits[0] != time.Minute || waits[1] != 2*time.Minute {
+			t.Errorf("Expected backoff waits [1m 2m], got %v", waits)
+		}
+	})
+
+	t.Run("gives up after the retry cap", func(t *testing.T) {
+		prRetryWait = func(time.Duration) {}
+
+		calls := 0
+		_, err := createWithRateLimitRetry(func() (*types.PullRequest, error) {
+			calls++
+			return nil, limited
+		}, func(int, time.Duration) {})
+
+		if calls != 4 {
+			t.Errorf("Expected 4 attempts, got %d", calls)
+		}
+		if err == nil || !strings.Contains(err.Error(), "still in effect after 4 attempt(s)") || !errors.Is(err, limited) {
+			t.Errorf("Expected a rate limit error wrapping the last failure, got %v", err)
+		}
+	})
+
+	t.Run("hard failures are not retried", func(t *testing.T) {
+		prRetryWait = func(time.Duration) { t.Error("Expected no wait for a hard failure") }
+
+		hardFailure := errors.New("pull request already exists")
+		calls := 0
+		_, err := createWithRateLimitRetry(func() (*types.PullRequest, error) {
+			calls++
+			return nil, hardFailure
+		}, func(int, time.Duration) {})
+
+		if calls != 1 || err != hardFailure {
+			t.Errorf("Expected one attempt returning the failure, got %d attempts and %v", calls, err)
+		}
+	})
+}
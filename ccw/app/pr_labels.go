@@ -0,0 +1,35 @@
+package app
+
+import (
+	"fmt"
+
+	"ccw/pr"
+	"ccw/types"
+)
+
+// applyPRLabels adds github.copy_issue_labels present on the issue and
+// github.default_labels to the new PR. Labelling is best effort: a failure is
+// reported as a warning and the workflow continues.
+func (app *CCWApp) applyPRLabels(issue *types.Issue, prURL string) {
+	if app.ccwConfig == nil {
+		return
+	}
+
+	var issueLabels []types.Label
+	if issue != nil {
+		issueLabels = issue.Labels
+	}
+	labels := pr.ResolvePRLabels(issueLabels, app.ccwConfig.GitHub.CopyIssueLabels, app.ccwConfig.GitHub.DefaultLabels)
+	if len(labels) == 0 {
+		return
+	}
+
+	if err := app.prManager.AddLabels(prURL, labels); err != nil {
+		app.ui.Warning(fmt.Sprintf("Failed to label pull request: %v", err))
+		return
+	}
+	app.logger.Info("pr", "Added labels to pull request", map[string]interface{}{
+		"pr_url": prURL,
+		"labels": labels,
+	})
+}
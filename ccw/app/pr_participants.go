@@ -0,0 +1,30 @@
+package app
+
+import (
+	"ccw/commit"
+	"ccw/types"
+)
+
+// prParticipants returns the reviewers and assignees for the PR: those given
+// with --reviewers/--assignees, plus "@me" when github.auto_assign is set
+func prParticipants(options *WorkflowOptions, autoAssign bool) (reviewers, assignees []string) {
+	if autoAssign {
+		assignees = append(assignees, "@me")
+	}
+	if options == nil {
+		return nil, assignees
+	}
+
+	for _, assignee := range options.Assignees {
+		if !commit.ContainsFold(assignees, assignee) {
+			assignees = append(assignees, assignee)
+		}
+	}
+	return options.Reviewers, assignees
+}
+
+// applyPRParticipants sets the reviewers and assignees of the PR request
+func (app *CCWApp) applyPRParticipants(req *types.PRRequest) {
+	autoAssign := app.ccwConfig != nil && app.ccwConfig.GitHub.AutoAssign
+	req.Reviewers, req.Assignees = prParticipants(app.options, autoAssign)
+}
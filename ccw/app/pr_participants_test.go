@@ -0,0 +1,83 @@
+package app
+
+import (
+	"reflect"
+	"testing"
+
+	"ccw/config"
+	"ccw/types"
+)
+
+func TestParseWorkflowArgsReviewersAndAssignees(t *testing.T) {
+	tests := []struct {
+		name              string
+		args              []string
+		expectedReviewers []string
+		expectedAssignees []string
+		expectError       bool
+	}{
+		{"separate values", []string{"https://github.com/o/r/issues/1", "--reviewers", "alice,bob", "--assignees", "carol"}, []string{"alice", "bob"}, []string{"carol"}, false},
+		{"equals values", []string{"--reviewers=alice, my-org/core", "--assignees=@me", "https://github.com/o/r/issues/1"}, []string{"alice", "my-org/core"}, []string{"@me"}, false},
+		{"repeated flags and duplicates", []string{"https://github.com/o/r/issues/1", "--reviewers", "alice,,Alice", "--reviewers", "bob"}, []string{"alice", "bob"}, nil, false},
+		{"leading at sign", []string{"https://github.com/o/r/issues/1", "--assignees", "@carol"}, nil, []string{"carol"}, false},
+		{"missing value", []string{"https://github.com/o/r/issues/1", "--reviewers"}, nil, nil, true},
+		{"empty list", []string{"https://github.com/o/r/issues/1", "--assignees", " , "}, nil, nil, true},
+		{"invalid login", []string{"https://github.com/o/r/issues/1", "--reviewers", "alice smith"}, nil, nil, true},
+		{"team as assignee", []string{"https://github.com/o/r/issues/1", "--assignees", "my-org/core"}, nil, nil, true},
+		{"login too long", []string{"https://github.com/o/r/issues/1", "--reviewers", "a123456789012345678901234567890123456789"}, nil, nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, options, err := ParseWorkflowArgs(tt.args)
+			if tt.expectError {
+				if err == nil {
+					t.Error("Expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(options.Reviewers, tt.expectedReviewers) {
+				t.Errorf("Expected reviewers %v, got %v", tt.expectedReviewers, options.Reviewers)
+			}
+			if !reflect.DeepEqual(options.Assignees, tt.expectedAssignees) {
+				t.Errorf("Expected assignees %v, got %v", tt.expectedAssignees, options.Assignees)
+			}
+		})
+	}
+}
+
+func TestApplyPRParticipants(t *testing.T) {
+	tests := []struct {
+		name              string
+		options           *WorkflowOptions
+		autoAssign        bool
+		expectedReviewers []string
+		expectedAssignees []string
+	}{
+		{"no options", nil, false, nil, nil},
+		{"auto assign only", nil, true, nil, []string{"@me"}},
+		{"flags", &WorkflowOptions{Reviewers: []string{"alice"}, Assignees: []string{"carol"}}, false, []string{"alice"}, []string{"carol"}},
+		{"flags augment auto assign", &WorkflowOptions{Assignees: []string{"carol", "@me"}}, true, nil, []string{"@me", "carol"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := &CCWApp{
+				ccwConfig: &config.CCWConfig{GitHub: config.GitHubConfiguration{AutoAssign: tt.autoAssign}},
+				options:   tt.options,
+			}
+			req := &types.PRRequest{Title: "T", Body: "B"}
+			app.applyPRParticipants(req)
+
+			if !reflect.DeepEqual(req.Reviewers, tt.expectedReviewers) {
+				t.Errorf("Expected reviewers %v, got %v", tt.expectedReviewers, req.Reviewers)
+			}
+			if !reflect.DeepEqual(req.Assignees, tt.expectedAssignees) {
+				t.Errorf("Expected assignees %v, got %v", tt.expectedAssignees, req.Assignees)
+			}
+		})
+	}
+}
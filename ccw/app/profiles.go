@@ -0,0 +1,131 @@
+package app
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"ccw/config"
+	"ccw/github"
+)
+
+// GitHub account profiles selected with --profile
+
+// ExtractProfileFlag removes --profile NAME or --profile=NAME from args (the
+// full os.Args) and returns the remaining arguments and the profile name
+func ExtractProfileFlag(args []string) ([]string, string, error) {
+	if len(args) == 0 {
+		return args, "", nil
+	}
+
+	rest := []string{args[0]}
+	profile := ""
+	for i := 1; i < len(args); i++ {
+		arg := args[i]
+		switch {
+		case arg == "--profile":
+			if i+1 >= len(args) || strings.HasPrefix(args[i+1], "--") {
+				return nil, "", fmt.Errorf("--profile requires a profile name")
+			}
+			i++
+			profile = args[i]
+		case strings.HasPrefix(arg, "--profile="):
+			profile = strings.TrimPrefix(arg, "--profile=")
+			if profile == "" {
+				return nil, "", fmt.Errorf("--profile requires a profile name")
+			}
+		default:
+			rest = append(rest, arg)
+		}
+	}
+	return rest, profile, nil
+}
+
+// configureGitHubAccess sets the gh path, host and token for every gh command in
+// this run. A selected profile's token settings take precedence over the
+// environment; profiles without them fall back to the github section.
+func configureGitHubAccess(ccwConfig *config.CCWConfig) (github.TokenSource, error) {
+	github.SetGHPath(ccwConfig.GitHub.GHPath)
+
+	name, profile, ok := ccwConfig.ActiveProfile()
+	if ok {
+		token, source, err := github.ResolveProfileToken(profile.TokenEnv, profile.TokenFile, profile.TokenCommand)
+		if err != nil {
+			return source, fmt.Errorf("failed to resolve GitHub token for profile %s from %s: %w", name, source, err)
+		}
+		github.SetHost(profile.Host)
+		if source != github.TokenSourceNone {
+			github.SetToken(token)
+			return source, nil
+		}
+	} else {
+		github.SetHost("")
+	}
+
+	// Resolve GitHub token for gh subprocesses (env > token_file > token_command)
+	token, source, err := github.ResolveToken(ccwConfig.GitHub.TokenFile, ccwConfig.GitHub.TokenCommand)
+	if err != nil {
+		return source, fmt.Errorf("failed to resolve GitHub token from %s: %w", source, err)
+	}
+	github.SetToken(token)
+	return source, nil
+}
+
+// profileTokenDescription describes where a profile's token comes from without revealing it
+func profileTokenDescription(profile config.ProfileConfiguration) string {
+	switch {
+	case profile.TokenEnv != "":
+		return "env " + profile.TokenEnv
+	case profile.TokenFile != "":
+		return "file " + profile.TokenFile
+	case profile.TokenCommand != "":
+		return "command"
+	default:
+		return "github section / environment"
+	}
+}
+
+// FormatProfiles renders the configured profiles, marking the active one with *
+func FormatProfiles(ccwConfig *config.CCWConfig) string {
+	names := ccwConfig.ProfileNames()
+	if len(names) == 0 {
+		return "No profiles configured. Add a profiles section to ccw.yaml.\n"
+	}
+
+	width := 0
+	for _, name := range names {
+		width = max(width, len(name))
+	}
+
+	var sb strings.Builder
+	for _, name := range names {
+		profile := ccwConfig.Profiles[name]
+		marker := " "
+		if name == ccwConfig.Profile {
+			marker = "*"
+		}
+		host := profile.Host
+		if host == "" {
+			host = "github.com"
+		}
+		sb.WriteString(fmt.Sprintf("%s %-*s  host: %s  token: %s\n", marker, width, name, host, profileTokenDescription(profile)))
+	}
+	return sb.String()
+}
+
+// HandleProfilesCommand implements `ccw profiles list`
+func HandleProfilesCommand() {
+	args := os.Args[2:]
+	if len(args) > 0 && args[0] != "list" {
+		fmt.Printf("Error: unknown profiles subcommand %s\n", args[0])
+		fmt.Println("Usage: ccw profiles list")
+		os.Exit(1)
+	}
+
+	ccwConfig, err := config.LoadConfiguration()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to load configuration: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Print(FormatProfiles(ccwConfig))
+}
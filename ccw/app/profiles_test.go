@@ -0,0 +1,146 @@
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"ccw/config"
+	"ccw/github"
+)
+
+func TestExtractProfileFlag(t *testing.T) {
+	tests := []struct {
+		name            string
+		args            []string
+		expectedArgs    []string
+		expectedProfile string
+		expectError     bool
+	}{
+		{"no profile", []string{"ccw", "list"}, []string{"ccw", "list"}, "", false},
+		{"separate value", []string{"ccw", "--profile", "work", "list", "--limit", "5"}, []string{"ccw", "list", "--limit", "5"}, "work", false},
+		{"equals form after url", []string{"ccw", "https://github.com/o/r/issues/1", "--profile=personal"}, []string{"ccw", "https://github.com/o/r/issues/1"}, "personal", false},
+		{"missing value", []string{"ccw", "list", "--profile"}, nil, "", true},
+		{"flag as value", []string{"ccw", "--profile", "--wait-lock"}, nil, "", true},
+		{"empty equals", []string{"ccw", "--profile="}, nil, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args, profile, err := ExtractProfileFlag(tt.args)
+			if tt.expectError {
+				if err == nil {
+					t.Error("Expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(args, tt.expectedArgs) {
+				t.Errorf("Expected args %v, got %v", tt.expectedArgs, args)
+			}
+			if profile != tt.expectedProfile {
+				t.Errorf("Expected profile '%s', got '%s'", tt.expectedProfile, profile)
+			}
+		})
+	}
+}
+
+// ghCommandEnv returns the GH_TOKEN, GH_ENTERPRISE_TOKEN and GH_HOST a gh
+// invocation would receive
+func ghCommandEnv() (string, string, string) {
+	token, enterpriseToken, host := "", "", ""
+	for _, entry := range github.NewGHCommand("api", "user").Env {
+		if value, ok := strings.CutPrefix(entry, "GH_TOKEN="); ok {
+			token = value
+		}
+		if value, ok := strings.CutPrefix(entry, "GH_ENTERPRISE_TOKEN="); ok {
+			enterpriseToken = value
+		}
+		if value, ok := strings.CutPrefix(entry, "GH_HOST="); ok {
+			host = value
+		}
+	}
+	return token, enterpriseToken, host
+}
+
+func TestConfigureGitHubAccessUsesSelectedProfile(t *testing.T) {
+	t.Setenv("GH_TOKEN", "ambient-token")
+	t.Setenv("GITHUB_TOKEN", "")
+	t.Setenv("GH_ENTERPRISE_TOKEN", "")
+	t.Setenv("WORK_GH_TOKEN", "work-token")
+	t.Cleanup(func() {
+		github.SetToken("")
+		github.SetHost("")
+		github.SetGHPath("")
+	})
+
+	personalTokenFile := filepath.Join(t.TempDir(), "personal-token")
+	if err := os.WriteFile(personalTokenFile, []byte("personal-token\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	profiles := map[string]config.ProfileConfiguration{
+		"work":     {Host: "github.example.com", TokenEnv: "WORK_GH_TOKEN"},
+		"personal": {TokenFile: personalTokenFile},
+		"hostonly": {Host: "ghe.internal"},
+		"dotcom":   {Host: "github.com", TokenEnv: "WORK_GH_TOKEN"},
+	}
+
+	tests := []struct {
+		name                    string
+		profile                 string
+		expectedToken           string
+		expectedEnterpriseToken string
+		expectedHost            string
+	}{
+		{"work profile", "work", "work-token", "work-token", "github.example.com"},
+		{"personal profile", "personal", "personal-token", "", ""},
+		{"profile without token falls back", "hostonly", "ambient-token", "ambient-token", "ghe.internal"},
+		{"github.com host", "dotcom", "work-token", "", "github.com"},
+		{"no profile selected", "", "ambient-token", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ccwConfig := config.GetDefaultCCWConfig()
+			ccwConfig.Profiles = profiles
+			ccwConfig.Profile = tt.profile
+
+			if _, err := configureGitHubAccess(ccwConfig); err != nil {
+				t.Fatalf("configureGitHubAccess failed: %v", err)
+			}
+			token, enterpriseToken, host := ghCommandEnv()
+			if token != tt.expectedToken {
+				t.Errorf("Expected GH_TOKEN '%s', got '%s'", tt.expectedToken, token)
+			}
+			if enterpriseToken != tt.expectedEnterpriseToken {
+				t.Errorf("Expected GH_ENTERPRISE_TOKEN '%s', got '%s'", tt.expectedEnterpriseToken, enterpriseToken)
+			}
+			if host != tt.expectedHost {
+				t.Errorf("Expected GH_HOST '%s', got '%s'", tt.expectedHost, host)
+			}
+		})
+	}
+}
+
+func TestFormatProfiles(t *testing.T) {
+	ccwConfig := config.GetDefaultCCWConfig()
+	if got := FormatProfiles(ccwConfig); !strings.Contains(got, "No profiles configured") {
+		t.Errorf("Expected empty message, got '%s'", got)
+	}
+
+	ccwConfig.Profile = "work"
+	ccwConfig.Profiles = map[string]config.ProfileConfiguration{
+		"work":     {Host: "github.example.com", TokenCommand: "security find-generic-password -w"},
+		"personal": {TokenEnv: "PERSONAL_GH_TOKEN"},
+	}
+	expected := "  personal  host: github.com  token: env PERSONAL_GH_TOKEN\n" +
+		"* work      host: github.example.com  token: command\n"
+	if got := FormatProfiles(ccwConfig); got != expected {
+		t.Errorf("Expected:\n%s\ngot:\n%s", expected, got)
+	}
+}
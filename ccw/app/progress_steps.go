@@ -0,0 +1,33 @@
+package app
+
+import (
+	"ccw/config"
+	"ccw/types"
+	"ccw/ui"
+)
+
+// progressStepsFromConfig builds the progress steps from workflow.steps.
+// Steps that match a built-in step inherit its name, description and
+// substeps unless overridden; other steps (hook phases, push) are named by ID.
+func progressStepsFromConfig(configured []config.WorkflowStepConfiguration) []types.WorkflowStep {
+	defaults := make(map[string]types.WorkflowStep)
+	for _, step := range ui.DefaultProgressSteps() {
+		defaults[step.ID] = step
+	}
+
+	steps := make([]types.WorkflowStep, 0, len(configured))
+	for _, c := range configured {
+		step, ok := defaults[c.ID]
+		if !ok {
+			step = types.WorkflowStep{ID: c.ID, Name: c.ID, Status: "pending"}
+		}
+		if c.Name != "" {
+			step.Name = c.Name
+		}
+		if c.Description != "" {
+			step.Description = c.Description
+		}
+		steps = append(steps, step)
+	}
+	return steps
+}
@@ -0,0 +1,64 @@
+package app
+
+import (
+	"testing"
+
+	"ccw/config"
+	"ccw/ui"
+)
+
+func TestProgressStepsFromConfig(t *testing.T) {
+	steps := progressStepsFromConfig([]config.WorkflowStepConfiguration{
+		{ID: "setup"},
+		{ID: "pre_implementation", Name: "Pre-implementation hooks"},
+		{ID: "validation", Name: "Checks"},
+		{ID: "push"},
+	})
+
+	if len(steps) != 4 {
+		t.Fatalf("Expected 4 steps, got %d", len(steps))
+	}
+	if steps[0].Name != "Setting up worktree" || steps[0].Description != "Creating isolated development environment" {
+		t.Errorf("Expected built-in setup name and description, got %+v", steps[0])
+	}
+	if steps[1].Name != "Pre-implementation hooks" || steps[1].Description != "" {
+		t.Errorf("Expected configured hook step name, got %+v", steps[1])
+	}
+	if steps[2].Name != "Checks" || steps[2].Description != "Running quality checks" || len(steps[2].Substeps) != 3 {
+		t.Errorf("Expected validation to keep its description and substeps, got %+v", steps[2])
+	}
+	if steps[3].Name != "push" {
+		t.Errorf("Expected step without built-in default to be named by ID, got '%s'", steps[3].Name)
+	}
+	for _, step := range steps {
+		if step.Status != "pending" {
+			t.Errorf("Expected step %s to be pending, got '%s'", step.ID, step.Status)
+		}
+	}
+}
+
+func TestDefaultProgressStepsAreKnownStepIDs(t *testing.T) {
+	known := make(map[string]bool)
+	for _, id := range config.WorkflowStepIDs {
+		known[id] = true
+	}
+	for _, step := range ui.DefaultProgressSteps() {
+		if !known[step.ID] {
+			t.Errorf("Built-in step %s is missing from config.WorkflowStepIDs", step.ID)
+		}
+	}
+}
+
+func TestSetProgressStepsFromConfig(t *testing.T) {
+	manager := ui.NewUIManager("default", false, false)
+	manager.SetProgressSteps(progressStepsFromConfig([]config.WorkflowStepConfiguration{{ID: "setup"}, {ID: "post_pr"}}))
+
+	if !manager.HasProgressStep("post_pr") || manager.HasProgressStep("fetch") {
+		t.Error("Expected only the configured steps to be tracked")
+	}
+	manager.UpdateProgress("post_pr", "completed")
+	steps := manager.ProgressSteps()
+	if len(steps) != 2 || steps[1].Status != "completed" {
+		t.Errorf("Expected post_pr step to be completed, got %+v", steps)
+	}
+}
@@ -0,0 +1,40 @@
+package app
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Claude working directory inside the worktree (claude.subdir)
+
+// projectSubdirPath returns worktreePath/subdir, or worktreePath when no
+// subdirectory is configured
+func projectSubdirPath(worktreePath, subdir string) string {
+	if subdir == "" {
+		return worktreePath
+	}
+	return filepath.Join(worktreePath, filepath.FromSlash(subdir))
+}
+
+// projectPath returns the directory Claude works in and validation runs in.
+// Git operations keep using the worktree root.
+func (app *CCWApp) projectPath() string {
+	var subdir string
+	if app.ccwConfig != nil {
+		subdir = app.ccwConfig.Claude.Subdir
+	}
+	return projectSubdirPath(app.worktreeConfig.WorktreePath, subdir)
+}
+
+// checkProjectPath reports a configured claude.subdir missing from the worktree
+func (app *CCWApp) checkProjectPath() error {
+	projectPath := app.projectPath()
+	if projectPath == app.worktreeConfig.WorktreePath {
+		return nil
+	}
+	if info, err := os.Stat(projectPath); err != nil || !info.IsDir() {
+		return fmt.Errorf("claude.subdir %s is not a directory in worktree %s", app.ccwConfig.Claude.Subdir, app.worktreeConfig.WorktreePath)
+	}
+	return nil
+}
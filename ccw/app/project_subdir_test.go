@@ -0,0 +1,81 @@
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"ccw/config"
+	"ccw/git"
+	"ccw/types"
+)
+
+func TestProjectSubdirPath(t *testing.T) {
+	tests := []struct {
+		name     string
+		subdir   string
+		expected string
+	}{
+		{"no subdir", "", "/work/issue-1"},
+		{"single directory", "parser", "/work/issue-1/parser"},
+		{"nested directory", "packages/parser/", "/work/issue-1/packages/parser"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := projectSubdirPath("/work/issue-1", tt.subdir); result != filepath.FromSlash(tt.expected) {
+				t.Errorf("Expected '%s', got '%s'", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestProjectPathUsesSubdirWhileGitUsesWorktreeRoot(t *testing.T) {
+	worktreePath := t.TempDir()
+	app := &CCWApp{
+		ccwConfig:      &config.CCWConfig{Claude: config.ClaudeConfiguration{Subdir: "packages/parser"}},
+		worktreeConfig: &git.WorktreeConfig{WorktreePath: worktreePath},
+	}
+
+	expected := filepath.Join(worktreePath, "packages", "parser")
+	if result := app.projectPath(); result != expected {
+		t.Errorf("Expected project path '%s', got '%s'", expected, result)
+	}
+	if app.worktreeConfig.WorktreePath != worktreePath {
+		t.Errorf("Expected git worktree path '%s', got '%s'", worktreePath, app.worktreeConfig.WorktreePath)
+	}
+	if err := app.checkProjectPath(); err == nil {
+		t.Error("Expected error for a missing subdir")
+	}
+
+	if err := os.MkdirAll(expected, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := app.checkProjectPath(); err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+
+	app.ccwConfig.Claude.Subdir = ""
+	if result := app.projectPath(); result != worktreePath {
+		t.Errorf("Expected worktree root without subdir, got '%s'", result)
+	}
+}
+
+func TestRunOpenLaunchesInSubdir(t *testing.T) {
+	base := t.TempDir()
+	dir := filepath.Join(base, "issue-4-20240101-090000")
+	writeOpenWorktree(t, dir, &git.WorktreeConfig{BranchName: "issue-4-20240101-090000", IssueNumber: 4}, &types.Issue{Number: 4, Title: "Parser"})
+
+	var launchedDir string
+	launch := func(workdir, contextContent string) error {
+		launchedDir = workdir
+		return nil
+	}
+	ccwConfig := &config.CCWConfig{WorktreeBase: base, Claude: config.ClaudeConfiguration{Subdir: "Sources"}}
+	if err := runOpen("4", ccwConfig, nil, launch); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if expected := filepath.Join(dir, "Sources"); launchedDir != expected {
+		t.Errorf("Expected launch in '%s', got '%s'", expected, launchedDir)
+	}
+}
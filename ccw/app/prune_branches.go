@@ -0,0 +1,209 @@
+package app
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"ccw/config"
+	"ccw/git"
+	"ccw/github"
+)
+
+// Deleting merged or stale CCW branches from the push remote
+
+// mergedPRLookupLimit is how many recently merged PRs are checked for branch names
+const mergedPRLookupLimit = 500
+
+// pruneBranchesOptions holds the parsed arguments of ccw prune-branches
+type pruneBranchesOptions struct {
+	Merged    bool
+	OlderThan time.Duration
+	Yes       bool // Delete without asking for confirmation
+	DryRun    bool // Only list the branches that would be deleted
+}
+
+// HandlePruneBranchesCommand deletes CCW branches on the push remote that are
+// merged or older than a threshold, after confirmation
+func HandlePruneBranchesCommand() {
+	options, err := parsePruneBranchesArgs(os.Args[2:])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n\n", err)
+		printPruneBranchesUsage()
+		os.Exit(1)
+	}
+
+	ccwConfig, err := config.LoadConfiguration()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to load configuration: %v\n", err)
+		os.Exit(1)
+	}
+
+	if err := runPruneBranches(options, ccwConfig, os.Stdin, os.Stdout); err != nil {
+		fmt.Fprintf(os.Stderr, "Prune failed: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// parsePruneBranchesArgs parses `ccw prune-branches [--merged] [--older-than AGE]
+// [--yes] [--dry-run]`. Without a selector, merged branches are pruned.
+func parsePruneBranchesArgs(args []string) (pruneBranchesOptions, error) {
+	var options pruneBranchesOptions
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		switch {
+		case arg == "--merged":
+			options.Merged = true
+		case arg == "--yes", arg == "-y":
+			options.Yes = true
+		case arg == "--dry-run":
+			options.DryRun = true
+		case arg == "--older-than" || strings.HasPrefix(arg, "--older-than="):
+			value, ok := strings.CutPrefix(arg, "--older-than=")
+			if !ok {
+				if i+1 >= len(args) {
+					return options, fmt.Errorf("--older-than requires an age such as 30d")
+				}
+				i++
+				value = args[i]
+			}
+			age, err := parseAgeThreshold(value)
+			if err != nil {
+				return options, err
+			}
+			options.OlderThan = age
+		default:
+			return options, fmt.Errorf("unknown argument %s", arg)
+		}
+	}
+	if !options.Merged && options.OlderThan == 0 {
+		options.Merged = true
+	}
+	return options, nil
+}
+
+// parseAgeThreshold parses an age as whole days ("30d") or a Go duration ("36h")
+func parseAgeThreshold(value string) (time.Duration, error) {
+	var age time.Duration
+	var err error
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		var n int
+		n, err = strconv.Atoi(days)
+		age = time.Duration(n) * 24 * time.Hour
+	} else {
+		age, err = time.ParseDuration(value)
+	}
+	if err != nil || age <= 0 {
+		return 0, fmt.Errorf("--older-than must be a positive age such as 30d or 36h, got %q", value)
+	}
+	return age, nil
+}
+
+// runPruneBranches selects the CCW branches to delete, confirms with the user
+// on in/out unless options.Yes, and deletes them from the push remote
+func runPruneBranches(options pruneBranchesOptions, ccwConfig *config.CCWConfig, in io.Reader, out io.Writer) error {
+	gitOps := git.NewOperations(ccwConfig.WorktreeBase, &git.GitOperationConfig{
+		PushRemote:    ccwConfig.Git.PushRemote,
+		RemoteName:    ccwConfig.Git.RemoteName,
+		DefaultBranch: ccwConfig.Git.DefaultBranch,
+	}, nil)
+	if err := gitOps.ValidatePushRemote("."); err != nil {
+		return err
+	}
+
+	branches, err := gitOps.ListRemoteBranches(".")
+	if err != nil {
+		return err
+	}
+
+	merged := map[string]bool{}
+	if options.Merged {
+		merged = mergedRemoteBranches(gitOps, out)
+	}
+
+	candidates := git.SelectBranchesToPrune(branches, merged, git.BranchPruneOptions{
+		Merged:    options.Merged,
+		OlderThan: options.OlderThan,
+		Now:       time.Now(),
+	})
+	if len(candidates) == 0 {
+		fmt.Fprintln(out, "No CCW branches to prune")
+		return nil
+	}
+
+	fmt.Fprintf(out, "CCW branches to delete from %s:\n", gitOps.PushRemote())
+	for _, candidate := range candidates {
+		fmt.Fprintf(out, "  %s (%s)\n", candidate.Branch.Name, candidate.Reason)
+	}
+	if options.DryRun {
+		return nil
+	}
+	if !options.Yes && !confirm(in, out, fmt.Sprintf("Delete %d branch(es)?", len(candidates))) {
+		fmt.Fprintln(out, "Aborted; no branches deleted")
+		return nil
+	}
+
+	var failed []string
+	for _, candidate := range candidates {
+		if err := gitOps.DeleteRemoteBranch(".", candidate.Branch.Name); err != nil {
+			fmt.Fprintf(out, "  failed: %v\n", err)
+			failed = append(failed, candidate.Branch.Name)
+			continue
+		}
+		fmt.Fprintf(out, "  deleted %s\n", candidate.Branch.Name)
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("failed to delete %d of %d branch(es): %s", len(failed), len(candidates), strings.Join(failed, ", "))
+	}
+	return nil
+}
+
+// mergedRemoteBranches combines the heads of merged PRs, which covers squash
+// merges, with branches git sees as merged into the default branch. Lookup
+// failures are reported and leave the other source in effect.
+func mergedRemoteBranches(gitOps *git.Operations, out io.Writer) map[string]bool {
+	merged := map[string]bool{}
+
+	if remoteURL, err := gitOps.RemoteURL(".", gitOps.PushRemote()); err == nil {
+		if owner, repo, err := github.ExtractRepoInfo(remoteURL); err == nil {
+			heads, err := (&github.GitHubClient{}).MergedPRBranches(owner, repo, mergedPRLookupLimit)
+			if err != nil {
+				fmt.Fprintf(out, "Warning: %v; using git merge status only\n", err)
+			}
+			for name := range heads {
+				merged[name] = true
+			}
+		}
+	}
+
+	gitMerged, err := gitOps.MergedRemoteBranches(".")
+	if err != nil {
+		fmt.Fprintf(out, "Warning: %v\n", err)
+	}
+	for name := range gitMerged {
+		merged[name] = true
+	}
+	return merged
+}
+
+// confirm asks question on out and reports whether the answer read from in is yes
+func confirm(in io.Reader, out io.Writer, question string) bool {
+	fmt.Fprintf(out, "%s [y/N]: ", question)
+	answer, _ := bufio.NewReader(in).ReadString('\n')
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return true
+	}
+	return false
+}
+
+// printPruneBranchesUsage displays usage for the prune-branches command
+func printPruneBranchesUsage() {
+	fmt.Println("Usage: ccw prune-branches [--merged] [--older-than AGE] [--yes] [--dry-run]")
+	fmt.Println("  Delete CCW issue branches from the push remote that are merged (default)")
+	fmt.Println("  or whose last commit is older than AGE (e.g. 30d, 36h)")
+}
@@ -0,0 +1,79 @@
+package app
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParsePruneBranchesArgs(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		expected pruneBranchesOptions
+		wantErr  bool
+	}{
+		{"defaults to merged", nil, pruneBranchesOptions{Merged: true}, false},
+		{"older than days", []string{"--older-than", "30d"}, pruneBranchesOptions{OlderThan: 30 * 24 * time.Hour}, false},
+		{"both selectors", []string{"--merged", "--older-than=36h", "--yes"}, pruneBranchesOptions{Merged: true, OlderThan: 36 * time.Hour, Yes: true}, false},
+		{"dry run", []string{"--dry-run"}, pruneBranchesOptions{Merged: true, DryRun: true}, false},
+		{"missing age", []string{"--older-than"}, pruneBranchesOptions{}, true},
+		{"invalid age", []string{"--older-than", "soon"}, pruneBranchesOptions{}, true},
+		{"unknown flag", []string{"--all"}, pruneBranchesOptions{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options, err := parsePruneBranchesArgs(tt.args)
+			if tt.wantErr {
+				if err == nil {
+					t.Error("Expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if options != tt.expected {
+				t.Errorf("Expected %+v, got %+v", tt.expected, options)
+			}
+		})
+	}
+}
+
+func TestParseAgeThreshold(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected time.Duration
+		wantErr  bool
+	}{
+		{"7d", 7 * 24 * time.Hour, false},
+		{"90m", 90 * time.Minute, false},
+		{"0d", 0, true},
+		{"-1h", 0, true},
+		{"d", 0, true},
+	}
+
+	for _, tt := range tests {
+		age, err := parseAgeThreshold(tt.value)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseAgeThreshold(%q): unexpected error state %v", tt.value, err)
+		}
+		if age != tt.expected {
+			t.Errorf("parseAgeThreshold(%q): expected %v, got %v", tt.value, tt.expected, age)
+		}
+	}
+}
+
+func TestConfirm(t *testing.T) {
+	for answer, expected := range map[string]bool{"y\n": true, "YES\n": true, "n\n": false, "\n": false, "": false} {
+		var out bytes.Buffer
+		if got := confirm(strings.NewReader(answer), &out, "Delete?"); got != expected {
+			t.Errorf("confirm(%q): expected %v, got %v", answer, expected, got)
+		}
+		if out.String() != "Delete? [y/N]: " {
+			t.Errorf("Unexpected prompt '%s'", out.String())
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package app
+
+import (
+	"fmt"
+
+	"ccw/github"
+	"ccw/pr"
+	"ccw/types"
+)
+
+// validatePushRemote fails early when git.push_remote is not a remote of the checkout
+func (app *CCWApp) validatePushRemote(dir string) error {
+	if err := app.gitOps.ValidatePushRemote(dir); err != nil {
+		app.logger.Error("workflow", "Push remote is not configured", map[string]interface{}{
+			"push_remote": app.gitOps.PushRemote(),
+			"error":       err.Error(),
+		})
+		return err
+	}
+	return nil
+}
+
+// targetPRAtBaseRepo points req at the base repository owner/repo and, when the
+// push remote belongs to another owner (a fork), qualifies the head branch with
+// that owner so the PR is opened from the pushed branch
+func (app *CCWApp) targetPRAtBaseRepo(req *types.PRRequest, dir, owner, repo string) {
+	if owner == "" || repo == "" {
+		return
+	}
+	req.Repo = fmt.Sprintf("%s/%s", owner, repo)
+
+	remoteURL, err := app.gitOps.RemoteURL(dir, app.gitOps.PushRemote())
+	if err != nil {
+		return
+	}
+	pushOwner, _, err := github.ExtractRepoInfo(remoteURL)
+	if err != nil {
+		app.logger.Warn("workflow", "Push remote is not a GitHub repository", map[string]interface{}{
+			"push_remote": app.gitOps.PushRemote(),
+			"url":         remoteURL,
+		})
+		return
+	}
+	req.Head = qualifyHeadRef(req.Head, owner, pushOwner)
+}
+
+// applyMaintainerCanModify sets req.MaintainerCanModify from pr.maintainer_can_modify.
+// Call it after targetPRAtBaseRepo: the setting only applies to PRs from a fork,
+// so same-repository PRs keep the default.
+func (app *CCWApp) applyMaintainerCanModify(req *types.PRRequest) {
+	configured := true
+	if app.ccwConfig != nil {
+		configured = app.ccwConfig.PR.MaintainerCanModify
+	}
+	req.MaintainerCanModify = maintainerCanModify(configured, req.Head)
+	if !configured && req.MaintainerCanModify && app.logger != nil {
+		app.logger.Debug("workflow", "pr.maintainer_can_modify only applies to PRs from a fork; ignoring", map[string]interface{}{
+			"head": req.Head,
+		})
+	}
+}
+
+// maintainerCanModify returns the maintainer edit permission for a PR from
+// head: the configured value for fork PRs, otherwise true
+func maintainerCanModify(configured bool, head string) bool {
+	return configured || !pr.IsCrossRepositoryHead(head)
+}
+
+// qualifyHeadRef returns branch as "pushOwner:branch" when it lives in a fork of baseOwner's repository
+func qualifyHeadRef(branch, baseOwner, pushOwner string) string {
+	if branch == "" || pushOwner == "" || pushOwner == baseOwner {
+		return branch
+	}
+	return pushOwner + ":" + branch
+}
@@ -0,0 +1,65 @@
+package app
+
+import (
+	"testing"
+
+	"ccw/config"
+	"ccw/types"
+)
+
+func TestQualifyHeadRef(t *testing.T) {
+	tests := []struct {
+		name      string
+		branch    string
+		baseOwner string
+		pushOwner string
+		expected  string
+	}{
+		{"same repository", "issue-1", "owner", "owner", "issue-1"},
+		{"fork push remote", "issue-1", "upstream-org", "me", "me:issue-1"},
+		{"unknown push owner", "issue-1", "owner", "", "issue-1"},
+		{"empty branch", "", "owner", "me", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := qualifyHeadRef(tt.branch, tt.baseOwner, tt.pushOwner); got != tt.expected {
+				t.Errorf("Expected '%s', got '%s'", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestApplyMaintainerCanModify(t *testing.T) {
+	tests := []struct {
+		name       string
+		configured *bool // nil leaves the app without configuration
+		head       string
+		expected   bool
+	}{
+		{"default without config", nil, "me:issue-1", true},
+		{"fork allows edits", boolPtr(true), "me:issue-1", true},
+		{"fork disables edits", boolPtr(false), "me:issue-1", false},
+		{"same repository ignores the setting", boolPtr(false), "issue-1", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := &CCWApp{}
+			if tt.configured != nil {
+				app.ccwConfig = config.GetDefaultCCWConfig()
+				app.ccwConfig.PR.MaintainerCanModify = *tt.configured
+			}
+
+			req := &types.PRRequest{Head: tt.head}
+			app.applyMaintainerCanModify(req)
+			if req.MaintainerCanModify != tt.expected {
+				t.Errorf("Expected MaintainerCanModify %v, got %v", tt.expected, req.MaintainerCanModify)
+			}
+		})
+	}
+}
+
+func boolPtr(value bool) *bool {
+	return &value
+}
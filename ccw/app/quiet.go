@@ -0,0 +1,57 @@
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Quiet mode output
+
+// quietResult is the JSON form of the --quiet result line
+type quietResult struct {
+	IssueURL string `json:"issue_url"`
+	Status   string `json:"status"` // "success" or "failed"
+	PRURL    string `json:"pr_url,omitempty"`
+	Error    string `json:"error,omitempty"`
+	Duration string `json:"duration"`
+
+	ImplementationSummary string `json:"implementation_summary,omitempty"`
+}
+
+// formatQuietResult renders the single line printed at the end of a --quiet
+// run, as JSON when log output is JSON (CCW_LOG_JSON=true). Only the JSON form
+// carries the implementation summary; the text form stays on one line.
+func formatQuietResult(summary *RunSummary, jsonOutput bool) string {
+	result := quietResult{
+		IssueURL: summary.IssueURL,
+		Status:   "success",
+		PRURL:    summary.PRURL,
+		Duration: summary.FinishedAt.Sub(summary.StartedAt).Round(time.Second).String(),
+
+		ImplementationSummary: summary.ImplementationSummary,
+	}
+	if summary.Error != nil {
+		result.Status = "failed"
+		result.Error = strings.ReplaceAll(summary.Error.Error(), "\n", " ")
+	}
+
+	if jsonOutput {
+		data, _ := json.Marshal(result)
+		return string(data)
+	}
+
+	target := result.IssueURL
+	if summary.Issue != nil {
+		target = fmt.Sprintf("issue #%d", summary.Issue.Number)
+	}
+	switch {
+	case result.Status == "failed":
+		return fmt.Sprintf("ccw: %s failed after %s: %s", target, result.Duration, result.Error)
+	case result.PRURL != "":
+		return fmt.Sprintf("ccw: %s succeeded in %s: %s", target, result.Duration, result.PRURL)
+	default:
+		return fmt.Sprintf("ccw: %s succeeded in %s", target, result.Duration)
+	}
+}
@@ -0,0 +1,84 @@
+package app
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"ccw/types"
+)
+
+func TestParseWorkflowArgsQuiet(t *testing.T) {
+	_, options, err := ParseWorkflowArgs([]string{"--quiet", "https://github.com/o/r/issues/1"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !options.Quiet {
+		t.Error("Expected Quiet to be set")
+	}
+}
+
+func TestFormatQuietResult(t *testing.T) {
+	started := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	issue := &types.Issue{Number: 42}
+
+	tests := []struct {
+		name     string
+		summary  *RunSummary
+		expected string
+	}{
+		{
+			"success with PR",
+			&RunSummary{Issue: issue, IssueURL: "https://github.com/o/r/issues/42", PRURL: "https://github.com/o/r/pull/7", StartedAt: started, FinishedAt: started.Add(754 * time.Second)},
+			"ccw: issue #42 succeeded in 12m34s: https://github.com/o/r/pull/7",
+		},
+		{
+			"success without PR",
+			&RunSummary{Issue: issue, StartedAt: started, FinishedAt: started.Add(time.Minute)},
+			"ccw: issue #42 succeeded in 1m0s",
+		},
+		{
+			"failure before the issue was fetched",
+			&RunSummary{IssueURL: "https://github.com/o/r/issues/42", Error: errors.New("push failed\nOutput: denied"), StartedAt: started, FinishedAt: started.Add(5 * time.Second)},
+			"ccw: https://github.com/o/r/issues/42 failed after 5s: push failed Output: denied",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := formatQuietResult(tt.summary, false); result != tt.expected {
+				t.Errorf("Expected '%s', got '%s'", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestFormatQuietResultJSON(t *testing.T) {
+	started := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	summary := &RunSummary{
+		IssueURL:   "https://github.com/o/r/issues/42",
+		Error:      errors.New("validation failed"),
+		StartedAt:  started,
+		FinishedAt: started.Add(90 * time.Second),
+	}
+
+	var result map[string]string
+	if err := json.Unmarshal([]byte(formatQuietResult(summary, true)), &result); err != nil {
+		t.Fatalf("Expected a JSON line: %v", err)
+	}
+	expected := map[string]string{
+		"issue_url": "https://github.com/o/r/issues/42",
+		"status":    "failed",
+		"error":     "validation failed",
+		"duration":  "1m30s",
+	}
+	for key, value := range expected {
+		if result[key] != value {
+			t.Errorf("Expected %s '%s', got '%s'", key, value, result[key])
+		}
+	}
+	if _, ok := result["pr_url"]; ok {
+		t.Error("Expected pr_url to be omitted without a PR")
+	}
+}
@@ -0,0 +1,172 @@
+package app
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+
+	"ccw/config"
+	"ccw/consoleui"
+	"ccw/github"
+	claudecli "ccw/pkg/claude"
+)
+
+// Re-authentication for the GitHub and Claude Code CLIs
+
+// AuthState is the observed state of one CLI's authentication
+type AuthState struct {
+	Available     bool // The CLI is installed
+	Authenticated bool // The CLI can make authenticated requests
+}
+
+// reauthAction is what reauth should do for a CLI in a given state
+type reauthAction int
+
+const (
+	reauthNone    reauthAction = iota // Already authenticated
+	reauthLogin                       // Run the CLI's login flow
+	reauthInstall                     // Cannot log in until the CLI is installed
+)
+
+// decideReauthAction chooses the action for a CLI given its auth state
+func decideReauthAction(state AuthState) reauthAction {
+	switch {
+	case !state.Available:
+		return reauthInstall
+	case !state.Authenticated:
+		return reauthLogin
+	default:
+		return reauthNone
+	}
+}
+
+// authProvider checks and logs in to one CLI
+type authProvider struct {
+	Name        string
+	InstallHint string
+	Check       func() AuthState
+	Login       func() error
+}
+
+// runReauth checks every provider, runs the login flow where needed and
+// confirms it with a follow-up check. It returns an error naming every
+// provider that is still not authenticated.
+func runReauth(providers []authProvider, out io.Writer) error {
+	var failed []string
+
+	for _, provider := range providers {
+		state := provider.Check()
+
+		switch decideReauthAction(state) {
+		case reauthNone:
+			fmt.Fprintf(out, "%s %s is authenticated\n", consoleui.Char("✅", "[OK]"), provider.Name)
+			continue
+		case reauthInstall:
+			fmt.Fprintf(out, "%s %s is not installed. %s\n", consoleui.Char("❌", "[ERROR]"), provider.Name, provider.InstallHint)
+			failed = append(failed, provider.Name)
+			continue
+		}
+
+		fmt.Fprintf(out, "%s %s is not authenticated, starting login...\n", consoleui.Char("🔑", "[LOGIN]"), provider.Name)
+		if err := provider.Login(); err != nil {
+			fmt.Fprintf(out, "%s %s login failed: %v\n", consoleui.Char("❌", "[ERROR]"), provider.Name, err)
+			failed = append(failed, provider.Name)
+			continue
+		}
+
+		// Confirm the login actually took effect
+		if provider.Check().Authenticated {
+			fmt.Fprintf(out, "%s %s re-authenticated successfully\n", consoleui.Char("✅", "[OK]"), provider.Name)
+		} else {
+			fmt.Fprintf(out, "%s %s is still not authenticated after login\n", consoleui.Char("❌", "[ERROR]"), provider.Name)
+			failed = append(failed, provider.Name)
+		}
+	}
+
+	if len(failed) > 0 {
+		return fmt.Errorf("authentication incomplete for: %s", strings.Join(failed, ", "))
+	}
+	return nil
+}
+
+// HandleReauthCommand checks GitHub and Claude Code authentication and
+// re-authenticates whichever is missing
+func HandleReauthCommand() {
+	// Use the configured gh executable rather than the one on PATH
+	if ccwConfig, err := config.LoadConfiguration(); err == nil {
+		github.SetGHPath(ccwConfig.GitHub.GHPath)
+		if _, profile, ok := ccwConfig.ActiveProfile(); ok {
+			github.SetHost(profile.Host)
+		}
+	}
+
+	fmt.Println(consoleui.Char("🔐 CCW Reauth", "CCW Reauth"))
+	fmt.Println("==================================")
+
+	if err := runReauth(defaultAuthProviders(), os.Stdout); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Println()
+	fmt.Println("GitHub and Claude Code are ready. Run 'ccw doctor' for a full diagnostic.")
+}
+
+// defaultAuthProviders returns the gh and claude providers backed by the real CLIs
+func defaultAuthProviders() []authProvider {
+	return []authProvider{
+		{
+			Name:        "GitHub CLI (gh)",
+			InstallHint: "Install it from https://cli.github.com or set github.gh_path.",
+			Check:       checkGHAuth,
+			Login: func() error {
+				return runInteractive(github.NewGHCommand("auth", "login"))
+			},
+		},
+		{
+			Name:        "Claude Code CLI",
+			InstallHint: "Install it with: npm install -g @anthropic-ai/claude-code",
+			Check:       checkClaudeAuth,
+			Login: func() error {
+				claudePath, err := claudecli.FindExecutable()
+				if err != nil {
+					return err
+				}
+				return runInteractive(exec.Command(claudePath, "/login"))
+			},
+		},
+	}
+}
+
+// checkGHAuth reports whether gh is installed and `gh auth status` succeeds
+func checkGHAuth() AuthState {
+	if _, err := github.ResolveGHPath(github.GHPath()); err != nil {
+		return AuthState{}
+	}
+	return AuthState{
+		Available:     true,
+		Authenticated: github.NewGHCommand("auth", "status").Run() == nil,
+	}
+}
+
+// checkClaudeAuth reports whether claude is installed and has credentials,
+// without sending a (billable) prompt
+func checkClaudeAuth() AuthState {
+	if _, err := claudecli.FindExecutable(); err != nil {
+		return AuthState{}
+	}
+	return AuthState{
+		Available:     true,
+		Authenticated: claudecli.HasCredentials(),
+	}
+}
+
+// runInteractive runs cmd attached to the current terminal
+func runInteractive(cmd *exec.Cmd) error {
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
@@ -0,0 +1,101 @@
+package app
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestDecideReauthAction(t *testing.T) {
+	tests := []struct {
+		name     string
+		state    AuthState
+		expected reauthAction
+	}{
+		{"authenticated", AuthState{Available: true, Authenticated: true}, reauthNone},
+		{"logged out", AuthState{Available: true}, reauthLogin},
+		{"not installed", AuthState{}, reauthInstall},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := decideReauthAction(tt.state); got != tt.expected {
+				t.Errorf("Expected action %d, got %d", tt.expected, got)
+			}
+		})
+	}
+}
+
+// stubProvider returns a provider whose check results are consumed in order
+// and which records how often login ran
+func stubProvider(name string, loginErr error, states ...AuthState) (*authProvider, *int) {
+	logins := 0
+	checks := 0
+	provider := &authProvider{
+		Name: name,
+		Check: func() AuthState {
+			state := states[checks]
+			if checks < len(states)-1 {
+				checks++
+			}
+			return state
+		},
+		Login: func() error {
+			logins++
+			return loginErr
+		},
+	}
+	return provider, &logins
+}
+
+func TestRunReauth(t *testing.T) {
+	authenticated := AuthState{Available: true, Authenticated: true}
+	loggedOut := AuthState{Available: true}
+	missing := AuthState{}
+
+	tests := []struct {
+		name           string
+		ghStates       []AuthState
+		ghLoginErr     error
+		claudeStates   []AuthState
+		expectedLogins [2]int
+		expectedFailed []string
+	}{
+		{"both authenticated", []AuthState{authenticated}, nil, []AuthState{authenticated}, [2]int{0, 0}, nil},
+		{"gh login succeeds", []AuthState{loggedOut, authenticated}, nil, []AuthState{authenticated}, [2]int{1, 0}, nil},
+		{"both need login", []AuthState{loggedOut, authenticated}, nil, []AuthState{loggedOut, authenticated}, [2]int{1, 1}, nil},
+		{"login does not take effect", []AuthState{loggedOut, loggedOut}, nil, []AuthState{authenticated}, [2]int{1, 0}, []string{"gh"}},
+		{"login command fails", []AuthState{loggedOut}, errors.New("cancelled"), []AuthState{authenticated}, [2]int{1, 0}, []string{"gh"}},
+		{"claude not installed", []AuthState{authenticated}, nil, []AuthState{missing}, [2]int{0, 0}, []string{"claude"}},
+		{"both failing", []AuthState{missing}, nil, []AuthState{loggedOut, loggedOut}, [2]int{0, 1}, []string{"gh", "claude"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gh, ghLogins := stubProvider("gh", tt.ghLoginErr, tt.ghStates...)
+			claude, claudeLogins := stubProvider("claude", nil, tt.claudeStates...)
+
+			var out bytes.Buffer
+			err := runReauth([]authProvider{*gh, *claude}, &out)
+
+			if *ghLogins != tt.expectedLogins[0] || *claudeLogins != tt.expectedLogins[1] {
+				t.Errorf("Expected logins %v, got [%d %d]", tt.expectedLogins, *ghLogins, *claudeLogins)
+			}
+
+			if len(tt.expectedFailed) == 0 {
+				if err != nil {
+					t.Errorf("Unexpected error: %v\n%s", err, out.String())
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("Expected error naming %v, got nil", tt.expectedFailed)
+			}
+			expected := "authentication incomplete for: " + strings.Join(tt.expectedFailed, ", ")
+			if err.Error() != expected {
+				t.Errorf("Expected '%s', got '%s'", expected, err.Error())
+			}
+		})
+	}
+}
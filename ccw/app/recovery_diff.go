@@ -0,0 +1,77 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	"ccw/types"
+)
+
+// Recording which errors each recovery attempt fixed
+
+// maxListedDiffErrors caps the resolved and introduced errors listed per
+// attempt in logs and the run summary
+const maxListedDiffErrors = 10
+
+// RecoveryAttempt records how one recovery attempt changed the validation errors
+type RecoveryAttempt struct {
+	Attempt   int
+	Diff      types.ErrorSetDiff
+	Recovered bool // Validation passed after this attempt
+}
+
+// recordRecoveryAttempt diffs the errors before and after a recovery attempt,
+// logs and shows the change, and keeps it for the run summary
+func (app *CCWApp) recordRecoveryAttempt(attempt int, before, after *types.ValidationResult) types.ErrorSetDiff {
+	diff := types.DiffValidationErrors(before, after)
+
+	app.logger.Info("workflow", "Recovery attempt error changes", map[string]interface{}{
+		"attempt":    attempt,
+		"resolved":   describeErrors(diff.Resolved, maxListedDiffErrors),
+		"introduced": describeErrors(diff.Introduced, maxListedDiffErrors),
+		"remaining":  len(diff.Remaining),
+	})
+	app.ui.Info(fmt.Sprintf("Recovery attempt %d: %s", attempt, diff))
+
+	if app.runSummary != nil {
+		app.runSummary.RecoveryAttempts = append(app.runSummary.RecoveryAttempts, RecoveryAttempt{
+			Attempt:   attempt,
+			Diff:      diff,
+			Recovered: after != nil && after.Success,
+		})
+	}
+	return diff
+}
+
+// describeErrors renders up to limit errors, noting how many were left out
+func describeErrors(errors []types.ValidationError, limit int) []string {
+	described := make([]string, 0, len(errors))
+	for i, validationErr := range errors {
+		if i == limit {
+			described = append(described, fmt.Sprintf("... and %d more", len(errors)-limit))
+			break
+		}
+		described = append(described, types.DescribeValidationError(validationErr))
+	}
+	return described
+}
+
+// renderRecoveryAttempts formats the recovery attempts for the run summary
+func renderRecoveryAttempts(attempts []RecoveryAttempt) string {
+	var sb strings.Builder
+	sb.WriteString("\n### Recovery Attempts\n\n")
+	for _, attempt := range attempts {
+		outcome := ""
+		if attempt.Recovered {
+			outcome = " (validation passed)"
+		}
+		sb.WriteString(fmt.Sprintf("- **Attempt %d:** %s%s\n", attempt.Attempt, attempt.Diff, outcome))
+		for _, line := range describeErrors(attempt.Diff.Resolved, maxListedDiffErrors) {
+			sb.WriteString(fmt.Sprintf("  - Resolved: %s\n", line))
+		}
+		for _, line := range describeErrors(attempt.Diff.Introduced, maxListedDiffErrors) {
+			sb.WriteString(fmt.Sprintf("  - Introduced: %s\n", line))
+		}
+	}
+	return sb.String()
+}
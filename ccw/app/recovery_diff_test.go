@@ -0,0 +1,109 @@
+package app
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"ccw/git"
+	"ccw/logging"
+	"ccw/types"
+	"ccw/ui"
+)
+
+func TestRecordRecoveryAttemptAddsToRunSummary(t *testing.T) {
+	logger, err := logging.NewLogger("recovery-diff-test", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	app := &CCWApp{ui: ui.NewUIManager("default", false, false), logger: logger, runSummary: &RunSummary{}}
+
+	lintErr := types.ValidationError{Type: "lint", File: "a.swift", Line: 3, Message: "unused variable"}
+	testErr := types.ValidationError{Type: "test", Message: "testLexer failed"}
+	buildErr := types.ValidationError{Type: "build", Message: "type mismatch"}
+
+	first := &types.ValidationResult{Errors: []types.ValidationError{lintErr, testErr}}
+	second := &types.ValidationResult{Errors: []types.ValidationError{testErr, buildErr}}
+	app.recordRecoveryAttempt(1, first, second)
+	app.recordRecoveryAttempt(2, second, &types.ValidationResult{Success: true})
+
+	attempts := app.runSummary.RecoveryAttempts
+	if len(attempts) != 2 || attempts[0].Recovered || !attempts[1].Recovered {
+		t.Fatalf("Expected two attempts, the second recovering, got %+v", attempts)
+	}
+
+	app.runSummary.Validation = &types.ValidationResult{Success: true}
+	rendered := RenderRunSummary(app.runSummary)
+	expected := []string{
+		"### Recovery Attempts",
+		"- **Attempt 1:** 1 resolved, 1 introduced, 1 remaining\n",
+		"  - Resolved: lint: a.swift:3: unused variable\n",
+		"  - Introduced: build: type mismatch\n",
+		"- **Attempt 2:** 2 resolved, 0 introduced, 0 remaining (validation passed)\n",
+		"  - Resolved: test: testLexer failed\n",
+	}
+	for _, text := range expected {
+		if !strings.Contains(rendered, text) {
+			t.Errorf("Expected summary to contain '%s', got:\n%s", text, rendered)
+		}
+	}
+}
+
+func TestDescribeErrorsLimit(t *testing.T) {
+	errors := []types.ValidationError{{Type: "lint", Message: "a"}, {Type: "lint", Message: "b"}, {Type: "lint", Message: "c"}}
+	got := describeErrors(errors, 2)
+	if strings.Join(got, "|") != "lint: a|lint: b|... and 1 more" {
+		t.Errorf("Expected two errors and a note, got %v", got)
+	}
+}
+
+func TestRecoveryRevalidationRunsOtherStagesOnceFixed(t *testing.T) {
+	var commands []string
+	lintFails := false
+	app := newGuardedApp(t, t.TempDir())
+	app.validator = git.NewQualityValidator()
+	app.validator.SetCommandRunner(func(dir, name string, args ...string) ([]byte, error) {
+		commands = append(commands, name+" "+args[0])
+		if name == "swiftlint" && lintFails {
+			return []byte("A.swift:1:1: error: broken"), errors.New("exit status 2")
+		}
+		return []byte("ok"), nil
+	})
+
+	result, err := app.validateImplementationStages([]string{"build", "test"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !result.Success || result.LintResult == nil {
+		t.Errorf("Expected a full, successful validation, got %+v", result)
+	}
+	if !strings.Contains(strings.Join(commands, "\n"), "swiftlint lint") {
+		t.Errorf("Expected lint to be re-run once build and test passed, got %v", commands)
+	}
+	for _, command := range []string{"swift build", "swift test"} {
+		if count := countCommand(commands, command); count != 1 {
+			t.Errorf("Expected '%s' to run once, ran %d times: %v", command, count, commands)
+		}
+	}
+
+	// A stage that passed before recovery but is broken now fails the run
+	lintFails = true
+	result, err = app.validateImplementationStages([]string{"build", "test"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result.Success {
+		t.Error("Expected the lint failure to fail validation")
+	}
+}
+
+// countCommand returns how often command appears in commands
+func countCommand(commands []string, command string) int {
+	count := 0
+	for _, c := range commands {
+		if c == command {
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,231 @@
+package app
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"ccw/github"
+	"ccw/types"
+)
+
+// Reproducing a crashed run from its crash report (ccw replay)
+
+// crashReportComponent is the log component crash reports are logged under
+const crashReportComponent = "crash_report"
+
+// CrashReport is the crash information saveCrashReport records
+type CrashReport struct {
+	Timestamp   string           `json:"timestamp"`
+	SessionID   string           `json:"session_id"`
+	PanicValue  interface{}      `json:"panic_value"`
+	StackTrace  string           `json:"stack_trace"`
+	IssueURL    string           `json:"issue_url"`
+	Environment CrashEnvironment `json:"environment"`
+	CommandLine []string         `json:"command_line"`
+	WorkingDir  string           `json:"working_dir"`
+}
+
+// CrashEnvironment describes the process a crash happened in
+type CrashEnvironment struct {
+	GoVersion string `json:"go_version"`
+	GOOS      string `json:"goos"`
+	GOARCH    string `json:"goarch"`
+	NumCPU    int    `json:"num_cpu"`
+	DebugMode bool   `json:"debug_mode"`
+}
+
+// replayWorkflow runs the replayed issue workflow; tests replace it
+var replayWorkflow = func(issueURL string, options *WorkflowOptions) error {
+	ccwApp, err := NewCCWApp()
+	if err != nil {
+		return fmt.Errorf("failed to initialize application: %w", err)
+	}
+	defer ccwApp.Cleanup()
+	ccwApp.SetWorkflowOptions(options)
+	return ccwApp.ExecuteWorkflowWithRecovery(issueURL)
+}
+
+// crashReportPath returns where the crash report of a session is written
+func crashReportPath(logDir, sessionID string) string {
+	return filepath.Join(logDir, fmt.Sprintf("crash-%s.json", sessionID))
+}
+
+// writeCrashReport saves a crash report as indented JSON for ccw replay
+func writeCrashReport(path string, report map[string]interface{}) error {
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode crash report: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create crash report directory: %w", err)
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// ParseCrashReport reads a crash report: either the JSON file saveCrashReport
+// writes, or a JSON session log containing a crash_report entry, in which case
+// the last such entry is used
+func ParseCrashReport(r io.Reader) (*CrashReport, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read crash report: %w", err)
+	}
+
+	var report CrashReport
+	if err := json.Unmarshal(data, &report); err == nil && report.IssueURL != "" {
+		return &report, nil
+	}
+
+	var found *CrashReport
+	scanner := bufio.NewScanner(strings.NewReader(string(data)))
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		var entry types.LogEntry
+		if json.Unmarshal(scanner.Bytes(), &entry) != nil || entry.Component != crashReportComponent {
+			continue
+		}
+		contextData, err := json.Marshal(entry.Context)
+		if err != nil {
+			continue
+		}
+		var logged CrashReport
+		if json.Unmarshal(contextData, &logged) == nil && logged.IssueURL != "" {
+			found = &logged
+		}
+	}
+	if found == nil {
+		return nil, fmt.Errorf("no crash report with an issue URL found")
+	}
+	return found, nil
+}
+
+// LoadCrashReport reads the crash report at path
+func LoadCrashReport(path string) (*CrashReport, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open crash report: %w", err)
+	}
+	defer file.Close()
+
+	report, err := ParseCrashReport(file)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return report, nil
+}
+
+// replayModeFlags are the run-mode commands ccw accepts before an issue URL
+var replayModeFlags = map[string]bool{"--debug": true, "--verbose": true, "--trace": true, "--console": true}
+
+// replayArgs returns the issue arguments of the crashed command line: the
+// program name and mode flag are dropped, and so is --progress-fd, whose
+// descriptor belonged to the crashed process
+func replayArgs(commandLine []string) []string {
+	if len(commandLine) < 2 {
+		return nil
+	}
+	args := commandLine[1:]
+	if replayModeFlags[args[0]] {
+		args = args[1:]
+	}
+
+	var kept []string
+	for i := 0; i < len(args); i++ {
+		switch {
+		case args[i] == "--progress-fd":
+			i++
+		case strings.HasPrefix(args[i], "--progress-fd="):
+		default:
+			kept = append(kept, args[i])
+		}
+	}
+	return kept
+}
+
+// replayPlan returns the issue URL and options to re-run a crashed run with.
+// The original options are restored from its command line when they still
+// parse; otherwise only the issue URL is replayed, with a warning.
+func replayPlan(report *CrashReport) (string, *WorkflowOptions, []string, error) {
+	if _, _, _, err := github.ExtractIssueInfo(report.IssueURL); err != nil {
+		return "", nil, nil, fmt.Errorf("crash report has no valid issue URL (%q): %w", report.IssueURL, err)
+	}
+
+	var warnings []string
+	args := replayArgs(report.CommandLine)
+	if len(args) > 0 {
+		issueURL, options, err := ParseWorkflowArgs(args)
+		if err == nil && issueURL == report.IssueURL {
+			return issueURL, options, warnings, nil
+		}
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf("original options not restored: %v", err))
+		}
+	}
+	return report.IssueURL, &WorkflowOptions{}, warnings, nil
+}
+
+// environmentWarnings lists how this process differs from the crashed one
+func environmentWarnings(env CrashEnvironment) []string {
+	var warnings []string
+	if env.GoVersion != "" && env.GoVersion != runtime.Version() {
+		warnings = append(warnings, fmt.Sprintf("crash happened with %s, replaying with %s", env.GoVersion, runtime.Version()))
+	}
+	if env.GOOS != "" && (env.GOOS != runtime.GOOS || env.GOARCH != runtime.GOARCH) {
+		warnings = append(warnings, fmt.Sprintf("crash happened on %s/%s, replaying on %s/%s", env.GOOS, env.GOARCH, runtime.GOOS, runtime.GOARCH))
+	}
+	return warnings
+}
+
+// runReplay loads a crash report and re-runs its issue from the crashed run's
+// working directory with the same options and trace logging
+func runReplay(path string, out io.Writer) error {
+	report, err := LoadCrashReport(path)
+	if err != nil {
+		return err
+	}
+	issueURL, options, warnings, err := replayPlan(report)
+	if err != nil {
+		return err
+	}
+	warnings = append(warnings, environmentWarnings(report.Environment)...)
+
+	if report.WorkingDir != "" && report.WorkingDir != "unknown" {
+		if err := os.Chdir(report.WorkingDir); err != nil {
+			return fmt.Errorf("failed to enter the crashed run's working directory: %w", err)
+		}
+	}
+
+	fmt.Fprintf(out, "Replaying crash of session %s (%s)\n", report.SessionID, report.Timestamp)
+	fmt.Fprintf(out, "  Issue:   %s\n", issueURL)
+	fmt.Fprintf(out, "  Panic:   %v\n", report.PanicValue)
+	if report.WorkingDir != "" {
+		fmt.Fprintf(out, "  Workdir: %s\n", report.WorkingDir)
+	}
+	for _, warning := range warnings {
+		fmt.Fprintf(out, "  Warning: %s\n", warning)
+	}
+
+	// Trace logging captures the steps leading up to the crash this time
+	EnableTraceMode()
+	return replayWorkflow(issueURL, options)
+}
+
+// HandleReplayCommand re-runs the workflow of a crashed run from its crash report
+func HandleReplayCommand() {
+	if len(os.Args) != 3 || strings.HasPrefix(os.Args[2], "-") {
+		fmt.Println("Usage: ccw replay <crash-report>")
+		fmt.Println("  crash-report  Crash report JSON (.ccw/logs/crash-<session>.json) or a JSON session log")
+		os.Exit(1)
+	}
+
+	if err := runReplay(os.Args[2], os.Stdout); err != nil {
+		log.Fatalf("Replay failed: %v", err)
+	}
+}
@@ -0,0 +1,225 @@
+package app
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+const testCrashIssueURL = "https://github.com/owner/repo/issues/42"
+
+func TestParseCrashReport(t *testing.T) {
+	t.Run("crash report file", func(t *testing.T) {
+		input := `{
+  "timestamp": "2024-01-01T09:00:00Z",
+  "session_id": "abc123",
+  "panic_value": "boom",
+  "issue_url": "` + testCrashIssueURL + `",
+  "environment": {"go_version": "go1.21.0", "goos": "linux", "goarch": "amd64", "num_cpu": 8},
+  "command_line": ["ccw", "--debug", "` + testCrashIssueURL + `"],
+  "working_dir": "/tmp/repo"
+}`
+		report, err := ParseCrashReport(strings.NewReader(input))
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if report.IssueURL != testCrashIssueURL {
+			t.Errorf("Expected '%s', got '%s'", testCrashIssueURL, report.IssueURL)
+		}
+		if report.SessionID != "abc123" {
+			t.Errorf("Expected 'abc123', got '%s'", report.SessionID)
+		}
+		if report.Environment.GOOS != "linux" || report.Environment.NumCPU != 8 {
+			t.Errorf("Unexpected environment: %+v", report.Environment)
+		}
+		if len(report.CommandLine) != 3 {
+			t.Errorf("Expected 3 command line args, got %d", len(report.CommandLine))
+		}
+	})
+
+	t.Run("json session log", func(t *testing.T) {
+		input := `{"timestamp":"2024-01-01T09:00:00Z","level":"INFO","message":"Starting","component":"app"}
+not json
+{"timestamp":"2024-01-01T09:00:01Z","level":"ERROR","message":"Application crash: boom","component":"crash_report","context":{"session_id":"s1","issue_url":"` + testCrashIssueURL + `","command_line":["ccw","` + testCrashIssueURL + `"],"working_dir":"/tmp/repo"}}
+`
+		report, err := ParseCrashReport(strings.NewReader(input))
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if report.IssueURL != testCrashIssueURL {
+			t.Errorf("Expected '%s', got '%s'", testCrashIssueURL, report.IssueURL)
+		}
+		if report.WorkingDir != "/tmp/repo" {
+			t.Errorf("Expected '/tmp/repo', got '%s'", report.WorkingDir)
+		}
+	})
+
+	t.Run("no crash report", func(t *testing.T) {
+		input := `{"timestamp":"2024-01-01T09:00:00Z","level":"INFO","message":"Starting","component":"app"}`
+		if _, err := ParseCrashReport(strings.NewReader(input)); err == nil {
+			t.Error("Expected an error for a log without a crash report")
+		}
+	})
+}
+
+func TestWriteCrashReportRoundTrip(t *testing.T) {
+	path := crashReportPath(filepath.Join(t.TempDir(), "logs"), "session-1")
+	if filepath.Base(path) != "crash-session-1.json" {
+		t.Errorf("Expected 'crash-session-1.json', got '%s'", filepath.Base(path))
+	}
+
+	err := writeCrashReport(path, map[string]interface{}{
+		"session_id":   "session-1",
+		"issue_url":    testCrashIssueURL,
+		"command_line": []string{"ccw", testCrashIssueURL},
+		"environment":  map[string]interface{}{"goos": runtime.GOOS},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	report, err := LoadCrashReport(path)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if report.SessionID != "session-1" || report.IssueURL != testCrashIssueURL {
+		t.Errorf("Unexpected report: %+v", report)
+	}
+}
+
+func TestReplayArgs(t *testing.T) {
+	tests := []struct {
+		name        string
+		commandLine []string
+		expected    []string
+	}{
+		{"empty", nil, nil},
+		{"program only", []string{"ccw"}, nil},
+		{"issue url", []string{"ccw", testCrashIssueURL}, []string{testCrashIssueURL}},
+		{"mode flag", []string{"ccw", "--trace", testCrashIssueURL}, []string{testCrashIssueURL}},
+		{"progress fd", []string{"ccw", "--progress-fd", "3", "--no-commit", testCrashIssueURL}, []string{"--no-commit", testCrashIssueURL}},
+		{"progress fd equals", []string{"ccw", "--progress-fd=3", testCrashIssueURL}, []string{testCrashIssueURL}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := replayArgs(tt.commandLine)
+			if strings.Join(got, " ") != strings.Join(tt.expected, " ") {
+				t.Errorf("Expected '%v', got '%v'", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestReplayPlan(t *testing.T) {
+	t.Run("restores options", func(t *testing.T) {
+		report := &CrashReport{IssueURL: testCrashIssueURL, CommandLine: []string{"ccw", "--debug", "--no-commit", testCrashIssueURL}}
+		issueURL, options, warnings, err := replayPlan(report)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if issueURL != testCrashIssueURL {
+			t.Errorf("Expected '%s', got '%s'", testCrashIssueURL, issueURL)
+		}
+		if !options.NoCommit {
+			t.Error("Expected --no-commit to be restored")
+		}
+		if len(warnings) != 0 {
+			t.Errorf("Expected no warnings, got %v", warnings)
+		}
+	})
+
+	t.Run("falls back to issue url", func(t *testing.T) {
+		report := &CrashReport{IssueURL: testCrashIssueURL, CommandLine: []string{"ccw", "--no-such-flag", testCrashIssueURL}}
+		issueURL, options, warnings, err := replayPlan(report)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if issueURL != testCrashIssueURL || options == nil {
+			t.Errorf("Expected a plan for '%s', got '%s'", testCrashIssueURL, issueURL)
+		}
+		if len(warnings) != 1 {
+			t.Errorf("Expected 1 warning, got %v", warnings)
+		}
+	})
+
+	t.Run("invalid issue url", func(t *testing.T) {
+		if _, _, _, err := replayPlan(&CrashReport{IssueURL: "not-a-url"}); err == nil {
+			t.Error("Expected an error for an invalid issue URL")
+		}
+	})
+}
+
+func TestEnvironmentWarnings(t *testing.T) {
+	same := CrashEnvironment{GoVersion: runtime.Version(), GOOS: runtime.GOOS, GOARCH: runtime.GOARCH}
+	if warnings := environmentWarnings(same); len(warnings) != 0 {
+		t.Errorf("Expected no warnings, got %v", warnings)
+	}
+
+	different := CrashEnvironment{GoVersion: "go0.0", GOOS: "plan9", GOARCH: "mips"}
+	if warnings := environmentWarnings(different); len(warnings) != 2 {
+		t.Errorf("Expected 2 warnings, got %v", warnings)
+	}
+}
+
+func TestRunReplayDispatch(t *testing.T) {
+	for _, key := range []string{"DEBUG_MODE", "VERBOSE_MODE", "TRACE_MODE", "CCW_LOG_FILE"} {
+		t.Setenv(key, "")
+	}
+	cwd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(cwd)
+
+	workDir, err := filepath.EvalSymlinks(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "crash.json")
+	err = writeCrashReport(path, map[string]interface{}{
+		"session_id":   "s1",
+		"panic_value":  "boom",
+		"issue_url":    testCrashIssueURL,
+		"command_line": []string{"ccw", "--no-commit", testCrashIssueURL},
+		"working_dir":  workDir,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	originalReplay := replayWorkflow
+	defer func() { replayWorkflow = originalReplay }()
+
+	var gotURL, gotDir string
+	var gotOptions *WorkflowOptions
+	replayWorkflow = func(issueURL string, options *WorkflowOptions) error {
+		gotURL, gotOptions = issueURL, options
+		gotDir, _ = os.Getwd()
+		return nil
+	}
+
+	var out bytes.Buffer
+	if err := runReplay(path, &out); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if gotURL != testCrashIssueURL {
+		t.Errorf("Expected '%s', got '%s'", testCrashIssueURL, gotURL)
+	}
+	if gotOptions == nil || !gotOptions.NoCommit {
+		t.Error("Expected the original --no-commit option to be replayed")
+	}
+	if gotDir != workDir {
+		t.Errorf("Expected '%s', got '%s'", workDir, gotDir)
+	}
+	if os.Getenv("TRACE_MODE") != "true" {
+		t.Error("Expected trace mode to be enabled for the replay")
+	}
+	if !strings.Contains(out.String(), "session s1") {
+		t.Errorf("Expected the session in the output, got '%s'", out.String())
+	}
+}
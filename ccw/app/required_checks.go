@@ -0,0 +1,42 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+)
+
+// requiredChecksFromProtection returns the checks branch protection requires
+// on the PR base branch, looked up once per run. It returns nil, so that every
+// check is required, when the lookup is off, fails or finds none.
+func (app *CCWApp) requiredChecksFromProtection() []string {
+	if app.protectionChecksLoaded {
+		return app.protectionChecks
+	}
+	app.protectionChecksLoaded = true
+
+	if app.ccwConfig == nil || !app.ccwConfig.Workflow.Done.DetectRequiredChecks ||
+		app.githubClient == nil || app.worktreeConfig == nil {
+		return nil
+	}
+
+	owner, repo, branch := app.worktreeConfig.Owner, app.worktreeConfig.Repository, app.shipBaseBranch()
+	checks, err := app.githubClient.GetRequiredChecks(owner, repo, branch)
+	if err != nil {
+		if app.logger != nil {
+			app.logger.Warn("workflow", "Branch protection not readable, requiring every check", map[string]interface{}{
+				"branch": branch,
+				"error":  err.Error(),
+			})
+		}
+		app.explain(fmt.Sprintf("every CI check is required: branch protection of %s is not readable", branch))
+		return nil
+	}
+	if len(checks) == 0 {
+		app.explain(fmt.Sprintf("every CI check is required: branch protection of %s requires no status checks", branch))
+		return nil
+	}
+
+	app.protectionChecks = checks
+	app.explain(fmt.Sprintf("required CI checks taken from branch protection of %s: %s", branch, strings.Join(checks, ", ")))
+	return checks
+}
@@ -0,0 +1,49 @@
+package app
+
+import (
+	"reflect"
+	"testing"
+
+	"ccw/config"
+)
+
+func TestDonePolicyUsesProtectionChecks(t *testing.T) {
+	done := config.GetDefaultCCWConfig().Workflow.Done
+
+	app := &CCWApp{ccwConfig: &config.CCWConfig{}}
+	app.ccwConfig.Workflow.Done = done
+	app.protectionChecks = []string{"build", "test"}
+	app.protectionChecksLoaded = true
+
+	if checks := app.donePolicy().RequiredChecks; !reflect.DeepEqual(checks, []string{"build", "test"}) {
+		t.Errorf("Expected checks from branch protection, got %v", checks)
+	}
+
+	// Configured checks win over branch protection
+	app.ccwConfig.Workflow.Done.RequiredChecks = []string{"lint"}
+	if checks := app.donePolicy().RequiredChecks; !reflect.DeepEqual(checks, []string{"lint"}) {
+		t.Errorf("Expected configured checks, got %v", checks)
+	}
+}
+
+func TestRequiredChecksFromProtectionFallsBack(t *testing.T) {
+	done := config.GetDefaultCCWConfig().Workflow.Done
+	done.DetectRequiredChecks = false
+
+	app := &CCWApp{ccwConfig: &config.CCWConfig{}}
+	app.ccwConfig.Workflow.Done = done
+
+	if checks := app.requiredChecksFromProtection(); checks != nil {
+		t.Errorf("Expected every check required with detection off, got %v", checks)
+	}
+	if !app.protectionChecksLoaded {
+		t.Error("Expected the lookup to be recorded")
+	}
+
+	// Without a GitHub client protection is not readable
+	app = &CCWApp{ccwConfig: &config.CCWConfig{}}
+	app.ccwConfig.Workflow.Done = config.GetDefaultCCWConfig().Workflow.Done
+	if checks := app.donePolicy().RequiredChecks; len(checks) != 0 {
+		t.Errorf("Expected every check required when protection is unreadable, got %v", checks)
+	}
+}
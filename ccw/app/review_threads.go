@@ -0,0 +1,83 @@
+package app
+
+import (
+	"fmt"
+
+	"ccw/consoleui"
+	"ccw/pr"
+	"ccw/types"
+)
+
+// reviewThreadReplyBody is the reply posted in each addressed review thread
+func reviewThreadReplyBody(commit string) string {
+	if len(commit) > 7 {
+		commit = commit[:7]
+	}
+	if commit == "" {
+		return "Addressed in the latest push."
+	}
+	return fmt.Sprintf("Addressed in %s.", commit)
+}
+
+// reviewThreadsEnabled reports whether inline review threads are analyzed and replied to
+func (app *CCWApp) reviewThreadsEnabled() bool {
+	return app.ccwConfig != nil && app.ccwConfig.PR.ReplyToReviewThreads
+}
+
+// reviewThreadComments returns the comments of unresolved review threads so
+// they are analyzed alongside top-level PR comments
+func (app *CCWApp) reviewThreadComments(prURL string) []types.PRComment {
+	if !app.reviewThreadsEnabled() {
+		return nil
+	}
+
+	threads, err := app.prManager.GetReviewThreads(prURL)
+	if err != nil {
+		app.ui.Warning(fmt.Sprintf("Failed to fetch review threads: %v", err))
+		return nil
+	}
+	return pr.UnresolvedThreadComments(threads)
+}
+
+// replyToAddressedThreads replies in each unresolved review thread containing
+// an addressed comment, resolving it when pr.resolve_review_threads is set
+func (app *CCWApp) replyToAddressedThreads(prURL string, analysis *types.PRCommentAnalysis) {
+	if !app.reviewThreadsEnabled() {
+		return
+	}
+
+	threads, err := app.prManager.GetReviewThreads(prURL)
+	if err != nil {
+		app.ui.Warning(fmt.Sprintf("Failed to fetch review threads: %v", err))
+		return
+	}
+	addressed := pr.ThreadsForComments(threads, analysis.ActionableComments)
+	if len(addressed) == 0 {
+		return
+	}
+
+	commit, _ := app.gitOps.HeadCommit(app.worktreeConfig.WorktreePath)
+	body := reviewThreadReplyBody(commit)
+	replied := 0
+	for _, thread := range addressed {
+		if err := app.prManager.ReplyToReviewThread(prURL, thread.ID, body); err != nil {
+			app.ui.Warning(fmt.Sprintf("Failed to reply to review thread on %s: %v", thread.Path, err))
+			continue
+		}
+		replied++
+		if app.ccwConfig.PR.ResolveReviewThreads {
+			if err := app.prManager.ResolveReviewThread(thread.ID); err != nil {
+				app.ui.Warning(fmt.Sprintf("Failed to resolve review thread on %s: %v", thread.Path, err))
+			}
+		}
+	}
+
+	app.logger.Info("workflow", "Replied to addressed review threads", map[string]interface{}{
+		"pr_url":   prURL,
+		"threads":  len(addressed),
+		"replied":  replied,
+		"resolved": app.ccwConfig.PR.ResolveReviewThreads,
+	})
+	replyIcon := consoleui.Char("💬", "[REPLIED]")
+	app.ui.Info(fmt.Sprintf("%s Replied to %d review thread(s)", replyIcon, replied))
+}
@@ -0,0 +1,20 @@
+package app
+
+import "testing"
+
+func TestReviewThreadReplyBody(t *testing.T) {
+	tests := []struct {
+		commit   string
+		expected string
+	}{
+		{"0123456789abcdef0123456789abcdef01234567", "Addressed in 0123456."},
+		{"abc1234", "Addressed in abc1234."},
+		{"", "Addressed in the latest push."},
+	}
+
+	for _, tt := range tests {
+		if result := reviewThreadReplyBody(tt.commit); result != tt.expected {
+			t.Errorf("Expected '%s', got '%s'", tt.expected, result)
+		}
+	}
+}
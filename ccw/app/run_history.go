@@ -0,0 +1,55 @@
+package app
+
+import (
+	"time"
+
+	"ccw/github"
+	"ccw/history"
+)
+
+// loadETAEstimator enables the progress ETA from previous runs of this repository
+func (app *CCWApp) loadETAEstimator(owner, repo string) {
+	records, err := history.Load(history.DefaultHistoryPath)
+	if err != nil {
+		app.logger.Warn("workflow", "Failed to load run history", map[string]interface{}{
+			"path":  history.DefaultHistoryPath,
+			"error": err.Error(),
+		})
+		return
+	}
+
+	estimator := history.NewETAEstimator(records, owner+"/"+repo, history.DetectLanguage("."))
+	if !estimator.HasHistory() {
+		return
+	}
+	app.ui.SetETAEstimator(estimator)
+}
+
+// recordRunHistory appends the phase durations of the finished run to the history file
+func (app *CCWApp) recordRunHistory(issueURL string, startedAt time.Time, runErr error) {
+	owner, repo, issueNumber, err := github.ExtractIssueInfo(issueURL)
+	if err != nil {
+		return
+	}
+
+	phases := history.PhaseDurations(app.ui.ProgressSteps())
+	if len(phases) == 0 {
+		return
+	}
+
+	record := history.Record{
+		Repository:  owner + "/" + repo,
+		Language:    history.DetectLanguage("."),
+		IssueNumber: issueNumber,
+		StartedAt:   startedAt,
+		FinishedAt:  time.Now(),
+		Success:     runErr == nil,
+		Phases:      phases,
+	}
+	if err := history.Append(history.DefaultHistoryPath, record); err != nil {
+		app.logger.Warn("workflow", "Failed to record run history", map[string]interface{}{
+			"path":  history.DefaultHistoryPath,
+			"error": err.Error(),
+		})
+	}
+}
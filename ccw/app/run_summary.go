@@ -0,0 +1,234 @@
+package app
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"ccw/git"
+	"ccw/types"
+)
+
+// Post-run markdown report
+
+// runSummaryFileName is the report written when no --summary-out is given
+const runSummaryFileName = "summary.md"
+
+// RunSummary collects the outcome of a single issue workflow run
+type RunSummary struct {
+	Issue                 *types.Issue
+	IssueURL              string
+	BranchName            string
+	WorktreePath          string
+	BaseCommit            string // HEAD when the worktree was created
+	CommitSHA             string
+	DiffStat              string
+	UnplannedFiles        []string // Changed files missing from the change plan
+	Validation            *types.ValidationResult
+	RecoveryAttempts      []RecoveryAttempt // How each recovery attempt changed the validation errors
+	PRURL                 string
+	CIConclusion          string // Final CI conclusion, e.g. "success" or "failure"
+	ImplementationSummary string // Claude's summary of the changes, also used in the PR body
+	Error                 error
+	StartedAt             time.Time
+	FinishedAt            time.Time
+}
+
+// RenderRunSummary formats a run summary as markdown
+func RenderRunSummary(summary *RunSummary) string {
+	var sb strings.Builder
+
+	title := "CCW Run Summary"
+	if summary.Issue != nil {
+		title = fmt.Sprintf("CCW Run Summary: #%d %s", summary.Issue.Number, summary.Issue.Title)
+	}
+	sb.WriteString(fmt.Sprintf("# %s\n\n", title))
+
+	status := "Success"
+	if summary.Error != nil {
+		status = fmt.Sprintf("Failed: %v", summary.Error)
+	}
+	sb.WriteString(fmt.Sprintf("- **Status:** %s\n", status))
+
+	if summary.Issue != nil {
+		issueURL := summary.IssueURL
+		if summary.Issue.HTMLURL != "" {
+			issueURL = summary.Issue.HTMLURL
+		}
+		sb.WriteString(fmt.Sprintf("- **Issue:** [#%d](%s) %s\n", summary.Issue.Number, issueURL, summary.Issue.Title))
+	} else if summary.IssueURL != "" {
+		sb.WriteString(fmt.Sprintf("- **Issue:** %s\n", summary.IssueURL))
+	}
+	if summary.BranchName != "" {
+		sb.WriteString(fmt.Sprintf("- **Branch:** `%s`\n", summary.BranchName))
+	}
+	if summary.CommitSHA != "" {
+		sb.WriteString(fmt.Sprintf("- **Commit:** `%s`\n", summary.CommitSHA))
+	}
+	if summary.PRURL != "" {
+		sb.WriteString(fmt.Sprintf("- **Pull Request:** %s\n", summary.PRURL))
+	}
+	if !summary.StartedAt.IsZero() && !summary.FinishedAt.IsZero() {
+		sb.WriteString(fmt.Sprintf("- **Duration:** %s\n", summary.FinishedAt.Sub(summary.StartedAt).Round(time.Second)))
+	}
+
+	sb.WriteString("\n## Changes\n\n")
+	if summary.DiffStat != "" {
+		sb.WriteString("```\n")
+		sb.WriteString(summary.DiffStat)
+		sb.WriteString("\n```\n")
+	} else {
+		sb.WriteString("No committed changes.\n")
+	}
+	if len(summary.UnplannedFiles) > 0 {
+		sb.WriteString("\n### Unplanned Changes\n\n")
+		for _, file := range summary.UnplannedFiles {
+			sb.WriteString(fmt.Sprintf("- `%s`\n", file))
+		}
+	}
+
+	sb.WriteString("\n## Validation\n\n")
+	if summary.Validation == nil {
+		sb.WriteString("Validation did not run.\n")
+		return sb.String()
+	}
+
+	sb.WriteString("| Check | Result |\n")
+	sb.WriteString("|-------|--------|\n")
+	if summary.Validation.LintResult != nil {
+		sb.WriteString(fmt.Sprintf("| Lint | %s |\n", passFail(summary.Validation.LintResult.Success)))
+	}
+	if summary.Validation.BuildResult != nil {
+		sb.WriteString(fmt.Sprintf("| Build | %s |\n", passFail(summary.Validation.BuildResult.Success)))
+	}
+	if test := summary.Validation.TestResult; test != nil {
+		result := passFail(test.Success)
+		if test.TestCount > 0 {
+			result = fmt.Sprintf("%s (%d/%d passed)", result, test.Passed, test.TestCount)
+		}
+		sb.WriteString(fmt.Sprintf("| Tests | %s |\n", result))
+	}
+	sb.WriteString(fmt.Sprintf("| Overall | %s |\n", passFail(summary.Validation.Success)))
+
+	if len(summary.Validation.Errors) > 0 {
+		sb.WriteString("\n### Errors\n\n")
+		for _, validationErr := range summary.Validation.Errors {
+			sb.WriteString(fmt.Sprintf("- **%s:** %s\n", validationErr.Type, validationErr.Message))
+		}
+	}
+	if len(summary.RecoveryAttempts) > 0 {
+		sb.WriteString(renderRecoveryAttempts(summary.RecoveryAttempts))
+	}
+
+	return sb.String()
+}
+
+// WriteRunSummary renders summary as markdown and writes it to path
+func WriteRunSummary(path string, summary *RunSummary) error {
+	if dir := filepath.Dir(path); dir != "." {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("failed to create summary directory: %w", err)
+		}
+	}
+
+	if err := os.WriteFile(path, []byte(RenderRunSummary(summary)), 0644); err != nil {
+		return fmt.Errorf("failed to write run summary: %w", err)
+	}
+
+	return nil
+}
+
+// runSummaryPath returns where the report for this run should be written: the
+// --summary-out path, the worktree's summary directory if the worktree still
+// exists, or .ccw for cleaned-up runs
+func (app *CCWApp) runSummaryPath(summary *RunSummary) string {
+	if app.options.SummaryOut != "" {
+		return app.options.SummaryOut
+	}
+	if summary.WorktreePath != "" && !app.inPlace() {
+		if info, err := os.Stat(summary.WorktreePath); err == nil && info.IsDir() {
+			return filepath.Join(app.worktreeSummaryDir(summary.WorktreePath), runSummaryFileName)
+		}
+	}
+	if summary.Issue != nil {
+		return filepath.Join(".ccw", fmt.Sprintf("summary-issue-%d.md", summary.Issue.Number))
+	}
+	return filepath.Join(".ccw", runSummaryFileName)
+}
+
+// worktreeSummaryDir is where the report is kept inside a worktree: the
+// git.metadata_dir when set, otherwise .ccw. Either way the directory is
+// ignored, so the report is not committed with the implementation.
+func (app *CCWApp) worktreeSummaryDir(worktreePath string) string {
+	dir := app.metadataDir()
+	if dir == "" {
+		dir = ".ccw"
+	}
+	return filepath.Join(worktreePath, dir)
+}
+
+// saveRunSummary writes the report to path, keeping a report inside the
+// worktree out of its commits
+func (app *CCWApp) saveRunSummary(path string, summary *RunSummary) error {
+	if summary.WorktreePath != "" && filepath.Dir(path) == app.worktreeSummaryDir(summary.WorktreePath) {
+		if err := git.EnsureUncommittedDir(filepath.Dir(path), "run summaries"); err != nil {
+			return err
+		}
+	}
+	return WriteRunSummary(path, summary)
+}
+
+// recordCommittedChanges captures the commit SHA and diff stats while the worktree exists
+func (app *CCWApp) recordCommittedChanges() {
+	if app.runSummary == nil || app.worktreeConfig == nil {
+		return
+	}
+	worktreePath := app.worktreeConfig.WorktreePath
+
+	if sha, err := app.gitOps.HeadCommit(worktreePath); err == nil {
+		app.runSummary.CommitSHA = sha
+	}
+	if app.runSummary.BaseCommit != "" {
+		if stat, err := app.gitOps.DiffStat(worktreePath, app.runSummary.BaseCommit); err == nil {
+			app.runSummary.DiffStat = stat
+		}
+	}
+}
+
+// writeRunSummary finalizes and writes the report for the current run
+func (app *CCWApp) writeRunSummary(runErr error) {
+	summary := app.runSummary
+	if summary == nil {
+		return
+	}
+	summary.Error = runErr
+	summary.FinishedAt = time.Now()
+
+	// Failed runs keep their worktree, so commits made so far can still be recorded
+	if summary.WorktreePath != "" {
+		if _, err := os.Stat(summary.WorktreePath); err == nil {
+			app.recordCommittedChanges()
+		}
+	}
+
+	path := app.runSummaryPath(summary)
+	if err := app.saveRunSummary(path, summary); err != nil {
+		app.logger.Warn("workflow", "Failed to write run summary", map[string]interface{}{
+			"path":  path,
+			"error": err.Error(),
+		})
+		return
+	}
+
+	app.ui.Info(fmt.Sprintf("Run summary written to %s", path))
+}
+
+// passFail renders a boolean check result
+func passFail(success bool) string {
+	if success {
+		return "Passed"
+	}
+	return "Failed"
+}
@@ -0,0 +1,205 @@
+package app
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"ccw/config"
+	"ccw/types"
+)
+
+func populatedRunSummary() *RunSummary {
+	started := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	return &RunSummary{
+		Issue: &types.Issue{
+			Number:  42,
+			Title:   "Fix parser crash",
+			HTMLURL: "https://github.com/owner/repo/issues/42",
+		},
+		IssueURL:     "https://github.com/owner/repo/issues/42",
+		BranchName:   "issue-42-20240101-100000",
+		WorktreePath: "/tmp/worktree",
+		CommitSHA:    "0123456789abcdef0123456789abcdef01234567",
+		DiffStat:     " parser.go | 10 +++++++---\n 1 file changed, 7 insertions(+), 3 deletions(-)",
+		Validation: &types.ValidationResult{
+			Success:     true,
+			LintResult:  &types.LintResult{Success: true},
+			BuildResult: &types.BuildResult{Success: true},
+			TestResult:  &types.TestResult{Success: true, TestCount: 12, Passed: 12},
+		},
+		PRURL:      "https://github.com/owner/repo/pull/43",
+		StartedAt:  started,
+		FinishedAt: started.Add(3*time.Minute + 20*time.Second),
+	}
+}
+
+func TestRenderRunSummary(t *testing.T) {
+	content := RenderRunSummary(populatedRunSummary())
+
+	expected := []string{
+		"# CCW Run Summary: #42 Fix parser crash",
+		"- **Status:** Success",
+		"- **Issue:** [#42](https://github.com/owner/repo/issues/42) Fix parser crash",
+		"- **Branch:** `issue-42-20240101-100000`",
+		"- **Commit:** `0123456789abcdef0123456789abcdef01234567`",
+		"- **Pull Request:** https://github.com/owner/repo/pull/43",
+		"- **Duration:** 3m20s",
+		"```\n parser.go | 10 +++++++---\n 1 file changed, 7 insertions(+), 3 deletions(-)\n```",
+		"| Lint | Passed |",
+		"| Build | Passed |",
+		"| Tests | Passed (12/12 passed) |",
+		"| Overall | Passed |",
+	}
+	for _, want := range expected {
+		if !strings.Contains(content, want) {
+			t.Errorf("Expected summary to contain %q, got:\n%s", want, content)
+		}
+	}
+	if strings.Contains(content, "### Errors") {
+		t.Error("Expected no errors section for a successful run")
+	}
+}
+
+func TestRenderRunSummaryFailedRun(t *testing.T) {
+	summary := populatedRunSummary()
+	summary.Error = errors.New("validation failed after 3 recovery attempts")
+	summary.PRURL = ""
+	summary.DiffStat = ""
+	summary.Validation = &types.ValidationResult{
+		Success:     false,
+		BuildResult: &types.BuildResult{Success: false},
+		Errors: []types.ValidationError{
+			{Type: "build", Message: "undefined: Token"},
+		},
+	}
+
+	content := RenderRunSummary(summary)
+
+	expected := []string{
+		"- **Status:** Failed: validation failed after 3 recovery attempts",
+		"No committed changes.",
+		"| Build | Failed |",
+		"| Overall | Failed |",
+		"### Errors",
+		"- **build:** undefined: Token",
+	}
+	for _, want := range expected {
+		if !strings.Contains(content, want) {
+			t.Errorf("Expected summary to contain %q, got:\n%s", want, content)
+		}
+	}
+	if strings.Contains(content, "Pull Request") {
+		t.Error("Expected no pull request line when no PR was created")
+	}
+}
+
+func TestRenderRunSummaryWithoutIssue(t *testing.T) {
+	content := RenderRunSummary(&RunSummary{
+		IssueURL: "https://github.com/owner/repo/issues/7",
+		Error:    errors.New("failed to fetch issue data"),
+	})
+
+	if !strings.Contains(content, "# CCW Run Summary\n") {
+		t.Errorf("Expected generic title, got:\n%s", content)
+	}
+	if !strings.Contains(content, "- **Issue:** https://github.com/owner/repo/issues/7") {
+		t.Errorf("Expected issue URL line, got:\n%s", content)
+	}
+	if !strings.Contains(content, "Validation did not run.") {
+		t.Errorf("Expected validation note, got:\n%s", content)
+	}
+}
+
+func TestWriteRunSummary(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "ccw-summary-test-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	path := filepath.Join(tmpDir, "reports", "summary.md")
+	summary := populatedRunSummary()
+	if err := WriteRunSummary(path, summary); err != nil {
+		t.Fatalf("WriteRunSummary failed: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read summary: %v", err)
+	}
+	if string(data) != RenderRunSummary(summary) {
+		t.Errorf("Expected written file to match rendered summary, got:\n%s", string(data))
+	}
+}
+
+func TestParseWorkflowArgsSummaryOut(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        []string
+		expected    string
+		expectError bool
+	}{
+		{"separate value", []string{"https://github.com/o/r/issues/1", "--summary-out", "out/summary.md"}, "out/summary.md", false},
+		{"equals value", []string{"--summary-out=report.md", "https://github.com/o/r/issues/1"}, "report.md", false},
+		{"missing value", []string{"https://github.com/o/r/issues/1", "--summary-out"}, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, options, err := ParseWorkflowArgs(tt.args)
+			if tt.expectError {
+				if err == nil {
+					t.Error("Expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if options.SummaryOut != tt.expected {
+				t.Errorf("Expected SummaryOut '%s', got '%s'", tt.expected, options.SummaryOut)
+			}
+		})
+	}
+}
+
+func TestRunSummaryInWorktreeIsNotCommitted(t *testing.T) {
+	repoDir, runGit := setupInPlaceRepo(t, "issue-3")
+
+	tests := []struct {
+		name        string
+		metadataDir string
+		expected    string
+	}{
+		{"default directory", "", filepath.Join(repoDir, ".ccw", runSummaryFileName)},
+		{"metadata directory", ".ccw-meta", filepath.Join(repoDir, ".ccw-meta", runSummaryFileName)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := &CCWApp{
+				ccwConfig: &config.CCWConfig{Git: config.GitConfiguration{MetadataDir: tt.metadataDir}},
+				options:   &WorkflowOptions{},
+			}
+			summary := &RunSummary{WorktreePath: repoDir, Issue: &types.Issue{Number: 3, Title: "Add lexer"}}
+
+			path := app.runSummaryPath(summary)
+			if path != tt.expected {
+				t.Fatalf("Expected '%s', got '%s'", tt.expected, path)
+			}
+			if err := app.saveRunSummary(path, summary); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if _, err := os.Stat(path); err != nil {
+				t.Errorf("Expected the summary at %s: %v", path, err)
+			}
+			if status := runGit("status", "--porcelain", "--untracked-files=all"); status != "" {
+				t.Errorf("Expected the summary to be ignored, got status:\n%s", status)
+			}
+		})
+	}
+}
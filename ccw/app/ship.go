@@ -0,0 +1,345 @@
+package app
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"ccw/config"
+	"ccw/git"
+	"ccw/github"
+	"ccw/hooks"
+	"ccw/types"
+)
+
+// Shipping existing local work without an issue
+
+// ShipOptions holds flags for `ccw ship`
+type ShipOptions struct {
+	Title string // PR title; defaults to the latest commit subject
+}
+
+// ParseShipArgs parses the arguments following `ccw ship`
+func ParseShipArgs(args []string) (*ShipOptions, error) {
+	options := &ShipOptions{}
+
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		switch {
+		case arg == "--title":
+			if i+1 >= len(args) {
+				return nil, fmt.Errorf("--title requires a value")
+			}
+			i++
+			options.Title = args[i]
+		case strings.HasPrefix(arg, "--title="):
+			options.Title = strings.TrimPrefix(arg, "--title=")
+		default:
+			return nil, fmt.Errorf("unknown option %s", arg)
+		}
+	}
+
+	return options, nil
+}
+
+// shipStep is a workflow progress step and whether `ccw ship` runs it
+type shipStep struct {
+	ID     string
+	Skip   bool
+	Reason string
+}
+
+// planShipSteps returns the progress steps for shipping local work. Issue
+// fetch, analysis and Claude implementation never run, and commit only runs
+// when the working tree has uncommitted changes.
+func planShipSteps(hasUncommittedChanges bool) []shipStep {
+	commit := shipStep{ID: "commit"}
+	if !hasUncommittedChanges {
+		commit.Skip = true
+		commit.Reason = "no uncommitted changes"
+	}
+
+	return []shipStep{
+		{ID: "setup"},
+		{ID: "fetch", Skip: true, Reason: "no issue"},
+		{ID: "analysis", Skip: true, Reason: "no issue"},
+		{ID: "implementation", Skip: true, Reason: "changes already made"},
+		{ID: "validation"},
+		commit,
+		{ID: "pr_generation"},
+		{ID: "pr_creation"},
+		{ID: "complete"},
+	}
+}
+
+// HandleShipCommand validates, commits, pushes and opens a PR for the current branch
+func HandleShipCommand() {
+	options, err := ParseShipArgs(os.Args[2:])
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		fmt.Println("Usage: ccw ship [--title TITLE]")
+		os.Exit(1)
+	}
+
+	ccwApp, err := NewCCWApp()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to initialize application: %v\n", err)
+		os.Exit(1)
+	}
+	defer ccwApp.Cleanup()
+
+	if err := ccwApp.ExecuteShipWorkflow(options); err != nil {
+		fmt.Fprintf(os.Stderr, "Ship failed: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// ExecuteShipWorkflow runs validation, commit, push and PR creation on the
+// current branch of the repository in the working directory. Unlike the issue
+// workflow it works in place: no worktree is created or removed.
+func (app *CCWApp) ExecuteShipWorkflow(options *ShipOptions) error {
+	if options == nil {
+		options = &ShipOptions{}
+	}
+	app.ui.DisplayHeader()
+
+	// Setup: describe the current checkout as the "worktree"
+	app.setPhase("setup")
+	app.ui.UpdateProgress("setup", "in_progress")
+	if err := app.setupShipCheckout(); err != nil {
+		app.ui.UpdateProgress("setup", "failed")
+		return err
+	}
+	app.applyDotEnv()
+	app.ui.UpdateProgress("setup", "completed")
+	repoPath := app.worktreeConfig.WorktreePath
+	branchName := app.worktreeConfig.BranchName
+	app.ui.Info(fmt.Sprintf("Shipping branch %s from %s/%s", branchName, app.worktreeConfig.Owner, app.worktreeConfig.Repository))
+
+	hasChanges, err := app.gitOps.HasUncommittedChanges(repoPath)
+	if err != nil {
+		return fmt.Errorf("failed to check for uncommitted changes: %w", err)
+	}
+
+	plan := planShipSteps(hasChanges)
+	commitPlanned := false
+	for _, step := range plan {
+		if step.Skip {
+			app.ui.UpdateProgress(step.ID, "completed")
+			app.debugStep("ship", "Skipping step", map[string]interface{}{
+				"step":   step.ID,
+				"reason": step.Reason,
+			})
+		} else if step.ID == "commit" {
+			commitPlanned = true
+		}
+	}
+
+	// Validation: there is no issue for Claude to recover against, so failures stop here
+	validationResult, err := app.validateImplementation()
+	if err != nil {
+		return err
+	}
+	typesValidationResult := ConvertValidationResult(validationResult)
+	if !validationResult.Success {
+		app.ui.UpdateProgress("validation", "failed")
+		app.ui.Error(app.formatValidationErrorsForDisplay(typesValidationResult))
+		app.emitActionsAnnotations(typesValidationResult)
+		return fmt.Errorf("validation failed with %d error(s); fix them and run ccw ship again", len(validationResult.Errors))
+	}
+	if err := app.runHooks(hooks.PhasePostValidation, nil, map[string]string{
+		"CCW_VALIDATION_SUCCESS": "true",
+	}); err != nil {
+		return err
+	}
+
+	if commitPlanned {
+		if err := app.commitShipChanges(options.Title); err != nil {
+			return err
+		}
+	}
+
+	title := options.Title
+	if title == "" {
+		title = app.headCommitSubject()
+	}
+	if title == "" {
+		title = branchName
+	}
+
+	if err := app.runHooks(hooks.PhasePrePush, nil, nil); err != nil {
+		return err
+	}
+	if err := app.pushChangesToRemote(branchName, repoPath); err != nil {
+		return err
+	}
+
+	app.setPhase("pr_generation")
+	app.ui.UpdateProgress("pr_generation", "in_progress")
+	diffStat, _ := app.gitOps.DiffStat(repoPath, app.shipBaseBranch())
+	body := shipPRDescription(diffStat, typesValidationResult)
+	app.ui.UpdateProgress("pr_generation", "completed")
+
+	return app.createShipPR(title, body, branchName, repoPath)
+}
+
+// setupShipCheckout fills worktreeConfig from the current repository and branch,
+// refusing to ship from the default branch or a detached HEAD
+func (app *CCWApp) setupShipCheckout() error {
+	rootOutput, err := git.CreateGitCommand([]string{"rev-parse", "--show-toplevel"}, ".").Output()
+	if err != nil {
+		return fmt.Errorf("ccw ship must be run inside a git repository: %w", err)
+	}
+	repoPath := strings.TrimSpace(string(rootOutput))
+
+	branchName, err := app.gitOps.GetCurrentBranch(repoPath)
+	if err != nil || branchName == "" {
+		return fmt.Errorf("ccw ship requires a checked-out branch (detached HEAD is not supported)")
+	}
+	if branchName == app.shipBaseBranch() {
+		return fmt.Errorf("refusing to ship from the default branch %s; create a feature branch first", branchName)
+	}
+
+	repoURL, err := github.GetCurrentRepoURL()
+	if err != nil {
+		return fmt.Errorf("failed to detect the GitHub repository: %w", err)
+	}
+	owner, repo, err := github.ExtractRepoInfo(repoURL)
+	if err != nil {
+		return fmt.Errorf("failed to parse repository URL %s: %w", repoURL, err)
+	}
+
+	app.worktreeConfig = &git.WorktreeConfig{
+		BasePath:     repoPath,
+		BranchName:   branchName,
+		WorktreePath: repoPath,
+		CreatedAt:    time.Now(),
+		Owner:        owner,
+		Repository:   repo,
+	}
+	return nil
+}
+
+// shipBaseBranch returns the branch PRs from `ccw ship` target
+func (app *CCWApp) shipBaseBranch() string {
+	if app.ccwConfig != nil && app.ccwConfig.Git.DefaultBranch != "" {
+		return app.ccwConfig.Git.DefaultBranch
+	}
+	return config.GetDefaultCCWConfig().Git.DefaultBranch
+}
+
+// commitShipChanges commits uncommitted work, using title as the message when given
+func (app *CCWApp) commitShipChanges(title string) error {
+	app.setPhase("commit")
+	app.ui.UpdateProgress("commit", "in_progress")
+	app.ui.Info("Committing changes...")
+
+	if err := app.checkCommitGuards(); err != nil {
+		app.ui.UpdateProgress("commit", "failed")
+		return err
+	}
+
+	message := title
+	if message == "" {
+		message = "chore: ship local changes"
+		select {
+		case result := <-app.commitGenerator.GenerateEnhancedCommitMessageAsync(app.worktreeConfig.WorktreePath, nil):
+			if result.Error == nil && strings.TrimSpace(result.Message) != "" {
+				message = result.Message
+			}
+		case <-time.After(30 * time.Second):
+			app.ui.Warning("Commit message generation timed out, using fallback")
+		}
+	}
+
+	message = app.finishCommitMessage(nil, message)
+	if err := app.gitOps.CommitChanges(app.worktreeConfig.WorktreePath, message); err != nil {
+		app.ui.UpdateProgress("commit", "failed")
+		return fmt.Errorf("failed to commit changes: %w", err)
+	}
+
+	app.ui.UpdateProgress("commit", "completed")
+	app.ui.Success("Changes committed successfully!")
+	return nil
+}
+
+// headCommitSubject returns the subject line of HEAD, or "" if unavailable
+func (app *CCWApp) headCommitSubject() string {
+	output, err := git.CreateGitCommand([]string{"log", "-1", "--format=%s"}, app.worktreeConfig.WorktreePath).Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(output))
+}
+
+// shipPRDescription builds the PR body from the branch diff and validation
+// results, ending with the ccw version footer
+func shipPRDescription(diffStat string, validationResult *types.ValidationResult) string {
+	var body strings.Builder
+	body.WriteString("## Summary\n\nShipped from local changes with `ccw ship`.\n")
+
+	if strings.TrimSpace(diffStat) != "" {
+		body.WriteString("\n## Changes\n\n```\n")
+		body.WriteString(strings.TrimSpace(diffStat))
+		body.WriteString("\n```\n")
+	}
+
+	if validationResult != nil {
+		body.WriteString("\n## Validation\n\n")
+		body.WriteString(fmt.Sprintf("- Lint: %s\n", shipCheckStatus(validationResult.LintResult != nil, validationResult.LintResult != nil && validationResult.LintResult.Success)))
+		body.WriteString(fmt.Sprintf("- Build: %s\n", shipCheckStatus(validationResult.BuildResult != nil, validationResult.BuildResult != nil && validationResult.BuildResult.Success)))
+		body.WriteString(fmt.Sprintf("- Tests: %s\n", shipCheckStatus(validationResult.TestResult != nil, validationResult.TestResult != nil && validationResult.TestResult.Success)))
+	}
+
+	return withGeneratedByFooter(body.String(), Version)
+}
+
+// shipCheckStatus describes one validation check in the PR body
+func shipCheckStatus(ran, success bool) string {
+	if !ran {
+		return "Skipped"
+	}
+	return passFail(success)
+}
+
+// createShipPR opens the pull request and monitors CI, leaving the checkout in place
+func (app *CCWApp) createShipPR(title, body, branchName, repoPath string) error {
+	app.setPhase("pr_creation")
+	app.ui.UpdateProgress("pr_creation", "in_progress")
+	app.ui.Info("Creating pull request...")
+
+	prRequest := &types.PRRequest{
+		Title: title,
+		Body:  body,
+		Head:  branchName,
+		Base:  app.shipBaseBranch(),
+	}
+	app.targetPRAtBaseRepo(prRequest, repoPath, app.worktreeConfig.Owner, app.worktreeConfig.Repository)
+	app.applyMaintainerCanModify(prRequest)
+
+	select {
+	case prResult := <-app.prManager.CreatePullRequestAsync(prRequest, repoPath):
+		if prResult.Error != nil {
+			app.ui.UpdateProgress("pr_creation", "failed")
+			return fmt.Errorf("failed to create PR: %w", prResult.Error)
+		}
+		app.ui.UpdateProgress("pr_creation", "completed")
+		app.ui.Success(fmt.Sprintf("Pull request created: %s", prResult.PullRequest.HTMLURL))
+		app.applyPRLabels(nil, prResult.PullRequest.HTMLURL)
+
+		if err := app.runHooks(hooks.PhasePostPR, nil, map[string]string{
+			"CCW_PR_URL": prResult.PullRequest.HTMLURL,
+		}); err != nil {
+			return err
+		}
+		app.monitorCIChecksWithGoroutines(prResult.PullRequest.HTMLURL)
+		app.deleteBranchIfMerged(prResult.PullRequest.HTMLURL, branchName, repoPath)
+	case <-time.After(1 * time.Minute):
+		app.ui.UpdateProgress("pr_creation", "failed")
+		return fmt.Errorf("PR creation timed out")
+	}
+
+	app.ui.UpdateProgress("complete", "completed")
+	return nil
+}
@@ -0,0 +1,128 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	"ccw/config"
+	"ccw/types"
+)
+
+func TestParseShipArgs(t *testing.T) {
+	tests := []struct {
+		name          string
+		args          []string
+		expectedTitle string
+		expectError   bool
+	}{
+		{"no arguments", nil, "", false},
+		{"separate title", []string{"--title", "Add lexer tests"}, "Add lexer tests", false},
+		{"equals title", []string{"--title=Fix parser"}, "Fix parser", false},
+		{"missing title value", []string{"--title"}, "", true},
+		{"unknown option", []string{"--draft"}, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options, err := ParseShipArgs(tt.args)
+			if tt.expectError {
+				if err == nil {
+					t.Error("Expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if options.Title != tt.expectedTitle {
+				t.Errorf("Expected title '%s', got '%s'", tt.expectedTitle, options.Title)
+			}
+		})
+	}
+}
+
+func TestPlanShipStepsSkipsIssueSteps(t *testing.T) {
+	tests := []struct {
+		name            string
+		hasChanges      bool
+		expectedSkipped []string
+	}{
+		{"uncommitted changes", true, []string{"fetch", "analysis", "implementation"}},
+		{"clean working tree", false, []string{"fetch", "analysis", "implementation", "commit"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var skipped, run []string
+			for _, step := range planShipSteps(tt.hasChanges) {
+				if step.Skip {
+					if step.Reason == "" {
+						t.Errorf("Expected a reason for skipping %s", step.ID)
+					}
+					skipped = append(skipped, step.ID)
+				} else {
+					run = append(run, step.ID)
+				}
+			}
+
+			if strings.Join(skipped, ",") != strings.Join(tt.expectedSkipped, ",") {
+				t.Errorf("Expected skipped steps %v, got %v", tt.expectedSkipped, skipped)
+			}
+			for _, required := range []string{"setup", "validation", "pr_generation", "pr_creation"} {
+				found := false
+				for _, id := range run {
+					found = found || id == required
+				}
+				if !found {
+					t.Errorf("Expected step %s to run, ran %v", required, run)
+				}
+			}
+		})
+	}
+}
+
+func TestPlanShipStepsMatchesProgressSteps(t *testing.T) {
+	known := map[string]bool{}
+	for _, id := range []string{"setup", "fetch", "analysis", "implementation", "validation", "commit", "pr_generation", "pr_creation", "complete"} {
+		known[id] = true
+	}
+	for _, step := range planShipSteps(true) {
+		if !known[step.ID] {
+			t.Errorf("Unknown progress step %s", step.ID)
+		}
+	}
+}
+
+func TestShipPRDescription(t *testing.T) {
+	validation := &types.ValidationResult{
+		Success:     true,
+		LintResult:  &types.LintResult{Success: true},
+		BuildResult: &types.BuildResult{Success: true},
+	}
+
+	body := shipPRDescription(" Sources/Lexer.swift | 4 ++--\n", validation)
+	for _, expected := range []string{"ccw ship", "Sources/Lexer.swift | 4 ++--", "- Lint: Passed", "- Build: Passed", "- Tests: Skipped"} {
+		if !strings.Contains(body, expected) {
+			t.Errorf("Expected PR body to contain '%s', got:\n%s", expected, body)
+		}
+	}
+	if strings.Contains(body, "Resolves #") {
+		t.Errorf("Expected no issue reference without an issue, got:\n%s", body)
+	}
+
+	if body := shipPRDescription("", nil); strings.Contains(body, "## Changes") || strings.Contains(body, "## Validation") {
+		t.Errorf("Expected only the summary without diff or validation, got:\n%s", body)
+	}
+}
+
+func TestShipBaseBranch(t *testing.T) {
+	app := &CCWApp{ccwConfig: &config.CCWConfig{Git: config.GitConfiguration{DefaultBranch: "main"}}}
+	if got := app.shipBaseBranch(); got != "main" {
+		t.Errorf("Expected 'main', got '%s'", got)
+	}
+
+	app = &CCWApp{}
+	if got := app.shipBaseBranch(); got != config.GetDefaultCCWConfig().Git.DefaultBranch {
+		t.Errorf("Expected the default branch, got '%s'", got)
+	}
+}
@@ -0,0 +1,55 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	"ccw/hooks"
+	"ccw/types"
+)
+
+// Post-PR smoke checks reported on the pull request
+
+// smokeCommand returns the configured hooks.post_pr_smoke command, or "" when disabled
+func (app *CCWApp) smokeCommand() string {
+	if app.ccwConfig == nil {
+		return ""
+	}
+	return strings.TrimSpace(app.ccwConfig.Hooks.PostPRSmoke)
+}
+
+// runPostPRSmoke runs the hooks.post_pr_smoke command in the worktree and posts
+// its result as a comment on prURL. A failing smoke check is reported, not fatal.
+func (app *CCWApp) runPostPRSmoke(issue *types.Issue, prURL string) {
+	command := app.smokeCommand()
+	if command == "" || app.hookRunner == nil {
+		return
+	}
+
+	app.ui.Info("Running post-PR smoke check...")
+	result := app.hookRunner.RunSmoke(command, app.worktreeConfig.WorktreePath, app.hookEnv(issue, map[string]string{
+		"CCW_PR_URL": prURL,
+	}))
+
+	fields := map[string]interface{}{
+		"command":  command,
+		"passed":   result.Passed,
+		"duration": result.Duration.String(),
+	}
+	if result.Passed {
+		app.logger.Info("hooks", "Post-PR smoke check passed", fields)
+		app.ui.Success("Smoke check passed")
+	} else {
+		fields["error"] = result.Err.Error()
+		app.logger.Warn("hooks", "Post-PR smoke check failed", fields)
+		app.ui.Warning(fmt.Sprintf("Smoke check failed: %v", result.Err))
+	}
+
+	if err := app.prManager.PostComment(prURL, hooks.FormatSmokeComment(result)); err != nil {
+		app.logger.Warn("hooks", "Failed to post smoke check result", map[string]interface{}{
+			"pr_url": prURL,
+			"error":  err.Error(),
+		})
+		app.ui.Warning(fmt.Sprintf("Could not post smoke check result: %v", err))
+	}
+}
@@ -0,0 +1,66 @@
+package app
+
+import (
+	"fmt"
+
+	"ccw/git"
+	"ccw/github"
+	"ccw/types"
+)
+
+// updateIssueTaskList checks off the issue's task list items that Claude
+// judges complete. Failures are reported but never fail the workflow.
+func (app *CCWApp) updateIssueTaskList(issue *types.Issue) {
+	if app.ccwConfig == nil || !app.ccwConfig.Workflow.UpdateTaskList {
+		return
+	}
+
+	tasks := github.UncheckedTasks(issue.Body)
+	if len(tasks) == 0 {
+		return
+	}
+
+	// Every change on the branch counts, including recovery commits
+	diffStat, err := app.gitOps.DiffWithOptions(app.worktreeConfig.WorktreePath, "", git.DiffOptions{Stat: true})
+	if err != nil {
+		app.ui.Warning(fmt.Sprintf("Could not summarise the branch changes for the task list: %v", err))
+		app.logger.Warn("workflow", "Task list diff failed", map[string]interface{}{
+			"error":        err.Error(),
+			"issue_number": issue.Number,
+		})
+		return
+	}
+
+	app.ui.Info(fmt.Sprintf("Checking %d open task list item(s) against the implementation...", len(tasks)))
+	completed, err := app.claudeIntegration.IdentifyCompletedTasks(app.worktreeConfig.WorktreePath, diffStat, tasks)
+	if err != nil {
+		app.ui.Warning(fmt.Sprintf("Could not determine completed tasks: %v", err))
+		app.logger.Warn("workflow", "Task list analysis failed", map[string]interface{}{
+			"error":        err.Error(),
+			"issue_number": issue.Number,
+		})
+		return
+	}
+
+	body, changed := github.CheckTaskListItems(issue.Body, completed)
+	if changed == 0 {
+		app.ui.Info("No task list items were marked complete")
+		return
+	}
+
+	if err := app.githubClient.UpdateIssueBody(app.worktreeConfig.Owner, app.worktreeConfig.Repository, issue.Number, body); err != nil {
+		app.ui.Warning(fmt.Sprintf("Failed to update issue task list: %v", err))
+		app.logger.Warn("workflow", "Issue task list update failed", map[string]interface{}{
+			"error":        err.Error(),
+			"issue_number": issue.Number,
+		})
+		return
+	}
+
+	issue.Body = body
+	app.ui.Success(fmt.Sprintf("Checked off %d task list item(s) on issue #%d", changed, issue.Number))
+	app.logger.Info("workflow", "Issue task list updated", map[string]interface{}{
+		"issue_number":  issue.Number,
+		"checked_items": changed,
+	})
+}
@@ -0,0 +1,75 @@
+package app
+
+import (
+	"fmt"
+	"sort"
+
+	"ccw/config"
+	"ccw/git"
+)
+
+// checkTestPolicy enforces policy.require_tests: the run's changes must add or
+// modify at least one test file. Without one the commit is blocked unless
+// policy.require_tests_action is "warn".
+func (app *CCWApp) checkTestPolicy() error {
+	policy := config.GetDefaultCCWConfig().Policy
+	if app.ccwConfig != nil {
+		policy = app.ccwConfig.Policy
+	}
+	if !policy.RequireTests {
+		return nil
+	}
+
+	changedFiles, err := app.runChangedFiles()
+	if err != nil {
+		app.logger.Warn("workflow", "Failed to list changed files for test policy check", map[string]interface{}{
+			"error": err.Error(),
+		})
+		return nil
+	}
+
+	testFiles := git.ChangedTestFiles(changedFiles, policy.TestPatterns...)
+	app.logger.Info("workflow", "Checked changes for test files", map[string]interface{}{
+		"changed_files": len(changedFiles),
+		"test_files":    testFiles,
+		"action":        policy.RequireTestsAction,
+	})
+	if len(testFiles) > 0 {
+		return nil
+	}
+
+	if policy.RequireTestsAction == "warn" {
+		app.ui.Warning(fmt.Sprintf("No test files changed among %d changed file(s); policy.require_tests is set", len(changedFiles)))
+		return nil
+	}
+
+	app.ui.Error("No test files changed; policy.require_tests needs every change to include tests")
+	return fmt.Errorf("refusing to commit %d changed file(s) without tests: add or update a test file, or set policy.require_tests_action to 'warn'", len(changedFiles))
+}
+
+// runChangedFiles returns the files changed by this run: uncommitted and
+// untracked files plus anything already committed on the branch, such as
+// recovery commits
+func (app *CCWApp) runChangedFiles() ([]string, error) {
+	worktreePath := app.worktreeConfig.WorktreePath
+
+	uncommitted, err := app.gitOps.ChangedFiles(worktreePath)
+	if err != nil {
+		return nil, err
+	}
+	committed, err := app.gitOps.DiffFiles(worktreePath, "")
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool)
+	var files []string
+	for _, file := range append(uncommitted, committed...) {
+		if !seen[file] {
+			seen[file] = true
+			files = append(files, file)
+		}
+	}
+	sort.Strings(files)
+	return files, nil
+}
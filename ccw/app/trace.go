@@ -0,0 +1,42 @@
+package app
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"strings"
+
+	"ccw/logging"
+)
+
+// Trace output routing for --trace-file
+
+// traceCaller returns the function that called into the tracing helpers,
+// skipping traceFunction and debugStep themselves
+func traceCaller() string {
+	pcs := make([]uintptr, 8)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(2, pcs)])
+	for {
+		frame, more := frames.Next()
+		if !strings.HasSuffix(frame.Function, ".traceFunction") && !strings.HasSuffix(frame.Function, ".debugStep") {
+			return fmt.Sprintf("%s:%d", strings.TrimPrefix(frame.Function, "ccw/"), frame.Line)
+		}
+		if !more {
+			return ""
+		}
+	}
+}
+
+// applyTraceFile turns on trace mode with traces written to path, or to
+// .ccw/trace-<session>.log when path is empty, keeping them out of the main log
+func (app *CCWApp) applyTraceFile(path string) {
+	if path == "" {
+		path = logging.DefaultTracePath(app.sessionID)
+	}
+	if err := app.logger.SetTraceFile(path); err != nil {
+		app.ui.Warning(fmt.Sprintf("Could not open trace file, tracing to the main log instead: %v", err))
+	} else {
+		app.ui.Info(fmt.Sprintf("Writing function traces to %s", path))
+	}
+	os.Setenv("TRACE_MODE", "true")
+}
@@ -0,0 +1,68 @@
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"ccw/logging"
+)
+
+func TestParseWorkflowArgsTraceFile(t *testing.T) {
+	tests := []struct {
+		name        string
+		arg         string
+		expectedOn  bool
+		expected    string
+		expectError bool
+	}{
+		{"default path", "--trace-file", true, "", false},
+		{"explicit path", "--trace-file=/tmp/ccw-trace.log", true, "/tmp/ccw-trace.log", false},
+		{"empty path", "--trace-file=", false, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, options, err := ParseWorkflowArgs([]string{tt.arg, "https://github.com/o/r/issues/1"})
+			if tt.expectError {
+				if err == nil {
+					t.Error("Expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if options.TraceToFile != tt.expectedOn || options.TraceFile != tt.expected {
+				t.Errorf("Expected trace %v to '%s', got %v to '%s'", tt.expectedOn, tt.expected, options.TraceToFile, options.TraceFile)
+			}
+		})
+	}
+}
+
+func TestTraceFunctionWritesToTraceFile(t *testing.T) {
+	t.Setenv("TRACE_MODE", "true")
+	logger, err := logging.NewLogger("trace-app-test", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tracePath := filepath.Join(t.TempDir(), "trace.log")
+	if err := logger.SetTraceFile(tracePath); err != nil {
+		t.Fatal(err)
+	}
+
+	app := &CCWApp{logger: logger}
+	app.traceFunction("validateImplementation", map[string]interface{}{"attempt": 2})
+	logger.Close()
+
+	data, err := os.ReadFile(tracePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, expected := range []string{"FUNCTION: validateImplementation", "caller=app.TestTraceFunctionWritesToTraceFile", `params={"attempt":2}`} {
+		if !strings.Contains(string(data), expected) {
+			t.Errorf("Expected trace to contain '%s', got: %s", expected, data)
+		}
+	}
+}
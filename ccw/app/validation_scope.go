@@ -0,0 +1,45 @@
+package app
+
+import (
+	"fmt"
+)
+
+// validationSince returns the ref validation is scoped to: --since-commit,
+// then validation.since, or "" to validate every file
+func (app *CCWApp) validationSince() string {
+	if app.options != nil && app.options.SinceCommit != "" {
+		return app.options.SinceCommit
+	}
+	if app.ccwConfig != nil {
+		return app.ccwConfig.Validation.Since
+	}
+	return ""
+}
+
+// scopeValidation limits lint to the files changed since the configured ref.
+// When the changed files cannot be listed, for example because a rebase
+// dropped the ref, validation falls back to the whole project.
+func (app *CCWApp) scopeValidation() {
+	since := app.validationSince()
+	if since == "" {
+		app.validator.ClearScope()
+		return
+	}
+
+	files, err := app.gitOps.ChangedFilesSince(app.projectPath(), since)
+	if err != nil {
+		app.validator.ClearScope()
+		app.ui.Warning(fmt.Sprintf("Validating all files: %v", err))
+		app.logger.Warn("workflow", "Failed to scope validation", map[string]interface{}{
+			"since": since,
+			"error": err.Error(),
+		})
+		return
+	}
+
+	app.validator.ScopeToFiles(files)
+	app.logger.Info("workflow", "Scoped validation to changed files", map[string]interface{}{
+		"since": since,
+		"files": len(files),
+	})
+}
@@ -0,0 +1,51 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	"ccw/consoleui"
+	"ccw/types"
+)
+
+// Warning limits for the console summary and the PR body footer
+const (
+	consoleWarningLimit  = 10
+	prFooterWarningLimit = 50
+)
+
+// reportValidationWarnings prints a summary of non-blocking validation
+// warnings, including after successful validation
+func (app *CCWApp) reportValidationWarnings(warnings []types.ValidationWarning) {
+	if len(warnings) == 0 {
+		return
+	}
+	warningIcon := consoleui.Char("⚠️", "[WARNING]")
+	app.ui.Warning(fmt.Sprintf("%s %d validation warning(s), not blocking:\n%s", warningIcon, len(warnings),
+		strings.TrimRight(types.FormatWarningSummary(warnings, consoleWarningLimit, "  - "), "\n")))
+}
+
+// includeWarningsInPR reports whether pr.include_warnings is set
+func (app *CCWApp) includeWarningsInPR() bool {
+	return app.ccwConfig != nil && app.ccwConfig.PR.IncludeWarnings
+}
+
+// warningsFooter renders validation warnings as a collapsed PR body footer,
+// or "" when there are none
+func warningsFooter(warnings []types.ValidationWarning) string {
+	if len(warnings) == 0 {
+		return ""
+	}
+
+	return fmt.Sprintf("\n\n---\n\n<details>\n<summary>⚠️ %d validation warning(s)</summary>\n\n%s\n</details>\n",
+		len(warnings), types.FormatWarningSummary(warnings, prFooterWarningLimit, "- "))
+}
+
+// withWarningsFooter appends the validation warnings to a PR body when
+// pr.include_warnings is set
+func (app *CCWApp) withWarningsFooter(body string, validationResult *types.ValidationResult) string {
+	if !app.includeWarningsInPR() || validationResult == nil {
+		return body
+	}
+	return strings.TrimRight(body, "\n") + warningsFooter(validationResult.Warnings)
+}
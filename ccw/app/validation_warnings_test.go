@@ -0,0 +1,36 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	"ccw/config"
+	"ccw/types"
+)
+
+func TestWithWarningsFooter(t *testing.T) {
+	result := &types.ValidationResult{
+		Success:  true,
+		Warnings: []types.ValidationWarning{{Type: "lint", File: "A.swift", Line: 4, Message: "Line too long"}},
+	}
+
+	app := &CCWApp{ccwConfig: config.GetDefaultCCWConfig()}
+	if body := app.withWarningsFooter("Body", result); body != "Body" {
+		t.Errorf("Expected the body unchanged by default, got %q", body)
+	}
+
+	app.ccwConfig.PR.IncludeWarnings = true
+	body := app.withWarningsFooter("Body\n", result)
+	if !strings.HasPrefix(body, "Body\n\n---\n") {
+		t.Errorf("Expected the footer after the body, got %q", body)
+	}
+	for _, expected := range []string{"1 validation warning(s)", "- A.swift:4: Line too long (lint)", "</details>"} {
+		if !strings.Contains(body, expected) {
+			t.Errorf("Expected footer to contain '%s', got %q", expected, body)
+		}
+	}
+
+	if body := app.withWarningsFooter("Body", &types.ValidationResult{Success: true}); body != "Body" {
+		t.Errorf("Expected no footer without warnings, got %q", body)
+	}
+}
@@ -0,0 +1,70 @@
+package app
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// CCW version and provenance annotations on generated commits and PRs
+
+// Version is the ccw version, set at build time:
+//
+//	go build -ldflags "-X ccw/app.Version=v1.2.3"
+var Version = "dev"
+
+// generatedByKey is the trailer and footer key naming the ccw version
+const generatedByKey = "Generated-By"
+
+// trailerLine matches a git trailer such as "Signed-off-by: Name <email>"
+var trailerLine = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9-]*: \S`)
+
+// generatedBy returns "ccw <version>", prefixing numeric versions with v
+func generatedBy(version string) string {
+	version = strings.TrimSpace(version)
+	if version == "" {
+		version = "dev"
+	}
+	if version[0] >= '0' && version[0] <= '9' {
+		version = "v" + version
+	}
+	return "ccw " + version
+}
+
+// withGeneratedByTrailer adds a Generated-By trailer naming version to a
+// commit message, joining an existing trailer block when the message ends
+// with one. Messages that already carry the trailer are returned unchanged.
+func withGeneratedByTrailer(message, version string) string {
+	if strings.Contains(message, "\n"+generatedByKey+": ") {
+		return message
+	}
+
+	message = strings.TrimRight(message, "\n")
+	trailer := fmt.Sprintf("%s: %s", generatedByKey, generatedBy(version))
+
+	paragraphs := strings.Split(message, "\n\n")
+	if len(paragraphs) > 1 && isTrailerBlock(paragraphs[len(paragraphs)-1]) {
+		return message + "\n" + trailer
+	}
+	return message + "\n\n" + trailer
+}
+
+// isTrailerBlock reports whether every line of paragraph is a git trailer
+func isTrailerBlock(paragraph string) bool {
+	for _, line := range strings.Split(strings.TrimSpace(paragraph), "\n") {
+		if !trailerLine.MatchString(line) {
+			return false
+		}
+	}
+	return true
+}
+
+// withGeneratedByFooter appends a footer naming version to a PR body
+func withGeneratedByFooter(body, version string) string {
+	return fmt.Sprintf("%s\n\n---\n\n<sub>%s: %s</sub>\n", strings.TrimRight(body, "\n"), generatedByKey, generatedBy(version))
+}
+
+// PrintVersion prints the ccw version
+func PrintVersion() {
+	fmt.Println(generatedBy(Version))
+}
@@ -0,0 +1,69 @@
+package app
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGeneratedBy(t *testing.T) {
+	tests := []struct {
+		version  string
+		expected string
+	}{
+		{"v1.2.3", "ccw v1.2.3"},
+		{"1.2.3", "ccw v1.2.3"},
+		{"dev", "ccw dev"},
+		{"", "ccw dev"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			if got := generatedBy(tt.version); got != tt.expected {
+				t.Errorf("Expected '%s', got '%s'", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestWithGeneratedByTrailer(t *testing.T) {
+	tests := []struct {
+		name     string
+		message  string
+		expected string
+	}{
+		{"subject only", "feat: add lexer", "feat: add lexer\n\nGenerated-By: ccw v1.2.3"},
+		{"body", "feat: add lexer\n\nResolves #12\n", "feat: add lexer\n\nResolves #12\n\nGenerated-By: ccw v1.2.3"},
+		{"existing trailers", "feat: add lexer\n\nBody text\n\nSigned-off-by: Dev <dev@example.com>",
+			"feat: add lexer\n\nBody text\n\nSigned-off-by: Dev <dev@example.com>\nGenerated-By: ccw v1.2.3"},
+		{"already annotated", "feat: add lexer\n\nGenerated-By: ccw v1.0.0", "feat: add lexer\n\nGenerated-By: ccw v1.0.0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := withGeneratedByTrailer(tt.message, "v1.2.3"); got != tt.expected {
+				t.Errorf("Expected '%s', got '%s'", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestWithGeneratedByFooter(t *testing.T) {
+	body := withGeneratedByFooter("## Summary\n\nAdds a lexer.\n\n", "v1.2.3")
+	if !strings.HasPrefix(body, "## Summary\n\nAdds a lexer.\n\n---\n") {
+		t.Errorf("Expected the footer after the body, got '%s'", body)
+	}
+	if !strings.Contains(body, "Generated-By: ccw v1.2.3") {
+		t.Errorf("Expected the version in the footer, got '%s'", body)
+	}
+}
+
+func TestShipPRDescriptionIncludesVersion(t *testing.T) {
+	original := Version
+	Version = "v9.8.7"
+	defer func() { Version = original }()
+
+	body := shipPRDescription("", nil)
+	if !strings.Contains(body, "Generated-By: ccw v9.8.7") {
+		t.Errorf("Expected the injected version in the PR body, got '%s'", body)
+	}
+}
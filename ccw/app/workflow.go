@@ -1,33 +1,36 @@
 package app
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
+	"os/signal"
 	"path/filepath"
 	"strings"
+	"syscall"
 	"time"
 
-	"ccw/commit"
+	"ccw/config"
+	"ccw/consoleui"
 	"ccw/git"
 	"ccw/github"
+	"ccw/hooks"
+	"ccw/lock"
 	"ccw/types"
+	"ccw/ui"
 )
 
-// getConsoleChar returns console-safe characters based on CI environment
-func getConsoleCharWorkflow(fancy, simple string) string {
-	if os.Getenv("CCW_CONSOLE_MODE") == "true" ||
-		os.Getenv("CI") == "true" ||
-		os.Getenv("GITHUB_ACTIONS") == "true" ||
-		os.Getenv("GITLAB_CI") == "true" ||
-		os.Getenv("JENKINS_URL") != "" {
-		return simple
-	}
-	return fancy
-}
+// issueWatcher runs the refreshing issue selector for ccw list --watch
+var issueWatcher = ui.RunIssueWatchUI
 
-// ExecuteListWorkflow handles interactive issue selection workflow
-func (app *CCWApp) ExecuteListWorkflow(repoURL string, state string, labels []string, limit int) error {
+// ExecuteListWorkflow handles interactive issue selection workflow. A failed issue
+// aborts the remaining ones when failFast is set; otherwise the batch keeps going.
+// Issues are offered in the order ranking gives them. A positive watch interval
+// shows them in a selector that re-fetches the list every interval. A non-empty
+// search is passed to GitHub's issue search as a raw query.
+func (app *CCWApp) ExecuteListWorkflow(repoURL string, state string, labels []string, search string, limit int, ranking ListRanking, failFast bool, watch time.Duration) error {
 	// Extract repository information
 	owner, repo, err := github.ExtractRepoInfo(repoURL)
 	if err != nil {
@@ -37,18 +40,31 @@ func (app *CCWApp) ExecuteListWorkflow(repoURL string, state string, labels []st
 	app.ui.Info(fmt.Sprintf("Fetching issues from %s/%s...", owner, repo))
 
 	// Fetch issues from GitHub
-	issues, err := app.githubClient.ListIssues(owner, repo, state, labels, limit)
+	listIssues := app.listerFor(search)
+	fetchIssues := func() ([]*types.Issue, error) {
+		issues, err := listIssues(owner, repo, state, labels, limit)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch issues: %w", err)
+		}
+		return rankIssues(issues, ranking), nil
+	}
+	issues, err := fetchIssues()
 	if err != nil {
-		return fmt.Errorf("failed to fetch issues: %w", err)
+		return err
 	}
 
-	if len(issues) == 0 {
-		app.ui.Warning("No issues found matching the criteria")
-		return nil
+	var selectedIssues []*types.Issue
+	if watch > 0 {
+		// Watching starts even without matches; new issues show up on refresh
+		selectedIssues, err = issueWatcher(issues, fetchIssues, watch)
+	} else {
+		if len(issues) == 0 {
+			app.ui.Warning("No issues found matching the criteria")
+			return nil
+		}
+		// Display issue selection interface
+		selectedIssues, err = app.ui.DisplayIssueSelection(issues)
 	}
-
-	// Display issue selection interface
-	selectedIssues, err := app.ui.DisplayIssueSelection(issues)
 	if err != nil {
 		return fmt.Errorf("issue selection failed: %w", err)
 	}
@@ -56,28 +72,51 @@ func (app *CCWApp) ExecuteListWorkflow(repoURL string, state string, labels []st
 	app.ui.Info(fmt.Sprintf("Selected %d issue(s) for processing", len(selectedIssues)))
 
 	// Process each selected issue
-	for i, issue := range selectedIssues {
-		app.ui.Info(fmt.Sprintf("Processing issue %d of %d: #%d %s", i+1, len(selectedIssues), issue.Number, issue.Title))
+	processed := 0
+	err = runIssueBatch(selectedIssues, failFast, func(issue *types.Issue) error {
+		processed++
+		app.ui.Info(fmt.Sprintf("Processing issue %d of %d: #%d %s", processed, len(selectedIssues), issue.Number, issue.Title))
 
 		// Construct issue URL
 		issueURL := fmt.Sprintf("https://github.com/%s/%s/issues/%d", owner, repo, issue.Number)
 
 		// Execute normal workflow for this issue
 		if err := app.ExecuteWorkflow(issueURL); err != nil {
-			app.ui.Warning(fmt.Sprintf("Failed to process issue #%d: %v", issue.Number, err))
-			// Continue with next issue rather than failing completely
-			continue
+			if failFast {
+				app.ui.Error(fmt.Sprintf("Failed to process issue #%d: %v (--fail-fast: aborting)", issue.Number, err))
+			} else {
+				app.ui.Warning(fmt.Sprintf("Failed to process issue #%d: %v", issue.Number, err))
+			}
+			return err
 		}
 
 		app.ui.Success(fmt.Sprintf("Successfully processed issue #%d", issue.Number))
+		return nil
+	})
+	app.printBatchReport(newBatchReport(selectedIssues, err))
+	if err != nil {
+		return err
 	}
 
 	app.ui.Success("All selected issues have been processed!")
 	return nil
 }
 
-// ExecuteWorkflow runs the main workflow for a given issue URL
+// ExecuteWorkflow runs the main workflow for a given issue URL and writes a run summary
 func (app *CCWApp) ExecuteWorkflow(issueURL string) error {
+	app.runSummary = &RunSummary{IssueURL: issueURL, StartedAt: time.Now()}
+	err := app.executeWorkflow(issueURL)
+	app.writeRunSummary(err)
+	app.reportFinalFailure(err)
+	app.recordRunHistory(issueURL, app.runSummary.StartedAt, err)
+	if app.ui.Quiet() {
+		fmt.Println(formatQuietResult(app.runSummary, os.Getenv("CCW_LOG_JSON") == "true"))
+	}
+	return err
+}
+
+// executeWorkflow runs the workflow steps for a given issue URL
+func (app *CCWApp) executeWorkflow(issueURL string) error {
 	app.debugStep("executeWorkflow", "Starting workflow execution", map[string]interface{}{
 		"issue_url": issueURL,
 	})
@@ -93,6 +132,7 @@ func (app *CCWApp) ExecuteWorkflow(issueURL string) error {
 		"issue_url": issueURL,
 	})
 
+	app.setPhase("setup")
 	app.ui.UpdateProgress("setup", "in_progress")
 	owner, repo, issueNumber, err := github.ExtractIssueInfo(issueURL)
 	if err != nil {
@@ -110,6 +150,20 @@ func (app *CCWApp) ExecuteWorkflow(issueURL string) error {
 	})
 
 	app.ui.Info(fmt.Sprintf("Processing issue #%d from %s/%s", issueNumber, owner, repo))
+	app.loadETAEstimator(owner, repo)
+
+	// Prevent concurrent runs on the same issue from sharing a worktree
+	issueLock, err := app.acquireIssueLock(issueNumber)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := issueLock.Release(); err != nil {
+			app.logger.Warn("workflow", "Failed to release issue lock", map[string]interface{}{
+				"error": err.Error(),
+			})
+		}
+	}()
 
 	// Step 2: Fetch issue data
 	app.debugStep("step2", "Fetching GitHub issue data", map[string]interface{}{
@@ -118,10 +172,20 @@ func (app *CCWApp) ExecuteWorkflow(issueURL string) error {
 		"issue_number": issueNumber,
 	})
 
+	app.setPhase("fetch")
 	app.ui.UpdateProgress("fetch", "in_progress")
 	app.ui.Info("Fetching GitHub issue data...")
 
-	issue, err := app.githubClient.GetIssue(owner, repo, issueNumber)
+	// One GraphQL round trip also brings the milestone and recent comments;
+	// fall back to the REST endpoint if it is unavailable
+	issue, err := app.githubClient.GetIssueGraphQL(owner, repo, issueNumber)
+	if err != nil {
+		app.logger.Warn("workflow", "GraphQL issue fetch failed, falling back to REST", map[string]interface{}{
+			"issue_number": issueNumber,
+			"error":        err.Error(),
+		})
+		issue, err = app.githubClient.GetIssue(owner, repo, issueNumber)
+	}
 	if err != nil {
 		app.ui.UpdateProgress("fetch", "failed")
 		app.logger.Error("workflow", "Failed to fetch issue data", map[string]interface{}{
@@ -141,13 +205,23 @@ func (app *CCWApp) ExecuteWorkflow(issueURL string) error {
 	})
 
 	app.ui.UpdateProgress("fetch", "completed")
+	app.runSummary.Issue = issue
 
 	// Step 3: Setup development environment
 	if err := app.setupDevelopmentEnvironment(issue, issueNumber, owner, repo, issueURL); err != nil {
 		return err
 	}
+	app.applyDotEnv()
+	app.runSummary.BranchName = app.worktreeConfig.BranchName
+	app.runSummary.WorktreePath = app.worktreeConfig.WorktreePath
+	if baseCommit, err := app.gitOps.HeadCommit(app.worktreeConfig.WorktreePath); err == nil {
+		app.runSummary.BaseCommit = baseCommit
+	}
 
 	// Step 4: Run implementation
+	if err := app.runHooks(hooks.PhasePreImplementation, issue, nil); err != nil {
+		return err
+	}
 	if err := app.runImplementation(issue); err != nil {
 		return err
 	}
@@ -157,37 +231,67 @@ func (app *CCWApp) ExecuteWorkflow(issueURL string) error {
 	if err != nil {
 		return err
 	}
+	app.runSummary.Validation = validationResult
+	if err := app.runHooks(hooks.PhasePostValidation, issue, map[string]string{
+		"CCW_VALIDATION_SUCCESS": fmt.Sprintf("%t", validationResult.Success),
+	}); err != nil {
+		return err
+	}
 
 	// Step 6: Commit changes (REQUIRED before PR creation)
 	if validationResult.Success {
-		if err := app.commitChanges(issue); err != nil {
-			return err
-		}
-
-		// Step 7: Execute async PR workflow after successful commit
-		// Convert back to git.ValidationResult for async workflow
-		gitValidationForAsync := convertTypesToGitValidationResult(validationResult)
-		return app.executeAsyncWorkflow(issue, gitValidationForAsync)
+		return app.finishValidatedImplementation(issue, validationResult)
 	}
 
 	app.ui.Warning("Implementation validation failed after all recovery attempts")
+	app.emitActionsAnnotations(validationResult)
 	app.logger.Error("workflow", "Implementation validation failed after recovery", map[string]interface{}{
 		"validation_errors": validationResult.Errors,
 		"worktree_path":     app.worktreeConfig.WorktreePath,
-		"recovery_attempts": app.config.MaxRetries,
+		"recovery_attempts": app.recoveryAttempts(),
 	})
-	return fmt.Errorf("validation failed after %d recovery attempts", app.config.MaxRetries)
+	return fmt.Errorf("validation failed after %d recovery attempts", app.recoveryAttempts())
 }
 
 // setupDevelopmentEnvironment creates worktree and saves issue data
 func (app *CCWApp) setupDevelopmentEnvironment(issue *types.Issue, issueNumber int, owner, repo, issueURL string) error {
+	if app.inPlace() {
+		return app.setupInPlaceEnvironment(app.issueBranchPrefix(issue), issueNumber, owner, repo, issueURL)
+	}
+
 	app.debugStep("step3", "Creating isolated development environment", map[string]interface{}{
 		"issue_number": issueNumber,
 	})
 
 	app.ui.Info("Creating isolated development environment...")
-	branchName := generateBranchName(issueNumber)
-	worktreePath := filepath.Join(app.config.WorktreeBase, branchName)
+
+	// Refuse to pile up worktrees beyond git.max_worktrees
+	if app.ccwConfig != nil {
+		if err := app.gitOps.EnforceWorktreeLimit(app.ccwConfig.Git.MaxWorktrees); err != nil {
+			app.ui.UpdateProgress("setup", "failed")
+			app.logger.Error("workflow", "Worktree limit reached", map[string]interface{}{
+				"max_worktrees": app.ccwConfig.Git.MaxWorktrees,
+				"error":         err.Error(),
+			})
+			return err
+		}
+
+		// Fail early instead of leaving a half-created worktree on a full disk
+		minFreeSpace, _ := config.ParseByteSize(app.ccwConfig.Git.MinFreeSpace)
+		if err := app.gitOps.EnforceMinFreeSpace(minFreeSpace); err != nil {
+			app.ui.UpdateProgress("setup", "failed")
+			app.logger.Error("workflow", "Insufficient disk space for a new worktree", map[string]interface{}{
+				"min_free_space": app.ccwConfig.Git.MinFreeSpace,
+				"error":          err.Error(),
+			})
+			return err
+		}
+	}
+	// The worktree directory keeps the unprefixed name so it stays flat under
+	// the worktree base; only the branch carries the git.branch_type_map prefix
+	worktreeName := generateBranchName(issueNumber)
+	branchName := app.issueBranchPrefix(issue) + worktreeName
+	worktreePath := filepath.Join(app.config.WorktreeBase, worktreeName)
 
 	app.debugStep("step3", "Generated worktree configuration", map[string]interface{}{
 		"branch_name":   branchName,
@@ -246,18 +350,19 @@ func (app *CCWApp) setupDevelopmentEnvironment(issue *types.Issue, issueNumber i
 		"worktree_path": worktreePath,
 	})
 
-	issueDataFile := filepath.Join(worktreePath, ".issue-data.json")
+	metadataDir := app.metadataDir()
+	issueDataFile := git.MetadataPath(worktreePath, metadataDir, git.IssueDataFile)
 	issueData, _ := json.MarshalIndent(issue, "", "  ")
-	if err := os.WriteFile(issueDataFile, issueData, 0644); err != nil {
+	if err := git.WriteMetadata(worktreePath, metadataDir, git.IssueDataFile, issueData); err != nil {
 		app.logger.Error("workflow", "Failed to save issue data", map[string]interface{}{
 			"file":  issueDataFile,
 			"error": err.Error(),
 		})
 	}
 
-	worktreeDataFile := filepath.Join(worktreePath, ".worktree-config.json")
+	worktreeDataFile := git.MetadataPath(worktreePath, metadataDir, git.WorktreeConfigFile)
 	worktreeData, _ := json.MarshalIndent(app.worktreeConfig, "", "  ")
-	if err := os.WriteFile(worktreeDataFile, worktreeData, 0644); err != nil {
+	if err := git.WriteMetadata(worktreePath, metadataDir, git.WorktreeConfigFile, worktreeData); err != nil {
 		app.logger.Error("workflow", "Failed to save worktree config", map[string]interface{}{
 			"file":  worktreeDataFile,
 			"error": err.Error(),
@@ -267,6 +372,14 @@ func (app *CCWApp) setupDevelopmentEnvironment(issue *types.Issue, issueNumber i
 	return nil
 }
 
+// metadataDir returns the configured git.metadata_dir
+func (app *CCWApp) metadataDir() string {
+	if app.ccwConfig != nil {
+		return app.ccwConfig.Git.MetadataDir
+	}
+	return ""
+}
+
 // runImplementation executes Claude Code implementation
 func (app *CCWApp) runImplementation(issue *types.Issue) error {
 	app.debugStep("step5", "Starting Claude Code implementation", map[string]interface{}{
@@ -274,9 +387,15 @@ func (app *CCWApp) runImplementation(issue *types.Issue) error {
 		"issue_number":  issue.Number,
 	})
 
+	app.setPhase("implementation")
 	app.ui.UpdateProgress("implementation", "in_progress")
 	app.ui.Info("Running implementation...")
 
+	if err := app.checkProjectPath(); err != nil {
+		app.ui.UpdateProgress("implementation", "failed")
+		return err
+	}
+
 	// Convert git.WorktreeConfig to types.WorktreeConfig
 	typesWorktreeConfig := &types.WorktreeConfig{
 		BasePath:     app.worktreeConfig.BasePath,
@@ -292,43 +411,94 @@ func (app *CCWApp) runImplementation(issue *types.Issue) error {
 	claudeCtx := &types.ClaudeContext{
 		IssueData:      issue,
 		WorktreeConfig: typesWorktreeConfig,
-		ProjectPath:    app.worktreeConfig.WorktreePath,
+		ProjectPath:    app.projectPath(),
 		TaskType:       "implementation",
 	}
 
 	app.debugStep("step5", "Executing Claude Code with context", map[string]interface{}{
 		"claude_context": map[string]interface{}{
-			"project_path": app.worktreeConfig.WorktreePath,
+			"project_path": claudeCtx.ProjectPath,
 			"task_type":    "implementation",
 			"issue_title":  issue.Title,
 		},
 	})
 
-	if err := app.claudeIntegration.RunWithContext(claudeCtx); err != nil {
-		app.logger.Error("workflow", "Claude Code execution failed", map[string]interface{}{
-			"error":         err.Error(),
-			"worktree_path": app.worktreeConfig.WorktreePath,
-			"issue_number":  issue.Number,
-		})
-		app.ui.Warning(fmt.Sprintf("Claude Code execution warning: %v", err))
-	} else {
+	plan := app.captureChangePlan(claudeCtx)
+
+	// Retry a failed Claude Code run up to workflow.max_implementation_attempts times
+	maxAttempts := app.implementationAttempts()
+	var runErr error
+	if _, ok := runAttempts(maxAttempts, func(attempt int) bool {
+		if attempt > 1 {
+			app.ui.Info(fmt.Sprintf("Implementation attempt %d of %d", attempt, maxAttempts))
+		}
+		runErr = app.claudeIntegration.RunWithContext(claudeCtx)
+		if runErr != nil {
+			app.logger.Error("workflow", "Claude Code execution failed", map[string]interface{}{
+				"error":         runErr.Error(),
+				"attempt":       attempt,
+				"worktree_path": app.worktreeConfig.WorktreePath,
+				"issue_number":  issue.Number,
+			})
+		}
+		return runErr == nil
+	}); ok {
 		app.debugStep("step5", "Claude Code execution completed successfully", nil)
+	} else {
+		app.ui.Warning(fmt.Sprintf("Claude Code execution warning: %v", runErr))
 	}
 
+	app.reconcileChangePlan(plan)
 	app.ui.UpdateProgress("implementation", "completed")
 	return nil
 }
 
 // validateImplementation runs quality validation
 func (app *CCWApp) validateImplementation() (*git.ValidationResult, error) {
+	return app.validateImplementationStages(nil)
+}
+
+// validateImplementationStages validates the implementation, running only the
+// named stages or, when stages is nil, all of them. When the named stages pass,
+// the other stages run too, as recovery changes may have broken a stage that
+// passed before.
+func (app *CCWApp) validateImplementationStages(stages []string) (*git.ValidationResult, error) {
 	app.debugStep("step6", "Starting implementation validation", map[string]interface{}{
 		"worktree_path": app.worktreeConfig.WorktreePath,
+		"stages":        stages,
 	})
 
+	app.setPhase("validation")
 	app.ui.UpdateProgress("validation", "in_progress")
+	app.ui.UpdateSubstepProgress("validation", "lint", "in_progress")
 	app.ui.Info("Validating implementation...")
+	app.scopeValidation()
+	app.explain(app.validator.Explain())
 
-	validationResult, err := app.validator.ValidateImplementation(app.worktreeConfig.WorktreePath)
+	// An interrupt skips the stages that have not started yet and stops the run
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	var validationResult *git.ValidationResult
+	var err error
+	if stages == nil {
+		validationResult, err = app.validator.ValidateImplementationContext(ctx, app.projectPath())
+	} else {
+		app.explain(fmt.Sprintf("validation: re-running only %s because the other stages passed before recovery",
+			strings.Join(stages, ", ")))
+		validationResult, err = app.validator.ValidateStages(ctx, app.projectPath(), stages)
+		if others := git.OtherStages(stages); err == nil && validationResult.Success && ctx.Err() == nil && len(others) > 0 {
+			app.explain(fmt.Sprintf("validation: the re-run stages pass; running %s to confirm", strings.Join(others, ", ")))
+			var confirmation *git.ValidationResult
+			confirmation, err = app.validator.ValidateStages(ctx, app.projectPath(), others)
+			if err == nil {
+				validationResult = git.MergeValidationResults(validationResult, confirmation)
+			}
+		}
+	}
+	if err == nil && ctx.Err() != nil {
+		err = errors.New("interrupted")
+	}
 	if err != nil {
 		app.ui.UpdateProgress("validation", "failed")
 		app.logger.Error("workflow", "Validation error", map[string]interface{}{
@@ -337,6 +507,7 @@ func (app *CCWApp) validateImplementation() (*git.ValidationResult, error) {
 		})
 		return nil, fmt.Errorf("validation error: %w", err)
 	}
+	app.exportJUnit(validationResult)
 
 	app.debugStep("step6", "Validation completed", map[string]interface{}{
 		"success":       validationResult.Success,
@@ -345,11 +516,13 @@ func (app *CCWApp) validateImplementation() (*git.ValidationResult, error) {
 		"build_success": validationResult.BuildResult != nil && validationResult.BuildResult.Success,
 		"test_success":  validationResult.TestResult != nil && validationResult.TestResult.Success,
 	})
+	app.updateValidationSubsteps(validationResult)
 
 	if validationResult.Success {
 		app.ui.UpdateProgress("validation", "completed")
 		app.ui.Success("Implementation validation successful!")
 	}
+	app.reportValidationWarnings(validationResult.Warnings)
 
 	return validationResult, nil
 }
@@ -361,16 +534,18 @@ func (app *CCWApp) commitChanges(issue *types.Issue) error {
 		"issue_number":  issue.Number,
 	})
 
+	app.setPhase("commit")
 	app.ui.UpdateProgress("commit", "in_progress")
 	app.ui.Info("Committing changes...")
 
-	// Generate commit message using the commit generator
-	issueForCommit := &commit.Issue{
-		Number: issue.Number,
-		Title:  issue.Title,
-		Body:   issue.Body,
+	if err := app.checkCommitGuards(); err != nil {
+		app.ui.UpdateProgress("commit", "failed")
+		return err
 	}
 
+	// Generate commit message using the commit generator
+	issueForCommit := commitIssue(issue)
+
 	// Generate commit message synchronously (blocking operation)
 	commitResultChan := app.commitGenerator.GenerateEnhancedCommitMessageAsync(app.worktreeConfig.WorktreePath, issueForCommit)
 
@@ -388,6 +563,8 @@ func (app *CCWApp) commitChanges(issue *types.Issue) error {
 		commitMessage = fmt.Sprintf("feat: %s\n\nResolves #%d", issue.Title, issue.Number)
 	}
 
+	commitMessage = app.finishCommitMessage(issue, commitMessage)
+
 	app.debugStep("step6_commit", "Generated commit message", map[string]interface{}{
 		"message": commitMessage,
 	})
@@ -408,12 +585,259 @@ func (app *CCWApp) commitChanges(issue *types.Issue) error {
 	})
 
 	app.ui.UpdateProgress("commit", "completed")
-	successIcon := getConsoleCharWorkflow("✅", "[SUCCESS]")
+	successIcon := consoleui.Char("✅", "[SUCCESS]")
 	app.ui.Success(fmt.Sprintf("%s Changes committed successfully!", successIcon))
 
 	return nil
 }
 
+// runAttempts calls attempt with 1, 2, ... until it reports success or maxAttempts
+// calls have been made, returning the number of calls and whether one succeeded
+func runAttempts(maxAttempts int, attempt func(n int) bool) (int, bool) {
+	for n := 1; n <= maxAttempts; n++ {
+		if attempt(n) {
+			return n, true
+		}
+	}
+	if maxAttempts < 0 {
+		return 0, false
+	}
+	return maxAttempts, false
+}
+
+// implementationAttempts returns how many times the initial implementation may run
+func (app *CCWApp) implementationAttempts() int {
+	if app.ccwConfig != nil && app.ccwConfig.Workflow.MaxImplementationAttempts > 0 {
+		return app.ccwConfig.Workflow.MaxImplementationAttempts
+	}
+	return 1
+}
+
+// recoveryAttempts returns how many recovery passes may follow failed validation,
+// falling back to max_retries when workflow.max_recovery_attempts is unset.
+// An explicit 0 disables recovery.
+func (app *CCWApp) recoveryAttempts() int {
+	if app.ccwConfig != nil && app.ccwConfig.Workflow.MaxRecoveryAttempts != nil {
+		return *app.ccwConfig.Workflow.MaxRecoveryAttempts
+	}
+	return app.config.MaxRetries
+}
+
+// updateValidationSubsteps reflects lint, build and test results in the validation progress substeps
+func (app *CCWApp) updateValidationSubsteps(result *git.ValidationResult) {
+	substepStatus := func(ran, success bool) string {
+		switch {
+		case !ran:
+			return "pending"
+		case success:
+			return "completed"
+		default:
+			return "failed"
+		}
+	}
+
+	app.ui.UpdateSubstepProgress("validation", "lint", substepStatus(result.LintResult != nil, result.LintResult != nil && result.LintResult.Success))
+	app.ui.UpdateSubstepProgress("validation", "build", substepStatus(result.BuildResult != nil, result.BuildResult != nil && result.BuildResult.Success))
+	app.ui.UpdateSubstepProgress("validation", "test", substepStatus(result.TestResult != nil, result.TestResult != nil && result.TestResult.Success))
+}
+
+// acquireIssueLock takes the per-issue lockfile, waiting for it when --wait-lock is set
+func (app *CCWApp) acquireIssueLock(issueNumber int) (*lock.IssueLock, error) {
+	issueLock, err := lock.Acquire(lock.DefaultLockDir, issueNumber, app.sessionID)
+	var lockedErr *lock.LockedError
+	if err == nil || !errors.As(err, &lockedErr) {
+		if err != nil {
+			return nil, fmt.Errorf("failed to acquire issue lock: %w", err)
+		}
+		return issueLock, nil
+	}
+
+	if app.options == nil || !app.options.WaitLock {
+		return nil, fmt.Errorf("%w; use --wait-lock to wait for it to finish", err)
+	}
+
+	app.ui.Info(fmt.Sprintf("Waiting for CCW process %d to finish issue #%d...", lockedErr.Holder.PID, issueNumber))
+	issueLock, err = lock.AcquireWait(context.Background(), lock.DefaultLockDir, issueNumber, app.sessionID, 2*time.Second)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire issue lock: %w", err)
+	}
+	return issueLock, nil
+}
+
+// runHooks executes lifecycle hooks for a phase inside the current worktree
+func (app *CCWApp) runHooks(phase hooks.Phase, issue *types.Issue, extraEnv map[string]string) error {
+	// Hook phases only have a progress step when listed in workflow.steps
+	stepID := string(phase)
+	hasStep := app.ui.HasProgressStep(stepID)
+
+	if app.hookRunner == nil || !app.hookRunner.HasHooks(phase) {
+		if hasStep {
+			app.ui.UpdateProgress(stepID, "completed")
+		}
+		return nil
+	}
+	if hasStep {
+		app.ui.UpdateProgress(stepID, "in_progress")
+	}
+
+	app.ui.Info(fmt.Sprintf("Running %s hooks...", phase))
+	if err := app.hookRunner.Run(phase, app.worktreeConfig.WorktreePath, app.hookEnv(issue, extraEnv)); err != nil {
+		app.logger.Error("hooks", "Lifecycle hook failed", map[string]interface{}{
+			"phase": string(phase),
+			"error": err.Error(),
+		})
+		if hasStep {
+			app.ui.UpdateProgress(stepID, "failed")
+		}
+		return fmt.Errorf("workflow aborted by hook: %w", err)
+	}
+
+	if hasStep {
+		app.ui.UpdateProgress(stepID, "completed")
+	}
+	return nil
+}
+
+// hookEnv returns the CCW_* environment passed to hook commands
+func (app *CCWApp) hookEnv(issue *types.Issue, extraEnv map[string]string) map[string]string {
+	env := map[string]string{
+		"CCW_ISSUE_URL":     app.worktreeConfig.IssueURL,
+		"CCW_BRANCH_NAME":   app.worktreeConfig.BranchName,
+		"CCW_WORKTREE_PATH": app.worktreeConfig.WorktreePath,
+		"CCW_REPO_OWNER":    app.worktreeConfig.Owner,
+		"CCW_REPO_NAME":     app.worktreeConfig.Repository,
+	}
+	// `ccw ship` runs without an issue
+	if issue != nil {
+		env["CCW_ISSUE_NUMBER"] = fmt.Sprintf("%d", issue.Number)
+		env["CCW_ISSUE_TITLE"] = issue.Title
+	}
+	for key, value := range extraEnv {
+		env[key] = value
+	}
+	return env
+}
+
+// checkChangedFiles scans changed files for oversized or binary content.
+// Findings block the commit unless commit.large_file_action is "warn".
+func (app *CCWApp) checkChangedFiles() error {
+	commitConfig := config.GetDefaultCCWConfig().Commit
+	if app.ccwConfig != nil {
+		commitConfig = app.ccwConfig.Commit
+	}
+
+	sizes, err := app.gitOps.ChangedFileSizes(app.worktreeConfig.WorktreePath)
+	if err != nil {
+		app.logger.Warn("workflow", "Failed to list changed files for scanning", map[string]interface{}{
+			"error": err.Error(),
+		})
+		return nil
+	}
+
+	findings := git.ScanChangedFiles(app.worktreeConfig.WorktreePath, sizes, commitConfig.MaxFileSize)
+	if len(findings) == 0 {
+		return nil
+	}
+
+	var details []string
+	for _, finding := range findings {
+		details = append(details, finding.String())
+	}
+
+	app.logger.Warn("workflow", "Large or binary files detected in changes", map[string]interface{}{
+		"files":         details,
+		"max_file_size": commitConfig.MaxFileSize,
+		"action":        commitConfig.LargeFileAction,
+	})
+
+	if commitConfig.LargeFileAction == "warn" {
+		for _, detail := range details {
+			app.ui.Warning(fmt.Sprintf("Committing large or binary file: %s", detail))
+		}
+		return nil
+	}
+
+	for _, detail := range details {
+		app.ui.Error(fmt.Sprintf("Blocked file: %s", detail))
+	}
+	return fmt.Errorf("refusing to commit %d large or binary file(s): %s", len(findings), strings.Join(details, "; "))
+}
+
+// checkProtectedPaths refuses to commit changes to commit.protected_paths unless
+// --allow-protected was given, in which case the touched paths are only reported
+func (app *CCWApp) checkProtectedPaths() error {
+	commitConfig := config.GetDefaultCCWConfig().Commit
+	if app.ccwConfig != nil {
+		commitConfig = app.ccwConfig.Commit
+	}
+	if len(commitConfig.ProtectedPaths) == 0 {
+		return nil
+	}
+
+	changedFiles, err := app.gitOps.ChangedFiles(app.worktreeConfig.WorktreePath)
+	if err != nil {
+		app.logger.Warn("workflow", "Failed to list changed files for protected path check", map[string]interface{}{
+			"error": err.Error(),
+		})
+		return nil
+	}
+
+	matches := git.FindProtectedPaths(changedFiles, commitConfig.ProtectedPaths)
+	if len(matches) == 0 {
+		return nil
+	}
+
+	var details []string
+	for _, match := range matches {
+		details = append(details, match.String())
+	}
+
+	allowed := app.options != nil && app.options.AllowProtected
+	app.logger.Warn("workflow", "Protected paths touched by changes", map[string]interface{}{
+		"files":   details,
+		"allowed": allowed,
+	})
+
+	if allowed {
+		for _, detail := range details {
+			app.ui.Warning(fmt.Sprintf("Committing protected path: %s", detail))
+		}
+		return nil
+	}
+
+	for _, detail := range details {
+		app.ui.Error(fmt.Sprintf("Protected path changed: %s", detail))
+	}
+	return fmt.Errorf("refusing to commit changes to %d protected path(s), rerun with --allow-protected to commit them: %s", len(matches), strings.Join(details, "; "))
+}
+
+// checkConflictMarkers refuses to commit files that still contain unresolved
+// conflict blocks left by Claude or a rebase
+func (app *CCWApp) checkConflictMarkers() error {
+	markers, err := app.gitOps.DetectConflictMarkers(app.worktreeConfig.WorktreePath)
+	if err != nil {
+		app.logger.Warn("workflow", "Failed to scan changed files for conflict markers", map[string]interface{}{
+			"error": err.Error(),
+		})
+		return nil
+	}
+	if len(markers) == 0 {
+		return nil
+	}
+
+	var details []string
+	for _, marker := range markers {
+		details = append(details, marker.String())
+	}
+	app.logger.Error("workflow", "Conflict markers found in changes", map[string]interface{}{
+		"files": details,
+	})
+	for _, detail := range details {
+		app.ui.Error(fmt.Sprintf("Conflict markers: %s", detail))
+	}
+	return fmt.Errorf("refusing to commit %d unresolved conflict(s): %s", len(markers), strings.Join(details, "; "))
+}
+
 // executeAsyncWorkflow runs the async PR creation workflow
 func (app *CCWApp) executeAsyncWorkflow(issue *types.Issue, validationResult *git.ValidationResult) error {
 	// Convert git.ValidationResult to types.ValidationResult
@@ -448,10 +872,20 @@ func (app *CCWApp) debugStep(step, message string, context map[string]interface{
 	}
 }
 
-// traceFunction logs detailed function call information
+// setPhase records the workflow phase being entered so later log entries carry it
+func (app *CCWApp) setPhase(phase string) {
+	if app.logger == nil {
+		return
+	}
+	app.logger.SetPhase(phase)
+	app.logger.Debug("workflow", fmt.Sprintf("Entering phase %s", phase))
+}
+
+// traceFunction logs detailed function call information, including the
+// caller, to the trace file when one is set and the main log otherwise
 func (app *CCWApp) traceFunction(funcName string, params map[string]interface{}) {
 	if os.Getenv("TRACE_MODE") == "true" {
-		app.logger.Debug("trace", fmt.Sprintf("FUNCTION: %s", funcName), params)
+		app.logger.Trace(funcName, traceCaller(), params)
 	}
 }
 
@@ -489,9 +923,10 @@ func convertGitValidationResultToTypes(gitResult *git.ValidationResult) *types.V
 	var buildResult *types.BuildResult
 	if gitResult.BuildResult != nil {
 		buildResult = &types.BuildResult{
-			Success: gitResult.BuildResult.Success,
-			Output:  gitResult.BuildResult.Output,
-			Error:   gitResult.BuildResult.Error,
+			Success:  gitResult.BuildResult.Success,
+			Output:   gitResult.BuildResult.Output,
+			Error:    gitResult.BuildResult.Error,
+			Warnings: gitResult.BuildResult.Warnings,
 		}
 	}
 
@@ -504,6 +939,8 @@ func convertGitValidationResultToTypes(gitResult *git.ValidationResult) *types.V
 			TestCount: gitResult.TestResult.TestCount,
 			Passed:    gitResult.TestResult.Passed,
 			Failed:    gitResult.TestResult.Failed,
+
+			FailedTests: gitResult.TestResult.FailedTests,
 		}
 	}
 
@@ -513,6 +950,7 @@ func convertGitValidationResultToTypes(gitResult *git.ValidationResult) *types.V
 		BuildResult: buildResult,
 		TestResult:  testResult,
 		Errors:      errors,
+		Warnings:    gitResult.Warnings,
 		Duration:    gitResult.Duration,
 		Timestamp:   gitResult.Timestamp,
 	}
@@ -561,9 +999,10 @@ func convertTypesToGitValidationResult(typesResult *types.ValidationResult) *git
 	var buildResult *git.BuildResult
 	if typesResult.BuildResult != nil {
 		buildResult = &git.BuildResult{
-			Success: typesResult.BuildResult.Success,
-			Output:  typesResult.BuildResult.Output,
-			Error:   typesResult.BuildResult.Error,
+			Success:  typesResult.BuildResult.Success,
+			Output:   typesResult.BuildResult.Output,
+			Error:    typesResult.BuildResult.Error,
+			Warnings: typesResult.BuildResult.Warnings,
 		}
 	}
 
@@ -576,6 +1015,8 @@ func convertTypesToGitValidationResult(typesResult *types.ValidationResult) *git
 			TestCount: typesResult.TestResult.TestCount,
 			Passed:    typesResult.TestResult.Passed,
 			Failed:    typesResult.TestResult.Failed,
+
+			FailedTests: typesResult.TestResult.FailedTests,
 		}
 	}
 
@@ -585,6 +1026,7 @@ func convertTypesToGitValidationResult(typesResult *types.ValidationResult) *git
 		BuildResult: buildResult,
 		TestResult:  testResult,
 		Errors:      errors,
+		Warnings:    typesResult.Warnings,
 		Duration:    typesResult.Duration,
 		Timestamp:   typesResult.Timestamp,
 	}
@@ -592,6 +1034,7 @@ func convertTypesToGitValidationResult(typesResult *types.ValidationResult) *git
 
 // validateImplementationWithRecovery validates implementation and attempts recovery on failure
 func (app *CCWApp) validateImplementationWithRecovery(issue *types.Issue) (*types.ValidationResult, error) {
+	app.setPhase("validation")
 	app.ui.UpdateProgress("validation", "in_progress")
 
 	// First validation attempt
@@ -603,6 +1046,7 @@ func (app *CCWApp) validateImplementationWithRecovery(issue *types.Issue) (*type
 
 	// Convert to types.ValidationResult
 	validationResult := convertGitValidationResultToTypes(gitValidationResult)
+	lastGitResult := gitValidationResult
 
 	// If validation succeeds, we're done
 	if validationResult.Success {
@@ -625,16 +1069,35 @@ func (app *CCWApp) validateImplementationWithRecovery(issue *types.Issue) (*type
 		app.ui.Warning("Validation failed with non-recoverable errors")
 		return validationResult, nil
 	}
+	if app.recoveryAttempts() <= 0 {
+		app.ui.UpdateProgress("validation", "failed")
+		app.ui.Warning("Validation failed; automatic recovery is disabled (workflow.max_recovery_attempts: 0)")
+		return validationResult, nil
+	}
 
 	// Attempt recovery
 	app.ui.Warning("Validation failed, attempting automatic recovery...")
 	app.logger.Info("workflow", "Starting validation recovery process", map[string]interface{}{
 		"initial_errors": len(validationResult.Errors),
-		"max_retries":    app.config.MaxRetries,
+		"max_recovery":   app.recoveryAttempts(),
 	})
 
-	for attempt := 1; attempt <= app.config.MaxRetries; attempt++ {
-		app.ui.Info(fmt.Sprintf("Recovery attempt %d of %d", attempt, app.config.MaxRetries))
+	// Commit the initial implementation so each recovery attempt gets its own
+	// commit; --no-commit leaves every change uncommitted
+	if app.noCommit() {
+		app.explain("--no-commit: recovery attempts are not committed separately")
+	} else if err := app.commitChanges(issue); err != nil {
+		app.ui.Warning(fmt.Sprintf("Failed to commit initial implementation before recovery: %v", err))
+	} else {
+		app.implementationCommitted = true
+	}
+
+	// A recovery commit that cannot be made stops recovery: the branch would
+	// otherwise be pushed without changes that validation relied on
+	var commitErr error
+	maxRecovery := app.recoveryAttempts()
+	_, recovered := runAttempts(maxRecovery, func(attempt int) bool {
+		app.ui.Info(fmt.Sprintf("Recovery attempt %d of %d", attempt, maxRecovery))
 
 		// Run Claude Code with error context to fix issues
 		if err := app.runRecoveryImplementation(issue, validationResult, attempt); err != nil {
@@ -643,21 +1106,32 @@ func (app *CCWApp) validateImplementationWithRecovery(issue *types.Issue) (*type
 				"error":   err.Error(),
 			})
 			app.ui.Warning(fmt.Sprintf("Recovery attempt %d failed: %v", attempt, err))
-			continue
+			return false
 		}
 
-		// Re-validate after recovery attempt
-		gitRecoveryResult, err := app.validateImplementation()
+		// Commit recovery changes separately so each attempt is distinguishable in history
+		if app.implementationCommitted {
+			if err := app.commitRecoveryChanges(issue, validationResult, attempt); err != nil {
+				commitErr = err
+				return true
+			}
+		}
+
+		// Re-validate the stages that failed, plus what they depend on, and every
+		// stage once those pass
+		gitRecoveryResult, err := app.validateImplementationStages(git.RerunStages(lastGitResult))
 		if err != nil {
 			app.logger.Error("workflow", "Validation after recovery failed", map[string]interface{}{
 				"attempt": attempt,
 				"error":   err.Error(),
 			})
-			continue
+			return false
 		}
+		lastGitResult = gitRecoveryResult
 
 		// Convert to types.ValidationResult
 		recoveryResult := convertGitValidationResultToTypes(gitRecoveryResult)
+		diff := app.recordRecoveryAttempt(attempt, validationResult, recoveryResult)
 
 		// Check if recovery was successful
 		if recoveryResult.Success {
@@ -667,7 +1141,8 @@ func (app *CCWApp) validateImplementationWithRecovery(issue *types.Issue) (*type
 				"successful_attempt": attempt,
 				"total_attempts":     attempt,
 			})
-			return recoveryResult, nil
+			validationResult = recoveryResult
+			return true
 		}
 
 		// Log progress for this attempt
@@ -675,6 +1150,8 @@ func (app *CCWApp) validateImplementationWithRecovery(issue *types.Issue) (*type
 			"attempt":          attempt,
 			"still_has_errors": len(recoveryResult.Errors),
 			"previous_errors":  len(validationResult.Errors),
+			"resolved":         len(diff.Resolved),
+			"introduced":       len(diff.Introduced),
 		})
 
 		// Update validation result for next iteration
@@ -682,11 +1159,20 @@ func (app *CCWApp) validateImplementationWithRecovery(issue *types.Issue) (*type
 
 		app.ui.Warning(fmt.Sprintf("Recovery attempt %d completed but validation still failing (%d errors)",
 			attempt, len(recoveryResult.Errors)))
+		return false
+	})
+	if commitErr != nil {
+		app.ui.UpdateProgress("validation", "failed")
+		app.ui.Error(commitErr.Error())
+		return nil, commitErr
+	}
+	if recovered {
+		return validationResult, nil
 	}
 
 	// All recovery attempts failed
 	app.ui.UpdateProgress("validation", "failed")
-	app.ui.Error(fmt.Sprintf("All %d recovery attempts failed", app.config.MaxRetries))
+	app.ui.Error(fmt.Sprintf("All %d recovery attempts failed", maxRecovery))
 	return validationResult, nil
 }
 
@@ -701,11 +1187,11 @@ func (app *CCWApp) runRecoveryImplementation(issue *types.Issue, validationResul
 	claudeContext := &types.ClaudeContext{
 		IssueData:        issue,
 		WorktreeConfig:   convertGitWorktreeConfigToTypes(app.worktreeConfig),
-		ProjectPath:      app.worktreeConfig.WorktreePath,
+		ProjectPath:      app.projectPath(),
 		IsRetry:          true,
 		RetryAttempt:     attempt,
-		ValidationErrors: validationResult.Errors,
-		MaxRetries:       app.config.MaxRetries,
+		ValidationErrors: types.DedupeErrors(validationResult.Errors),
+		MaxRetries:       app.recoveryAttempts(),
 		TaskType:         "implementation",
 	}
 
@@ -725,6 +1211,85 @@ func (app *CCWApp) runRecoveryImplementation(issue *types.Issue, validationResul
 	return nil
 }
 
+// commitRecoveryChanges commits the changes made by a recovery attempt. An
+// error means the changes are left uncommitted and the run must stop.
+func (app *CCWApp) commitRecoveryChanges(issue *types.Issue, validationResult *types.ValidationResult, attempt int) error {
+	hasChanges, err := app.hasUncommittedChanges()
+	if err != nil {
+		return fmt.Errorf("failed to check recovery attempt %d for changes: %w", attempt, err)
+	}
+	if !hasChanges {
+		return nil
+	}
+
+	if err := app.checkCommitGuards(); err != nil {
+		return fmt.Errorf("cannot commit recovery attempt %d: %w", attempt, err)
+	}
+
+	commitMessage := app.commitGenerator.GenerateRecoveryCommitMessage(commitIssue(issue), validationResult, attempt)
+	commitMessage = app.finishCommitMessage(issue, commitMessage)
+
+	app.debugStep("recovery_commit", "Committing recovery changes", map[string]interface{}{
+		"attempt": attempt,
+		"message": commitMessage,
+	})
+
+	if err := app.gitOps.CommitChanges(app.worktreeConfig.WorktreePath, commitMessage); err != nil {
+		app.logger.Error("workflow", "Failed to commit recovery changes", map[string]interface{}{
+			"attempt": attempt,
+			"error":   err.Error(),
+		})
+		return fmt.Errorf("failed to commit recovery attempt %d: %w", attempt, err)
+	}
+
+	app.ui.Info(fmt.Sprintf("Committed recovery changes for attempt %d", attempt))
+	return nil
+}
+
+// commitValidationFixes commits what re-validation left behind after a
+// successful recovery, such as the lint fix pass rewriting sources, so the
+// pushed branch matches the tree that passed validation
+func (app *CCWApp) commitValidationFixes(issue *types.Issue) error {
+	hasChanges, err := app.hasUncommittedChanges()
+	if err != nil {
+		return fmt.Errorf("failed to check for changes made during validation: %w", err)
+	}
+	if !hasChanges {
+		return nil
+	}
+
+	if err := app.checkCommitGuards(); err != nil {
+		return fmt.Errorf("cannot commit changes made during validation: %w", err)
+	}
+
+	commitMessage := app.finishCommitMessage(issue, fmt.Sprintf("style: apply validation fixes\n\nRefs #%d", issue.Number))
+	if err := app.gitOps.CommitChanges(app.worktreeConfig.WorktreePath, commitMessage); err != nil {
+		app.logger.Error("workflow", "Failed to commit validation fixes", map[string]interface{}{
+			"error": err.Error(),
+		})
+		return fmt.Errorf("failed to commit changes made during validation: %w", err)
+	}
+
+	app.ui.Info("Committed changes made during validation")
+	return nil
+}
+
+// hasUncommittedChanges reports whether the worktree holds changes a commit
+// would include. CommitChanges never stages the worktree config, so it alone
+// is no change.
+func (app *CCWApp) hasUncommittedChanges() (bool, error) {
+	changedFiles, err := app.gitOps.ChangedFiles(app.worktreeConfig.WorktreePath)
+	if err != nil {
+		return false, err
+	}
+	for _, file := range changedFiles {
+		if file != "."+git.WorktreeConfigFile {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
 // formatValidationErrorsForDisplay formats validation errors for user display
 func (app *CCWApp) formatValidationErrorsForDisplay(result *types.ValidationResult) string {
 	if len(result.Errors) == 0 {
@@ -733,20 +1298,18 @@ func (app *CCWApp) formatValidationErrorsForDisplay(result *types.ValidationResu
 
 	var output strings.Builder
 
-	// Group errors by type
-	errorsByType := make(map[string][]types.ValidationError)
-	for _, err := range result.Errors {
-		errorsByType[err.Type] = append(errorsByType[err.Type], err)
-	}
-
-	// Format each type
-	for errorType, errors := range errorsByType {
-		output.WriteString(fmt.Sprintf("  %s (%d errors):\n", strings.ToUpper(errorType), len(errors)))
-		for _, err := range errors {
+	// Group errors by type, collapsing identical errors, in a stable order
+	for _, group := range types.GroupErrorsByType(types.DedupeErrors(result.Errors)) {
+		output.WriteString(fmt.Sprintf("  %s (%d errors):\n", strings.ToUpper(group.Type), len(group.Errors)))
+		for _, err := range group.Errors {
+			repeat := ""
+			if err.Occurrences() > 1 {
+				repeat = fmt.Sprintf(" (x%d)", err.Occurrences())
+			}
 			if err.File != "" && err.Line > 0 {
-				output.WriteString(fmt.Sprintf("    - %s:%d: %s\n", err.File, err.Line, err.Message))
+				output.WriteString(fmt.Sprintf("    - %s:%d: %s%s\n", err.File, err.Line, err.Message, repeat))
 			} else {
-				output.WriteString(fmt.Sprintf("    - %s\n", err.Message))
+				output.WriteString(fmt.Sprintf("    - %s%s\n", err.Message, repeat))
 			}
 		}
 		output.WriteString("\n")
@@ -0,0 +1,194 @@
+package claude
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"ccw/types"
+)
+
+// PlannedChange is one file Claude intends to change, with its reason
+type PlannedChange struct {
+	Path   string `json:"path"`
+	Reason string `json:"reason"`
+}
+
+// ChangePlan is Claude's machine-readable statement of intent, captured before
+// implementation so it can be compared with the actual diff
+type ChangePlan struct {
+	IssueNumber int             `json:"issue_number"`
+	CreatedAt   time.Time       `json:"created_at"`
+	Files       []PlannedChange `json:"files"`
+}
+
+// ChangePlanReconciliation compares a ChangePlan with the files actually changed
+type ChangePlanReconciliation struct {
+	Planned   []string `json:"planned"`   // Planned files that were changed
+	Unplanned []string `json:"unplanned"` // Changed files missing from the plan
+	Untouched []string `json:"untouched"` // Planned files that were not changed
+}
+
+// HasDiscrepancies reports whether the actual diff strayed from the plan
+func (r *ChangePlanReconciliation) HasDiscrepancies() bool {
+	return len(r.Unplanned) > 0 || len(r.Untouched) > 0
+}
+
+// GenerateChangePlan asks Claude, without editing anything, which files it
+// intends to change for the issue in ctx and why
+func (ci *ClaudeIntegration) GenerateChangePlan(ctx *types.ClaudeContext) (*ChangePlan, error) {
+	if ctx.IssueData == nil {
+		return nil, fmt.Errorf("change plan requires issue data")
+	}
+	ctx = ci.withTruncatedIssueBody(ctx)
+
+	output, err := ci.runPrint(ctx.ProjectPath, buildChangePlanPrompt(ctx.IssueData), 3*time.Minute, true)
+	if err != nil {
+		return nil, fmt.Errorf("Claude Code change plan failed: %w\nOutput: %s", err, string(output))
+	}
+
+	plan, err := parseChangePlan(string(output))
+	if err != nil {
+		return nil, err
+	}
+	plan.IssueNumber = ctx.IssueData.Number
+	plan.CreatedAt = time.Now()
+	return plan, nil
+}
+
+// buildChangePlanPrompt creates the prompt requesting a JSON change plan
+func buildChangePlanPrompt(issue *types.Issue) string {
+	var prompt strings.Builder
+	prompt.WriteString("You are about to implement the following GitHub issue in this repository.\n")
+	prompt.WriteString("Do not modify any files yet. Explore the code and plan the change.\n\n")
+
+	prompt.WriteString(fmt.Sprintf("## Issue #%d: %s\n", issue.Number, issue.Title))
+	if body := strings.TrimSpace(issue.Body); body != "" {
+		prompt.WriteString(body)
+		prompt.WriteString("\n")
+	}
+
+	prompt.WriteString("\nList every file you intend to create, modify or delete, with a one-line reason. ")
+	prompt.WriteString("Use paths relative to the repository root. Reply with only a JSON object of the form:\n")
+	prompt.WriteString("{\"files\": [{\"path\": \"Sources/Foo.swift\", \"reason\": \"add parsing for bar\"}]}\n")
+	return prompt.String()
+}
+
+// parseChangePlan extracts the JSON change plan from Claude's reply, which may
+// wrap it in prose or a code fence
+func parseChangePlan(output string) (*ChangePlan, error) {
+	start := strings.Index(output, "{")
+	end := strings.LastIndex(output, "}")
+	if start < 0 || end < start {
+		return nil, fmt.Errorf("no change plan found in Claude output")
+	}
+
+	var plan ChangePlan
+	if err := json.Unmarshal([]byte(output[start:end+1]), &plan); err != nil {
+		return nil, fmt.Errorf("failed to parse change plan: %w", err)
+	}
+
+	files := plan.Files[:0]
+	for _, file := range plan.Files {
+		if file.Path = normalizePlanPath(file.Path); file.Path != "" {
+			files = append(files, file)
+		}
+	}
+	plan.Files = files
+	return &plan, nil
+}
+
+// SaveChangePlan writes plan as indented JSON to filePath
+func SaveChangePlan(filePath string, plan *ChangePlan) error {
+	data, err := json.MarshalIndent(plan, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode change plan: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
+		return fmt.Errorf("failed to create change plan directory: %w", err)
+	}
+	if err := os.WriteFile(filePath, data, 0644); err != nil {
+		return fmt.Errorf("failed to write change plan: %w", err)
+	}
+	return nil
+}
+
+// ReconcileChangePlan compares the planned files with the changed files from
+// `git diff --name-only`. A planned path ending in "/" covers everything below it.
+func ReconcileChangePlan(plan *ChangePlan, changed []string) *ChangePlanReconciliation {
+	result := &ChangePlanReconciliation{}
+
+	var planned []string
+	if plan != nil {
+		for _, file := range plan.Files {
+			if entry := normalizePlanPath(file.Path); entry != "" {
+				planned = append(planned, entry)
+			}
+		}
+	}
+
+	covered := make(map[string]bool)
+	for _, file := range changed {
+		file = normalizePlanPath(file)
+		if file == "" {
+			continue
+		}
+
+		matched := false
+		for _, entry := range planned {
+			if entry == file || (strings.HasSuffix(entry, "/") && strings.HasPrefix(file, entry)) {
+				covered[entry] = true
+				matched = true
+			}
+		}
+		if matched {
+			result.Planned = appendUnique(result.Planned, file)
+		} else {
+			result.Unplanned = appendUnique(result.Unplanned, file)
+		}
+	}
+
+	for _, entry := range planned {
+		if !covered[entry] {
+			result.Untouched = appendUnique(result.Untouched, entry)
+		}
+	}
+
+	sort.Strings(result.Planned)
+	sort.Strings(result.Unplanned)
+	sort.Strings(result.Untouched)
+	return result
+}
+
+// normalizePlanPath converts a path to the slash-separated, repository-relative
+// form git reports, keeping a trailing "/" that marks a directory
+func normalizePlanPath(p string) string {
+	p = strings.TrimSpace(filepath.ToSlash(p))
+	if p == "" {
+		return ""
+	}
+	isDir := strings.HasSuffix(p, "/")
+	p = strings.TrimPrefix(path.Clean(p), "./")
+	if p == "." || p == "/" {
+		return ""
+	}
+	if isDir {
+		p += "/"
+	}
+	return p
+}
+
+// appendUnique appends value unless values already contains it
+func appendUnique(values []string, value string) []string {
+	for _, existing := range values {
+		if existing == value {
+			return values
+		}
+	}
+	return append(values, value)
+}
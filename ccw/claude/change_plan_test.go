@@ -0,0 +1,103 @@
+package claude
+
+import (
+	"reflect"
+	"testing"
+)
+
+func planOf(paths ...string) *ChangePlan {
+	plan := &ChangePlan{}
+	for _, p := range paths {
+		plan.Files = append(plan.Files, PlannedChange{Path: p, Reason: "test"})
+	}
+	return plan
+}
+
+func TestReconcileChangePlan(t *testing.T) {
+	tests := []struct {
+		name          string
+		plan          *ChangePlan
+		changed       []string
+		wantPlanned   []string
+		wantUnplanned []string
+		wantUntouched []string
+	}{
+		{
+			name:        "actual matches plan",
+			plan:        planOf("Sources/Lexer.swift", "Tests/LexerTests.swift"),
+			changed:     []string{"Tests/LexerTests.swift", "Sources/Lexer.swift"},
+			wantPlanned: []string{"Sources/Lexer.swift", "Tests/LexerTests.swift"},
+		},
+		{
+			name:          "unplanned and untouched files",
+			plan:          planOf("Sources/Lexer.swift", "Sources/Parser.swift"),
+			changed:       []string{"Sources/Lexer.swift", "Package.swift"},
+			wantPlanned:   []string{"Sources/Lexer.swift"},
+			wantUnplanned: []string{"Package.swift"},
+			wantUntouched: []string{"Sources/Parser.swift"},
+		},
+		{
+			name:        "directory entry covers files below it",
+			plan:        planOf("Tests/"),
+			changed:     []string{"Tests/A.swift", "Tests/Sub/B.swift"},
+			wantPlanned: []string{"Tests/A.swift", "Tests/Sub/B.swift"},
+		},
+		{
+			name:          "paths are normalized and de-duplicated",
+			plan:          planOf("./Sources/Lexer.swift"),
+			changed:       []string{"Sources/Lexer.swift", "README.md", "README.md", ""},
+			wantPlanned:   []string{"Sources/Lexer.swift"},
+			wantUnplanned: []string{"README.md"},
+		},
+		{
+			name:          "no plan flags every change",
+			plan:          nil,
+			changed:       []string{"b.go", "a.go"},
+			wantUnplanned: []string{"a.go", "b.go"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ReconcileChangePlan(tt.plan, tt.changed)
+			if !reflect.DeepEqual(result.Planned, tt.wantPlanned) {
+				t.Errorf("Expected planned %v, got %v", tt.wantPlanned, result.Planned)
+			}
+			if !reflect.DeepEqual(result.Unplanned, tt.wantUnplanned) {
+				t.Errorf("Expected unplanned %v, got %v", tt.wantUnplanned, result.Unplanned)
+			}
+			if !reflect.DeepEqual(result.Untouched, tt.wantUntouched) {
+				t.Errorf("Expected untouched %v, got %v", tt.wantUntouched, result.Untouched)
+			}
+
+			wantDiscrepancies := len(tt.wantUnplanned) > 0 || len(tt.wantUntouched) > 0
+			if result.HasDiscrepancies() != wantDiscrepancies {
+				t.Errorf("Expected HasDiscrepancies %v, got %v", wantDiscrepancies, result.HasDiscrepancies())
+			}
+		})
+	}
+}
+
+func TestParseChangePlan(t *testing.T) {
+	output := "Here is my plan:\n```json\n" +
+		`{"files": [{"path": "./Sources/Lexer.swift", "reason": "handle tabs"}, {"path": "  ", "reason": "blank"}]}` +
+		"\n```\n"
+
+	plan, err := parseChangePlan(output)
+	if err != nil {
+		t.Fatalf("parseChangePlan failed: %v", err)
+	}
+	if len(plan.Files) != 1 {
+		t.Fatalf("Expected 1 planned file, got %d", len(plan.Files))
+	}
+	if plan.Files[0].Path != "Sources/Lexer.swift" {
+		t.Errorf("Expected 'Sources/Lexer.swift', got '%s'", plan.Files[0].Path)
+	}
+	if plan.Files[0].Reason != "handle tabs" {
+		t.Errorf("Expected 'handle tabs', got '%s'", plan.Files[0].Reason)
+	}
+
+	if _, err := parseChangePlan("I could not decide."); err == nil {
+		t.Error("Expected error for output without JSON")
+	}
+}
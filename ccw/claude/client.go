@@ -6,9 +6,18 @@ import (
 
 // ClaudeIntegration handles Claude Code integration
 type ClaudeIntegration struct {
-	Timeout    time.Duration
-	MaxRetries int
-	DebugMode  bool
+	Timeout          time.Duration
+	IdleTimeout      time.Duration // Abort non-interactive runs silent this long, 0 = disabled
+	MaxRetries       int
+	DebugMode        bool
+	MaxContextChars  int           // Maximum issue body and prelude length passed to Claude, 0 = unlimited
+	Prelude          string        // Team rules prepended to every implementation context
+	ContextFiles     []ContextFile // Reference files appended to the implementation context
+	Env              []string      // Extra KEY=value variables for the Claude Code process
+	AllowedTools     []string      // Tool rules passed with --allowedTools, e.g. "Bash(git diff:*)"
+	DisallowedTools  []string      // Tool rules passed with --disallowedTools
+	WorkingDirOnly   bool          // Do not grant the worktree root when running in claude.subdir
+	ResumeTranscript bool          // Include and save the worktree's prior session transcript
 }
 
 // NewClaudeIntegration creates a new Claude integration instance
@@ -0,0 +1,50 @@
+package claude
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// RewriteCommitMessage asks Claude to rewrite message so that it no longer has
+// the listed lint violations, keeping its meaning
+func (ci *ClaudeIntegration) RewriteCommitMessage(projectPath, message string, violations []string) (string, error) {
+	output, err := ci.runPrint(projectPath, buildCommitRewritePrompt(message, violations), 2*time.Minute, false)
+	if err != nil {
+		return "", fmt.Errorf("Claude Code commit message rewrite failed: %w", err)
+	}
+
+	rewritten := strings.TrimSpace(trimCodeFence(string(output)))
+	if rewritten == "" {
+		return "", fmt.Errorf("Claude Code returned an empty commit message")
+	}
+	return rewritten, nil
+}
+
+// buildCommitRewritePrompt creates the prompt for RewriteCommitMessage
+func buildCommitRewritePrompt(message string, violations []string) string {
+	var prompt strings.Builder
+	prompt.WriteString("The following git commit message fails the repository's commit message rules.\n\n")
+	prompt.WriteString("```\n")
+	prompt.WriteString(strings.TrimSpace(message))
+	prompt.WriteString("\n```\n\nProblems:\n")
+	for _, violation := range violations {
+		prompt.WriteString(fmt.Sprintf("- %s\n", violation))
+	}
+	prompt.WriteString("\nRewrite it to fix every problem while keeping its meaning. ")
+	prompt.WriteString("Reply with only the new commit message, no explanation.\n")
+	return prompt.String()
+}
+
+// trimCodeFence removes a surrounding ``` fence from output, if any
+func trimCodeFence(output string) string {
+	trimmed := strings.TrimSpace(output)
+	if !strings.HasPrefix(trimmed, "```") || !strings.HasSuffix(trimmed, "```") || len(trimmed) < 6 {
+		return output
+	}
+	trimmed = strings.TrimSuffix(trimmed, "```")
+	if newline := strings.Index(trimmed, "\n"); newline >= 0 {
+		return trimmed[newline+1:]
+	}
+	return ""
+}
@@ -2,11 +2,19 @@ package claude
 
 import (
 	"fmt"
+	"math"
 	"strings"
+	"unicode/utf8"
 
 	"ccw/types"
 )
 
+// MarkdownContext renders the .claude-context.md content for ctx, applying the
+// same issue body budget as RunWithContext
+func (ci *ClaudeIntegration) MarkdownContext(ctx *types.ClaudeContext) (string, error) {
+	return ci.generateMarkdownContext(ci.withTruncatedIssueBody(ctx))
+}
+
 // generateMarkdownContext creates comprehensive markdown context file
 func (ci *ClaudeIntegration) generateMarkdownContext(ctx *types.ClaudeContext) (string, error) {
 	var md strings.Builder
@@ -15,6 +23,17 @@ func (ci *ClaudeIntegration) generateMarkdownContext(ctx *types.ClaudeContext) (
 	md.WriteString("# Claude Code Context\n\n")
 	md.WriteString("This file provides comprehensive context for the current GitHub issue implementation.\n\n")
 
+	// Team rules apply to every run, so they come before the issue. The
+	// prelude and every section after the issue body share what is left of
+	// claude.max_context_chars once the body is counted.
+	used := utf8.RuneCountInString(ctx.IssueData.Body)
+	prelude := ci.contextPrelude(used)
+	used += utf8.RuneCountInString(prelude)
+	if prelude != "" {
+		md.WriteString("## 📌 Team Guidelines\n\n")
+		md.WriteString(prelude + "\n\n")
+	}
+
 	// Issue Information
 	md.WriteString("## 📋 Issue Information\n\n")
 	md.WriteString(fmt.Sprintf("- **Issue Number**: #%d\n", ctx.IssueData.Number))
@@ -44,9 +63,40 @@ func (ci *ClaudeIntegration) generateMarkdownContext(ctx *types.ClaudeContext) (
 		md.WriteString(strings.Join(assignees, ", ") + "\n")
 	}
 
+	// Milestone
+	if milestone := ctx.IssueData.Milestone; milestone != nil {
+		md.WriteString(fmt.Sprintf("- **Milestone**: %s\n", milestone.Title))
+	}
+
 	md.WriteString("\n### Issue Description\n\n")
 	md.WriteString(ctx.IssueData.Body + "\n\n")
 
+	// Recent discussion, in whatever is left of the budget
+	if len(ctx.IssueData.Comments) > 0 {
+		budget := math.MaxInt
+		if ci.MaxContextChars > 0 {
+			budget = ci.MaxContextChars - used
+		}
+		comments := truncateComments(ctx.IssueData.Comments, budget)
+		used += utf8.RuneCountInString(comments)
+		md.WriteString("### Recent Comments\n\n")
+		md.WriteString(comments)
+	}
+
+	// Reference files supplied with --context-file, in what is left after that
+	if files := ci.contextFilesSection(used); files != "" {
+		used += utf8.RuneCountInString(files)
+		md.WriteString("## 📎 Reference Files\n\n")
+		md.WriteString(files + "\n\n")
+	}
+
+	// Condensed prior session saved in the worktree (claude.resume_transcript),
+	// in what is left after the reference files
+	if transcript := ci.priorTranscript(ctx, used); transcript != "" {
+		md.WriteString("## 🧾 Previous Session\n\n")
+		md.WriteString(transcript + "\n\n")
+	}
+
 	// Development Environment
 	md.WriteString("## 🛠️ Development Environment\n\n")
 	md.WriteString(fmt.Sprintf("- **Repository**: %s/%s\n", ctx.IssueData.Repository.Owner.Login, ctx.IssueData.Repository.Name))
@@ -83,6 +133,9 @@ func (ci *ClaudeIntegration) generateMarkdownContext(ctx *types.ClaudeContext) (
 				}
 				md.WriteString("\n")
 			}
+			if err.Occurrences() > 1 {
+				md.WriteString(fmt.Sprintf("- **Occurrences**: %d\n", err.Occurrences()))
+			}
 			md.WriteString(fmt.Sprintf("- **Recoverable**: %t\n", err.Recoverable))
 			md.WriteString("\n")
 		}
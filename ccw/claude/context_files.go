@@ -0,0 +1,98 @@
+package claude
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"unicode/utf8"
+)
+
+// Reference files supplied with --context-file
+
+// defaultContextFilesBudget caps the characters of context file content sent
+// to Claude when claude.max_context_chars is unset
+const defaultContextFilesBudget = 100000
+
+// ContextFile is a reference file whose content is added to the Claude context
+type ContextFile struct {
+	Path    string
+	Content string
+}
+
+// LoadContextFiles reads each path, failing on the first that is missing,
+// not a regular file or unreadable
+func LoadContextFiles(paths []string) ([]ContextFile, error) {
+	files := make([]ContextFile, 0, len(paths))
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil, fmt.Errorf("context file %s does not exist", path)
+			}
+			return nil, fmt.Errorf("context file %s is not accessible: %w", path, err)
+		}
+		if !info.Mode().IsRegular() {
+			return nil, fmt.Errorf("context file %s is not a regular file", path)
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read context file %s: %w", path, err)
+		}
+		files = append(files, ContextFile{Path: path, Content: strings.TrimSpace(string(data))})
+	}
+	return files, nil
+}
+
+// formatContextFiles concatenates files between BEGIN/END delimiter lines, in
+// order, spending at most budget characters of file content. The file that
+// crosses the budget is cut at a line or word boundary; later files are left
+// out with a note.
+func formatContextFiles(files []ContextFile, budget int) string {
+	var sections []string
+	remaining := budget
+	omitted := 0
+
+	for _, file := range files {
+		if remaining <= 0 {
+			omitted++
+			continue
+		}
+
+		content := file.Content
+		if total := utf8.RuneCountInString(content); total > remaining {
+			content = strings.TrimRight(cutAtBoundary(content, remaining), " \n")
+			content += fmt.Sprintf("\n\n_(Context file truncated from %d characters to fit the context limit.)_", total)
+			remaining = 0
+		} else {
+			remaining -= total
+		}
+
+		sections = append(sections, fmt.Sprintf("----- BEGIN CONTEXT FILE: %s -----\n%s\n----- END CONTEXT FILE: %s -----", file.Path, content, file.Path))
+	}
+
+	if omitted > 0 {
+		sections = append(sections, fmt.Sprintf("_(%d context file(s) omitted to fit the context limit.)_", omitted))
+	}
+	return strings.Join(sections, "\n\n")
+}
+
+// contextFilesSection returns the context files as they are written to
+// .claude-context.md. When claude.max_context_chars is set they share it with
+// the used characters of the issue body, prelude and comments before them.
+func (ci *ClaudeIntegration) contextFilesSection(used int) string {
+	budget := defaultContextFilesBudget
+	if ci.MaxContextChars > 0 {
+		budget = ci.MaxContextChars - used
+	}
+	return formatContextFiles(ci.ContextFiles, budget)
+}
+
+// withContextFiles points a Claude prompt at the context files. Their content
+// is only in .claude-context.md, keeping the prompt argument short.
+func (ci *ClaudeIntegration) withContextFiles(input string) string {
+	if len(ci.ContextFiles) == 0 {
+		return input
+	}
+	return input + fmt.Sprintf("\n\nReference files supplied for this issue (%d) are in .claude-context.md under \"Reference Files\"; read them before starting.\n", len(ci.ContextFiles))
+}
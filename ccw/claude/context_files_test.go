@@ -0,0 +1,131 @@
+package claude
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"ccw/types"
+)
+
+func TestLoadContextFiles(t *testing.T) {
+	tmpDir := t.TempDir()
+	spec := filepath.Join(tmpDir, "spec.md")
+	if err := os.WriteFile(spec, []byte("\nThe API returns JSON.\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	files, err := LoadContextFiles([]string{spec})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(files) != 1 || files[0].Path != spec || files[0].Content != "The API returns JSON." {
+		t.Errorf("Unexpected files %+v", files)
+	}
+
+	for name, path := range map[string]string{
+		"missing file": filepath.Join(tmpDir, "missing.md"),
+		"directory":    tmpDir,
+	} {
+		if _, err := LoadContextFiles([]string{spec, path}); err == nil || !strings.Contains(err.Error(), path) {
+			t.Errorf("%s: expected an error naming %s, got %v", name, path, err)
+		}
+	}
+}
+
+func TestFormatContextFiles(t *testing.T) {
+	spec := ContextFile{Path: "spec.md", Content: "alpha beta"}
+	design := ContextFile{Path: "design.md", Content: "gamma delta epsilon"}
+	notes := ContextFile{Path: "notes.md", Content: "zeta"}
+
+	t.Run("within budget", func(t *testing.T) {
+		result := formatContextFiles([]ContextFile{spec, design}, 100)
+		expected := "----- BEGIN CONTEXT FILE: spec.md -----\nalpha beta\n----- END CONTEXT FILE: spec.md -----\n\n" +
+			"----- BEGIN CONTEXT FILE: design.md -----\ngamma delta epsilon\n----- END CONTEXT FILE: design.md -----"
+		if result != expected {
+			t.Errorf("Expected:\n%s\ngot:\n%s", expected, result)
+		}
+	})
+
+	t.Run("budget truncates and omits", func(t *testing.T) {
+		// spec uses 10 characters, leaving 12 for design; notes does not fit
+		result := formatContextFiles([]ContextFile{spec, design, notes}, 22)
+		if !strings.Contains(result, "alpha beta\n") {
+			t.Errorf("Expected the first file in full, got:\n%s", result)
+		}
+		if !strings.Contains(result, "gamma delta\n\n_(Context file truncated from 19 characters to fit the context limit.)_\n----- END CONTEXT FILE: design.md -----") {
+			t.Errorf("Expected the second file cut at a word boundary, got:\n%s", result)
+		}
+		if strings.Contains(result, "zeta") || !strings.HasSuffix(result, "_(1 context file(s) omitted to fit the context limit.)_") {
+			t.Errorf("Expected the third file to be omitted, got:\n%s", result)
+		}
+	})
+
+	t.Run("no files", func(t *testing.T) {
+		if result := formatContextFiles(nil, 100); result != "" {
+			t.Errorf("Expected empty output, got '%s'", result)
+		}
+	})
+}
+
+func TestContextFilesInAssembledContext(t *testing.T) {
+	ci := &ClaudeIntegration{
+		MaxContextChars: 1000,
+		ContextFiles: []ContextFile{
+			{Path: "spec.md", Content: "Spec content"},
+			{Path: "design.md", Content: "Design content"},
+		},
+	}
+	ctx := &types.ClaudeContext{
+		IssueData:      &types.Issue{Number: 7, Title: "Add export", Body: "Issue body"},
+		WorktreeConfig: &types.WorktreeConfig{},
+	}
+
+	content, err := ci.MarkdownContext(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	reference := strings.Index(content, "## 📎 Reference Files\n\n----- BEGIN CONTEXT FILE: spec.md -----")
+	if reference < 0 || strings.Index(content, "Issue body") > reference {
+		t.Errorf("Expected reference files after the issue description, got:\n%s", content)
+	}
+	if !strings.Contains(content, "Spec content") || !strings.Contains(content, "Design content") {
+		t.Errorf("Expected both files in the context, got:\n%s", content)
+	}
+
+	// The prompt only points at the context file; the content is sent once
+	prompt := ci.withContextFiles("Please work on issue #7")
+	if !strings.HasPrefix(prompt, "Please work on issue #7\n\nReference files supplied for this issue (2) are in .claude-context.md") {
+		t.Errorf("Expected a pointer to the context file, got '%s'", prompt)
+	}
+	if strings.Contains(prompt, "Spec content") {
+		t.Errorf("Expected no file content in the prompt, got '%s'", prompt)
+	}
+	if result := (&ClaudeIntegration{}).withContextFiles("prompt"); result != "prompt" {
+		t.Errorf("Expected the prompt unchanged without files, got '%s'", result)
+	}
+}
+
+func TestContextFilesShareIssueBudget(t *testing.T) {
+	ci := &ClaudeIntegration{
+		MaxContextChars: 100,
+		ContextFiles:    []ContextFile{{Path: "spec.md", Content: strings.Repeat("spec ", 40)}},
+	}
+	ctx := &types.ClaudeContext{
+		IssueData:      &types.Issue{Number: 7, Title: "Add export", Body: strings.Repeat("body ", 16)},
+		WorktreeConfig: &types.WorktreeConfig{},
+	}
+
+	content, err := ci.MarkdownContext(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The 80 character body leaves 20 characters for the file
+	if count := strings.Count(content, "spec "); count > 4 {
+		t.Errorf("Expected the file cut to the remaining budget, got %d words:\n%s", count, content)
+	}
+	if !strings.Contains(content, "Context file truncated from 200 characters") {
+		t.Errorf("Expected a truncation note, got:\n%s", content)
+	}
+}
@@ -6,13 +6,19 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"sort"
 	"strings"
+	"time"
+	"unicode/utf8"
 
 	"ccw/types"
 )
 
 // RunWithContext executes Claude Code with provided context
 func (ci *ClaudeIntegration) RunWithContext(ctx *types.ClaudeContext) error {
+	// Keep very long issue bodies within the configured context budget
+	ctx = ci.withTruncatedIssueBody(ctx)
+
 	// Create JSON context file
 	contextFile := filepath.Join(ctx.ProjectPath, ".claude-context.json")
 	contextData, err := json.MarshalIndent(ctx, "", "  ")
@@ -40,15 +46,23 @@ func (ci *ClaudeIntegration) RunWithContext(ctx *types.ClaudeContext) error {
 	// Use the correct Claude Code path
 	claudePath := "/Users/kuu/.claude/local/claude"
 
-	// Prepare input for Claude with issue context
-	claudeInput := ci.buildClaudeInput(ctx)
+	// Prepare input for Claude with issue context; the prelude and prior
+	// session share claude.max_context_chars with the issue body
+	used := utf8.RuneCountInString(ctx.IssueData.Body)
+	prelude := ci.contextPrelude(used)
+	transcript := ci.priorTranscript(ctx, used+utf8.RuneCountInString(prelude))
+	claudeInput := withPrelude(withTranscript(ci.withContextFiles(ci.buildClaudeInput(ctx)), transcript), prelude)
 
-	// Always use interactive mode with pre-filled prompt
-	args := []string{claudeInput}
+	// Always use interactive mode with pre-filled prompt; tool and directory
+	// flags follow the prompt
+	args := append([]string{claudeInput}, ci.permissionArgs(ctx)...)
 
 	// Create command - no timeout for interactive mode
 	cmd := exec.Command(claudePath, args...)
 	cmd.Dir = ctx.ProjectPath
+	if len(ci.Env) > 0 {
+		cmd.Env = append(os.Environ(), ci.Env...)
+	}
 	
 	// Run Claude interactively with the prompt pre-loaded
 	cmd.Stdout = os.Stdout
@@ -64,7 +78,15 @@ func (ci *ClaudeIntegration) RunWithContext(ctx *types.ClaudeContext) error {
 	fmt.Printf("🚀 Launching Claude Code...\n\n")
 
 	// Run in interactive mode
-	if err := cmd.Run(); err != nil {
+	startedAt := time.Now()
+	runErr := cmd.Run()
+
+	// Keep the session for the next run; a missing transcript never fails the run
+	if err := ci.saveSessionTranscript(ctx, startedAt); err != nil && ci.DebugMode {
+		fmt.Printf("⚠️  Could not save the Claude Code transcript: %v\n", err)
+	}
+
+	if err := runErr; err != nil {
 		// Enhanced error reporting
 		var errorDetails strings.Builder
 		errorDetails.WriteString(fmt.Sprintf("Claude Code execution failed: %v\n", err))
@@ -161,23 +183,27 @@ func formatValidationErrorsDetailed(errors []types.ValidationError) string {
 		return "✅ No validation errors found."
 	}
 
-	// Group errors by type
-	errorsByType := make(map[string][]types.ValidationError)
-	for _, err := range errors {
-		errorsByType[err.Type] = append(errorsByType[err.Type], err)
-	}
-
 	var output strings.Builder
 
-	for errorType, errs := range errorsByType {
-		output.WriteString(fmt.Sprintf("━━━ %s ERRORS (%d) ━━━\n", strings.ToUpper(errorType), len(errs)))
+	// Group errors by type in a stable order so Claude sees the same prompt for the same errors
+	groups := types.GroupErrorsByType(errors)
+	errorTypes := make(map[string]bool, len(groups))
+	for _, group := range groups {
+		errorTypes[group.Type] = true
+	}
+
+	for _, group := range groups {
+		output.WriteString(fmt.Sprintf("━━━ %s ERRORS (%d) ━━━\n", strings.ToUpper(group.Type), len(group.Errors)))
 
-		for i, err := range errs {
+		for i, err := range group.Errors {
 			output.WriteString(fmt.Sprintf("%d. ", i+1))
 			if err.File != "" && err.Line > 0 {
 				output.WriteString(fmt.Sprintf("📁 %s:%d\n", err.File, err.Line))
 			}
 			output.WriteString(fmt.Sprintf("   💬 %s\n", err.Message))
+			if err.Occurrences() > 1 {
+				output.WriteString(fmt.Sprintf("   🔁 Occurred %d times\n", err.Occurrences()))
+			}
 
 			// Add detailed cause information if available
 			if err.Cause != nil {
@@ -200,8 +226,13 @@ func formatValidationErrorsDetailed(errors []types.ValidationError) string {
 				}
 				if len(err.Cause.Context) > 0 {
 					output.WriteString("   📋 Context:\n")
-					for key, value := range err.Cause.Context {
-						output.WriteString(fmt.Sprintf("      %s: %s\n", key, value))
+					keys := make([]string, 0, len(err.Cause.Context))
+					for key := range err.Cause.Context {
+						keys = append(keys, key)
+					}
+					sort.Strings(keys)
+					for _, key := range keys {
+						output.WriteString(fmt.Sprintf("      %s: %s\n", key, err.Cause.Context[key]))
 					}
 				}
 			}
@@ -218,15 +249,15 @@ func formatValidationErrorsDetailed(errors []types.ValidationError) string {
 	// Add helpful recovery suggestions
 	output.WriteString("🔍 RECOVERY SUGGESTIONS:\n")
 
-	if _, hasLint := errorsByType["lint"]; hasLint {
+	if errorTypes["lint"] {
 		output.WriteString("• SwiftLint: Run 'swiftlint lint --fix' first, then manually fix remaining issues\n")
 	}
 
-	if _, hasBuild := errorsByType["build"]; hasBuild {
+	if errorTypes["build"] {
 		output.WriteString("• Build: Check imports, types, and syntax. Look for compilation errors in output\n")
 	}
 
-	if _, hasTest := errorsByType["test"]; hasTest {
+	if errorTypes["test"] {
 		output.WriteString("• Tests: Check test logic, assertions, and test data. Run individual tests to isolate issues\n")
 	}
 
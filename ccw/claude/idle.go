@@ -0,0 +1,51 @@
+package claude
+
+import (
+	"context"
+	"os/exec"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	claudecli "ccw/pkg/claude"
+)
+
+// Idle watchdog for non-interactive Claude Code runs (claude.idle_timeout)
+
+// IdleTimeoutError reports a Claude Code run aborted because it produced no
+// output for the idle timeout
+type IdleTimeoutError = claudecli.IdleTimeoutError
+
+// runPrint runs `claude --print` in dir with prompt on stdin and returns its
+// stdout, plus stderr when combined. The run is killed after timeout, or with
+// an *IdleTimeoutError once it has written nothing for ci.IdleTimeout.
+func (ci *ClaudeIntegration) runPrint(dir, prompt string, timeout time.Duration, combined bool) ([]byte, error) {
+	cmdCtx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(cmdCtx, "claude", append([]string{"--print"}, ci.toolArgs()...)...)
+	cmd.Dir = dir
+	cmd.Stdin = strings.NewReader(prompt)
+	// Don't wait on children still holding the output after Claude Code is killed
+	cmd.WaitDelay = time.Second
+
+	output := claudecli.NewActivityWriter()
+	cmd.Stdout = output
+	if combined {
+		cmd.Stderr = output
+	}
+
+	var stalled atomic.Bool
+	if ci.IdleTimeout > 0 {
+		go claudecli.WatchIdle(cmdCtx, output, ci.IdleTimeout, func() {
+			stalled.Store(true)
+			cancel()
+		})
+	}
+
+	err := cmd.Run()
+	if err != nil && stalled.Load() {
+		err = &IdleTimeoutError{Idle: ci.IdleTimeout}
+	}
+	return output.Bytes(), err
+}
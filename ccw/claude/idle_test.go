@@ -0,0 +1,69 @@
+package claude
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// setupFakeClaude puts a claude script running body first on PATH
+func setupFakeClaude(t *testing.T, body string) {
+	t.Helper()
+	binDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(binDir, "claude"), []byte("#!/bin/sh\n"+body+"\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", binDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+}
+
+func TestRunPrintAbortsIdleRun(t *testing.T) {
+	setupFakeClaude(t, "echo started\nexec sleep 30")
+	ci := &ClaudeIntegration{IdleTimeout: 400 * time.Millisecond}
+
+	start := time.Now()
+	output, err := ci.runPrint(t.TempDir(), "prompt", 30*time.Second, true)
+
+	var idleErr *IdleTimeoutError
+	if !errors.As(err, &idleErr) {
+		t.Fatalf("Expected *IdleTimeoutError, got %T: %v", err, err)
+	}
+	if idleErr.Idle != 400*time.Millisecond {
+		t.Errorf("Expected idle %v, got %v", 400*time.Millisecond, idleErr.Idle)
+	}
+	if !strings.Contains(string(output), "started") {
+		t.Errorf("Expected the output so far, got '%s'", output)
+	}
+	if elapsed := time.Since(start); elapsed > 10*time.Second {
+		t.Errorf("Expected the watchdog to abort well before the hard timeout, took %v", elapsed)
+	}
+}
+
+func TestRunPrintAllowsSteadyOutput(t *testing.T) {
+	// Runs longer than the idle timeout in total, but never goes quiet for that long
+	setupFakeClaude(t, "cat >/dev/null\nfor i in 1 2 3 4 5; do echo line $i; sleep 0.2; done")
+	ci := &ClaudeIntegration{IdleTimeout: 600 * time.Millisecond}
+
+	output, err := ci.runPrint(t.TempDir(), "prompt", 30*time.Second, false)
+	if err != nil {
+		t.Fatalf("Expected success, got error: %v", err)
+	}
+	if !strings.Contains(string(output), "line 5") {
+		t.Errorf("Expected full output, got '%s'", output)
+	}
+}
+
+func TestRunPrintWithoutIdleTimeout(t *testing.T) {
+	setupFakeClaude(t, "cat\necho done >&2")
+	ci := &ClaudeIntegration{}
+
+	output, err := ci.runPrint(t.TempDir(), "prompt", 30*time.Second, true)
+	if err != nil {
+		t.Fatalf("Expected success, got error: %v", err)
+	}
+	if string(output) != "promptdone\n" {
+		t.Errorf("Expected the prompt echoed with stderr combined, got '%s'", output)
+	}
+}
@@ -1,11 +1,9 @@
 package claude
 
 import (
-	"context"
 	"encoding/json"
 	"fmt"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"strings"
 	"time"
@@ -49,18 +47,10 @@ func (ci *ClaudeIntegration) generatePRDescriptionSync(req *types.PRDescriptionR
 	}
 	defer os.Remove(contextFile)
 
-	// Create command with timeout
-	cmdCtx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
-	defer cancel()
-
-	cmd := exec.CommandContext(cmdCtx, "claude", "--print")
-	cmd.Dir = req.WorktreeConfig.WorktreePath
-
 	// Prepare Claude prompt for PR description generation
 	claudeInput := ci.buildPRDescriptionPrompt(req)
 
-	cmd.Stdin = strings.NewReader(claudeInput)
-	output, err := cmd.CombinedOutput()
+	output, err := ci.runPrint(req.WorktreeConfig.WorktreePath, claudeInput, 5*time.Minute, true)
 	if err != nil {
 		return "", fmt.Errorf("Claude Code PR description generation failed: %w\nOutput: %s", err, string(output))
 	}
@@ -69,7 +59,8 @@ func (ci *ClaudeIntegration) generatePRDescriptionSync(req *types.PRDescriptionR
 	description := strings.TrimSpace(string(output))
 
 	// Basic validation that we got markdown content
-	if len(description) < 100 || !strings.Contains(description, "##") {
+	// Repository templates need not use "##" headings
+	if len(description) < 100 || (req.TemplateBody == "" && !strings.Contains(description, "##")) {
 		return "", fmt.Errorf("Claude Code returned invalid or incomplete PR description")
 	}
 
@@ -78,6 +69,10 @@ func (ci *ClaudeIntegration) generatePRDescriptionSync(req *types.PRDescriptionR
 
 // buildPRDescriptionPrompt creates the prompt for PR description generation
 func (ci *ClaudeIntegration) buildPRDescriptionPrompt(req *types.PRDescriptionRequest) string {
+	if req.TemplateBody != "" {
+		return ci.buildPRTemplatePrompt(req)
+	}
+
 	return fmt.Sprintf(`
 Please generate a comprehensive pull request description for GitHub issue #%d: %s
 
@@ -171,6 +166,11 @@ func (ci *ClaudeIntegration) CreateEnhancedPRDescription(req *types.PRDescriptio
 
 // getFallbackPRDescription returns a template-based PR description
 func (ci *ClaudeIntegration) getFallbackPRDescription(req *types.PRDescriptionRequest) string {
+	// The repository's own template, already filled with what CCW knows
+	if req.TemplateBody != "" {
+		return req.TemplateBody
+	}
+
 	return fmt.Sprintf(`## Summary
 Resolves #%d
 
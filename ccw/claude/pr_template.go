@@ -0,0 +1,201 @@
+package claude
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"ccw/types"
+)
+
+// prTemplateCandidates are the locations GitHub reads a single PR template from
+var prTemplateCandidates = []string{
+	".github/pull_request_template.md",
+	".github/PULL_REQUEST_TEMPLATE.md",
+	"pull_request_template.md",
+	"PULL_REQUEST_TEMPLATE.md",
+	"docs/pull_request_template.md",
+	"docs/PULL_REQUEST_TEMPLATE.md",
+}
+
+// prTemplateHeadingPattern matches a markdown heading line
+var prTemplateHeadingPattern = regexp.MustCompile(`^\s{0,3}#{1,6}\s+(.+?)\s*#*\s*$`)
+
+// htmlCommentPattern matches HTML comments, which templates use for instructions
+var htmlCommentPattern = regexp.MustCompile(`(?s)<!--.*?-->`)
+
+// Template section kinds CCW knows how to fill
+const (
+	prSectionSummary = "summary"
+	prSectionTesting = "testing"
+	prSectionIssue   = "issue"
+)
+
+// prSectionKeywords maps lowercase heading keywords to the section kind they denote
+var prSectionKeywords = []struct {
+	kind     string
+	keywords []string
+}{
+	{prSectionTesting, []string{"test", "validation", "verification", "qa"}},
+	{prSectionIssue, []string{"related issue", "linked issue", "issue", "fixes", "closes"}},
+	{prSectionSummary, []string{"summary", "description", "overview", "motivation"}},
+}
+
+// FindPRTemplate returns the path of the repository's pull request template in
+// worktreePath. When only a PULL_REQUEST_TEMPLATE directory exists, the first
+// markdown file in it is used. The boolean is false if no template exists.
+func FindPRTemplate(worktreePath string) (string, bool) {
+	for _, candidate := range prTemplateCandidates {
+		path := filepath.Join(worktreePath, candidate)
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			return path, true
+		}
+	}
+
+	for _, dir := range []string{".github/PULL_REQUEST_TEMPLATE", "PULL_REQUEST_TEMPLATE", "docs/PULL_REQUEST_TEMPLATE"} {
+		matches, _ := filepath.Glob(filepath.Join(worktreePath, dir, "*.md"))
+		if len(matches) > 0 {
+			sort.Strings(matches)
+			return matches[0], true
+		}
+	}
+
+	return "", false
+}
+
+// LoadPRTemplate discovers the repository's PR template and fills its known
+// sections from req. It returns "" when the repository has no template.
+func LoadPRTemplate(req *types.PRDescriptionRequest) (string, error) {
+	if req.WorktreeConfig == nil {
+		return "", nil
+	}
+
+	path, ok := FindPRTemplate(req.WorktreeConfig.WorktreePath)
+	if !ok {
+		return "", nil
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read PR template %s: %w", path, err)
+	}
+
+	return FillPRTemplate(string(content), req), nil
+}
+
+// FillPRTemplate fills the summary, testing and related-issue sections of a PR
+// template. Filled content replaces the section's instructional HTML comments;
+// any other section content (such as checklists) is kept after it, and
+// sections CCW does not recognise are left untouched.
+func FillPRTemplate(template string, req *types.PRDescriptionRequest) string {
+	fills := map[string]string{
+		prSectionSummary: strings.TrimSpace(req.ImplementationSummary),
+		prSectionTesting: templateTestingSection(req.ValidationResult),
+	}
+	if req.Issue != nil {
+		fills[prSectionIssue] = fmt.Sprintf("Resolves #%d", req.Issue.Number)
+	}
+
+	lines := strings.Split(strings.ReplaceAll(template, "\r\n", "\n"), "\n")
+	var result []string
+	filled := make(map[string]bool)
+
+	for i := 0; i < len(lines); i++ {
+		result = append(result, lines[i])
+
+		matches := prTemplateHeadingPattern.FindStringSubmatch(lines[i])
+		if matches == nil {
+			continue
+		}
+		kind := classifyPRTemplateHeading(matches[1])
+		if kind == "" || filled[kind] || fills[kind] == "" {
+			continue
+		}
+
+		// Collect the section body up to the next heading
+		end := i + 1
+		for end < len(lines) && !prTemplateHeadingPattern.MatchString(lines[end]) {
+			end++
+		}
+		remaining := strings.TrimSpace(htmlCommentPattern.ReplaceAllString(strings.Join(lines[i+1:end], "\n"), ""))
+
+		section := fills[kind]
+		if remaining != "" {
+			section += "\n\n" + remaining
+		}
+		result = append(result, "", section, "")
+		filled[kind] = true
+		i = end - 1
+	}
+
+	return strings.TrimRight(strings.Join(result, "\n"), "\n") + "\n"
+}
+
+// classifyPRTemplateHeading returns the section kind a heading denotes, or ""
+func classifyPRTemplateHeading(heading string) string {
+	heading = strings.ToLower(heading)
+	for _, section := range prSectionKeywords {
+		for _, keyword := range section.keywords {
+			if strings.Contains(heading, keyword) {
+				return section.kind
+			}
+		}
+	}
+	return ""
+}
+
+// templateTestingSection lists the validation results for the testing section
+func templateTestingSection(result *types.ValidationResult) string {
+	if result == nil {
+		return ""
+	}
+	return fmt.Sprintf("- SwiftLint: %s\n- Build: %s\n- Tests: %s",
+		validationStatus(result.LintResult != nil, result.LintResult != nil && result.LintResult.Success),
+		validationStatus(result.BuildResult != nil, result.BuildResult != nil && result.BuildResult.Success),
+		validationStatus(result.TestResult != nil, result.TestResult != nil && result.TestResult.Success))
+}
+
+// validationStatus renders a validation step outcome
+func validationStatus(ran, success bool) string {
+	switch {
+	case !ran:
+		return "➖ Skipped"
+	case success:
+		return "✅ Passed"
+	default:
+		return "❌ Failed"
+	}
+}
+
+// buildPRTemplatePrompt asks Claude to complete the repository's PR template
+// rather than CCW's default description layout
+func (ci *ClaudeIntegration) buildPRTemplatePrompt(req *types.PRDescriptionRequest) string {
+	return fmt.Sprintf(`
+Please complete this repository's pull request template for GitHub issue #%d: %s
+
+Issue Description:
+%s
+
+Implementation Summary:
+%s
+
+The template below has been pre-filled where possible. Keep every heading, checklist
+and section in the order given, refine the pre-filled sections, and fill in the
+remaining sections from the issue and the changes in this worktree. Tick checklist
+items only when they are true for this change.
+
+Template:
+%s
+
+Please respond with ONLY the completed Markdown template, no additional text or formatting.
+`,
+		req.Issue.Number,
+		req.Issue.Title,
+		req.Issue.Body,
+		req.ImplementationSummary,
+		req.TemplateBody,
+	)
+}
@@ -0,0 +1,173 @@
+package claude
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"ccw/types"
+)
+
+func writeTemplate(t *testing.T, root, relPath, content string) string {
+	t.Helper()
+	path := filepath.Join(root, relPath)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestFindPRTemplate(t *testing.T) {
+	tests := []struct {
+		name     string
+		files    []string
+		expected string
+	}{
+		{"no template", nil, ""},
+		{"github directory", []string{".github/PULL_REQUEST_TEMPLATE.md"}, ".github/PULL_REQUEST_TEMPLATE.md"},
+		{"lowercase name", []string{".github/pull_request_template.md"}, ".github/pull_request_template.md"},
+		{"repository root", []string{"PULL_REQUEST_TEMPLATE.md"}, "PULL_REQUEST_TEMPLATE.md"},
+		{"docs directory", []string{"docs/PULL_REQUEST_TEMPLATE.md"}, "docs/PULL_REQUEST_TEMPLATE.md"},
+		{".github wins over root", []string{"PULL_REQUEST_TEMPLATE.md", ".github/PULL_REQUEST_TEMPLATE.md"}, ".github/PULL_REQUEST_TEMPLATE.md"},
+		{"template directory", []string{".github/PULL_REQUEST_TEMPLATE/feature.md", ".github/PULL_REQUEST_TEMPLATE/bugfix.md"}, ".github/PULL_REQUEST_TEMPLATE/bugfix.md"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := t.TempDir()
+			for _, file := range tt.files {
+				writeTemplate(t, root, file, "## Summary\n")
+			}
+
+			path, ok := FindPRTemplate(root)
+			if tt.expected == "" {
+				if ok {
+					t.Errorf("Expected no template, got '%s'", path)
+				}
+				return
+			}
+			if !ok {
+				t.Fatal("Expected a template to be found")
+			}
+			// Case-insensitive filesystems may resolve either spelling
+			if !strings.EqualFold(path, filepath.Join(root, tt.expected)) {
+				t.Errorf("Expected '%s', got '%s'", filepath.Join(root, tt.expected), path)
+			}
+		})
+	}
+}
+
+func templateRequest() *types.PRDescriptionRequest {
+	return &types.PRDescriptionRequest{
+		Issue:                 &types.Issue{Number: 42, Title: "Add interpolation"},
+		ImplementationSummary: "Implementation completed with the following changes:\n- Modified 1 existing files: Lexer.swift",
+		ValidationResult: &types.ValidationResult{
+			Success:     true,
+			LintResult:  &types.LintResult{Success: true},
+			BuildResult: &types.BuildResult{Success: true},
+		},
+	}
+}
+
+func TestFillPRTemplate(t *testing.T) {
+	template := `## Description
+<!-- Describe your changes in detail -->
+
+## Related Issue
+<!-- Link the issue here -->
+
+## How Has This Been Tested?
+<!-- Please describe the tests you ran -->
+- [ ] Unit tests
+
+## Screenshots (if appropriate)
+
+## Checklist
+- [ ] My code follows the style guidelines
+`
+
+	filled := FillPRTemplate(template, templateRequest())
+
+	expected := `## Description
+
+Implementation completed with the following changes:
+- Modified 1 existing files: Lexer.swift
+
+## Related Issue
+
+Resolves #42
+
+## How Has This Been Tested?
+
+- SwiftLint: ✅ Passed
+- Build: ✅ Passed
+- Tests: ➖ Skipped
+
+- [ ] Unit tests
+
+## Screenshots (if appropriate)
+
+## Checklist
+- [ ] My code follows the style guidelines
+`
+	if filled != expected {
+		t.Errorf("Expected filled template:\n%s\ngot:\n%s", expected, filled)
+	}
+}
+
+func TestFillPRTemplateKeepsUnknownSections(t *testing.T) {
+	template := "# Type of change\n- [ ] Bug fix\n- [ ] New feature\r\n"
+
+	filled := FillPRTemplate(template, templateRequest())
+	if filled != "# Type of change\n- [ ] Bug fix\n- [ ] New feature\n" {
+		t.Errorf("Expected unknown section to be unchanged, got %q", filled)
+	}
+}
+
+func TestFillPRTemplateFillsEachSectionOnce(t *testing.T) {
+	template := "## Summary\n\n## Summary of dependencies\nNone\n"
+	req := templateRequest()
+	req.ImplementationSummary = "Adds interpolation."
+
+	filled := FillPRTemplate(template, req)
+	if strings.Count(filled, "Adds interpolation.") != 1 {
+		t.Errorf("Expected the summary to be filled once, got:\n%s", filled)
+	}
+	if !strings.Contains(filled, "## Summary of dependencies\nNone") {
+		t.Errorf("Expected the second section to be untouched, got:\n%s", filled)
+	}
+}
+
+func TestLoadPRTemplate(t *testing.T) {
+	root := t.TempDir()
+	req := templateRequest()
+	req.WorktreeConfig = &types.WorktreeConfig{WorktreePath: root}
+
+	body, err := LoadPRTemplate(req)
+	if err != nil || body != "" {
+		t.Fatalf("Expected no template body without a template, got %q (%v)", body, err)
+	}
+
+	writeTemplate(t, root, ".github/PULL_REQUEST_TEMPLATE.md", "## Summary\n<!-- what changed -->\n")
+	body, err = LoadPRTemplate(req)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !strings.Contains(body, "Lexer.swift") || strings.Contains(body, "<!--") {
+		t.Errorf("Expected the filled summary section, got:\n%s", body)
+	}
+}
+
+func TestFallbackPRDescriptionUsesTemplate(t *testing.T) {
+	req := templateRequest()
+	req.TemplateBody = "## Summary\n\nAdds interpolation.\n"
+
+	ci := &ClaudeIntegration{}
+	if got := ci.getFallbackPRDescription(req); got != req.TemplateBody {
+		t.Errorf("Expected fallback to use the template body, got:\n%s", got)
+	}
+}
@@ -0,0 +1,65 @@
+package claude
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"unicode/utf8"
+)
+
+// Team prelude prepended to every implementation run
+
+// LoadPrelude resolves the claude.prelude setting. A value starting with "@"
+// names a file (relative to the working directory) whose content is used;
+// anything else is the prelude text itself.
+func LoadPrelude(value string) (string, error) {
+	if !strings.HasPrefix(value, "@") {
+		return strings.TrimSpace(value), nil
+	}
+
+	path := strings.TrimPrefix(value, "@")
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read prelude file %s: %w", path, err)
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+// truncatePrelude shortens prelude to at most maxChars characters, ending at a
+// line or word boundary and noting the cut. A maxChars of 0 or less disables
+// truncation.
+func truncatePrelude(prelude string, maxChars int) string {
+	total := utf8.RuneCountInString(prelude)
+	if maxChars <= 0 || total <= maxChars {
+		return prelude
+	}
+
+	note := fmt.Sprintf("\n\n_(Prelude truncated from %d characters to fit the context limit.)_", total)
+	budget := maxChars - utf8.RuneCountInString(note)
+	if budget <= 0 {
+		return string([]rune(prelude)[:maxChars])
+	}
+	return strings.TrimRight(cutAtBoundary(prelude, budget), " \n") + note
+}
+
+// contextPrelude returns the prelude as it is sent to Claude. When
+// claude.max_context_chars is set it gets what is left of it after the used
+// characters of the issue body.
+func (ci *ClaudeIntegration) contextPrelude(used int) string {
+	if ci.MaxContextChars <= 0 {
+		return ci.Prelude
+	}
+	remaining := ci.MaxContextChars - used
+	if remaining <= 0 {
+		return ""
+	}
+	return truncatePrelude(ci.Prelude, remaining)
+}
+
+// withPrelude prepends the prelude to a Claude prompt
+func withPrelude(input, prelude string) string {
+	if prelude == "" {
+		return input
+	}
+	return prelude + "\n\n" + input
+}
@@ -0,0 +1,165 @@
+package claude
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"ccw/types"
+)
+
+func TestLoadPrelude(t *testing.T) {
+	tmpDir := t.TempDir()
+	path := filepath.Join(tmpDir, "rules.md")
+	if err := os.WriteFile(path, []byte("\nUse tabs.\nNo new dependencies.\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		value    string
+		expected string
+		wantErr  bool
+	}{
+		{"empty", "", "", false},
+		{"inline text", "  Follow docs/style.md  ", "Follow docs/style.md", false},
+		{"file reference", "@" + path, "Use tabs.\nNo new dependencies.", false},
+		{"missing file", "@" + filepath.Join(tmpDir, "missing.md"), "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := LoadPrelude(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Expected error %v, got %v", tt.wantErr, err)
+			}
+			if result != tt.expected {
+				t.Errorf("Expected '%s', got '%s'", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestTruncatePrelude(t *testing.T) {
+	prelude := strings.Repeat("Keep functions small.\n", 50)
+
+	if result := truncatePrelude(prelude, 0); result != prelude {
+		t.Error("Expected no truncation with a zero limit")
+	}
+	if result := truncatePrelude("short", 100); result != "short" {
+		t.Errorf("Expected 'short', got '%s'", result)
+	}
+
+	result := truncatePrelude(prelude, 300)
+	if utf8.RuneCountInString(result) > 300 {
+		t.Errorf("Expected at most 300 characters, got %d", utf8.RuneCountInString(result))
+	}
+	if !strings.HasPrefix(result, "Keep functions small.\n") {
+		t.Errorf("Expected prelude to keep its beginning, got '%s'", result)
+	}
+	if !strings.Contains(result, "Prelude truncated from 1100 characters") {
+		t.Errorf("Expected truncation note, got '%s'", result)
+	}
+}
+
+func TestMarkdownContextIncludesPrelude(t *testing.T) {
+	ctx := &types.ClaudeContext{
+		IssueData:      &types.Issue{Number: 7, Title: "Add parser", Body: "Parse things"},
+		WorktreeConfig: &types.WorktreeConfig{BranchName: "issue-7"},
+		TaskType:       "implementation",
+	}
+
+	ci := &ClaudeIntegration{Prelude: "Use tabs for indentation."}
+	content, err := ci.MarkdownContext(ctx)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	preludeIdx := strings.Index(content, "## 📌 Team Guidelines\n\nUse tabs for indentation.")
+	issueIdx := strings.Index(content, "## 📋 Issue Information")
+	if preludeIdx < 0 || preludeIdx > issueIdx {
+		t.Errorf("Expected prelude section before the issue, got:\n%s", content)
+	}
+
+	ci = &ClaudeIntegration{}
+	content, err = ci.MarkdownContext(ctx)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if strings.Contains(content, "Team Guidelines") {
+		t.Error("Expected no prelude section without a prelude")
+	}
+}
+
+func TestWithPrelude(t *testing.T) {
+	ci := &ClaudeIntegration{Prelude: strings.Repeat("rule ", 100), MaxContextChars: 120}
+
+	result := withPrelude("Please work on issue #7", ci.contextPrelude(0))
+	if !strings.HasSuffix(result, "\n\nPlease work on issue #7") {
+		t.Errorf("Expected prompt after the prelude, got '%s'", result)
+	}
+	if !strings.Contains(result, "Prelude truncated") {
+		t.Errorf("Expected prelude truncated to the context limit, got '%s'", result)
+	}
+
+	if result := withPrelude("prompt", (&ClaudeIntegration{}).contextPrelude(0)); result != "prompt" {
+		t.Errorf("Expected 'prompt', got '%s'", result)
+	}
+}
+
+func TestMarkdownContextSharesBudgetWithPreludeAndTranscript(t *testing.T) {
+	worktree := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(worktree, TranscriptDir), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(TranscriptPath(worktree), []byte(sampleTranscript), 0644); err != nil {
+		t.Fatal(err)
+	}
+	newContext := func(body string) *types.ClaudeContext {
+		return &types.ClaudeContext{
+			IssueData:      &types.Issue{Number: 7, Title: "Add parser", Body: body},
+			WorktreeConfig: &types.WorktreeConfig{BranchName: "issue-7", WorktreePath: worktree},
+			TaskType:       "implementation",
+		}
+	}
+
+	// The fixed template without a prelude, body or transcript
+	baseline, err := (&ClaudeIntegration{}).MarkdownContext(newContext(""))
+	if err != nil {
+		t.Fatal(err)
+	}
+	headings := utf8.RuneCountInString("## 📌 Team Guidelines\n\n\n\n## 🧾 Previous Session\n\n\n\n")
+
+	tests := []struct {
+		name           string
+		maxChars       int
+		body           string
+		prelude        string
+		wantTranscript bool
+	}{
+		{"prelude fills what the body leaves", 400, strings.Repeat("b", 150), strings.Repeat("Keep functions small.\n", 50), false},
+		{"transcript gets the rest", 1000, strings.Repeat("b", 100), "Use tabs for indentation.", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ci := &ClaudeIntegration{Prelude: tt.prelude, MaxContextChars: tt.maxChars, ResumeTranscript: true}
+			content, err := ci.MarkdownContext(newContext(tt.body))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			added := utf8.RuneCountInString(content) - utf8.RuneCountInString(baseline) - headings
+			if added > tt.maxChars {
+				t.Errorf("Expected the prelude, body and transcript within %d characters, got %d", tt.maxChars, added)
+			}
+			if !strings.Contains(content, "Team Guidelines") {
+				t.Error("Expected the prelude to be kept")
+			}
+			if tt.wantTranscript && !strings.Contains(content, "Previous Session") {
+				t.Errorf("Expected the transcript in the remaining budget, got:\n%s", content)
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package claude
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// completedTasksPattern finds the "COMPLETED:" answer line in Claude's reply
+var completedTasksPattern = regexp.MustCompile("(?im)^[\\s`*]*COMPLETED:\\s*(.*)$")
+
+// IdentifyCompletedTasks asks Claude which of the issue's task list items the
+// branch changes summarised by diffStat address and returns those items
+func (ci *ClaudeIntegration) IdentifyCompletedTasks(worktreePath, diffStat string, tasks []string) ([]string, error) {
+	if len(tasks) == 0 {
+		return nil, nil
+	}
+
+	output, err := ci.runPrint(worktreePath, buildCompletedTasksPrompt(tasks, diffStat), 2*time.Minute, true)
+	if err != nil {
+		return nil, fmt.Errorf("Claude Code task list analysis failed: %w\nOutput: %s", err, string(output))
+	}
+
+	var completed []string
+	for _, n := range parseCompletedTaskNumbers(string(output), len(tasks)) {
+		completed = append(completed, tasks[n-1])
+	}
+	return completed, nil
+}
+
+// buildCompletedTasksPrompt creates the prompt listing the numbered tasks
+func buildCompletedTasksPrompt(tasks []string, diffStat string) string {
+	var prompt strings.Builder
+	prompt.WriteString("You have just implemented changes for a GitHub issue in this repository.\n")
+	prompt.WriteString("Review the changes and decide which of the issue's tasks are fully addressed.\n\n")
+
+	prompt.WriteString("## Tasks\n")
+	for i, task := range tasks {
+		prompt.WriteString(fmt.Sprintf("%d. %s\n", i+1, task))
+	}
+
+	if strings.TrimSpace(diffStat) != "" {
+		prompt.WriteString("\n## Changed Files\n```\n")
+		prompt.WriteString(strings.TrimSpace(diffStat))
+		prompt.WriteString("\n```\n")
+	}
+
+	prompt.WriteString("\nOnly include tasks you are confident are complete. ")
+	prompt.WriteString("Reply with a single line in the form `COMPLETED: 1, 3` listing task numbers, ")
+	prompt.WriteString("or `COMPLETED: none` if no task is complete.\n")
+	return prompt.String()
+}
+
+// parseCompletedTaskNumbers extracts valid, de-duplicated task numbers (1..taskCount)
+// from Claude's reply
+func parseCompletedTaskNumbers(output string, taskCount int) []int {
+	matches := completedTasksPattern.FindStringSubmatch(output)
+	if matches == nil {
+		return nil
+	}
+
+	var numbers []int
+	seen := make(map[int]bool)
+	for _, field := range strings.FieldsFunc(matches[1], func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\t' || r == '`'
+	}) {
+		n, err := strconv.Atoi(strings.TrimPrefix(field, "#"))
+		if err != nil || n < 1 || n > taskCount || seen[n] {
+			continue
+		}
+		seen[n] = true
+		numbers = append(numbers, n)
+	}
+	return numbers
+}
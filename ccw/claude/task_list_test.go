@@ -0,0 +1,42 @@
+package claude
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseCompletedTaskNumbers(t *testing.T) {
+	tests := []struct {
+		name      string
+		output    string
+		taskCount int
+		expected  []int
+	}{
+		{"comma separated", "COMPLETED: 1, 3", 3, []int{1, 3}},
+		{"with explanation", "Task 2 is only partly done.\nCOMPLETED: 1,3\n", 3, []int{1, 3}},
+		{"backticks and hashes", "`COMPLETED: #2 #1`", 2, []int{2, 1}},
+		{"lowercase prefix", "completed: 2", 2, []int{2}},
+		{"none", "COMPLETED: none", 3, nil},
+		{"out of range and duplicates", "COMPLETED: 0, 2, 2, 9", 3, []int{2}},
+		{"no answer line", "I could not tell.", 3, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseCompletedTaskNumbers(tt.output, tt.taskCount); !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestBuildCompletedTasksPrompt(t *testing.T) {
+	prompt := buildCompletedTasksPrompt([]string{"Tokenize strings", "Add tests"}, " Sources/Lexer.swift | 12 ++\n")
+
+	for _, expected := range []string{"1. Tokenize strings", "2. Add tests", "Sources/Lexer.swift | 12 ++", "COMPLETED:"} {
+		if !strings.Contains(prompt, expected) {
+			t.Errorf("Expected prompt to contain '%s'", expected)
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package claude
+
+import (
+	"path/filepath"
+	"strings"
+
+	"ccw/types"
+)
+
+// Claude CLI flags that limit which tools and directories Claude Code may use
+
+// toolArgs returns the --allowedTools and --disallowedTools flags for the
+// configured tool rules, such as "Bash(git diff:*)" or "Edit". Each rule is its
+// own argument, since a comma inside a rule would split a joined list; callers
+// put the prompt before these flags or on stdin so it is never read as a rule.
+func (ci *ClaudeIntegration) toolArgs() []string {
+	var args []string
+	if tools := nonEmpty(ci.AllowedTools); len(tools) > 0 {
+		args = append(append(args, "--allowedTools"), tools...)
+	}
+	if tools := nonEmpty(ci.DisallowedTools); len(tools) > 0 {
+		args = append(append(args, "--disallowedTools"), tools...)
+	}
+	return args
+}
+
+// permissionArgs returns the tool flags for an interactive run, plus --add-dir
+// for the worktree root when Claude works in a claude.subdir below it. With
+// WorkingDirOnly the root is not added, so Claude's file tools stay inside the
+// directory it runs in.
+func (ci *ClaudeIntegration) permissionArgs(ctx *types.ClaudeContext) []string {
+	args := ci.toolArgs()
+	if ci.WorkingDirOnly || ctx == nil || ctx.WorktreeConfig == nil || ctx.WorktreeConfig.WorktreePath == "" {
+		return args
+	}
+	if filepath.Clean(ctx.WorktreeConfig.WorktreePath) != filepath.Clean(ctx.ProjectPath) {
+		args = append(args, "--add-dir", ctx.WorktreeConfig.WorktreePath)
+	}
+	return args
+}
+
+// nonEmpty returns values with blank entries dropped and the rest trimmed
+func nonEmpty(values []string) []string {
+	var kept []string
+	for _, value := range values {
+		if value = strings.TrimSpace(value); value != "" {
+			kept = append(kept, value)
+		}
+	}
+	return kept
+}
@@ -0,0 +1,54 @@
+package claude
+
+import (
+	"reflect"
+	"testing"
+
+	"ccw/types"
+)
+
+func TestPermissionArgs(t *testing.T) {
+	worktree := &types.WorktreeConfig{WorktreePath: "/work/issue-1"}
+
+	tests := []struct {
+		name        string
+		ci          ClaudeIntegration
+		projectPath string
+		expected    []string
+	}{
+		{"nothing configured", ClaudeIntegration{}, "/work/issue-1", nil},
+		{
+			"allowed and disallowed tools",
+			ClaudeIntegration{AllowedTools: []string{"Edit", " Bash(swift build:*) ", "", "Bash(git log --format=%h,%s:*)"}, DisallowedTools: []string{"WebFetch"}},
+			"/work/issue-1",
+			[]string{"--allowedTools", "Edit", "Bash(swift build:*)", "Bash(git log --format=%h,%s:*)", "--disallowedTools", "WebFetch"},
+		},
+		{"only blank rules", ClaudeIntegration{AllowedTools: []string{" "}}, "/work/issue-1", nil},
+		{"subdir grants the worktree root", ClaudeIntegration{}, "/work/issue-1/packages/parser", []string{"--add-dir", "/work/issue-1"}},
+		{"working dir only keeps Claude in the subdir", ClaudeIntegration{WorkingDirOnly: true}, "/work/issue-1/packages/parser", nil},
+		{
+			"tools with subdir",
+			ClaudeIntegration{DisallowedTools: []string{"Bash(git push:*)"}},
+			"/work/issue-1/packages/parser",
+			[]string{"--disallowedTools", "Bash(git push:*)", "--add-dir", "/work/issue-1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := &types.ClaudeContext{ProjectPath: tt.projectPath, WorktreeConfig: worktree}
+			if got := tt.ci.permissionArgs(ctx); !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("Expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+
+	// Print-mode calls get only the tool flags
+	ci := &ClaudeIntegration{AllowedTools: []string{"Read"}}
+	if got := ci.toolArgs(); !reflect.DeepEqual(got, []string{"--allowedTools", "Read"}) {
+		t.Errorf("Expected allowed tools flag, got %q", got)
+	}
+	if got := ci.permissionArgs(nil); !reflect.DeepEqual(got, []string{"--allowedTools", "Read"}) {
+		t.Errorf("Expected only tool flags without a context, got %q", got)
+	}
+}
@@ -0,0 +1,344 @@
+package claude
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"ccw/git"
+	"ccw/types"
+)
+
+// Carrying a condensed prior Claude Code session into the next run (claude.resume_transcript)
+
+// TranscriptDir and TranscriptFile locate the saved session transcript inside a worktree
+const (
+	TranscriptDir  = ".ccw"
+	TranscriptFile = "transcript.jsonl"
+)
+
+// defaultTranscriptBudget caps the condensed transcript when
+// claude.max_context_chars is unset
+const defaultTranscriptBudget = 8000
+
+// maxTurnChars caps each condensed turn so one long reply cannot crowd out the rest
+const maxTurnChars = 600
+
+// claudeProjectsDir is where Claude Code keeps its session transcripts; tests replace it
+var claudeProjectsDir = func() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".claude", "projects")
+}
+
+// projectDirPattern matches the characters Claude Code replaces when naming a
+// project's transcript directory after its path
+var projectDirPattern = regexp.MustCompile(`[^A-Za-z0-9]`)
+
+// TranscriptTurn is one condensed message of a session
+type TranscriptTurn struct {
+	Role  string   // "user" or "assistant"
+	Text  string   // Text content, without tool output
+	Tools []string // Tools the assistant used, e.g. "Edit Sources/Lexer.swift"
+}
+
+// transcriptLine is one line of a Claude Code JSONL transcript. Lines that only
+// carry role and content are accepted too.
+type transcriptLine struct {
+	Type    string          `json:"type"`
+	Role    string          `json:"role"`
+	Content json.RawMessage `json:"content"`
+	Message *struct {
+		Role    string          `json:"role"`
+		Content json.RawMessage `json:"content"`
+	} `json:"message"`
+}
+
+// contentBlock is one block of a message's content array
+type contentBlock struct {
+	Type  string          `json:"type"`
+	Text  string          `json:"text"`
+	Name  string          `json:"name"`
+	Input json.RawMessage `json:"input"`
+}
+
+// TranscriptPath returns where the transcript of the last session in a worktree is saved
+func TranscriptPath(worktreePath string) string {
+	return filepath.Join(worktreePath, TranscriptDir, TranscriptFile)
+}
+
+// ParseTranscript reads the user and assistant turns of a JSONL transcript.
+// Tool results, summaries and lines that do not parse are skipped.
+func ParseTranscript(r io.Reader) ([]TranscriptTurn, error) {
+	var turns []TranscriptTurn
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		var line transcriptLine
+		if err := json.Unmarshal(scanner.Bytes(), &line); err != nil {
+			continue
+		}
+
+		role, content := line.Role, line.Content
+		if line.Message != nil {
+			role, content = line.Message.Role, line.Message.Content
+		}
+		if role == "" {
+			role = line.Type
+		}
+		if role != "user" && role != "assistant" {
+			continue
+		}
+
+		turn := parseTranscriptContent(role, content)
+		if turn.Text != "" || len(turn.Tools) > 0 {
+			turns = append(turns, turn)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read transcript: %w", err)
+	}
+	return turns, nil
+}
+
+// parseTranscriptContent condenses message content, a string or a list of blocks
+func parseTranscriptContent(role string, content json.RawMessage) TranscriptTurn {
+	turn := TranscriptTurn{Role: role}
+
+	var text string
+	if json.Unmarshal(content, &text) == nil {
+		turn.Text = strings.TrimSpace(text)
+		return turn
+	}
+
+	var blocks []contentBlock
+	if json.Unmarshal(content, &blocks) != nil {
+		return turn
+	}
+	var texts []string
+	for _, block := range blocks {
+		switch block.Type {
+		case "text":
+			if text := strings.TrimSpace(block.Text); text != "" {
+				texts = append(texts, text)
+			}
+		case "tool_use":
+			turn.Tools = append(turn.Tools, describeToolUse(block))
+		}
+	}
+	turn.Text = strings.Join(texts, "\n\n")
+	return turn
+}
+
+// describeToolUse names a tool call with the file or command it acted on
+func describeToolUse(block contentBlock) string {
+	var input struct {
+		FilePath string `json:"file_path"`
+		Command  string `json:"command"`
+		Pattern  string `json:"pattern"`
+	}
+	_ = json.Unmarshal(block.Input, &input)
+
+	target := input.FilePath
+	if target == "" {
+		target = input.Command
+	}
+	if target == "" {
+		target = input.Pattern
+	}
+	target = strings.Join(strings.Fields(target), " ")
+	if utf8.RuneCountInString(target) > 80 {
+		target = string([]rune(target)[:77]) + "..."
+	}
+	if target == "" {
+		return block.Name
+	}
+	return block.Name + " " + target
+}
+
+// CondenseTranscript renders turns as a short conversation of at most budget
+// characters. Each turn is cut to a few hundred characters, and when they
+// still do not fit the most recent turns are kept with a note about the
+// earlier ones. The first user turn, ccw's own prompt, is left out.
+func CondenseTranscript(turns []TranscriptTurn, budget int) string {
+	if len(turns) > 0 && turns[0].Role == "user" {
+		turns = turns[1:]
+	}
+	if len(turns) == 0 || budget <= 0 {
+		return ""
+	}
+
+	rendered := make([]string, len(turns))
+	for i, turn := range turns {
+		rendered[i] = renderTranscriptTurn(turn)
+	}
+
+	// Keep the latest turns that fit, leaving room for the omission note
+	note := func(omitted int) string {
+		return fmt.Sprintf("_(%d earlier turn(s) omitted to fit the context limit.)_", omitted)
+	}
+	used := 0
+	first := len(rendered)
+	for first > 0 {
+		size := utf8.RuneCountInString(rendered[first-1]) + 2
+		reserve := 0
+		if first-1 > 0 {
+			reserve = utf8.RuneCountInString(note(first-1)) + 2
+		}
+		if used+size+reserve > budget {
+			break
+		}
+		used += size
+		first--
+	}
+
+	kept := rendered[first:]
+	if first > 0 {
+		kept = append([]string{note(first)}, kept...)
+	}
+	if first == len(rendered) {
+		// Not even the last turn fits; cut it down to the budget
+		return strings.TrimRight(cutAtBoundary(rendered[len(rendered)-1], budget), " \n")
+	}
+	return strings.Join(kept, "\n\n")
+}
+
+// renderTranscriptTurn formats one turn as a markdown paragraph
+func renderTranscriptTurn(turn TranscriptTurn) string {
+	speaker := "**User:**"
+	if turn.Role == "assistant" {
+		speaker = "**Claude:**"
+	}
+
+	text := strings.Join(strings.Fields(turn.Text), " ")
+	if utf8.RuneCountInString(text) > maxTurnChars {
+		text = strings.TrimRight(cutAtBoundary(text, maxTurnChars), " ") + " …"
+	}
+
+	parts := []string{speaker}
+	if text != "" {
+		parts = append(parts, text)
+	}
+	if len(turn.Tools) > 0 {
+		parts = append(parts, fmt.Sprintf("_(used %s)_", strings.Join(uniqueStrings(turn.Tools), "; ")))
+	}
+	return strings.Join(parts, " ")
+}
+
+// uniqueStrings returns values without repeats, in first-seen order
+func uniqueStrings(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	var unique []string
+	for _, value := range values {
+		if !seen[value] {
+			seen[value] = true
+			unique = append(unique, value)
+		}
+	}
+	return unique
+}
+
+// transcriptBudget is the characters the condensed transcript may use: a
+// quarter of claude.max_context_chars, at most what is left of it after used
+// characters, or a fixed amount when that is unset
+func (ci *ClaudeIntegration) transcriptBudget(used int) int {
+	if ci.MaxContextChars > 0 {
+		return min(ci.MaxContextChars/4, ci.MaxContextChars-used)
+	}
+	return defaultTranscriptBudget
+}
+
+// priorTranscript returns the condensed transcript saved in the worktree by
+// the previous run, or "" when resuming is off or there is none. It shares
+// claude.max_context_chars with the used characters before it.
+func (ci *ClaudeIntegration) priorTranscript(ctx *types.ClaudeContext, used int) string {
+	if !ci.ResumeTranscript || ctx == nil || ctx.WorktreeConfig == nil || ctx.WorktreeConfig.WorktreePath == "" {
+		return ""
+	}
+	file, err := os.Open(TranscriptPath(ctx.WorktreeConfig.WorktreePath))
+	if err != nil {
+		return ""
+	}
+	defer file.Close()
+
+	turns, err := ParseTranscript(file)
+	if err != nil {
+		return ""
+	}
+	return CondenseTranscript(turns, ci.transcriptBudget(used))
+}
+
+// withTranscript appends the condensed prior session to a Claude prompt
+func withTranscript(input, transcript string) string {
+	if transcript == "" {
+		return input
+	}
+	return input + "\n\nSummary of the previous Claude Code session on this issue:\n\n" + transcript + "\n"
+}
+
+// latestSessionTranscript returns the newest Claude Code transcript for a
+// session run in projectPath that was written after since
+func latestSessionTranscript(projectPath string, since time.Time) (string, error) {
+	base := claudeProjectsDir()
+	if base == "" {
+		return "", errors.New("home directory unknown")
+	}
+	absPath, err := filepath.Abs(projectPath)
+	if err != nil {
+		return "", err
+	}
+	dir := filepath.Join(base, projectDirPattern.ReplaceAllString(absPath, "-"))
+
+	matches, err := filepath.Glob(filepath.Join(dir, "*.jsonl"))
+	if err != nil {
+		return "", err
+	}
+	type candidate struct {
+		path    string
+		modTime time.Time
+	}
+	var candidates []candidate
+	for _, match := range matches {
+		if info, err := os.Stat(match); err == nil && !info.ModTime().Before(since) {
+			candidates = append(candidates, candidate{match, info.ModTime()})
+		}
+	}
+	if len(candidates) == 0 {
+		return "", fmt.Errorf("no Claude Code transcript in %s since %s", dir, since.Format(time.RFC3339))
+	}
+	sort.Slice(candidates, func(i, j int) bool { return candidates[i].modTime.After(candidates[j].modTime) })
+	return candidates[0].path, nil
+}
+
+// saveSessionTranscript copies the transcript of the session that just ran
+// into the worktree, where the next run picks it up
+func (ci *ClaudeIntegration) saveSessionTranscript(ctx *types.ClaudeContext, since time.Time) error {
+	if !ci.ResumeTranscript || ctx.WorktreeConfig == nil || ctx.WorktreeConfig.WorktreePath == "" {
+		return nil
+	}
+	source, err := latestSessionTranscript(ctx.ProjectPath, since)
+	if err != nil {
+		return err
+	}
+	data, err := os.ReadFile(source)
+	if err != nil {
+		return fmt.Errorf("failed to read Claude Code transcript: %w", err)
+	}
+
+	target := TranscriptPath(ctx.WorktreeConfig.WorktreePath)
+	if err := git.EnsureUncommittedDir(filepath.Dir(target), "session transcripts"); err != nil {
+		return err
+	}
+	return os.WriteFile(target, data, 0644)
+}
@@ -0,0 +1,158 @@
+package claude
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"ccw/types"
+)
+
+const sampleTranscript = `{"type":"summary","summary":"Fix lexer"}
+{"type":"user","message":{"role":"user","content":"Implement issue #42"}}
+{"type":"assistant","message":{"role":"assistant","content":[{"type":"text","text":"Reading the lexer first."},{"type":"tool_use","name":"Read","input":{"file_path":"Sources/Lexer.swift"}}]}}
+{"type":"user","message":{"role":"user","content":[{"type":"tool_result","content":"file contents"}]}}
+not json
+{"role":"assistant","content":[{"type":"tool_use","name":"Bash","input":{"command":"swift   test"}},{"type":"text","text":"Tests pass now."}]}
+`
+
+func TestParseTranscript(t *testing.T) {
+	turns, err := ParseTranscript(strings.NewReader(sampleTranscript))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := []TranscriptTurn{
+		{Role: "user", Text: "Implement issue #42"},
+		{Role: "assistant", Text: "Reading the lexer first.", Tools: []string{"Read Sources/Lexer.swift"}},
+		{Role: "assistant", Text: "Tests pass now.", Tools: []string{"Bash swift test"}},
+	}
+	if len(turns) != len(expected) {
+		t.Fatalf("Expected %d turns, got %d: %+v", len(expected), len(turns), turns)
+	}
+	for i, turn := range turns {
+		if turn.Role != expected[i].Role || turn.Text != expected[i].Text || strings.Join(turn.Tools, "|") != strings.Join(expected[i].Tools, "|") {
+			t.Errorf("Turn %d: expected %+v, got %+v", i, expected[i], turn)
+		}
+	}
+}
+
+func TestCondenseTranscript(t *testing.T) {
+	prompt := TranscriptTurn{Role: "user", Text: "ccw prompt"}
+
+	t.Run("within budget", func(t *testing.T) {
+		turns := []TranscriptTurn{
+			prompt,
+			{Role: "assistant", Text: "Reading the lexer first.", Tools: []string{"Read Lexer.swift", "Read Lexer.swift"}},
+			{Role: "user", Text: "Keep the API unchanged"},
+		}
+		result := CondenseTranscript(turns, 1000)
+		expected := "**Claude:** Reading the lexer first. _(used Read Lexer.swift)_\n\n**User:** Keep the API unchanged"
+		if result != expected {
+			t.Errorf("Expected:\n%s\ngot:\n%s", expected, result)
+		}
+	})
+
+	t.Run("keeps the latest turns", func(t *testing.T) {
+		turns := []TranscriptTurn{prompt}
+		for i := 1; i <= 50; i++ {
+			turns = append(turns, TranscriptTurn{Role: "assistant", Text: fmt.Sprintf("Step %d done.", i)})
+		}
+		budget := 200
+		result := CondenseTranscript(turns, budget)
+		if utf8.RuneCountInString(result) > budget {
+			t.Errorf("Expected at most %d characters, got %d:\n%s", budget, utf8.RuneCountInString(result), result)
+		}
+		if !strings.HasPrefix(result, "_(") || !strings.Contains(result, "earlier turn(s) omitted") {
+			t.Errorf("Expected an omission note, got:\n%s", result)
+		}
+		if !strings.HasSuffix(result, "Step 50 done.") || strings.Contains(result, "Step 1 done.") {
+			t.Errorf("Expected only the latest turns, got:\n%s", result)
+		}
+	})
+
+	t.Run("caps long turns", func(t *testing.T) {
+		turns := []TranscriptTurn{prompt, {Role: "assistant", Text: strings.Repeat("word ", 1000)}}
+		result := CondenseTranscript(turns, 5000)
+		if size := utf8.RuneCountInString(result); size > maxTurnChars+len("**Claude:**  …") {
+			t.Errorf("Expected the turn to be capped near %d characters, got %d", maxTurnChars, size)
+		}
+	})
+
+	t.Run("single turn over budget", func(t *testing.T) {
+		turns := []TranscriptTurn{prompt, {Role: "assistant", Text: strings.Repeat("word ", 100)}}
+		if result := CondenseTranscript(turns, 50); utf8.RuneCountInString(result) > 50 {
+			t.Errorf("Expected at most 50 characters, got %q", result)
+		}
+	})
+
+	t.Run("prompt only", func(t *testing.T) {
+		if result := CondenseTranscript([]TranscriptTurn{prompt}, 1000); result != "" {
+			t.Errorf("Expected empty transcript, got %q", result)
+		}
+	})
+}
+
+func TestPriorTranscript(t *testing.T) {
+	worktree := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(worktree, TranscriptDir), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(TranscriptPath(worktree), []byte(sampleTranscript), 0644); err != nil {
+		t.Fatal(err)
+	}
+	ctx := &types.ClaudeContext{WorktreeConfig: &types.WorktreeConfig{WorktreePath: worktree}}
+
+	ci := &ClaudeIntegration{}
+	if result := ci.priorTranscript(ctx, 0); result != "" {
+		t.Errorf("Expected no transcript when resuming is off, got %q", result)
+	}
+
+	ci.ResumeTranscript = true
+	result := ci.priorTranscript(ctx, 0)
+	if !strings.Contains(result, "Tests pass now.") || strings.Contains(result, "Implement issue #42") {
+		t.Errorf("Unexpected transcript:\n%s", result)
+	}
+	if prompt := withTranscript("Fix it", result); !strings.HasPrefix(prompt, "Fix it\n\nSummary of the previous Claude Code session") {
+		t.Errorf("Unexpected prompt:\n%s", prompt)
+	}
+}
+
+func TestSaveSessionTranscript(t *testing.T) {
+	projects := t.TempDir()
+	original := claudeProjectsDir
+	claudeProjectsDir = func() string { return projects }
+	defer func() { claudeProjectsDir = original }()
+
+	worktree := t.TempDir()
+	sessionDir := filepath.Join(projects, projectDirPattern.ReplaceAllString(worktree, "-"))
+	if err := os.MkdirAll(sessionDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	startedAt := time.Now().Add(-time.Second)
+	if err := os.WriteFile(filepath.Join(sessionDir, "session.jsonl"), []byte(sampleTranscript), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	ci := &ClaudeIntegration{ResumeTranscript: true}
+	ctx := &types.ClaudeContext{ProjectPath: worktree, WorktreeConfig: &types.WorktreeConfig{WorktreePath: worktree}}
+	if err := ci.saveSessionTranscript(ctx, startedAt); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	saved, err := os.ReadFile(TranscriptPath(worktree))
+	if err != nil || string(saved) != sampleTranscript {
+		t.Errorf("Expected the session to be saved, got %q (%v)", saved, err)
+	}
+	if _, err := os.Stat(filepath.Join(worktree, TranscriptDir, ".gitignore")); err != nil {
+		t.Errorf("Expected a .gitignore next to the transcript: %v", err)
+	}
+
+	if err := ci.saveSessionTranscript(ctx, time.Now().Add(time.Hour)); err == nil {
+		t.Error("Expected an error when no session ran since the start time")
+	}
+}
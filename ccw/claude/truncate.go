@@ -0,0 +1,224 @@
+package claude
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"ccw/types"
+)
+
+// Context-aware truncation of issue bodies passed to Claude
+
+// truncationMarker replaces content removed from the issue body
+const truncationMarker = "\n[…]\n"
+
+// bodySegment is a run of prose or a fenced code block within an issue body
+type bodySegment struct {
+	text string
+	code bool
+}
+
+// TruncateIssueBody shortens body to at most maxChars characters, keeping the
+// beginning of the prose and as many fenced code blocks as fit. Removed content
+// is replaced with an ellipsis marker and a note is appended. A maxChars of 0
+// or less disables truncation.
+func TruncateIssueBody(body string, maxChars int) string {
+	total := utf8.RuneCountInString(body)
+	if maxChars <= 0 || total <= maxChars {
+		return body
+	}
+
+	note := fmt.Sprintf("\n\n_(Issue body truncated from %d characters to fit the context limit.)_", total)
+	markerLen := utf8.RuneCountInString(truncationMarker)
+	budget := maxChars - utf8.RuneCountInString(note)
+	if budget <= markerLen {
+		return string([]rune(body)[:maxChars])
+	}
+
+	segments := splitFencedSegments(body)
+
+	// Reserve room for code blocks and their surrounding markers, but always keep
+	// at least a quarter for the head
+	codeLen := 2 * markerLen
+	for _, segment := range segments {
+		if segment.code {
+			codeLen += utf8.RuneCountInString(segment.text)
+		}
+	}
+	proseBudget := budget - codeLen
+	if proseBudget < budget/4 {
+		proseBudget = budget / 4
+	}
+
+	var out strings.Builder
+	used := 0
+	lastWasMarker := false
+	addMarker := func() {
+		if !lastWasMarker && used+markerLen <= budget {
+			out.WriteString(truncationMarker)
+			used += markerLen
+			lastWasMarker = true
+		}
+	}
+
+	for _, segment := range segments {
+		length := utf8.RuneCountInString(segment.text)
+
+		if segment.code {
+			if used+length+markerLen <= budget {
+				out.WriteString(segment.text)
+				used += length
+				lastWasMarker = false
+			} else {
+				addMarker()
+			}
+			continue
+		}
+
+		available := proseBudget
+		if budget-used-markerLen < available {
+			available = budget - used - markerLen
+		}
+		if length <= available {
+			out.WriteString(segment.text)
+			used += length
+			proseBudget -= length
+			lastWasMarker = false
+			continue
+		}
+
+		if available > 0 {
+			head := cutAtBoundary(segment.text, available)
+			out.WriteString(head)
+			used += utf8.RuneCountInString(head)
+			lastWasMarker = false
+		}
+		proseBudget = 0
+		addMarker()
+	}
+
+	return strings.TrimRight(out.String(), "\n") + note
+}
+
+// splitFencedSegments splits body into prose and fenced (``` or ~~~) code blocks.
+// An unterminated fence runs to the end of the body.
+func splitFencedSegments(body string) []bodySegment {
+	var segments []bodySegment
+	var current strings.Builder
+	inFence := false
+	fence := ""
+
+	flush := func(code bool) {
+		if current.Len() > 0 {
+			segments = append(segments, bodySegment{text: current.String(), code: code})
+			current.Reset()
+		}
+	}
+
+	lines := strings.SplitAfter(body, "\n")
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case !inFence && (strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~")):
+			flush(false)
+			inFence = true
+			fence = trimmed[:3]
+			current.WriteString(line)
+		case inFence && strings.HasPrefix(trimmed, fence):
+			current.WriteString(line)
+			flush(true)
+			inFence = false
+		default:
+			current.WriteString(line)
+		}
+	}
+	flush(inFence)
+
+	return segments
+}
+
+// cutAtBoundary returns at most maxChars characters of text, preferring to end
+// at a line break or space
+func cutAtBoundary(text string, maxChars int) string {
+	runes := []rune(text)
+	if len(runes) <= maxChars {
+		return text
+	}
+
+	head := string(runes[:maxChars])
+	if idx := strings.LastIndex(head, "\n"); idx > len(head)/2 {
+		return head[:idx+1]
+	}
+	if idx := strings.LastIndex(head, " "); idx > len(head)/2 {
+		return head[:idx]
+	}
+	return head
+}
+
+// truncateComments renders comments (oldest first) in at most maxChars
+// characters. Once the budget runs out the newest comments are kept, the
+// oldest one that still fits is cut short and the rest are replaced with a note.
+func truncateComments(comments []types.IssueComment, maxChars int) string {
+	rendered := make([]string, len(comments))
+	total := 0
+	for i, comment := range comments {
+		rendered[i] = fmt.Sprintf("**@%s** (%s):\n\n%s\n\n", comment.Author.Login, comment.CreatedAt.Format("2006-01-02"), strings.TrimSpace(comment.Body))
+		total += utf8.RuneCountInString(rendered[i])
+	}
+	if total <= maxChars {
+		return strings.Join(rendered, "")
+	}
+
+	omittedNote := func(count int) string {
+		return fmt.Sprintf("_(%d earlier comment(s) omitted to fit the context limit.)_\n\n", count)
+	}
+	budget := maxChars - utf8.RuneCountInString(omittedNote(len(comments)))
+	var kept []string
+	first := len(rendered)
+	for first > 0 {
+		length := utf8.RuneCountInString(rendered[first-1])
+		if length > budget {
+			break
+		}
+		kept = append([]string{rendered[first-1]}, kept...)
+		budget -= length
+		first--
+	}
+
+	// Cut the next older comment to whatever room is left, keeping its header
+	if first > 0 {
+		const cutNote = "\n_(Comment truncated to fit the context limit.)_\n\n"
+		comment := comments[first-1]
+		header := fmt.Sprintf("**@%s** (%s):\n\n", comment.Author.Login, comment.CreatedAt.Format("2006-01-02"))
+		room := budget - utf8.RuneCountInString(header) - utf8.RuneCountInString(cutNote)
+		if room >= 80 {
+			body := strings.TrimRight(cutAtBoundary(strings.TrimSpace(comment.Body), room), " \n")
+			kept = append([]string{header + body + "\n" + cutNote}, kept...)
+			first--
+		}
+	}
+
+	if first == 0 {
+		return strings.Join(kept, "")
+	}
+	return omittedNote(first) + strings.Join(kept, "")
+}
+
+// withTruncatedIssueBody returns a copy of ctx whose issue body fits MaxContextChars
+func (ci *ClaudeIntegration) withTruncatedIssueBody(ctx *types.ClaudeContext) *types.ClaudeContext {
+	if ci.MaxContextChars <= 0 || ctx.IssueData == nil {
+		return ctx
+	}
+
+	truncated := TruncateIssueBody(ctx.IssueData.Body, ci.MaxContextChars)
+	if truncated == ctx.IssueData.Body {
+		return ctx
+	}
+
+	issueCopy := *ctx.IssueData
+	issueCopy.Body = truncated
+	ctxCopy := *ctx
+	ctxCopy.IssueData = &issueCopy
+	return &ctxCopy
+}
@@ -0,0 +1,216 @@
+package claude
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"ccw/types"
+)
+
+func TestTruncateIssueBody(t *testing.T) {
+	code := "```swift\nlet parser = Parser()\nparser.parse(\"x\")\n```\n"
+	longProse := strings.Repeat("This sentence describes the problem in detail. ", 60)
+
+	tests := []struct {
+		name         string
+		body         string
+		maxChars     int
+		expectSame   bool
+		wantContains []string
+		wantMissing  []string
+	}{
+		{
+			name:       "short body unchanged",
+			body:       "Fix the parser.\n" + code,
+			maxChars:   1000,
+			expectSame: true,
+		},
+		{
+			name:       "limit disabled",
+			body:       longProse,
+			maxChars:   0,
+			expectSame: true,
+		},
+		{
+			name:         "long prose gets head and marker",
+			body:         "Summary line.\n\n" + longProse,
+			maxChars:     400,
+			wantContains: []string{"Summary line.", "[…]", "truncated from"},
+		},
+		{
+			name:         "code fence after long prose is preserved",
+			body:         "Summary line.\n\n" + longProse + "\n\n" + code + "\nTrailing notes.\n",
+			maxChars:     600,
+			wantContains: []string{"Summary line.", code, "[…]"},
+		},
+		{
+			name:         "code fence before prose is preserved",
+			body:         code + "\n" + longProse,
+			maxChars:     500,
+			wantContains: []string{code, "[…]"},
+		},
+		{
+			name:         "oversized code block is dropped with marker",
+			body:         "Summary line.\n\n```\n" + strings.Repeat("x := 1\n", 200) + "```\n",
+			maxChars:     300,
+			wantContains: []string{"Summary line.", "[…]"},
+			wantMissing:  []string{"```"},
+		},
+		{
+			name:         "unterminated fence treated as code",
+			body:         "Intro\n```go\n" + strings.Repeat("fmt.Println(1)\n", 100),
+			maxChars:     300,
+			wantContains: []string{"Intro", "[…]"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := TruncateIssueBody(tt.body, tt.maxChars)
+
+			if tt.expectSame {
+				if result != tt.body {
+					t.Errorf("Expected body to be unchanged, got:\n%s", result)
+				}
+				return
+			}
+
+			if utf8.RuneCountInString(result) > tt.maxChars {
+				t.Errorf("Expected at most %d characters, got %d", tt.maxChars, utf8.RuneCountInString(result))
+			}
+			for _, want := range tt.wantContains {
+				if !strings.Contains(result, want) {
+					t.Errorf("Expected result to contain %q, got:\n%s", want, result)
+				}
+			}
+			for _, missing := range tt.wantMissing {
+				if strings.Contains(result, missing) {
+					t.Errorf("Expected result not to contain %q, got:\n%s", missing, result)
+				}
+			}
+		})
+	}
+}
+
+func TestTruncateIssueBodyMultibyte(t *testing.T) {
+	body := strings.Repeat("日本語の説明文です。", 100)
+	result := TruncateIssueBody(body, 200)
+
+	if !utf8.ValidString(result) {
+		t.Error("Expected valid UTF-8 after truncation")
+	}
+	if utf8.RuneCountInString(result) > 200 {
+		t.Errorf("Expected at most 200 characters, got %d", utf8.RuneCountInString(result))
+	}
+}
+
+func TestSplitFencedSegments(t *testing.T) {
+	body := "intro\n```\ncode\n```\nmiddle\n~~~\nmore code\n~~~\nend"
+	segments := splitFencedSegments(body)
+
+	expected := []bodySegment{
+		{text: "intro\n", code: false},
+		{text: "```\ncode\n```\n", code: true},
+		{text: "middle\n", code: false},
+		{text: "~~~\nmore code\n~~~\n", code: true},
+		{text: "end", code: false},
+	}
+
+	if len(segments) != len(expected) {
+		t.Fatalf("Expected %d segments, got %d: %+v", len(expected), len(segments), segments)
+	}
+	for i := range expected {
+		if segments[i] != expected[i] {
+			t.Errorf("Segment %d: expected %+v, got %+v", i, expected[i], segments[i])
+		}
+	}
+}
+
+func TestWithTruncatedIssueBodyDoesNotMutateIssue(t *testing.T) {
+	issue := &types.Issue{Number: 1, Body: strings.Repeat("word ", 500)}
+	ctx := &types.ClaudeContext{IssueData: issue}
+	ci := &ClaudeIntegration{MaxContextChars: 300}
+
+	truncated := ci.withTruncatedIssueBody(ctx)
+
+	if truncated.IssueData.Body == issue.Body {
+		t.Error("Expected truncated context body")
+	}
+	if utf8.RuneCountInString(issue.Body) != 2500 {
+		t.Error("Original issue body must not be modified")
+	}
+}
+
+func TestTruncateComments(t *testing.T) {
+	comments := []types.IssueComment{
+		{Author: types.User{Login: "alice"}, Body: strings.Repeat("old ", 100)},
+		{Author: types.User{Login: "bob"}, Body: strings.Repeat("middle ", 50)},
+		{Author: types.User{Login: "carol"}, Body: "newest"},
+	}
+
+	all := truncateComments(comments, math.MaxInt)
+	for _, login := range []string{"@alice", "@bob", "@carol"} {
+		if !strings.Contains(all, login) {
+			t.Errorf("Expected %s without a limit, got:\n%s", login, all)
+		}
+	}
+
+	tests := []struct {
+		name     string
+		maxChars int
+		kept     []string
+		dropped  []string
+		note     string
+	}{
+		{"oldest cut short", 700, []string{"@alice", "@bob", "@carol"}, nil, "Comment truncated"},
+		{"oldest omitted", 600, []string{"@bob", "@carol"}, []string{"@alice"}, "1 earlier comment(s) omitted"},
+		{"no room", 0, nil, []string{"@alice", "@bob", "@carol"}, "3 earlier comment(s) omitted"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := truncateComments(comments, tt.maxChars)
+			if tt.maxChars > 0 && utf8.RuneCountInString(result) > tt.maxChars {
+				t.Errorf("Expected at most %d characters, got %d", tt.maxChars, utf8.RuneCountInString(result))
+			}
+			for _, login := range tt.kept {
+				if !strings.Contains(result, login) {
+					t.Errorf("Expected %s to be kept, got:\n%s", login, result)
+				}
+			}
+			for _, login := range tt.dropped {
+				if strings.Contains(result, login) {
+					t.Errorf("Expected %s to be dropped, got:\n%s", login, result)
+				}
+			}
+			if !strings.Contains(result, tt.note) {
+				t.Errorf("Expected '%s', got:\n%s", tt.note, result)
+			}
+		})
+	}
+}
+
+func TestMarkdownContextCommentsShareBudget(t *testing.T) {
+	issue := &types.Issue{
+		Number: 1,
+		Body:   strings.Repeat("body ", 100),
+		Comments: []types.IssueComment{
+			{Author: types.User{Login: "alice"}, Body: strings.Repeat("comment ", 500)},
+		},
+	}
+	ci := &ClaudeIntegration{MaxContextChars: 1000}
+
+	ctx := &types.ClaudeContext{IssueData: issue, WorktreeConfig: &types.WorktreeConfig{BranchName: "issue-1"}}
+	content, err := ci.MarkdownContext(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Count(content, "comment ") > 100 {
+		t.Errorf("Expected the comment to fit what is left of the budget, got %d words", strings.Count(content, "comment "))
+	}
+	if !strings.Contains(content, "Comment truncated to fit the context limit") {
+		t.Errorf("Expected a truncation note, got:\n%s", content)
+	}
+}
@@ -0,0 +1,82 @@
+package commit
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Rendering commit.body_template for commit messages ccw writes itself
+
+// DefaultBodyLines is how many lines of the issue body {issue_body} keeps when
+// commit.body_lines is unset
+const DefaultBodyLines = 10
+
+// maxBodyChars caps the {issue_body} excerpt so one long line cannot bloat the commit
+const maxBodyChars = 2000
+
+// RenderCommitBody replaces the {token} placeholders in template with details
+// of issue: {issue_number}, {issue_title}, {issue_url}, {issue_labels} (comma
+// separated) and {issue_body}, the first maxLines lines of the issue body.
+// Unknown tokens are left as written, and runs of blank lines left by missing
+// details are collapsed.
+func RenderCommitBody(template string, issue *Issue, maxLines int) string {
+	if issue == nil {
+		issue = &Issue{}
+	}
+	if maxLines <= 0 {
+		maxLines = DefaultBodyLines
+	}
+
+	number := ""
+	if issue.Number > 0 {
+		number = fmt.Sprintf("%d", issue.Number)
+	}
+
+	rendered := strings.NewReplacer(
+		"{issue_number}", number,
+		"{issue_title}", issue.Title,
+		"{issue_url}", issue.URL,
+		"{issue_labels}", strings.Join(issue.Labels, ", "),
+		"{issue_body}", TruncateIssueBody(issue.Body, maxLines),
+	).Replace(template)
+	return collapseBlankLines(rendered)
+}
+
+// TruncateIssueBody returns the first maxLines lines of body, at most
+// maxBodyChars long, marking a cut with "…"
+func TruncateIssueBody(body string, maxLines int) string {
+	lines := strings.Split(strings.TrimSpace(strings.ReplaceAll(body, "\r\n", "\n")), "\n")
+	truncated := false
+	if len(lines) > maxLines {
+		lines = lines[:maxLines]
+		truncated = true
+	}
+
+	excerpt := strings.TrimRight(strings.Join(lines, "\n"), " \t\n")
+	if runes := []rune(excerpt); len(runes) > maxBodyChars {
+		excerpt = strings.TrimRight(string(runes[:maxBodyChars]), " \t\n")
+		truncated = true
+	}
+	if truncated && excerpt != "" {
+		excerpt += "\n…"
+	}
+	return excerpt
+}
+
+// collapseBlankLines trims message and squeezes runs of blank lines into one
+func collapseBlankLines(message string) string {
+	var out []string
+	blank := false
+	for _, line := range strings.Split(strings.TrimSpace(message), "\n") {
+		if strings.TrimSpace(line) == "" {
+			if blank {
+				continue
+			}
+			blank = true
+		} else {
+			blank = false
+		}
+		out = append(out, strings.TrimRight(line, " \t"))
+	}
+	return strings.Join(out, "\n")
+}
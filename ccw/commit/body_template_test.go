@@ -0,0 +1,79 @@
+package commit
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestRenderCommitBody(t *testing.T) {
+	issue := &Issue{
+		Number: 42,
+		Title:  "Support Unicode identifiers",
+		Body:   "The lexer rejects é.\r\n\r\nSteps:\r\n1. Parse `é := 1`",
+		URL:    "https://github.com/o/r/issues/42",
+		Labels: []string{"bug", "lexer"},
+	}
+
+	tests := []struct {
+		name     string
+		template string
+		issue    *Issue
+		expected string
+	}{
+		{"all tokens", "#{issue_number} {issue_title}\n{issue_url}\nLabels: {issue_labels}\n\n{issue_body}", issue,
+			"#42 Support Unicode identifiers\nhttps://github.com/o/r/issues/42\nLabels: bug, lexer\n\nThe lexer rejects é.\n\nSteps:\n1. Parse `é := 1`"},
+		{"unknown tokens kept", "{issue_body} {nope}", &Issue{Body: "x"}, "x {nope}"},
+		{"empty details collapse blank lines", "{issue_body}\n\n\n\nRefs #{issue_number}", &Issue{Number: 7}, "Refs #7"},
+		{"nil issue", "{issue_title}", nil, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := RenderCommitBody(tt.template, tt.issue, 10); got != tt.expected {
+				t.Errorf("Expected '%s', got '%s'", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestTruncateIssueBody(t *testing.T) {
+	var lines []string
+	for i := 1; i <= 20; i++ {
+		lines = append(lines, fmt.Sprintf("line %d", i))
+	}
+	long := strings.Join(lines, "\n")
+
+	tests := []struct {
+		name     string
+		body     string
+		maxLines int
+		expected string
+	}{
+		{"short body unchanged", "line 1\nline 2", 10, "line 1\nline 2"},
+		{"cut to max lines", long, 3, "line 1\nline 2\nline 3\n…"},
+		{"exactly max lines", "a\nb\nc", 3, "a\nb\nc"},
+		{"surrounding blank lines trimmed", "\n\nbody\n\n", 10, "body"},
+		{"trailing blank lines of the excerpt dropped", "a\n\n\nb", 2, "a\n…"},
+		{"empty body", "", 10, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := TruncateIssueBody(tt.body, tt.maxLines); got != tt.expected {
+				t.Errorf("Expected '%s', got '%s'", tt.expected, got)
+			}
+		})
+	}
+
+	// One very long line is cut by length
+	excerpt := TruncateIssueBody(strings.Repeat("é", maxBodyChars+100), 10)
+	if runes := []rune(excerpt); len(runes) != maxBodyChars+2 || !strings.HasSuffix(excerpt, "\n…") {
+		t.Errorf("Expected %d characters and a cut marker, got %d", maxBodyChars, len(runes))
+	}
+
+	// An unset line count keeps the default
+	if got := RenderCommitBody("{issue_body}", &Issue{Body: long}, 0); strings.Count(got, "\n") != DefaultBodyLines {
+		t.Errorf("Expected %d lines and a cut marker, got '%s'", DefaultBodyLines, got)
+	}
+}
@@ -490,9 +490,11 @@ type Config struct{}
 
 // Issue represents a GitHub issue
 type Issue struct {
-	Number int    `json:"number"`
-	Title  string `json:"title"`
-	Body   string `json:"body"`
+	Number int      `json:"number"`
+	Title  string   `json:"title"`
+	Body   string   `json:"body"`
+	URL    string   `json:"url,omitempty"`
+	Labels []string `json:"labels,omitempty"`
 }
 
 // Helper function to create git commands
@@ -0,0 +1,91 @@
+package commit
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strings"
+)
+
+// Commit message lint rules
+
+// LintRules are the built-in commit message checks
+type LintRules struct {
+	MaxSubjectLength int      // 0 disables the subject length check
+	Types            []string // Allowed conventional commit types; empty allows any type
+}
+
+// subjectPattern matches a conventional commit subject: type(scope)!: description
+var subjectPattern = regexp.MustCompile(`^([a-zA-Z]+)(\([^)]*\))?!?: \S`)
+
+// LintMessage checks message against rules and returns one violation per
+// failed rule, or nil when the message passes
+func LintMessage(message string, rules LintRules) []string {
+	lines := strings.Split(strings.TrimRight(message, "\n"), "\n")
+	subject := lines[0]
+
+	var violations []string
+	if strings.TrimSpace(subject) == "" {
+		return []string{"subject is empty"}
+	}
+
+	if rules.MaxSubjectLength > 0 {
+		if length := len([]rune(subject)); length > rules.MaxSubjectLength {
+			violations = append(violations, fmt.Sprintf("subject is %d characters, longer than %d", length, rules.MaxSubjectLength))
+		}
+	}
+
+	if matches := subjectPattern.FindStringSubmatch(subject); matches == nil {
+		violations = append(violations, "subject has no type prefix, e.g. \"feat: ...\"")
+	} else if len(rules.Types) > 0 && !ContainsFold(rules.Types, matches[1]) {
+		violations = append(violations, fmt.Sprintf("type %q is not one of %s", matches[1], strings.Join(rules.Types, ", ")))
+	}
+
+	if len(lines) > 1 && strings.TrimSpace(lines[1]) != "" {
+		violations = append(violations, "body must be separated from the subject by a blank line")
+	}
+
+	return violations
+}
+
+// RunCommitlint checks message with the commitlint CLI in dir, which picks up
+// the repository's commitlint configuration. Violations are commitlint's
+// reported problems; an error means commitlint itself could not run.
+func RunCommitlint(dir, message string) ([]string, error) {
+	cmd := exec.Command("commitlint")
+	cmd.Dir = dir
+	cmd.Stdin = strings.NewReader(message)
+
+	output, err := cmd.CombinedOutput()
+	if err == nil {
+		return nil, nil
+	}
+
+	var exitErr *exec.ExitError
+	if !errors.As(err, &exitErr) {
+		return nil, fmt.Errorf("failed to run commitlint: %w", err)
+	}
+
+	var violations []string
+	for _, line := range strings.Split(string(output), "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "✖") && !strings.Contains(line, "found") {
+			violations = append(violations, strings.TrimSpace(strings.TrimPrefix(line, "✖")))
+		}
+	}
+	if len(violations) == 0 {
+		violations = append(violations, strings.TrimSpace(string(output)))
+	}
+	return violations, nil
+}
+
+// ContainsFold reports whether values contains value, ignoring case
+func ContainsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
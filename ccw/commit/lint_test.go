@@ -0,0 +1,51 @@
+package commit
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLintMessage(t *testing.T) {
+	rules := LintRules{MaxSubjectLength: 50, Types: []string{"feat", "fix", "chore"}}
+
+	tests := []struct {
+		name     string
+		message  string
+		rules    LintRules
+		expected []string
+	}{
+		{"valid subject only", "feat: add parser", rules, nil},
+		{"valid with scope and body", "fix(lexer): handle tabs\n\nTabs were counted as one column.\n", rules, nil},
+		{"breaking change marker", "feat(api)!: drop v1 endpoints", rules, nil},
+		{"empty subject", "\n\nbody", rules, []string{"subject is empty"}},
+		{"subject too long", "feat: " + strings.Repeat("x", 50), rules, []string{"subject is 56 characters, longer than 50"}},
+		{"length counts characters not bytes", "feat: " + strings.Repeat("é", 44), rules, nil},
+		{"no type", "Add parser", rules, []string{`subject has no type prefix, e.g. "feat: ..."`}},
+		{"missing space after colon", "feat:add parser", rules, []string{`subject has no type prefix, e.g. "feat: ..."`}},
+		{"disallowed type", "wip: add parser", rules, []string{`type "wip" is not one of feat, fix, chore`}},
+		{"type match ignores case", "Feat: add parser", rules, nil},
+		{"any type when none configured", "wip: add parser", LintRules{}, nil},
+		{"no length limit when zero", "feat: " + strings.Repeat("x", 200), LintRules{}, nil},
+		{"body without blank line", "feat: add parser\nSupports nested blocks.", rules, []string{"body must be separated from the subject by a blank line"}},
+		{
+			"several violations",
+			"Add a parser that handles nested blocks and comments\nDetails.",
+			rules,
+			[]string{
+				"subject is 52 characters, longer than 50",
+				`subject has no type prefix, e.g. "feat: ..."`,
+				"body must be separated from the subject by a blank line",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			violations := LintMessage(tt.message, tt.rules)
+			if !reflect.DeepEqual(violations, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, violations)
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package commit
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"ccw/types"
+)
+
+// Recovery commit message generation
+
+// GenerateRecoveryCommitMessage creates a commit message for changes made during a
+// validation recovery attempt, listing the error types that the attempt addressed
+func (cmg *CommitMessageGenerator) GenerateRecoveryCommitMessage(issue *Issue, validationResult *types.ValidationResult, attempt int) string {
+	var message strings.Builder
+
+	message.WriteString(fmt.Sprintf("fix: address validation errors (attempt %d)", attempt))
+
+	errorTypes := summarizeErrorTypes(validationResult)
+	if len(errorTypes) > 0 {
+		message.WriteString("\n\nAddressed errors:\n")
+		for _, summary := range errorTypes {
+			message.WriteString(fmt.Sprintf("- %s\n", summary))
+		}
+	} else {
+		message.WriteString("\n")
+	}
+
+	if issue != nil {
+		message.WriteString(fmt.Sprintf("\nRefs #%d", issue.Number))
+	}
+
+	return strings.TrimRight(message.String(), "\n")
+}
+
+// summarizeErrorTypes returns "type (count)" entries sorted by error type
+func summarizeErrorTypes(validationResult *types.ValidationResult) []string {
+	if validationResult == nil {
+		return nil
+	}
+
+	counts := make(map[string]int)
+	for _, validationError := range validationResult.Errors {
+		errorType := validationError.Type
+		if errorType == "" {
+			errorType = "unknown"
+		}
+		counts[errorType]++
+	}
+
+	errorTypes := make([]string, 0, len(counts))
+	for errorType := range counts {
+		errorTypes = append(errorTypes, errorType)
+	}
+	sort.Strings(errorTypes)
+
+	summaries := make([]string, len(errorTypes))
+	for i, errorType := range errorTypes {
+		summaries[i] = fmt.Sprintf("%s (%d)", errorType, counts[errorType])
+	}
+
+	return summaries
+}
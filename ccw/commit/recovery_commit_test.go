@@ -0,0 +1,117 @@
+package commit
+
+import (
+	"strings"
+	"testing"
+
+	"ccw/types"
+)
+
+func TestGenerateRecoveryCommitMessage(t *testing.T) {
+	cmg := &CommitMessageGenerator{}
+	issue := &Issue{Number: 42, Title: "Add parser support"}
+
+	tests := []struct {
+		name             string
+		issue            *Issue
+		validationResult *types.ValidationResult
+		attempt          int
+		wantSubject      string
+		wantContains     []string
+		wantMissing      []string
+	}{
+		{
+			name:  "first attempt with build errors",
+			issue: issue,
+			validationResult: &types.ValidationResult{
+				Errors: []types.ValidationError{
+					{Type: "build", Message: "undefined symbol"},
+					{Type: "build", Message: "missing return"},
+				},
+			},
+			attempt:      1,
+			wantSubject:  "fix: address validation errors (attempt 1)",
+			wantContains: []string{"- build (2)", "Refs #42"},
+			wantMissing:  []string{"- lint", "- test"},
+		},
+		{
+			name:  "later attempt with mixed errors is sorted by type",
+			issue: issue,
+			validationResult: &types.ValidationResult{
+				Errors: []types.ValidationError{
+					{Type: "test", Message: "assertion failed"},
+					{Type: "lint", Message: "line too long"},
+					{Type: "build", Message: "undefined symbol"},
+					{Type: "lint", Message: "trailing whitespace"},
+				},
+			},
+			attempt:      3,
+			wantSubject:  "fix: address validation errors (attempt 3)",
+			wantContains: []string{"- build (1)\n- lint (2)\n- test (1)"},
+		},
+		{
+			name:             "no errors and no issue",
+			issue:            nil,
+			validationResult: &types.ValidationResult{},
+			attempt:          2,
+			wantSubject:      "fix: address validation errors (attempt 2)",
+			wantMissing:      []string{"Addressed errors", "Refs #"},
+		},
+		{
+			name:             "nil validation result",
+			issue:            issue,
+			validationResult: nil,
+			attempt:          1,
+			wantSubject:      "fix: address validation errors (attempt 1)",
+			wantContains:     []string{"Refs #42"},
+			wantMissing:      []string{"Addressed errors"},
+		},
+		{
+			name:  "errors without type are reported as unknown",
+			issue: issue,
+			validationResult: &types.ValidationResult{
+				Errors: []types.ValidationError{{Message: "something broke"}},
+			},
+			attempt:      1,
+			wantSubject:  "fix: address validation errors (attempt 1)",
+			wantContains: []string{"- unknown (1)"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			message := cmg.GenerateRecoveryCommitMessage(tt.issue, tt.validationResult, tt.attempt)
+
+			subject := strings.SplitN(message, "\n", 2)[0]
+			if subject != tt.wantSubject {
+				t.Errorf("Expected subject '%s', got '%s'", tt.wantSubject, subject)
+			}
+
+			for _, want := range tt.wantContains {
+				if !strings.Contains(message, want) {
+					t.Errorf("Expected message to contain '%s', got:\n%s", want, message)
+				}
+			}
+
+			for _, missing := range tt.wantMissing {
+				if strings.Contains(message, missing) {
+					t.Errorf("Expected message not to contain '%s', got:\n%s", missing, message)
+				}
+			}
+		})
+	}
+}
+
+func TestGenerateRecoveryCommitMessageDistinctPerAttempt(t *testing.T) {
+	cmg := &CommitMessageGenerator{}
+	result := &types.ValidationResult{
+		Errors: []types.ValidationError{{Type: "lint", Message: "line too long"}},
+	}
+
+	first := cmg.GenerateRecoveryCommitMessage(&Issue{Number: 7}, result, 1)
+	second := cmg.GenerateRecoveryCommitMessage(&Issue{Number: 7}, result, 2)
+
+	if first == second {
+		t.Errorf("Expected distinct messages per attempt, both were '%s'", first)
+	}
+}
@@ -0,0 +1,47 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Byte size settings such as git.min_free_space
+
+// byteSizeUnits maps size suffixes to their multiplier; sizes are binary, so
+// 1GB is 1024^3 bytes
+var byteSizeUnits = []struct {
+	suffix     string
+	multiplier uint64
+}{
+	{"TB", 1 << 40},
+	{"GB", 1 << 30},
+	{"MB", 1 << 20},
+	{"KB", 1 << 10},
+	{"B", 1},
+}
+
+// ParseByteSize parses a size such as "500MB", "2GB" or "1048576" into bytes.
+// Suffixes are case-insensitive and may carry an "i" (GiB); empty means 0.
+func ParseByteSize(size string) (uint64, error) {
+	s := strings.ToUpper(strings.TrimSpace(size))
+	if s == "" {
+		return 0, nil
+	}
+	s = strings.Replace(s, "IB", "B", 1)
+
+	multiplier := uint64(1)
+	for _, unit := range byteSizeUnits {
+		if strings.HasSuffix(s, unit.suffix) {
+			s = strings.TrimSpace(strings.TrimSuffix(s, unit.suffix))
+			multiplier = unit.multiplier
+			break
+		}
+	}
+
+	value, err := strconv.ParseFloat(s, 64)
+	if err != nil || value < 0 {
+		return 0, fmt.Errorf("invalid size %q: use a number of bytes or a size such as 500MB or 2GB", size)
+	}
+	return uint64(value * float64(multiplier)), nil
+}
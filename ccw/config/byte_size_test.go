@@ -0,0 +1,41 @@
+package config
+
+import "testing"
+
+func TestParseByteSize(t *testing.T) {
+	tests := []struct {
+		size     string
+		expected uint64
+		wantErr  bool
+	}{
+		{"", 0, false},
+		{"1048576", 1 << 20, false},
+		{"512B", 512, false},
+		{"500MB", 500 << 20, false},
+		{"2GB", 2 << 30, false},
+		{"2 gb", 2 << 30, false},
+		{"1.5GiB", 3 << 29, false},
+		{"1TB", 1 << 40, false},
+		{"GB", 0, true},
+		{"-1GB", 0, true},
+		{"lots", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.size, func(t *testing.T) {
+			got, err := ParseByteSize(tt.size)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Expected error %v, got %v", tt.wantErr, err)
+			}
+			if got != tt.expected {
+				t.Errorf("Expected %d, got %d", tt.expected, got)
+			}
+		})
+	}
+
+	config := GetDefaultCCWConfig()
+	config.Git.MinFreeSpace = "plenty"
+	if err := config.Validate(); err == nil {
+		t.Error("Expected an invalid git.min_free_space to fail validation")
+	}
+}
@@ -19,6 +19,10 @@ func GetDefaultCCWConfig() *CCWConfig {
 			Unicode:     true,
 			Width:       80,
 			Height:      24,
+			ShowLogs:    true,
+			ConsoleMode: false,
+
+			LogBufferSize: 1000,
 		},
 
 		Git: GitConfiguration{
@@ -27,6 +31,15 @@ func GetDefaultCCWConfig() *CCWConfig {
 			RetryDelay:    "2s",
 			DefaultBranch: "master",
 			RemoteName:    "origin",
+			PushRemote:    "origin",
+			BranchTypeMap: map[string]string{},
+			MaxWorktrees:  0,
+			MetadataDir:   "",
+			MinFreeSpace:  "",
+
+			WorktreeCreateRetries: 2,
+			WorktreeRemoveRetries: 3,
+			WorktreeForceRemove:   true,
 		},
 
 		Logging: LoggingConfiguration{
@@ -50,11 +63,26 @@ func GetDefaultCCWConfig() *CCWConfig {
 		},
 
 		GitHub: GitHubConfiguration{
-			MonitorCI:     false,
-			PRTemplate:    "",
-			IssueTemplate: "",
-			DefaultLabels: []string{},
-			AutoAssign:    false,
+			MonitorCI:       false,
+			PRTemplate:      "",
+			IssueTemplate:   "",
+			DefaultLabels:   []string{},
+			CopyIssueLabels: []string{},
+			AutoAssign:      false,
+			TokenFile:       "",
+			TokenCommand:    "",
+			GHPath:          "",
+			MinGHVersion:    "2.0.0",
+			Timeout:         "2m",
+		},
+
+		PR: PRConfiguration{
+			DeleteBranchAfterMerge: false,
+			MergeWait:              "2m",
+			ReplyToReviewThreads:   false,
+			ResolveReviewThreads:   false,
+			MaintainerCanModify:    true,
+			IncludeWarnings:        false,
 		},
 
 		Claude: ClaudeConfiguration{
@@ -63,6 +91,45 @@ func GetDefaultCCWConfig() *CCWConfig {
 			Model:                 "",
 			Context:               "",
 			EnhancedCommitMessage: true,
+			MaxContextChars:       20000,
+			AllowedTools:          []string{},
+			DisallowedTools:       []string{},
+			WorkingDirOnly:        false,
+			ResumeTranscript:      false,
+			IdleTimeout:           "",
+		},
+
+		CI: CIConfiguration{
+			CheckAliases:       []CheckAliasConfiguration{},
+			InitialDelay:       "30s",
+			InitialDelayJitter: "0s",
+			MaxMonitorDuration: "30m",
+			RetriggerStrategy:  "none",
+			FailureLogLines:    30,
+			CommentFailureLogs: false,
+		},
+
+		Workflow: WorkflowConfiguration{
+			MaxImplementationAttempts: 1,
+			MaxRecoveryAttempts:       nil,
+			UpdateTaskList:            false,
+			ChangePlan:                false,
+			Done: DoneConfiguration{
+				RequireValidation:       true,
+				RequireCI:               true,
+				RequiredChecks:          []string{},
+				DetectRequiredChecks:    true,
+				MaxHighPriorityComments: 0,
+				BlockOnChangesRequested: true,
+				RebaseOnConflict:        false,
+			},
+		},
+
+		Validation: ValidationConfiguration{
+			ContainerImage: "",
+			Parallel:       false,
+			Since:          "",
+			JUnitOut:       "",
 		},
 
 		ValidationRecovery: ValidationRecoveryConfiguration{
@@ -74,6 +141,32 @@ func GetDefaultCCWConfig() *CCWConfig {
 			AutoFixEnabled:        true,
 			VerboseOutput:         false,
 		},
+
+		Commit: CommitConfiguration{
+			MaxFileSize:     5 * 1024 * 1024,
+			LargeFileAction: "block",
+			ProtectedPaths:  []string{".github/workflows/", ".env", ".env.*", "*.pem", "*.key"},
+			BodyTemplate:    "",
+			BodyLines:       10,
+			Lint: CommitLintConfiguration{
+				Mode:             "off",
+				MaxSubjectLength: 72,
+				Types:            []string{"feat", "fix", "docs", "style", "refactor", "perf", "test", "build", "ci", "chore", "revert"},
+			},
+		},
+
+		Policy: PolicyConfiguration{
+			RequireTests:       false,
+			RequireTestsAction: "block",
+		},
+
+		Hooks: HooksConfiguration{
+			Timeout: "10m",
+		},
+
+		Env: EnvConfiguration{
+			DotEnv: false,
+		},
 	}
 }
 
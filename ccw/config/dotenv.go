@@ -0,0 +1,151 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// Dotenv file parsing for env.dotenv
+
+// DotEnvFile is the file env.dotenv loads
+const DotEnvFile = ".env"
+
+// dotEnvKeyPattern matches the variable names accepted in a dotenv file
+var dotEnvKeyPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// DotEnvVar is one assignment from a dotenv file
+type DotEnvVar struct {
+	Key   string
+	Value string
+}
+
+// String returns the assignment in KEY=value form for exec.Cmd.Env
+func (v DotEnvVar) String() string {
+	return v.Key + "=" + v.Value
+}
+
+// LoadDotEnv reads and parses the dotenv file at path
+func LoadDotEnv(path string) ([]DotEnvVar, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	vars, err := ParseDotEnv(string(data))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return vars, nil
+}
+
+// ParseDotEnv parses dotenv content: KEY=value lines with an optional
+// "export " prefix, blank lines and # comments. Unquoted values end at a
+// " #" comment and are trimmed. Single-quoted values are literal; double-quoted
+// values expand \n, \r, \t, \" and \\. Both may span lines. Errors name the
+// line but never include values, which are often secrets.
+func ParseDotEnv(content string) ([]DotEnvVar, error) {
+	var vars []DotEnvVar
+	lines := strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n")
+
+	for i := 0; i < len(lines); i++ {
+		lineNumber := i + 1
+		line := strings.TrimSpace(lines[i])
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if rest, ok := strings.CutPrefix(line, "export"); ok && rest != "" && (rest[0] == ' ' || rest[0] == '\t') {
+			line = strings.TrimSpace(rest)
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("line %d: expected KEY=value", lineNumber)
+		}
+		key = strings.TrimSpace(key)
+		if !dotEnvKeyPattern.MatchString(key) {
+			return nil, fmt.Errorf("line %d: invalid variable name %q", lineNumber, key)
+		}
+		value = strings.TrimLeft(value, " \t")
+
+		if value == "" || (value[0] != '"' && value[0] != '\'') {
+			vars = append(vars, DotEnvVar{Key: key, Value: unquotedDotEnvValue(value)})
+			continue
+		}
+
+		// Quoted values may continue on the following lines
+		quote := value[0]
+		raw := value[1:]
+		end := closingQuote(raw, quote)
+		for end < 0 && i+1 < len(lines) {
+			i++
+			raw += "\n" + lines[i]
+			end = closingQuote(raw, quote)
+		}
+		if end < 0 {
+			return nil, fmt.Errorf("line %d: unterminated quoted value for %s", lineNumber, key)
+		}
+		if trailing := strings.TrimSpace(raw[end+1:]); trailing != "" && !strings.HasPrefix(trailing, "#") {
+			return nil, fmt.Errorf("line %d: unexpected text after quoted value for %s", lineNumber, key)
+		}
+
+		value = raw[:end]
+		if quote == '"' {
+			value = expandDotEnvEscapes(value)
+		}
+		vars = append(vars, DotEnvVar{Key: key, Value: value})
+	}
+	return vars, nil
+}
+
+// unquotedDotEnvValue strips an inline " #" comment and surrounding whitespace
+func unquotedDotEnvValue(value string) string {
+	for i := 1; i < len(value); i++ {
+		if value[i] == '#' && (value[i-1] == ' ' || value[i-1] == '\t') {
+			value = value[:i]
+			break
+		}
+	}
+	return strings.TrimSpace(value)
+}
+
+// closingQuote returns the index of the quote ending raw, or -1. Inside double
+// quotes a backslash escapes the next character.
+func closingQuote(raw string, quote byte) int {
+	for i := 0; i < len(raw); i++ {
+		switch {
+		case quote == '"' && raw[i] == '\\':
+			i++
+		case raw[i] == quote:
+			return i
+		}
+	}
+	return -1
+}
+
+// expandDotEnvEscapes expands the escapes allowed in double-quoted values;
+// other backslashes are kept
+func expandDotEnvEscapes(value string) string {
+	var b strings.Builder
+	for i := 0; i < len(value); i++ {
+		if value[i] != '\\' || i+1 == len(value) {
+			b.WriteByte(value[i])
+			continue
+		}
+		i++
+		switch value[i] {
+		case 'n':
+			b.WriteByte('\n')
+		case 'r':
+			b.WriteByte('\r')
+		case 't':
+			b.WriteByte('\t')
+		case '"', '\\':
+			b.WriteByte(value[i])
+		default:
+			b.WriteByte('\\')
+			b.WriteByte(value[i])
+		}
+	}
+	return b.String()
+}
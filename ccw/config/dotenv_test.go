@@ -0,0 +1,95 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseDotEnv(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected []DotEnvVar
+	}{
+		{"empty", "", nil},
+		{"comments and empty lines", "# comment\n\n   \n  # indented comment\nA=1\n", []DotEnvVar{{"A", "1"}}},
+		{"export prefix", "export TOKEN=abc\nexport\tB=2", []DotEnvVar{{"TOKEN", "abc"}, {"B", "2"}}},
+		{"name starting with export", "EXPORTED=yes", []DotEnvVar{{"EXPORTED", "yes"}}},
+		{"spaces around equals", "A = spaced value  ", []DotEnvVar{{"A", "spaced value"}}},
+		{"empty value", "EMPTY=\nQUOTED_EMPTY=\"\"", []DotEnvVar{{"EMPTY", ""}, {"QUOTED_EMPTY", ""}}},
+		{"inline comment", "A=value # trailing comment", []DotEnvVar{{"A", "value"}}},
+		{"hash without space is kept", "URL=https://example.com/#anchor", []DotEnvVar{{"URL", "https://example.com/#anchor"}}},
+		{"equals in value", "DSN=user=me;password=x=y", []DotEnvVar{{"DSN", "user=me;password=x=y"}}},
+		{"double quotes", `A="hello # not a comment"  # comment`, []DotEnvVar{{"A", "hello # not a comment"}}},
+		{"double quote escapes", `A="line1\nline2\t\"quoted\" \\ \$x"`, []DotEnvVar{{"A", "line1\nline2\t\"quoted\" \\ \\$x"}}},
+		{"single quotes are literal", `A='raw \n "value" # kept'`, []DotEnvVar{{"A", `raw \n "value" # kept`}}},
+		{"multi-line double quotes", "KEY=\"-----BEGIN-----\nabc\n-----END-----\"\nNEXT=1", []DotEnvVar{{"KEY", "-----BEGIN-----\nabc\n-----END-----"}, {"NEXT", "1"}}},
+		{"multi-line single quotes", "A='one\ntwo'", []DotEnvVar{{"A", "one\ntwo"}}},
+		{"windows line endings", "A=1\r\nB=\"2\"\r\n", []DotEnvVar{{"A", "1"}, {"B", "2"}}},
+		{"duplicates kept in order", "A=1\nA=2", []DotEnvVar{{"A", "1"}, {"A", "2"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ParseDotEnv(tt.content)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("Expected %q, got %q", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestParseDotEnvErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		line    string
+	}{
+		{"missing equals", "A=1\nJUST_A_WORD", "line 2"},
+		{"invalid name", "1BAD=x", "line 1"},
+		{"unterminated quote", "A=1\nSECRET=\"s3cr3t\nmore", "line 2"},
+		{"text after quote", "SECRET='s3cr3t' extra", "line 1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseDotEnv(tt.content)
+			if err == nil {
+				t.Fatal("Expected an error")
+			}
+			if !strings.Contains(err.Error(), tt.line) {
+				t.Errorf("Expected error naming %s, got '%v'", tt.line, err)
+			}
+			if strings.Contains(err.Error(), "s3cr3t") {
+				t.Errorf("Expected the value to stay out of the error, got '%v'", err)
+			}
+		})
+	}
+}
+
+func TestLoadDotEnv(t *testing.T) {
+	tmpDir := t.TempDir()
+	path := filepath.Join(tmpDir, DotEnvFile)
+	if err := os.WriteFile(path, []byte("export API_URL=http://localhost\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	vars, err := LoadDotEnv(path)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(vars) != 1 || vars[0].String() != "API_URL=http://localhost" {
+		t.Errorf("Expected API_URL=http://localhost, got %q", vars)
+	}
+
+	if _, err := LoadDotEnv(filepath.Join(tmpDir, "missing")); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("Expected a not-exist error, got %v", err)
+	}
+}
@@ -49,6 +49,9 @@ ui:
   unicode: true             # Enable Unicode characters
   width: 80                 # Terminal width (0 = auto-detect)
   height: 24                # Terminal height (0 = auto-detect)
+  show_logs: true           # Show the logs panel beside the interactive UI
+  console_mode: false       # Always use plain console output instead of the interactive UI
+  log_buffer_size: 1000     # Log entries kept for the logs panel
 
 # Git Operations
 git:
@@ -57,6 +60,18 @@ git:
   retry_delay: "2s"         # Delay between retries
   default_branch: "master"  # Default branch name
   remote_name: "origin"     # Default remote name
+  push_remote: "origin"     # Remote to push branches to (e.g. your fork when origin is upstream)
+  branch_type_map: {}       # Branch prefix per issue label; "default" applies when none match
+  # branch_type_map:
+  #   bug: fix              # fix/issue-12-20240101-090000
+  #   enhancement: feat
+  #   default: chore
+  max_worktrees: 0          # Refuse new issue worktrees once this many exist (0 = unlimited)
+  metadata_dir: ""          # Keep issue-data.json and worktree-config.json in this worktree subdirectory, e.g. .ccw
+  min_free_space: ""        # Refuse new worktrees when less space is free at the worktree base, e.g. 2GB (empty = no check)
+  worktree_create_retries: 2 # Retry worktree creation after transient failures such as file locks (0 = no retry)
+  worktree_remove_retries: 3 # Retry worktree removal while files are locked by editors or antivirus (0 = no retry)
+  worktree_force_remove: true # Delete the worktree directory when removal keeps failing (false = leave it and report)
 
 # Logging
 logging:
@@ -84,7 +99,34 @@ github:
   pr_template: ""           # Path to PR description template
   issue_template: ""        # Path to issue template
   default_labels: []        # Default labels to apply to PRs
+  copy_issue_labels: []     # Issue labels copied to the PR, e.g. [bug, enhancement]
   auto_assign: false        # Auto-assign PRs to current user
+  token_file: ""            # File containing a GitHub token (GH_TOKEN/GITHUB_TOKEN take precedence)
+  token_command: ""         # Command printing a GitHub token, e.g. a keychain lookup
+  gh_path: ""               # Path to the gh executable (default: gh on PATH)
+  min_gh_version: "2.0.0"   # Warn when the installed gh is older than this
+  timeout: "2m"             # Limit for each gh call to GitHub, separate from claude_timeout and git.timeout (0s = none)
+
+# GitHub Account Profiles
+# Select one per run with --profile NAME (or CCW_PROFILE). The profile's host
+# and token are used for every gh command in that run.
+profile: ""                 # Profile used when --profile is not given (empty = none)
+profiles: {}
+# profiles:
+#   work:
+#     host: "github.example.com"
+#     token_command: "security find-generic-password -s gh-work -w"
+#   personal:
+#     token_env: "PERSONAL_GH_TOKEN"
+
+# Pull Request Settings
+pr:
+  delete_branch_after_merge: false # Delete the remote branch when the PR is merged once CI passes
+  merge_wait: "2m"                 # How long to wait for the merge (e.g. auto-merge) before keeping the branch (0s = check once)
+  reply_to_review_threads: false   # Address inline review threads and reply in each thread instead of top-level
+  resolve_review_threads: false    # Also resolve the threads replied to
+  maintainer_can_modify: true      # Allow maintainers to edit the branch of PRs opened from a fork
+  include_warnings: false          # Append non-blocking validation warnings to the PR body
 
 # Claude Code Integration
 claude:
@@ -93,6 +135,105 @@ claude:
   model: ""                        # Specific Claude model to use (empty = default)
   context: ""                      # Additional context file path
   enhanced_commit_message: true    # Enable AI-powered commit message generation
+  max_context_chars: 20000         # Max issue body and prelude length sent to Claude (0 = unlimited)
+  prelude: ""                      # House rules prepended to every run, or "@path" to read them from a file
+  subdir: ""                       # Monorepo subdirectory Claude and validation work in (git still uses the worktree root)
+  allowed_tools: []                # Tools Claude may use without asking (--allowedTools), e.g. ["Edit", "Bash(swift build:*)"]
+  disallowed_tools: []             # Tools Claude may not use (--disallowedTools), e.g. ["WebFetch", "Bash(git push:*)"]
+  working_dir_only: false          # With subdir, do not also grant Claude the worktree root (--add-dir)
+  resume_transcript: false         # Include a condensed transcript of the previous session in this worktree
+  idle_timeout: ""                 # Abort plan, PR description and other --print runs silent this long, e.g. "3m" (empty = disabled)
+
+# CI Failure Categorization
+# Aliases are checked in order before the built-in build/lint/test keyword
+# matching. Patterns match check names case-insensitively as a substring, or
+# as a glob when they contain * ? or [.
+ci:
+  initial_delay: "30s"       # Wait before the first status poll so checks can register
+  initial_delay_jitter: "0s" # Random extra wait up to this duration, to spread concurrent runs
+  max_monitor_duration: "30m" # Stop watching CI after this long; the PR is left open
+  retrigger_strategy: none    # Re-run failed CI once: rerun (gh run rerun), empty-commit, or none
+  failure_log_lines: 30       # Log lines shown per failed Actions check (gh run view --log-failed); 0 = off
+  comment_failure_logs: false # Also post those log excerpts as a PR comment
+  check_aliases: []
+  # check_aliases:
+  #   - pattern: "style-gate"
+  #     category: lint
+  #   - pattern: "deploy-*"
+  #     category: unknown
+
+# Workflow Attempts
+workflow:
+  max_implementation_attempts: 1  # Claude Code runs for the initial implementation
+  # max_recovery_attempts: 3      # Recovery passes after failed validation (unset = max_retries, 0 = none)
+  update_task_list: false         # Check off completed "- [ ]" items in the issue body
+  change_plan: false              # Record the files Claude intends to change and flag unplanned edits
+  done:                           # Definition of done checked after CI completes
+    require_validation: true      # Local validation must have passed
+    require_ci: true              # CI checks must be green
+    required_checks: []           # Check name patterns that must pass (empty = every check)
+    detect_required_checks: true  # Without required_checks, use branch protection's required checks when readable
+    max_high_priority_comments: 0 # Unaddressed high-priority PR comments tolerated
+    block_on_changes_requested: true # A "changes requested" review blocks regardless of CI
+    rebase_on_conflict: false        # Rebase onto the base branch and force-push once when the PR conflicts
+  steps: []                       # Custom progress steps, e.g. [{id: setup}, {id: pre_implementation, name: "Pre-implementation hooks"}]
+  messages:                       # Final message templates (empty = built-in). Tokens: {issue_number}, {issue_title},
+                                  # {issue_url}, {branch}, {pr_url}, {duration}, {ci_conclusion}, {error}
+    success: ""                   # e.g. "Issue #{issue_number} done in {duration}: {pr_url} (CI: {ci_conclusion})"
+    failure: ""                   # e.g. "Issue #{issue_number} failed after {duration}: {error}"
+
+# Validation
+validation:
+  container_image: ""       # Run lint/build/test in this Docker image, e.g. "swift:5.10" (empty = host)
+  parallel: false           # Run lint alongside build; tests still wait for the build
+  since: ""                 # Lint only files changed since this ref, e.g. "origin/main" (empty = all files)
+  junit_out: ""             # Write validation results as JUnit XML here, e.g. build/ccw-junit.xml
+
+# Commit Safety
+commit:
+  max_file_size: 5242880     # Max size in bytes for a changed file (0 = no limit)
+  large_file_action: "block" # Action for large or binary files: block, warn
+  author_name: ""           # Commit author name for automation identities (empty = git config)
+  author_email: ""          # Commit author email, required with author_name
+  protected_paths:          # Gitignore-style paths that need --allow-protected to commit
+    - ".github/workflows/"
+    - ".env"
+    - ".env.*"
+    - "*.pem"
+    - "*.key"
+  body_template: ""         # Issue details added below the subject of generated commit messages (empty = none).
+                            # Tokens: {issue_number}, {issue_title}, {issue_url}, {issue_labels}, {issue_body}
+  # body_template: |
+  #   {issue_body}
+  #
+  #   Labels: {issue_labels}
+  body_lines: 10            # Issue body lines kept by {issue_body}; longer bodies are cut with "…"
+  lint:
+    mode: "off"                # Check commit messages: off, builtin, commitlint
+    max_subject_length: 72     # Built-in rule: maximum subject line length
+    types: [feat, fix, docs, style, refactor, perf, test, build, ci, chore, revert]
+
+# Change Policy
+policy:
+  require_tests: false          # Changes must add or modify a test file (*_test.go, Tests/, *.spec.ts, ...)
+  require_tests_action: "block" # Action when no test file changed: block, warn
+  test_patterns: []             # Extra gitignore-style test file patterns, e.g. ["integration/"]
+
+# Lifecycle Hooks
+# Commands run in the worktree with CCW_HOOK_PHASE, CCW_ISSUE_NUMBER,
+# CCW_BRANCH_NAME and CCW_WORKTREE_PATH set. A failing hook aborts the
+# workflow unless continue_on_error is true.
+hooks:
+  timeout: "10m"            # Timeout for each hook command
+  pre_implementation: []    # e.g. [{command: "make deps"}]
+  post_validation: []
+  pre_push: []              # e.g. [{command: "make lint", continue_on_error: true}]
+  post_pr: []
+  post_pr_smoke: ""         # Smoke command run after PR creation; output posted as a PR comment
+
+# Environment for validation commands and Claude Code
+env:
+  dotenv: false             # Load .env from the working directory and the worktree (worktree wins)
 `
 
 	if err := os.WriteFile(filename, []byte(yamlData), 0644); err != nil {
@@ -12,10 +12,22 @@ import (
 
 // Configuration loading and environment variable handling
 
-// LoadConfiguration loads configuration from YAML file with fallback to environment variables
+// LoadConfiguration loads configuration from YAML files with fallback to environment variables.
+// Later sources override earlier ones: defaults, the repository's .ccw/config.yaml,
+// the cwd or user config file, then environment variables. Command-line flags
+// are applied on top by the caller.
 func LoadConfiguration() (*CCWConfig, error) {
 	config := GetDefaultCCWConfig()
 
+	// Repository defaults shipped in .ccw/config.yaml, limited to repoConfigKeys
+	if cwd, err := os.Getwd(); err == nil {
+		if repoConfigPath := findRepoConfigFile(cwd); repoConfigPath != "" {
+			if err := loadRepoYAMLInto(config, repoConfigPath); err != nil {
+				// Invalid repository config, continue with defaults
+			}
+		}
+	}
+
 	// Try to load from YAML file
 	if err := loadFromYAMLFile(config); err != nil {
 		// YAML file not found or invalid, continue with defaults
@@ -27,10 +39,9 @@ func LoadConfiguration() (*CCWConfig, error) {
 	return config, nil
 }
 
-// Load configuration from YAML file
-func loadFromYAMLFile(config *CCWConfig) error {
-	// Try multiple possible config file locations
-	configPaths := []string{
+// configSearchPaths lists the config file locations in lookup order
+func configSearchPaths() []string {
+	return []string{
 		"ccw.yaml",
 		"ccw.yml",
 		".ccw.yaml",
@@ -40,19 +51,169 @@ func loadFromYAMLFile(config *CCWConfig) error {
 		filepath.Join(os.Getenv("HOME"), ".config", "ccw", "config.yaml"),
 		filepath.Join(os.Getenv("HOME"), ".config", "ccw", "config.yml"),
 	}
+}
+
+// repoConfigNames lists the repository config files looked for in each directory
+var repoConfigNames = []string{
+	filepath.Join(".ccw", "config.yaml"),
+	filepath.Join(".ccw", "config.yml"),
+}
 
-	for _, configPath := range configPaths {
-		if data, err := os.ReadFile(configPath); err == nil {
-			if err := yaml.Unmarshal(data, config); err != nil {
-				return fmt.Errorf("failed to parse YAML config file %s: %w", configPath, err)
+// findRepoConfigFile walks up from startDir to the git root looking for a
+// repository config file. It returns "" when none is found or startDir is not
+// inside a git repository.
+func findRepoConfigFile(startDir string) string {
+	var found string
+	dir := filepath.Clean(startDir)
+	for {
+		if found == "" {
+			for _, name := range repoConfigNames {
+				candidate := filepath.Join(dir, name)
+				if info, err := os.Stat(candidate); err == nil && !info.IsDir() {
+					found = candidate
+					break
+				}
 			}
-			return nil
+		}
+
+		// .git is a directory in a clone and a file in a worktree
+		if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+			return found
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+// repoConfigKeys lists the keys a repository's .ccw/config.yaml may set. A
+// cloned repository is not trusted, so keys that run commands or choose what
+// is executed, read or written outside the checkout (hooks, token commands,
+// gh_path, container images, the Claude prelude and tool rules, profiles,
+// log and report paths) are only read from the user's own config. A key
+// allows everything below it.
+var repoConfigKeys = map[string]bool{
+	"max_retries":    true,
+	"claude_timeout": true,
+
+	"ui":          true,
+	"performance": true,
+	"pr":          true,
+	"ci":          true,
+	"workflow":    true,
+	"policy":      true,
+
+	"validation_recovery": true,
+
+	"git.timeout":         true,
+	"git.retry_attempts":  true,
+	"git.retry_delay":     true,
+	"git.default_branch":  true,
+	"git.remote_name":     true,
+	"git.push_remote":     true,
+	"git.branch_type_map": true,
+	"git.metadata_dir":    true,
+
+	"github.monitor_ci":        true,
+	"github.pr_template":       true,
+	"github.issue_template":    true,
+	"github.default_labels":    true,
+	"github.copy_issue_labels": true,
+	"github.auto_assign":       true,
+	"github.min_gh_version":    true,
+	"github.timeout":           true,
+
+	"claude.timeout":                 true,
+	"claude.max_retries":             true,
+	"claude.model":                   true,
+	"claude.context":                 true,
+	"claude.enhanced_commit_message": true,
+	"claude.max_context_chars":       true,
+	"claude.subdir":                  true,
+	"claude.working_dir_only":        true,
+	"claude.resume_transcript":       true,
+	"claude.idle_timeout":            true,
+
+	"validation.parallel": true,
+	"validation.since":    true,
+
+	"commit.max_file_size":           true,
+	"commit.large_file_action":       true,
+	"commit.protected_paths":         true,
+	"commit.body_template":           true,
+	"commit.body_lines":              true,
+	"commit.lint.max_subject_length": true,
+	"commit.lint.types":              true,
+}
+
+// loadRepoYAMLInto merges the repository config file at configPath into
+// config, dropping every key not allowed by repoConfigKeys
+func loadRepoYAMLInto(config *CCWConfig, configPath string) error {
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		return fmt.Errorf("failed to read config file %s: %w", configPath, err)
+	}
+	var values map[string]interface{}
+	if err := yaml.Unmarshal(data, &values); err != nil {
+		return fmt.Errorf("failed to parse YAML config file %s: %w", configPath, err)
+	}
+
+	allowed, err := yaml.Marshal(filterRepoConfig(values, ""))
+	if err != nil {
+		return fmt.Errorf("failed to filter config file %s: %w", configPath, err)
+	}
+	if err := yaml.Unmarshal(allowed, config); err != nil {
+		return fmt.Errorf("failed to parse YAML config file %s: %w", configPath, err)
+	}
+	return nil
+}
+
+// filterRepoConfig returns the entries of values whose dotted key, under
+// prefix, is allowed by repoConfigKeys
+func filterRepoConfig(values map[string]interface{}, prefix string) map[string]interface{} {
+	filtered := make(map[string]interface{})
+	for key, value := range values {
+		path := prefix + key
+		if repoConfigKeys[path] {
+			filtered[key] = value
+			continue
+		}
+		if nested, ok := value.(map[string]interface{}); ok {
+			if kept := filterRepoConfig(nested, path+"."); len(kept) > 0 {
+				filtered[key] = kept
+			}
+		}
+	}
+	return filtered
+}
+
+// Load configuration from YAML file
+func loadFromYAMLFile(config *CCWConfig) error {
+	for _, configPath := range configSearchPaths() {
+		if _, err := os.Stat(configPath); err == nil {
+			return loadYAMLInto(config, configPath)
 		}
 	}
 
 	return fmt.Errorf("no config file found")
 }
 
+// loadYAMLInto merges the YAML file at configPath into config; keys missing
+// from the file keep their current values
+func loadYAMLInto(config *CCWConfig, configPath string) error {
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		return fmt.Errorf("failed to read config file %s: %w", configPath, err)
+	}
+	if err := yaml.Unmarshal(data, config); err != nil {
+		return fmt.Errorf("failed to parse YAML config file %s: %w", configPath, err)
+	}
+	return nil
+}
+
 // Load configuration from environment variables
 func loadFromEnvironment(config *CCWConfig) {
 	// Core settings
@@ -84,6 +245,17 @@ func loadFromEnvironment(config *CCWConfig) {
 	if val := os.Getenv("CCW_UNICODE"); val != "" {
 		config.UI.Unicode = strings.ToLower(val) == "true"
 	}
+	if val := os.Getenv("CCW_SHOW_LOGS"); val != "" {
+		config.UI.ShowLogs = strings.ToLower(val) == "true"
+	}
+	if val := os.Getenv("CCW_CONSOLE_MODE"); val != "" {
+		config.UI.ConsoleMode = strings.ToLower(val) == "true"
+	}
+	if val := os.Getenv("CCW_LOG_BUFFER_SIZE"); val != "" {
+		if size, err := strconv.Atoi(val); err == nil {
+			config.UI.LogBufferSize = size
+		}
+	}
 
 	// Git Configuration
 	if val := os.Getenv("CCW_GIT_TIMEOUT"); val != "" {
@@ -100,6 +272,36 @@ func loadFromEnvironment(config *CCWConfig) {
 	if val := os.Getenv("CCW_GIT_DEFAULT_BRANCH"); val != "" {
 		config.Git.DefaultBranch = val
 	}
+	if val := os.Getenv("CCW_GIT_PUSH_REMOTE"); val != "" {
+		config.Git.PushRemote = val
+	}
+	if val := os.Getenv("CCW_GIT_BRANCH_TYPE_MAP"); val != "" {
+		config.Git.BranchTypeMap = parseBranchTypeMap(val)
+	}
+	if val := os.Getenv("CCW_GIT_MAX_WORKTREES"); val != "" {
+		if limit, err := strconv.Atoi(val); err == nil {
+			config.Git.MaxWorktrees = limit
+		}
+	}
+	if val := os.Getenv("CCW_GIT_METADATA_DIR"); val != "" {
+		config.Git.MetadataDir = val
+	}
+	if val := os.Getenv("CCW_GIT_MIN_FREE_SPACE"); val != "" {
+		config.Git.MinFreeSpace = val
+	}
+	if val := os.Getenv("CCW_GIT_WORKTREE_CREATE_RETRIES"); val != "" {
+		if retries, err := strconv.Atoi(val); err == nil {
+			config.Git.WorktreeCreateRetries = retries
+		}
+	}
+	if val := os.Getenv("CCW_GIT_WORKTREE_REMOVE_RETRIES"); val != "" {
+		if retries, err := strconv.Atoi(val); err == nil {
+			config.Git.WorktreeRemoveRetries = retries
+		}
+	}
+	if val := os.Getenv("CCW_GIT_WORKTREE_FORCE_REMOVE"); val != "" {
+		config.Git.WorktreeForceRemove = strings.ToLower(val) == "true"
+	}
 
 	// Logging Configuration
 	if val := os.Getenv("CCW_LOG_LEVEL"); val != "" {
@@ -152,9 +354,50 @@ func loadFromEnvironment(config *CCWConfig) {
 	if val := os.Getenv("CCW_DEFAULT_LABELS"); val != "" {
 		config.GitHub.DefaultLabels = strings.Split(val, ",")
 	}
+	if val := os.Getenv("CCW_COPY_ISSUE_LABELS"); val != "" {
+		config.GitHub.CopyIssueLabels = strings.Split(val, ",")
+	}
 	if val := os.Getenv("CCW_AUTO_ASSIGN"); val != "" {
 		config.GitHub.AutoAssign = strings.ToLower(val) == "true"
 	}
+	if val := os.Getenv("CCW_PROFILE"); val != "" {
+		config.Profile = val
+	}
+	if val := os.Getenv("CCW_GITHUB_TOKEN_FILE"); val != "" {
+		config.GitHub.TokenFile = val
+	}
+	if val := os.Getenv("CCW_GITHUB_TOKEN_COMMAND"); val != "" {
+		config.GitHub.TokenCommand = val
+	}
+	if val := os.Getenv("CCW_GITHUB_GH_PATH"); val != "" {
+		config.GitHub.GHPath = val
+	}
+	if val := os.Getenv("CCW_GITHUB_MIN_GH_VERSION"); val != "" {
+		config.GitHub.MinGHVersion = val
+	}
+	if val := os.Getenv("CCW_GITHUB_TIMEOUT"); val != "" {
+		config.GitHub.Timeout = val
+	}
+
+	// Pull Request Configuration
+	if val := os.Getenv("CCW_PR_DELETE_BRANCH_AFTER_MERGE"); val != "" {
+		config.PR.DeleteBranchAfterMerge = strings.ToLower(val) == "true"
+	}
+	if val := os.Getenv("CCW_PR_MERGE_WAIT"); val != "" {
+		config.PR.MergeWait = val
+	}
+	if val := os.Getenv("CCW_PR_REPLY_TO_REVIEW_THREADS"); val != "" {
+		config.PR.ReplyToReviewThreads = strings.ToLower(val) == "true"
+	}
+	if val := os.Getenv("CCW_PR_MAINTAINER_CAN_MODIFY"); val != "" {
+		config.PR.MaintainerCanModify = strings.ToLower(val) == "true"
+	}
+	if val := os.Getenv("CCW_PR_INCLUDE_WARNINGS"); val != "" {
+		config.PR.IncludeWarnings = strings.ToLower(val) == "true"
+	}
+	if val := os.Getenv("CCW_PR_RESOLVE_REVIEW_THREADS"); val != "" {
+		config.PR.ResolveReviewThreads = strings.ToLower(val) == "true"
+	}
 
 	// Claude Configuration
 	if val := os.Getenv("CCW_CLAUDE_TIMEOUT"); val != "" {
@@ -174,4 +417,180 @@ func loadFromEnvironment(config *CCWConfig) {
 	if val := os.Getenv("CCW_ENHANCED_COMMIT_MESSAGE"); val != "" {
 		config.Claude.EnhancedCommitMessage = strings.ToLower(val) == "true"
 	}
+	if val := os.Getenv("CCW_CLAUDE_MAX_CONTEXT_CHARS"); val != "" {
+		if chars, err := strconv.Atoi(val); err == nil {
+			config.Claude.MaxContextChars = chars
+		}
+	}
+	if val := os.Getenv("CCW_CLAUDE_PRELUDE"); val != "" {
+		config.Claude.Prelude = val
+	}
+	if val := os.Getenv("CCW_CLAUDE_SUBDIR"); val != "" {
+		config.Claude.Subdir = val
+	}
+	if val := os.Getenv("CCW_CLAUDE_ALLOWED_TOOLS"); val != "" {
+		config.Claude.AllowedTools = strings.Split(val, ",")
+	}
+	if val := os.Getenv("CCW_CLAUDE_DISALLOWED_TOOLS"); val != "" {
+		config.Claude.DisallowedTools = strings.Split(val, ",")
+	}
+	if val := os.Getenv("CCW_CLAUDE_WORKING_DIR_ONLY"); val != "" {
+		config.Claude.WorkingDirOnly = strings.ToLower(val) == "true"
+	}
+	if val := os.Getenv("CCW_CLAUDE_RESUME_TRANSCRIPT"); val != "" {
+		config.Claude.ResumeTranscript = strings.ToLower(val) == "true"
+	}
+	if val := os.Getenv("CCW_CLAUDE_IDLE_TIMEOUT"); val != "" {
+		config.Claude.IdleTimeout = val
+	}
+
+	// CI Configuration
+	if val := os.Getenv("CCW_CI_CHECK_ALIASES"); val != "" {
+		config.CI.CheckAliases = parseCheckAliases(val)
+	}
+	if val := os.Getenv("CCW_CI_INITIAL_DELAY"); val != "" {
+		config.CI.InitialDelay = val
+	}
+	if val := os.Getenv("CCW_CI_INITIAL_DELAY_JITTER"); val != "" {
+		config.CI.InitialDelayJitter = val
+	}
+	if val := os.Getenv("CCW_CI_MAX_MONITOR_DURATION"); val != "" {
+		config.CI.MaxMonitorDuration = val
+	}
+	if val := os.Getenv("CCW_CI_RETRIGGER_STRATEGY"); val != "" {
+		config.CI.RetriggerStrategy = val
+	}
+	if val := os.Getenv("CCW_CI_FAILURE_LOG_LINES"); val != "" {
+		if lines, err := strconv.Atoi(val); err == nil {
+			config.CI.FailureLogLines = lines
+		}
+	}
+	if val := os.Getenv("CCW_CI_COMMENT_FAILURE_LOGS"); val != "" {
+		config.CI.CommentFailureLogs = strings.ToLower(val) == "true"
+	}
+
+	// Workflow Configuration
+	if val := os.Getenv("CCW_WORKFLOW_MAX_IMPLEMENTATION_ATTEMPTS"); val != "" {
+		if attempts, err := strconv.Atoi(val); err == nil {
+			config.Workflow.MaxImplementationAttempts = attempts
+		}
+	}
+	if val := os.Getenv("CCW_WORKFLOW_MAX_RECOVERY_ATTEMPTS"); val != "" {
+		if attempts, err := strconv.Atoi(val); err == nil {
+			config.Workflow.MaxRecoveryAttempts = &attempts
+		}
+	}
+	if val := os.Getenv("CCW_WORKFLOW_UPDATE_TASK_LIST"); val != "" {
+		config.Workflow.UpdateTaskList = strings.ToLower(val) == "true"
+	}
+	if val := os.Getenv("CCW_WORKFLOW_CHANGE_PLAN"); val != "" {
+		config.Workflow.ChangePlan = strings.ToLower(val) == "true"
+	}
+	if val := os.Getenv("CCW_DONE_REQUIRE_VALIDATION"); val != "" {
+		config.Workflow.Done.RequireValidation = strings.ToLower(val) == "true"
+	}
+	if val := os.Getenv("CCW_DONE_REQUIRE_CI"); val != "" {
+		config.Workflow.Done.RequireCI = strings.ToLower(val) == "true"
+	}
+	if val := os.Getenv("CCW_DONE_REQUIRED_CHECKS"); val != "" {
+		config.Workflow.Done.RequiredChecks = strings.Split(val, ",")
+	}
+	if val := os.Getenv("CCW_DONE_DETECT_REQUIRED_CHECKS"); val != "" {
+		config.Workflow.Done.DetectRequiredChecks = strings.ToLower(val) == "true"
+	}
+	if val := os.Getenv("CCW_DONE_BLOCK_ON_CHANGES_REQUESTED"); val != "" {
+		config.Workflow.Done.BlockOnChangesRequested = strings.ToLower(val) == "true"
+	}
+	if val := os.Getenv("CCW_DONE_REBASE_ON_CONFLICT"); val != "" {
+		config.Workflow.Done.RebaseOnConflict = strings.ToLower(val) == "true"
+	}
+
+	// Validation Configuration
+	if val := os.Getenv("CCW_VALIDATION_CONTAINER_IMAGE"); val != "" {
+		config.Validation.ContainerImage = val
+	}
+	if val := os.Getenv("CCW_VALIDATION_PARALLEL"); val != "" {
+		config.Validation.Parallel = strings.ToLower(val) == "true"
+	}
+	if val := os.Getenv("CCW_VALIDATION_SINCE"); val != "" {
+		config.Validation.Since = val
+	}
+	if val := os.Getenv("CCW_VALIDATION_JUNIT_OUT"); val != "" {
+		config.Validation.JUnitOut = val
+	}
+
+	// Commit Configuration
+	if val := os.Getenv("CCW_COMMIT_MAX_FILE_SIZE"); val != "" {
+		if size, err := strconv.ParseInt(val, 10, 64); err == nil {
+			config.Commit.MaxFileSize = size
+		}
+	}
+	if val := os.Getenv("CCW_COMMIT_LARGE_FILE_ACTION"); val != "" {
+		config.Commit.LargeFileAction = val
+	}
+	if val := os.Getenv("CCW_COMMIT_AUTHOR_NAME"); val != "" {
+		config.Commit.AuthorName = val
+	}
+	if val := os.Getenv("CCW_COMMIT_AUTHOR_EMAIL"); val != "" {
+		config.Commit.AuthorEmail = val
+	}
+	if val := os.Getenv("CCW_COMMIT_PROTECTED_PATHS"); val != "" {
+		config.Commit.ProtectedPaths = strings.Split(val, ",")
+	}
+	if val := os.Getenv("CCW_COMMIT_BODY_TEMPLATE"); val != "" {
+		config.Commit.BodyTemplate = val
+	}
+	if val := os.Getenv("CCW_COMMIT_BODY_LINES"); val != "" {
+		if lines, err := strconv.Atoi(val); err == nil {
+			config.Commit.BodyLines = lines
+		}
+	}
+	if val := os.Getenv("CCW_COMMIT_LINT_MODE"); val != "" {
+		config.Commit.Lint.Mode = val
+	}
+
+	// Change Policy Configuration
+	if val := os.Getenv("CCW_POLICY_REQUIRE_TESTS"); val != "" {
+		config.Policy.RequireTests = strings.ToLower(val) == "true"
+	}
+	if val := os.Getenv("CCW_POLICY_REQUIRE_TESTS_ACTION"); val != "" {
+		config.Policy.RequireTestsAction = val
+	}
+	if val := os.Getenv("CCW_POLICY_TEST_PATTERNS"); val != "" {
+		config.Policy.TestPatterns = strings.Split(val, ",")
+	}
+
+	// Subprocess Environment Configuration
+	if val := os.Getenv("CCW_ENV_DOTENV"); val != "" {
+		config.Env.DotEnv = strings.ToLower(val) == "true"
+	}
+}
+
+// parseBranchTypeMap parses "label=prefix" pairs separated by commas
+func parseBranchTypeMap(val string) map[string]string {
+	typeMap := make(map[string]string)
+	for _, pair := range strings.Split(val, ",") {
+		parts := strings.SplitN(pair, "=", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		typeMap[strings.TrimSpace(parts[0])] = strings.TrimSpace(parts[1])
+	}
+	return typeMap
+}
+
+// parseCheckAliases parses "pattern=category" pairs separated by commas
+func parseCheckAliases(val string) []CheckAliasConfiguration {
+	var aliases []CheckAliasConfiguration
+	for _, pair := range strings.Split(val, ",") {
+		parts := strings.SplitN(pair, "=", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		aliases = append(aliases, CheckAliasConfiguration{
+			Pattern:  strings.TrimSpace(parts[0]),
+			Category: strings.TrimSpace(parts[1]),
+		})
+	}
+	return aliases
 }
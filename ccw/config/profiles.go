@@ -0,0 +1,25 @@
+package config
+
+import "sort"
+
+// GitHub account profile selection
+
+// ActiveProfile returns the selected profile; ok is false when no profile is
+// selected or the selected name is not defined
+func (c *CCWConfig) ActiveProfile() (name string, profile ProfileConfiguration, ok bool) {
+	if c.Profile == "" {
+		return "", ProfileConfiguration{}, false
+	}
+	profile, ok = c.Profiles[c.Profile]
+	return c.Profile, profile, ok
+}
+
+// ProfileNames returns the defined profile names in sorted order
+func (c *CCWConfig) ProfileNames() []string {
+	names := make([]string, 0, len(c.Profiles))
+	for name := range c.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
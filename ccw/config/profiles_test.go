@@ -0,0 +1,55 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestActiveProfile(t *testing.T) {
+	t.Setenv("CCW_PROFILE", "work")
+	config := GetDefaultCCWConfig()
+	config.Profiles = map[string]ProfileConfiguration{
+		"work":     {Host: "github.example.com"},
+		"personal": {TokenEnv: "PERSONAL_GH_TOKEN"},
+	}
+
+	if _, _, ok := config.ActiveProfile(); ok {
+		t.Error("Expected no active profile before CCW_PROFILE is applied")
+	}
+
+	loadFromEnvironment(config)
+	name, profile, ok := config.ActiveProfile()
+	if !ok || name != "work" || profile.Host != "github.example.com" {
+		t.Errorf("Expected work profile to be active, got %s %+v %v", name, profile, ok)
+	}
+	if err := config.Validate(); err != nil {
+		t.Errorf("Expected valid configuration, got %v", err)
+	}
+
+	if names := config.ProfileNames(); !reflect.DeepEqual(names, []string{"personal", "work"}) {
+		t.Errorf("Expected sorted profile names, got %v", names)
+	}
+}
+
+func TestValidateProfiles(t *testing.T) {
+	tests := []struct {
+		name     string
+		profile  string
+		profiles map[string]ProfileConfiguration
+	}{
+		{"undefined profile", "missing", map[string]ProfileConfiguration{"work": {}}},
+		{"host with scheme", "", map[string]ProfileConfiguration{"work": {Host: "https://github.example.com"}}},
+		{"host with path", "", map[string]ProfileConfiguration{"work": {Host: "github.example.com/api"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := GetDefaultCCWConfig()
+			config.Profile = tt.profile
+			config.Profiles = tt.profiles
+			if err := config.Validate(); err == nil {
+				t.Error("Expected validation error, got nil")
+			}
+		})
+	}
+}
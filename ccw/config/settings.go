@@ -0,0 +1,117 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Persisting settings changed from the interactive UI
+
+// UIThemes lists the theme names accepted by ui.theme
+var UIThemes = []string{"default", "minimal", "modern", "compact"}
+
+// ConfigFilePath returns the config file LoadConfiguration reads, or ccw.yaml
+// in the working directory when none exists yet
+func ConfigFilePath() string {
+	for _, configPath := range configSearchPaths() {
+		if _, err := os.Stat(configPath); err == nil {
+			return configPath
+		}
+	}
+	return "ccw.yaml"
+}
+
+// UpdateUIConfiguration writes ui into the ui section of the YAML file at
+// filename. Other sections, unknown keys and comments are kept; a missing file
+// is created with just the ui section.
+func UpdateUIConfiguration(filename string, ui UIConfiguration) error {
+	data, err := os.ReadFile(filename)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	updated, err := setYAMLSection(data, "ui", ui)
+	if err != nil {
+		return fmt.Errorf("failed to update config file %s: %w", filename, err)
+	}
+
+	if dir := filepath.Dir(filename); dir != "." {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("failed to create config directory: %w", err)
+		}
+	}
+	if err := os.WriteFile(filename, updated, 0644); err != nil {
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+	return nil
+}
+
+// setYAMLSection replaces the keys of the top-level mapping section in the YAML
+// document data with the encoded value, preserving everything else
+func setYAMLSection(data []byte, section string, value interface{}) ([]byte, error) {
+	var doc yaml.Node
+	if len(bytes.TrimSpace(data)) > 0 {
+		if err := yaml.Unmarshal(data, &doc); err != nil {
+			return nil, fmt.Errorf("failed to parse YAML: %w", err)
+		}
+	}
+	if len(doc.Content) == 0 {
+		doc = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode, Tag: "!!map"}}}
+	}
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("top level of config file is not a mapping")
+	}
+
+	var encoded yaml.Node
+	if err := encoded.Encode(value); err != nil {
+		return nil, fmt.Errorf("failed to encode %s section: %w", section, err)
+	}
+
+	existing := mappingValue(root, section)
+	if existing == nil || existing.Kind != yaml.MappingNode {
+		if existing != nil {
+			*existing = encoded
+		} else {
+			root.Content = append(root.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: section}, &encoded)
+		}
+	} else {
+		for i := 0; i+1 < len(encoded.Content); i += 2 {
+			key, val := encoded.Content[i], encoded.Content[i+1]
+			if current := mappingValue(existing, key.Value); current != nil {
+				// Keep the comments attached to the existing value
+				val.LineComment = current.LineComment
+				val.HeadComment = current.HeadComment
+				val.FootComment = current.FootComment
+				*current = *val
+			} else {
+				existing.Content = append(existing.Content, key, val)
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&doc); err != nil {
+		return nil, fmt.Errorf("failed to encode YAML: %w", err)
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, fmt.Errorf("failed to encode YAML: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// mappingValue returns the value node for key in a YAML mapping node, or nil
+func mappingValue(mapping *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return mapping.Content[i+1]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestUpdateUIConfigurationPreservesOtherSettings(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ccw.yaml")
+	original := `# CCW Configuration File
+max_retries: 5
+
+ui:
+  theme: "default"          # Options: default, minimal, modern, compact
+  animations: true          # Enable terminal animations
+
+git:
+  push_remote: "fork"
+`
+	if err := os.WriteFile(path, []byte(original), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	ui := GetDefaultCCWConfig().UI
+	ui.Theme = "compact"
+	ui.Animations = false
+	ui.ShowLogs = false
+	ui.ConsoleMode = true
+	if err := UpdateUIConfiguration(path, ui); err != nil {
+		t.Fatalf("UpdateUIConfiguration failed: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	content := string(data)
+	for _, expected := range []string{"# CCW Configuration File", "# Options: default, minimal, modern, compact", "push_remote: \"fork\""} {
+		if !strings.Contains(content, expected) {
+			t.Errorf("Expected '%s' to be preserved in:\n%s", expected, content)
+		}
+	}
+
+	loaded := GetDefaultCCWConfig()
+	if err := yaml.Unmarshal(data, loaded); err != nil {
+		t.Fatalf("Failed to parse updated config: %v", err)
+	}
+	if loaded.MaxRetries != 5 {
+		t.Errorf("Expected max_retries 5, got %d", loaded.MaxRetries)
+	}
+	if loaded.Git.PushRemote != "fork" {
+		t.Errorf("Expected push_remote 'fork', got '%s'", loaded.Git.PushRemote)
+	}
+	if loaded.UI != ui {
+		t.Errorf("Expected ui %+v, got %+v", ui, loaded.UI)
+	}
+}
+
+func TestUpdateUIConfigurationCreatesFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "ccw.yaml")
+
+	ui := GetDefaultCCWConfig().UI
+	ui.Theme = "minimal"
+	if err := UpdateUIConfiguration(path, ui); err != nil {
+		t.Fatalf("UpdateUIConfiguration failed: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Expected config file to be created: %v", err)
+	}
+	loaded := GetDefaultCCWConfig()
+	if err := yaml.Unmarshal(data, loaded); err != nil {
+		t.Fatalf("Failed to parse created config: %v", err)
+	}
+	if loaded.UI.Theme != "minimal" {
+		t.Errorf("Expected theme 'minimal', got '%s'", loaded.UI.Theme)
+	}
+}
+
+func TestUpdateUIConfigurationRejectsNonMapping(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ccw.yaml")
+	if err := os.WriteFile(path, []byte("- just\n- a list\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := UpdateUIConfiguration(path, UIConfiguration{}); err == nil {
+		t.Error("Expected error for a config file that is not a mapping")
+	}
+}
@@ -27,11 +27,39 @@ type CCWConfig struct {
 	// GitHub Configuration
 	GitHub GitHubConfiguration `yaml:"github" json:"github"`
 
+	// Pull Request Configuration
+	PR PRConfiguration `yaml:"pr" json:"pr"`
+
+	// CI Configuration
+	CI CIConfiguration `yaml:"ci" json:"ci"`
+
 	// Claude Configuration
 	Claude ClaudeConfiguration `yaml:"claude" json:"claude"`
 
+	// Workflow Configuration
+	Workflow WorkflowConfiguration `yaml:"workflow" json:"workflow"`
+
+	// Validation Configuration
+	Validation ValidationConfiguration `yaml:"validation" json:"validation"`
+
 	// Validation Recovery Configuration
 	ValidationRecovery ValidationRecoveryConfiguration `yaml:"validation_recovery" json:"validation_recovery"`
+
+	// Commit Configuration
+	Commit CommitConfiguration `yaml:"commit" json:"commit"`
+
+	// Change Policy Configuration
+	Policy PolicyConfiguration `yaml:"policy" json:"policy"`
+
+	// Lifecycle Hooks Configuration
+	Hooks HooksConfiguration `yaml:"hooks" json:"hooks"`
+
+	// Subprocess Environment Configuration
+	Env EnvConfiguration `yaml:"env" json:"env"`
+
+	// GitHub Account Profiles
+	Profile  string                          `yaml:"profile" json:"profile"` // Profile used when --profile is not given
+	Profiles map[string]ProfileConfiguration `yaml:"profiles" json:"profiles"`
 }
 
 // UI Configuration
@@ -42,6 +70,12 @@ type UIConfiguration struct {
 	Unicode     bool   `yaml:"unicode" json:"unicode"`
 	Width       int    `yaml:"width" json:"width"`
 	Height      int    `yaml:"height" json:"height"`
+	ShowLogs    bool   `yaml:"show_logs" json:"show_logs"`       // Show the logs panel beside the TUI
+	ConsoleMode bool   `yaml:"console_mode" json:"console_mode"` // Always use plain console output instead of the TUI
+
+	// Log entries kept for the logs panel; the oldest entry is dropped when it
+	// is full, since nothing drains the buffer while the UI runs
+	LogBufferSize int `yaml:"log_buffer_size" json:"log_buffer_size"`
 }
 
 // Git Configuration
@@ -51,6 +85,34 @@ type GitConfiguration struct {
 	RetryDelay    string `yaml:"retry_delay" json:"retry_delay"`
 	DefaultBranch string `yaml:"default_branch" json:"default_branch"`
 	RemoteName    string `yaml:"remote_name" json:"remote_name"`
+	PushRemote    string `yaml:"push_remote" json:"push_remote"` // Remote that branches are pushed to
+
+	// Branch prefix per issue label, e.g. bug: fix; the "default" key applies
+	// when no label matches. Empty keeps plain issue-N-timestamp branches.
+	BranchTypeMap map[string]string `yaml:"branch_type_map" json:"branch_type_map"`
+
+	// Issue worktrees allowed under the worktree base; 0 means no limit
+	MaxWorktrees int `yaml:"max_worktrees" json:"max_worktrees"`
+
+	// Worktree subdirectory for CCW metadata files such as issue-data.json;
+	// empty keeps the .issue-data.json dotfiles in the worktree root
+	MetadataDir string `yaml:"metadata_dir" json:"metadata_dir"`
+
+	// Free space required at the worktree base before creating a worktree,
+	// such as "2GB"; empty disables the check
+	MinFreeSpace string `yaml:"min_free_space" json:"min_free_space"`
+
+	// Extra attempts after a transient worktree creation failure, such as a
+	// file lock; permanent errors like an existing branch are not retried
+	WorktreeCreateRetries int `yaml:"worktree_create_retries" json:"worktree_create_retries"`
+
+	// Extra attempts when removing a worktree fails because its files are
+	// still open, e.g. by an editor or antivirus scanner on Windows
+	WorktreeRemoveRetries int `yaml:"worktree_remove_retries" json:"worktree_remove_retries"`
+
+	// Delete the worktree directory when removal keeps failing; when false the
+	// worktree is left in place and reported
+	WorktreeForceRemove bool `yaml:"worktree_force_remove" json:"worktree_force_remove"`
 }
 
 // Logging Configuration
@@ -81,7 +143,46 @@ type GitHubConfiguration struct {
 	PRTemplate    string   `yaml:"pr_template" json:"pr_template"`
 	IssueTemplate string   `yaml:"issue_template" json:"issue_template"`
 	DefaultLabels []string `yaml:"default_labels" json:"default_labels"`
-	AutoAssign    bool     `yaml:"auto_assign" json:"auto_assign"`
+	// Issue labels copied to the PR when present on the issue
+	CopyIssueLabels []string `yaml:"copy_issue_labels" json:"copy_issue_labels"`
+	AutoAssign      bool     `yaml:"auto_assign" json:"auto_assign"`
+	TokenFile       string   `yaml:"token_file" json:"token_file"`
+	TokenCommand    string   `yaml:"token_command" json:"token_command"`
+	GHPath          string   `yaml:"gh_path" json:"gh_path"`
+	MinGHVersion    string   `yaml:"min_gh_version" json:"min_gh_version"`
+	// Limit for each gh command talking to GitHub; 0 means no limit
+	Timeout string `yaml:"timeout" json:"timeout"`
+}
+
+// ProfileConfiguration is the GitHub account gh uses when the profile is selected.
+// Profiles without token settings fall back to the github section and environment.
+type ProfileConfiguration struct {
+	Host         string `yaml:"host" json:"host"`                   // GitHub host passed to gh as GH_HOST (empty = github.com)
+	TokenEnv     string `yaml:"token_env" json:"token_env"`         // Environment variable holding the token
+	TokenFile    string `yaml:"token_file" json:"token_file"`       // File containing the token
+	TokenCommand string `yaml:"token_command" json:"token_command"` // Command printing the token
+}
+
+// Pull Request Configuration
+type PRConfiguration struct {
+	// Delete the remote branch once the PR is found merged after CI passes
+	DeleteBranchAfterMerge bool `yaml:"delete_branch_after_merge" json:"delete_branch_after_merge"`
+
+	// How long to keep checking for the merge, e.g. by auto-merge, before
+	// keeping the branch; 0 checks once
+	MergeWait string `yaml:"merge_wait" json:"merge_wait"`
+
+	// Analyze inline review threads and reply in each addressed thread
+	ReplyToReviewThreads bool `yaml:"reply_to_review_threads" json:"reply_to_review_threads"`
+
+	// Resolve review threads after replying to them
+	ResolveReviewThreads bool `yaml:"resolve_review_threads" json:"resolve_review_threads"`
+
+	// Let base repository maintainers push to the PR branch; only applies to PRs from forks
+	MaintainerCanModify bool `yaml:"maintainer_can_modify" json:"maintainer_can_modify"`
+
+	// Append non-blocking validation warnings to the PR body
+	IncludeWarnings bool `yaml:"include_warnings" json:"include_warnings"`
 }
 
 // Claude Configuration
@@ -91,6 +192,85 @@ type ClaudeConfiguration struct {
 	Model                 string `yaml:"model" json:"model"`
 	Context               string `yaml:"context" json:"context"`
 	EnhancedCommitMessage bool   `yaml:"enhanced_commit_message" json:"enhanced_commit_message"`
+	MaxContextChars       int    `yaml:"max_context_chars" json:"max_context_chars"`
+	Prelude               string `yaml:"prelude" json:"prelude"` // Text, or @path to a file, prepended to every implementation context
+	Subdir                string `yaml:"subdir" json:"subdir"`   // Worktree-relative directory Claude and validation run in
+
+	// Claude Code tool rules passed as --allowedTools/--disallowedTools, e.g. "Bash(git diff:*)"
+	AllowedTools    []string `yaml:"allowed_tools" json:"allowed_tools"`
+	DisallowedTools []string `yaml:"disallowed_tools" json:"disallowed_tools"`
+	WorkingDirOnly  bool     `yaml:"working_dir_only" json:"working_dir_only"` // Keep Claude's file tools inside claude.subdir instead of the whole worktree
+
+	ResumeTranscript bool `yaml:"resume_transcript" json:"resume_transcript"` // Save each session to .ccw/transcript.jsonl and include it, condensed, in the next run
+
+	IdleTimeout string `yaml:"idle_timeout" json:"idle_timeout"` // Abort non-interactive Claude runs that print nothing this long, "" = disabled
+}
+
+// CI Configuration
+type CIConfiguration struct {
+	CheckAliases       []CheckAliasConfiguration `yaml:"check_aliases" json:"check_aliases"`
+	InitialDelay       string                    `yaml:"initial_delay" json:"initial_delay"`               // Wait before the first status poll so checks can register
+	InitialDelayJitter string                    `yaml:"initial_delay_jitter" json:"initial_delay_jitter"` // Random extra delay up to this duration
+	MaxMonitorDuration string                    `yaml:"max_monitor_duration" json:"max_monitor_duration"` // Give up watching CI after this long; the PR stays open
+	RetriggerStrategy  string                    `yaml:"retrigger_strategy" json:"retrigger_strategy"`     // How failed CI is re-run once: "rerun", "empty-commit" or "none"
+	FailureLogLines    int                       `yaml:"failure_log_lines" json:"failure_log_lines"`       // Trailing log lines shown per failed check; 0 skips fetching logs
+	CommentFailureLogs bool                      `yaml:"comment_failure_logs" json:"comment_failure_logs"` // Also post the failed check log excerpts as a PR comment
+}
+
+// CheckAliasConfiguration maps CI check names matching Pattern to a failure category
+type CheckAliasConfiguration struct {
+	Pattern  string `yaml:"pattern" json:"pattern"`   // Substring, or glob when it contains * ? or [
+	Category string `yaml:"category" json:"category"` // "build", "lint", "test" or "unknown"
+}
+
+// Workflow Configuration
+type WorkflowConfiguration struct {
+	MaxImplementationAttempts int               `yaml:"max_implementation_attempts" json:"max_implementation_attempts"`
+	MaxRecoveryAttempts       *int              `yaml:"max_recovery_attempts" json:"max_recovery_attempts"` // Unset = max_retries, 0 = no recovery
+	UpdateTaskList            bool              `yaml:"update_task_list" json:"update_task_list"`
+	ChangePlan                bool              `yaml:"change_plan" json:"change_plan"` // Ask Claude for a ChangePlan before implementing
+	Done                      DoneConfiguration `yaml:"done" json:"done"`
+
+	// Progress steps shown for a run, in order; empty uses the built-in steps
+	Steps []WorkflowStepConfiguration `yaml:"steps" json:"steps"`
+
+	// Final message templates printed when a run ends
+	Messages FinalMessagesConfiguration `yaml:"messages" json:"messages"`
+}
+
+// FinalMessagesConfiguration holds the templates for the message printed when
+// a run ends. Tokens such as {pr_url} and {duration} are replaced with run
+// details; empty templates keep the built-in messages.
+type FinalMessagesConfiguration struct {
+	Success string `yaml:"success" json:"success"`
+	Failure string `yaml:"failure" json:"failure"`
+}
+
+// WorkflowStepConfiguration is one progress step. Name and description default
+// to the built-in step with the same ID, or to the ID for hook phase steps.
+type WorkflowStepConfiguration struct {
+	ID          string `yaml:"id" json:"id"`
+	Name        string `yaml:"name" json:"name"`
+	Description string `yaml:"description" json:"description"`
+}
+
+// DoneConfiguration is the definition of done a run must meet once CI completes
+type DoneConfiguration struct {
+	RequireValidation       bool     `yaml:"require_validation" json:"require_validation"`
+	RequireCI               bool     `yaml:"require_ci" json:"require_ci"`
+	RequiredChecks          []string `yaml:"required_checks" json:"required_checks"`               // Check name patterns; empty requires every check
+	DetectRequiredChecks    bool     `yaml:"detect_required_checks" json:"detect_required_checks"` // Without required_checks, require the checks branch protection requires
+	MaxHighPriorityComments int      `yaml:"max_high_priority_comments" json:"max_high_priority_comments"`
+	BlockOnChangesRequested bool     `yaml:"block_on_changes_requested" json:"block_on_changes_requested"` // A "changes requested" review blocks regardless of CI
+	RebaseOnConflict        bool     `yaml:"rebase_on_conflict" json:"rebase_on_conflict"`                 // Rebase and force-push once when the PR conflicts with its base
+}
+
+// Validation Configuration
+type ValidationConfiguration struct {
+	ContainerImage string `yaml:"container_image" json:"container_image"`
+	Parallel       bool   `yaml:"parallel" json:"parallel"`   // Run lint alongside build; tests still wait for the build
+	Since          string `yaml:"since" json:"since"`         // Lint only files changed since this ref; empty lints everything
+	JUnitOut       string `yaml:"junit_out" json:"junit_out"` // Write each validation result as JUnit XML to this path
 }
 
 // Validation Recovery Configuration
@@ -104,6 +284,56 @@ type ValidationRecoveryConfiguration struct {
 	VerboseOutput         bool     `yaml:"verbose_output" json:"verbose_output"`
 }
 
+// Change Policy Configuration
+type PolicyConfiguration struct {
+	RequireTests       bool     `yaml:"require_tests" json:"require_tests"`               // Changes must add or modify at least one test file
+	RequireTestsAction string   `yaml:"require_tests_action" json:"require_tests_action"` // "block" or "warn"
+	TestPatterns       []string `yaml:"test_patterns" json:"test_patterns"`               // Extra gitignore-style test file patterns
+}
+
+// Commit Configuration
+type CommitConfiguration struct {
+	MaxFileSize     int64    `yaml:"max_file_size" json:"max_file_size"`         // Bytes, 0 disables the size check
+	LargeFileAction string   `yaml:"large_file_action" json:"large_file_action"` // "block" or "warn"
+	AuthorName      string   `yaml:"author_name" json:"author_name"`             // Empty uses git's configured identity
+	AuthorEmail     string   `yaml:"author_email" json:"author_email"`
+	ProtectedPaths  []string `yaml:"protected_paths" json:"protected_paths"` // Gitignore-style patterns requiring --allow-protected
+	BodyTemplate    string   `yaml:"body_template" json:"body_template"`     // Issue details added below the subject of generated commit messages
+	BodyLines       int      `yaml:"body_lines" json:"body_lines"`           // Issue body lines kept by {issue_body}
+
+	Lint CommitLintConfiguration `yaml:"lint" json:"lint"`
+}
+
+// CommitLintConfiguration controls checking generated commit messages before
+// committing. A failing message is rewritten by Claude once, then replaced by a
+// conforming fallback message.
+type CommitLintConfiguration struct {
+	Mode             string   `yaml:"mode" json:"mode"`                             // "off", "builtin" or "commitlint"
+	MaxSubjectLength int      `yaml:"max_subject_length" json:"max_subject_length"` // Built-in subject length limit (0 = no limit)
+	Types            []string `yaml:"types" json:"types"`                           // Built-in allowed types (empty = any type)
+}
+
+// Lifecycle Hooks Configuration
+type HooksConfiguration struct {
+	Timeout           string              `yaml:"timeout" json:"timeout"`
+	PreImplementation []HookConfiguration `yaml:"pre_implementation" json:"pre_implementation"`
+	PostValidation    []HookConfiguration `yaml:"post_validation" json:"post_validation"`
+	PrePush           []HookConfiguration `yaml:"pre_push" json:"pre_push"`
+	PostPR            []HookConfiguration `yaml:"post_pr" json:"post_pr"`
+	PostPRSmoke       string              `yaml:"post_pr_smoke" json:"post_pr_smoke"` // Command whose pass/fail and output are posted as a PR comment (empty = disabled)
+}
+
+// Subprocess Environment Configuration
+type EnvConfiguration struct {
+	DotEnv bool `yaml:"dotenv" json:"dotenv"` // Pass variables from .env in the working directory and worktree to validation and Claude
+}
+
+// HookConfiguration describes a single hook command
+type HookConfiguration struct {
+	Command         string `yaml:"command" json:"command"`
+	ContinueOnError bool   `yaml:"continue_on_error" json:"continue_on_error"`
+}
+
 // Legacy Config struct for backward compatibility
 type Config struct {
 	WorktreeBase      string                   `json:"worktree_base"`
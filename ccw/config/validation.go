@@ -2,12 +2,28 @@ package config
 
 import (
 	"fmt"
+	"net/mail"
+	"path"
+	"regexp"
 	"strings"
 	"time"
 )
 
 // Configuration validation
 
+// versionPattern matches dotted version numbers such as 2.40.1
+var versionPattern = regexp.MustCompile(`^v?\d+(\.\d+)*$`)
+
+// branchTypePattern matches branch prefixes such as fix or feat/
+var branchTypePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*/?$`)
+
+// toolRulePattern matches Claude Code tool rules such as Edit, Bash(git diff:*)
+// or mcp__github__get_issue
+var toolRulePattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]*(\([^,()]*\))?$`)
+
+// validCheckCategories are the CI failure categories a check alias may assign
+var validCheckCategories = map[string]bool{"build": true, "lint": true, "test": true, "unknown": true}
+
 // Validate configuration values
 func (c *CCWConfig) Validate() error {
 	// Validate timeout formats
@@ -31,6 +47,32 @@ func (c *CCWConfig) Validate() error {
 	if c.Git.RetryAttempts < 0 || c.Git.RetryAttempts > 10 {
 		return fmt.Errorf("git.retry_attempts must be between 0 and 10")
 	}
+	if c.Git.PushRemote == "" || strings.ContainsAny(c.Git.PushRemote, " \t/:") {
+		return fmt.Errorf("git.push_remote must be a remote name such as \"origin\", got %q", c.Git.PushRemote)
+	}
+	for label, prefix := range c.Git.BranchTypeMap {
+		if prefix != "" && (!branchTypePattern.MatchString(prefix) || strings.Contains(prefix, "..")) {
+			return fmt.Errorf("git.branch_type_map.%s must be a branch prefix such as \"fix\" or \"fix/\", got %q", label, prefix)
+		}
+	}
+	if c.Git.MaxWorktrees < 0 {
+		return fmt.Errorf("git.max_worktrees must not be negative")
+	}
+	if dir := c.Git.MetadataDir; dir != "" {
+		clean := path.Clean(dir)
+		if path.IsAbs(dir) || clean == "." || clean == ".." || strings.HasPrefix(clean, "../") {
+			return fmt.Errorf("git.metadata_dir must be a directory inside the worktree: %s", dir)
+		}
+	}
+	if _, err := ParseByteSize(c.Git.MinFreeSpace); err != nil {
+		return fmt.Errorf("git.min_free_space: %w", err)
+	}
+	if c.Git.WorktreeCreateRetries < 0 {
+		return fmt.Errorf("git.worktree_create_retries must not be negative")
+	}
+	if c.Git.WorktreeRemoveRetries < 0 {
+		return fmt.Errorf("git.worktree_remove_retries must not be negative")
+	}
 	if c.Performance.Level < 0 || c.Performance.Level > 2 {
 		return fmt.Errorf("performance.level must be between 0 and 2")
 	}
@@ -38,6 +80,19 @@ func (c *CCWConfig) Validate() error {
 		return fmt.Errorf("performance.change_detection_sensitivity must be between 0.0 and 1.0")
 	}
 
+	if c.PR.MergeWait != "" {
+		mergeWait, err := time.ParseDuration(c.PR.MergeWait)
+		if err != nil {
+			return fmt.Errorf("invalid pr.merge_wait format: %w", err)
+		}
+		if mergeWait < 0 {
+			return fmt.Errorf("pr.merge_wait must not be negative")
+		}
+	}
+	if c.UI.LogBufferSize < 0 {
+		return fmt.Errorf("ui.log_buffer_size must not be negative")
+	}
+
 	// Validate log level
 	validLogLevels := []string{"debug", "info", "warn", "error"}
 	valid := false
@@ -56,5 +111,206 @@ func (c *CCWConfig) Validate() error {
 		return fmt.Errorf("logging.format must be 'text' or 'json'")
 	}
 
+	// Validate GitHub CLI settings
+	if c.GitHub.MinGHVersion != "" && !versionPattern.MatchString(c.GitHub.MinGHVersion) {
+		return fmt.Errorf("github.min_gh_version must be a dotted version like 2.40.0: %s", c.GitHub.MinGHVersion)
+	}
+	if c.GitHub.Timeout != "" {
+		timeout, err := time.ParseDuration(c.GitHub.Timeout)
+		if err != nil {
+			return fmt.Errorf("invalid github.timeout format: %w", err)
+		}
+		if timeout < 0 {
+			return fmt.Errorf("github.timeout must not be negative")
+		}
+	}
+
+	// Validate account profiles
+	for name, profile := range c.Profiles {
+		if strings.TrimSpace(name) == "" {
+			return fmt.Errorf("profiles entries require a name")
+		}
+		if strings.Contains(profile.Host, "://") || strings.ContainsAny(profile.Host, "/ \t") {
+			return fmt.Errorf("profiles.%s.host must be a bare hostname like github.example.com: %q", name, profile.Host)
+		}
+	}
+	if c.Profile != "" {
+		if _, ok := c.Profiles[c.Profile]; !ok {
+			return fmt.Errorf("profile %q is not defined under profiles", c.Profile)
+		}
+	}
+
+	if c.Claude.MaxContextChars < 0 {
+		return fmt.Errorf("claude.max_context_chars must not be negative")
+	}
+	if c.Claude.IdleTimeout != "" {
+		idle, err := time.ParseDuration(c.Claude.IdleTimeout)
+		if err != nil {
+			return fmt.Errorf("invalid claude.idle_timeout format: %w", err)
+		}
+		if idle < 0 {
+			return fmt.Errorf("claude.idle_timeout must not be negative")
+		}
+	}
+	if strings.TrimSpace(c.Claude.Prelude) == "@" {
+		return fmt.Errorf("claude.prelude file reference must name a file after @")
+	}
+	if subdir := c.Claude.Subdir; subdir != "" {
+		clean := path.Clean(subdir)
+		if path.IsAbs(subdir) || clean == "." || clean == ".." || strings.HasPrefix(clean, "../") {
+			return fmt.Errorf("claude.subdir must be a directory inside the worktree: %s", subdir)
+		}
+	}
+	for field, rules := range map[string][]string{"allowed_tools": c.Claude.AllowedTools, "disallowed_tools": c.Claude.DisallowedTools} {
+		for _, rule := range rules {
+			if rule = strings.TrimSpace(rule); rule != "" && !toolRulePattern.MatchString(rule) {
+				return fmt.Errorf("claude.%s entries must be tool rules such as \"Edit\" or \"Bash(git diff:*)\": %q", field, rule)
+			}
+		}
+	}
+
+	// Validate workflow attempt limits
+	if c.Workflow.MaxImplementationAttempts < 1 || c.Workflow.MaxImplementationAttempts > 10 {
+		return fmt.Errorf("workflow.max_implementation_attempts must be between 1 and 10")
+	}
+	if attempts := c.Workflow.MaxRecoveryAttempts; attempts != nil && (*attempts < 0 || *attempts > 10) {
+		return fmt.Errorf("workflow.max_recovery_attempts must be between 0 and 10")
+	}
+	if c.Workflow.Done.MaxHighPriorityComments < 0 {
+		return fmt.Errorf("workflow.done.max_high_priority_comments must not be negative")
+	}
+	for _, pattern := range c.Workflow.Done.RequiredChecks {
+		if _, err := path.Match(strings.ToLower(pattern), ""); err != nil {
+			return fmt.Errorf("workflow.done.required_checks pattern %q is invalid: %w", pattern, err)
+		}
+	}
+	if err := validateWorkflowSteps(c.Workflow.Steps); err != nil {
+		return err
+	}
+
+	// Validate CI settings
+	for _, alias := range c.CI.CheckAliases {
+		if strings.TrimSpace(alias.Pattern) == "" {
+			return fmt.Errorf("ci.check_aliases entries require a pattern")
+		}
+		if _, err := path.Match(strings.ToLower(alias.Pattern), ""); err != nil {
+			return fmt.Errorf("ci.check_aliases pattern %q is invalid: %w", alias.Pattern, err)
+		}
+		if !validCheckCategories[alias.Category] {
+			return fmt.Errorf("ci.check_aliases category for %q must be one of build, lint, test, unknown: %q", alias.Pattern, alias.Category)
+		}
+	}
+	if c.CI.InitialDelay != "" {
+		if delay, err := time.ParseDuration(c.CI.InitialDelay); err != nil || delay < 0 {
+			return fmt.Errorf("ci.initial_delay must be a non-negative duration: %q", c.CI.InitialDelay)
+		}
+	}
+	if c.CI.InitialDelayJitter != "" {
+		if jitter, err := time.ParseDuration(c.CI.InitialDelayJitter); err != nil || jitter < 0 {
+			return fmt.Errorf("ci.initial_delay_jitter must be a non-negative duration: %q", c.CI.InitialDelayJitter)
+		}
+	}
+	if c.CI.MaxMonitorDuration != "" {
+		if maxDuration, err := time.ParseDuration(c.CI.MaxMonitorDuration); err != nil || maxDuration <= 0 {
+			return fmt.Errorf("ci.max_monitor_duration must be a positive duration: %q", c.CI.MaxMonitorDuration)
+		}
+	}
+	switch c.CI.RetriggerStrategy {
+	case "", "rerun", "empty-commit", "none":
+	default:
+		return fmt.Errorf("ci.retrigger_strategy must be one of rerun, empty-commit, none: %q", c.CI.RetriggerStrategy)
+	}
+	if c.CI.FailureLogLines < 0 {
+		return fmt.Errorf("ci.failure_log_lines must be 0 or more: %d", c.CI.FailureLogLines)
+	}
+
+	// Validate validation settings
+	if strings.ContainsAny(c.Validation.ContainerImage, " \t\n") {
+		return fmt.Errorf("validation.container_image must be a single image reference: %q", c.Validation.ContainerImage)
+	}
+	if strings.ContainsAny(c.Validation.Since, " \t\n") || strings.HasPrefix(c.Validation.Since, "-") {
+		return fmt.Errorf("validation.since must be a single git ref: %q", c.Validation.Since)
+	}
+
+	// Validate commit settings
+	if c.Commit.MaxFileSize < 0 {
+		return fmt.Errorf("commit.max_file_size must not be negative")
+	}
+	if c.Commit.LargeFileAction != "block" && c.Commit.LargeFileAction != "warn" {
+		return fmt.Errorf("commit.large_file_action must be 'block' or 'warn'")
+	}
+	if (c.Commit.AuthorName == "") != (c.Commit.AuthorEmail == "") {
+		return fmt.Errorf("commit.author_name and commit.author_email must be set together")
+	}
+	if c.Commit.AuthorEmail != "" {
+		if addr, err := mail.ParseAddress(c.Commit.AuthorEmail); err != nil || addr.Address != c.Commit.AuthorEmail {
+			return fmt.Errorf("commit.author_email is not a valid email address: %s", c.Commit.AuthorEmail)
+		}
+	}
+	if c.Commit.BodyLines < 0 {
+		return fmt.Errorf("commit.body_lines must not be negative")
+	}
+	for _, pattern := range c.Commit.ProtectedPaths {
+		if err := validatePathPattern(pattern); err != nil {
+			return fmt.Errorf("commit.protected_paths contains an invalid pattern %q: %w", pattern, err)
+		}
+	}
+
+	if c.PR.ResolveReviewThreads && !c.PR.ReplyToReviewThreads {
+		return fmt.Errorf("pr.resolve_review_threads requires pr.reply_to_review_threads")
+	}
+
+	// Validate change policy
+	if c.Policy.RequireTestsAction != "block" && c.Policy.RequireTestsAction != "warn" {
+		return fmt.Errorf("policy.require_tests_action must be 'block' or 'warn'")
+	}
+	for _, pattern := range c.Policy.TestPatterns {
+		if err := validatePathPattern(pattern); err != nil {
+			return fmt.Errorf("policy.test_patterns contains an invalid pattern %q: %w", pattern, err)
+		}
+	}
+	switch c.Commit.Lint.Mode {
+	case "", "off", "builtin", "commitlint":
+	default:
+		return fmt.Errorf("commit.lint.mode must be 'off', 'builtin' or 'commitlint'")
+	}
+	if c.Commit.Lint.MaxSubjectLength < 0 {
+		return fmt.Errorf("commit.lint.max_subject_length must not be negative")
+	}
+
+	// Validate hooks
+	if c.Hooks.Timeout != "" {
+		if _, err := time.ParseDuration(c.Hooks.Timeout); err != nil {
+			return fmt.Errorf("invalid hooks.timeout format: %w", err)
+		}
+	}
+	hookPhases := map[string][]HookConfiguration{
+		"pre_implementation": c.Hooks.PreImplementation,
+		"post_validation":    c.Hooks.PostValidation,
+		"pre_push":           c.Hooks.PrePush,
+		"post_pr":            c.Hooks.PostPR,
+	}
+	for phase, hooks := range hookPhases {
+		for i, hook := range hooks {
+			if strings.TrimSpace(hook.Command) == "" {
+				return fmt.Errorf("hooks.%s[%d].command must not be empty", phase, i)
+			}
+		}
+	}
+
+	return nil
+}
+
+// validatePathPattern checks each segment of a gitignore-style pattern for glob syntax errors
+func validatePathPattern(pattern string) error {
+	pattern = strings.TrimPrefix(strings.TrimSpace(pattern), "!")
+	if pattern == "" {
+		return fmt.Errorf("pattern is empty")
+	}
+	for _, segment := range strings.Split(pattern, "/") {
+		if _, err := path.Match(segment, ""); err != nil {
+			return err
+		}
+	}
 	return nil
 }
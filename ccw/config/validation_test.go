@@ -0,0 +1,109 @@
+package config
+
+import "testing"
+
+func TestValidateClaudeSubdir(t *testing.T) {
+	tests := []struct {
+		subdir  string
+		wantErr bool
+	}{
+		{"", false},
+		{"packages/parser", false},
+		{"..config", false},
+		{"/abs/path", true},
+		{".", true},
+		{"..", true},
+		{"../sibling", true},
+		{"packages/../../escape", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.subdir, func(t *testing.T) {
+			config := GetDefaultCCWConfig()
+			config.Claude.Subdir = tt.subdir
+			if err := config.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Expected error %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestValidateClaudeToolRules(t *testing.T) {
+	tests := []struct {
+		rule    string
+		wantErr bool
+	}{
+		{"Edit", false},
+		{"Bash(git diff:*)", false},
+		{"mcp__github__get_issue", false},
+		{"WebFetch(domain:github.com)", false},
+		{"", false},
+		{"Bash(a,b)", true},
+		{"rm -rf", true},
+		{"Bash(", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.rule, func(t *testing.T) {
+			config := GetDefaultCCWConfig()
+			config.Claude.DisallowedTools = []string{tt.rule}
+			if err := config.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Expected error %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestValidateClaudeIdleTimeout(t *testing.T) {
+	tests := []struct {
+		idleTimeout string
+		wantErr     bool
+	}{
+		{"", false},
+		{"0s", false},
+		{"3m", false},
+		{"soon", true},
+		{"-1m", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.idleTimeout, func(t *testing.T) {
+			config := GetDefaultCCWConfig()
+			config.Claude.IdleTimeout = tt.idleTimeout
+			if err := config.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Expected error %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestValidateUILogBufferSize(t *testing.T) {
+	config := GetDefaultCCWConfig()
+	config.UI.LogBufferSize = -1
+	if err := config.Validate(); err == nil {
+		t.Error("Expected a negative ui.log_buffer_size to be refused")
+	}
+}
+
+func TestValidatePRMergeWait(t *testing.T) {
+	tests := []struct {
+		mergeWait string
+		wantErr   bool
+	}{
+		{"", false},
+		{"0s", false},
+		{"5m", false},
+		{"later", true},
+		{"-1m", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.mergeWait, func(t *testing.T) {
+			config := GetDefaultCCWConfig()
+			config.PR.MergeWait = tt.mergeWait
+			if err := config.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Expected error %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
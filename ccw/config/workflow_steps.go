@@ -0,0 +1,53 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// WorkflowStepIDs are the step IDs the workflow reports progress for: the
+// built-in steps, push, and one step per lifecycle hook phase
+var WorkflowStepIDs = []string{
+	"setup",
+	"fetch",
+	"analysis",
+	"pre_implementation",
+	"implementation",
+	"validation",
+	"post_validation",
+	"commit",
+	"pre_push",
+	"push",
+	"pr_generation",
+	"pr_creation",
+	"post_pr",
+	"complete",
+}
+
+// validateWorkflowSteps checks that each configured step has a known ID and
+// appears at most once
+func validateWorkflowSteps(steps []WorkflowStepConfiguration) error {
+	seen := make(map[string]bool, len(steps))
+	for i, step := range steps {
+		if step.ID == "" {
+			return fmt.Errorf("workflow.steps[%d] has no id", i)
+		}
+		if !isWorkflowStepID(step.ID) {
+			return fmt.Errorf("workflow.steps[%d] has unknown id %q; must be one of %s", i, step.ID, strings.Join(WorkflowStepIDs, ", "))
+		}
+		if seen[step.ID] {
+			return fmt.Errorf("workflow.steps lists %q more than once", step.ID)
+		}
+		seen[step.ID] = true
+	}
+	return nil
+}
+
+func isWorkflowStepID(id string) bool {
+	for _, known := range WorkflowStepIDs {
+		if id == known {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,85 @@
+package config
+
+import (
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestLoadWorkflowSteps(t *testing.T) {
+	data := `
+workflow:
+  steps:
+    - id: setup
+    - id: pre_implementation
+      name: Pre-implementation hooks
+    - id: implementation
+      name: Claude
+      description: Implementing the issue
+    - id: complete
+`
+	config := GetDefaultCCWConfig()
+	if err := yaml.Unmarshal([]byte(data), config); err != nil {
+		t.Fatalf("Failed to parse YAML: %v", err)
+	}
+	if err := config.Validate(); err != nil {
+		t.Fatalf("Expected valid configuration, got %v", err)
+	}
+
+	steps := config.Workflow.Steps
+	if len(steps) != 4 {
+		t.Fatalf("Expected 4 steps, got %d", len(steps))
+	}
+	expected := []WorkflowStepConfiguration{
+		{ID: "setup"},
+		{ID: "pre_implementation", Name: "Pre-implementation hooks"},
+		{ID: "implementation", Name: "Claude", Description: "Implementing the issue"},
+		{ID: "complete"},
+	}
+	for i := range expected {
+		if steps[i] != expected[i] {
+			t.Errorf("Step %d: expected %+v, got %+v", i, expected[i], steps[i])
+		}
+	}
+}
+
+func TestValidateWorkflowSteps(t *testing.T) {
+	tests := []struct {
+		name        string
+		steps       []WorkflowStepConfiguration
+		expectedErr string
+	}{
+		{"no steps", nil, ""},
+		{"every known step", stepsFor(WorkflowStepIDs...), ""},
+		{"missing id", []WorkflowStepConfiguration{{Name: "Setup"}}, "workflow.steps[0] has no id"},
+		{"unknown id", stepsFor("setup", "deploy"), `workflow.steps[1] has unknown id "deploy"`},
+		{"substep id", stepsFor("lint"), `unknown id "lint"`},
+		{"duplicate id", stepsFor("setup", "commit", "setup"), `lists "setup" more than once`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := GetDefaultCCWConfig()
+			config.Workflow.Steps = tt.steps
+			err := config.Validate()
+			if tt.expectedErr == "" {
+				if err != nil {
+					t.Errorf("Expected no error, got %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.expectedErr) {
+				t.Errorf("Expected error containing '%s', got %v", tt.expectedErr, err)
+			}
+		})
+	}
+}
+
+func stepsFor(ids ...string) []WorkflowStepConfiguration {
+	steps := make([]WorkflowStepConfiguration, len(ids))
+	for i, id := range ids {
+		steps[i] = WorkflowStepConfiguration{ID: id}
+	}
+	return steps
+}
@@ -0,0 +1,34 @@
+// Package consoleui decides between emoji and plain text console characters
+package consoleui
+
+import (
+	"os"
+	"strings"
+)
+
+// NoEmojiEnv forces plain console characters when "true"; --no-emoji sets it
+const NoEmojiEnv = "CCW_NO_EMOJI"
+
+// ConsoleMode reports whether output targets a plain console: console mode
+// was forced with CCW_CONSOLE_MODE or ccw runs on a CI system
+func ConsoleMode() bool {
+	return os.Getenv("CCW_CONSOLE_MODE") == "true" ||
+		os.Getenv("CI") == "true" ||
+		os.Getenv("GITHUB_ACTIONS") == "true" ||
+		os.Getenv("GITLAB_CI") == "true" ||
+		os.Getenv("JENKINS_URL") != ""
+}
+
+// NoEmoji reports whether emoji were turned off with --no-emoji or CCW_NO_EMOJI
+func NoEmoji() bool {
+	return strings.ToLower(os.Getenv(NoEmojiEnv)) == "true"
+}
+
+// Char returns fancy, or simple when emoji are turned off or output targets a
+// plain console
+func Char(fancy, simple string) string {
+	if NoEmoji() || ConsoleMode() {
+		return simple
+	}
+	return fancy
+}
@@ -0,0 +1,60 @@
+package consoleui
+
+import "testing"
+
+// clearEnv unsets every variable that selects plain console characters
+func clearEnv(t *testing.T) {
+	t.Helper()
+	for _, key := range []string{NoEmojiEnv, "CCW_CONSOLE_MODE", "CI", "GITHUB_ACTIONS", "GITLAB_CI", "JENKINS_URL"} {
+		t.Setenv(key, "")
+	}
+}
+
+func TestChar(t *testing.T) {
+	tests := []struct {
+		name     string
+		key      string
+		value    string
+		expected string
+	}{
+		{"interactive terminal", "", "", "✅"},
+		{"no emoji flag", NoEmojiEnv, "true", "[OK]"},
+		{"no emoji uppercase", NoEmojiEnv, "TRUE", "[OK]"},
+		{"no emoji false", NoEmojiEnv, "false", "✅"},
+		{"console mode", "CCW_CONSOLE_MODE", "true", "[OK]"},
+		{"generic CI", "CI", "true", "[OK]"},
+		{"GitHub Actions", "GITHUB_ACTIONS", "true", "[OK]"},
+		{"GitLab CI", "GITLAB_CI", "true", "[OK]"},
+		{"Jenkins", "JENKINS_URL", "https://jenkins.example.com", "[OK]"},
+		{"CI false", "CI", "false", "✅"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clearEnv(t)
+			if tt.key != "" {
+				t.Setenv(tt.key, tt.value)
+			}
+			if result := Char("✅", "[OK]"); result != tt.expected {
+				t.Errorf("Expected '%s', got '%s'", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestNoEmojiIsIndependentOfConsoleMode(t *testing.T) {
+	clearEnv(t)
+	t.Setenv("CI", "true")
+	if NoEmoji() {
+		t.Error("Expected CI alone not to count as --no-emoji")
+	}
+	if !ConsoleMode() {
+		t.Error("Expected CI to select console mode")
+	}
+
+	clearEnv(t)
+	t.Setenv(NoEmojiEnv, "true")
+	if ConsoleMode() {
+		t.Error("Expected --no-emoji not to force console mode")
+	}
+}
@@ -0,0 +1,19 @@
+package git
+
+import "fmt"
+
+// emptyCommitArgs builds git arguments for a commit that records no changes
+func (g *Operations) emptyCommitArgs(commitMessage string) []string {
+	return append(g.commitArgs(commitMessage), "--allow-empty")
+}
+
+// CommitEmpty creates a commit with no changes on the worktree's branch, so
+// pushing it re-triggers CI. Changes already staged would be included, so it
+// is meant for a clean worktree.
+func (g *Operations) CommitEmpty(worktreePath, commitMessage string) error {
+	cmd := CreateGitCommand(g.emptyCommitArgs(commitMessage), worktreePath)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to create empty commit: %w\nOutput: %s", err, string(output))
+	}
+	return nil
+}
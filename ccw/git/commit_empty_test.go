@@ -0,0 +1,40 @@
+package git
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestEmptyCommitArgs(t *testing.T) {
+	ops := NewOperations(".", &GitOperationConfig{AuthorName: "bot", AuthorEmail: "bot@example.com"}, nil)
+	args := ops.emptyCommitArgs("chore: re-trigger CI")
+	expected := []string{"-c", "user.name=bot", "-c", "user.email=bot@example.com", "commit", "-m", "chore: re-trigger CI", "--allow-empty"}
+	if strings.Join(args, "|") != strings.Join(expected, "|") {
+		t.Errorf("Expected %q, got %q", expected, args)
+	}
+
+	args = NewOperations(".", nil, nil).emptyCommitArgs("chore: re-trigger CI")
+	expected = []string{"commit", "-m", "chore: re-trigger CI", "--allow-empty"}
+	if strings.Join(args, "|") != strings.Join(expected, "|") {
+		t.Errorf("Expected %q, got %q", expected, args)
+	}
+}
+
+func TestCommitEmpty(t *testing.T) {
+	tmpDir, runGit := initTestRepo(t)
+	writeTestFile(t, tmpDir, "a.txt", []byte("a"))
+	runGit("add", ".")
+	runGit("commit", "-q", "-m", "initial")
+
+	ops := NewOperations(tmpDir, nil, nil)
+	if err := ops.CommitEmpty(tmpDir, "chore: re-trigger CI"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if subject := runGit("log", "-1", "--format=%s"); subject != "chore: re-trigger CI" {
+		t.Errorf("Expected 'chore: re-trigger CI', got '%s'", subject)
+	}
+	if files := runGit("show", "--name-only", "--format=", "HEAD"); files != "" {
+		t.Errorf("Expected an empty commit, got files '%s'", files)
+	}
+}
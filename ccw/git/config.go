@@ -12,6 +12,9 @@ func GetDefaultGitConfig() *GitOperationConfig {
 		Timeout:       30 * time.Second, // 30 second timeout for git operations
 		RetryAttempts: 3,                // Retry failed operations up to 3 times
 		RetryDelay:    2 * time.Second,  // Wait 2 seconds between retries
+
+		WorktreeCreateRetries: DefaultWorktreeCreateRetries,
+		WorktreeRemoveRetries: DefaultWorktreeRemoveRetries,
 	}
 }
 
@@ -52,6 +55,27 @@ func (g *Operations) GetRetryDelay() time.Duration {
 	return 2 * time.Second // default retry delay
 }
 
+// commitArgs builds git commit arguments using the configured author identity
+func (g *Operations) commitArgs(commitMessage string) []string {
+	if g.config == nil {
+		return buildCommitArgs(commitMessage, "", "")
+	}
+	return buildCommitArgs(commitMessage, g.config.AuthorName, g.config.AuthorEmail)
+}
+
+// buildCommitArgs returns git arguments for a commit, prefixing -c overrides so the
+// identity applies to both author and committer. Trailers in the message are untouched.
+func buildCommitArgs(commitMessage, authorName, authorEmail string) []string {
+	var args []string
+	if authorName != "" {
+		args = append(args, "-c", "user.name="+authorName)
+	}
+	if authorEmail != "" {
+		args = append(args, "-c", "user.email="+authorEmail)
+	}
+	return append(args, "commit", "-m", commitMessage)
+}
+
 // NewQualityValidator creates a new quality validator
 func NewQualityValidator() *QualityValidator {
 	return &QualityValidator{
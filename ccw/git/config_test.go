@@ -0,0 +1,62 @@
+package git
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestBuildCommitArgs(t *testing.T) {
+	message := "feat: add parser\n\nResolves #1\n\nCo-Authored-By: Claude <noreply@anthropic.com>"
+
+	tests := []struct {
+		name        string
+		authorName  string
+		authorEmail string
+		expected    []string
+	}{
+		{
+			name:     "no identity override",
+			expected: []string{"commit", "-m", message},
+		},
+		{
+			name:        "name and email",
+			authorName:  "ccw-bot",
+			authorEmail: "ccw-bot@example.com",
+			expected:    []string{"-c", "user.name=ccw-bot", "-c", "user.email=ccw-bot@example.com", "commit", "-m", message},
+		},
+		{
+			name:        "name with spaces",
+			authorName:  "CCW Automation",
+			authorEmail: "bot@example.com",
+			expected:    []string{"-c", "user.name=CCW Automation", "-c", "user.email=bot@example.com", "commit", "-m", message},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args := buildCommitArgs(message, tt.authorName, tt.authorEmail)
+			if strings.Join(args, "|") != strings.Join(tt.expected, "|") {
+				t.Errorf("Expected %q, got %q", tt.expected, args)
+			}
+			// The message, including its Co-Authored-By trailer, is passed through verbatim
+			if args[len(args)-1] != message {
+				t.Errorf("Expected commit message to be preserved, got '%s'", args[len(args)-1])
+			}
+		})
+	}
+}
+
+func TestOperationsCommitArgsUsesConfig(t *testing.T) {
+	ops := NewOperations(".", &GitOperationConfig{AuthorName: "bot", AuthorEmail: "bot@example.com"}, nil)
+	args := ops.commitArgs("chore: test")
+	expected := []string{"-c", "user.name=bot", "-c", "user.email=bot@example.com", "commit", "-m", "chore: test"}
+	if strings.Join(args, "|") != strings.Join(expected, "|") {
+		t.Errorf("Expected %q, got %q", expected, args)
+	}
+
+	ops = NewOperations(".", nil, nil)
+	args = ops.commitArgs("chore: test")
+	if args[0] != "commit" {
+		t.Errorf("Expected default config to produce plain commit args, got %q", args)
+	}
+}
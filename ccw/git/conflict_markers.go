@@ -0,0 +1,108 @@
+package git
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Conflict marker detection for changes left mid-merge or mid-rebase
+
+// ConflictMarker is an unresolved conflict block in a changed file
+type ConflictMarker struct {
+	Path string `json:"path"`
+	Line int    `json:"line"` // Line of the opening <<<<<<< marker
+}
+
+// String returns a human-readable description of the conflict
+func (c ConflictMarker) String() string {
+	return fmt.Sprintf("%s:%d", c.Path, c.Line)
+}
+
+// DetectConflictMarkers scans every added or modified text file in the
+// worktree for unresolved conflict blocks, sorted by path and line
+func (g *Operations) DetectConflictMarkers(worktreePath string) ([]ConflictMarker, error) {
+	sizes, err := g.ChangedFileSizes(worktreePath)
+	if err != nil {
+		return nil, err
+	}
+
+	var markers []ConflictMarker
+	for path := range sizes {
+		fullPath := filepath.Join(worktreePath, path)
+		if binary, err := isBinaryFile(fullPath); err != nil || binary {
+			continue
+		}
+		content, err := os.ReadFile(fullPath)
+		if err != nil {
+			continue
+		}
+		for _, line := range FindConflictMarkers(path, content) {
+			markers = append(markers, ConflictMarker{Path: path, Line: line})
+		}
+	}
+
+	sort.Slice(markers, func(i, j int) bool {
+		if markers[i].Path != markers[j].Path {
+			return markers[i].Path < markers[j].Path
+		}
+		return markers[i].Line < markers[j].Line
+	})
+	return markers, nil
+}
+
+// FindConflictMarkers returns the line numbers of conflict blocks in content.
+// To avoid false positives only complete blocks count: "<<<<<<<", then
+// "=======", then ">>>>>>>", each at the start of a line. Indented or quoted
+// markers (e.g. in test fixtures) and markers inside fenced code blocks of
+// markdown files are ignored, as are lone "=======" heading underlines.
+func FindConflictMarkers(path string, content []byte) []int {
+	markdown := isMarkdownFile(path)
+
+	var lines []int
+	open, separated := 0, false
+	inFence := false
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimRight(scanner.Text(), "\r")
+
+		if markdown && strings.HasPrefix(strings.TrimSpace(line), "```") {
+			inFence = !inFence
+			open, separated = 0, false
+			continue
+		}
+		if inFence {
+			continue
+		}
+
+		switch {
+		case isConflictMarker(line, "<<<<<<<"):
+			open, separated = n, false
+		case open > 0 && line == "=======":
+			separated = true
+		case open > 0 && separated && isConflictMarker(line, ">>>>>>>"):
+			lines = append(lines, open)
+			open, separated = 0, false
+		}
+	}
+	return lines
+}
+
+// isConflictMarker reports whether line is marker alone or followed by a
+// space and a label, as git writes them
+func isConflictMarker(line, marker string) bool {
+	return line == marker || strings.HasPrefix(line, marker+" ")
+}
+
+func isMarkdownFile(path string) bool {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".md", ".markdown", ".mdx":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,128 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestFindConflictMarkers(t *testing.T) {
+	tests := []struct {
+		name     string
+		path     string
+		content  string
+		expected []int
+	}{
+		{
+			"merge conflict",
+			"Sources/main.swift",
+			"let a = 1\n<<<<<<< HEAD\nlet b = 2\n=======\nlet b = 3\n>>>>>>> feature\n",
+			[]int{2},
+		},
+		{
+			"diff3 conflict with base section",
+			"main.go",
+			"<<<<<<< ours\na\n||||||| base\nb\n=======\nc\n>>>>>>> theirs\n",
+			[]int{1},
+		},
+		{
+			"bare markers and CRLF line endings",
+			"notes.txt",
+			"x\r\n<<<<<<<\r\ny\r\n=======\r\nz\r\n>>>>>>>\r\n",
+			[]int{2},
+		},
+		{
+			"several conflicts",
+			"a.swift",
+			"<<<<<<< HEAD\n1\n=======\n2\n>>>>>>> b\nok\n<<<<<<< HEAD\n3\n=======\n4\n>>>>>>> b\n",
+			[]int{1, 7},
+		},
+		{
+			"markers inside a string literal",
+			"ConflictTests.swift",
+			"let sample = \"\"\"\n    <<<<<<< HEAD\n    =======\n    >>>>>>> branch\n    \"\"\"\n",
+			nil,
+		},
+		{
+			"quoted markers",
+			"parser.go",
+			"if strings.HasPrefix(line, \"<<<<<<< \") {\n=======\n\">>>>>>> \"\n",
+			nil,
+		},
+		{
+			"heading underline without opening marker",
+			"README.txt",
+			"Title\n=======\n\nText\n",
+			nil,
+		},
+		{
+			"unterminated block",
+			"a.txt",
+			"<<<<<<< HEAD\nours\n=======\ntheirs\n",
+			nil,
+		},
+		{
+			"longer marker runs",
+			"a.txt",
+			"<<<<<<<< x\n=======\n>>>>>>>>\n",
+			nil,
+		},
+		{
+			"documented example in markdown fence",
+			"docs/merging.md",
+			"Resolve conflicts like:\n\n```\n<<<<<<< HEAD\nmine\n=======\ntheirs\n>>>>>>> branch\n```\n",
+			nil,
+		},
+		{
+			"real conflict in markdown outside fences",
+			"README.md",
+			"# Title\n<<<<<<< HEAD\nmine\n=======\ntheirs\n>>>>>>> branch\n",
+			[]int{2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lines := FindConflictMarkers(tt.path, []byte(tt.content))
+			if !reflect.DeepEqual(lines, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, lines)
+			}
+		})
+	}
+}
+
+func TestDetectConflictMarkers(t *testing.T) {
+	tmpDir, runGit := initTestRepo(t)
+	writeTestFile(t, tmpDir, "clean.txt", []byte("one\n"))
+	writeTestFile(t, tmpDir, "committed.txt", []byte("<<<<<<< a\n=======\n>>>>>>> b\n"))
+	runGit("add", ".")
+	runGit("commit", "-q", "-m", "initial")
+
+	conflict := []byte("<<<<<<< HEAD\nmine\n=======\ntheirs\n>>>>>>> branch\n")
+	writeTestFile(t, tmpDir, "clean.txt", []byte("one\ntwo\n"))
+	writeTestFile(t, tmpDir, "src/merged.swift", append([]byte("import Foundation\n"), conflict...))
+	writeTestFile(t, tmpDir, "binary.dat", append([]byte{0x00}, conflict...))
+
+	ops := NewOperations(tmpDir, nil, nil)
+	markers, err := ops.DetectConflictMarkers(tmpDir)
+	if err != nil {
+		t.Fatalf("DetectConflictMarkers failed: %v", err)
+	}
+
+	// Unchanged files and binary files are not scanned
+	expected := []ConflictMarker{{Path: "src/merged.swift", Line: 2}}
+	if !reflect.DeepEqual(markers, expected) {
+		t.Fatalf("Expected %v, got %v", expected, markers)
+	}
+	if markers[0].String() != "src/merged.swift:2" {
+		t.Errorf("Expected 'src/merged.swift:2', got '%s'", markers[0].String())
+	}
+
+	if err := os.Remove(filepath.Join(tmpDir, "src", "merged.swift")); err != nil {
+		t.Fatal(err)
+	}
+	if markers, err := ops.DetectConflictMarkers(tmpDir); err != nil || len(markers) != 0 {
+		t.Errorf("Expected no markers once the file is removed, got %v (%v)", markers, err)
+	}
+}
@@ -0,0 +1,125 @@
+package git
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Worktree diffs against a base revision
+
+// diffBaseCandidates lists the refs tried, in order, when no diff base is given
+func diffBaseCandidates(remote, defaultBranch string) []string {
+	if remote == "" {
+		remote = DefaultPushRemote
+	}
+
+	var candidates []string
+	for _, branch := range []string{defaultBranch, "main", "master"} {
+		if branch == "" {
+			continue
+		}
+		for _, ref := range []string{remote + "/" + branch, branch} {
+			duplicate := false
+			for _, existing := range candidates {
+				duplicate = duplicate || existing == ref
+			}
+			if !duplicate {
+				candidates = append(candidates, ref)
+			}
+		}
+	}
+	return candidates
+}
+
+// ResolveDiffBase returns the revision diffs of worktreePath are taken
+// against. A non-empty base must name a commit. An empty base resolves to the
+// merge base of HEAD and the default branch (remote-tracking ref first), or
+// HEAD when no default branch exists, so only the branch's own changes show.
+func (g *Operations) ResolveDiffBase(worktreePath, base string) (string, error) {
+	if base != "" {
+		cmd := CreateGitCommand([]string{"rev-parse", "--verify", "--quiet", base + "^{commit}"}, worktreePath)
+		if err := cmd.Run(); err != nil {
+			return "", fmt.Errorf("diff base %s is not a commit in %s", base, worktreePath)
+		}
+		return base, nil
+	}
+
+	var remote, defaultBranch string
+	if g.config != nil {
+		remote, defaultBranch = g.config.RemoteName, g.config.DefaultBranch
+	}
+	for _, candidate := range diffBaseCandidates(remote, defaultBranch) {
+		output, err := CreateGitCommand([]string{"merge-base", "HEAD", candidate}, worktreePath).Output()
+		if err == nil {
+			return strings.TrimSpace(string(output)), nil
+		}
+	}
+	return "HEAD", nil
+}
+
+// DiffOptions selects the form of Diff output
+type DiffOptions struct {
+	Stat  bool // Summarize per-file changes instead of printing patches
+	Color bool // Include ANSI colors
+}
+
+// diffCommandArgs builds the git diff arguments for a resolved base
+func diffCommandArgs(resolvedBase string, options DiffOptions) []string {
+	args := []string{"diff"}
+	if options.Color {
+		args = append(args, "--color=always")
+	} else {
+		args = append(args, "--no-color")
+	}
+	if options.Stat {
+		args = append(args, "--stat")
+	}
+	return append(args, resolvedBase)
+}
+
+// Diff returns `git diff` output for the worktree against base, covering
+// committed and uncommitted changes to tracked files
+func (g *Operations) Diff(worktreePath, base string) (string, error) {
+	return g.DiffWithOptions(worktreePath, base, DiffOptions{})
+}
+
+// DiffWithOptions is Diff with stat and color control
+func (g *Operations) DiffWithOptions(worktreePath, base string, options DiffOptions) (string, error) {
+	resolved, err := g.ResolveDiffBase(worktreePath, base)
+	if err != nil {
+		return "", err
+	}
+
+	output, err := CreateGitCommand(diffCommandArgs(resolved, options), worktreePath).Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to get diff: %w", err)
+	}
+	return string(output), nil
+}
+
+// DiffFiles returns the tracked files whose content differs from base, in the
+// order git lists them
+func (g *Operations) DiffFiles(worktreePath, base string) ([]string, error) {
+	resolved, err := g.ResolveDiffBase(worktreePath, base)
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := CreateGitCommand([]string{"diff", "--name-only", "-z", resolved}, worktreePath).Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get changed file names: %w", err)
+	}
+	return parseNulSeparatedNames(string(output)), nil
+}
+
+// parseNulSeparatedNames splits -z output into file names. Names are taken
+// verbatim, so paths with spaces or non-ASCII characters need no unquoting.
+func parseNulSeparatedNames(output string) []string {
+	var names []string
+	for _, name := range strings.Split(output, "\x00") {
+		if name = strings.TrimRight(name, "\n"); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
@@ -0,0 +1,165 @@
+package git
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDiffBaseCandidates(t *testing.T) {
+	tests := []struct {
+		name          string
+		remote        string
+		defaultBranch string
+		expected      []string
+	}{
+		{"defaults", "", "", []string{"origin/main", "main", "origin/master", "master"}},
+		{"configured branch first", "upstream", "develop", []string{"upstream/develop", "develop", "upstream/main", "main", "upstream/master", "master"}},
+		{"configured branch deduplicated", "origin", "master", []string{"origin/master", "master", "origin/main", "main"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := diffBaseCandidates(tt.remote, tt.defaultBranch)
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestParseNulSeparatedNames(t *testing.T) {
+	tests := []struct {
+		name     string
+		output   string
+		expected []string
+	}{
+		{"empty", "", nil},
+		{"single", "a.go\x00", []string{"a.go"}},
+		{"spaces and unicode", "dir/with space.go\x00café.txt\x00", []string{"dir/with space.go", "café.txt"}},
+		{"trailing newline", "a.go\x00b.go\x00\n", []string{"a.go", "b.go"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := parseNulSeparatedNames(tt.output)
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestDiffAndDiffFiles(t *testing.T) {
+	tmpDir, runGit := initTestRepo(t)
+	writeTestFile(t, tmpDir, "base.txt", []byte("base"))
+	runGit("add", ".")
+	runGit("commit", "-q", "-m", "initial")
+	baseCommit := runGit("rev-parse", "HEAD")
+
+	runGit("checkout", "-q", "-b", "feature")
+	writeTestFile(t, tmpDir, "feature file.txt", []byte("feature"))
+	runGit("add", ".")
+	runGit("commit", "-q", "-m", "feature")
+
+	// main moves on after the branch point; its changes must not show up
+	runGit("checkout", "-q", "main")
+	writeTestFile(t, tmpDir, "main-only.txt", []byte("main"))
+	runGit("add", ".")
+	runGit("commit", "-q", "-m", "main")
+	runGit("checkout", "-q", "feature")
+
+	writeTestFile(t, tmpDir, "base.txt", []byte("base edited"))
+
+	ops := NewOperations(tmpDir, nil, nil)
+
+	resolved, err := ops.ResolveDiffBase(tmpDir, "")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if resolved != baseCommit {
+		t.Errorf("Expected merge base '%s', got '%s'", baseCommit, resolved)
+	}
+
+	files, err := ops.DiffFiles(tmpDir, "")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	expected := []string{"base.txt", "feature file.txt"}
+	if !reflect.DeepEqual(files, expected) {
+		t.Errorf("Expected %v, got %v", expected, files)
+	}
+
+	files, err = ops.DiffFiles(tmpDir, "HEAD")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !reflect.DeepEqual(files, []string{"base.txt"}) {
+		t.Errorf("Expected [base.txt], got %v", files)
+	}
+
+	diff, err := ops.Diff(tmpDir, "")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !strings.Contains(diff, "+base edited") || strings.Contains(diff, "main-only.txt") {
+		t.Errorf("Expected diff of the branch's own changes, got:\n%s", diff)
+	}
+
+	if _, err := ops.DiffFiles(tmpDir, "no-such-ref"); err == nil {
+		t.Error("Expected error for unknown base")
+	}
+
+	// A stat of the branch covers every commit since the branch point, such
+	// as recovery commits after the implementation, not just the last one
+	writeTestFile(t, tmpDir, "recovery.txt", []byte("recovery"))
+	runGit("add", ".")
+	runGit("commit", "-q", "-m", "recovery")
+	stat, err := ops.DiffWithOptions(tmpDir, "", DiffOptions{Stat: true})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	for _, file := range []string{"feature file.txt", "recovery.txt", "base.txt"} {
+		if !strings.Contains(stat, file) {
+			t.Errorf("Expected %s in the branch stat, got:\n%s", file, stat)
+		}
+	}
+}
+
+func TestDiffStatInSingleCommitRepository(t *testing.T) {
+	tmpDir, runGit := initTestRepo(t)
+	runGit("branch", "-M", "feature")
+	runGit("commit", "-q", "--allow-empty", "-m", "initial")
+	writeTestFile(t, tmpDir, "Lexer.swift", []byte("lexer"))
+	runGit("add", ".")
+
+	// Without HEAD~1 or a default branch, the stat still covers uncommitted work
+	stat, err := NewOperations(tmpDir, nil, nil).DiffWithOptions(tmpDir, "", DiffOptions{Stat: true})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !strings.Contains(stat, "Lexer.swift") {
+		t.Errorf("Expected Lexer.swift in the stat, got:\n%s", stat)
+	}
+}
+
+func TestDiffCommandArgs(t *testing.T) {
+	tests := []struct {
+		name     string
+		options  DiffOptions
+		expected []string
+	}{
+		{"patch", DiffOptions{}, []string{"diff", "--no-color", "abc123"}},
+		{"stat", DiffOptions{Stat: true}, []string{"diff", "--no-color", "--stat", "abc123"}},
+		{"colored stat", DiffOptions{Stat: true, Color: true}, []string{"diff", "--color=always", "--stat", "abc123"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := diffCommandArgs("abc123", tt.options)
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
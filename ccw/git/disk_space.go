@@ -0,0 +1,89 @@
+package git
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"ccw/platform"
+)
+
+// Checking free disk space at the worktree base before creating a worktree
+
+// freeDiskSpace reports the bytes available at a path; tests stub it
+var freeDiskSpace = platform.FreeDiskSpace
+
+// LowDiskSpaceError reports that the worktree base has less free space than
+// git.min_free_space requires
+type LowDiskSpaceError struct {
+	Path     string
+	Free     uint64
+	Required uint64
+}
+
+// Error describes the shortfall and how to resolve it
+func (e *LowDiskSpaceError) Error() string {
+	return fmt.Sprintf("not enough free disk space at %s: %s available, git.min_free_space requires %s. "+
+		"Free up space (ccw --cleanup removes old worktrees) or lower git.min_free_space",
+		e.Path, formatByteSize(e.Free), formatByteSize(e.Required))
+}
+
+// formatByteSize renders bytes with a binary unit, such as 1.5GB
+func formatByteSize(bytes uint64) string {
+	units := []string{"B", "KB", "MB", "GB", "TB"}
+	value := float64(bytes)
+	unit := 0
+	for value >= 1024 && unit < len(units)-1 {
+		value /= 1024
+		unit++
+	}
+	if unit == 0 {
+		return fmt.Sprintf("%dB", bytes)
+	}
+	return fmt.Sprintf("%.1f%s", value, units[unit])
+}
+
+// CheckFreeSpace returns a *LowDiskSpaceError when free is below required;
+// a required size of 0 disables the check
+func CheckFreeSpace(path string, free, required uint64) error {
+	if required == 0 || free >= required {
+		return nil
+	}
+	return &LowDiskSpaceError{Path: path, Free: free, Required: required}
+}
+
+// EnforceMinFreeSpace refuses to create a worktree when the filesystem holding
+// the worktree base has less than required bytes free. The check is skipped on
+// platforms where free space cannot be determined.
+func (g *Operations) EnforceMinFreeSpace(required uint64) error {
+	if required == 0 {
+		return nil
+	}
+
+	path := existingAncestor(g.basePath)
+	free, err := freeDiskSpace(path)
+	if err != nil {
+		if errors.Is(err, platform.ErrFreeSpaceUnsupported) {
+			return nil
+		}
+		return fmt.Errorf("failed to check free disk space: %w", err)
+	}
+	return CheckFreeSpace(g.basePath, free, required)
+}
+
+// existingAncestor returns path, or its nearest parent that exists, since the
+// worktree base is created on first use
+func existingAncestor(path string) string {
+	path = filepath.Clean(path)
+	for {
+		if _, err := os.Stat(path); err == nil {
+			return path
+		}
+		parent := filepath.Dir(path)
+		if parent == path {
+			return path
+		}
+		path = parent
+	}
+}
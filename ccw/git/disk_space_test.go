@@ -0,0 +1,95 @@
+package git
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"ccw/platform"
+)
+
+func TestCheckFreeSpace(t *testing.T) {
+	const gb = 1 << 30
+	tests := []struct {
+		name     string
+		free     uint64
+		required uint64
+		wantErr  bool
+	}{
+		{"check disabled", 0, 0, false},
+		{"plenty free", 10 * gb, 2 * gb, false},
+		{"exactly the minimum", 2 * gb, 2 * gb, false},
+		{"below the minimum", gb, 2 * gb, true},
+		{"disk full", 0, 1, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckFreeSpace("/worktrees", tt.free, tt.required)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Expected error %v, got %v", tt.wantErr, err)
+			}
+			var diskErr *LowDiskSpaceError
+			if tt.wantErr && !errors.As(err, &diskErr) {
+				t.Errorf("Expected a *LowDiskSpaceError, got %T", err)
+			}
+		})
+	}
+
+	err := CheckFreeSpace("/worktrees", 1536<<20, 2*gb)
+	for _, expected := range []string{"/worktrees", "1.5GB available", "requires 2.0GB", "git.min_free_space"} {
+		if !strings.Contains(err.Error(), expected) {
+			t.Errorf("Expected error to contain '%s', got '%s'", expected, err.Error())
+		}
+	}
+}
+
+func TestEnforceMinFreeSpace(t *testing.T) {
+	original := freeDiskSpace
+	defer func() { freeDiskSpace = original }()
+
+	base := t.TempDir()
+	ops := &Operations{basePath: filepath.Join(base, "not-created-yet")}
+
+	var checked string
+	free, freeErr := uint64(100), error(nil)
+	freeDiskSpace = func(path string) (uint64, error) {
+		checked = path
+		return free, freeErr
+	}
+
+	if err := ops.EnforceMinFreeSpace(0); err != nil || checked != "" {
+		t.Errorf("Expected no check without a minimum, got %v after checking '%s'", err, checked)
+	}
+
+	var diskErr *LowDiskSpaceError
+	if err := ops.EnforceMinFreeSpace(200); !errors.As(err, &diskErr) {
+		t.Errorf("Expected a *LowDiskSpaceError, got %v", err)
+	}
+	if checked != base {
+		t.Errorf("Expected the nearest existing directory '%s' to be checked, got '%s'", base, checked)
+	}
+	if err := ops.EnforceMinFreeSpace(100); err != nil {
+		t.Errorf("Expected enough space, got %v", err)
+	}
+
+	freeErr = platform.ErrFreeSpaceUnsupported
+	if err := ops.EnforceMinFreeSpace(200); err != nil {
+		t.Errorf("Expected the check skipped on unsupported platforms, got %v", err)
+	}
+	freeErr = errors.New("permission denied")
+	if err := ops.EnforceMinFreeSpace(200); err == nil || !strings.Contains(err.Error(), "permission denied") {
+		t.Errorf("Expected the detection error, got %v", err)
+	}
+}
+
+func TestFreeDiskSpace(t *testing.T) {
+	free, err := platform.FreeDiskSpace(t.TempDir())
+	if errors.Is(err, platform.ErrFreeSpaceUnsupported) {
+		t.Skip(err)
+	}
+	if err != nil || free == 0 {
+		t.Errorf("Expected free space for the temp dir, got %d, %v", free, err)
+	}
+}
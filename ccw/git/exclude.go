@@ -0,0 +1,55 @@
+package git
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Keeping ccw's runtime state out of a checkout's commits
+
+// RuntimeStateExclude matches the .ccw directory holding ccw's locks, logs
+// and run history wherever ccw is run inside a checkout
+const RuntimeStateExclude = ".ccw/"
+
+// ClaudeSettingsExclude matches the Claude Code permissions file ccw writes
+// into a checkout it works in place
+const ClaudeSettingsExclude = ".claude/settings.local.json"
+
+// ExcludeFromCommits adds pattern to the repository's info/exclude file, so
+// git status, git add and the dirty check ignore matching files without a
+// change to the tracked .gitignore. A pattern already listed is not repeated.
+func ExcludeFromCommits(repoPath, pattern string) error {
+	output, err := CreateGitCommand([]string{"rev-parse", "--git-path", "info/exclude"}, repoPath).Output()
+	if err != nil {
+		return fmt.Errorf("failed to locate info/exclude: %w", err)
+	}
+	excludePath := strings.TrimSpace(string(output))
+	if !filepath.IsAbs(excludePath) {
+		excludePath = filepath.Join(repoPath, excludePath)
+	}
+
+	existing, err := os.ReadFile(excludePath)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read %s: %w", excludePath, err)
+	}
+	for _, line := range strings.Split(string(existing), "\n") {
+		if strings.TrimSpace(line) == pattern {
+			return nil
+		}
+	}
+
+	if err := os.MkdirAll(filepath.Dir(excludePath), 0755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(excludePath), err)
+	}
+	content := string(existing)
+	if content != "" && !strings.HasSuffix(content, "\n") {
+		content += "\n"
+	}
+	content += pattern + "\n"
+	if err := os.WriteFile(excludePath, []byte(content), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", excludePath, err)
+	}
+	return nil
+}
@@ -0,0 +1,131 @@
+package git
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Changed file scanning for accidental large or binary files
+
+// binarySniffLength matches the amount of content git inspects when detecting binary files
+const binarySniffLength = 8000
+
+// FileScanFinding describes a changed file that should not be committed as-is
+type FileScanFinding struct {
+	Path      string `json:"path"`
+	Size      int64  `json:"size"`
+	Oversized bool   `json:"oversized"`
+	Binary    bool   `json:"binary"`
+}
+
+// String returns a human-readable description of the finding
+func (f FileScanFinding) String() string {
+	var reasons []string
+	if f.Oversized {
+		reasons = append(reasons, "exceeds size limit")
+	}
+	if f.Binary {
+		reasons = append(reasons, "binary content")
+	}
+	return fmt.Sprintf("%s (%d bytes, %s)", f.Path, f.Size, strings.Join(reasons, ", "))
+}
+
+// ChangedFileSizes returns the size of every added or modified file in the worktree,
+// including untracked files. Deleted files are skipped.
+func (g *Operations) ChangedFileSizes(worktreePath string) (map[string]int64, error) {
+	cmd := CreateGitCommand([]string{"status", "--porcelain", "--untracked-files=all"}, worktreePath)
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get git status: %w", err)
+	}
+
+	sizes := make(map[string]int64)
+	for _, line := range strings.Split(string(output), "\n") {
+		if len(line) < 4 {
+			continue
+		}
+
+		status := line[:2]
+		if strings.Contains(status, "D") {
+			continue
+		}
+
+		// Renames are reported as "old -> new"; only the new path exists
+		paths := porcelainPaths(line[3:])
+		path := paths[len(paths)-1]
+
+		info, err := os.Stat(filepath.Join(worktreePath, path))
+		if err != nil || info.IsDir() {
+			continue
+		}
+		sizes[path] = info.Size()
+	}
+
+	return sizes, nil
+}
+
+// porcelainPaths returns the unquoted paths of a porcelain status entry, which
+// holds "old -> new" for renames
+func porcelainPaths(entry string) []string {
+	paths := []string{entry}
+	if idx := strings.Index(entry, " -> "); idx >= 0 {
+		paths = []string{entry[:idx], entry[idx+4:]}
+	}
+	for i, path := range paths {
+		if unquoted, err := strconv.Unquote(path); err == nil {
+			paths[i] = unquoted
+		}
+	}
+	return paths
+}
+
+// ScanChangedFiles checks the given files for size over maxSize and for binary content.
+// A maxSize of 0 disables the size check. Findings are sorted by path.
+func ScanChangedFiles(worktreePath string, sizes map[string]int64, maxSize int64) []FileScanFinding {
+	var findings []FileScanFinding
+
+	for path, size := range sizes {
+		finding := FileScanFinding{
+			Path:      path,
+			Size:      size,
+			Oversized: maxSize > 0 && size > maxSize,
+		}
+
+		if binary, err := isBinaryFile(filepath.Join(worktreePath, path)); err == nil {
+			finding.Binary = binary
+		}
+
+		if finding.Oversized || finding.Binary {
+			findings = append(findings, finding)
+		}
+	}
+
+	sort.Slice(findings, func(i, j int) bool {
+		return findings[i].Path < findings[j].Path
+	})
+
+	return findings
+}
+
+// isBinaryFile reports whether the file contains a NUL byte in its leading content
+func isBinaryFile(path string) (bool, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return false, err
+	}
+	defer file.Close()
+
+	buf := make([]byte, binarySniffLength)
+	n, err := io.ReadFull(file, buf)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return false, err
+	}
+
+	return bytes.IndexByte(buf[:n], 0) >= 0, nil
+}
@@ -0,0 +1,133 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeTestFile(t *testing.T, dir, name string, content []byte) int64 {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, content, 0644); err != nil {
+		t.Fatal(err)
+	}
+	return int64(len(content))
+}
+
+func TestScanChangedFiles(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "ccw-file-scan-test-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	sizes := map[string]int64{
+		"Sources/main.swift": writeTestFile(t, tmpDir, "Sources/main.swift", []byte("print(\"hello\")\n")),
+		"large.txt":          writeTestFile(t, tmpDir, "large.txt", []byte(strings.Repeat("a", 2048))),
+		"image.png":          writeTestFile(t, tmpDir, "image.png", []byte{0x89, 'P', 'N', 'G', 0x00, 0x01}),
+		"large.bin":          writeTestFile(t, tmpDir, "large.bin", append(make([]byte, 2048), 'x')),
+	}
+
+	tests := []struct {
+		name    string
+		maxSize int64
+		want    []FileScanFinding
+	}{
+		{
+			name:    "size and binary checks",
+			maxSize: 1024,
+			want: []FileScanFinding{
+				{Path: "image.png", Size: 6, Binary: true},
+				{Path: "large.bin", Size: 2049, Oversized: true, Binary: true},
+				{Path: "large.txt", Size: 2048, Oversized: true},
+			},
+		},
+		{
+			name:    "size check disabled",
+			maxSize: 0,
+			want: []FileScanFinding{
+				{Path: "image.png", Size: 6, Binary: true},
+				{Path: "large.bin", Size: 2049, Binary: true},
+			},
+		},
+		{
+			name:    "limit above all sizes",
+			maxSize: 1 << 20,
+			want: []FileScanFinding{
+				{Path: "image.png", Size: 6, Binary: true},
+				{Path: "large.bin", Size: 2049, Binary: true},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ScanChangedFiles(tmpDir, sizes, tt.maxSize)
+			if len(got) != len(tt.want) {
+				t.Fatalf("Expected %d findings, got %d: %v", len(tt.want), len(got), got)
+			}
+			for i := range tt.want {
+				if got[i] != tt.want[i] {
+					t.Errorf("Finding %d: expected %+v, got %+v", i, tt.want[i], got[i])
+				}
+			}
+		})
+	}
+}
+
+func TestScanChangedFilesMissingFile(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "ccw-file-scan-test-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	// Files that vanish between status and scan are only checked by size
+	findings := ScanChangedFiles(tmpDir, map[string]int64{"gone.txt": 10}, 5)
+	if len(findings) != 1 || !findings[0].Oversized || findings[0].Binary {
+		t.Errorf("Expected single oversized finding, got %v", findings)
+	}
+}
+
+func TestFileScanFindingString(t *testing.T) {
+	finding := FileScanFinding{Path: "a.bin", Size: 42, Oversized: true, Binary: true}
+	expected := "a.bin (42 bytes, exceeds size limit, binary content)"
+	if finding.String() != expected {
+		t.Errorf("Expected '%s', got '%s'", expected, finding.String())
+	}
+}
+
+func TestChangedFileSizes(t *testing.T) {
+	tmpDir, runGit := initTestRepo(t)
+	writeTestFile(t, tmpDir, "tracked.txt", []byte("one"))
+	writeTestFile(t, tmpDir, "removed.txt", []byte("gone soon"))
+	runGit("add", ".")
+	runGit("commit", "-q", "-m", "initial")
+
+	writeTestFile(t, tmpDir, "tracked.txt", []byte("one two"))
+	writeTestFile(t, tmpDir, "nested/new.txt", []byte("brand new"))
+	if err := os.Remove(filepath.Join(tmpDir, "removed.txt")); err != nil {
+		t.Fatal(err)
+	}
+
+	ops := NewOperations(tmpDir, nil, nil)
+	sizes, err := ops.ChangedFileSizes(tmpDir)
+	if err != nil {
+		t.Fatalf("ChangedFileSizes failed: %v", err)
+	}
+
+	expected := map[string]int64{"tracked.txt": 7, "nested/new.txt": 9}
+	if len(sizes) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, sizes)
+	}
+	for path, size := range expected {
+		if sizes[path] != size {
+			t.Errorf("Expected %s to be %d bytes, got %d", path, size, sizes[path])
+		}
+	}
+}
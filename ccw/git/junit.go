@@ -0,0 +1,146 @@
+package git
+
+import (
+	"encoding/xml"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// JUnit XML export of validation results for CI dashboards
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Time     string           `xml:"time,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Timestamp string          `xml:"timestamp,attr,omitempty"`
+	Cases     []junitTestCase `xml:"testcase"`
+	SystemOut string          `xml:"system-out,omitempty"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Body    string `xml:",chardata"`
+}
+
+// junitOutputLimit caps the tool output embedded in a failure
+const junitOutputLimit = 10000
+
+// RenderJUnit renders result as JUnit XML with one suite per stage that ran.
+// The test suite carries the pass/fail counts of swift test and a test case for
+// each failing test; lint and build are single test cases.
+func RenderJUnit(result *ValidationResult) ([]byte, error) {
+	suites := junitTestSuites{Name: "ccw validation", Time: fmt.Sprintf("%.3f", result.Duration.Seconds())}
+	timestamp := ""
+	if !result.Timestamp.IsZero() {
+		timestamp = result.Timestamp.UTC().Format("2006-01-02T15:04:05")
+	}
+
+	stageSuite := func(name, command string, success bool, output string) junitTestSuite {
+		testCase := junitTestCase{Name: command, ClassName: "ccw." + name}
+		failures := 0
+		if !success {
+			testCase.Failure = &junitFailure{Message: command + " failed", Body: truncateJUnitOutput(output)}
+			failures = 1
+		}
+		return junitTestSuite{Name: name, Tests: 1, Failures: failures, Timestamp: timestamp, Cases: []junitTestCase{testCase}}
+	}
+
+	if lint := result.LintResult; lint != nil {
+		suites.Suites = append(suites.Suites, stageSuite("lint", "swiftlint lint", lint.Success, lint.Output))
+	}
+	if build := result.BuildResult; build != nil {
+		suites.Suites = append(suites.Suites, stageSuite("build", "swift build", build.Success, build.Output+build.Error))
+	}
+	if test := result.TestResult; test != nil {
+		suites.Suites = append(suites.Suites, testSuite(test, timestamp))
+	}
+
+	for _, suite := range suites.Suites {
+		suites.Tests += suite.Tests
+		suites.Failures += suite.Failures
+	}
+
+	data, err := xml.MarshalIndent(suites, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to render JUnit XML: %w", err)
+	}
+	return append([]byte(xml.Header), append(data, '\n')...), nil
+}
+
+// testSuite converts swift test results. Passing tests are only counted, since
+// swift test output does not name them reliably.
+func testSuite(test *TestResult, timestamp string) junitTestSuite {
+	suite := junitTestSuite{Name: "test", Tests: test.TestCount, Failures: test.Failed, Timestamp: timestamp}
+
+	for _, name := range test.FailedTests {
+		className, testName := "ccw.test", name
+		if i := strings.LastIndex(name, "."); i > 0 {
+			className, testName = name[:i], name[i+1:]
+		}
+		suite.Cases = append(suite.Cases, junitTestCase{
+			Name:      testName,
+			ClassName: className,
+			Failure:   &junitFailure{Message: name + " failed"},
+		})
+	}
+
+	// A failed run that named no failing test, such as a crash, is one failure
+	if !test.Success && len(suite.Cases) == 0 {
+		suite.Cases = append(suite.Cases, junitTestCase{
+			Name:      "swift test",
+			ClassName: "ccw.test",
+			Failure:   &junitFailure{Message: "swift test failed", Body: truncateJUnitOutput(test.Output)},
+		})
+	}
+	if suite.Failures < len(suite.Cases) {
+		suite.Failures = len(suite.Cases)
+	}
+	if suite.Tests < suite.Failures {
+		suite.Tests = suite.Failures
+	}
+	suite.SystemOut = truncateJUnitOutput(test.Output)
+	return suite
+}
+
+// truncateJUnitOutput keeps the end of long tool output, where failures are reported
+func truncateJUnitOutput(output string) string {
+	output = strings.ToValidUTF8(output, "")
+	if len(output) <= junitOutputLimit {
+		return output
+	}
+	return "...\n" + strings.ToValidUTF8(output[len(output)-junitOutputLimit:], "")
+}
+
+// ExportJUnit writes result as JUnit XML to path, creating its directory
+func ExportJUnit(result *ValidationResult, path string) error {
+	data, err := RenderJUnit(result)
+	if err != nil {
+		return err
+	}
+	if dir := filepath.Dir(path); dir != "." {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("failed to create JUnit output directory: %w", err)
+		}
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write JUnit XML: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+package git
+
+import (
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFailedTestNames(t *testing.T) {
+	output := strings.Join([]string{
+		"Test Case '-[AppTests.ParserTests testEmptyInput]' started.",
+		"Test Case '-[AppTests.ParserTests testEmptyInput]' failed (0.002 seconds).",
+		"Test Case 'LexerTests.testNumbers' passed (0.001 seconds).",
+		"Test Case 'LexerTests.testStrings' failed (0.004 seconds).",
+		"Test Case 'LexerTests.testStrings' failed (0.004 seconds).",
+	}, "\n")
+
+	expected := []string{"AppTests.ParserTests.testEmptyInput", "LexerTests.testStrings"}
+	if result := failedTestNames(output); !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected %v, got %v", expected, result)
+	}
+}
+
+func TestRenderJUnit(t *testing.T) {
+	result := &ValidationResult{
+		Success:     false,
+		LintResult:  &LintResult{Success: true},
+		BuildResult: &BuildResult{Success: true},
+		TestResult: &TestResult{
+			Success:     false,
+			Output:      "Executed 5 tests, with 2 failures \x1b[31m<red>\x1b[0m",
+			TestCount:   5,
+			Passed:      3,
+			Failed:      2,
+			FailedTests: []string{"AppTests.ParserTests.testEmptyInput", "LexerTests.testStrings"},
+		},
+		Duration:  1500 * time.Millisecond,
+		Timestamp: time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC),
+	}
+
+	data, err := RenderJUnit(result)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	var parsed junitTestSuites
+	if err := xml.Unmarshal(data, &parsed); err != nil {
+		t.Fatalf("Expected well-formed XML, got %v:\n%s", err, data)
+	}
+	if parsed.Tests != 7 || parsed.Failures != 2 || parsed.Time != "1.500" {
+		t.Errorf("Expected 7 tests, 2 failures in 1.500s, got %d, %d in %s", parsed.Tests, parsed.Failures, parsed.Time)
+	}
+	if len(parsed.Suites) != 3 {
+		t.Fatalf("Expected lint, build and test suites, got %+v", parsed.Suites)
+	}
+
+	test := parsed.Suites[2]
+	if test.Name != "test" || test.Tests != 5 || test.Failures != 2 {
+		t.Errorf("Expected test suite with 5 tests and 2 failures, got %s with %d and %d", test.Name, test.Tests, test.Failures)
+	}
+	var failures []string
+	for _, testCase := range test.Cases {
+		if testCase.Failure != nil {
+			failures = append(failures, testCase.ClassName+"/"+testCase.Name)
+		}
+	}
+	expected := []string{"AppTests.ParserTests/testEmptyInput", "LexerTests/testStrings"}
+	if !reflect.DeepEqual(failures, expected) {
+		t.Errorf("Expected failures %v, got %v", expected, failures)
+	}
+	if parsed.Suites[0].Failures != 0 || parsed.Suites[0].Cases[0].Failure != nil {
+		t.Errorf("Expected passing lint suite, got %+v", parsed.Suites[0])
+	}
+}
+
+func TestRenderJUnitUnnamedTestFailure(t *testing.T) {
+	data, err := RenderJUnit(&ValidationResult{
+		BuildResult: &BuildResult{Success: false, Error: "error: cannot find 'x' in scope"},
+		TestResult:  &TestResult{Success: false, Output: "fatal error"},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	var parsed junitTestSuites
+	if err := xml.Unmarshal(data, &parsed); err != nil {
+		t.Fatalf("Expected well-formed XML, got %v", err)
+	}
+	if parsed.Tests != 2 || parsed.Failures != 2 {
+		t.Errorf("Expected a failed build and one failed test run, got %d tests and %d failures", parsed.Tests, parsed.Failures)
+	}
+	if failure := parsed.Suites[0].Cases[0].Failure; failure == nil || !strings.Contains(failure.Body, "cannot find 'x'") {
+		t.Errorf("Expected the build error in the failure, got %+v", failure)
+	}
+}
+
+func TestExportJUnit(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "reports", "junit.xml")
+
+	if err := ExportJUnit(&ValidationResult{Success: true, TestResult: &TestResult{Success: true, TestCount: 4, Passed: 4}}, path); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(data), xml.Header) || !strings.Contains(string(data), `tests="4" failures="0"`) {
+		t.Errorf("Expected a JUnit document with 4 passing tests, got:\n%s", data)
+	}
+}
@@ -0,0 +1,62 @@
+package git
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+)
+
+// CCW metadata files saved in each issue worktree
+
+// Metadata file names, without the leading dot used in the worktree root
+const (
+	IssueDataFile      = "issue-data.json"
+	WorktreeConfigFile = "worktree-config.json"
+)
+
+// MetadataPath returns where the named metadata file lives: inside metadataDir
+// when it is set, otherwise as a dotfile in the worktree root
+func MetadataPath(worktreePath, metadataDir, name string) string {
+	if metadataDir == "" {
+		return legacyMetadataPath(worktreePath, name)
+	}
+	return filepath.Join(worktreePath, metadataDir, name)
+}
+
+// legacyMetadataPath is the original worktree-root location of a metadata file
+func legacyMetadataPath(worktreePath, name string) string {
+	return filepath.Join(worktreePath, "."+name)
+}
+
+// WriteMetadata saves a metadata file, creating metadataDir with a .gitignore
+// so the file is not committed along with the implementation
+func WriteMetadata(worktreePath, metadataDir, name string, data []byte) error {
+	path := MetadataPath(worktreePath, metadataDir, name)
+	if metadataDir != "" {
+		if err := EnsureUncommittedDir(filepath.Dir(path), "metadata files"); err != nil {
+			return err
+		}
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// ReadMetadata reads a metadata file. When metadataDir is set but the file is
+// only found at its legacy worktree-root location, it is moved into
+// metadataDir first; if the move fails the legacy copy is still returned.
+func ReadMetadata(worktreePath, metadataDir, name string) ([]byte, error) {
+	data, err := os.ReadFile(MetadataPath(worktreePath, metadataDir, name))
+	if metadataDir == "" || !errors.Is(err, os.ErrNotExist) {
+		return data, err
+	}
+
+	legacyPath := legacyMetadataPath(worktreePath, name)
+	data, legacyErr := os.ReadFile(legacyPath)
+	if legacyErr != nil {
+		// Report the configured location rather than the legacy one
+		return nil, err
+	}
+	if WriteMetadata(worktreePath, metadataDir, name, data) == nil {
+		_ = os.Remove(legacyPath)
+	}
+	return data, nil
+}
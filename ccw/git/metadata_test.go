@@ -0,0 +1,98 @@
+package git
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestMetadataPath(t *testing.T) {
+	tests := []struct {
+		name        string
+		metadataDir string
+		expected    string
+	}{
+		{"legacy dotfile", "", filepath.Join("wt", ".issue-data.json")},
+		{"metadata directory", ".ccw", filepath.Join("wt", ".ccw", "issue-data.json")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := MetadataPath("wt", tt.metadataDir, IssueDataFile); result != tt.expected {
+				t.Errorf("Expected '%s', got '%s'", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestWriteMetadataToMetadataDir(t *testing.T) {
+	worktree := t.TempDir()
+
+	if err := WriteMetadata(worktree, ".ccw", WorktreeConfigFile, []byte("{}")); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(worktree, ".ccw", "worktree-config.json")); err != nil {
+		t.Errorf("Expected the file under .ccw: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(worktree, ".worktree-config.json")); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("Expected no legacy dotfile, got %v", err)
+	}
+	ignore, err := os.ReadFile(filepath.Join(worktree, ".ccw", ".gitignore"))
+	if err != nil || string(ignore) != "# Created by ccw; metadata files are never committed\n*\n" {
+		t.Errorf("Expected a .gitignore ignoring the metadata directory, got %q (%v)", ignore, err)
+	}
+}
+
+func TestReadMetadata(t *testing.T) {
+	t.Run("legacy location without metadata dir", func(t *testing.T) {
+		worktree := t.TempDir()
+		if err := os.WriteFile(filepath.Join(worktree, ".issue-data.json"), []byte(`{"number":1}`), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		data, err := ReadMetadata(worktree, "", IssueDataFile)
+		if err != nil || string(data) != `{"number":1}` {
+			t.Errorf("Expected the legacy file, got %q (%v)", data, err)
+		}
+	})
+
+	t.Run("metadata dir location", func(t *testing.T) {
+		worktree := t.TempDir()
+		if err := WriteMetadata(worktree, ".ccw", IssueDataFile, []byte(`{"number":2}`)); err != nil {
+			t.Fatal(err)
+		}
+
+		data, err := ReadMetadata(worktree, ".ccw", IssueDataFile)
+		if err != nil || string(data) != `{"number":2}` {
+			t.Errorf("Expected the .ccw file, got %q (%v)", data, err)
+		}
+	})
+
+	t.Run("legacy file is migrated", func(t *testing.T) {
+		worktree := t.TempDir()
+		legacyPath := filepath.Join(worktree, ".issue-data.json")
+		if err := os.WriteFile(legacyPath, []byte(`{"number":3}`), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		data, err := ReadMetadata(worktree, ".ccw", IssueDataFile)
+		if err != nil || string(data) != `{"number":3}` {
+			t.Fatalf("Expected the legacy contents, got %q (%v)", data, err)
+		}
+		if _, err := os.Stat(legacyPath); !errors.Is(err, os.ErrNotExist) {
+			t.Errorf("Expected the legacy file to be removed, got %v", err)
+		}
+		migrated, err := os.ReadFile(filepath.Join(worktree, ".ccw", "issue-data.json"))
+		if err != nil || string(migrated) != `{"number":3}` {
+			t.Errorf("Expected the file moved under .ccw, got %q (%v)", migrated, err)
+		}
+	})
+
+	t.Run("missing everywhere", func(t *testing.T) {
+		_, err := ReadMetadata(t.TempDir(), ".ccw", IssueDataFile)
+		if !errors.Is(err, os.ErrNotExist) {
+			t.Errorf("Expected a not-exist error, got %v", err)
+		}
+	})
+}
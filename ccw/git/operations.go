@@ -16,36 +16,21 @@ func (g *Operations) CreateWorktree(branchName, worktreePath string) error {
 		return fmt.Errorf("failed to create worktree directory: %w", err)
 	}
 
-	// Create git worktree using cross-platform command with timeout
-	cmd := CreateGitCommandWithTimeout([]string{"worktree", "add", "-b", branchName, worktreePath, "HEAD"}, g.basePath, g.GetTimeout())
-
-	output, err := cmd.CombinedOutput()
-	if err != nil {
-		return fmt.Errorf("failed to create git worktree: %w\nOutput: %s", err, string(output))
-	}
-
-	return nil
+	// Create git worktree using cross-platform command with timeout, retrying
+	// transient failures such as file locks
+	return g.addWorktreeWithRetry(branchName, worktreePath)
 }
 
-// RemoveWorktree removes a git worktree
+// RemoveWorktree removes a git worktree, retrying while files in it are
+// locked and deleting the directory as a last resort
 func (g *Operations) RemoveWorktree(worktreePath string) error {
-	// Get the parent directory to run git worktree remove from
-	parentDir := filepath.Dir(worktreePath)
-
-	// Remove the worktree using cross-platform command
-	cmd := CreateGitCommand([]string{"worktree", "remove", "--force", worktreePath}, parentDir)
-	if err := cmd.Run(); err != nil {
-		// If git worktree remove fails, try manual cleanup
-		return os.RemoveAll(worktreePath)
-	}
-
-	return nil
+	return g.removeWorktreeWithRetry(worktreePath)
 }
 
 // PushBranch pushes a branch to remote repository with retry logic
 func (g *Operations) PushBranch(worktreePath, branchName string) error {
 	// Push to remote with retry logic for network operations
-	if err := ExecuteGitCommandWithRetry([]string{"push", "-u", "origin", branchName}, worktreePath); err != nil {
+	if err := ExecuteGitCommandWithRetry(g.pushArgs(branchName), worktreePath); err != nil {
 		return fmt.Errorf("failed to push branch: %w", err)
 	}
 
@@ -74,6 +59,52 @@ func (g *Operations) GetCurrentBranch(worktreePath string) (string, error) {
 	return strings.TrimSpace(string(output)), nil
 }
 
+// HeadCommit returns the full SHA of HEAD in the worktree
+func (g *Operations) HeadCommit(worktreePath string) (string, error) {
+	cmd := CreateGitCommand([]string{"rev-parse", "HEAD"}, worktreePath)
+	output, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve HEAD: %w", err)
+	}
+
+	return strings.TrimSpace(string(output)), nil
+}
+
+// DiffStat returns `git diff --stat` output for the changes between baseRef and HEAD
+func (g *Operations) DiffStat(worktreePath, baseRef string) (string, error) {
+	cmd := CreateGitCommand([]string{"diff", "--stat", baseRef + "..HEAD"}, worktreePath)
+	output, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to get diff stat: %w", err)
+	}
+
+	return strings.TrimRight(string(output), "\n"), nil
+}
+
+// DiffNameOnly returns the files changed since baseRef, as listed by
+// `git diff --name-only`, including uncommitted edits and untracked files
+func (g *Operations) DiffNameOnly(worktreePath, baseRef string) ([]string, error) {
+	cmd := CreateGitCommand([]string{"diff", "--name-only", baseRef}, worktreePath)
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get changed file names: %w", err)
+	}
+
+	untrackedCmd := CreateGitCommand([]string{"ls-files", "--others", "--exclude-standard"}, worktreePath)
+	untracked, err := untrackedCmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list untracked files: %w", err)
+	}
+
+	var files []string
+	for _, line := range strings.Split(string(output)+string(untracked), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			files = append(files, line)
+		}
+	}
+	return files, nil
+}
+
 // ListWorktrees returns a list of all worktrees
 func (g *Operations) ListWorktrees() ([]string, error) {
 	cmd := CreateGitCommand([]string{"worktree", "list", "--porcelain"}, g.basePath)
@@ -100,7 +131,7 @@ func (g *Operations) ListWorktrees() ([]string, error) {
 
 // CheckBranchExists checks if a branch exists on remote
 func (g *Operations) CheckBranchExists(branchName string) (bool, error) {
-	cmd := CreateGitCommand([]string{"ls-remote", "--heads", "origin", branchName}, g.basePath)
+	cmd := CreateGitCommand([]string{"ls-remote", "--heads", g.PushRemote(), branchName}, g.basePath)
 	output, err := cmd.Output()
 	if err != nil {
 		return false, fmt.Errorf("failed to check remote branch: %w", err)
@@ -151,7 +182,7 @@ func (g *Operations) CommitChanges(worktreePath, commitMessage string) error {
 	}
 
 	// Create commit with the provided message
-	commitCmd := CreateGitCommand([]string{"commit", "-m", commitMessage}, worktreePath)
+	commitCmd := CreateGitCommand(g.commitArgs(commitMessage), worktreePath)
 	if err := commitCmd.Run(); err != nil {
 		return fmt.Errorf("failed to create commit: %w", err)
 	}
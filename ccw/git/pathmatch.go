@@ -0,0 +1,116 @@
+package git
+
+import (
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// Gitignore-style path matching
+
+// pathPattern is a single parsed gitignore-style pattern
+type pathPattern struct {
+	raw      string
+	segments []string
+	negate   bool
+	dirOnly  bool
+}
+
+// PathMatcher matches repository-relative paths against gitignore-style patterns.
+// Patterns without a slash match at any depth, a leading or inner slash anchors
+// the pattern to the repository root, a trailing slash matches only directories,
+// "**" matches any number of directories and "!" re-includes a previously
+// matched path. Later patterns take precedence, as in .gitignore.
+type PathMatcher struct {
+	patterns []pathPattern
+}
+
+// NewPathMatcher parses patterns, skipping blank lines and "#" comments
+func NewPathMatcher(patterns []string) *PathMatcher {
+	matcher := &PathMatcher{}
+	for _, raw := range patterns {
+		pattern := strings.TrimSpace(raw)
+		if pattern == "" || strings.HasPrefix(pattern, "#") {
+			continue
+		}
+
+		parsed := pathPattern{raw: pattern}
+		if strings.HasPrefix(pattern, "!") {
+			parsed.negate = true
+			pattern = pattern[1:]
+		}
+		if strings.HasSuffix(pattern, "/") {
+			parsed.dirOnly = true
+			pattern = strings.TrimRight(pattern, "/")
+		}
+
+		anchored := strings.Contains(pattern, "/")
+		pattern = strings.TrimPrefix(pattern, "/")
+		if pattern == "" {
+			continue
+		}
+
+		parsed.segments = strings.Split(pattern, "/")
+		if !anchored {
+			parsed.segments = append([]string{"**"}, parsed.segments...)
+		}
+		matcher.patterns = append(matcher.patterns, parsed)
+	}
+	return matcher
+}
+
+// Match reports whether filePath is matched and returns the deciding pattern
+func (m *PathMatcher) Match(filePath string) (bool, string) {
+	segments := strings.Split(strings.TrimPrefix(filepath.ToSlash(filePath), "./"), "/")
+
+	matched, decidingPattern := false, ""
+	for _, pattern := range m.patterns {
+		if pattern.matches(segments) {
+			matched = !pattern.negate
+			decidingPattern = pattern.raw
+		}
+	}
+	if !matched {
+		return false, ""
+	}
+	return true, decidingPattern
+}
+
+// matches reports whether the pattern matches the path or one of its parent
+// directories. Directory-only patterns never match the final (file) segment.
+func (p pathPattern) matches(pathSegments []string) bool {
+	limit := len(pathSegments)
+	if p.dirOnly {
+		limit--
+	}
+	for n := 1; n <= limit; n++ {
+		if matchSegments(p.segments, pathSegments[:n]) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchSegments matches pattern segments against path segments, expanding "**"
+func matchSegments(pattern, segments []string) bool {
+	if len(pattern) == 0 {
+		return len(segments) == 0
+	}
+
+	if pattern[0] == "**" {
+		for i := 0; i <= len(segments); i++ {
+			if matchSegments(pattern[1:], segments[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+
+	if len(segments) == 0 {
+		return false
+	}
+	if ok, err := path.Match(pattern[0], segments[0]); err != nil || !ok {
+		return false
+	}
+	return matchSegments(pattern[1:], segments[1:])
+}
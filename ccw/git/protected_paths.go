@@ -0,0 +1,60 @@
+package git
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Protected path detection for changes that need explicit opt-in
+
+// ProtectedPathMatch is a changed file covered by a protected path pattern
+type ProtectedPathMatch struct {
+	Path    string `json:"path"`
+	Pattern string `json:"pattern"`
+}
+
+// String returns a human-readable description of the match
+func (m ProtectedPathMatch) String() string {
+	return fmt.Sprintf("%s (matches %s)", m.Path, m.Pattern)
+}
+
+// FindProtectedPaths returns the changed files matched by the protected path
+// patterns, sorted by path
+func FindProtectedPaths(changedFiles []string, patterns []string) []ProtectedPathMatch {
+	if len(patterns) == 0 {
+		return nil
+	}
+
+	matcher := NewPathMatcher(patterns)
+	var matches []ProtectedPathMatch
+	for _, file := range changedFiles {
+		if matched, pattern := matcher.Match(file); matched {
+			matches = append(matches, ProtectedPathMatch{Path: file, Pattern: pattern})
+		}
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		return matches[i].Path < matches[j].Path
+	})
+	return matches
+}
+
+// ChangedFiles returns every path touched in the worktree, including deleted,
+// renamed and untracked files
+func (g *Operations) ChangedFiles(worktreePath string) ([]string, error) {
+	cmd := CreateGitCommand([]string{"status", "--porcelain", "--untracked-files=all"}, worktreePath)
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get git status: %w", err)
+	}
+
+	var files []string
+	for _, line := range strings.Split(string(output), "\n") {
+		if len(line) < 4 {
+			continue
+		}
+		files = append(files, porcelainPaths(line[3:])...)
+	}
+	return files, nil
+}
@@ -0,0 +1,105 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestPathMatcher(t *testing.T) {
+	tests := []struct {
+		name     string
+		patterns []string
+		path     string
+		expected bool
+	}{
+		{"basename at root", []string{"go.sum"}, "go.sum", true},
+		{"basename nested", []string{"go.sum"}, "tools/go.sum", true},
+		{"glob basename", []string{"*.pem"}, "certs/server.pem", true},
+		{"glob no match", []string{"*.pem"}, "certs/server.crt", false},
+		{"directory pattern", []string{".github/workflows/"}, ".github/workflows/ci.yml", true},
+		{"directory pattern needs directory", []string{"secrets/"}, "secrets", false},
+		{"anchored pattern", []string{"/config/prod.yml"}, "config/prod.yml", true},
+		{"anchored pattern not nested", []string{"/config/prod.yml"}, "app/config/prod.yml", false},
+		{"inner slash anchors", []string{"config/prod.yml"}, "app/config/prod.yml", false},
+		{"double star", []string{"**/fixtures/*.json"}, "Tests/a/fixtures/data.json", true},
+		{"double star suffix", []string{"deploy/**"}, "deploy/k8s/app.yaml", true},
+		{"unanchored directory name", []string{"secrets"}, "app/secrets/token.txt", true},
+		{"env variants", []string{".env.*"}, ".env.production", true},
+		{"negation", []string{"*.key", "!public.key"}, "keys/public.key", false},
+		{"negation then re-include", []string{"*.key", "!public.key", "keys/*.key"}, "keys/public.key", true},
+		{"comments and blanks ignored", []string{"# *.swift", "", "  "}, "main.swift", false},
+		{"leading dot slash in path", []string{"/Package.resolved"}, "./Package.resolved", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matched, _ := NewPathMatcher(tt.patterns).Match(tt.path)
+			if matched != tt.expected {
+				t.Errorf("Expected %v for %s against %v, got %v", tt.expected, tt.path, tt.patterns, matched)
+			}
+		})
+	}
+}
+
+func TestFindProtectedPaths(t *testing.T) {
+	changed := []string{
+		"Sources/Parser.swift",
+		".github/workflows/ci.yml",
+		"go.sum",
+		".env",
+		"certs/dev.pem",
+		"README.md",
+	}
+	patterns := []string{".github/workflows/", ".env", "*.pem", "go.sum"}
+
+	matches := FindProtectedPaths(changed, patterns)
+	expected := []ProtectedPathMatch{
+		{Path: ".env", Pattern: ".env"},
+		{Path: ".github/workflows/ci.yml", Pattern: ".github/workflows/"},
+		{Path: "certs/dev.pem", Pattern: "*.pem"},
+		{Path: "go.sum", Pattern: "go.sum"},
+	}
+	if !reflect.DeepEqual(matches, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, matches)
+	}
+
+	if got := matches[1].String(); got != ".github/workflows/ci.yml (matches .github/workflows/)" {
+		t.Errorf("Unexpected description '%s'", got)
+	}
+
+	if matches := FindProtectedPaths(changed, nil); len(matches) != 0 {
+		t.Errorf("Expected no matches without patterns, got %+v", matches)
+	}
+	if matches := FindProtectedPaths([]string{"Sources/Lexer.swift"}, patterns); len(matches) != 0 {
+		t.Errorf("Expected no matches for unprotected files, got %+v", matches)
+	}
+}
+
+func TestChangedFilesIncludesDeletionsAndRenames(t *testing.T) {
+	tmpDir, runGit := initTestRepo(t)
+	writeTestFile(t, tmpDir, ".github/workflows/ci.yml", []byte("on: push\n"))
+	writeTestFile(t, tmpDir, "old.txt", []byte("rename me"))
+	writeTestFile(t, tmpDir, "kept.txt", []byte("unchanged"))
+	runGit("add", ".")
+	runGit("commit", "-q", "-m", "initial")
+
+	if err := os.Remove(filepath.Join(tmpDir, ".github/workflows/ci.yml")); err != nil {
+		t.Fatal(err)
+	}
+	runGit("mv", "old.txt", "new.txt")
+	writeTestFile(t, tmpDir, "secrets/.env", []byte("TOKEN=x"))
+
+	files, err := NewOperations(tmpDir, nil, nil).ChangedFiles(tmpDir)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	sort.Strings(files)
+
+	expected := []string{".github/workflows/ci.yml", "new.txt", "old.txt", "secrets/.env"}
+	if !reflect.DeepEqual(files, expected) {
+		t.Errorf("Expected %v, got %v", expected, files)
+	}
+}
@@ -0,0 +1,71 @@
+package git
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Rebasing an issue branch onto its PR's base when the PR conflicts
+
+// RebaseConflictError reports a rebase that stopped on conflicts; the rebase
+// has been aborted and the branch is unchanged
+type RebaseConflictError struct {
+	Onto  string   // Ref the branch was rebased onto, e.g. origin/main
+	Files []string // Files with conflicts
+}
+
+func (e *RebaseConflictError) Error() string {
+	if len(e.Files) == 0 {
+		return fmt.Sprintf("rebase onto %s stopped on conflicts", e.Onto)
+	}
+	return fmt.Sprintf("rebase onto %s conflicts in %s", e.Onto, strings.Join(e.Files, ", "))
+}
+
+// BaseRemote returns the remote holding PR base branches
+func (g *Operations) BaseRemote() string {
+	if g.config != nil && g.config.RemoteName != "" {
+		return g.config.RemoteName
+	}
+	return "origin"
+}
+
+// RebaseOntoRemoteBase fetches base from the base remote and rebases the
+// worktree's branch onto it. On conflicts the rebase is aborted, leaving the
+// branch as it was, and a *RebaseConflictError names the conflicting files.
+func (g *Operations) RebaseOntoRemoteBase(worktreePath, base string) error {
+	remote := g.BaseRemote()
+	if err := ExecuteGitCommandWithRetry([]string{"fetch", remote, base}, worktreePath); err != nil {
+		return fmt.Errorf("failed to fetch %s from %s: %w", base, remote, err)
+	}
+
+	onto := remote + "/" + base
+	output, err := CreateGitCommand([]string{"rebase", onto}, worktreePath).CombinedOutput()
+	if err == nil {
+		return nil
+	}
+
+	conflicted, _ := CreateGitCommand([]string{"diff", "--name-only", "--diff-filter=U", "-z"}, worktreePath).Output()
+	if abortErr := CreateGitCommand([]string{"rebase", "--abort"}, worktreePath).Run(); abortErr != nil {
+		return fmt.Errorf("failed to rebase onto %s: %w\nOutput: %s\nand failed to abort the rebase: %v", onto, err, string(output), abortErr)
+	}
+
+	files := parseNulSeparatedNames(string(conflicted))
+	if len(files) > 0 || strings.Contains(string(output), "CONFLICT") {
+		return &RebaseConflictError{Onto: onto, Files: files}
+	}
+	return fmt.Errorf("failed to rebase onto %s: %w\nOutput: %s", onto, err, string(output))
+}
+
+// forcePushArgs builds the git arguments that replace branchName on the push
+// remote, refusing when the remote branch moved since it was last fetched
+func (g *Operations) forcePushArgs(branchName string) []string {
+	return []string{"push", "--force-with-lease", "-u", g.PushRemote(), branchName}
+}
+
+// ForcePushBranch pushes a rewritten branch, such as one just rebased
+func (g *Operations) ForcePushBranch(worktreePath, branchName string) error {
+	if err := ExecuteGitCommandWithRetry(g.forcePushArgs(branchName), worktreePath); err != nil {
+		return fmt.Errorf("failed to force-push branch: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package git
+
+import (
+	"errors"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestRebaseOntoRemoteBase(t *testing.T) {
+	upstreamDir, runUpstream := initTestRepo(t)
+	tmpDir := t.TempDir()
+	remoteDir := filepath.Join(tmpDir, "remote.git")
+	workDir := filepath.Join(tmpDir, "work")
+
+	runGit := func(dir string, args ...string) string {
+		t.Helper()
+		return runUpstream(append([]string{"-C", dir}, args...)...)
+	}
+	commit := func(dir, name, content, message string) {
+		t.Helper()
+		writeTestFile(t, dir, name, []byte(content))
+		runGit(dir, "add", ".")
+		runGit(dir, "commit", "-q", "-m", message)
+	}
+
+	runGit(tmpDir, "init", "-q", "--bare", "-b", "main", remoteDir)
+	runGit(upstreamDir, "remote", "add", "origin", remoteDir)
+	commit(upstreamDir, "Read Me.md", "hello\n", "initial")
+	runGit(upstreamDir, "push", "-q", "origin", "main")
+
+	runGit(tmpDir, "clone", "-q", remoteDir, workDir)
+	runGit(workDir, "config", "user.email", "test@example.com")
+	runGit(workDir, "config", "user.name", "Test")
+	runGit(workDir, "checkout", "-q", "-b", "issue-1")
+
+	ops := NewOperations(tmpDir, &GitOperationConfig{RemoteName: "origin"}, nil)
+
+	t.Run("clean rebase", func(t *testing.T) {
+		commit(upstreamDir, "Lexer.swift", "lexer\n", "add lexer")
+		runGit(upstreamDir, "push", "-q", "origin", "main")
+		commit(workDir, "Parser.swift", "parser\n", "add parser")
+
+		if err := ops.RebaseOntoRemoteBase(workDir, "main"); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if log := runGit(workDir, "log", "--format=%s"); log != "add parser\nadd lexer\ninitial" {
+			t.Errorf("Expected the branch on top of main, got:\n%s", log)
+		}
+	})
+
+	t.Run("conflict is aborted", func(t *testing.T) {
+		commit(upstreamDir, "Read Me.md", "upstream\n", "edit readme upstream")
+		runGit(upstreamDir, "push", "-q", "origin", "main")
+		commit(workDir, "Read Me.md", "branch\n", "edit readme on branch")
+		head := runGit(workDir, "rev-parse", "HEAD")
+
+		err := ops.RebaseOntoRemoteBase(workDir, "main")
+		var conflict *RebaseConflictError
+		if !errors.As(err, &conflict) {
+			t.Fatalf("Expected RebaseConflictError, got %v", err)
+		}
+		if conflict.Onto != "origin/main" || !reflect.DeepEqual(conflict.Files, []string{"Read Me.md"}) {
+			t.Errorf("Unexpected conflict %+v", conflict)
+		}
+		if after := runGit(workDir, "rev-parse", "HEAD"); after != head {
+			t.Errorf("Expected the branch to stay at %s, got %s", head, after)
+		}
+		if status := runGit(workDir, "status", "--porcelain"); status != "" {
+			t.Errorf("Expected a clean tree after aborting, got:\n%s", status)
+		}
+	})
+}
+
+func TestForcePushArgs(t *testing.T) {
+	ops := NewOperations(".", &GitOperationConfig{PushRemote: "fork"}, nil)
+	expected := []string{"push", "--force-with-lease", "-u", "fork", "issue-1"}
+	if args := ops.forcePushArgs("issue-1"); !reflect.DeepEqual(args, expected) {
+		t.Errorf("Expected %q, got %q", expected, args)
+	}
+}
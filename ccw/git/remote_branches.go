@@ -0,0 +1,126 @@
+package git
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Finding remote CCW branches that can be pruned
+
+// ccwBranchPattern matches branches CCW creates: issue-<number>-<timestamp>,
+// optionally under a git.branch_type_map prefix such as fix/
+var ccwBranchPattern = regexp.MustCompile(`^([A-Za-z0-9._-]+/)?issue-\d+-`)
+
+// IsCCWBranch reports whether name looks like a branch created by CCW
+func IsCCWBranch(name string) bool {
+	return ccwBranchPattern.MatchString(name)
+}
+
+// RemoteBranch is a branch on the push remote
+type RemoteBranch struct {
+	Name        string // Branch name without the remote prefix
+	CommittedAt time.Time
+}
+
+// remoteBranchFormat prints each remote-tracking ref with its commit time
+const remoteBranchFormat = "%(refname:short)%09%(committerdate:unix)"
+
+// ListRemoteBranches fetches the push remote with --prune and returns its
+// branches, sorted by name
+func (g *Operations) ListRemoteBranches(dir string) ([]RemoteBranch, error) {
+	remote := g.PushRemote()
+	if err := ExecuteGitCommandWithRetry([]string{"fetch", "--prune", remote}, dir); err != nil {
+		return nil, fmt.Errorf("failed to fetch %s: %w", remote, err)
+	}
+
+	output, err := CreateGitCommand([]string{"for-each-ref", "--format=" + remoteBranchFormat, "refs/remotes/" + remote + "/"}, dir).Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list branches of %s: %w", remote, err)
+	}
+	return parseRemoteBranches(string(output), remote), nil
+}
+
+// MergedRemoteBranches returns the push remote's branches whose tips are
+// reachable from its default branch. Squash and rebase merges are not detected.
+func (g *Operations) MergedRemoteBranches(dir string) (map[string]bool, error) {
+	remote := g.PushRemote()
+	var defaultBranch string
+	if g.config != nil {
+		defaultBranch = g.config.DefaultBranch
+	}
+
+	for _, candidate := range diffBaseCandidates(remote, defaultBranch) {
+		if !strings.HasPrefix(candidate, remote+"/") {
+			continue
+		}
+		output, err := CreateGitCommand([]string{"for-each-ref", "--format=" + remoteBranchFormat, "--merged=" + candidate, "refs/remotes/" + remote + "/"}, dir).Output()
+		if err != nil {
+			continue
+		}
+		merged := make(map[string]bool)
+		for _, branch := range parseRemoteBranches(string(output), remote) {
+			merged[branch.Name] = true
+		}
+		return merged, nil
+	}
+	return nil, fmt.Errorf("no default branch found on %s", remote)
+}
+
+// parseRemoteBranches parses remoteBranchFormat lines, stripping the remote
+// prefix and skipping the remote's HEAD
+func parseRemoteBranches(output, remote string) []RemoteBranch {
+	var branches []RemoteBranch
+	for _, line := range strings.Split(output, "\n") {
+		ref, unix, found := strings.Cut(strings.TrimSpace(line), "\t")
+		name, ok := strings.CutPrefix(ref, remote+"/")
+		if !found || !ok || name == "HEAD" || name == "" {
+			continue
+		}
+		branch := RemoteBranch{Name: name}
+		if seconds, err := strconv.ParseInt(unix, 10, 64); err == nil {
+			branch.CommittedAt = time.Unix(seconds, 0)
+		}
+		branches = append(branches, branch)
+	}
+	sort.Slice(branches, func(i, j int) bool { return branches[i].Name < branches[j].Name })
+	return branches
+}
+
+// BranchPruneOptions selects which CCW branches to prune
+type BranchPruneOptions struct {
+	Merged    bool          // Prune branches whose work was merged
+	OlderThan time.Duration // Prune branches whose last commit is at least this old (0 = off)
+	Now       time.Time
+}
+
+// PruneCandidate is a branch selected for deletion and why
+type PruneCandidate struct {
+	Branch RemoteBranch
+	Reason string
+}
+
+// SelectBranchesToPrune returns the CCW branches that are merged (when
+// options.Merged) or older than options.OlderThan. Branches that do not match
+// the CCW naming pattern are never selected.
+func SelectBranchesToPrune(branches []RemoteBranch, merged map[string]bool, options BranchPruneOptions) []PruneCandidate {
+	var candidates []PruneCandidate
+	for _, branch := range branches {
+		if !IsCCWBranch(branch.Name) {
+			continue
+		}
+		switch {
+		case options.Merged && merged[branch.Name]:
+			candidates = append(candidates, PruneCandidate{Branch: branch, Reason: "merged"})
+		case options.OlderThan > 0 && !branch.CommittedAt.IsZero() && options.Now.Sub(branch.CommittedAt) >= options.OlderThan:
+			candidates = append(candidates, PruneCandidate{
+				Branch: branch,
+				Reason: fmt.Sprintf("last commit %s ago", formatWorktreeAge(options.Now.Sub(branch.CommittedAt))),
+			})
+		}
+	}
+	return candidates
+}
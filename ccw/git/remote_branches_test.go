@@ -0,0 +1,85 @@
+package git
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestIsCCWBranch(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected bool
+	}{
+		{"issue-12-20240101-120000", true},
+		{"fix/issue-12-20240101-120000", true},
+		{"feat/issue-3-x", true},
+		{"main", false},
+		{"feature/login", false},
+		{"issue-12", false},
+		{"my-issue-12-x", false},
+	}
+
+	for _, tt := range tests {
+		if got := IsCCWBranch(tt.name); got != tt.expected {
+			t.Errorf("IsCCWBranch(%q): expected %v, got %v", tt.name, tt.expected, got)
+		}
+	}
+}
+
+func TestParseRemoteBranches(t *testing.T) {
+	output := "origin/HEAD\t1700000000\norigin/main\t1700000000\norigin/fix/issue-2-a\t1700000100\nupstream/issue-9-x\t1\norigin/issue-1-b\tbad\n"
+
+	branches := parseRemoteBranches(output, "origin")
+	expected := []RemoteBranch{
+		{Name: "fix/issue-2-a", CommittedAt: time.Unix(1700000100, 0)},
+		{Name: "issue-1-b"},
+		{Name: "main", CommittedAt: time.Unix(1700000000, 0)},
+	}
+	if !reflect.DeepEqual(branches, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, branches)
+	}
+}
+
+func TestSelectBranchesToPrune(t *testing.T) {
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+	branches := []RemoteBranch{
+		{Name: "issue-1-merged", CommittedAt: now.Add(-2 * day)},
+		{Name: "issue-2-stale", CommittedAt: now.Add(-40 * day)},
+		{Name: "fix/issue-3-fresh", CommittedAt: now.Add(-time.Hour)},
+		{Name: "issue-4-merged-and-stale", CommittedAt: now.Add(-60 * day)},
+		{Name: "issue-5-unknown-date"},
+		{Name: "main", CommittedAt: now.Add(-90 * day)},
+		{Name: "feature/merged", CommittedAt: now.Add(-90 * day)},
+	}
+	merged := map[string]bool{
+		"issue-1-merged":           true,
+		"issue-4-merged-and-stale": true,
+		"main":                     true,
+		"feature/merged":           true,
+	}
+
+	tests := []struct {
+		name     string
+		options  BranchPruneOptions
+		expected []string
+	}{
+		{"merged only", BranchPruneOptions{Merged: true, Now: now}, []string{"issue-1-merged: merged", "issue-4-merged-and-stale: merged"}},
+		{"older than 30 days", BranchPruneOptions{OlderThan: 30 * day, Now: now}, []string{"issue-2-stale: last commit 40d ago", "issue-4-merged-and-stale: last commit 60d ago"}},
+		{"merged or stale", BranchPruneOptions{Merged: true, OlderThan: 30 * day, Now: now}, []string{"issue-1-merged: merged", "issue-2-stale: last commit 40d ago", "issue-4-merged-and-stale: merged"}},
+		{"nothing selected", BranchPruneOptions{Now: now}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, candidate := range SelectBranchesToPrune(branches, merged, tt.options) {
+				got = append(got, candidate.Branch.Name+": "+candidate.Reason)
+			}
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package git
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Remote selection for pushing branches
+
+// DefaultPushRemote is the remote branches are pushed to when none is configured
+const DefaultPushRemote = "origin"
+
+// PushRemote returns the configured push remote, or DefaultPushRemote
+func (g *Operations) PushRemote() string {
+	if g.config != nil && g.config.PushRemote != "" {
+		return g.config.PushRemote
+	}
+	return DefaultPushRemote
+}
+
+// pushArgs builds the git arguments that push branchName to the push remote
+func (g *Operations) pushArgs(branchName string) []string {
+	return []string{"push", "-u", g.PushRemote(), branchName}
+}
+
+// deleteRemoteBranchArgs builds the git arguments that delete branchName from the push remote
+func (g *Operations) deleteRemoteBranchArgs(branchName string) []string {
+	return []string{"push", g.PushRemote(), "--delete", branchName}
+}
+
+// DeleteRemoteBranch deletes branchName from the push remote, running git in dir
+func (g *Operations) DeleteRemoteBranch(dir, branchName string) error {
+	if err := ExecuteGitCommandWithRetry(g.deleteRemoteBranchArgs(branchName), dir); err != nil {
+		return fmt.Errorf("failed to delete remote branch %s: %w", branchName, err)
+	}
+	return nil
+}
+
+// ListRemotes returns the remote names reported by `git remote`
+func (g *Operations) ListRemotes(dir string) ([]string, error) {
+	cmd := CreateGitCommand([]string{"remote"}, dir)
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list git remotes: %w", err)
+	}
+	return parseRemotes(string(output)), nil
+}
+
+// RemoteURL returns the fetch URL of the named remote
+func (g *Operations) RemoteURL(dir, remote string) (string, error) {
+	cmd := CreateGitCommand([]string{"remote", "get-url", remote}, dir)
+	output, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to get URL of remote %s: %w", remote, err)
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// ValidatePushRemote checks that the configured push remote exists in dir
+func (g *Operations) ValidatePushRemote(dir string) error {
+	remotes, err := g.ListRemotes(dir)
+	if err != nil {
+		return err
+	}
+	return checkRemoteExists(remotes, g.PushRemote())
+}
+
+// parseRemotes splits `git remote` output into remote names
+func parseRemotes(output string) []string {
+	var remotes []string
+	for _, line := range strings.Split(output, "\n") {
+		if name := strings.TrimSpace(line); name != "" {
+			remotes = append(remotes, name)
+		}
+	}
+	return remotes
+}
+
+// checkRemoteExists returns an error naming the available remotes when remote is not among them
+func checkRemoteExists(remotes []string, remote string) error {
+	for _, name := range remotes {
+		if name == remote {
+			return nil
+		}
+	}
+	if len(remotes) == 0 {
+		return fmt.Errorf("push remote %q not found: repository has no remotes", remote)
+	}
+	return fmt.Errorf("push remote %q not found (available: %s); set git.push_remote", remote, strings.Join(remotes, ", "))
+}
@@ -0,0 +1,108 @@
+package git
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestPushRemoteFromConfig(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   *GitOperationConfig
+		expected string
+	}{
+		{"nil config", nil, "origin"},
+		{"empty push remote", &GitOperationConfig{}, "origin"},
+		{"configured push remote", &GitOperationConfig{PushRemote: "fork"}, "fork"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ops := NewOperations(".", tt.config, nil)
+			if remote := ops.PushRemote(); remote != tt.expected {
+				t.Errorf("Expected '%s', got '%s'", tt.expected, remote)
+			}
+
+			args := ops.pushArgs("issue-1")
+			expected := []string{"push", "-u", tt.expected, "issue-1"}
+			if strings.Join(args, "|") != strings.Join(expected, "|") {
+				t.Errorf("Expected %q, got %q", expected, args)
+			}
+
+			args = ops.deleteRemoteBranchArgs("issue-1")
+			expected = []string{"push", tt.expected, "--delete", "issue-1"}
+			if strings.Join(args, "|") != strings.Join(expected, "|") {
+				t.Errorf("Expected %q, got %q", expected, args)
+			}
+		})
+	}
+}
+
+func TestCheckRemoteExists(t *testing.T) {
+	remotes := parseRemotes("origin\nupstream\n\n")
+
+	if err := checkRemoteExists(remotes, "upstream"); err != nil {
+		t.Errorf("Expected upstream to exist, got %v", err)
+	}
+
+	err := checkRemoteExists(remotes, "fork")
+	if err == nil {
+		t.Fatal("Expected error for missing remote")
+	}
+	if !strings.Contains(err.Error(), "origin, upstream") {
+		t.Errorf("Expected available remotes in error, got '%s'", err.Error())
+	}
+
+	if err := checkRemoteExists(nil, "origin"); err == nil || !strings.Contains(err.Error(), "no remotes") {
+		t.Errorf("Expected 'no remotes' error, got %v", err)
+	}
+}
+
+func TestPushBranchTargetsConfiguredRemote(t *testing.T) {
+	repoDir, runRepo := initTestRepo(t)
+	tmpDir := t.TempDir()
+	originDir := filepath.Join(tmpDir, "origin.git")
+	forkDir := filepath.Join(tmpDir, "fork.git")
+
+	runGit := func(dir string, args ...string) string {
+		t.Helper()
+		return runRepo(append([]string{"-C", dir}, args...)...)
+	}
+
+	runGit(tmpDir, "init", "-q", "--bare", originDir)
+	runGit(tmpDir, "init", "-q", "--bare", forkDir)
+	runGit(repoDir, "remote", "add", "origin", originDir)
+	runGit(repoDir, "remote", "add", "fork", forkDir)
+	writeTestFile(t, repoDir, "README.md", []byte("hello"))
+	runGit(repoDir, "add", ".")
+	runGit(repoDir, "commit", "-q", "-m", "initial")
+	runGit(repoDir, "checkout", "-q", "-b", "issue-7")
+
+	ops := NewOperations(repoDir, &GitOperationConfig{PushRemote: "fork"}, nil)
+	if err := ops.ValidatePushRemote(repoDir); err != nil {
+		t.Fatalf("Expected fork remote to validate, got %v", err)
+	}
+	if err := ops.PushBranch(repoDir, "issue-7"); err != nil {
+		t.Fatalf("PushBranch failed: %v", err)
+	}
+
+	if heads := runGit(repoDir, "ls-remote", "--heads", "fork", "issue-7"); !strings.Contains(heads, "refs/heads/issue-7") {
+		t.Errorf("Expected issue-7 on fork, got '%s'", heads)
+	}
+	if heads := runGit(repoDir, "ls-remote", "--heads", "origin", "issue-7"); strings.TrimSpace(heads) != "" {
+		t.Errorf("Expected nothing pushed to origin, got '%s'", heads)
+	}
+
+	if err := ops.DeleteRemoteBranch(repoDir, "issue-7"); err != nil {
+		t.Fatalf("DeleteRemoteBranch failed: %v", err)
+	}
+	if heads := runGit(repoDir, "ls-remote", "--heads", "fork", "issue-7"); strings.TrimSpace(heads) != "" {
+		t.Errorf("Expected issue-7 deleted from fork, got '%s'", heads)
+	}
+
+	missing := NewOperations(repoDir, &GitOperationConfig{PushRemote: "upstream"}, nil)
+	if err := missing.ValidatePushRemote(repoDir); err == nil {
+		t.Error("Expected validation error for missing upstream remote")
+	}
+}
@@ -0,0 +1,41 @@
+package git
+
+import "sort"
+
+// Detection of test files among changed paths
+
+// DefaultTestFilePatterns are gitignore-style patterns for test files in the
+// languages ccw is commonly used with
+var DefaultTestFilePatterns = []string{
+	// Directories
+	"Tests/", "test/", "tests/", "__tests__/", "spec/",
+	// Go
+	"*_test.go",
+	// Swift, Java, Kotlin, C#
+	"*Tests.swift", "*Test.swift", "*Test.java", "*Tests.java", "*Test.kt", "*Tests.cs",
+	// JavaScript, TypeScript
+	"*.test.js", "*.spec.js", "*.test.jsx", "*.spec.jsx",
+	"*.test.ts", "*.spec.ts", "*.test.tsx", "*.spec.tsx",
+	// Python
+	"test_*.py", "*_test.py",
+	// Ruby
+	"*_spec.rb", "*_test.rb",
+	// Rust
+	"*_test.rs",
+}
+
+// ChangedTestFiles returns the files that are tests according to
+// DefaultTestFilePatterns plus extraPatterns, sorted by path
+func ChangedTestFiles(files []string, extraPatterns ...string) []string {
+	patterns := append(append([]string{}, DefaultTestFilePatterns...), extraPatterns...)
+	matcher := NewPathMatcher(patterns)
+
+	var testFiles []string
+	for _, file := range files {
+		if matched, _ := matcher.Match(file); matched {
+			testFiles = append(testFiles, file)
+		}
+	}
+	sort.Strings(testFiles)
+	return testFiles
+}
@@ -0,0 +1,34 @@
+package git
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestChangedTestFiles(t *testing.T) {
+	tests := []struct {
+		name     string
+		files    []string
+		extra    []string
+		expected []string
+	}{
+		{"go", []string{"lexer/lexer.go", "lexer/lexer_test.go"}, nil, []string{"lexer/lexer_test.go"}},
+		{"swift test target", []string{"Sources/Parser/Parser.swift", "Tests/ParserTests/ParserTests.swift"}, nil, []string{"Tests/ParserTests/ParserTests.swift"}},
+		{"typescript", []string{"src/app.ts", "src/app.spec.ts", "src/view.test.tsx"}, nil, []string{"src/app.spec.ts", "src/view.test.tsx"}},
+		{"javascript tests directory", []string{"lib/index.js", "lib/__tests__/index.js"}, nil, []string{"lib/__tests__/index.js"}},
+		{"python", []string{"pkg/core.py", "pkg/test_core.py", "pkg/io_test.py"}, nil, []string{"pkg/io_test.py", "pkg/test_core.py"}},
+		{"ruby", []string{"app/models/user.rb", "spec/models/user_spec.rb"}, nil, []string{"spec/models/user_spec.rb"}},
+		{"java", []string{"src/main/java/App.java", "src/test/java/AppTest.java"}, nil, []string{"src/test/java/AppTest.java"}},
+		{"no tests", []string{"README.md", "main.go", "contest.go"}, nil, nil},
+		{"extra pattern", []string{"integration/run.sh", "main.go"}, []string{"integration/"}, []string{"integration/run.sh"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ChangedTestFiles(tt.files, tt.extra...)
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package git
+
+import (
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+// initTestRepo creates an empty repository on main with a test identity in a
+// temporary directory and returns it with a runGit that runs git there,
+// failing the test on error and returning the trimmed output. The test is
+// skipped when git is not available.
+func initTestRepo(t *testing.T) (string, func(args ...string) string) {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+
+	dir := t.TempDir()
+	runGit := func(args ...string) string {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		output, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, output)
+		}
+		return strings.TrimSpace(string(output))
+	}
+	runGit("init", "-q", "-b", "main")
+	runGit("config", "user.email", "test@example.com")
+	runGit("config", "user.name", "Test")
+	return dir, runGit
+}
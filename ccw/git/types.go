@@ -14,6 +14,15 @@ type GitOperationConfig struct {
 	Timeout       time.Duration
 	RetryAttempts int
 	RetryDelay    time.Duration
+	AuthorName    string // Overrides user.name for commits when set
+	AuthorEmail   string // Overrides user.email for commits when set
+	PushRemote    string // Remote branches are pushed to; DefaultPushRemote when empty
+	RemoteName    string // Remote holding the default branch; "origin" when empty
+	DefaultBranch string // Branch diffs are taken against when no base is given
+
+	WorktreeCreateRetries int  // Extra attempts after a transient worktree creation failure
+	WorktreeRemoveRetries int  // Extra attempts while a worktree's files are locked during removal
+	NoForceRemove         bool // Report a worktree that cannot be removed instead of deleting its directory
 }
 
 // Operations manages git operations with timeout and retry configuration
@@ -37,13 +46,14 @@ type WorktreeConfig struct {
 
 // ValidationResult represents the result of code quality validation
 type ValidationResult struct {
-	Success     bool                    `json:"success"`
-	LintResult  *LintResult             `json:"lint_result,omitempty"`
-	BuildResult *BuildResult            `json:"build_result,omitempty"`
-	TestResult  *TestResult             `json:"test_result,omitempty"`
-	Errors      []types.ValidationError `json:"errors,omitempty"`
-	Duration    time.Duration           `json:"duration"`
-	Timestamp   time.Time               `json:"timestamp"`
+	Success     bool                      `json:"success"`
+	LintResult  *LintResult               `json:"lint_result,omitempty"`
+	BuildResult *BuildResult              `json:"build_result,omitempty"`
+	TestResult  *TestResult               `json:"test_result,omitempty"`
+	Errors      []types.ValidationError   `json:"errors,omitempty"`
+	Warnings    []types.ValidationWarning `json:"warnings,omitempty"` // Non-blocking warnings from every tool
+	Duration    time.Duration             `json:"duration"`
+	Timestamp   time.Time                 `json:"timestamp"`
 }
 
 // LintResult represents SwiftLint execution results
@@ -57,9 +67,10 @@ type LintResult struct {
 
 // BuildResult represents Swift build results
 type BuildResult struct {
-	Success bool   `json:"success"`
-	Output  string `json:"output"`
-	Error   string `json:"error"`
+	Success  bool     `json:"success"`
+	Output   string   `json:"output"`
+	Error    string   `json:"error"`
+	Warnings []string `json:"warnings,omitempty"`
 }
 
 // TestResult represents Swift test execution results
@@ -69,6 +80,8 @@ type TestResult struct {
 	TestCount int    `json:"test_count"`
 	Passed    int    `json:"passed"`
 	Failed    int    `json:"failed"`
+
+	FailedTests []string `json:"failed_tests,omitempty"` // Names of the failing test cases
 }
 
 // QualityValidator handles code quality validation
@@ -76,6 +89,12 @@ type QualityValidator struct {
 	swiftlintEnabled bool
 	buildEnabled     bool
 	testsEnabled     bool
+	containerImage   string        // Run validation commands in this Docker image when set
+	runCommand       CommandRunner // Executes validation commands; defaults to the host
+	parallel         bool          // Run independent validation stages concurrently
+	scoped           bool          // Limit lint to changedFiles
+	changedFiles     []string      // Worktree-relative files changed since the validation scope ref
+	env              []string      // Extra KEY=value variables for validation commands
 }
 
 // Issue represents a GitHub issue (minimal definition for git package)
@@ -0,0 +1,29 @@
+package git
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Directories ccw keeps inside a worktree without committing them
+
+// EnsureUncommittedDir creates dir with a .gitignore ignoring everything in
+// it, so ccw's own files there are never staged with the implementation. what
+// names the files for the comment in the .gitignore. An existing .gitignore
+// is left alone.
+func EnsureUncommittedDir(dir, what string) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", dir, err)
+	}
+	ignorePath := filepath.Join(dir, ".gitignore")
+	if _, err := os.Stat(ignorePath); !errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	content := fmt.Sprintf("# Created by ccw; %s are never committed\n*\n", what)
+	if err := os.WriteFile(ignorePath, []byte(content), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", ignorePath, err)
+	}
+	return nil
+}
@@ -1,6 +1,7 @@
 package git
 
 import (
+	"context"
 	"fmt"
 	"os/exec"
 	"regexp"
@@ -15,17 +16,58 @@ import (
 
 // Validate implementation
 func (qv *QualityValidator) ValidateImplementation(projectPath string) (*ValidationResult, error) {
+	return qv.ValidateImplementationContext(context.Background(), projectPath)
+}
+
+// ValidateImplementationContext runs the enabled lint, build and test stages,
+// concurrently where their dependencies allow when the validator is parallel.
+// Stages not yet started when ctx is done are skipped and reported as errors.
+func (qv *QualityValidator) ValidateImplementationContext(ctx context.Context, projectPath string) (*ValidationResult, error) {
+	return qv.validateStages(ctx, projectPath, nil)
+}
+
+// ValidateStages runs only the named enabled stages ("lint", "build", "test"),
+// skipping those not yet started when ctx is done. The result holds just
+// those stages; use RerunStages to pick them.
+func (qv *QualityValidator) ValidateStages(ctx context.Context, projectPath string, stages []string) (*ValidationResult, error) {
+	selected := make(map[string]bool, len(stages))
+	for _, stage := range stages {
+		selected[stage] = true
+	}
+	return qv.validateStages(ctx, projectPath, selected)
+}
+
+// validateStages runs the enabled stages in selected, or all of them when
+// selected is nil
+func (qv *QualityValidator) validateStages(ctx context.Context, projectPath string, selected map[string]bool) (*ValidationResult, error) {
 	result := &ValidationResult{
 		Success:   true,
 		Timestamp: time.Now(),
 	}
 	start := time.Now()
 
-	// Run SwiftLint
-	if qv.swiftlintEnabled {
-		lintResult, err := qv.runSwiftLint(projectPath)
-		if err != nil {
-			result.Success = false
+	var stages []validationStage
+	addStage := func(name string, run func() []types.ValidationError) {
+		if selected != nil && !selected[name] {
+			return
+		}
+		stages = append(stages, validationStage{Name: name, DependsOn: validationStageDependencies[name], Run: run})
+	}
+
+	// Run SwiftLint. Its fix pass rewrites sources, so it finishes before any
+	// stage starts; only the read-only check may overlap a parallel build.
+	if qv.swiftlintEnabled && (selected == nil || selected["lint"]) {
+		var fixOutput []byte
+		var fixed bool
+		if ctx.Err() == nil {
+			fixOutput, fixed = qv.fixSwiftLint(projectPath)
+		}
+		addStage("lint", func() []types.ValidationError {
+			lintResult, err := qv.checkSwiftLint(projectPath, fixOutput, fixed)
+			result.LintResult = lintResult
+			if err == nil {
+				return nil
+			}
 			validationErr := types.NewCommandValidationError(
 				"lint",
 				"SwiftLint validation failed",
@@ -37,19 +79,19 @@ func (qv *QualityValidator) ValidateImplementation(projectPath string) (*Validat
 			)
 			validationErr.AddContext("project_path", projectPath)
 			validationErr.AddContext("auto_fix_attempted", "true")
-			result.Errors = append(result.Errors, validationErr)
-		}
-		result.LintResult = lintResult
-		if lintResult != nil && !lintResult.Success {
-			result.Success = false
-		}
+			qv.addContainerContext(&validationErr)
+			return []types.ValidationError{validationErr}
+		})
 	}
 
 	// Run build
 	if qv.buildEnabled {
-		buildResult, err := qv.runBuild(projectPath)
-		if err != nil {
-			result.Success = false
+		addStage("build", func() []types.ValidationError {
+			buildResult, err := qv.runBuild(projectPath)
+			result.BuildResult = buildResult
+			if err == nil {
+				return nil
+			}
 			validationErr := types.NewCommandValidationError(
 				"build",
 				"Swift build failed",
@@ -61,19 +103,19 @@ func (qv *QualityValidator) ValidateImplementation(projectPath string) (*Validat
 			)
 			validationErr.AddContext("project_path", projectPath)
 			validationErr.AddContext("build_configuration", "debug")
-			result.Errors = append(result.Errors, validationErr)
-		}
-		result.BuildResult = buildResult
-		if buildResult != nil && !buildResult.Success {
-			result.Success = false
-		}
+			qv.addContainerContext(&validationErr)
+			return []types.ValidationError{validationErr}
+		})
 	}
 
 	// Run tests
 	if qv.testsEnabled {
-		testResult, err := qv.runTests(projectPath)
-		if err != nil {
-			result.Success = false
+		addStage("test", func() []types.ValidationError {
+			testResult, err := qv.runTests(projectPath)
+			result.TestResult = testResult
+			if err == nil {
+				return nil
+			}
 			validationErr := types.NewCommandValidationError(
 				"test",
 				"Swift tests failed",
@@ -86,49 +128,102 @@ func (qv *QualityValidator) ValidateImplementation(projectPath string) (*Validat
 			validationErr.AddContext("project_path", projectPath)
 			validationErr.AddContext("test_count", fmt.Sprintf("%d", testResult.TestCount))
 			validationErr.AddContext("failed_count", fmt.Sprintf("%d", testResult.Failed))
-			result.Errors = append(result.Errors, validationErr)
-		}
-		result.TestResult = testResult
-		if testResult != nil && !testResult.Success {
+			qv.addContainerContext(&validationErr)
+			return []types.ValidationError{validationErr}
+		})
+	}
+
+	// Combine stage results in stage order, whatever order they finished in
+	for _, stageErrors := range runValidationStages(ctx, stages, qv.parallel) {
+		if len(stageErrors) > 0 {
 			result.Success = false
+			result.Errors = append(result.Errors, stageErrors...)
 		}
 	}
+	result.Warnings = validationWarnings(result)
+	if (result.LintResult != nil && !result.LintResult.Success) ||
+		(result.BuildResult != nil && !result.BuildResult.Success) ||
+		(result.TestResult != nil && !result.TestResult.Success) {
+		result.Success = false
+	}
 
 	result.Duration = time.Since(start)
 	return result, nil
 }
 
+// validationWarnings aggregates the warnings of the lint and build results.
+// Warnings are informational and do not affect Success.
+func validationWarnings(result *ValidationResult) []types.ValidationWarning {
+	var lintWarnings, buildWarnings []string
+	if result.LintResult != nil {
+		lintWarnings = result.LintResult.Warnings
+	}
+	if result.BuildResult != nil {
+		buildWarnings = result.BuildResult.Warnings
+	}
+	return types.CollectWarnings(lintWarnings, buildWarnings)
+}
+
+// addContainerContext records the container image on errors from containerized runs
+func (qv *QualityValidator) addContainerContext(validationErr *types.ValidationError) {
+	if qv.containerImage != "" {
+		validationErr.AddContext("container_image", qv.containerImage)
+	}
+}
+
 // Run SwiftLint
 func (qv *QualityValidator) runSwiftLint(projectPath string) (*LintResult, error) {
-	result := &LintResult{}
-
-	// First, try to auto-fix
-	fixCmd := exec.Command("swiftlint", "lint", "--fix")
-	fixCmd.Dir = projectPath
-	fixOutput, fixErr := fixCmd.CombinedOutput()
-	if fixErr == nil {
-		result.AutoFixed = true
+	fixOutput, fixed := qv.fixSwiftLint(projectPath)
+	return qv.checkSwiftLint(projectPath, fixOutput, fixed)
+}
+
+// swiftLintPaths returns the paths to lint, none meaning the whole project.
+// ok is false when a scoped run has no changed Swift files to lint.
+func (qv *QualityValidator) swiftLintPaths() (paths []string, ok bool) {
+	// A scoped run lints only the changed Swift files
+	if !qv.scoped {
+		return nil, true
 	}
+	paths = swiftSourcePaths(qv.changedFiles)
+	return paths, len(paths) > 0
+}
+
+// fixSwiftLint runs swiftlint lint --fix, which rewrites sources in place, and
+// reports whether it succeeded
+func (qv *QualityValidator) fixSwiftLint(projectPath string) ([]byte, bool) {
+	paths, ok := qv.swiftLintPaths()
+	if !ok {
+		return nil, false
+	}
+	output, err := qv.runValidationCommand(projectPath, "swiftlint", append([]string{"lint", "--fix"}, paths...)...)
+	return output, err == nil
+}
 
-	// Then run lint check
-	cmd := exec.Command("swiftlint", "lint")
-	cmd.Dir = projectPath
-	output, err := cmd.CombinedOutput()
+// checkSwiftLint runs the read-only swiftlint lint after fixSwiftLint
+func (qv *QualityValidator) checkSwiftLint(projectPath string, fixOutput []byte, fixed bool) (*LintResult, error) {
+	result := &LintResult{AutoFixed: fixed}
+
+	paths, ok := qv.swiftLintPaths()
+	if !ok {
+		result.Success = true
+		result.Output = "No changed Swift files to lint"
+		return result, nil
+	}
+
+	output, err := qv.runValidationCommand(projectPath, "swiftlint", append([]string{"lint"}, paths...)...)
 
 	result.Output = string(output)
 	result.Success = err == nil
+	// Warnings are reported whether or not lint passes
+	result.Warnings = warningLines(string(output))
 
 	if err != nil {
 		result.Errors = []string{err.Error()}
 		// Try to extract specific errors from output
 		lines := strings.Split(string(output), "\n")
 		for _, line := range lines {
-			if strings.Contains(line, "error:") || strings.Contains(line, "warning:") {
-				if strings.Contains(line, "error:") {
-					result.Errors = append(result.Errors, line)
-				} else {
-					result.Warnings = append(result.Warnings, line)
-				}
+			if strings.Contains(line, "error:") {
+				result.Errors = append(result.Errors, line)
 			}
 		}
 
@@ -140,15 +235,26 @@ func (qv *QualityValidator) runSwiftLint(projectPath string) (*LintResult, error
 	return result, nil
 }
 
+// warningLines returns the tool output lines that report a warning, skipping
+// lines that also report an error
+func warningLines(output string) []string {
+	var warnings []string
+	for _, line := range strings.Split(output, "\n") {
+		if strings.Contains(line, "warning:") && !strings.Contains(line, "error:") {
+			warnings = append(warnings, line)
+		}
+	}
+	return warnings
+}
+
 // Run Swift build
 func (qv *QualityValidator) runBuild(projectPath string) (*BuildResult, error) {
-	cmd := exec.Command("swift", "build")
-	cmd.Dir = projectPath
-	output, err := cmd.CombinedOutput()
+	output, err := qv.runValidationCommand(projectPath, "swift", "build")
 
 	result := &BuildResult{
-		Success: err == nil,
-		Output:  string(output),
+		Success:  err == nil,
+		Output:   string(output),
+		Warnings: warningLines(string(output)),
 	}
 
 	if err != nil {
@@ -161,9 +267,7 @@ func (qv *QualityValidator) runBuild(projectPath string) (*BuildResult, error) {
 
 // Run Swift tests
 func (qv *QualityValidator) runTests(projectPath string) (*TestResult, error) {
-	cmd := exec.Command("swift", "test")
-	cmd.Dir = projectPath
-	output, err := cmd.CombinedOutput()
+	output, err := qv.runValidationCommand(projectPath, "swift", "test")
 
 	result := &TestResult{
 		Success: err == nil,
@@ -194,6 +298,7 @@ func (qv *QualityValidator) runTests(projectPath string) (*TestResult, error) {
 		}
 		result.TestCount = result.Passed + result.Failed
 	}
+	result.FailedTests = failedTestNames(outputStr)
 
 	if err != nil {
 		return result, fmt.Errorf("swift test failed: %w\nOutput: %s\nTest results: %d passed, %d failed",
@@ -203,6 +308,26 @@ func (qv *QualityValidator) runTests(projectPath string) (*TestResult, error) {
 	return result, nil
 }
 
+// failedTestCasePattern matches XCTest failure lines: "Test Case
+// '-[Module.Class testName]' failed" on macOS and "Test Case
+// 'Class.testName' failed" on Linux
+var failedTestCasePattern = regexp.MustCompile(`Test Case '-?\[?([^'\]]+)\]?' failed`)
+
+// failedTestNames returns the failing test cases in swift test output as
+// Class.testName, in order and without duplicates
+func failedTestNames(output string) []string {
+	var names []string
+	seen := make(map[string]bool)
+	for _, match := range failedTestCasePattern.FindAllStringSubmatch(output, -1) {
+		name := strings.ReplaceAll(strings.TrimSpace(match[1]), " ", ".")
+		if !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
 // Check if validation is needed (i.e., there are changes)
 func (qv *QualityValidator) ShouldValidate(gitOps *GitOperations, worktreePath string) (bool, error) {
 	hasChanges, err := gitOps.HasUncommittedChanges(worktreePath)
@@ -0,0 +1,129 @@
+package git
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// Container-backed validation
+
+// containerWorkdir is where the worktree is mounted inside the validation container
+const containerWorkdir = "/src"
+
+// CommandRunner runs a command in dir and returns its combined output
+type CommandRunner func(dir, name string, args ...string) ([]byte, error)
+
+// execCommandRunner runs commands directly on the host
+func execCommandRunner(dir, name string, args ...string) ([]byte, error) {
+	return envCommandRunner(nil)(dir, name, args...)
+}
+
+// envCommandRunner runs commands on the host with env added to ccw's environment
+func envCommandRunner(env []string) CommandRunner {
+	return func(dir, name string, args ...string) ([]byte, error) {
+		cmd := exec.Command(name, args...)
+		cmd.Dir = dir
+		if len(env) > 0 {
+			cmd.Env = append(os.Environ(), env...)
+		}
+		return cmd.CombinedOutput()
+	}
+}
+
+// NewContainerQualityValidator creates a validator that runs lint, build and
+// tests inside image with the worktree mounted at /src
+func NewContainerQualityValidator(image string) *QualityValidator {
+	qv := NewQualityValidator()
+	qv.containerImage = image
+	return qv
+}
+
+// SetCommandRunner replaces how validation commands are executed
+func (qv *QualityValidator) SetCommandRunner(runner CommandRunner) {
+	qv.runCommand = runner
+}
+
+// SetEnv adds KEY=value variables to the environment of validation commands.
+// Containers receive them by name through docker run -e, so values stay off
+// the command line.
+func (qv *QualityValidator) SetEnv(env []string) {
+	qv.env = env
+}
+
+// ContainerImage returns the image validation runs in, or "" for the host
+func (qv *QualityValidator) ContainerImage() string {
+	return qv.containerImage
+}
+
+// Explain describes which validation steps run and where, for --explain output
+func (qv *QualityValidator) Explain() string {
+	var steps []string
+	if qv.swiftlintEnabled {
+		steps = append(steps, "lint")
+	}
+	if qv.buildEnabled {
+		steps = append(steps, "build")
+	}
+	if qv.testsEnabled {
+		steps = append(steps, "test")
+	}
+	if len(steps) == 0 {
+		return "validation: no steps enabled"
+	}
+
+	var explanation string
+	if qv.containerImage != "" {
+		explanation = fmt.Sprintf("validation: running %s in container %s because validation.container_image is set",
+			strings.Join(steps, ", "), qv.containerImage)
+	} else {
+		explanation = fmt.Sprintf("validation: running %s on the host because validation.container_image is empty",
+			strings.Join(steps, ", "))
+	}
+	if qv.scoped && qv.swiftlintEnabled {
+		explanation += fmt.Sprintf("; lint limited to %d changed Swift file(s) because a validation ref is set",
+			len(swiftSourcePaths(qv.changedFiles)))
+	}
+	return explanation
+}
+
+// BuildDockerRunArgs returns docker arguments that run command in image with
+// worktreePath mounted as the working directory
+func BuildDockerRunArgs(image, worktreePath string, command []string) []string {
+	return buildDockerRunArgsWithEnv(image, worktreePath, nil, command)
+}
+
+// buildDockerRunArgsWithEnv is BuildDockerRunArgs that also forwards the
+// variables of env, by name, from the docker client's environment
+func buildDockerRunArgsWithEnv(image, worktreePath string, env []string, command []string) []string {
+	args := []string{"run", "--rm", "-v", worktreePath + ":" + containerWorkdir, "-w", containerWorkdir}
+	for _, variable := range env {
+		name, _, _ := strings.Cut(variable, "=")
+		args = append(args, "-e", name)
+	}
+	args = append(args, image)
+	return append(args, command...)
+}
+
+// runValidationCommand runs a validation command on the host, or inside the
+// configured container when one is set
+func (qv *QualityValidator) runValidationCommand(projectPath, name string, args ...string) ([]byte, error) {
+	runner := qv.runCommand
+	if runner == nil {
+		runner = envCommandRunner(qv.env)
+	}
+
+	if qv.containerImage == "" {
+		return runner(projectPath, name, args...)
+	}
+
+	// Docker bind mounts require an absolute host path
+	mountPath, err := filepath.Abs(projectPath)
+	if err != nil {
+		mountPath = projectPath
+	}
+	command := append([]string{name}, args...)
+	return runner(projectPath, "docker", buildDockerRunArgsWithEnv(qv.containerImage, mountPath, qv.env, command)...)
+}
@@ -0,0 +1,202 @@
+package git
+
+import (
+	"errors"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// recordedCommand captures one invocation of a mocked CommandRunner
+type recordedCommand struct {
+	dir  string
+	name string
+	args []string
+}
+
+// mockRunner returns canned output keyed by the validation command ("swift build", ...)
+type mockRunner struct {
+	calls   []recordedCommand
+	outputs map[string]string
+	fail    map[string]bool
+}
+
+func (m *mockRunner) run(dir, name string, args ...string) ([]byte, error) {
+	m.calls = append(m.calls, recordedCommand{dir: dir, name: name, args: args})
+
+	// Strip the docker prefix so responses are keyed by the inner command
+	command := append([]string{name}, args...)
+	if name == "docker" {
+		for i, arg := range args {
+			// -w <workdir> <image> <command...>
+			if arg == "-w" && i+3 < len(args) {
+				command = args[i+3:]
+				break
+			}
+		}
+	}
+	key := strings.Join(command, " ")
+
+	output := m.outputs[key]
+	if m.fail[key] {
+		return []byte(output), errors.New("exit status 1")
+	}
+	return []byte(output), nil
+}
+
+func TestBuildDockerRunArgs(t *testing.T) {
+	args := BuildDockerRunArgs("swift:5.10", "/work/issue-1", []string{"swift", "build"})
+	expected := []string{"run", "--rm", "-v", "/work/issue-1:/src", "-w", "/src", "swift:5.10", "swift", "build"}
+
+	if !reflect.DeepEqual(args, expected) {
+		t.Errorf("Expected %v, got %v", expected, args)
+	}
+}
+
+func TestContainerValidatorRunsCommandsInDocker(t *testing.T) {
+	runner := &mockRunner{}
+	validator := NewContainerQualityValidator("swift:5.10")
+	validator.SetCommandRunner(runner.run)
+
+	result, err := validator.ValidateImplementation("worktree")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !result.Success {
+		t.Errorf("Expected success, got errors: %+v", result.Errors)
+	}
+
+	mountPath, _ := filepath.Abs("worktree")
+	expectedCommands := [][]string{
+		{"swiftlint", "lint", "--fix"},
+		{"swiftlint", "lint"},
+		{"swift", "build"},
+		{"swift", "test"},
+	}
+	if len(runner.calls) != len(expectedCommands) {
+		t.Fatalf("Expected %d commands, got %d: %+v", len(expectedCommands), len(runner.calls), runner.calls)
+	}
+	for i, call := range runner.calls {
+		if call.name != "docker" {
+			t.Errorf("Command %d: expected docker, got %s", i, call.name)
+		}
+		if call.dir != "worktree" {
+			t.Errorf("Command %d: expected dir 'worktree', got '%s'", i, call.dir)
+		}
+		expectedArgs := BuildDockerRunArgs("swift:5.10", mountPath, expectedCommands[i])
+		if !reflect.DeepEqual(call.args, expectedArgs) {
+			t.Errorf("Command %d: expected %v, got %v", i, expectedArgs, call.args)
+		}
+	}
+}
+
+func TestHostValidatorDoesNotUseDocker(t *testing.T) {
+	runner := &mockRunner{}
+	validator := NewQualityValidator()
+	validator.SetCommandRunner(runner.run)
+
+	if _, err := validator.ValidateImplementation("worktree"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	for _, call := range runner.calls {
+		if call.name == "docker" {
+			t.Errorf("Expected host execution, got docker call %v", call.args)
+		}
+	}
+	if runner.calls[2].name != "swift" || !reflect.DeepEqual(runner.calls[2].args, []string{"build"}) {
+		t.Errorf("Expected 'swift build' on the host, got %s %v", runner.calls[2].name, runner.calls[2].args)
+	}
+}
+
+func TestContainerValidatorMapsResults(t *testing.T) {
+	runner := &mockRunner{
+		outputs: map[string]string{
+			"swift build": "error: no such module 'Parser'",
+			"swift test":  "Test Suite 'All tests' passed\n8 tests passed\n2 tests failed",
+		},
+		fail: map[string]bool{
+			"swift build": true,
+			"swift test":  true,
+		},
+	}
+	validator := NewContainerQualityValidator("swift:5.10")
+	validator.SetCommandRunner(runner.run)
+
+	result, err := validator.ValidateImplementation("worktree")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if result.Success {
+		t.Error("Expected validation failure")
+	}
+	if result.LintResult == nil || !result.LintResult.Success || !result.LintResult.AutoFixed {
+		t.Errorf("Expected successful auto-fixed lint, got %+v", result.LintResult)
+	}
+	if result.BuildResult == nil || result.BuildResult.Success {
+		t.Errorf("Expected failed build, got %+v", result.BuildResult)
+	} else if result.BuildResult.Output != "error: no such module 'Parser'" {
+		t.Errorf("Expected build output to be mapped, got '%s'", result.BuildResult.Output)
+	}
+	if result.TestResult == nil || result.TestResult.Passed != 8 || result.TestResult.Failed != 2 || result.TestResult.TestCount != 10 {
+		t.Errorf("Expected 8 passed and 2 failed tests, got %+v", result.TestResult)
+	}
+
+	if len(result.Errors) != 2 {
+		t.Fatalf("Expected 2 validation errors, got %d", len(result.Errors))
+	}
+	for _, validationErr := range result.Errors {
+		if validationErr.Cause == nil || validationErr.Cause.Context["container_image"] != "swift:5.10" {
+			t.Errorf("Expected container_image context on %s error, got %+v", validationErr.Type, validationErr.Cause)
+		}
+	}
+}
+
+func TestValidatorExplain(t *testing.T) {
+	host := NewQualityValidator().Explain()
+	if host != "validation: running lint, build, test on the host because validation.container_image is empty" {
+		t.Errorf("Unexpected host explanation: %s", host)
+	}
+
+	container := NewContainerQualityValidator("swift:5.10").Explain()
+	if container != "validation: running lint, build, test in container swift:5.10 because validation.container_image is set" {
+		t.Errorf("Unexpected container explanation: %s", container)
+	}
+}
+
+func TestContainerValidatorForwardsEnvByName(t *testing.T) {
+	runner := &mockRunner{}
+	validator := NewContainerQualityValidator("swift:5.10")
+	validator.SetCommandRunner(runner.run)
+	validator.SetEnv([]string{"API_TOKEN=s3cr3t", "REGION=eu"})
+
+	if _, err := validator.runBuild("worktree"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	args := runner.calls[0].args
+	joined := strings.Join(args, " ")
+	if !strings.Contains(joined, "-e API_TOKEN -e REGION swift:5.10 swift build") {
+		t.Errorf("Expected variables forwarded by name before the image, got %v", args)
+	}
+	if strings.Contains(joined, "s3cr3t") {
+		t.Errorf("Expected values to stay off the command line, got %v", args)
+	}
+}
+
+func TestEnvCommandRunner(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh not available")
+	}
+
+	output, err := envCommandRunner([]string{"CCW_TEST_DOTENV=loaded"})(t.TempDir(), "sh", "-c", "echo $CCW_TEST_DOTENV")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if strings.TrimSpace(string(output)) != "loaded" {
+		t.Errorf("Expected 'loaded', got '%s'", output)
+	}
+}
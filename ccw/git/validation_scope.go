@@ -0,0 +1,56 @@
+package git
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Validation limited to files changed since a ref
+
+// ChangedFilesSince returns the files under worktreePath that differ from
+// ref, covering commits after ref, uncommitted edits and untracked files.
+// Paths are relative to worktreePath, which may be a project subdirectory of
+// the worktree. Deleted files are left out since there is nothing left to
+// validate.
+func (g *Operations) ChangedFilesSince(worktreePath, ref string) ([]string, error) {
+	cmd := CreateGitCommand([]string{"rev-parse", "--verify", "--quiet", ref + "^{commit}"}, worktreePath)
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("validation ref %s is not a commit in %s", ref, worktreePath)
+	}
+
+	output, err := CreateGitCommand([]string{"diff", "--name-only", "--relative", "--diff-filter=d", "-z", ref}, worktreePath).Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get files changed since %s: %w", ref, err)
+	}
+	files := parseNulSeparatedNames(string(output))
+
+	output, err = CreateGitCommand([]string{"ls-files", "--others", "--exclude-standard", "-z"}, worktreePath).Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list untracked files: %w", err)
+	}
+	return append(files, parseNulSeparatedNames(string(output))...), nil
+}
+
+// ScopeToFiles limits lint to files, as returned by ChangedFilesSince. Build
+// and tests still cover the whole package since Swift compiles it as a unit.
+func (qv *QualityValidator) ScopeToFiles(files []string) {
+	qv.scoped = true
+	qv.changedFiles = files
+}
+
+// ClearScope makes lint cover the whole project again
+func (qv *QualityValidator) ClearScope() {
+	qv.scoped = false
+	qv.changedFiles = nil
+}
+
+// swiftSourcePaths returns the Swift sources among files
+func swiftSourcePaths(files []string) []string {
+	var paths []string
+	for _, file := range files {
+		if strings.HasSuffix(file, ".swift") {
+			paths = append(paths, file)
+		}
+	}
+	return paths
+}
@@ -0,0 +1,127 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestChangedFilesSince(t *testing.T) {
+	tmpDir, runGit := initTestRepo(t)
+	writeTestFile(t, tmpDir, "Old.swift", []byte("old"))
+	writeTestFile(t, tmpDir, "Removed.swift", []byte("removed"))
+	runGit("add", ".")
+	runGit("commit", "-q", "-m", "initial")
+	since := runGit("rev-parse", "HEAD")
+
+	writeTestFile(t, tmpDir, "Rebased.swift", []byte("rebased"))
+	runGit("add", ".")
+	runGit("commit", "-q", "-m", "after ref")
+	afterRef := runGit("rev-parse", "HEAD")
+
+	writeTestFile(t, tmpDir, "Old.swift", []byte("old edited"))
+	writeTestFile(t, tmpDir, "New File.swift", []byte("untracked"))
+	if err := os.Remove(filepath.Join(tmpDir, "Removed.swift")); err != nil {
+		t.Fatal(err)
+	}
+
+	ops := NewOperations(tmpDir, nil, nil)
+	tests := []struct {
+		name     string
+		ref      string
+		expected []string
+	}{
+		{"commits and worktree changes since ref", since, []string{"New File.swift", "Old.swift", "Rebased.swift"}},
+		{"only worktree changes since a later ref", afterRef, []string{"New File.swift", "Old.swift"}},
+		{"relative ref", "HEAD~1", []string{"New File.swift", "Old.swift", "Rebased.swift"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			files, err := ops.ChangedFilesSince(tmpDir, tt.ref)
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			sort.Strings(files)
+			if !reflect.DeepEqual(files, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, files)
+			}
+		})
+	}
+
+	if _, err := ops.ChangedFilesSince(tmpDir, "no-such-ref"); err == nil {
+		t.Error("Expected error for unknown ref")
+	}
+
+	// From a project subdirectory, both committed and untracked files are
+	// listed relative to it, and changes outside it are left out
+	subDir := filepath.Join(tmpDir, "Package")
+	writeTestFile(t, subDir, filepath.Join("Sources", "Committed.swift"), []byte("committed"))
+	runGit("add", "Package")
+	runGit("commit", "-q", "-m", "package")
+	writeTestFile(t, subDir, filepath.Join("Sources", "Untracked.swift"), []byte("untracked"))
+
+	files, err := ops.ChangedFilesSince(subDir, since)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	sort.Strings(files)
+	expected := []string{"Sources/Committed.swift", "Sources/Untracked.swift"}
+	if !reflect.DeepEqual(files, expected) {
+		t.Errorf("Expected %v, got %v", expected, files)
+	}
+}
+
+func TestScopedLintRunsOnChangedSwiftFiles(t *testing.T) {
+	runner := &mockRunner{}
+	validator := NewQualityValidator()
+	validator.SetCommandRunner(runner.run)
+	validator.ScopeToFiles([]string{"Sources/A.swift", "README.md", "Tests/B.swift"})
+
+	if _, err := validator.runSwiftLint("worktree"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := [][]string{
+		{"lint", "--fix", "Sources/A.swift", "Tests/B.swift"},
+		{"lint", "Sources/A.swift", "Tests/B.swift"},
+	}
+	if len(runner.calls) != len(expected) {
+		t.Fatalf("Expected %d commands, got %d", len(expected), len(runner.calls))
+	}
+	for i, call := range runner.calls {
+		if !reflect.DeepEqual(call.args, expected[i]) {
+			t.Errorf("Command %d: expected %v, got %v", i, expected[i], call.args)
+		}
+	}
+
+	validator.ClearScope()
+	runner.calls = nil
+	if _, err := validator.runSwiftLint("worktree"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(runner.calls[1].args, []string{"lint"}) {
+		t.Errorf("Expected an unscoped lint after ClearScope, got %v", runner.calls[1].args)
+	}
+}
+
+func TestScopedLintWithoutSwiftChanges(t *testing.T) {
+	runner := &mockRunner{}
+	validator := NewQualityValidator()
+	validator.SetCommandRunner(runner.run)
+	validator.ScopeToFiles([]string{"README.md"})
+
+	result, err := validator.runSwiftLint("worktree")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !result.Success || len(runner.calls) != 0 {
+		t.Errorf("Expected lint to pass without running, got %+v after %d commands", result, len(runner.calls))
+	}
+	if !strings.Contains(validator.Explain(), "lint limited to 0 changed Swift file(s)") {
+		t.Errorf("Expected the scope in the explanation, got '%s'", validator.Explain())
+	}
+}
@@ -0,0 +1,192 @@
+package git
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"ccw/types"
+)
+
+// Scheduling of validation stages
+
+// validationStageDependencies declares which stages must finish before a stage
+// starts. Tests exercise the build output, so they wait for the build.
+var validationStageDependencies = map[string][]string{
+	"test": {"build"},
+}
+
+// SetParallel makes independent validation stages run concurrently
+func (qv *QualityValidator) SetParallel(parallel bool) {
+	qv.parallel = parallel
+}
+
+// validationStage is one validation step. Run stores the step's result and
+// returns the validation errors it found.
+type validationStage struct {
+	Name      string
+	DependsOn []string
+	Run       func() []types.ValidationError
+}
+
+// runValidationStages runs stages and returns each stage's errors, indexed like
+// stages. Sequentially, stages run in order; in parallel, each stage starts as
+// soon as the stages it depends on have finished, whatever their outcome.
+// Dependencies must name earlier stages; others are ignored. Stages that have
+// not started once ctx is done are skipped with an error.
+func runValidationStages(ctx context.Context, stages []validationStage, parallel bool) [][]types.ValidationError {
+	results := make([][]types.ValidationError, len(stages))
+	run := func(i int) {
+		if err := ctx.Err(); err != nil {
+			results[i] = []types.ValidationError{types.NewValidationErrorWithCause(
+				stages[i].Name, fmt.Sprintf("%s validation skipped", stages[i].Name), err, false)}
+			return
+		}
+		results[i] = stages[i].Run()
+	}
+
+	if !parallel {
+		for i := range stages {
+			run(i)
+		}
+		return results
+	}
+
+	done := make(map[string]chan struct{}, len(stages))
+	var wg sync.WaitGroup
+	for i, stage := range stages {
+		var waitFor []chan struct{}
+		for _, dependency := range stage.DependsOn {
+			if ch, ok := done[dependency]; ok {
+				waitFor = append(waitFor, ch)
+			}
+		}
+		finished := make(chan struct{})
+		done[stage.Name] = finished
+
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			defer close(finished)
+			for _, ch := range waitFor {
+				<-ch
+			}
+			run(i)
+		}(i)
+	}
+	wg.Wait()
+	return results
+}
+
+// validationStageOrder lists the validation stages in the order they run
+var validationStageOrder = []string{"lint", "build", "test"}
+
+// FailedStages returns the stages that failed in result, in stage order: those
+// whose result is unsuccessful or that reported errors
+func FailedStages(result *ValidationResult) []string {
+	if result == nil {
+		return nil
+	}
+	failed := map[string]bool{
+		"lint":  result.LintResult != nil && !result.LintResult.Success,
+		"build": result.BuildResult != nil && !result.BuildResult.Success,
+		"test":  result.TestResult != nil && !result.TestResult.Success,
+	}
+	for _, validationErr := range result.Errors {
+		if _, ok := failed[validationErr.Type]; ok {
+			failed[validationErr.Type] = true
+		}
+	}
+
+	var stages []string
+	for _, stage := range validationStageOrder {
+		if failed[stage] {
+			stages = append(stages, stage)
+		}
+	}
+	return stages
+}
+
+// RerunStages returns the stages to re-validate after prior failed: its failed
+// stages plus everything they depend on, such as build before test. It returns
+// nil, meaning every stage, when prior names no failed stage.
+func RerunStages(prior *ValidationResult) []string {
+	failed := FailedStages(prior)
+	if len(failed) == 0 {
+		return nil
+	}
+
+	needed := make(map[string]bool)
+	var require func(stage string)
+	require = func(stage string) {
+		if needed[stage] {
+			return
+		}
+		needed[stage] = true
+		for _, dependency := range validationStageDependencies[stage] {
+			require(dependency)
+		}
+	}
+	for _, stage := range failed {
+		require(stage)
+	}
+
+	var stages []string
+	for _, stage := range validationStageOrder {
+		if needed[stage] {
+			stages = append(stages, stage)
+		}
+	}
+	return stages
+}
+
+// OtherStages returns the validation stages not named in stages, in stage order
+func OtherStages(stages []string) []string {
+	named := make(map[string]bool, len(stages))
+	for _, stage := range stages {
+		named[stage] = true
+	}
+
+	var others []string
+	for _, stage := range validationStageOrder {
+		if !named[stage] {
+			others = append(others, stage)
+		}
+	}
+	return others
+}
+
+// MergeValidationResults combines the results of two runs over different
+// stages into one result covering both, with errors in stage order
+func MergeValidationResults(first, second *ValidationResult) *ValidationResult {
+	merged := *first
+	merged.Success = first.Success && second.Success
+	if second.LintResult != nil {
+		merged.LintResult = second.LintResult
+	}
+	if second.BuildResult != nil {
+		merged.BuildResult = second.BuildResult
+	}
+	if second.TestResult != nil {
+		merged.TestResult = second.TestResult
+	}
+	merged.Errors = append(append([]types.ValidationError(nil), first.Errors...), second.Errors...)
+	sort.SliceStable(merged.Errors, func(i, j int) bool {
+		return stageIndex(merged.Errors[i].Type) < stageIndex(merged.Errors[j].Type)
+	})
+	merged.Warnings = validationWarnings(&merged)
+	merged.Duration = first.Duration + second.Duration
+	return &merged
+}
+
+// stageIndex returns the position of stage in validationStageOrder, sorting
+// unknown stages last
+func stageIndex(stage string) int {
+	for i, name := range validationStageOrder {
+		if name == stage {
+			return i
+		}
+	}
+	return len(validationStageOrder)
+}
@@ -0,0 +1,329 @@
+package git
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"ccw/types"
+)
+
+// stageRecorder records stage start and finish events in order
+type stageRecorder struct {
+	mu     sync.Mutex
+	events []string
+}
+
+func (r *stageRecorder) record(event string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, event)
+}
+
+func (r *stageRecorder) index(event string) int {
+	for i, e := range r.events {
+		if e == event {
+			return i
+		}
+	}
+	return -1
+}
+
+// recordedStage returns a stage that records its start and finish around hold
+func recordedStage(r *stageRecorder, name string, dependsOn []string, hold func()) validationStage {
+	return validationStage{
+		Name:      name,
+		DependsOn: dependsOn,
+		Run: func() []types.ValidationError {
+			r.record(name + " start")
+			hold()
+			r.record(name + " end")
+			return []types.ValidationError{{Type: name, Message: name + " failed"}}
+		},
+	}
+}
+
+func TestRunValidationStagesSequential(t *testing.T) {
+	recorder := &stageRecorder{}
+	stages := []validationStage{
+		recordedStage(recorder, "lint", nil, func() {}),
+		recordedStage(recorder, "build", nil, func() {}),
+		recordedStage(recorder, "test", []string{"build"}, func() {}),
+	}
+
+	results := runValidationStages(context.Background(), stages, false)
+
+	expected := []string{"lint start", "lint end", "build start", "build end", "test start", "test end"}
+	if !reflect.DeepEqual(recorder.events, expected) {
+		t.Errorf("Expected %v, got %v", expected, recorder.events)
+	}
+	if len(results) != 3 || results[2][0].Type != "test" {
+		t.Errorf("Expected results in stage order, got %+v", results)
+	}
+}
+
+func TestRunValidationStagesParallelRespectsDependencies(t *testing.T) {
+	recorder := &stageRecorder{}
+	lintStarted := make(chan struct{})
+	buildStarted := make(chan struct{})
+
+	stages := []validationStage{
+		// lint and build each wait until the other has started, which only
+		// completes when they run concurrently
+		recordedStage(recorder, "lint", nil, func() {
+			close(lintStarted)
+			<-buildStarted
+		}),
+		recordedStage(recorder, "build", nil, func() {
+			close(buildStarted)
+			<-lintStarted
+			time.Sleep(20 * time.Millisecond)
+		}),
+		recordedStage(recorder, "test", []string{"build"}, func() {}),
+	}
+
+	finished := make(chan [][]types.ValidationError)
+	go func() { finished <- runValidationStages(context.Background(), stages, true) }()
+
+	var results [][]types.ValidationError
+	select {
+	case results = <-finished:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected lint and build to run concurrently")
+	}
+
+	if recorder.index("test start") < recorder.index("build end") {
+		t.Errorf("Expected test to start after build finished, got %v", recorder.events)
+	}
+	var stageTypes []string
+	for _, stageErrors := range results {
+		stageTypes = append(stageTypes, stageErrors[0].Type)
+	}
+	if !reflect.DeepEqual(stageTypes, []string{"lint", "build", "test"}) {
+		t.Errorf("Expected errors combined in stage order, got %v", stageTypes)
+	}
+}
+
+func TestRunValidationStagesIgnoresUnknownDependencies(t *testing.T) {
+	recorder := &stageRecorder{}
+	stages := []validationStage{
+		// build is disabled, so test must not wait for it
+		recordedStage(recorder, "test", []string{"build"}, func() {}),
+	}
+
+	results := runValidationStages(context.Background(), stages, true)
+	if len(results) != 1 || recorder.index("test end") < 0 {
+		t.Errorf("Expected test to run, got %v", recorder.events)
+	}
+}
+
+func TestRunValidationStagesCancelled(t *testing.T) {
+	for _, parallel := range []bool{false, true} {
+		recorder := &stageRecorder{}
+		ctx, cancel := context.WithCancel(context.Background())
+		stages := []validationStage{
+			recordedStage(recorder, "build", nil, cancel),
+			recordedStage(recorder, "test", []string{"build"}, func() {}),
+		}
+
+		results := runValidationStages(ctx, stages, parallel)
+
+		if recorder.index("test start") >= 0 {
+			t.Errorf("parallel=%v: expected test to be skipped after cancellation, got %v", parallel, recorder.events)
+		}
+		if len(results[1]) != 1 || results[1][0].Message != "test validation skipped" {
+			t.Errorf("parallel=%v: expected a skipped error for test, got %+v", parallel, results[1])
+		}
+		cancel()
+	}
+}
+
+func TestValidateImplementationParallelCombinesResults(t *testing.T) {
+	qv := NewQualityValidator()
+	qv.SetParallel(true)
+	qv.SetCommandRunner(func(dir, name string, args ...string) ([]byte, error) {
+		if name == "swift" && args[0] == "build" {
+			return []byte("error: boom"), errors.New("exit status 1")
+		}
+		return []byte("ok"), nil
+	})
+
+	result, err := qv.ValidateImplementation(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Success {
+		t.Error("Expected validation to fail when the build fails")
+	}
+	if len(result.Errors) != 1 || result.Errors[0].Type != "build" {
+		t.Errorf("Expected only the build error, got %+v", result.Errors)
+	}
+	if result.LintResult == nil || result.BuildResult == nil || result.TestResult == nil {
+		t.Error("Expected every stage result to be recorded")
+	}
+}
+
+func TestRerunStages(t *testing.T) {
+	tests := []struct {
+		name     string
+		prior    *ValidationResult
+		expected []string
+	}{
+		{"nil result reruns everything", nil, nil},
+		{"no failed stage reruns everything", &ValidationResult{LintResult: &LintResult{Success: true}}, nil},
+		{"lint only", &ValidationResult{
+			LintResult:  &LintResult{Success: false},
+			BuildResult: &BuildResult{Success: true},
+			TestResult:  &TestResult{Success: true},
+		}, []string{"lint"}},
+		{"tests rerun with the build", &ValidationResult{
+			LintResult:  &LintResult{Success: true},
+			BuildResult: &BuildResult{Success: true},
+			TestResult:  &TestResult{Success: false},
+		}, []string{"build", "test"}},
+		{"build failure", &ValidationResult{
+			BuildResult: &BuildResult{Success: false},
+		}, []string{"build"}},
+		{"failure known only from errors", &ValidationResult{
+			Errors: []types.ValidationError{{Type: "test"}, {Type: "lint"}},
+		}, []string{"lint", "build", "test"}},
+		{"unknown error types rerun everything", &ValidationResult{
+			Errors: []types.ValidationError{{Type: "recovery"}},
+		}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := RerunStages(tt.prior); !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestValidateStagesRunsOnlySelectedStages(t *testing.T) {
+	runner := &mockRunner{}
+	validator := NewQualityValidator()
+	validator.SetCommandRunner(runner.run)
+
+	result, err := validator.ValidateStages(context.Background(), "worktree", []string{"build", "test"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !result.Success {
+		t.Errorf("Expected success, got errors: %+v", result.Errors)
+	}
+
+	var commands []string
+	for _, call := range runner.calls {
+		commands = append(commands, call.name+" "+call.args[0])
+	}
+	expected := []string{"swift build", "swift test"}
+	if !reflect.DeepEqual(commands, expected) {
+		t.Errorf("Expected %v, got %v", expected, commands)
+	}
+	if result.LintResult != nil {
+		t.Errorf("Expected no lint result, got %+v", result.LintResult)
+	}
+}
+
+func TestValidateImplementationParallelFixesBeforeBuild(t *testing.T) {
+	recorder := &stageRecorder{}
+	qv := NewQualityValidator()
+	qv.SetParallel(true)
+	qv.SetCommandRunner(func(dir, name string, args ...string) ([]byte, error) {
+		event := name + " " + args[0]
+		if len(args) > 1 && args[1] == "--fix" {
+			event += " --fix"
+		}
+		recorder.record(event + " start")
+		time.Sleep(10 * time.Millisecond)
+		recorder.record(event + " end")
+		return []byte("ok"), nil
+	})
+
+	result, err := qv.ValidateImplementationContext(context.Background(), t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !result.Success || result.LintResult == nil || !result.LintResult.AutoFixed {
+		t.Errorf("Expected a successful, auto-fixed lint, got %+v", result.LintResult)
+	}
+
+	fixEnd := recorder.index("swiftlint lint --fix end")
+	if fixEnd < 0 {
+		t.Fatalf("Expected the fix pass to run, got %v", recorder.events)
+	}
+	for _, event := range []string{"swift build start", "swiftlint lint start"} {
+		if index := recorder.index(event); index < fixEnd {
+			t.Errorf("Expected %s after the fix pass finished, got %v", event, recorder.events)
+		}
+	}
+}
+
+func TestValidateStagesSkipsStagesOnceCancelled(t *testing.T) {
+	runner := &mockRunner{}
+	validator := NewQualityValidator()
+	validator.SetCommandRunner(runner.run)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	result, err := validator.ValidateStages(ctx, "worktree", []string{"lint", "build"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(runner.calls) != 0 {
+		t.Errorf("Expected no commands after cancellation, got %d", len(runner.calls))
+	}
+	if result.Success || len(result.Errors) != 2 {
+		t.Errorf("Expected both skipped stages to fail validation, got %+v", result.Errors)
+	}
+}
+
+func TestOtherStages(t *testing.T) {
+	if others := OtherStages([]string{"build", "test"}); !reflect.DeepEqual(others, []string{"lint"}) {
+		t.Errorf("Expected [lint], got %v", others)
+	}
+	if others := OtherStages([]string{"lint", "build", "test"}); others != nil {
+		t.Errorf("Expected no other stages, got %v", others)
+	}
+}
+
+func TestMergeValidationResults(t *testing.T) {
+	first := &ValidationResult{
+		Success:     false,
+		BuildResult: &BuildResult{Success: true},
+		TestResult:  &TestResult{Success: false},
+		Errors:      []types.ValidationError{{Type: "test", Message: "testLexer failed"}},
+		Duration:    time.Second,
+	}
+	second := &ValidationResult{
+		Success:    false,
+		LintResult: &LintResult{Success: false, Warnings: []string{"A.swift:1:1: warning: line_length"}},
+		Errors:     []types.ValidationError{{Type: "lint", Message: "SwiftLint validation failed"}},
+		Duration:   time.Second,
+	}
+
+	merged := MergeValidationResults(first, second)
+	if merged.Success {
+		t.Error("Expected the merged result to fail")
+	}
+	if merged.LintResult == nil || merged.BuildResult == nil || merged.TestResult == nil {
+		t.Errorf("Expected every stage result, got %+v", merged)
+	}
+	if len(merged.Errors) != 2 || merged.Errors[0].Type != "lint" || merged.Errors[1].Type != "test" {
+		t.Errorf("Expected errors in stage order, got %+v", merged.Errors)
+	}
+	if len(merged.Warnings) != 1 {
+		t.Errorf("Expected the lint warning, got %+v", merged.Warnings)
+	}
+	if merged.Duration != 2*time.Second {
+		t.Errorf("Expected the durations to add up, got %v", merged.Duration)
+	}
+	if len(first.Errors) != 1 {
+		t.Errorf("Expected the first result to be left unchanged, got %+v", first.Errors)
+	}
+}
@@ -0,0 +1,29 @@
+package git
+
+import "testing"
+
+func TestValidationPassesWithWarnings(t *testing.T) {
+	runner := &mockRunner{outputs: map[string]string{
+		"swiftlint lint": "Sources/App/Main.swift:3:1: warning: Line Length Violation: Line should be 120 characters or less\nDone linting!",
+		"swift build":    "Sources/App/Main.swift:10:9: warning: variable 'x' was never used\nBuild complete!",
+	}}
+	validator := NewQualityValidator()
+	validator.SetCommandRunner(runner.run)
+
+	result, err := validator.ValidateImplementation("worktree")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !result.Success {
+		t.Fatalf("Expected warnings not to fail validation, got errors: %+v", result.Errors)
+	}
+	if len(result.Warnings) != 2 {
+		t.Fatalf("Expected 2 warnings, got %+v", result.Warnings)
+	}
+	if result.Warnings[0].Type != "lint" || result.Warnings[0].Line != 3 {
+		t.Errorf("Expected the lint warning first, got %+v", result.Warnings[0])
+	}
+	if result.Warnings[1].Type != "build" || result.Warnings[1].Message != "variable 'x' was never used" {
+		t.Errorf("Expected the build warning second, got %+v", result.Warnings[1])
+	}
+}
@@ -0,0 +1,117 @@
+package git
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Limiting the number of issue worktrees under the worktree base
+
+// issueWorktreePattern matches the issue-<number>-<timestamp> directories ccw creates
+var issueWorktreePattern = regexp.MustCompile(`^issue-\d+-(\d{8}-\d{6})$`)
+
+// IssueWorktree is an issue worktree directory found under the worktree base
+type IssueWorktree struct {
+	Path      string
+	CreatedAt time.Time // From the directory name, or its modification time
+}
+
+// ListIssueWorktrees returns the issue worktrees under worktreeBase, oldest first
+func ListIssueWorktrees(worktreeBase string) ([]IssueWorktree, error) {
+	entries, err := os.ReadDir(worktreeBase)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read worktree base %s: %w", worktreeBase, err)
+	}
+
+	var worktrees []IssueWorktree
+	for _, entry := range entries {
+		match := issueWorktreePattern.FindStringSubmatch(entry.Name())
+		if !entry.IsDir() || match == nil {
+			continue
+		}
+
+		createdAt, err := time.ParseInLocation("20060102-150405", match[1], time.Local)
+		if err != nil {
+			info, infoErr := entry.Info()
+			if infoErr != nil {
+				continue
+			}
+			createdAt = info.ModTime()
+		}
+		worktrees = append(worktrees, IssueWorktree{Path: filepath.Join(worktreeBase, entry.Name()), CreatedAt: createdAt})
+	}
+
+	sort.SliceStable(worktrees, func(i, j int) bool {
+		return worktrees[i].CreatedAt.Before(worktrees[j].CreatedAt)
+	})
+	return worktrees, nil
+}
+
+// WorktreeLimitError reports that git.max_worktrees would be exceeded, with
+// the oldest worktrees whose removal makes room for a new one
+type WorktreeLimitError struct {
+	Limit        int
+	Count        int
+	PruneOldest  []IssueWorktree
+	WorktreeBase string
+	Now          time.Time
+}
+
+// Error describes the limit and suggests which stale worktrees to remove
+func (e *WorktreeLimitError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "worktree limit reached: %d issue worktree(s) exist under %s and git.max_worktrees is %d.", e.Count, e.WorktreeBase, e.Limit)
+	fmt.Fprintf(&b, " Remove %d stale worktree(s) to continue, oldest first:", len(e.PruneOldest))
+	for _, worktree := range e.PruneOldest {
+		fmt.Fprintf(&b, "\n  git worktree remove --force %s  # %s old", worktree.Path, formatWorktreeAge(e.Now.Sub(worktree.CreatedAt)))
+	}
+	b.WriteString("\nOr remove every worktree with: ccw --cleanup")
+	return b.String()
+}
+
+// formatWorktreeAge renders an age in whole days, or hours under a day
+func formatWorktreeAge(age time.Duration) string {
+	if age < 24*time.Hour {
+		return fmt.Sprintf("%dh", int(age.Hours()))
+	}
+	return fmt.Sprintf("%dd", int(age.Hours()/24))
+}
+
+// CheckWorktreeLimit returns a *WorktreeLimitError when creating one more
+// worktree would exceed limit. worktrees must be sorted oldest first; a limit
+// of 0 or less disables the check.
+func CheckWorktreeLimit(worktrees []IssueWorktree, limit int, worktreeBase string, now time.Time) error {
+	if limit <= 0 || len(worktrees) < limit {
+		return nil
+	}
+
+	return &WorktreeLimitError{
+		Limit:        limit,
+		Count:        len(worktrees),
+		PruneOldest:  worktrees[:len(worktrees)-limit+1],
+		WorktreeBase: worktreeBase,
+		Now:          now,
+	}
+}
+
+// EnforceWorktreeLimit refuses to make room for another worktree under the
+// worktree base once limit issue worktrees exist
+func (g *Operations) EnforceWorktreeLimit(limit int) error {
+	if limit <= 0 {
+		return nil
+	}
+
+	worktrees, err := ListIssueWorktrees(g.basePath)
+	if err != nil {
+		return err
+	}
+	return CheckWorktreeLimit(worktrees, limit, g.basePath, time.Now())
+}
@@ -0,0 +1,92 @@
+package git
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestListIssueWorktrees(t *testing.T) {
+	base := t.TempDir()
+	for _, name := range []string{"issue-3-20240301-120000", "issue-1-20240101-090000", "issue-2-20240201-100000", "notes", "issue-x-20240101-090000"} {
+		if err := os.MkdirAll(filepath.Join(base, name), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeTestFile(t, base, "issue-4-20240401-090000", []byte("not a directory"))
+
+	worktrees, err := ListIssueWorktrees(base)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	var names []string
+	for _, worktree := range worktrees {
+		names = append(names, filepath.Base(worktree.Path))
+	}
+	expected := "issue-1-20240101-090000,issue-2-20240201-100000,issue-3-20240301-120000"
+	if strings.Join(names, ",") != expected {
+		t.Errorf("Expected '%s', got '%s'", expected, strings.Join(names, ","))
+	}
+
+	if worktrees, err := ListIssueWorktrees(filepath.Join(base, "missing")); err != nil || len(worktrees) != 0 {
+		t.Errorf("Expected no worktrees for a missing base, got %v, %v", worktrees, err)
+	}
+}
+
+func TestCheckWorktreeLimit(t *testing.T) {
+	now := time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)
+	worktrees := []IssueWorktree{
+		{Path: "wt/issue-1-20240101-090000", CreatedAt: now.Add(-30 * 24 * time.Hour)},
+		{Path: "wt/issue-2-20240301-100000", CreatedAt: now.Add(-9 * 24 * time.Hour)},
+		{Path: "wt/issue-3-20240310-090000", CreatedAt: now.Add(-3 * time.Hour)},
+	}
+
+	tests := []struct {
+		name        string
+		limit       int
+		expectPrune []string
+	}{
+		{"unlimited", 0, nil},
+		{"below limit", 4, nil},
+		{"at limit", 3, []string{"wt/issue-1-20240101-090000"}},
+		{"over limit", 2, []string{"wt/issue-1-20240101-090000", "wt/issue-2-20240301-100000"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckWorktreeLimit(worktrees, tt.limit, "wt", now)
+			if tt.expectPrune == nil {
+				if err != nil {
+					t.Errorf("Expected no error, got %v", err)
+				}
+				return
+			}
+
+			var limitErr *WorktreeLimitError
+			if !errors.As(err, &limitErr) {
+				t.Fatalf("Expected *WorktreeLimitError, got %v", err)
+			}
+			if len(limitErr.PruneOldest) != len(tt.expectPrune) {
+				t.Fatalf("Expected %d prune suggestions, got %d", len(tt.expectPrune), len(limitErr.PruneOldest))
+			}
+			for i, path := range tt.expectPrune {
+				if limitErr.PruneOldest[i].Path != path {
+					t.Errorf("Expected suggestion '%s', got '%s'", path, limitErr.PruneOldest[i].Path)
+				}
+				if !strings.Contains(err.Error(), "git worktree remove --force "+path) {
+					t.Errorf("Expected error to suggest removing %s, got:\n%s", path, err.Error())
+				}
+			}
+		})
+	}
+
+	err := CheckWorktreeLimit(worktrees, 1, "wt", now)
+	for _, expected := range []string{"3 issue worktree(s) exist under wt", "git.max_worktrees is 1", "# 30d old", "# 9d old", "# 3h old", "ccw --cleanup"} {
+		if !strings.Contains(err.Error(), expected) {
+			t.Errorf("Expected error to contain '%s', got:\n%s", expected, err.Error())
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package git
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Detecting worktrees whose base repository has moved or been removed
+
+// WorktreeDetachedError reports a worktree whose .git file points at
+// repository metadata that no longer exists
+type WorktreeDetachedError struct {
+	WorktreePath string
+	GitDir       string // Where the worktree's .git file points
+	Output       string // git's own error output
+}
+
+func (e *WorktreeDetachedError) Error() string {
+	return fmt.Sprintf("worktree %s is detached from its base repository: %s does not exist. "+
+		"Move the base repository back, run `git worktree repair %s` from its new location, "+
+		"or delete the worktree directory and rerun ccw", e.WorktreePath, e.GitDir, e.WorktreePath)
+}
+
+// readGitdirLink returns the gitdir a worktree's .git file points at,
+// resolved against the worktree, and false when .git is not such a file
+func readGitdirLink(worktreePath string) (string, bool) {
+	data, err := os.ReadFile(filepath.Join(worktreePath, ".git"))
+	if err != nil {
+		return "", false
+	}
+
+	line := strings.TrimSpace(strings.SplitN(string(data), "\n", 2)[0])
+	gitDir, ok := strings.CutPrefix(line, "gitdir:")
+	if !ok {
+		return "", false
+	}
+	gitDir = strings.TrimSpace(gitDir)
+	if !filepath.IsAbs(gitDir) {
+		gitDir = filepath.Join(worktreePath, gitDir)
+	}
+	return gitDir, true
+}
+
+// CheckWorktreeLinkage verifies that git can still reach the repository of
+// the worktree at worktreePath. A worktree whose base repository moved or was
+// removed yields a *WorktreeDetachedError with remediation steps. Directories
+// without a .git file are not linked worktrees and pass unchecked.
+func (g *Operations) CheckWorktreeLinkage(worktreePath string) error {
+	gitDir, linked := readGitdirLink(worktreePath)
+	if !linked {
+		return nil
+	}
+
+	output, err := CreateGitCommand([]string{"rev-parse", "--git-dir"}, worktreePath).CombinedOutput()
+	if err == nil {
+		return nil
+	}
+
+	if _, statErr := os.Stat(gitDir); os.IsNotExist(statErr) {
+		return &WorktreeDetachedError{
+			WorktreePath: worktreePath,
+			GitDir:       gitDir,
+			Output:       strings.TrimSpace(string(output)),
+		}
+	}
+	return fmt.Errorf("git cannot use worktree %s: %w\nOutput: %s", worktreePath, err, string(output))
+}
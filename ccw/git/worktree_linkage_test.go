@@ -0,0 +1,73 @@
+package git
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestReadGitdirLink(t *testing.T) {
+	dir := t.TempDir()
+
+	if _, linked := readGitdirLink(dir); linked {
+		t.Error("Expected no link without a .git entry")
+	}
+
+	if err := os.Mkdir(filepath.Join(dir, ".git"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if _, linked := readGitdirLink(dir); linked {
+		t.Error("Expected a .git directory not to count as a worktree link")
+	}
+
+	worktree := t.TempDir()
+	writeTestFile(t, worktree, ".git", []byte("gitdir: /repo/.git/worktrees/issue-1\n"))
+	if gitDir, linked := readGitdirLink(worktree); !linked || gitDir != "/repo/.git/worktrees/issue-1" {
+		t.Errorf("Expected '/repo/.git/worktrees/issue-1', got '%s' (linked %v)", gitDir, linked)
+	}
+
+	writeTestFile(t, worktree, ".git", []byte("gitdir: ../repo/.git/worktrees/issue-1\n"))
+	expected := filepath.Join(worktree, "../repo/.git/worktrees/issue-1")
+	if gitDir, _ := readGitdirLink(worktree); gitDir != expected {
+		t.Errorf("Expected '%s', got '%s'", expected, gitDir)
+	}
+}
+
+func TestCheckWorktreeLinkageDetectsMovedBaseRepo(t *testing.T) {
+	repoDir, runGit := initTestRepo(t)
+	tmpDir := t.TempDir()
+	worktreeDir := filepath.Join(tmpDir, "issue-1-20240101-090000")
+
+	writeTestFile(t, repoDir, "README.md", []byte("hello"))
+	runGit("add", ".")
+	runGit("commit", "-q", "-m", "initial")
+	runGit("worktree", "add", "-q", "-b", "issue-1", worktreeDir)
+
+	ops := NewOperations(repoDir, nil, nil)
+	if err := ops.CheckWorktreeLinkage(worktreeDir); err != nil {
+		t.Fatalf("Expected linked worktree to pass, got %v", err)
+	}
+	if err := ops.CheckWorktreeLinkage(t.TempDir()); err != nil {
+		t.Errorf("Expected plain directory to pass, got %v", err)
+	}
+
+	if err := os.Rename(repoDir, filepath.Join(tmpDir, "moved")); err != nil {
+		t.Fatal(err)
+	}
+
+	err := ops.CheckWorktreeLinkage(worktreeDir)
+	var detached *WorktreeDetachedError
+	if !errors.As(err, &detached) {
+		t.Fatalf("Expected WorktreeDetachedError, got %v", err)
+	}
+	if detached.WorktreePath != worktreeDir {
+		t.Errorf("Expected worktree path '%s', got '%s'", worktreeDir, detached.WorktreePath)
+	}
+	for _, expected := range []string{"detached from its base repository", "git worktree repair"} {
+		if !strings.Contains(err.Error(), expected) {
+			t.Errorf("Expected error to mention '%s', got '%s'", expected, err.Error())
+		}
+	}
+}
@@ -0,0 +1,124 @@
+package git
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Retrying worktree removal held up by file locks (git.worktree_remove_retries)
+
+// DefaultWorktreeRemoveRetries is how often a locked worktree removal is
+// retried when git.worktree_remove_retries is unset
+const DefaultWorktreeRemoveRetries = 3
+
+// worktreeRemoveDelay is the first wait between removal attempts; it grows
+// with each attempt. Editors and antivirus scanners usually let go quickly,
+// so it is shorter than the network retry delay.
+const worktreeRemoveDelay = 500 * time.Millisecond
+
+// lockedRemoveErrors are messages of a removal that failed because files in
+// the worktree were still open, in addition to the creation lock messages
+var lockedRemoveErrors = []string{
+	"directory not empty",
+	"failed to delete",
+	"device or resource busy",
+}
+
+// WorktreeRemovalError reports a worktree that is still on disk after every
+// removal attempt, with the steps to remove it by hand
+type WorktreeRemovalError struct {
+	Path     string
+	Attempts int
+	Err      error
+}
+
+func (e *WorktreeRemovalError) Error() string {
+	return fmt.Sprintf("failed to remove worktree %s after %d attempts: %v\n"+
+		"Close editors, terminals or other programs using files in it, then run:\n"+
+		"  git worktree remove --force --force %s\n"+
+		"  git worktree prune",
+		e.Path, e.Attempts, e.Err, e.Path)
+}
+
+func (e *WorktreeRemovalError) Unwrap() error {
+	return e.Err
+}
+
+// worktreeRemoveAttempt runs one git worktree remove; tests replace it
+var worktreeRemoveAttempt = func(g *Operations, worktreePath string) ([]byte, error) {
+	cmd := CreateGitCommand([]string{"worktree", "remove", "--force", worktreePath}, filepath.Dir(worktreePath))
+	return cmd.CombinedOutput()
+}
+
+// worktreeForceRemove is the last resort once removal keeps failing: git is
+// told to remove the worktree even if locked, then whatever is left of the
+// directory is deleted and the worktree registration pruned. Tests replace it.
+var worktreeForceRemove = func(g *Operations, worktreePath string) error {
+	parentDir := filepath.Dir(worktreePath)
+	_ = CreateGitCommand([]string{"worktree", "remove", "--force", "--force", worktreePath}, parentDir).Run()
+	if err := os.RemoveAll(worktreePath); err != nil {
+		return err
+	}
+	_ = CreateGitCommand([]string{"worktree", "prune"}, parentDir).Run()
+	return nil
+}
+
+// isLockedRemoveError reports whether a failed removal may succeed once
+// other programs release files in the worktree
+func isLockedRemoveError(err error, output string) bool {
+	if IsTransientWorktreeError(err, output) {
+		return true
+	}
+	message := strings.ToLower(err.Error() + "\n" + output)
+	for _, pattern := range lockedRemoveErrors {
+		if strings.Contains(message, pattern) {
+			return true
+		}
+	}
+	return false
+}
+
+// worktreeRemoveRetries returns the configured retries, never negative
+func (g *Operations) worktreeRemoveRetries() int {
+	if g.config == nil {
+		return DefaultWorktreeRemoveRetries
+	}
+	if g.config.WorktreeRemoveRetries < 0 {
+		return 0
+	}
+	return g.config.WorktreeRemoveRetries
+}
+
+// removeWorktreeWithRetry runs git worktree remove, retrying failures caused
+// by locked files with short growing delays. When removal still fails, the
+// directory is force-deleted unless NoForceRemove is set; a worktree that
+// remains on disk is reported as a WorktreeRemovalError.
+func (g *Operations) removeWorktreeWithRetry(worktreePath string) error {
+	retries := g.worktreeRemoveRetries()
+
+	var lastErr error
+	attempts := 0
+	for attempt := 0; ; attempt++ {
+		attempts++
+		output, err := worktreeRemoveAttempt(g, worktreePath)
+		if err == nil {
+			return nil
+		}
+		lastErr = fmt.Errorf("%w: %s", err, strings.TrimSpace(string(output)))
+		if attempt >= retries || !isLockedRemoveError(err, string(output)) {
+			break
+		}
+		worktreeRetrySleep(time.Duration(attempt+1) * worktreeRemoveDelay)
+	}
+
+	if g.config != nil && g.config.NoForceRemove {
+		return &WorktreeRemovalError{Path: worktreePath, Attempts: attempts, Err: lastErr}
+	}
+	if err := worktreeForceRemove(g, worktreePath); err != nil {
+		return &WorktreeRemovalError{Path: worktreePath, Attempts: attempts + 1, Err: err}
+	}
+	return nil
+}
@@ -0,0 +1,136 @@
+package git
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeRemover replaces worktree removal with attempts that fail until
+// failures runs out, recording each step taken
+type fakeRemover struct {
+	failures int
+	output   string
+	forceErr error
+	steps    []string
+	sleeps   []time.Duration
+}
+
+func installFakeRemover(t *testing.T, f *fakeRemover) {
+	t.Helper()
+	originalAttempt, originalForce, originalSleep := worktreeRemoveAttempt, worktreeForceRemove, worktreeRetrySleep
+	worktreeRemoveAttempt = func(g *Operations, worktreePath string) ([]byte, error) {
+		f.steps = append(f.steps, "remove")
+		if f.failures > 0 {
+			f.failures--
+			return []byte(f.output), errors.New("exit status 255")
+		}
+		return nil, nil
+	}
+	worktreeForceRemove = func(g *Operations, worktreePath string) error {
+		f.steps = append(f.steps, "force")
+		return f.forceErr
+	}
+	worktreeRetrySleep = func(d time.Duration) { f.sleeps = append(f.sleeps, d) }
+	t.Cleanup(func() {
+		worktreeRemoveAttempt, worktreeForceRemove, worktreeRetrySleep = originalAttempt, originalForce, originalSleep
+	})
+}
+
+const lockedOutput = "error: failed to delete 'issue-1': Permission denied"
+
+func TestRemoveWorktreeRetrySequence(t *testing.T) {
+	tests := []struct {
+		name    string
+		remover fakeRemover
+		config  *GitOperationConfig
+		steps   string
+		sleeps  int
+		wantErr bool
+	}{
+		{"succeeds first time", fakeRemover{}, &GitOperationConfig{WorktreeRemoveRetries: 3}, "remove", 0, false},
+		{"succeeds after locks clear", fakeRemover{failures: 2, output: lockedOutput}, &GitOperationConfig{WorktreeRemoveRetries: 3}, "remove remove remove", 2, false},
+		{"escalates after retries", fakeRemover{failures: 10, output: lockedOutput}, &GitOperationConfig{WorktreeRemoveRetries: 2}, "remove remove remove force", 2, false},
+		{"escalates at once on other errors", fakeRemover{failures: 10, output: "fatal: 'issue-1' is not a working tree"}, &GitOperationConfig{WorktreeRemoveRetries: 3}, "remove force", 0, false},
+		{"reports failed escalation", fakeRemover{failures: 10, output: lockedOutput, forceErr: errors.New("access is denied")}, &GitOperationConfig{WorktreeRemoveRetries: 1}, "remove remove force", 1, true},
+		{"no force removal", fakeRemover{failures: 10, output: lockedOutput}, &GitOperationConfig{WorktreeRemoveRetries: 1, NoForceRemove: true}, "remove remove", 1, true},
+		{"default retries", fakeRemover{failures: 10, output: lockedOutput}, nil, "remove remove remove remove force", 3, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			remover := tt.remover
+			installFakeRemover(t, &remover)
+
+			ops := &Operations{basePath: t.TempDir(), config: tt.config}
+			err := ops.RemoveWorktree("/tmp/worktrees/issue-1")
+
+			if got := strings.Join(remover.steps, " "); got != tt.steps {
+				t.Errorf("Expected steps '%s', got '%s'", tt.steps, got)
+			}
+			if len(remover.sleeps) != tt.sleeps {
+				t.Errorf("Expected %d delays, got %v", tt.sleeps, remover.sleeps)
+			}
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Expected error %v, got %v", tt.wantErr, err)
+			}
+			if err != nil {
+				var removalErr *WorktreeRemovalError
+				if !errors.As(err, &removalErr) {
+					t.Fatalf("Expected a WorktreeRemovalError, got %T", err)
+				}
+				if !strings.Contains(err.Error(), "git worktree prune") {
+					t.Errorf("Expected cleanup guidance, got '%s'", err.Error())
+				}
+			}
+		})
+	}
+}
+
+func TestRemoveWorktreeGrowingDelays(t *testing.T) {
+	remover := fakeRemover{failures: 3, output: lockedOutput}
+	installFakeRemover(t, &remover)
+
+	ops := &Operations{config: &GitOperationConfig{WorktreeRemoveRetries: 3}}
+	if err := ops.RemoveWorktree("/tmp/worktrees/issue-1"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := []time.Duration{worktreeRemoveDelay, 2 * worktreeRemoveDelay, 3 * worktreeRemoveDelay}
+	if len(remover.sleeps) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, remover.sleeps)
+	}
+	for i := range expected {
+		if remover.sleeps[i] != expected[i] {
+			t.Errorf("Expected %v, got %v", expected, remover.sleeps)
+			break
+		}
+	}
+}
+
+func TestRemoveWorktreeRealRepository(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	repoDir, _ := setupRetryRepo(t, worktreeAddAttempt)
+
+	ops := NewOperations(repoDir, &GitOperationConfig{Timeout: 30 * time.Second, WorktreeRemoveRetries: 1}, nil)
+	worktreePath := filepath.Join(t.TempDir(), "issue-1")
+	if err := ops.CreateWorktree("issue-1", worktreePath); err != nil {
+		t.Fatalf("Failed to create worktree: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(worktreePath, "untracked.txt"), []byte("x"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := ops.RemoveWorktree(worktreePath); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, err := os.Stat(worktreePath); !os.IsNotExist(err) {
+		t.Errorf("Expected %s to be removed", worktreePath)
+	}
+}
@@ -0,0 +1,119 @@
+package git
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// Retrying transient worktree creation failures (git.worktree_create_retries)
+
+// DefaultWorktreeCreateRetries is how often a transient worktree creation
+// failure is retried when git.worktree_create_retries is unset
+const DefaultWorktreeCreateRetries = 2
+
+// permanentWorktreeErrors are git messages that another attempt cannot fix
+var permanentWorktreeErrors = []string{
+	"already exists",
+	"is not a valid branch name",
+	"invalid reference",
+	"not a valid object name",
+	"not a git repository",
+	"is already checked out",
+	"is already used by worktree",
+}
+
+// transientWorktreeErrors are git and filesystem messages caused by locks or
+// scanners holding files briefly, common with antivirus software on Windows
+var transientWorktreeErrors = []string{
+	"unable to create",
+	".lock",
+	"resource busy",
+	"text file busy",
+	"permission denied",
+	"access is denied",
+	"being used by another process",
+	"resource temporarily unavailable",
+	"unable to write",
+	"could not lock",
+	"signal: killed",
+	"timeout",
+}
+
+// worktreeAddAttempt runs one git worktree add; tests replace it
+var worktreeAddAttempt = func(g *Operations, branchName, worktreePath string) ([]byte, error) {
+	cmd := CreateGitCommandWithTimeout([]string{"worktree", "add", "-b", branchName, worktreePath, "HEAD"}, g.basePath, g.GetTimeout())
+	return cmd.CombinedOutput()
+}
+
+// worktreeRetrySleep waits between attempts; tests replace it
+var worktreeRetrySleep = time.Sleep
+
+// IsTransientWorktreeError reports whether a failed git worktree add is worth
+// retrying. Permanent causes such as an existing branch take precedence, so
+// they fail fast.
+func IsTransientWorktreeError(err error, output string) bool {
+	if err == nil {
+		return false
+	}
+	message := strings.ToLower(err.Error() + "\n" + output)
+	for _, pattern := range permanentWorktreeErrors {
+		if strings.Contains(message, pattern) {
+			return false
+		}
+	}
+	for _, pattern := range transientWorktreeErrors {
+		if strings.Contains(message, pattern) {
+			return true
+		}
+	}
+	return false
+}
+
+// worktreeCreateRetries returns the configured retries, never negative
+func (g *Operations) worktreeCreateRetries() int {
+	if g.config == nil || g.config.WorktreeCreateRetries < 0 {
+		return 0
+	}
+	return g.config.WorktreeCreateRetries
+}
+
+// addWorktreeWithRetry runs git worktree add, retrying transient failures
+// with a growing delay. A failed attempt can leave a partial directory, a
+// registered worktree and a new branch behind; they are removed before the
+// next attempt so it does not fail on them.
+func (g *Operations) addWorktreeWithRetry(branchName, worktreePath string) error {
+	branchExisted := g.branchExists(branchName)
+	retries := g.worktreeCreateRetries()
+
+	for attempt := 0; ; attempt++ {
+		output, err := worktreeAddAttempt(g, branchName, worktreePath)
+		if err == nil {
+			return nil
+		}
+		if attempt >= retries || !IsTransientWorktreeError(err, string(output)) {
+			if attempt > 0 {
+				return fmt.Errorf("failed to create git worktree after %d attempts: %w\nOutput: %s", attempt+1, err, string(output))
+			}
+			return fmt.Errorf("failed to create git worktree: %w\nOutput: %s", err, string(output))
+		}
+
+		g.cleanupPartialWorktree(branchName, worktreePath, branchExisted)
+		worktreeRetrySleep(time.Duration(attempt+1) * g.GetRetryDelay())
+	}
+}
+
+// cleanupPartialWorktree removes what a failed git worktree add left behind
+func (g *Operations) cleanupPartialWorktree(branchName, worktreePath string, branchExisted bool) {
+	_ = os.RemoveAll(worktreePath)
+	_ = CreateGitCommand([]string{"worktree", "prune"}, g.basePath).Run()
+	if !branchExisted {
+		_ = CreateGitCommand([]string{"branch", "-D", branchName}, g.basePath).Run()
+	}
+}
+
+// branchExists reports whether a local branch exists in the base repository
+func (g *Operations) branchExists(branchName string) bool {
+	return CreateGitCommand([]string{"rev-parse", "--verify", "--quiet", "refs/heads/" + branchName}, g.basePath).Run() == nil
+}
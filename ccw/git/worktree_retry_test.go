@@ -0,0 +1,128 @@
+package git
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestIsTransientWorktreeError(t *testing.T) {
+	failed := errors.New("exit status 128")
+	tests := []struct {
+		name      string
+		err       error
+		output    string
+		transient bool
+	}{
+		{"index lock", failed, "fatal: Unable to create '/repo/.git/worktrees/x/index.lock': File exists.", true},
+		{"antivirus", failed, "error: unable to unlink old 'Package.swift': Permission denied", true},
+		{"busy", failed, "fatal: could not open 'x': Device or resource busy", true},
+		{"timeout", errors.New("signal: killed"), "", true},
+		{"branch exists", failed, "fatal: a branch named 'issue-1' already exists", false},
+		{"path exists", failed, "fatal: '/tmp/issue-1' already exists", false},
+		{"bad branch", failed, "fatal: 'a..b' is not a valid branch name", false},
+		{"unknown", failed, "fatal: something else", false},
+		{"no error", nil, "Preparing worktree", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsTransientWorktreeError(tt.err, tt.output); got != tt.transient {
+				t.Errorf("Expected %v, got %v", tt.transient, got)
+			}
+		})
+	}
+}
+
+// setupRetryRepo creates a repository with one commit and stubs the worktree
+// add attempt and retry sleep, restoring them after the test
+func setupRetryRepo(t *testing.T, attempt func(g *Operations, branchName, worktreePath string) ([]byte, error)) (string, *[]time.Duration) {
+	t.Helper()
+	repoDir, runGit := initTestRepo(t)
+	runGit("commit", "-q", "--allow-empty", "-m", "initial")
+
+	var sleeps []time.Duration
+	originalAttempt, originalSleep := worktreeAddAttempt, worktreeRetrySleep
+	worktreeAddAttempt = attempt
+	worktreeRetrySleep = func(d time.Duration) { sleeps = append(sleeps, d) }
+	t.Cleanup(func() { worktreeAddAttempt, worktreeRetrySleep = originalAttempt, originalSleep })
+	return repoDir, &sleeps
+}
+
+func TestCreateWorktreeRetriesTransientFailures(t *testing.T) {
+	attempts := 0
+	realAttempt := worktreeAddAttempt
+	repoDir, sleeps := setupRetryRepo(t, func(g *Operations, branchName, worktreePath string) ([]byte, error) {
+		attempts++
+		if attempts < 3 {
+			// Leave a partial directory and branch behind, as an interrupted git would
+			if err := os.MkdirAll(filepath.Join(worktreePath, "Sources"), 0755); err != nil {
+				t.Fatal(err)
+			}
+			_ = exec.Command("git", "-C", g.basePath, "branch", branchName).Run()
+			return []byte("fatal: Unable to create 'index.lock': File exists."), errors.New("exit status 128")
+		}
+		return realAttempt(g, branchName, worktreePath)
+	})
+
+	ops := NewOperations(repoDir, &GitOperationConfig{Timeout: 30 * time.Second, RetryDelay: time.Second, WorktreeCreateRetries: 2}, nil)
+	worktreePath := filepath.Join(t.TempDir(), "issue-1")
+	if err := ops.CreateWorktree("issue-1", worktreePath); err != nil {
+		t.Fatalf("Expected the third attempt to succeed, got %v", err)
+	}
+	if attempts != 3 {
+		t.Errorf("Expected 3 attempts, got %d", attempts)
+	}
+	if len(*sleeps) != 2 || (*sleeps)[0] != time.Second || (*sleeps)[1] != 2*time.Second {
+		t.Errorf("Expected growing delays of 1s and 2s, got %v", *sleeps)
+	}
+	if _, err := os.Stat(filepath.Join(worktreePath, ".git")); err != nil {
+		t.Errorf("Expected a worktree at %s: %v", worktreePath, err)
+	}
+}
+
+func TestCreateWorktreeGivesUpAfterRetries(t *testing.T) {
+	attempts := 0
+	repoDir, _ := setupRetryRepo(t, func(g *Operations, branchName, worktreePath string) ([]byte, error) {
+		attempts++
+		return []byte("error: Permission denied"), errors.New("exit status 128")
+	})
+
+	ops := NewOperations(repoDir, &GitOperationConfig{WorktreeCreateRetries: 1}, nil)
+	err := ops.CreateWorktree("issue-1", filepath.Join(t.TempDir(), "issue-1"))
+	if err == nil || !strings.Contains(err.Error(), "after 2 attempts") {
+		t.Errorf("Expected an error after 2 attempts, got %v", err)
+	}
+	if attempts != 2 {
+		t.Errorf("Expected 2 attempts, got %d", attempts)
+	}
+}
+
+func TestCreateWorktreePermanentFailureFailsFast(t *testing.T) {
+	attempts := 0
+	repoDir, sleeps := setupRetryRepo(t, func(g *Operations, branchName, worktreePath string) ([]byte, error) {
+		attempts++
+		return []byte("fatal: a branch named 'issue-1' already exists"), errors.New("exit status 128")
+	})
+
+	// The existing branch must survive: it was not created by the failed attempt
+	if output, err := exec.Command("git", "-C", repoDir, "branch", "issue-1").CombinedOutput(); err != nil {
+		t.Fatalf("git branch failed: %v\n%s", err, output)
+	}
+
+	ops := NewOperations(repoDir, &GitOperationConfig{WorktreeCreateRetries: 3}, nil)
+	err := ops.CreateWorktree("issue-1", filepath.Join(t.TempDir(), "issue-1"))
+	if err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Errorf("Expected the permanent error, got %v", err)
+	}
+	if attempts != 1 || len(*sleeps) != 0 {
+		t.Errorf("Expected a single attempt without waiting, got %d attempts and %v", attempts, *sleeps)
+	}
+	if !ops.branchExists("issue-1") {
+		t.Error("Expected the existing branch to be kept")
+	}
+}
@@ -2,7 +2,6 @@ package github
 
 import (
 	"fmt"
-	"os/exec"
 )
 
 // CheckGHCLI checks if gh CLI is available and authenticated
@@ -10,17 +9,23 @@ func CheckGHCLI() error {
 	debugLog("CheckGHCLI", "Checking gh CLI availability and authentication", nil)
 
 	// Check if gh command is available
-	if _, err := exec.LookPath("gh"); err != nil {
-		debugLog("CheckGHCLI", "gh CLI not found in PATH", map[string]interface{}{
+	resolved, err := ResolveGHPath(GHPath())
+	if err != nil {
+		debugLog("CheckGHCLI", "gh CLI not found", map[string]interface{}{
 			"error": err.Error(),
 		})
+		if GHPath() != defaultGHCommand {
+			return fmt.Errorf("gh CLI not found at configured gh_path: %w", err)
+		}
 		return fmt.Errorf("gh CLI is not installed. Please install it: brew install gh")
 	}
 
-	debugLog("CheckGHCLI", "gh CLI found in PATH", nil)
+	debugLog("CheckGHCLI", "gh CLI found", map[string]interface{}{
+		"path": resolved,
+	})
 
 	// Check if user is authenticated
-	cmd := exec.Command("gh", "auth", "status")
+	cmd := NewGHCommand("auth", "status")
 	output, err := cmd.CombinedOutput()
 
 	if err != nil {
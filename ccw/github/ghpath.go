@@ -0,0 +1,144 @@
+package github
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// gh executable resolution and version checks
+
+// defaultGHCommand is used when no gh_path is configured
+const defaultGHCommand = "gh"
+
+var (
+	ghPath      = defaultGHCommand
+	ghPathMutex sync.RWMutex
+)
+
+// ghVersionPattern matches the first line of `gh --version`, e.g. "gh version 2.40.1 (2023-12-13)"
+var ghVersionPattern = regexp.MustCompile(`gh version v?(\d+(?:\.\d+)*)`)
+
+// SetGHPath sets the gh executable used by all gh subprocesses, expanding a
+// leading ~/. An empty path restores the default lookup of gh on PATH.
+func SetGHPath(path string) {
+	ghPathMutex.Lock()
+	defer ghPathMutex.Unlock()
+	if path == "" {
+		path = defaultGHCommand
+	}
+	ghPath = expandHome(path)
+}
+
+// expandHome replaces a leading ~/ in path with the home directory
+func expandHome(path string) string {
+	if strings.HasPrefix(path, "~/") {
+		return filepath.Join(os.Getenv("HOME"), path[2:])
+	}
+	return path
+}
+
+// GHPath returns the gh executable used for gh subprocesses
+func GHPath() string {
+	ghPathMutex.RLock()
+	defer ghPathMutex.RUnlock()
+	return ghPath
+}
+
+// ResolveGHPath resolves the configured gh path to an executable. An empty value
+// looks up gh on PATH; a bare name is looked up on PATH; anything containing a
+// path separator must point to an existing executable file.
+func ResolveGHPath(configured string) (string, error) {
+	if configured == "" {
+		configured = defaultGHCommand
+	}
+	configured = expandHome(configured)
+
+	if !strings.ContainsRune(configured, os.PathSeparator) && !strings.Contains(configured, "/") {
+		resolved, err := exec.LookPath(configured)
+		if err != nil {
+			return "", fmt.Errorf("%s not found in PATH: %w", configured, err)
+		}
+		return resolved, nil
+	}
+
+	info, err := os.Stat(configured)
+	if err != nil {
+		return "", fmt.Errorf("gh_path %s is not accessible: %w", configured, err)
+	}
+	if info.IsDir() {
+		return "", fmt.Errorf("gh_path %s is a directory", configured)
+	}
+	if info.Mode().Perm()&0111 == 0 {
+		return "", fmt.Errorf("gh_path %s is not executable", configured)
+	}
+
+	return configured, nil
+}
+
+// GHVersion runs `gh --version` and returns the parsed version number
+func GHVersion() (string, error) {
+	output, err := NewGHCommand("--version").Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to get gh version: %w", err)
+	}
+	return ParseGHVersion(string(output))
+}
+
+// ParseGHVersion extracts the version number from `gh --version` output
+func ParseGHVersion(output string) (string, error) {
+	match := ghVersionPattern.FindStringSubmatch(output)
+	if match == nil {
+		return "", fmt.Errorf("unrecognized gh version output: %q", strings.TrimSpace(output))
+	}
+	return match[1], nil
+}
+
+// CompareVersions compares two dotted version strings numerically, returning
+// -1, 0 or 1. Missing components are treated as zero and a leading "v" is ignored.
+func CompareVersions(a, b string) int {
+	partsA := strings.Split(strings.TrimPrefix(a, "v"), ".")
+	partsB := strings.Split(strings.TrimPrefix(b, "v"), ".")
+
+	for i := 0; i < len(partsA) || i < len(partsB); i++ {
+		numA, numB := versionComponent(partsA, i), versionComponent(partsB, i)
+		if numA < numB {
+			return -1
+		}
+		if numA > numB {
+			return 1
+		}
+	}
+	return 0
+}
+
+// versionComponent returns the numeric prefix of parts[i], or 0 if absent
+func versionComponent(parts []string, i int) int {
+	if i >= len(parts) {
+		return 0
+	}
+	digits := parts[i]
+	if end := strings.IndexFunc(digits, func(r rune) bool { return r < '0' || r > '9' }); end >= 0 {
+		digits = digits[:end]
+	}
+	n, _ := strconv.Atoi(digits)
+	return n
+}
+
+// CheckGHVersion returns the installed gh version and whether it satisfies minimum.
+// An empty minimum always passes.
+func CheckGHVersion(minimum string) (string, bool, error) {
+	version, err := GHVersion()
+	if err != nil {
+		return "", false, err
+	}
+	if minimum == "" {
+		return version, true, nil
+	}
+	return version, CompareVersions(version, minimum) >= 0, nil
+}
@@ -0,0 +1,201 @@
+package github
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func TestResolveGHPath(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("executable bit checks are POSIX only")
+	}
+
+	tmpDir, err := os.MkdirTemp("", "ccw-ghpath-test-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	executable := filepath.Join(tmpDir, "gh-custom")
+	if err := os.WriteFile(executable, []byte("#!/bin/sh\necho 'gh version 2.40.1 (2023-12-13)'\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	nonExecutable := filepath.Join(tmpDir, "gh-plain")
+	if err := os.WriteFile(nonExecutable, []byte("not a program"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Put the fake executable on PATH under a bare name
+	pathDir := filepath.Join(tmpDir, "bin")
+	if err := os.Mkdir(pathDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(executable, filepath.Join(pathDir, "gh-on-path")); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", pathDir)
+
+	tests := []struct {
+		name        string
+		configured  string
+		expected    string
+		expectError bool
+	}{
+		{"absolute executable path", executable, executable, false},
+		{"bare name looked up on PATH", "gh-on-path", filepath.Join(pathDir, "gh-on-path"), false},
+		{"missing file", filepath.Join(tmpDir, "missing"), "", true},
+		{"directory", tmpDir, "", true},
+		{"not executable", nonExecutable, "", true},
+		{"bare name not on PATH", "gh-does-not-exist", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resolved, err := ResolveGHPath(tt.configured)
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("Expected error for %s, got path %s", tt.configured, resolved)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if resolved != tt.expected {
+				t.Errorf("Expected '%s', got '%s'", tt.expected, resolved)
+			}
+		})
+	}
+}
+
+func TestGHCommandUsesConfiguredPath(t *testing.T) {
+	t.Cleanup(func() { SetGHPath("") })
+
+	if cmd := NewGHCommand("--version"); cmd.Args[0] != "gh" {
+		t.Errorf("Expected default command 'gh', got '%s'", cmd.Args[0])
+	}
+
+	SetGHPath("/opt/tools/gh")
+	if cmd := NewGHCommand("--version"); cmd.Path != "/opt/tools/gh" {
+		t.Errorf("Expected configured path '/opt/tools/gh', got '%s'", cmd.Path)
+	}
+
+	SetGHPath("")
+	if GHPath() != "gh" {
+		t.Errorf("Expected empty path to reset to 'gh', got '%s'", GHPath())
+	}
+}
+
+func TestCheckGHVersionWithConfiguredPath(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake gh uses POSIX shell")
+	}
+	t.Cleanup(func() { SetGHPath("") })
+
+	tmpDir, err := os.MkdirTemp("", "ccw-ghversion-test-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	fakeGH := filepath.Join(tmpDir, "gh")
+	script := "#!/bin/sh\necho 'gh version 2.40.1 (2023-12-13)'\necho 'https://github.com/cli/cli/releases/tag/v2.40.1'\n"
+	if err := os.WriteFile(fakeGH, []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+	SetGHPath(fakeGH)
+
+	version, ok, err := CheckGHVersion("2.0.0")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if version != "2.40.1" || !ok {
+		t.Errorf("Expected 2.40.1 to satisfy 2.0.0, got version=%s ok=%v", version, ok)
+	}
+
+	if _, ok, _ := CheckGHVersion("2.41"); ok {
+		t.Error("Expected 2.40.1 to be below minimum 2.41")
+	}
+}
+
+func TestGHCommandRunsHomeRelativePath(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake gh uses POSIX shell")
+	}
+	t.Cleanup(func() { SetGHPath("") })
+
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	if err := os.MkdirAll(filepath.Join(home, "bin"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	script := "#!/bin/sh\necho 'gh version 2.40.1 (2023-12-13)'\n"
+	if err := os.WriteFile(filepath.Join(home, "bin", "gh"), []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	SetGHPath("~/bin/gh")
+	version, err := GHVersion()
+	if err != nil {
+		t.Fatalf("Expected ~/bin/gh to be run, got %v", err)
+	}
+	if version != "2.40.1" {
+		t.Errorf("Expected version 2.40.1 from ~/bin/gh, got '%s'", version)
+	}
+}
+
+func TestParseGHVersion(t *testing.T) {
+	tests := []struct {
+		name        string
+		output      string
+		expected    string
+		expectError bool
+	}{
+		{"release output", "gh version 2.40.1 (2023-12-13)\nhttps://github.com/cli/cli/releases/tag/v2.40.1\n", "2.40.1", false},
+		{"dev build", "gh version v2.45.0-12-gabcdef (2024-03-01)", "2.45.0", false},
+		{"unrecognized", "command not found", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			version, err := ParseGHVersion(tt.output)
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("Expected error, got version %s", version)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if version != tt.expected {
+				t.Errorf("Expected '%s', got '%s'", tt.expected, version)
+			}
+		})
+	}
+}
+
+func TestCompareVersions(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected int
+	}{
+		{"2.40.1", "2.40.1", 0},
+		{"2.40.1", "2.4.10", 1},
+		{"2.9.0", "2.10.0", -1},
+		{"2.0", "2.0.0", 0},
+		{"v2.1.0", "2.0.9", 1},
+		{"1.14.0", "2.0.0", -1},
+		{"2.3.0-rc1", "2.3.0", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.a+"_vs_"+tt.b, func(t *testing.T) {
+			if got := CompareVersions(tt.a, tt.b); got != tt.expected {
+				t.Errorf("CompareVersions(%s, %s): expected %d, got %d", tt.a, tt.b, tt.expected, got)
+			}
+		})
+	}
+}
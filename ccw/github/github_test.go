@@ -233,6 +233,41 @@ func TestExtractIssueInfo(t *testing.T) {
 	}
 }
 
+func TestExtractPRInfo(t *testing.T) {
+	testCases := []struct {
+		name           string
+		prURL          string
+		expectedOwner  string
+		expectedRepo   string
+		expectedNumber int
+		expectError    bool
+	}{
+		{"github.com", "https://github.com/fumiya-kume/FeLangKit/pull/42", "fumiya-kume", "FeLangKit", 42, false},
+		{"enterprise host with trailing slash", "https://github.example.com/team/app/pull/7/", "team", "app", 7, false},
+		{"issue URL", "https://github.com/owner/repo/issues/42", "", "", 0, true},
+		{"files tab", "https://github.com/owner/repo/pull/42/files", "", "", 0, true},
+		{"empty", "", "", "", 0, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			owner, repo, number, err := ExtractPRInfo(tc.prURL)
+			if tc.expectError {
+				if err == nil {
+					t.Errorf("Expected error for URL '%s', but got none", tc.prURL)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error for URL '%s': %v", tc.prURL, err)
+			}
+			if owner != tc.expectedOwner || repo != tc.expectedRepo || number != tc.expectedNumber {
+				t.Errorf("Expected %s/%s#%d, got %s/%s#%d", tc.expectedOwner, tc.expectedRepo, tc.expectedNumber, owner, repo, number)
+			}
+		})
+	}
+}
+
 func TestTruncateString(t *testing.T) {
 	testCases := []struct {
 		name      string
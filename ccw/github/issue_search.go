@@ -0,0 +1,171 @@
+package github
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"ccw/types"
+)
+
+// Listing issues with a raw GitHub search query (ccw list --search)
+
+// maxSearchQueryLength is the longest query GitHub's search accepts
+const maxSearchQueryLength = 256
+
+// issueSearchFields are the gh issue list --json fields mapped onto types.Issue
+const issueSearchFields = "number,title,body,state,url,labels,assignees,milestone,createdAt,updatedAt,reactionGroups"
+
+// ghSearchIssue is one issue as printed by gh issue list --json
+type ghSearchIssue struct {
+	Number int    `json:"number"`
+	Title  string `json:"title"`
+	Body   string `json:"body"`
+	State  string `json:"state"`
+	URL    string `json:"url"`
+	Labels []struct {
+		Name  string `json:"name"`
+		Color string `json:"color"`
+	} `json:"labels"`
+	Assignees []struct {
+		Login string `json:"login"`
+	} `json:"assignees"`
+	Milestone *struct {
+		Number int    `json:"number"`
+		Title  string `json:"title"`
+		State  string `json:"state"`
+	} `json:"milestone"`
+	CreatedAt      time.Time `json:"createdAt"`
+	UpdatedAt      time.Time `json:"updatedAt"`
+	ReactionGroups []struct {
+		Content string `json:"content"`
+		Users   struct {
+			TotalCount int `json:"totalCount"`
+		} `json:"users"`
+	} `json:"reactionGroups"`
+}
+
+// ValidateSearchQuery rejects queries gh or GitHub cannot take as a single
+// --search value
+func ValidateSearchQuery(query string) error {
+	trimmed := strings.TrimSpace(query)
+	if trimmed == "" {
+		return fmt.Errorf("search query must not be empty")
+	}
+	if strings.ContainsAny(query, "\n\r\x00") {
+		return fmt.Errorf("search query must be a single line")
+	}
+	if length := utf8.RuneCountInString(trimmed); length > maxSearchQueryLength {
+		return fmt.Errorf("search query is %d characters; GitHub accepts at most %d", length, maxSearchQueryLength)
+	}
+	if strings.Count(trimmed, `"`)%2 != 0 {
+		return fmt.Errorf("search query has an unbalanced quote: %s", trimmed)
+	}
+	return nil
+}
+
+// issueSearchArgs builds the gh issue list arguments for a search. The query
+// is forwarded as given; state and labels narrow it further when set.
+func issueSearchArgs(owner, repo, state string, labels []string, query string, limit int) []string {
+	args := []string{"issue", "list", "--repo", owner + "/" + repo, "--search", strings.TrimSpace(query)}
+	if state != "" {
+		args = append(args, "--state", state)
+	}
+	for _, label := range labels {
+		if label != "" {
+			args = append(args, "--label", label)
+		}
+	}
+	if limit > 0 {
+		args = append(args, "--limit", strconv.Itoa(limit))
+	}
+	return append(args, "--json", issueSearchFields)
+}
+
+// parseSearchIssues maps gh issue list JSON onto types.Issue, with the
+// lowercase states and reaction counts the REST listing provides
+func parseSearchIssues(output []byte, owner, repo string) ([]*types.Issue, error) {
+	var found []ghSearchIssue
+	if err := json.Unmarshal(output, &found); err != nil {
+		return nil, fmt.Errorf("failed to decode search results: %w", err)
+	}
+
+	repository := types.Repository{Name: repo, FullName: owner + "/" + repo, Owner: types.User{Login: owner}}
+	issues := make([]*types.Issue, 0, len(found))
+	for _, item := range found {
+		issue := &types.Issue{
+			Number:     item.Number,
+			Title:      item.Title,
+			Body:       item.Body,
+			State:      strings.ToLower(item.State),
+			URL:        item.URL,
+			HTMLURL:    item.URL,
+			Labels:     []types.Label{},
+			Assignees:  []types.User{},
+			CreatedAt:  item.CreatedAt,
+			UpdatedAt:  item.UpdatedAt,
+			Repository: repository,
+		}
+		for _, label := range item.Labels {
+			issue.Labels = append(issue.Labels, types.Label{Name: label.Name, Color: label.Color})
+		}
+		for _, assignee := range item.Assignees {
+			issue.Assignees = append(issue.Assignees, types.User{Login: assignee.Login})
+		}
+		if item.Milestone != nil {
+			issue.Milestone = &types.Milestone{Number: item.Milestone.Number, Title: item.Milestone.Title, State: strings.ToLower(item.Milestone.State)}
+		}
+		if len(item.ReactionGroups) > 0 {
+			issue.Reactions = &types.Reactions{}
+			for _, group := range item.ReactionGroups {
+				addReaction(issue.Reactions, group.Content, group.Users.TotalCount)
+			}
+		}
+		issues = append(issues, issue)
+	}
+	return issues, nil
+}
+
+// addReaction adds a GraphQL reaction group count to the REST-style totals
+func addReaction(reactions *types.Reactions, content string, count int) {
+	reactions.TotalCount += count
+	switch content {
+	case "THUMBS_UP":
+		reactions.ThumbsUp += count
+	case "THUMBS_DOWN":
+		reactions.ThumbsDown += count
+	case "LAUGH":
+		reactions.Laugh += count
+	case "HOORAY":
+		reactions.Hooray += count
+	case "CONFUSED":
+		reactions.Confused += count
+	case "HEART":
+		reactions.Heart += count
+	case "ROCKET":
+		reactions.Rocket += count
+	case "EYES":
+		reactions.Eyes += count
+	}
+}
+
+// SearchIssues lists the issues of a repository matching a raw GitHub search
+// query, such as "is:open label:bug sort:updated-desc", in the order GitHub
+// returns them
+func (gc *GitHubClient) SearchIssues(owner, repo string, state string, labels []string, query string, limit int) ([]*types.Issue, error) {
+	if err := ValidateSearchQuery(query); err != nil {
+		return nil, err
+	}
+
+	cmd, cancel := NewTimedGHCommand(issueSearchArgs(owner, repo, state, labels, query, limit)...)
+	defer cancel()
+
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to search issues via gh CLI: %w", err)
+	}
+	return parseSearchIssues(output, owner, repo)
+}
@@ -0,0 +1,115 @@
+package github
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"ccw/types"
+)
+
+func TestValidateSearchQuery(t *testing.T) {
+	tests := []struct {
+		query   string
+		wantErr bool
+	}{
+		{"is:open label:bug sort:updated-desc", false},
+		{`"parser error" in:title -label:wontfix`, false},
+		{"", true},
+		{"   ", true},
+		{"is:open\nlabel:bug", true},
+		{`"unterminated`, true},
+		{strings.Repeat("a", 257), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			if err := ValidateSearchQuery(tt.query); (err != nil) != tt.wantErr {
+				t.Errorf("Expected error %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestIssueSearchArgs(t *testing.T) {
+	args := issueSearchArgs("o", "r", "all", []string{"bug", "", "ui"}, " is:open sort:updated-desc ", 10)
+	expected := []string{
+		"issue", "list", "--repo", "o/r",
+		"--search", "is:open sort:updated-desc",
+		"--state", "all",
+		"--label", "bug", "--label", "ui",
+		"--limit", "10",
+		"--json", issueSearchFields,
+	}
+	if !reflect.DeepEqual(args, expected) {
+		t.Errorf("Expected %q, got %q", expected, args)
+	}
+
+	args = issueSearchArgs("o", "r", "", nil, "-label:docs", 0)
+	expected = []string{"issue", "list", "--repo", "o/r", "--search", "-label:docs", "--json", issueSearchFields}
+	if !reflect.DeepEqual(args, expected) {
+		t.Errorf("Expected %q, got %q", expected, args)
+	}
+}
+
+func TestParseSearchIssues(t *testing.T) {
+	output := `[{
+		"number": 42,
+		"title": "Lexer crashes on tabs",
+		"body": "Steps to reproduce",
+		"state": "OPEN",
+		"url": "https://github.com/o/r/issues/42",
+		"labels": [{"name": "bug", "color": "d73a4a"}],
+		"assignees": [{"login": "alice"}],
+		"milestone": {"number": 3, "title": "v1.0", "state": "OPEN"},
+		"createdAt": "2026-10-01T09:00:00Z",
+		"updatedAt": "2026-10-14T12:30:00Z",
+		"reactionGroups": [
+			{"content": "THUMBS_UP", "users": {"totalCount": 5}},
+			{"content": "HEART", "users": {"totalCount": 1}}
+		]
+	}, {
+		"number": 7,
+		"title": "Docs typo",
+		"state": "CLOSED",
+		"url": "https://github.com/o/r/issues/7",
+		"labels": [],
+		"createdAt": "2026-09-01T09:00:00Z",
+		"updatedAt": "2026-09-02T09:00:00Z"
+	}]`
+
+	issues, err := parseSearchIssues([]byte(output), "o", "r")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(issues) != 2 {
+		t.Fatalf("Expected 2 issues, got %d", len(issues))
+	}
+
+	expected := &types.Issue{
+		Number:     42,
+		Title:      "Lexer crashes on tabs",
+		Body:       "Steps to reproduce",
+		State:      "open",
+		URL:        "https://github.com/o/r/issues/42",
+		HTMLURL:    "https://github.com/o/r/issues/42",
+		Labels:     []types.Label{{Name: "bug", Color: "d73a4a"}},
+		Assignees:  []types.User{{Login: "alice"}},
+		Milestone:  &types.Milestone{Number: 3, Title: "v1.0", State: "open"},
+		CreatedAt:  time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC),
+		UpdatedAt:  time.Date(2026, 10, 14, 12, 30, 0, 0, time.UTC),
+		Repository: types.Repository{Name: "r", FullName: "o/r", Owner: types.User{Login: "o"}},
+		Reactions:  &types.Reactions{TotalCount: 6, ThumbsUp: 5, Heart: 1},
+	}
+	if !reflect.DeepEqual(issues[0], expected) {
+		t.Errorf("Expected %+v, got %+v", expected, issues[0])
+	}
+	if issues[1].State != "closed" || issues[1].Reactions != nil || issues[1].Milestone != nil || len(issues[1].Labels) != 0 {
+		t.Errorf("Unexpected second issue %+v", issues[1])
+	}
+
+	if _, err := parseSearchIssues([]byte("not json"), "o", "r"); err == nil {
+		t.Error("Expected an error for invalid output")
+	}
+}
@@ -21,7 +21,8 @@ func (gc *GitHubClient) GetIssue(owner, repo string, issueNumber int) (*types.Is
 		"api_endpoint": apiEndpoint,
 	})
 
-	cmd := exec.Command("gh", "api", apiEndpoint)
+	cmd, cancel := NewTimedGHCommand("api", apiEndpoint)
+	defer cancel()
 
 	output, err := cmd.Output()
 	if err != nil {
@@ -86,7 +87,8 @@ func (gc *GitHubClient) ListIssues(owner, repo string, state string, labels []st
 		url += "?" + strings.Join(params, "&")
 	}
 
-	cmd := exec.Command("gh", "api", url)
+	cmd, cancel := NewTimedGHCommand("api", url)
+	defer cancel()
 
 	output, err := cmd.Output()
 	if err != nil {
@@ -118,4 +120,20 @@ func ExtractIssueInfo(issueURL string) (owner, repo string, issueNumber int, err
 	}
 
 	return owner, repo, issueNumber, nil
-}
\ No newline at end of file
+}
+
+// ExtractPRInfo extracts owner, repo and PR number from a pull request URL on
+// github.com or a GitHub Enterprise host
+func ExtractPRInfo(prURL string) (owner, repo string, prNumber int, err error) {
+	re := regexp.MustCompile(`^https://[^/]+/([^/]+)/([^/]+)/pull/(\d+)/?$`)
+	matches := re.FindStringSubmatch(strings.TrimSpace(prURL))
+	if len(matches) != 4 {
+		return "", "", 0, fmt.Errorf("invalid GitHub pull request URL format: %s", prURL)
+	}
+
+	prNumber, err = strconv.Atoi(matches[3])
+	if err != nil {
+		return "", "", 0, fmt.Errorf("invalid pull request number: %s", matches[3])
+	}
+	return matches[1], matches[2], prNumber, nil
+}
@@ -0,0 +1,198 @@
+package github
+
+import (
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	"ccw/types"
+)
+
+// recentIssueComments is how many of the latest comments GetIssueGraphQL fetches
+const recentIssueComments = 20
+
+// issueGraphQLQuery fetches an issue with its labels, assignees, milestone and
+// recent comments in a single round trip
+const issueGraphQLQuery = `query($owner: String!, $repo: String!, $number: Int!, $comments: Int!) {
+  repository(owner: $owner, name: $repo) {
+    name
+    nameWithOwner
+    owner { login url }
+    issue(number: $number) {
+      number
+      title
+      body
+      state
+      url
+      createdAt
+      updatedAt
+      labels(first: 50) { nodes { name color } }
+      assignees(first: 20) { nodes { login url } }
+      milestone { number title state dueOn }
+      comments(last: $comments) { nodes { author { login url } body createdAt url } }
+    }
+  }
+}`
+
+// graphQLUser is an actor in a GraphQL response; deleted accounts come back as null
+type graphQLUser struct {
+	Login string `json:"login"`
+	URL   string `json:"url"`
+}
+
+// issueGraphQLResponse mirrors the shape of issueGraphQLQuery's result
+type issueGraphQLResponse struct {
+	Data struct {
+		Repository *struct {
+			Name          string      `json:"name"`
+			NameWithOwner string      `json:"nameWithOwner"`
+			Owner         graphQLUser `json:"owner"`
+			Issue         *struct {
+				Number    int       `json:"number"`
+				Title     string    `json:"title"`
+				Body      string    `json:"body"`
+				State     string    `json:"state"`
+				URL       string    `json:"url"`
+				CreatedAt time.Time `json:"createdAt"`
+				UpdatedAt time.Time `json:"updatedAt"`
+				Labels    struct {
+					Nodes []types.Label `json:"nodes"`
+				} `json:"labels"`
+				Assignees struct {
+					Nodes []graphQLUser `json:"nodes"`
+				} `json:"assignees"`
+				Milestone *struct {
+					Number int        `json:"number"`
+					Title  string     `json:"title"`
+					State  string     `json:"state"`
+					DueOn  *time.Time `json:"dueOn"`
+				} `json:"milestone"`
+				Comments struct {
+					Nodes []struct {
+						Author    *graphQLUser `json:"author"`
+						Body      string       `json:"body"`
+						CreatedAt time.Time    `json:"createdAt"`
+						URL       string       `json:"url"`
+					} `json:"nodes"`
+				} `json:"comments"`
+			} `json:"issue"`
+		} `json:"repository"`
+	} `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+// GetIssueGraphQL fetches an issue with labels, assignees, milestone and recent
+// comments using a single `gh api graphql` call
+func (gc *GitHubClient) GetIssueGraphQL(owner, repo string, issueNumber int) (*types.Issue, error) {
+	debugLog("GetIssueGraphQL", "Fetching issue data", map[string]interface{}{
+		"owner":        owner,
+		"repo":         repo,
+		"issue_number": issueNumber,
+	})
+
+	cmd, cancel := NewTimedGHCommand("api", "graphql",
+		"-f", "query="+issueGraphQLQuery,
+		"-f", "owner="+owner,
+		"-f", "repo="+repo,
+		"-F", fmt.Sprintf("number=%d", issueNumber),
+		"-F", fmt.Sprintf("comments=%d", recentIssueComments))
+	defer cancel()
+
+	output, err := cmd.Output()
+	if err != nil {
+		if exitError, ok := err.(*exec.ExitError); ok {
+			debugLog("GetIssueGraphQL", "gh api graphql command failed", map[string]interface{}{
+				"error":  err.Error(),
+				"stderr": string(exitError.Stderr),
+			})
+		}
+		return nil, fmt.Errorf("failed to fetch issue via gh GraphQL: %w", err)
+	}
+
+	issue, err := parseIssueGraphQLResponse(output)
+	if err != nil {
+		debugLog("GetIssueGraphQL", "Failed to decode GraphQL response", map[string]interface{}{
+			"error":      err.Error(),
+			"raw_output": truncateString(string(output), 500),
+		})
+		return nil, err
+	}
+
+	debugLog("GetIssueGraphQL", "Issue decoded successfully", map[string]interface{}{
+		"issue_title":    issue.Title,
+		"issue_labels":   len(issue.Labels),
+		"issue_comments": len(issue.Comments),
+	})
+	return issue, nil
+}
+
+// parseIssueGraphQLResponse maps a GraphQL issue response onto types.Issue,
+// using the same lowercase state values as the REST API
+func parseIssueGraphQLResponse(data []byte) (*types.Issue, error) {
+	var resp issueGraphQLResponse
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, fmt.Errorf("failed to decode GraphQL response: %w", err)
+	}
+	if len(resp.Errors) > 0 {
+		messages := make([]string, len(resp.Errors))
+		for i, e := range resp.Errors {
+			messages[i] = e.Message
+		}
+		return nil, fmt.Errorf("GraphQL query failed: %s", strings.Join(messages, "; "))
+	}
+
+	repository := resp.Data.Repository
+	if repository == nil || repository.Issue == nil {
+		return nil, fmt.Errorf("issue not found in GraphQL response")
+	}
+	src := repository.Issue
+
+	issue := &types.Issue{
+		Number:    src.Number,
+		Title:     src.Title,
+		Body:      src.Body,
+		State:     strings.ToLower(src.State),
+		URL:       src.URL,
+		HTMLURL:   src.URL,
+		Labels:    src.Labels.Nodes,
+		CreatedAt: src.CreatedAt,
+		UpdatedAt: src.UpdatedAt,
+		Repository: types.Repository{
+			Name:     repository.Name,
+			FullName: repository.NameWithOwner,
+			Owner:    types.User{Login: repository.Owner.Login, URL: repository.Owner.URL},
+		},
+	}
+
+	for _, assignee := range src.Assignees.Nodes {
+		issue.Assignees = append(issue.Assignees, types.User{Login: assignee.Login, URL: assignee.URL})
+	}
+
+	if src.Milestone != nil {
+		issue.Milestone = &types.Milestone{
+			Number: src.Milestone.Number,
+			Title:  src.Milestone.Title,
+			State:  strings.ToLower(src.Milestone.State),
+			DueOn:  src.Milestone.DueOn,
+		}
+	}
+
+	for _, comment := range src.Comments.Nodes {
+		author := types.User{Login: "ghost"}
+		if comment.Author != nil {
+			author = types.User{Login: comment.Author.Login, URL: comment.Author.URL}
+		}
+		issue.Comments = append(issue.Comments, types.IssueComment{
+			Author:    author,
+			Body:      comment.Body,
+			CreatedAt: comment.CreatedAt,
+			URL:       comment.URL,
+		})
+	}
+
+	return issue, nil
+}
@@ -0,0 +1,118 @@
+package github
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+const sampleIssueGraphQLResponse = `{
+  "data": {
+    "repository": {
+      "name": "FeLangKit",
+      "nameWithOwner": "fumiya-kume/FeLangKit",
+      "owner": {"login": "fumiya-kume", "url": "https://github.com/fumiya-kume"},
+      "issue": {
+        "number": 42,
+        "title": "Support tabs in the lexer",
+        "body": "Tabs should count as whitespace.",
+        "state": "OPEN",
+        "url": "https://github.com/fumiya-kume/FeLangKit/issues/42",
+        "createdAt": "2024-03-01T10:00:00Z",
+        "updatedAt": "2024-03-02T11:30:00Z",
+        "labels": {"nodes": [{"name": "bug", "color": "d73a4a"}, {"name": "lexer", "color": "0e8a16"}]},
+        "assignees": {"nodes": [{"login": "octocat", "url": "https://github.com/octocat"}]},
+        "milestone": {"number": 3, "title": "v1.0", "state": "OPEN", "dueOn": "2024-04-01T00:00:00Z"},
+        "comments": {"nodes": [
+          {"author": {"login": "reviewer", "url": "https://github.com/reviewer"}, "body": "Also handle CRLF.", "createdAt": "2024-03-01T12:00:00Z", "url": "https://github.com/fumiya-kume/FeLangKit/issues/42#issuecomment-1"},
+          {"author": null, "body": "From a deleted account", "createdAt": "2024-03-02T09:00:00Z", "url": "https://github.com/fumiya-kume/FeLangKit/issues/42#issuecomment-2"}
+        ]}
+      }
+    }
+  }
+}`
+
+func TestParseIssueGraphQLResponse(t *testing.T) {
+	issue, err := parseIssueGraphQLResponse([]byte(sampleIssueGraphQLResponse))
+	if err != nil {
+		t.Fatalf("parseIssueGraphQLResponse failed: %v", err)
+	}
+
+	if issue.Number != 42 || issue.Title != "Support tabs in the lexer" {
+		t.Errorf("Unexpected issue #%d '%s'", issue.Number, issue.Title)
+	}
+	if issue.State != "open" {
+		t.Errorf("Expected state 'open', got '%s'", issue.State)
+	}
+	if issue.HTMLURL != "https://github.com/fumiya-kume/FeLangKit/issues/42" {
+		t.Errorf("Unexpected HTML URL '%s'", issue.HTMLURL)
+	}
+	if !issue.CreatedAt.Equal(time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)) {
+		t.Errorf("Unexpected created time %v", issue.CreatedAt)
+	}
+	if issue.Repository.FullName != "fumiya-kume/FeLangKit" || issue.Repository.Owner.Login != "fumiya-kume" {
+		t.Errorf("Unexpected repository %+v", issue.Repository)
+	}
+
+	if len(issue.Labels) != 2 || issue.Labels[1].Name != "lexer" || issue.Labels[0].Color != "d73a4a" {
+		t.Errorf("Unexpected labels %+v", issue.Labels)
+	}
+	if len(issue.Assignees) != 1 || issue.Assignees[0].Login != "octocat" {
+		t.Errorf("Unexpected assignees %+v", issue.Assignees)
+	}
+
+	if issue.Milestone == nil {
+		t.Fatal("Expected milestone")
+	}
+	if issue.Milestone.Title != "v1.0" || issue.Milestone.State != "open" || issue.Milestone.DueOn == nil {
+		t.Errorf("Unexpected milestone %+v", issue.Milestone)
+	}
+
+	if len(issue.Comments) != 2 {
+		t.Fatalf("Expected 2 comments, got %d", len(issue.Comments))
+	}
+	if issue.Comments[0].Author.Login != "reviewer" || issue.Comments[0].Body != "Also handle CRLF." {
+		t.Errorf("Unexpected first comment %+v", issue.Comments[0])
+	}
+	if issue.Comments[1].Author.Login != "ghost" {
+		t.Errorf("Expected deleted author to map to 'ghost', got '%s'", issue.Comments[1].Author.Login)
+	}
+}
+
+func TestParseIssueGraphQLResponseWithoutOptionalFields(t *testing.T) {
+	data := `{"data": {"repository": {"name": "r", "nameWithOwner": "o/r", "owner": {"login": "o"},
+		"issue": {"number": 1, "title": "t", "body": "", "state": "CLOSED", "url": "u",
+		"labels": {"nodes": []}, "assignees": {"nodes": []}, "milestone": null, "comments": {"nodes": []}}}}}`
+
+	issue, err := parseIssueGraphQLResponse([]byte(data))
+	if err != nil {
+		t.Fatalf("parseIssueGraphQLResponse failed: %v", err)
+	}
+	if issue.State != "closed" {
+		t.Errorf("Expected state 'closed', got '%s'", issue.State)
+	}
+	if issue.Milestone != nil || len(issue.Comments) != 0 || len(issue.Assignees) != 0 {
+		t.Errorf("Expected no optional fields, got %+v", issue)
+	}
+}
+
+func TestParseIssueGraphQLResponseErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     string
+		contains string
+	}{
+		{"graphql errors", `{"data": {"repository": null}, "errors": [{"message": "Could not resolve to an Issue with the number of 999."}]}`, "Could not resolve"},
+		{"missing issue", `{"data": {"repository": {"name": "r", "issue": null}}}`, "not found"},
+		{"invalid json", `not json`, "decode"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseIssueGraphQLResponse([]byte(tt.data))
+			if err == nil || !strings.Contains(err.Error(), tt.contains) {
+				t.Errorf("Expected error containing '%s', got %v", tt.contains, err)
+			}
+		})
+	}
+}
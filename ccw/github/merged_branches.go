@@ -0,0 +1,41 @@
+package github
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// MergedPRBranches returns the head branch names of the most recent merged
+// pull requests in owner/repo, which also covers squash and rebase merges
+func (gc *GitHubClient) MergedPRBranches(owner, repo string, limit int) (map[string]bool, error) {
+	cmd, cancel := NewTimedGHCommand("pr", "list",
+		"--state", "merged",
+		"--limit", fmt.Sprintf("%d", limit),
+		"--repo", fmt.Sprintf("%s/%s", owner, repo),
+		"--json", "headRefName")
+	defer cancel()
+
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list merged PRs: %w", err)
+	}
+	return parseMergedPRBranches(output)
+}
+
+// parseMergedPRBranches decodes `gh pr list --json headRefName` output
+func parseMergedPRBranches(data []byte) (map[string]bool, error) {
+	var prs []struct {
+		HeadRefName string `json:"headRefName"`
+	}
+	if err := json.Unmarshal(data, &prs); err != nil {
+		return nil, fmt.Errorf("failed to decode PR list: %w", err)
+	}
+
+	branches := make(map[string]bool, len(prs))
+	for _, pr := range prs {
+		if pr.HeadRefName != "" {
+			branches[pr.HeadRefName] = true
+		}
+	}
+	return branches, nil
+}
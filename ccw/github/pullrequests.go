@@ -39,7 +39,8 @@ func (gc *GitHubClient) CreatePR(owner, repo string, req *types.PRRequest) (*typ
 		"args":    args,
 	})
 
-	cmd := exec.Command("gh", args...)
+	cmd, cancel := NewTimedGHCommand(args...)
+	defer cancel()
 
 	// Capture both stdout and stderr
 	output, err := cmd.Output()
@@ -115,10 +116,11 @@ func (gc *GitHubClient) CreatePR(owner, repo string, req *types.PRRequest) (*typ
 
 // CheckExistingPR checks for existing PRs for this branch
 func (gc *GitHubClient) CheckExistingPR(owner, repo, branchName string) (*types.PullRequest, error) {
-	cmd := exec.Command("gh", "pr", "list",
+	cmd, cancel := NewTimedGHCommand("pr", "list",
 		"--head", branchName,
 		"--repo", fmt.Sprintf("%s/%s", owner, repo),
 		"--json", "number,url,title,state")
+	defer cancel()
 
 	output, err := cmd.Output()
 	if err != nil {
@@ -139,9 +141,10 @@ func (gc *GitHubClient) CheckExistingPR(owner, repo, branchName string) (*types.
 
 // GetPRStatus gets PR status and checks
 func (gc *GitHubClient) GetPRStatus(owner, repo string, prNumber int) (string, error) {
-	cmd := exec.Command("gh", "pr", "view", strconv.Itoa(prNumber),
+	cmd, cancel := NewTimedGHCommand("pr", "view", strconv.Itoa(prNumber),
 		"--repo", fmt.Sprintf("%s/%s", owner, repo),
 		"--json", "statusCheckRollup")
+	defer cancel()
 
 	output, err := cmd.Output()
 	if err != nil {
@@ -177,9 +180,10 @@ func (gc *GitHubClient) GetPRStatus(owner, repo string, prNumber int) (string, e
 
 // GetDetailedCIStatus gets detailed CI status for monitoring
 func (gc *GitHubClient) GetDetailedCIStatus(owner, repo string, prNumber int) (*types.CIStatus, error) {
-	cmd := exec.Command("gh", "pr", "view", strconv.Itoa(prNumber),
+	cmd, cancel := NewTimedGHCommand("pr", "view", strconv.Itoa(prNumber),
 		"--repo", fmt.Sprintf("%s/%s", owner, repo),
 		"--json", "statusCheckRollup,url")
+	defer cancel()
 
 	output, err := cmd.Output()
 	if err != nil {
@@ -0,0 +1,58 @@
+package github
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+)
+
+// GetRequiredChecks returns the status checks branch protection requires on
+// branch in owner/repo. It fails when the branch is unprotected or the
+// protection settings are not readable with the current credentials, which
+// needs admin access to the repository.
+func (gc *GitHubClient) GetRequiredChecks(owner, repo, branch string) ([]string, error) {
+	endpoint := fmt.Sprintf("repos/%s/%s/branches/%s/protection", owner, repo, url.PathEscape(branch))
+	cmd, cancel := NewTimedGHCommand("api", endpoint)
+	defer cancel()
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read branch protection for %s: %w", branch, err)
+	}
+	return parseRequiredChecks(output)
+}
+
+// parseRequiredChecks extracts the required status check names from a branch
+// protection response, combining the legacy contexts list with checks pinned
+// to an app, in the order GitHub lists them
+func parseRequiredChecks(data []byte) ([]string, error) {
+	var protection struct {
+		RequiredStatusChecks *struct {
+			Contexts []string `json:"contexts"`
+			Checks   []struct {
+				Context string `json:"context"`
+			} `json:"checks"`
+		} `json:"required_status_checks"`
+	}
+	if err := json.Unmarshal(data, &protection); err != nil {
+		return nil, fmt.Errorf("failed to decode branch protection: %w", err)
+	}
+	if protection.RequiredStatusChecks == nil {
+		return nil, nil
+	}
+
+	var names []string
+	seen := make(map[string]bool)
+	add := func(name string) {
+		if name != "" && !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	for _, name := range protection.RequiredStatusChecks.Contexts {
+		add(name)
+	}
+	for _, check := range protection.RequiredStatusChecks.Checks {
+		add(check.Context)
+	}
+	return names, nil
+}
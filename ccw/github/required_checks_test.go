@@ -0,0 +1,57 @@
+package github
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseRequiredChecks(t *testing.T) {
+	tests := []struct {
+		name     string
+		response string
+		expected []string
+	}{
+		{
+			name: "contexts and app checks",
+			response: `{
+				"url": "https://api.github.com/repos/o/r/branches/main/protection",
+				"required_status_checks": {
+					"strict": true,
+					"contexts": ["build", "test (ubuntu-latest)"],
+					"checks": [
+						{"context": "build", "app_id": 15368},
+						{"context": "lint", "app_id": null}
+					]
+				},
+				"enforce_admins": {"enabled": false}
+			}`,
+			expected: []string{"build", "test (ubuntu-latest)", "lint"},
+		},
+		{
+			name:     "no required status checks",
+			response: `{"required_pull_request_reviews": {"required_approving_review_count": 1}}`,
+			expected: nil,
+		},
+		{
+			name:     "empty checks",
+			response: `{"required_status_checks": {"strict": false, "contexts": [], "checks": []}}`,
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := parseRequiredChecks([]byte(tt.response))
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+
+	if _, err := parseRequiredChecks([]byte(`{"message": "Branch not protected"`)); err == nil {
+		t.Error("Expected error for malformed response")
+	}
+}
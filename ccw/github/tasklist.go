@@ -0,0 +1,141 @@
+package github
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"regexp"
+	"strings"
+)
+
+// Issue task lists ("- [ ] item")
+
+// taskListItemPattern matches a markdown task list line, capturing the prefix up
+// to the checkbox, the checkbox state, the closing bracket and the item text
+var taskListItemPattern = regexp.MustCompile(`^(\s*(?:[-*+]|\d+[.)])\s+\[)([ xX])(\]\s+)(.*?)\s*$`)
+
+// TaskListItem is a single checkbox item in an issue body
+type TaskListItem struct {
+	Line    int
+	Text    string
+	Checked bool
+}
+
+// ParseTaskList returns the task list items in body, ignoring fenced code blocks
+func ParseTaskList(body string) []TaskListItem {
+	var items []TaskListItem
+	forEachTaskLine(strings.Split(body, "\n"), func(index int, matches []string) {
+		items = append(items, TaskListItem{
+			Line:    index,
+			Text:    matches[4],
+			Checked: matches[2] != " ",
+		})
+	})
+	return items
+}
+
+// UncheckedTasks returns the text of every unchecked task list item in body
+func UncheckedTasks(body string) []string {
+	var tasks []string
+	for _, item := range ParseTaskList(body) {
+		if !item.Checked {
+			tasks = append(tasks, item.Text)
+		}
+	}
+	return tasks
+}
+
+// CheckTaskListItems checks off unchecked items whose text matches one of
+// completed and returns the new body with the number of items changed.
+// Matching ignores case and surrounding whitespace; everything else in the
+// body, including line endings, is preserved.
+func CheckTaskListItems(body string, completed []string) (string, int) {
+	wanted := make(map[string]bool, len(completed))
+	for _, task := range completed {
+		if key := normalizeTaskText(task); key != "" {
+			wanted[key] = true
+		}
+	}
+	if len(wanted) == 0 {
+		return body, 0
+	}
+
+	lines := strings.Split(body, "\n")
+	changed := 0
+	forEachTaskLine(lines, func(index int, matches []string) {
+		if matches[2] != " " || !wanted[normalizeTaskText(matches[4])] {
+			return
+		}
+		// Replace only the checkbox character so trailing text and "\r" survive
+		offset := len(matches[1])
+		lines[index] = lines[index][:offset] + "x" + lines[index][offset+1:]
+		changed++
+	})
+
+	if changed == 0 {
+		return body, 0
+	}
+	return strings.Join(lines, "\n"), changed
+}
+
+// forEachTaskLine calls fn for every task list line outside fenced code blocks
+func forEachTaskLine(lines []string, fn func(index int, matches []string)) {
+	inFence := false
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			inFence = !inFence
+			continue
+		}
+		if inFence {
+			continue
+		}
+		if matches := taskListItemPattern.FindStringSubmatch(line); matches != nil {
+			fn(i, matches)
+		}
+	}
+}
+
+// normalizeTaskText lowercases text and collapses whitespace for comparison
+func normalizeTaskText(text string) string {
+	return strings.ToLower(strings.Join(strings.Fields(text), " "))
+}
+
+// UpdateIssueBody replaces the body of an issue via gh issue edit --body-file
+func (gc *GitHubClient) UpdateIssueBody(owner, repo string, issueNumber int, body string) error {
+	bodyFile, err := os.CreateTemp("", "ccw-issue-body-*.md")
+	if err != nil {
+		return fmt.Errorf("failed to create issue body file: %w", err)
+	}
+	defer os.Remove(bodyFile.Name())
+
+	if _, err := bodyFile.WriteString(body); err != nil {
+		bodyFile.Close()
+		return fmt.Errorf("failed to write issue body file: %w", err)
+	}
+	if err := bodyFile.Close(); err != nil {
+		return fmt.Errorf("failed to write issue body file: %w", err)
+	}
+
+	args := []string{"issue", "edit", fmt.Sprintf("%d", issueNumber),
+		"--repo", fmt.Sprintf("%s/%s", owner, repo),
+		"--body-file", bodyFile.Name()}
+
+	debugLog("UpdateIssueBody", "Updating issue body", map[string]interface{}{
+		"owner":        owner,
+		"repo":         repo,
+		"issue_number": issueNumber,
+		"body_len":     len(body),
+	})
+
+	cmd, cancel := NewTimedGHCommand(args...)
+	defer cancel()
+	if _, err := cmd.Output(); err != nil {
+		if exitError, ok := err.(*exec.ExitError); ok {
+			return fmt.Errorf("failed to update issue body via gh CLI: %w\nStderr: %s", err, string(exitError.Stderr))
+		}
+		return fmt.Errorf("failed to update issue body via gh CLI: %w", err)
+	}
+
+	return nil
+}
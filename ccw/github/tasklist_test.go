@@ -0,0 +1,132 @@
+package github
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const taskListBody = `## Summary
+Add string interpolation to the lexer.
+
+## Tasks
+- [ ] Tokenize interpolated strings
+- [x] Add parser support
+* [ ] Update the   README
+1. [ ] Add lexer tests
+
+` + "```markdown\n- [ ] Tokenize interpolated strings\n```" + `
+
+Related to #12`
+
+func TestParseTaskList(t *testing.T) {
+	items := ParseTaskList(taskListBody)
+
+	expected := []TaskListItem{
+		{Line: 4, Text: "Tokenize interpolated strings", Checked: false},
+		{Line: 5, Text: "Add parser support", Checked: true},
+		{Line: 6, Text: "Update the   README", Checked: false},
+		{Line: 7, Text: "Add lexer tests", Checked: false},
+	}
+	if !reflect.DeepEqual(items, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, items)
+	}
+
+	unchecked := UncheckedTasks(taskListBody)
+	expectedUnchecked := []string{"Tokenize interpolated strings", "Update the   README", "Add lexer tests"}
+	if !reflect.DeepEqual(unchecked, expectedUnchecked) {
+		t.Errorf("Expected %v, got %v", expectedUnchecked, unchecked)
+	}
+}
+
+func TestCheckTaskListItems(t *testing.T) {
+	tests := []struct {
+		name            string
+		body            string
+		completed       []string
+		expectedBody    string
+		expectedChanged int
+	}{
+		{
+			name:            "checks matching items",
+			body:            "- [ ] First\n- [ ] Second\n- [ ] Third",
+			completed:       []string{"First", "Third"},
+			expectedBody:    "- [x] First\n- [ ] Second\n- [x] Third",
+			expectedChanged: 2,
+		},
+		{
+			name:            "matching ignores case and spacing",
+			body:            "* [ ] Update the   README",
+			completed:       []string{"update the readme"},
+			expectedBody:    "* [x] Update the   README",
+			expectedChanged: 1,
+		},
+		{
+			name:            "already checked items untouched",
+			body:            "- [X] Done\n- [ ] Open",
+			completed:       []string{"Done"},
+			expectedBody:    "- [X] Done\n- [ ] Open",
+			expectedChanged: 0,
+		},
+		{
+			name:            "preserves surrounding content and CRLF",
+			body:            "Intro text\r\n\r\n  - [ ] Nested task\r\n1) [ ] Numbered\r\nOutro",
+			completed:       []string{"Nested task", "Numbered"},
+			expectedBody:    "Intro text\r\n\r\n  - [x] Nested task\r\n1) [x] Numbered\r\nOutro",
+			expectedChanged: 2,
+		},
+		{
+			name:            "ignores fenced code blocks",
+			body:            "```\n- [ ] Task\n```\n- [ ] Task",
+			completed:       []string{"Task"},
+			expectedBody:    "```\n- [ ] Task\n```\n- [x] Task",
+			expectedChanged: 1,
+		},
+		{
+			name:            "no completed items",
+			body:            "- [ ] Task",
+			completed:       nil,
+			expectedBody:    "- [ ] Task",
+			expectedChanged: 0,
+		},
+		{
+			name:            "unknown completed item",
+			body:            "- [ ] Task",
+			completed:       []string{"Something else"},
+			expectedBody:    "- [ ] Task",
+			expectedChanged: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, changed := CheckTaskListItems(tt.body, tt.completed)
+			if body != tt.expectedBody {
+				t.Errorf("Expected body %q, got %q", tt.expectedBody, body)
+			}
+			if changed != tt.expectedChanged {
+				t.Errorf("Expected %d changed items, got %d", tt.expectedChanged, changed)
+			}
+		})
+	}
+}
+
+func TestCheckTaskListItemsFullBody(t *testing.T) {
+	body, changed := CheckTaskListItems(taskListBody, []string{"Tokenize interpolated strings", "Add lexer tests"})
+	if changed != 2 {
+		t.Fatalf("Expected 2 changed items, got %d", changed)
+	}
+
+	items := ParseTaskList(body)
+	for _, item := range items {
+		if !item.Checked && item.Text != "Update the   README" {
+			t.Errorf("Expected '%s' to be checked", item.Text)
+		}
+	}
+
+	// The copy inside the code fence must stay unchecked
+	expectedFence := "```markdown\n- [ ] Tokenize interpolated strings\n```"
+	if !strings.Contains(body, expectedFence) {
+		t.Errorf("Expected fenced example to be preserved, got:\n%s", body)
+	}
+}
@@ -0,0 +1,45 @@
+package github
+
+import (
+	"context"
+	"os/exec"
+	"sync"
+	"time"
+)
+
+// Time limit for gh commands that talk to GitHub
+
+var (
+	ghTimeout      time.Duration
+	ghTimeoutMutex sync.RWMutex
+)
+
+// SetTimeout sets how long each GitHub gh command may run; 0 means no limit
+func SetTimeout(timeout time.Duration) {
+	ghTimeoutMutex.Lock()
+	defer ghTimeoutMutex.Unlock()
+	ghTimeout = timeout
+}
+
+// Timeout returns the configured gh command time limit, or 0 for none
+func Timeout() time.Duration {
+	ghTimeoutMutex.RLock()
+	defer ghTimeoutMutex.RUnlock()
+	return ghTimeout
+}
+
+// CommandContext returns a context ending after the configured timeout, or a
+// cancellable context without a deadline when no timeout is set
+func CommandContext() (context.Context, context.CancelFunc) {
+	if timeout := Timeout(); timeout > 0 {
+		return context.WithTimeout(context.Background(), timeout)
+	}
+	return context.WithCancel(context.Background())
+}
+
+// NewTimedGHCommand creates a gh command like NewGHCommand that is killed once
+// the configured timeout passes. Call cancel after the command finishes.
+func NewTimedGHCommand(args ...string) (*exec.Cmd, context.CancelFunc) {
+	ctx, cancel := CommandContext()
+	return NewGHCommandContext(ctx, args...), cancel
+}
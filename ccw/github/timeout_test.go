@@ -0,0 +1,58 @@
+package github
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+)
+
+func TestCommandContextAppliesTimeout(t *testing.T) {
+	defer SetTimeout(Timeout())
+
+	SetTimeout(90 * time.Second)
+	ctx, cancel := CommandContext()
+	defer cancel()
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		t.Fatal("Expected a deadline when github.timeout is set")
+	}
+	if remaining := time.Until(deadline); remaining <= 0 || remaining > 90*time.Second {
+		t.Errorf("Expected a deadline within 90s, got %v", remaining)
+	}
+
+	SetTimeout(0)
+	ctx, cancel = CommandContext()
+	defer cancel()
+	if _, ok := ctx.Deadline(); ok {
+		t.Error("Expected no deadline when github.timeout is 0")
+	}
+}
+
+func TestTimedGHCommandIsKilledAfterTimeout(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a shell script as the gh executable")
+	}
+	defer SetTimeout(Timeout())
+	defer SetGHPath(GHPath())
+
+	slowGH := filepath.Join(t.TempDir(), "gh")
+	if err := os.WriteFile(slowGH, []byte("#!/bin/sh\nexec sleep 10\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	SetGHPath(slowGH)
+	SetTimeout(100 * time.Millisecond)
+
+	cmd, cancel := NewTimedGHCommand("api", "user")
+	defer cancel()
+
+	start := time.Now()
+	err := cmd.Run()
+	if err == nil {
+		t.Fatal("Expected the command to be killed")
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Expected the timeout to stop the command, ran for %v", elapsed)
+	}
+}
@@ -0,0 +1,197 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+)
+
+// GitHub token resolution and gh subprocess construction
+
+// TokenSource identifies where the GitHub token was resolved from
+type TokenSource string
+
+const (
+	TokenSourceNone    TokenSource = "none"
+	TokenSourceEnv     TokenSource = "env"
+	TokenSourceFile    TokenSource = "file"
+	TokenSourceCommand TokenSource = "command"
+)
+
+// tokenCommandTimeout bounds keychain/secret manager lookups
+const tokenCommandTimeout = 30 * time.Second
+
+var (
+	ghToken      string
+	ghHost       string
+	ghTokenMutex sync.RWMutex
+)
+
+// ResolveToken resolves the GitHub token with precedence env > file > command.
+// An empty token with TokenSourceNone means gh should use its own stored credentials.
+func ResolveToken(tokenFile, tokenCommand string) (string, TokenSource, error) {
+	for _, name := range []string{"GH_TOKEN", "GITHUB_TOKEN"} {
+		if token := strings.TrimSpace(os.Getenv(name)); token != "" {
+			return token, TokenSourceEnv, nil
+		}
+	}
+
+	if tokenFile != "" {
+		token, err := readTokenFile(tokenFile)
+		if err != nil {
+			return "", TokenSourceFile, err
+		}
+		return token, TokenSourceFile, nil
+	}
+
+	if tokenCommand != "" {
+		token, err := runTokenCommand(tokenCommand)
+		if err != nil {
+			return "", TokenSourceCommand, err
+		}
+		return token, TokenSourceCommand, nil
+	}
+
+	return "", TokenSourceNone, nil
+}
+
+// ResolveProfileToken resolves an account profile's token with precedence
+// tokenEnv > tokenFile > tokenCommand. GH_TOKEN and GITHUB_TOKEN are not
+// consulted, so an explicitly selected profile wins over ambient credentials.
+// TokenSourceNone means the profile configures no token.
+func ResolveProfileToken(tokenEnv, tokenFile, tokenCommand string) (string, TokenSource, error) {
+	if tokenEnv != "" {
+		token := strings.TrimSpace(os.Getenv(tokenEnv))
+		if token == "" {
+			return "", TokenSourceEnv, fmt.Errorf("environment variable %s is empty", tokenEnv)
+		}
+		return token, TokenSourceEnv, nil
+	}
+
+	if tokenFile != "" {
+		token, err := readTokenFile(tokenFile)
+		return token, TokenSourceFile, err
+	}
+
+	if tokenCommand != "" {
+		token, err := runTokenCommand(tokenCommand)
+		return token, TokenSourceCommand, err
+	}
+
+	return "", TokenSourceNone, nil
+}
+
+// SetToken sets the token passed to gh subprocesses. The token is only added to
+// the environment of gh commands and never to the CCW process environment.
+func SetToken(token string) {
+	ghTokenMutex.Lock()
+	defer ghTokenMutex.Unlock()
+	ghToken = token
+}
+
+// currentToken returns the configured token
+func currentToken() string {
+	ghTokenMutex.RLock()
+	defer ghTokenMutex.RUnlock()
+	return ghToken
+}
+
+// SetHost sets the GitHub host gh subprocesses talk to via GH_HOST. An empty
+// host leaves gh's own default in place.
+func SetHost(host string) {
+	ghTokenMutex.Lock()
+	defer ghTokenMutex.Unlock()
+	ghHost = host
+}
+
+// Host returns the GitHub host configured for gh subprocesses, or "" for gh's default
+func Host() string {
+	ghTokenMutex.RLock()
+	defer ghTokenMutex.RUnlock()
+	return ghHost
+}
+
+// NewGHCommand creates a gh command that carries the configured token
+func NewGHCommand(args ...string) *exec.Cmd {
+	return NewGHCommandContext(context.Background(), args...)
+}
+
+// NewGHCommandContext creates a gh command bound to ctx that uses the configured
+// gh path and carries the configured token and host. gh only reads GH_TOKEN for
+// github.com, so for any other host the token is also passed as GH_ENTERPRISE_TOKEN.
+func NewGHCommandContext(ctx context.Context, args ...string) *exec.Cmd {
+	cmd := exec.CommandContext(ctx, GHPath(), args...)
+
+	var env []string
+	token, host := currentToken(), Host()
+	if token != "" {
+		env = append(env, "GH_TOKEN="+token)
+		if host != "" && !strings.EqualFold(host, "github.com") {
+			env = append(env, "GH_ENTERPRISE_TOKEN="+token)
+		}
+	}
+	if host != "" {
+		env = append(env, "GH_HOST="+host)
+	}
+	if len(env) > 0 {
+		cmd.Env = append(os.Environ(), env...)
+	}
+	return cmd
+}
+
+// RedactToken replaces any occurrence of the configured token in s
+func RedactToken(s string) string {
+	token := currentToken()
+	if token == "" {
+		return s
+	}
+	return strings.ReplaceAll(s, token, "[REDACTED]")
+}
+
+// readTokenFile reads a token from a file, expanding a leading ~
+func readTokenFile(path string) (string, error) {
+	path = expandHome(path)
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read github token file: %w", err)
+	}
+
+	token := strings.TrimSpace(string(data))
+	if token == "" {
+		return "", fmt.Errorf("github token file %s is empty", path)
+	}
+
+	return token, nil
+}
+
+// runTokenCommand runs a shell command and uses its trimmed stdout as the token
+func runTokenCommand(command string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), tokenCommandTimeout)
+	defer cancel()
+
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd", "/C", command)
+	} else {
+		cmd = exec.CommandContext(ctx, "sh", "-c", command)
+	}
+
+	// Only stdout is captured; stderr is discarded so secrets are not echoed into errors
+	output, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("github token command failed: %w", err)
+	}
+
+	token := strings.TrimSpace(string(output))
+	if token == "" {
+		return "", fmt.Errorf("github token command produced no output")
+	}
+
+	return token, nil
+}
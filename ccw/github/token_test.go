@@ -0,0 +1,221 @@
+package github
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func setupTokenTest(t *testing.T) string {
+	t.Setenv("GH_TOKEN", "")
+	t.Setenv("GITHUB_TOKEN", "")
+	t.Cleanup(func() {
+		SetToken("")
+		SetHost("")
+	})
+
+	tmpDir, err := os.MkdirTemp("", "ccw-token-test-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(tmpDir) })
+	return tmpDir
+}
+
+func TestResolveTokenPrecedence(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("token command test uses POSIX shell")
+	}
+	tmpDir := setupTokenTest(t)
+
+	tokenFile := filepath.Join(tmpDir, "token")
+	if err := os.WriteFile(tokenFile, []byte("file-token\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	tokenCommand := "echo command-token"
+
+	tests := []struct {
+		name           string
+		ghToken        string
+		githubToken    string
+		tokenFile      string
+		tokenCommand   string
+		expectedToken  string
+		expectedSource TokenSource
+	}{
+		{"GH_TOKEN wins over everything", "env-token", "other-env", tokenFile, tokenCommand, "env-token", TokenSourceEnv},
+		{"GITHUB_TOKEN used when GH_TOKEN unset", "", "github-env", tokenFile, tokenCommand, "github-env", TokenSourceEnv},
+		{"file wins over command", "", "", tokenFile, tokenCommand, "file-token", TokenSourceFile},
+		{"command used last", "", "", "", tokenCommand, "command-token", TokenSourceCommand},
+		{"nothing configured", "", "", "", "", "", TokenSourceNone},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("GH_TOKEN", tt.ghToken)
+			t.Setenv("GITHUB_TOKEN", tt.githubToken)
+
+			token, source, err := ResolveToken(tt.tokenFile, tt.tokenCommand)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if token != tt.expectedToken {
+				t.Errorf("Expected token '%s', got '%s'", tt.expectedToken, token)
+			}
+			if source != tt.expectedSource {
+				t.Errorf("Expected source '%s', got '%s'", tt.expectedSource, source)
+			}
+		})
+	}
+}
+
+func TestResolveTokenErrors(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("token command test uses POSIX shell")
+	}
+	tmpDir := setupTokenTest(t)
+
+	emptyFile := filepath.Join(tmpDir, "empty")
+	if err := os.WriteFile(emptyFile, []byte("  \n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name         string
+		tokenFile    string
+		tokenCommand string
+	}{
+		{"missing file", filepath.Join(tmpDir, "missing"), ""},
+		{"empty file", emptyFile, ""},
+		{"failing command", "", "exit 1"},
+		{"command without output", "", "true"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, err := ResolveToken(tt.tokenFile, tt.tokenCommand); err == nil {
+				t.Error("Expected error, got nil")
+			}
+		})
+	}
+}
+
+func TestNewGHCommandPassesTokenOnlyToSubprocess(t *testing.T) {
+	setupTokenTest(t)
+
+	cmd := NewGHCommand("auth", "status")
+	for _, entry := range cmd.Env {
+		if strings.HasPrefix(entry, "GH_TOKEN=") {
+			t.Errorf("Expected no GH_TOKEN without a configured token, got '%s'", entry)
+		}
+	}
+
+	SetToken("secret-token-value")
+	cmd = NewGHCommand("auth", "status")
+
+	found := false
+	for _, entry := range cmd.Env {
+		if entry == "GH_TOKEN=secret-token-value" {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("Expected GH_TOKEN in gh subprocess environment")
+	}
+	if os.Getenv("GH_TOKEN") == "secret-token-value" {
+		t.Error("Token must not be set in the CCW process environment")
+	}
+}
+
+func TestTokenNotLeakedIntoLogs(t *testing.T) {
+	setupTokenTest(t)
+	t.Setenv("DEBUG_MODE", "true")
+
+	SetToken("secret-token-value")
+
+	reader, writer, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	originalStdout := os.Stdout
+	os.Stdout = writer
+
+	debugLog("Test", "message with secret-token-value", map[string]interface{}{
+		"stderr": "bad credentials for secret-token-value",
+	})
+
+	writer.Close()
+	os.Stdout = originalStdout
+	output, _ := io.ReadAll(reader)
+
+	if strings.Contains(string(output), "secret-token-value") {
+		t.Errorf("Token leaked into debug log: %s", output)
+	}
+	if !strings.Contains(string(output), "[REDACTED]") {
+		t.Errorf("Expected redaction marker in debug log, got: %s", output)
+	}
+}
+
+func TestResolveProfileToken(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("token command test uses POSIX shell")
+	}
+	tmpDir := setupTokenTest(t)
+	t.Setenv("GH_TOKEN", "ambient-token")
+	t.Setenv("WORK_GH_TOKEN", "work-env-token")
+
+	tokenFile := filepath.Join(tmpDir, "token")
+	if err := os.WriteFile(tokenFile, []byte("work-file-token\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name           string
+		tokenEnv       string
+		tokenFile      string
+		tokenCommand   string
+		expectedToken  string
+		expectedSource TokenSource
+	}{
+		{"named env wins", "WORK_GH_TOKEN", tokenFile, "echo command-token", "work-env-token", TokenSourceEnv},
+		{"file over command", "", tokenFile, "echo command-token", "work-file-token", TokenSourceFile},
+		{"command", "", "", "echo command-token", "command-token", TokenSourceCommand},
+		{"ambient GH_TOKEN ignored", "", "", "", "", TokenSourceNone},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token, source, err := ResolveProfileToken(tt.tokenEnv, tt.tokenFile, tt.tokenCommand)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if token != tt.expectedToken || source != tt.expectedSource {
+				t.Errorf("Expected (%s, %s), got (%s, %s)", tt.expectedToken, tt.expectedSource, token, source)
+			}
+		})
+	}
+
+	if _, _, err := ResolveProfileToken("UNSET_PROFILE_TOKEN", "", ""); err == nil {
+		t.Error("Expected error for an empty token environment variable")
+	}
+}
+
+func TestNewGHCommandPassesHost(t *testing.T) {
+	setupTokenTest(t)
+
+	SetHost("github.example.com")
+	cmd := NewGHCommand("issue", "view", "1")
+
+	found := false
+	for _, entry := range cmd.Env {
+		if entry == "GH_HOST=github.example.com" {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("Expected GH_HOST in gh subprocess environment")
+	}
+}
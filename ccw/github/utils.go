@@ -12,11 +12,11 @@ func debugLog(function, message string, context map[string]interface{}) {
 		contextStr := ""
 		if context != nil {
 			if data, err := json.Marshal(context); err == nil {
-				contextStr = string(data)
+				contextStr = RedactToken(string(data))
 			}
 		}
 
-		fmt.Printf("[DEBUG] [GitHub:%s] %s", function, message)
+		fmt.Printf("[DEBUG] [GitHub:%s] %s", function, RedactToken(message))
 		if contextStr != "" {
 			fmt.Printf(" | Context: %s", contextStr)
 		}
@@ -0,0 +1,107 @@
+package history
+
+import (
+	"fmt"
+	"time"
+
+	"ccw/types"
+)
+
+// ETAEstimator predicts remaining workflow time from average phase durations
+// of previous runs
+type ETAEstimator struct {
+	averages map[string]time.Duration
+}
+
+// NewETAEstimator averages phase durations over the records for repository and
+// language. When no run of this repository in that language exists, runs of
+// the same language in other repositories are used instead.
+func NewETAEstimator(records []Record, repository, language string) *ETAEstimator {
+	matching := filterRecords(records, func(r Record) bool {
+		return r.Repository == repository && r.Language == language
+	})
+	if len(matching) == 0 && language != "" {
+		matching = filterRecords(records, func(r Record) bool {
+			return r.Language == language
+		})
+	}
+
+	totals := make(map[string]float64)
+	counts := make(map[string]int)
+	for _, record := range matching {
+		for phase, seconds := range record.Phases {
+			if seconds < 0 {
+				continue
+			}
+			totals[phase] += seconds
+			counts[phase]++
+		}
+	}
+
+	averages := make(map[string]time.Duration, len(totals))
+	for phase, total := range totals {
+		averages[phase] = time.Duration(total / float64(counts[phase]) * float64(time.Second))
+	}
+	return &ETAEstimator{averages: averages}
+}
+
+// filterRecords returns the records for which keep returns true
+func filterRecords(records []Record, keep func(Record) bool) []Record {
+	var result []Record
+	for _, record := range records {
+		if keep(record) {
+			result = append(result, record)
+		}
+	}
+	return result
+}
+
+// HasHistory reports whether any phase estimate is available
+func (e *ETAEstimator) HasHistory() bool {
+	return e != nil && len(e.averages) > 0
+}
+
+// PhaseEstimate returns the average duration of phase in previous runs
+func (e *ETAEstimator) PhaseEstimate(phase string) (time.Duration, bool) {
+	if e == nil {
+		return 0, false
+	}
+	estimate, ok := e.averages[phase]
+	return estimate, ok
+}
+
+// Remaining estimates the time left for steps at now: the full estimate of
+// every pending step plus whatever is left of the estimate for steps in
+// progress. Steps without history contribute nothing. The boolean is false
+// when there is no history to estimate from.
+func (e *ETAEstimator) Remaining(steps []types.WorkflowStep, now time.Time) (time.Duration, bool) {
+	if !e.HasHistory() {
+		return 0, false
+	}
+
+	var remaining time.Duration
+	for _, step := range steps {
+		estimate, ok := e.averages[step.ID]
+		if !ok {
+			continue
+		}
+
+		switch step.Status {
+		case "pending", "":
+			remaining += estimate
+		case "in_progress":
+			if left := estimate - now.Sub(step.StartTime); left > 0 {
+				remaining += left
+			}
+		}
+	}
+	return remaining, true
+}
+
+// FormatETA renders a remaining duration for progress displays
+func FormatETA(remaining time.Duration) string {
+	if remaining < time.Second {
+		return "almost done"
+	}
+	return fmt.Sprintf("~%s", remaining.Round(time.Second))
+}
@@ -0,0 +1,117 @@
+package history
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"ccw/types"
+)
+
+// Run history recorded to history.jsonl, one JSON record per workflow run
+
+// DefaultHistoryPath is where run history is kept, relative to the working directory
+var DefaultHistoryPath = filepath.Join(".ccw", "history.jsonl")
+
+// Record describes one completed or failed workflow run
+type Record struct {
+	Repository  string             `json:"repository"`
+	Language    string             `json:"language,omitempty"`
+	IssueNumber int                `json:"issue_number,omitempty"`
+	StartedAt   time.Time          `json:"started_at"`
+	FinishedAt  time.Time          `json:"finished_at"`
+	Success     bool               `json:"success"`
+	Phases      map[string]float64 `json:"phases"` // Phase ID to duration in seconds
+}
+
+// PhaseDurations extracts the duration of every step that ran to completion
+func PhaseDurations(steps []types.WorkflowStep) map[string]float64 {
+	phases := make(map[string]float64)
+	for _, step := range steps {
+		if step.Status != "completed" || step.StartTime.IsZero() || step.EndTime.Before(step.StartTime) {
+			continue
+		}
+		phases[step.ID] = step.EndTime.Sub(step.StartTime).Seconds()
+	}
+	return phases
+}
+
+// Append adds record as a new line to the history file at path
+func Append(path string, record Record) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to encode history record: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create history directory: %w", err)
+	}
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open history file: %w", err)
+	}
+	defer file.Close()
+
+	if _, err := file.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to write history record: %w", err)
+	}
+	return nil
+}
+
+// Load reads every record from the history file at path. A missing file yields
+// no records, and malformed lines are skipped.
+func Load(path string) ([]Record, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to open history file: %w", err)
+	}
+	defer file.Close()
+
+	var records []Record
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var record Record
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			continue
+		}
+		records = append(records, record)
+	}
+	if err := scanner.Err(); err != nil {
+		return records, fmt.Errorf("failed to read history file: %w", err)
+	}
+	return records, nil
+}
+
+// languageMarkers maps project files to the language they indicate, in priority order
+var languageMarkers = []struct {
+	file     string
+	language string
+}{
+	{"Package.swift", "swift"},
+	{"go.mod", "go"},
+	{"Cargo.toml", "rust"},
+	{"package.json", "javascript"},
+	{"pyproject.toml", "python"},
+	{"requirements.txt", "python"},
+	{"pom.xml", "java"},
+	{"build.gradle", "java"},
+	{"Gemfile", "ruby"},
+}
+
+// DetectLanguage guesses the project language from marker files in dir
+func DetectLanguage(dir string) string {
+	for _, marker := range languageMarkers {
+		if _, err := os.Stat(filepath.Join(dir, marker.file)); err == nil {
+			return marker.language
+		}
+	}
+	return ""
+}
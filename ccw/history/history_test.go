@@ -0,0 +1,178 @@
+package history
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"ccw/types"
+)
+
+func TestAppendAndLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".ccw", "history.jsonl")
+
+	records, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load of missing file failed: %v", err)
+	}
+	if len(records) != 0 {
+		t.Fatalf("Expected no records for missing file, got %d", len(records))
+	}
+
+	first := Record{Repository: "owner/repo", Language: "go", IssueNumber: 1, Success: true, Phases: map[string]float64{"setup": 5}}
+	second := Record{Repository: "owner/repo", Language: "go", IssueNumber: 2, Phases: map[string]float64{"setup": 7}}
+	if err := Append(path, first); err != nil {
+		t.Fatalf("Append failed: %v", err)
+	}
+
+	// A corrupted line must not hide the records around it
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		t.Fatalf("Failed to open history file: %v", err)
+	}
+	file.WriteString("{not json\n")
+	file.Close()
+
+	if err := Append(path, second); err != nil {
+		t.Fatalf("Append failed: %v", err)
+	}
+
+	records, err = Load(path)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("Expected 2 records, got %d", len(records))
+	}
+	if records[0].IssueNumber != 1 || records[1].IssueNumber != 2 {
+		t.Errorf("Expected issues 1 and 2, got %d and %d", records[0].IssueNumber, records[1].IssueNumber)
+	}
+	if records[1].Phases["setup"] != 7 {
+		t.Errorf("Expected setup phase 7, got %v", records[1].Phases["setup"])
+	}
+}
+
+func TestPhaseDurations(t *testing.T) {
+	start := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	steps := []types.WorkflowStep{
+		{ID: "setup", Status: "completed", StartTime: start, EndTime: start.Add(4 * time.Second)},
+		{ID: "fetch", Status: "failed", StartTime: start, EndTime: start.Add(time.Second)},
+		{ID: "analysis", Status: "in_progress", StartTime: start},
+		{ID: "commit", Status: "completed"},
+	}
+
+	phases := PhaseDurations(steps)
+	if len(phases) != 1 {
+		t.Fatalf("Expected 1 phase, got %d: %v", len(phases), phases)
+	}
+	if phases["setup"] != 4 {
+		t.Errorf("Expected setup to take 4s, got %v", phases["setup"])
+	}
+}
+
+func sampleHistory() []Record {
+	return []Record{
+		{Repository: "owner/repo", Language: "swift", Phases: map[string]float64{"setup": 10, "implementation": 100}},
+		{Repository: "owner/repo", Language: "swift", Phases: map[string]float64{"setup": 20, "implementation": 200}},
+		{Repository: "owner/other", Language: "swift", Phases: map[string]float64{"setup": 60, "validation": 30}},
+		{Repository: "owner/tool", Language: "go", Phases: map[string]float64{"setup": 2}},
+	}
+}
+
+func TestNewETAEstimator(t *testing.T) {
+	tests := []struct {
+		name       string
+		repository string
+		language   string
+		phase      string
+		expected   time.Duration
+		found      bool
+	}{
+		{"averages matching repository", "owner/repo", "swift", "setup", 15 * time.Second, true},
+		{"ignores other repositories", "owner/repo", "swift", "validation", 0, false},
+		{"falls back to same language", "owner/new", "swift", "setup", 30 * time.Second, true},
+		{"other languages are not used", "owner/new", "rust", "setup", 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			estimator := NewETAEstimator(sampleHistory(), tt.repository, tt.language)
+			estimate, found := estimator.PhaseEstimate(tt.phase)
+			if found != tt.found {
+				t.Fatalf("Expected found=%v, got %v", tt.found, found)
+			}
+			if estimate != tt.expected {
+				t.Errorf("Expected estimate %v, got %v", tt.expected, estimate)
+			}
+		})
+	}
+}
+
+func TestRemaining(t *testing.T) {
+	now := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	estimator := NewETAEstimator(sampleHistory(), "owner/repo", "swift")
+
+	steps := []types.WorkflowStep{
+		{ID: "setup", Status: "completed"},
+		{ID: "implementation", Status: "in_progress", StartTime: now.Add(-50 * time.Second)},
+		{ID: "commit", Status: "pending"},
+	}
+	remaining, ok := estimator.Remaining(steps, now)
+	if !ok {
+		t.Fatal("Expected an estimate with history")
+	}
+	if remaining != 100*time.Second {
+		t.Errorf("Expected 100s remaining, got %v", remaining)
+	}
+
+	// Overrunning a phase must not produce a negative estimate
+	steps[1].StartTime = now.Add(-10 * time.Minute)
+	steps[0].Status = "pending"
+	remaining, _ = estimator.Remaining(steps, now)
+	if remaining != 15*time.Second {
+		t.Errorf("Expected 15s remaining, got %v", remaining)
+	}
+}
+
+func TestRemainingWithoutHistory(t *testing.T) {
+	steps := []types.WorkflowStep{{ID: "setup", Status: "pending"}}
+
+	var nilEstimator *ETAEstimator
+	if _, ok := nilEstimator.Remaining(steps, time.Now()); ok {
+		t.Error("Expected no estimate from a nil estimator")
+	}
+	if _, ok := NewETAEstimator(nil, "owner/repo", "swift").Remaining(steps, time.Now()); ok {
+		t.Error("Expected no estimate without history")
+	}
+}
+
+func TestFormatETA(t *testing.T) {
+	tests := []struct {
+		remaining time.Duration
+		expected  string
+	}{
+		{0, "almost done"},
+		{500 * time.Millisecond, "almost done"},
+		{90 * time.Second, "~1m30s"},
+		{190400 * time.Millisecond, "~3m10s"},
+	}
+
+	for _, tt := range tests {
+		if got := FormatETA(tt.remaining); got != tt.expected {
+			t.Errorf("FormatETA(%v): expected '%s', got '%s'", tt.remaining, tt.expected, got)
+		}
+	}
+}
+
+func TestDetectLanguage(t *testing.T) {
+	dir := t.TempDir()
+	if lang := DetectLanguage(dir); lang != "" {
+		t.Errorf("Expected no language, got '%s'", lang)
+	}
+
+	os.WriteFile(filepath.Join(dir, "Package.swift"), []byte("// swift-tools-version:5.9\n"), 0644)
+	if lang := DetectLanguage(dir); lang != "swift" {
+		t.Errorf("Expected 'swift', got '%s'", lang)
+	}
+}
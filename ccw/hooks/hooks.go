@@ -0,0 +1,148 @@
+package hooks
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"runtime"
+	"sort"
+	"time"
+)
+
+// Lifecycle hook execution for user-defined commands
+
+// Phase identifies a workflow lifecycle point at which hooks run
+type Phase string
+
+const (
+	PhasePreImplementation Phase = "pre_implementation"
+	PhasePostValidation    Phase = "post_validation"
+	PhasePrePush           Phase = "pre_push"
+	PhasePostPR            Phase = "post_pr"
+	PhasePostPRSmoke       Phase = "post_pr_smoke"
+)
+
+// Hook is a shell command executed at a lifecycle phase
+type Hook struct {
+	Command         string
+	ContinueOnError bool
+}
+
+// HookError reports a hook failure that aborts the workflow
+type HookError struct {
+	Phase   Phase
+	Command string
+	Err     error
+}
+
+func (e *HookError) Error() string {
+	return fmt.Sprintf("%s hook %q failed: %v", e.Phase, e.Command, e.Err)
+}
+
+func (e *HookError) Unwrap() error {
+	return e.Err
+}
+
+// Runner executes configured hooks in the order they were declared
+type Runner struct {
+	hooks   map[Phase][]Hook
+	timeout time.Duration
+	stdout  io.Writer
+	stderr  io.Writer
+}
+
+// NewRunner creates a hook runner. A zero timeout means hooks run without a deadline.
+func NewRunner(hooks map[Phase][]Hook, timeout time.Duration) *Runner {
+	if hooks == nil {
+		hooks = make(map[Phase][]Hook)
+	}
+
+	return &Runner{
+		hooks:   hooks,
+		timeout: timeout,
+		stdout:  os.Stdout,
+		stderr:  os.Stderr,
+	}
+}
+
+// SetOutput redirects hook stdout and stderr
+func (r *Runner) SetOutput(stdout, stderr io.Writer) {
+	r.stdout = stdout
+	r.stderr = stderr
+}
+
+// HasHooks reports whether any hook is configured for the phase
+func (r *Runner) HasHooks(phase Phase) bool {
+	return len(r.hooks[phase]) > 0
+}
+
+// Run executes all hooks for a phase in workDir. CCW_HOOK_PHASE and the given
+// env entries are added to the process environment. The first failing hook that
+// is not marked ContinueOnError stops execution and is returned as a *HookError.
+func (r *Runner) Run(phase Phase, workDir string, env map[string]string) error {
+	for _, hook := range r.hooks[phase] {
+		if err := r.runHook(phase, hook, workDir, env); err != nil {
+			if hook.ContinueOnError {
+				fmt.Fprintf(r.stderr, "%s hook %q failed (continuing): %v\n", phase, hook.Command, err)
+				continue
+			}
+			return &HookError{Phase: phase, Command: hook.Command, Err: err}
+		}
+	}
+
+	return nil
+}
+
+// runHook executes a single hook command through the platform shell
+func (r *Runner) runHook(phase Phase, hook Hook, workDir string, env map[string]string) error {
+	ctx := context.Background()
+	if r.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, r.timeout)
+		defer cancel()
+	}
+
+	cmd := shellCommand(ctx, hook.Command)
+	cmd.Dir = workDir
+	cmd.Stdout = r.stdout
+	cmd.Stderr = r.stderr
+	cmd.Env = append(os.Environ(), buildEnv(phase, env)...)
+	// Don't wait on output pipes held open by orphaned children after a timeout
+	cmd.WaitDelay = time.Second
+
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return fmt.Errorf("timed out after %s", r.timeout)
+		}
+		return err
+	}
+
+	return nil
+}
+
+// shellCommand runs command through the platform shell
+func shellCommand(ctx context.Context, command string) *exec.Cmd {
+	if runtime.GOOS == "windows" {
+		return exec.CommandContext(ctx, "cmd", "/C", command)
+	}
+	return exec.CommandContext(ctx, "sh", "-c", command)
+}
+
+// buildEnv converts the phase and env map into sorted KEY=value entries
+func buildEnv(phase Phase, env map[string]string) []string {
+	entries := []string{fmt.Sprintf("CCW_HOOK_PHASE=%s", phase)}
+
+	keys := make([]string, 0, len(env))
+	for key := range env {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		entries = append(entries, fmt.Sprintf("%s=%s", key, env[key]))
+	}
+
+	return entries
+}
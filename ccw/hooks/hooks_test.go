@@ -0,0 +1,172 @@
+package hooks
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+func setupHookTest(t *testing.T) string {
+	if runtime.GOOS == "windows" {
+		t.Skip("hook tests use POSIX shell commands")
+	}
+
+	tmpDir, err := os.MkdirTemp("", "ccw-hooks-test-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(tmpDir) })
+	return tmpDir
+}
+
+func readLines(t *testing.T, path string) []string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		t.Fatal(err)
+	}
+	return strings.Fields(string(data))
+}
+
+func TestRunnerExecutionOrder(t *testing.T) {
+	tmpDir := setupHookTest(t)
+
+	runner := NewRunner(map[Phase][]Hook{
+		PhasePreImplementation: {
+			{Command: "echo first >> order.txt"},
+			{Command: "echo second >> order.txt"},
+		},
+		PhasePostValidation: {
+			{Command: "echo third >> order.txt"},
+		},
+	}, 0)
+	runner.SetOutput(&bytes.Buffer{}, &bytes.Buffer{})
+
+	for _, phase := range []Phase{PhasePreImplementation, PhasePostValidation, PhasePrePush} {
+		if err := runner.Run(phase, tmpDir, nil); err != nil {
+			t.Fatalf("Unexpected error running %s hooks: %v", phase, err)
+		}
+	}
+
+	got := readLines(t, filepath.Join(tmpDir, "order.txt"))
+	expected := []string{"first", "second", "third"}
+	if strings.Join(got, ",") != strings.Join(expected, ",") {
+		t.Errorf("Expected order %v, got %v", expected, got)
+	}
+}
+
+func TestRunnerAbortOnFailure(t *testing.T) {
+	tmpDir := setupHookTest(t)
+
+	tests := []struct {
+		name        string
+		hooks       []Hook
+		expectError bool
+		expected    []string
+	}{
+		{
+			name: "failure aborts remaining hooks",
+			hooks: []Hook{
+				{Command: "echo before >> order.txt"},
+				{Command: "exit 3"},
+				{Command: "echo after >> order.txt"},
+			},
+			expectError: true,
+			expected:    []string{"before"},
+		},
+		{
+			name: "continue_on_error keeps going",
+			hooks: []Hook{
+				{Command: "echo before >> order.txt"},
+				{Command: "exit 3", ContinueOnError: true},
+				{Command: "echo after >> order.txt"},
+			},
+			expectError: false,
+			expected:    []string{"before", "after"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			os.Remove(filepath.Join(tmpDir, "order.txt"))
+
+			stderr := &bytes.Buffer{}
+			runner := NewRunner(map[Phase][]Hook{PhasePrePush: tt.hooks}, 0)
+			runner.SetOutput(&bytes.Buffer{}, stderr)
+
+			err := runner.Run(PhasePrePush, tmpDir, nil)
+			if tt.expectError {
+				var hookErr *HookError
+				if !errors.As(err, &hookErr) {
+					t.Fatalf("Expected HookError, got %v", err)
+				}
+				if hookErr.Phase != PhasePrePush || hookErr.Command != "exit 3" {
+					t.Errorf("Unexpected hook error details: %+v", hookErr)
+				}
+			} else {
+				if err != nil {
+					t.Fatalf("Expected no error, got %v", err)
+				}
+				if !strings.Contains(stderr.String(), "continuing") {
+					t.Errorf("Expected continued failure to be reported, got '%s'", stderr.String())
+				}
+			}
+
+			got := readLines(t, filepath.Join(tmpDir, "order.txt"))
+			if strings.Join(got, ",") != strings.Join(tt.expected, ",") {
+				t.Errorf("Expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestRunnerEnvironmentAndWorkDir(t *testing.T) {
+	tmpDir := setupHookTest(t)
+
+	stdout := &bytes.Buffer{}
+	runner := NewRunner(map[Phase][]Hook{
+		PhasePostPR: {{Command: `echo "$CCW_HOOK_PHASE $CCW_PR_URL $(basename "$PWD")"`}},
+	}, 0)
+	runner.SetOutput(stdout, &bytes.Buffer{})
+
+	if err := runner.Run(PhasePostPR, tmpDir, map[string]string{"CCW_PR_URL": "https://example.com/pr/1"}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := "post_pr https://example.com/pr/1 " + filepath.Base(tmpDir)
+	if strings.TrimSpace(stdout.String()) != expected {
+		t.Errorf("Expected '%s', got '%s'", expected, strings.TrimSpace(stdout.String()))
+	}
+}
+
+func TestRunnerTimeout(t *testing.T) {
+	tmpDir := setupHookTest(t)
+
+	runner := NewRunner(map[Phase][]Hook{
+		PhasePreImplementation: {{Command: "sleep 5"}},
+	}, 100*time.Millisecond)
+	runner.SetOutput(&bytes.Buffer{}, &bytes.Buffer{})
+
+	err := runner.Run(PhasePreImplementation, tmpDir, nil)
+	if err == nil || !strings.Contains(err.Error(), "timed out") {
+		t.Errorf("Expected timeout error, got %v", err)
+	}
+}
+
+func TestRunnerHasHooks(t *testing.T) {
+	runner := NewRunner(map[Phase][]Hook{PhasePrePush: {{Command: "true"}}}, 0)
+	if !runner.HasHooks(PhasePrePush) {
+		t.Error("Expected pre_push hooks to be reported")
+	}
+	if runner.HasHooks(PhasePostPR) {
+		t.Error("Expected no post_pr hooks")
+	}
+}
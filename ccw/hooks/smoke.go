@@ -0,0 +1,90 @@
+package hooks
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// Post-PR smoke commands whose result is reported on the pull request
+
+// maxSmokeOutput caps the captured output quoted in a PR comment, keeping the
+// tail where failures are usually reported
+const maxSmokeOutput = 10000
+
+// SmokeResult is the outcome of a post-PR smoke command
+type SmokeResult struct {
+	Command  string
+	Output   string // Combined stdout and stderr
+	Passed   bool
+	Duration time.Duration
+	Err      error // Why the command failed; nil when it passed
+}
+
+// RunSmoke executes command in workDir with the runner's timeout and captures
+// its combined output. A failing command is reported in the result rather
+// than returned as an error.
+func (r *Runner) RunSmoke(command, workDir string, env map[string]string) SmokeResult {
+	ctx := context.Background()
+	if r.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, r.timeout)
+		defer cancel()
+	}
+
+	var output bytes.Buffer
+	cmd := shellCommand(ctx, command)
+	cmd.Dir = workDir
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+	cmd.Env = append(os.Environ(), buildEnv(PhasePostPRSmoke, env)...)
+	cmd.WaitDelay = time.Second
+
+	start := time.Now()
+	err := cmd.Run()
+	if err != nil && ctx.Err() == context.DeadlineExceeded {
+		err = fmt.Errorf("timed out after %s", r.timeout)
+	}
+
+	return SmokeResult{
+		Command:  command,
+		Output:   output.String(),
+		Passed:   err == nil,
+		Duration: time.Since(start).Round(time.Millisecond),
+		Err:      err,
+	}
+}
+
+// FormatSmokeComment renders result as a Markdown PR comment with a pass/fail
+// heading and the command output in a collapsible block
+func FormatSmokeComment(result SmokeResult) string {
+	var b strings.Builder
+	if result.Passed {
+		fmt.Fprintf(&b, "### ✅ Smoke check passed\n\n")
+	} else {
+		fmt.Fprintf(&b, "### ❌ Smoke check failed\n\n")
+	}
+	fmt.Fprintf(&b, "Command: `%s` (%s)\n", result.Command, result.Duration)
+	if result.Err != nil {
+		fmt.Fprintf(&b, "Error: %v\n", result.Err)
+	}
+
+	output := strings.TrimRight(result.Output, "\n")
+	if output == "" {
+		b.WriteString("\nThe command produced no output.\n")
+		return b.String()
+	}
+	if len(output) > maxSmokeOutput {
+		output = "... (output truncated)\n" + output[len(output)-maxSmokeOutput:]
+	}
+	// Lengthen the fence when the output itself contains one
+	fence := "```"
+	for strings.Contains(output, fence) {
+		fence += "`"
+	}
+	fmt.Fprintf(&b, "\n<details><summary>Output</summary>\n\n%s\n%s\n%s\n\n</details>\n", fence, output, fence)
+	return b.String()
+}
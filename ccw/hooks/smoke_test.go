@@ -0,0 +1,101 @@
+package hooks
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRunSmokeCapturesOutput(t *testing.T) {
+	tmpDir := setupHookTest(t)
+	runner := NewRunner(nil, 0)
+
+	result := runner.RunSmoke("echo out; echo err >&2; echo $CCW_HOOK_PHASE $CCW_PR_URL", tmpDir, map[string]string{
+		"CCW_PR_URL": "https://github.com/owner/repo/pull/1",
+	})
+	if !result.Passed || result.Err != nil {
+		t.Fatalf("Expected smoke check to pass, got %v", result.Err)
+	}
+	for _, want := range []string{"out", "err", "post_pr_smoke https://github.com/owner/repo/pull/1"} {
+		if !strings.Contains(result.Output, want) {
+			t.Errorf("Expected output to contain %q, got %q", want, result.Output)
+		}
+	}
+}
+
+func TestRunSmokeReportsFailure(t *testing.T) {
+	tmpDir := setupHookTest(t)
+	runner := NewRunner(nil, 0)
+
+	result := runner.RunSmoke("echo broken link; exit 3", tmpDir, nil)
+	if result.Passed || result.Err == nil {
+		t.Fatal("Expected smoke check to fail")
+	}
+	if !strings.Contains(result.Output, "broken link") {
+		t.Errorf("Expected output of the failing command, got %q", result.Output)
+	}
+}
+
+func TestRunSmokeTimeout(t *testing.T) {
+	tmpDir := setupHookTest(t)
+	runner := NewRunner(nil, 100*time.Millisecond)
+
+	result := runner.RunSmoke("sleep 5", tmpDir, nil)
+	if result.Passed || result.Err == nil || !strings.Contains(result.Err.Error(), "timed out") {
+		t.Errorf("Expected a timeout failure, got %v", result.Err)
+	}
+}
+
+func TestFormatSmokeComment(t *testing.T) {
+	tests := []struct {
+		name     string
+		result   SmokeResult
+		contains []string
+		excludes []string
+	}{
+		{
+			name:     "passed",
+			result:   SmokeResult{Command: "make smoke", Output: "all links ok\n", Passed: true, Duration: 2 * time.Second},
+			contains: []string{"### ✅ Smoke check passed", "Command: `make smoke` (2s)", "<details><summary>Output</summary>", "```\nall links ok\n```"},
+			excludes: []string{"Error:"},
+		},
+		{
+			name:     "failed",
+			result:   SmokeResult{Command: "make smoke", Output: "404 /docs", Err: errors.New("exit status 1")},
+			contains: []string{"### ❌ Smoke check failed", "Error: exit status 1", "404 /docs"},
+		},
+		{
+			name:     "no output",
+			result:   SmokeResult{Command: "true", Passed: true},
+			contains: []string{"The command produced no output."},
+			excludes: []string{"<details>"},
+		},
+		{
+			name:     "output containing a fence",
+			result:   SmokeResult{Command: "cat README.md", Output: "```go\nx\n```", Passed: true},
+			contains: []string{"````\n```go\nx\n```\n````"},
+		},
+		{
+			name:     "long output keeps the tail",
+			result:   SmokeResult{Command: "check", Output: strings.Repeat("a", maxSmokeOutput) + "TAIL", Passed: true},
+			contains: []string{"... (output truncated)", "TAIL"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			comment := FormatSmokeComment(tt.result)
+			for _, want := range tt.contains {
+				if !strings.Contains(comment, want) {
+					t.Errorf("Expected comment to contain %q, got:\n%s", want, comment)
+				}
+			}
+			for _, unwanted := range tt.excludes {
+				if strings.Contains(comment, unwanted) {
+					t.Errorf("Expected comment not to contain %q, got:\n%s", unwanted, comment)
+				}
+			}
+		})
+	}
+}
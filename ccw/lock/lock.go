@@ -0,0 +1,244 @@
+package lock
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync/atomic"
+	"syscall"
+	"time"
+)
+
+// Per-issue lockfiles preventing concurrent CCW runs on the same issue
+
+// DefaultLockDir is where issue lockfiles are kept, relative to the working directory
+var DefaultLockDir = filepath.Join(".ccw", "locks")
+
+// LockInfo is the content of a lockfile describing its holder
+type LockInfo struct {
+	PID         int       `json:"pid"`
+	Hostname    string    `json:"hostname"`
+	SessionID   string    `json:"session_id"`
+	IssueNumber int       `json:"issue_number"`
+	AcquiredAt  time.Time `json:"acquired_at"`
+}
+
+// LockedError is returned when the issue lock is held by a live process
+type LockedError struct {
+	Path   string
+	Holder LockInfo
+}
+
+func (e *LockedError) Error() string {
+	return fmt.Sprintf("issue #%d is locked by CCW process %d on %s since %s (lockfile: %s)",
+		e.Holder.IssueNumber, e.Holder.PID, e.Holder.Hostname,
+		e.Holder.AcquiredAt.Format(time.RFC3339), e.Path)
+}
+
+// IssueLock is a held lock on an issue
+type IssueLock struct {
+	path string
+	info LockInfo
+}
+
+// Path returns the lockfile path
+func (l *IssueLock) Path() string {
+	return l.path
+}
+
+// LockPath returns the lockfile path for an issue
+func LockPath(dir string, issueNumber int) string {
+	return filepath.Join(dir, fmt.Sprintf("issue-%d.lock", issueNumber))
+}
+
+// Acquire takes the lock for an issue. A lock left behind by a dead process on
+// this host is reclaimed; a lock held by a live process returns *LockedError.
+func Acquire(dir string, issueNumber int, sessionID string) (*IssueLock, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create lock directory: %w", err)
+	}
+
+	hostname, _ := os.Hostname()
+	info := LockInfo{
+		PID:         os.Getpid(),
+		Hostname:    hostname,
+		SessionID:   sessionID,
+		IssueNumber: issueNumber,
+		AcquiredAt:  time.Now(),
+	}
+	path := LockPath(dir, issueNumber)
+
+	// One retry after reclaiming a stale lock
+	for attempt := 0; attempt < 2; attempt++ {
+		err := createLockFile(path, info)
+		if err == nil {
+			return &IssueLock{path: path, info: info}, nil
+		}
+		if !errors.Is(err, os.ErrExist) {
+			return nil, err
+		}
+
+		holder, readErr := readLockFile(path)
+		if readErr == nil && !isStale(holder, hostname) {
+			return nil, &LockedError{Path: path, Holder: holder}
+		}
+
+		if err := reclaimStale(path, hostname); err != nil {
+			return nil, fmt.Errorf("failed to remove stale lock %s: %w", path, err)
+		}
+	}
+
+	holder, _ := readLockFile(path)
+	return nil, &LockedError{Path: path, Holder: holder}
+}
+
+// AcquireWait polls until the lock is acquired or ctx is done
+func AcquireWait(ctx context.Context, dir string, issueNumber int, sessionID string, pollInterval time.Duration) (*IssueLock, error) {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		lock, err := Acquire(dir, issueNumber, sessionID)
+		var lockedErr *LockedError
+		if err == nil || !errors.As(err, &lockedErr) {
+			return lock, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("gave up waiting for lock: %w", err)
+		case <-ticker.C:
+		}
+	}
+}
+
+// Release removes the lockfile if it is still owned by this lock
+func (l *IssueLock) Release() error {
+	if l == nil {
+		return nil
+	}
+
+	holder, err := readLockFile(l.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+
+	if holder.PID != l.info.PID || holder.SessionID != l.info.SessionID {
+		return nil // Reclaimed by someone else; not ours to remove
+	}
+
+	if err := os.Remove(l.path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to release lock %s: %w", l.path, err)
+	}
+	return nil
+}
+
+// createLockFile atomically creates the lockfile by linking a fully written temp file
+func createLockFile(path string, info LockInfo) error {
+	data, err := json.MarshalIndent(info, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode lock info: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary lockfile: %w", err)
+	}
+	tmpPath := tmp.Name()
+	defer os.Remove(tmpPath)
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write temporary lockfile: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write temporary lockfile: %w", err)
+	}
+
+	if err := os.Link(tmpPath, path); err != nil {
+		if _, statErr := os.Stat(path); statErr == nil {
+			return os.ErrExist
+		}
+		return fmt.Errorf("failed to create lockfile: %w", err)
+	}
+
+	return nil
+}
+
+// reclaimSeq keeps the names reclaimStale moves locks to unique within this process
+var reclaimSeq atomic.Uint64
+
+// reclaimStale removes the stale lock at path. The lock is first renamed to a
+// name unique to this process, so when several processes reclaim the same
+// stale lock only one of them moves it; the others find it gone. Should the
+// moved lock turn out to be a live one, created after the stale holder was
+// read, it is put back.
+func reclaimStale(path, hostname string) error {
+	moved := fmt.Sprintf("%s.stale-%d-%d", path, os.Getpid(), reclaimSeq.Add(1))
+	if err := os.Rename(path, moved); err != nil {
+		if os.IsNotExist(err) {
+			return nil // Another process reclaimed it first
+		}
+		return err
+	}
+	defer os.Remove(moved)
+
+	if holder, err := readLockFile(moved); err == nil && !isStale(holder, hostname) {
+		if err := os.Link(moved, path); err != nil && !os.IsExist(err) {
+			return fmt.Errorf("failed to restore live lock: %w", err)
+		}
+	}
+	return nil
+}
+
+// readLockFile reads and decodes a lockfile
+func readLockFile(path string) (LockInfo, error) {
+	var info LockInfo
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return info, err
+	}
+	if err := json.Unmarshal(data, &info); err != nil {
+		return info, fmt.Errorf("corrupt lockfile %s: %w", path, err)
+	}
+	return info, nil
+}
+
+// isStale reports whether the holder is a dead process on this host.
+// Locks from other hosts cannot be checked and are treated as live.
+func isStale(holder LockInfo, hostname string) bool {
+	if holder.Hostname != hostname {
+		return false
+	}
+	return !processAlive(holder.PID)
+}
+
+// processAlive checks process liveness without affecting the process
+func processAlive(pid int) bool {
+	if pid <= 0 {
+		return false
+	}
+
+	process, err := os.FindProcess(pid)
+	if err != nil {
+		return false
+	}
+
+	// FindProcess opens a handle on Windows and fails for missing processes
+	if runtime.GOOS == "windows" {
+		return true
+	}
+
+	err = process.Signal(syscall.Signal(0))
+	if err == nil {
+		return true
+	}
+	return errors.Is(err, syscall.EPERM)
+}
@@ -0,0 +1,271 @@
+package lock
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+)
+
+func setupLockDir(t *testing.T) string {
+	tmpDir, err := os.MkdirTemp("", "ccw-lock-test-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(tmpDir) })
+	return tmpDir
+}
+
+func writeLockInfo(t *testing.T, path string, info LockInfo) {
+	t.Helper()
+	data, err := json.Marshal(info)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// deadPID returns the PID of a process that has already exited
+func deadPID(t *testing.T) int {
+	t.Helper()
+	cmd := exec.Command("true")
+	if err := cmd.Run(); err != nil {
+		t.Skipf("cannot spawn helper process: %v", err)
+	}
+	return cmd.Process.Pid
+}
+
+func TestAcquireAndRelease(t *testing.T) {
+	dir := setupLockDir(t)
+
+	lock, err := Acquire(dir, 42, "session-a")
+	if err != nil {
+		t.Fatalf("Expected to acquire lock, got %v", err)
+	}
+	if _, err := os.Stat(LockPath(dir, 42)); err != nil {
+		t.Fatalf("Expected lockfile to exist: %v", err)
+	}
+
+	// Second acquisition by a live holder must fail
+	_, err = Acquire(dir, 42, "session-b")
+	var lockedErr *LockedError
+	if !errors.As(err, &lockedErr) {
+		t.Fatalf("Expected LockedError, got %v", err)
+	}
+	if lockedErr.Holder.PID != os.Getpid() || lockedErr.Holder.SessionID != "session-a" {
+		t.Errorf("Unexpected holder info: %+v", lockedErr.Holder)
+	}
+
+	// Different issues do not conflict
+	other, err := Acquire(dir, 43, "session-b")
+	if err != nil {
+		t.Fatalf("Expected lock on a different issue, got %v", err)
+	}
+	defer other.Release()
+
+	if err := lock.Release(); err != nil {
+		t.Fatalf("Release failed: %v", err)
+	}
+	if _, err := os.Stat(LockPath(dir, 42)); !os.IsNotExist(err) {
+		t.Error("Expected lockfile to be removed after release")
+	}
+
+	// Lock can be taken again after release
+	again, err := Acquire(dir, 42, "session-b")
+	if err != nil {
+		t.Fatalf("Expected re-acquire after release, got %v", err)
+	}
+	again.Release()
+}
+
+func TestReleaseDoesNotRemoveForeignLock(t *testing.T) {
+	dir := setupLockDir(t)
+
+	lock, err := Acquire(dir, 7, "session-a")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Simulate the lock being reclaimed by another session
+	hostname, _ := os.Hostname()
+	writeLockInfo(t, lock.Path(), LockInfo{PID: os.Getpid(), Hostname: hostname, SessionID: "session-b", IssueNumber: 7})
+
+	if err := lock.Release(); err != nil {
+		t.Fatalf("Release failed: %v", err)
+	}
+	if _, err := os.Stat(lock.Path()); err != nil {
+		t.Error("Expected foreign lockfile to be left in place")
+	}
+}
+
+func TestStaleLockReclamation(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("stale PID detection test requires POSIX process semantics")
+	}
+	dir := setupLockDir(t)
+	hostname, _ := os.Hostname()
+
+	tests := []struct {
+		name        string
+		content     []byte
+		holder      *LockInfo
+		expectClaim bool
+	}{
+		{
+			name:        "dead process on this host is reclaimed",
+			holder:      &LockInfo{PID: deadPID(t), Hostname: hostname, SessionID: "old", IssueNumber: 1},
+			expectClaim: true,
+		},
+		{
+			name:        "corrupt lockfile is reclaimed",
+			content:     []byte("not json"),
+			expectClaim: true,
+		},
+		{
+			name:        "live process is respected",
+			holder:      &LockInfo{PID: os.Getpid(), Hostname: hostname, SessionID: "live", IssueNumber: 1},
+			expectClaim: false,
+		},
+		{
+			name:        "lock from another host is respected",
+			holder:      &LockInfo{PID: deadPID(t), Hostname: hostname + "-elsewhere", SessionID: "remote", IssueNumber: 1},
+			expectClaim: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := LockPath(dir, 1)
+			if tt.holder != nil {
+				writeLockInfo(t, path, *tt.holder)
+			} else if err := os.WriteFile(path, tt.content, 0644); err != nil {
+				t.Fatal(err)
+			}
+			defer os.Remove(path)
+
+			lock, err := Acquire(dir, 1, "new")
+			if tt.expectClaim {
+				if err != nil {
+					t.Fatalf("Expected stale lock to be reclaimed, got %v", err)
+				}
+				info, readErr := readLockFile(path)
+				if readErr != nil || info.SessionID != "new" {
+					t.Errorf("Expected lockfile to belong to new session, got %+v (%v)", info, readErr)
+				}
+				lock.Release()
+			} else {
+				var lockedErr *LockedError
+				if !errors.As(err, &lockedErr) {
+					t.Errorf("Expected LockedError, got %v", err)
+				}
+			}
+		})
+	}
+}
+
+func TestAcquireWait(t *testing.T) {
+	dir := setupLockDir(t)
+
+	held, err := Acquire(dir, 5, "holder")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		held.Release()
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	lock, err := AcquireWait(ctx, dir, 5, "waiter", 10*time.Millisecond)
+	if err != nil {
+		t.Fatalf("Expected to acquire lock after release, got %v", err)
+	}
+	lock.Release()
+}
+
+func TestAcquireWaitGivesUp(t *testing.T) {
+	dir := setupLockDir(t)
+
+	held, err := Acquire(dir, 6, "holder")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer held.Release()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	if _, err := AcquireWait(ctx, dir, 6, "waiter", 10*time.Millisecond); err == nil {
+		t.Error("Expected error when context expires while lock is held")
+	}
+}
+
+func TestConcurrentStaleLockReclamation(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("stale PID detection test requires POSIX process semantics")
+	}
+	dir := setupLockDir(t)
+	hostname, _ := os.Hostname()
+	writeLockInfo(t, LockPath(dir, 1), LockInfo{PID: deadPID(t), Hostname: hostname, SessionID: "old", IssueNumber: 1})
+
+	// Every reclaimer sees the same stale lock; only one may end up holding it
+	const reclaimers = 8
+	var wg sync.WaitGroup
+	acquired := make(chan *IssueLock, reclaimers)
+	for i := 0; i < reclaimers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if lock, err := Acquire(dir, 1, fmt.Sprintf("session-%d", i)); err == nil {
+				acquired <- lock
+			}
+		}(i)
+	}
+	wg.Wait()
+	close(acquired)
+
+	var holders []*IssueLock
+	for lock := range acquired {
+		holders = append(holders, lock)
+	}
+	if len(holders) != 1 {
+		t.Fatalf("Expected exactly one reclaimer to hold the lock, got %d", len(holders))
+	}
+	info, err := readLockFile(LockPath(dir, 1))
+	if err != nil || info.SessionID != holders[0].info.SessionID {
+		t.Errorf("Expected the lockfile to belong to the holder, got %+v (%v)", info, err)
+	}
+	holders[0].Release()
+}
+
+func TestReclaimStaleRestoresLiveLock(t *testing.T) {
+	dir := setupLockDir(t)
+	hostname, _ := os.Hostname()
+	path := LockPath(dir, 1)
+
+	// A fresh lock created after the stale holder was read must survive
+	writeLockInfo(t, path, LockInfo{PID: os.Getpid(), Hostname: hostname, SessionID: "fresh", IssueNumber: 1})
+	if err := reclaimStale(path, hostname); err != nil {
+		t.Fatalf("reclaimStale failed: %v", err)
+	}
+
+	info, err := readLockFile(path)
+	if err != nil || info.SessionID != "fresh" {
+		t.Errorf("Expected the live lock to be put back, got %+v (%v)", info, err)
+	}
+	if matches, _ := filepath.Glob(path + ".stale-*"); len(matches) != 0 {
+		t.Errorf("Expected no leftover moved locks, got %v", matches)
+	}
+}
@@ -0,0 +1,106 @@
+package logging
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Session log file discovery and tailing
+
+// DefaultLogDir is where session log files are written
+var DefaultLogDir = filepath.Join(".", ".ccw", "logs")
+
+// SessionLogFileName returns the log file name for a session
+func SessionLogFileName(sessionID string) string {
+	return fmt.Sprintf("ccw-%s.log", sessionID)
+}
+
+// ResolveSessionLogFile returns the log file for sessionID, or the most recently
+// written session log when sessionID is empty
+func ResolveSessionLogFile(logDir, sessionID string) (string, error) {
+	if sessionID != "" {
+		path := filepath.Join(logDir, SessionLogFileName(sessionID))
+		if _, err := os.Stat(path); err != nil {
+			return "", fmt.Errorf("no log file for session %s in %s", sessionID, logDir)
+		}
+		return path, nil
+	}
+
+	matches, err := filepath.Glob(filepath.Join(logDir, "ccw-*.log"))
+	if err != nil {
+		return "", fmt.Errorf("failed to list log files: %w", err)
+	}
+
+	var latestPath string
+	var latestTime time.Time
+	for _, path := range matches {
+		info, err := os.Stat(path)
+		if err != nil || info.IsDir() {
+			continue
+		}
+		if latestPath == "" || info.ModTime().After(latestTime) {
+			latestPath = path
+			latestTime = info.ModTime()
+		}
+	}
+
+	if latestPath == "" {
+		return "", fmt.Errorf("no session log files found in %s (enable file logging with CCW_LOG_FILE=true)", logDir)
+	}
+
+	return latestPath, nil
+}
+
+// PrintSessionLog writes the session log to w
+func PrintSessionLog(logFile string, w io.Writer) error {
+	file, err := os.Open(logFile)
+	if err != nil {
+		return fmt.Errorf("failed to open log file: %w", err)
+	}
+	defer file.Close()
+
+	if _, err := io.Copy(w, file); err != nil {
+		return fmt.Errorf("failed to read log file: %w", err)
+	}
+	return nil
+}
+
+// FollowFile copies new content appended to path into w until ctx is done,
+// starting at offset. Truncation restarts from the beginning of the file.
+func FollowFile(ctx context.Context, path string, offset int64, w io.Writer, pollInterval time.Duration) error {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		info, err := os.Stat(path)
+		if err != nil {
+			return fmt.Errorf("failed to stat log file: %w", err)
+		}
+
+		if info.Size() < offset {
+			offset = 0
+		}
+
+		if info.Size() > offset {
+			file, err := os.Open(path)
+			if err != nil {
+				return fmt.Errorf("failed to open log file: %w", err)
+			}
+			if _, err := file.Seek(offset, io.SeekStart); err == nil {
+				copied, _ := io.Copy(w, file)
+				offset += copied
+			}
+			file.Close()
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,176 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func setupLogDir(t *testing.T) string {
+	tmpDir, err := os.MkdirTemp("", "ccw-logs-test-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(tmpDir) })
+	return tmpDir
+}
+
+func writeLog(t *testing.T, path, content string, modTime time.Time) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(path, modTime, modTime); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestResolveSessionLogFile(t *testing.T) {
+	logDir := setupLogDir(t)
+	now := time.Now()
+
+	writeLog(t, filepath.Join(logDir, SessionLogFileName("100-old")), "old\n", now.Add(-2*time.Hour))
+	writeLog(t, filepath.Join(logDir, SessionLogFileName("200-new")), "new\n", now.Add(-time.Minute))
+	writeLog(t, filepath.Join(logDir, "other.txt"), "ignored\n", now)
+
+	tests := []struct {
+		name        string
+		sessionID   string
+		expected    string
+		expectError bool
+	}{
+		{"latest session when no ID", "", SessionLogFileName("200-new"), false},
+		{"explicit session", "100-old", SessionLogFileName("100-old"), false},
+		{"unknown session", "999-missing", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path, err := ResolveSessionLogFile(logDir, tt.sessionID)
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("Expected error, got path %s", path)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if filepath.Base(path) != tt.expected {
+				t.Errorf("Expected '%s', got '%s'", tt.expected, filepath.Base(path))
+			}
+		})
+	}
+}
+
+func TestResolveSessionLogFileEmptyDir(t *testing.T) {
+	if _, err := ResolveSessionLogFile(setupLogDir(t), ""); err == nil {
+		t.Error("Expected error when no log files exist")
+	}
+}
+
+func TestPrintSessionLog(t *testing.T) {
+	logDir := setupLogDir(t)
+	logFile := filepath.Join(logDir, SessionLogFileName("300-print"))
+	writeLog(t, logFile, "first\nsecond\n", time.Now())
+
+	var out bytes.Buffer
+	if err := PrintSessionLog(logFile, &out); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := "first\nsecond\n"
+	if out.String() != expected {
+		t.Errorf("Expected '%s', got '%s'", expected, out.String())
+	}
+}
+
+// syncBuffer is a bytes.Buffer safe for concurrent use
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func TestFollowFileGrowingFile(t *testing.T) {
+	logDir := setupLogDir(t)
+	logFile := filepath.Join(logDir, SessionLogFileName("400-follow"))
+	writeLog(t, logFile, "first\n", time.Now())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	out := &syncBuffer{}
+	done := make(chan error, 1)
+
+	go func() {
+		done <- FollowFile(ctx, logFile, int64(len("first\n")), out, 5*time.Millisecond)
+	}()
+
+	file, err := os.OpenFile(logFile, os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, line := range []string{"second\n", "third\n"} {
+		if _, err := file.WriteString(line); err != nil {
+			t.Fatal(err)
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	file.Close()
+
+	deadline := time.Now().Add(time.Second)
+	for !strings.Contains(out.String(), "third") && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	cancel()
+
+	if err := <-done; err != nil {
+		t.Fatalf("FollowFile returned error: %v", err)
+	}
+	if out.String() != "second\nthird\n" {
+		t.Errorf("Expected only appended lines, got '%s'", out.String())
+	}
+}
+
+func TestFollowFileTruncation(t *testing.T) {
+	logDir := setupLogDir(t)
+	logFile := filepath.Join(logDir, SessionLogFileName("500-trunc"))
+	writeLog(t, logFile, "a long line before truncation\n", time.Now())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	out := &syncBuffer{}
+	done := make(chan error, 1)
+
+	go func() {
+		done <- FollowFile(ctx, logFile, int64(len("a long line before truncation\n")), out, 5*time.Millisecond)
+	}()
+
+	time.Sleep(20 * time.Millisecond)
+	writeLog(t, logFile, "restart\n", time.Now())
+
+	deadline := time.Now().Add(time.Second)
+	for !strings.Contains(out.String(), "restart") && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	cancel()
+	<-done
+
+	if out.String() != "restart\n" {
+		t.Errorf("Expected content after truncation, got '%s'", out.String())
+	}
+}
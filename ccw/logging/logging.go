@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sync"
 	"time"
 
 	"ccw/types"
@@ -25,6 +26,12 @@ type Logger struct {
 	sessionID  string
 	enableFile bool
 	enableJSON bool
+
+	phaseMu sync.RWMutex
+	phase   string // Workflow phase attached to every entry
+
+	traceMu   sync.Mutex
+	traceFile *os.File // Sink for Trace entries, kept out of the main log when set
 }
 
 // Initialize logger
@@ -52,13 +59,11 @@ func NewLogger(sessionID string, enableFile bool) (*Logger, error) {
 
 	// Initialize file logging if enabled
 	if enableFile {
-		logDir := filepath.Join(".", ".ccw", "logs")
-		if err := os.MkdirAll(logDir, 0755); err != nil {
+		if err := os.MkdirAll(DefaultLogDir, 0755); err != nil {
 			return nil, fmt.Errorf("failed to create log directory: %w", err)
 		}
 
-		logFileName := fmt.Sprintf("ccw-%s.log", sessionID)
-		logFilePath := filepath.Join(logDir, logFileName)
+		logFilePath := filepath.Join(DefaultLogDir, SessionLogFileName(sessionID))
 
 		file, err := os.OpenFile(logFilePath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
 		if err != nil {
@@ -70,12 +75,34 @@ func NewLogger(sessionID string, enableFile bool) (*Logger, error) {
 	return logger, nil
 }
 
+// SetLevel sets the minimum level of entries that are logged
+func (l *Logger) SetLevel(level types.LogLevel) {
+	l.logLevel = level
+}
+
 // Close logger
 func (l *Logger) Close() error {
+	traceErr := l.closeTraceFile()
 	if l.logFile != nil {
-		return l.logFile.Close()
+		if err := l.logFile.Close(); err != nil {
+			return err
+		}
 	}
-	return nil
+	return traceErr
+}
+
+// SetPhase sets the workflow phase recorded on subsequent entries; "" clears it
+func (l *Logger) SetPhase(phase string) {
+	l.phaseMu.Lock()
+	defer l.phaseMu.Unlock()
+	l.phase = phase
+}
+
+// Phase returns the workflow phase currently recorded on entries
+func (l *Logger) Phase() string {
+	l.phaseMu.RLock()
+	defer l.phaseMu.RUnlock()
+	return l.phase
 }
 
 // Log methods
@@ -111,6 +138,7 @@ func (l *Logger) log(level types.LogLevel, component, message string, context ..
 		Message:   message,
 		SessionID: l.sessionID,
 		Component: component,
+		Phase:     l.Phase(),
 	}
 
 	if len(context) > 0 {
@@ -160,11 +188,19 @@ func (l *Logger) outputToConsole(entry types.LogEntry) {
 		} else {
 			timestamp := entry.Timestamp.Format("2006-01-02 15:04:05")
 			fmt.Printf("[%s] %s [%s] %s: %s\n",
-				timestamp, entry.Level, entry.Component, entry.SessionID, entry.Message)
+				timestamp, entry.Level, entryLabel(entry), entry.SessionID, entry.Message)
 		}
 	}
 }
 
+// entryLabel formats the bracketed source of a text log line as "component" or "component/phase"
+func entryLabel(entry types.LogEntry) string {
+	if entry.Phase == "" {
+		return entry.Component
+	}
+	return entry.Component + "/" + entry.Phase
+}
+
 // Output log entry to file
 func (l *Logger) outputToFile(entry types.LogEntry) {
 	if l.logFile == nil {
@@ -179,7 +215,7 @@ func (l *Logger) outputToFile(entry types.LogEntry) {
 	} else {
 		timestamp := entry.Timestamp.Format("2006-01-02 15:04:05")
 		output = fmt.Sprintf("[%s] %s [%s] %s: %s\n",
-			timestamp, entry.Level, entry.Component, entry.SessionID, entry.Message)
+			timestamp, entry.Level, entryLabel(entry), entry.SessionID, entry.Message)
 	}
 
 	l.logFile.WriteString(output)
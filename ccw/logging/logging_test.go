@@ -0,0 +1,91 @@
+package logging
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"ccw/types"
+)
+
+// captureUILogs routes UI log entries into a slice for the duration of the test
+func captureUILogs(t *testing.T) *[]types.LogEntry {
+	t.Helper()
+	var entries []types.LogEntry
+	previousMode := IsUIMode()
+	SetUILogFunction(func(entry types.LogEntry) { entries = append(entries, entry) })
+	SetUIMode(true)
+	t.Cleanup(func() {
+		SetUILogFunction(nil)
+		SetUIMode(previousMode)
+	})
+	return &entries
+}
+
+func TestLoggerAttachesPhase(t *testing.T) {
+	t.Setenv("CCW_LOG_LEVEL", "debug")
+	entries := captureUILogs(t)
+
+	logger, err := NewLogger("phase-test", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	logger.Info("app", "before any phase")
+	logger.SetPhase("implementation")
+	logger.Debug("claude", "running")
+	logger.Error("git", "failed")
+	logger.SetPhase("validation")
+	logger.Info("validator", "checking")
+	logger.SetPhase("")
+	logger.Info("app", "done")
+
+	expected := []string{"", "implementation", "implementation", "validation", ""}
+	if len(*entries) != len(expected) {
+		t.Fatalf("Expected %d entries, got %d", len(expected), len(*entries))
+	}
+	for i, entry := range *entries {
+		if entry.Phase != expected[i] {
+			t.Errorf("Entry %d (%s): expected phase '%s', got '%s'", i, entry.Message, expected[i], entry.Phase)
+		}
+	}
+}
+
+func TestLoggerWritesPhaseToFile(t *testing.T) {
+	captureUILogs(t)
+	previousDir := DefaultLogDir
+	DefaultLogDir = setupLogDir(t)
+	t.Cleanup(func() { DefaultLogDir = previousDir })
+
+	tests := []struct {
+		name     string
+		json     string
+		expected string
+	}{
+		{"text", "false", "[validator/validation] file-test-text: checking"},
+		{"json", "true", `"phase":"validation"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("CCW_LOG_JSON", tt.json)
+			sessionID := "file-test-" + tt.name
+			logger, err := NewLogger(sessionID, true)
+			if err != nil {
+				t.Fatal(err)
+			}
+			logger.SetPhase("validation")
+			logger.Info("validator", "checking")
+			logger.Close()
+
+			data, err := os.ReadFile(filepath.Join(DefaultLogDir, SessionLogFileName(sessionID)))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !strings.Contains(string(data), tt.expected) {
+				t.Errorf("Expected log file to contain '%s', got: %s", tt.expected, data)
+			}
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package logging
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"ccw/types"
+)
+
+// Dedicated trace sink for TRACE_MODE function traces
+
+// DefaultTracePath returns the trace file used when --trace-file names no path
+func DefaultTracePath(sessionID string) string {
+	return filepath.Join(".", ".ccw", fmt.Sprintf("trace-%s.log", sessionID))
+}
+
+// SetTraceFile sends trace entries to the file at path, appending, instead of
+// the main log
+func (l *Logger) SetTraceFile(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create trace directory: %w", err)
+	}
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open trace file: %w", err)
+	}
+
+	l.traceMu.Lock()
+	defer l.traceMu.Unlock()
+	if l.traceFile != nil {
+		l.traceFile.Close()
+	}
+	l.traceFile = file
+	return nil
+}
+
+// Trace records a function call with its caller and parameters. With a trace
+// file set the entry goes only there; otherwise it is logged at debug level.
+func (l *Logger) Trace(funcName, caller string, params map[string]interface{}) {
+	l.traceMu.Lock()
+	defer l.traceMu.Unlock()
+	if l.traceFile == nil {
+		context := make(map[string]interface{}, len(params)+1)
+		for key, value := range params {
+			context[key] = value
+		}
+		if caller != "" {
+			context["caller"] = caller
+		}
+		l.Debug("trace", fmt.Sprintf("FUNCTION: %s", funcName), context)
+		return
+	}
+
+	entry := types.LogEntry{
+		Timestamp: time.Now(),
+		Level:     "TRACE",
+		Message:   fmt.Sprintf("FUNCTION: %s", funcName),
+		SessionID: l.sessionID,
+		Component: "trace",
+		Phase:     l.Phase(),
+		Context:   map[string]interface{}{"caller": caller, "params": params},
+	}
+
+	var output string
+	if l.enableJSON {
+		if jsonData, err := json.Marshal(entry); err == nil {
+			output = string(jsonData) + "\n"
+		}
+	} else {
+		paramsJSON, err := json.Marshal(params)
+		if err != nil {
+			paramsJSON = []byte(fmt.Sprintf("%q", fmt.Sprint(params)))
+		}
+		output = fmt.Sprintf("[%s] TRACE [%s] %s: %s caller=%s params=%s\n",
+			entry.Timestamp.Format("2006-01-02 15:04:05.000"), entryLabel(entry), entry.SessionID, entry.Message, caller, paramsJSON)
+	}
+	l.traceFile.WriteString(output)
+}
+
+// closeTraceFile closes the trace sink, if any
+func (l *Logger) closeTraceFile() error {
+	l.traceMu.Lock()
+	defer l.traceMu.Unlock()
+	if l.traceFile == nil {
+		return nil
+	}
+	err := l.traceFile.Close()
+	l.traceFile = nil
+	return err
+}
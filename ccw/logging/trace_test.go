@@ -0,0 +1,75 @@
+package logging
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestTraceGoesToTraceFileNotMainLog(t *testing.T) {
+	captureUILogs(t)
+	t.Setenv("CCW_LOG_LEVEL", "debug")
+	t.Setenv("CCW_LOG_JSON", "false")
+	previousDir := DefaultLogDir
+	DefaultLogDir = setupLogDir(t)
+	t.Cleanup(func() { DefaultLogDir = previousDir })
+
+	logger, err := NewLogger("trace-test", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tracePath := filepath.Join(t.TempDir(), "nested", "trace.log")
+	if err := logger.SetTraceFile(tracePath); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	logger.Info("app", "regular entry")
+	logger.Trace("runImplementation", "app.(*CCWApp).ExecuteWorkflow:42", map[string]interface{}{"issue_number": 7})
+	if err := logger.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	trace, err := os.ReadFile(tracePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, expected := range []string{"TRACE", "FUNCTION: runImplementation", "caller=app.(*CCWApp).ExecuteWorkflow:42", `params={"issue_number":7}`} {
+		if !strings.Contains(string(trace), expected) {
+			t.Errorf("Expected trace file to contain '%s', got: %s", expected, trace)
+		}
+	}
+	if strings.Contains(string(trace), "regular entry") {
+		t.Errorf("Expected regular entries to stay out of the trace file, got: %s", trace)
+	}
+
+	mainLog, err := os.ReadFile(filepath.Join(DefaultLogDir, SessionLogFileName("trace-test")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(mainLog), "runImplementation") {
+		t.Errorf("Expected the trace to stay out of the main log, got: %s", mainLog)
+	}
+	if !strings.Contains(string(mainLog), "regular entry") {
+		t.Errorf("Expected the main log to keep regular entries, got: %s", mainLog)
+	}
+}
+
+func TestTraceWithoutTraceFileLogsDebug(t *testing.T) {
+	t.Setenv("CCW_LOG_LEVEL", "debug")
+	entries := captureUILogs(t)
+
+	logger, err := NewLogger("trace-fallback", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	logger.Trace("runImplementation", "caller:1", map[string]interface{}{"issue_number": 7})
+
+	if len(*entries) != 1 {
+		t.Fatalf("Expected 1 entry, got %d", len(*entries))
+	}
+	entry := (*entries)[0]
+	if entry.Component != "trace" || entry.Message != "FUNCTION: runImplementation" || entry.Context["caller"] != "caller:1" {
+		t.Errorf("Expected a debug trace entry with its caller, got %+v", entry)
+	}
+}
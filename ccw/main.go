@@ -7,10 +7,27 @@ import (
 
 	"ccw/app"
 	"ccw/config"
+	"ccw/consoleui"
 	"ccw/ui"
 )
 
 func main() {
+	// --profile may appear anywhere and selects the GitHub account for any command
+	args, profile, err := app.ExtractProfileFlag(os.Args)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	if profile != "" {
+		os.Setenv("CCW_PROFILE", profile)
+	}
+	// --no-emoji forces plain console characters for the whole run
+	args, noEmoji := app.ExtractNoEmojiFlag(args)
+	if noEmoji {
+		os.Setenv(consoleui.NoEmojiEnv, "true")
+	}
+	os.Args = args
+
 	if len(os.Args) < 2 {
 		app.PrintUsage()
 		os.Exit(1)
@@ -21,12 +38,39 @@ func main() {
 	case "-h", "--help":
 		app.PrintUsage()
 		return
+	case "--version", "version":
+		app.PrintVersion()
+		return
 	case "list":
 		app.HandleListCommand()
 		return
 	case "doctor":
 		app.HandleDoctorCommand()
 		return
+	case "logs":
+		app.HandleLogsCommand()
+		return
+	case "reauth":
+		app.HandleReauthCommand()
+		return
+	case "ship":
+		app.HandleShipCommand()
+		return
+	case "open":
+		app.HandleOpenCommand()
+		return
+	case "diff":
+		app.HandleDiffCommand()
+		return
+	case "prune-branches":
+		app.HandlePruneBranchesCommand()
+		return
+	case "profiles":
+		app.HandleProfilesCommand()
+		return
+	case "replay":
+		app.HandleReplayCommand()
+		return
 	case "--demo-ui":
 		ui.RunBubbleTeaDemo()
 		return
@@ -60,19 +104,31 @@ func main() {
 	}
 
 	// Default case: issue URL provided
-	issueURL := os.Args[1]
+	issueURL, options := parseIssueArgs(os.Args[1:])
 
 	ccwApp, err := app.NewCCWApp()
 	if err != nil {
 		log.Fatalf("Failed to initialize application: %v", err)
 	}
 	defer ccwApp.Cleanup()
+	ccwApp.SetWorkflowOptions(options)
 
 	if err := ccwApp.ExecuteWorkflow(issueURL); err != nil {
 		log.Fatalf("Workflow failed: %v", err)
 	}
 }
 
+// parseIssueArgs parses the issue URL and workflow options, exiting on invalid input
+func parseIssueArgs(args []string) (string, *app.WorkflowOptions) {
+	issueURL, options, err := app.ParseWorkflowArgs(args)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		app.PrintUsage()
+		os.Exit(1)
+	}
+	return issueURL, options
+}
+
 // handleInitConfig generates sample configuration file
 func handleInitConfig() {
 	filename := "ccw.yaml"
@@ -108,13 +164,14 @@ func handleDebugMode() {
 	}
 
 	app.EnableDebugMode()
-	issueURL := os.Args[2]
+	issueURL, options := parseIssueArgs(os.Args[2:])
 
 	ccwApp, err := app.NewCCWApp()
 	if err != nil {
 		log.Fatalf("Failed to initialize application: %v", err)
 	}
 	defer ccwApp.Cleanup()
+	ccwApp.SetWorkflowOptions(options)
 
 	if err := ccwApp.ExecuteWorkflowWithRecovery(issueURL); err != nil {
 		log.Fatalf("Workflow failed: %v", err)
@@ -130,13 +187,14 @@ func handleVerboseMode() {
 	}
 
 	app.EnableVerboseMode()
-	issueURL := os.Args[2]
+	issueURL, options := parseIssueArgs(os.Args[2:])
 
 	ccwApp, err := app.NewCCWApp()
 	if err != nil {
 		log.Fatalf("Failed to initialize application: %v", err)
 	}
 	defer ccwApp.Cleanup()
+	ccwApp.SetWorkflowOptions(options)
 
 	if err := ccwApp.ExecuteWorkflowWithRecovery(issueURL); err != nil {
 		log.Fatalf("Workflow failed: %v", err)
@@ -152,13 +210,14 @@ func handleTraceMode() {
 	}
 
 	app.EnableTraceMode()
-	issueURL := os.Args[2]
+	issueURL, options := parseIssueArgs(os.Args[2:])
 
 	ccwApp, err := app.NewCCWApp()
 	if err != nil {
 		log.Fatalf("Failed to initialize application: %v", err)
 	}
 	defer ccwApp.Cleanup()
+	ccwApp.SetWorkflowOptions(options)
 
 	if err := ccwApp.ExecuteWorkflowWithRecovery(issueURL); err != nil {
 		log.Fatalf("Workflow failed: %v", err)
@@ -176,13 +235,14 @@ func handleConsoleMode() {
 	// Set environment variable to force console mode
 	os.Setenv("CCW_CONSOLE_MODE", "true")
 
-	issueURL := os.Args[2]
+	issueURL, options := parseIssueArgs(os.Args[2:])
 
 	ccwApp, err := app.NewCCWApp()
 	if err != nil {
 		log.Fatalf("Failed to initialize application: %v", err)
 	}
 	defer ccwApp.Cleanup()
+	ccwApp.SetWorkflowOptions(options)
 
 	if err := ccwApp.ExecuteWorkflow(issueURL); err != nil {
 		log.Fatalf("Workflow failed: %v", err)
@@ -0,0 +1,47 @@
+package claude
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+)
+
+// credentialEnvVars hold credentials Claude Code uses instead of a stored login
+var credentialEnvVars = []string{"ANTHROPIC_API_KEY", "ANTHROPIC_AUTH_TOKEN", "CLAUDE_CODE_OAUTH_TOKEN"}
+
+// keychainHasCredentials reports whether the macOS keychain holds the Claude Code login
+var keychainHasCredentials = func() bool {
+	return exec.Command("security", "find-generic-password", "-s", "Claude Code-credentials").Run() == nil
+}
+
+// FindExecutable returns the path of the Claude Code CLI, from PATH or a
+// common installation location
+func FindExecutable() (string, error) {
+	return findClaudeExecutable()
+}
+
+// HasCredentials reports whether Claude Code has credentials to use, without
+// sending it a request: a key or token in the environment, or a login saved
+// by `claude /login` in the config directory or, on macOS, the keychain.
+func HasCredentials() bool {
+	for _, name := range credentialEnvVars {
+		if os.Getenv(name) != "" {
+			return true
+		}
+	}
+
+	configDir := os.Getenv("CLAUDE_CONFIG_DIR")
+	if configDir == "" {
+		homeDir, err := os.UserHomeDir()
+		if err != nil {
+			return false
+		}
+		configDir = filepath.Join(homeDir, ".claude")
+	}
+	if info, err := os.Stat(filepath.Join(configDir, ".credentials.json")); err == nil && info.Size() > 0 {
+		return true
+	}
+
+	return runtime.GOOS == "darwin" && keychainHasCredentials()
+}
@@ -0,0 +1,36 @@
+package claude
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestHasCredentials(t *testing.T) {
+	original := keychainHasCredentials
+	keychainHasCredentials = func() bool { return false }
+	defer func() { keychainHasCredentials = original }()
+
+	configDir := t.TempDir()
+	t.Setenv("CLAUDE_CONFIG_DIR", configDir)
+	for _, name := range credentialEnvVars {
+		t.Setenv(name, "")
+	}
+
+	if HasCredentials() {
+		t.Error("Expected no credentials without a key or a stored login")
+	}
+
+	t.Setenv("ANTHROPIC_API_KEY", "sk-test")
+	if !HasCredentials() {
+		t.Error("Expected an API key in the environment to count as credentials")
+	}
+	t.Setenv("ANTHROPIC_API_KEY", "")
+
+	if err := os.WriteFile(filepath.Join(configDir, ".credentials.json"), []byte(`{"claudeAiOauth":{}}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if !HasCredentials() {
+		t.Error("Expected a stored login to count as credentials")
+	}
+}
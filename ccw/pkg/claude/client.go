@@ -7,12 +7,14 @@ import (
 	"os/exec"
 	"path/filepath"
 	"strings"
+	"sync/atomic"
 	"time"
 )
 
 // Client represents a Claude Code CLI client
 type Client struct {
-	timeout time.Duration
+	timeout     time.Duration
+	idleTimeout time.Duration // Abort non-interactive runs silent this long, 0 = disabled
 }
 
 // NewClient creates a new Claude client with specified timeout
@@ -22,6 +24,12 @@ func NewClient(timeout time.Duration) *Client {
 	}
 }
 
+// SetIdleTimeout makes ExecuteNonInteractive abort a run that writes nothing
+// for idle; 0 disables the watchdog
+func (c *Client) SetIdleTimeout(idle time.Duration) {
+	c.idleTimeout = idle
+}
+
 // LaunchInteractive starts an interactive Claude Code session
 func (c *Client) LaunchInteractive(workdir, contextContent string) error {
 	// Create context file
@@ -141,29 +149,43 @@ func (c *Client) ExecuteNonInteractive(workdir, prompt string) (string, error) {
 		return "", fmt.Errorf("Claude Code executable not found: %w", err)
 	}
 
+	// Create context with timeout
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
 	// Use --print flag for non-interactive output
-	cmd := exec.Command(claudePath, "--print")
+	cmd := exec.CommandContext(ctx, claudePath, "--print")
 	cmd.Dir = workdir
+	// Don't wait on children still holding stdout after Claude Code is killed
+	cmd.WaitDelay = time.Second
 
 	// Write prompt to stdin
 	cmd.Stdin = createPromptReader(prompt)
 
-	// Create context with timeout
-	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
-	defer cancel()
+	// Output on either stream shows the run is still making progress
+	stdout := NewActivityWriter()
+	cmd.Stdout = stdout
+	cmd.Stderr = stdout.Silent()
+
+	var stalled atomic.Bool
+	if c.idleTimeout > 0 {
+		go WatchIdle(ctx, stdout, c.idleTimeout, func() {
+			stalled.Store(true)
+			cancel()
+		})
+	}
 
-	// Execute with context
-	output, err := cmd.Output()
-	if err != nil {
-		select {
-		case <-ctx.Done():
+	if err := cmd.Run(); err != nil {
+		if stalled.Load() {
+			return "", &IdleTimeoutError{Idle: c.idleTimeout}
+		}
+		if ctx.Err() == context.DeadlineExceeded {
 			return "", fmt.Errorf("Claude Code timed out after %v", c.timeout)
-		default:
-			return "", fmt.Errorf("Claude Code execution failed: %w", err)
 		}
+		return "", fmt.Errorf("Claude Code execution failed: %w", err)
 	}
 
-	return string(output), nil
+	return string(stdout.Bytes()), nil
 }
 
 // GenerateCommitMessage generates a commit message using Claude
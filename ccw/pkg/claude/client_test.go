@@ -474,9 +474,37 @@ func TestExecuteNonInteractive_ExecutableNotFound(t *testing.T) {
 }
 
 func TestExecuteNonInteractive_Timeout(t *testing.T) {
-	// Skip this test as the current implementation doesn't properly handle context timeout in cmd.Output()
-	// The cmd.Output() method doesn't respect context cancellation properly
-	t.Skip("Context timeout not properly implemented in ExecuteNonInteractive - would need cmd.Start() and cmd.Wait() with goroutine")
+	client := NewClient(300 * time.Millisecond)
+	workdir := setupTempWorkdir(t)
+	setupFakeClaude(t, "echo started\nexec sleep 30")
+
+	start := time.Now()
+	_, err := client.ExecuteNonInteractive(workdir, "test prompt")
+	if err == nil {
+		t.Fatal("Expected timeout error")
+	}
+	if !strings.Contains(err.Error(), "timed out after") {
+		t.Errorf("Expected timeout error, got: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 10*time.Second {
+		t.Errorf("Expected the stalled process to be killed promptly, took %v", elapsed)
+	}
+}
+
+// setupFakeClaude puts a fake claude running body on PATH
+func setupFakeClaude(t *testing.T, body string) {
+	t.Helper()
+	tempDir := setupTempWorkdir(t)
+	script := "#!/bin/bash\n" + body + "\n"
+	if err := os.WriteFile(filepath.Join(tempDir, "claude"), []byte(script), 0755); err != nil {
+		t.Fatalf("Failed to create fake executable: %v", err)
+	}
+
+	originalPath := os.Getenv("PATH")
+	t.Cleanup(func() {
+		os.Setenv("PATH", originalPath)
+	})
+	os.Setenv("PATH", tempDir+string(os.PathListSeparator)+originalPath)
 }
 
 // Tests for AI generation methods
@@ -0,0 +1,91 @@
+package claude
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// Idle watchdog for non-interactive Claude Code runs
+
+// IdleTimeoutError reports a Claude Code run aborted because it produced no
+// output for the idle timeout
+type IdleTimeoutError struct {
+	Idle time.Duration
+}
+
+func (e *IdleTimeoutError) Error() string {
+	return fmt.Sprintf("Claude Code produced no output for %d seconds; aborted as stuck", int(e.Idle.Seconds()))
+}
+
+// ActivityWriter collects output and records when it was last written to
+type ActivityWriter struct {
+	mu       sync.Mutex
+	buf      bytes.Buffer
+	lastSeen time.Time
+}
+
+// NewActivityWriter returns an empty writer that counts as active from now
+func NewActivityWriter() *ActivityWriter {
+	return &ActivityWriter{lastSeen: time.Now()}
+}
+
+func (w *ActivityWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.lastSeen = time.Now()
+	return w.buf.Write(p)
+}
+
+// Silent returns a writer whose writes count as activity on w without being
+// collected, for output such as stderr that is not part of the result
+func (w *ActivityWriter) Silent() io.Writer {
+	return silentActivity{w}
+}
+
+type silentActivity struct {
+	w *ActivityWriter
+}
+
+func (s silentActivity) Write(p []byte) (int, error) {
+	s.w.mu.Lock()
+	defer s.w.mu.Unlock()
+	s.w.lastSeen = time.Now()
+	return len(p), nil
+}
+
+// idleFor returns how long ago output was last written (or the writer created)
+func (w *ActivityWriter) idleFor() time.Duration {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return time.Since(w.lastSeen)
+}
+
+// Bytes returns a copy of the output collected so far
+func (w *ActivityWriter) Bytes() []byte {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return append([]byte(nil), w.buf.Bytes()...)
+}
+
+// WatchIdle calls abort once w has been idle for idle, checking a few times
+// per idle period until ctx is done
+func WatchIdle(ctx context.Context, w *ActivityWriter, idle time.Duration, abort func()) {
+	ticker := time.NewTicker(idle / 4)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if w.idleFor() >= idle {
+				abort()
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package claude
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestExecuteNonInteractiveAbortsIdleRun(t *testing.T) {
+	setupFakeClaude(t, "echo started\nexec sleep 30")
+	client := NewClient(30 * time.Second)
+	client.SetIdleTimeout(400 * time.Millisecond)
+
+	start := time.Now()
+	_, err := client.ExecuteNonInteractive(t.TempDir(), "prompt")
+
+	var idleErr *IdleTimeoutError
+	if !errors.As(err, &idleErr) {
+		t.Fatalf("Expected *IdleTimeoutError, got %T: %v", err, err)
+	}
+	if elapsed := time.Since(start); elapsed > 10*time.Second {
+		t.Errorf("Expected the watchdog to abort well before the hard timeout, took %v", elapsed)
+	}
+}
+
+func TestExecuteNonInteractiveCountsStderrAsActivity(t *testing.T) {
+	// Silent on stdout for longer than the idle timeout, but never on stderr
+	setupFakeClaude(t, "cat >/dev/null\nfor i in 1 2 3 4 5; do echo working >&2; sleep 0.2; done\necho done")
+	client := NewClient(30 * time.Second)
+	client.SetIdleTimeout(600 * time.Millisecond)
+
+	output, err := client.ExecuteNonInteractive(t.TempDir(), "prompt")
+	if err != nil {
+		t.Fatalf("Expected success, got error: %v", err)
+	}
+	if strings.TrimSpace(output) != "done" {
+		t.Errorf("Expected only stdout in the result, got '%s'", output)
+	}
+}
@@ -0,0 +1,9 @@
+package platform
+
+import "errors"
+
+// Free disk space detection
+
+// ErrFreeSpaceUnsupported is returned by FreeDiskSpace on platforms where the
+// available space cannot be determined
+var ErrFreeSpaceUnsupported = errors.New("free disk space detection is not supported on this platform")
@@ -0,0 +1,8 @@
+//go:build !linux && !darwin && !freebsd && !windows
+
+package platform
+
+// FreeDiskSpace is not implemented on this platform
+func FreeDiskSpace(path string) (uint64, error) {
+	return 0, ErrFreeSpaceUnsupported
+}
@@ -0,0 +1,57 @@
+package pr
+
+import (
+	"path"
+	"strings"
+
+	"ccw/types"
+)
+
+// CheckAlias assigns a failure category to CI checks whose name matches Pattern
+type CheckAlias struct {
+	Pattern  string
+	Category types.CIFailureType
+}
+
+// SetCheckAliases sets aliases consulted, in order, before keyword classification
+func (pm *PRManager) SetCheckAliases(aliases []CheckAlias) {
+	pm.checkAliases = aliases
+}
+
+// Matches reports whether checkName matches the alias pattern. Patterns containing
+// glob metacharacters match the whole name; others match as a substring. Both
+// comparisons ignore case.
+func (a CheckAlias) Matches(checkName string) bool {
+	pattern := strings.ToLower(a.Pattern)
+	name := strings.ToLower(checkName)
+	if pattern == "" {
+		return false
+	}
+	if strings.ContainsAny(pattern, "*?[") {
+		matched, err := path.Match(pattern, name)
+		return err == nil && matched
+	}
+	return strings.Contains(name, pattern)
+}
+
+// ClassifyCheck returns the failure category for a check name, applying
+// aliases before the built-in build/lint/test keyword matching
+func ClassifyCheck(checkName string, aliases []CheckAlias) types.CIFailureType {
+	for _, alias := range aliases {
+		if alias.Matches(checkName) {
+			return alias.Category
+		}
+	}
+
+	checkNameLower := strings.ToLower(checkName)
+	switch {
+	case strings.Contains(checkNameLower, "build"):
+		return types.CIFailureBuild
+	case strings.Contains(checkNameLower, "lint"):
+		return types.CIFailureLint
+	case strings.Contains(checkNameLower, "test"):
+		return types.CIFailureTest
+	default:
+		return types.CIFailureUnknown
+	}
+}
@@ -0,0 +1,82 @@
+package pr
+
+import (
+	"testing"
+	"time"
+
+	"ccw/types"
+)
+
+func TestClassifyCheck(t *testing.T) {
+	aliases := []CheckAlias{
+		{Pattern: "style-gate", Category: types.CIFailureLint},
+		{Pattern: "unit-*", Category: types.CIFailureTest},
+		{Pattern: "docs-build", Category: types.CIFailureUnknown},
+		{Pattern: "*-gate", Category: types.CIFailureBuild},
+	}
+
+	tests := []struct {
+		name      string
+		checkName string
+		aliases   []CheckAlias
+		expected  types.CIFailureType
+	}{
+		{"built-in build keyword", "swift-build", nil, types.CIFailureBuild},
+		{"built-in lint keyword", "SwiftLint", nil, types.CIFailureLint},
+		{"built-in test keyword", "Unit Tests", nil, types.CIFailureTest},
+		{"custom name unknown without alias", "style-gate", nil, types.CIFailureUnknown},
+		{"substring alias", "CI / style-gate (macos)", aliases, types.CIFailureLint},
+		{"alias ignores case", "Style-Gate", aliases, types.CIFailureLint},
+		{"glob alias", "unit-macos", aliases, types.CIFailureTest},
+		{"glob matches whole name only", "ci / unit-macos", aliases, types.CIFailureUnknown},
+		{"alias overrides built-in keyword", "docs-build", aliases, types.CIFailureUnknown},
+		{"first matching alias wins", "style-gate", aliases, types.CIFailureLint},
+		{"later alias applies when earlier do not match", "security-gate", aliases, types.CIFailureBuild},
+		{"falls back to keywords", "integration-test", aliases, types.CIFailureTest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ClassifyCheck(tt.checkName, tt.aliases); got != tt.expected {
+				t.Errorf("Expected '%s', got '%s'", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestAnalyzeCIFailuresUsesAliases(t *testing.T) {
+	pm := NewPRManager(time.Minute, 1, false)
+	pm.SetCheckAliases([]CheckAlias{
+		{Pattern: "style-gate", Category: types.CIFailureLint},
+		{Pattern: "preview-build", Category: types.CIFailureUnknown},
+	})
+
+	status := &types.CIStatus{
+		Checks: []types.CheckRun{
+			{Name: "style-gate", Conclusion: "failure"},
+			{Name: "preview-build", Conclusion: "failure"},
+			{Name: "swift-test", Conclusion: "error"},
+			{Name: "style-gate-cache", Conclusion: "success"},
+		},
+	}
+
+	failures := pm.AnalyzeCIFailures(status)
+	if len(failures) != 3 {
+		t.Fatalf("Expected 3 failures, got %d", len(failures))
+	}
+
+	expected := []struct {
+		failureType types.CIFailureType
+		recoverable bool
+	}{
+		{types.CIFailureLint, true},
+		{types.CIFailureUnknown, false},
+		{types.CIFailureTest, true},
+	}
+	for i, want := range expected {
+		if failures[i].Type != want.failureType || failures[i].Recoverable != want.recoverable {
+			t.Errorf("Failure %d (%s): expected %s/%v, got %s/%v", i, failures[i].CheckName,
+				want.failureType, want.recoverable, failures[i].Type, failures[i].Recoverable)
+		}
+	}
+}
@@ -0,0 +1,179 @@
+package pr
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"ccw/github"
+	"ccw/types"
+)
+
+// Failed check log excerpts for the CI failure analysis
+
+// DefaultLogExcerptLines is how many trailing log lines an excerpt keeps
+const DefaultLogExcerptLines = 30
+
+// Bounds on an excerpt beyond its tail, so one noisy log cannot flood the
+// terminal or a PR comment
+const (
+	maxEarlierErrorLines = 10
+	maxExcerptChars      = 6000
+	maxExcerptLineChars  = 300
+)
+
+// ErrCheckLogsUnavailable is returned for checks whose logs gh cannot fetch,
+// i.e. anything that is not a GitHub Actions run
+var ErrCheckLogsUnavailable = errors.New("logs are only available for GitHub Actions checks")
+
+// actionsJobURLPattern matches check URLs of GitHub Actions jobs, capturing
+// the host, repository, run ID and job ID
+var actionsJobURLPattern = regexp.MustCompile(`^https://([^/]+)/([^/]+/[^/]+)/actions/runs/(\d+)/job/(\d+)`)
+
+// ghLogPrefixPattern matches the "job<TAB>step<TAB>timestamp " prefix gh puts
+// on each --log-failed line
+var ghLogPrefixPattern = regexp.MustCompile(`^[^\t]*\t[^\t]*\t(?:\x{FEFF})?\d{4}-\d{2}-\d{2}T[0-9:.]+Z ?`)
+
+// logANSIPattern matches terminal color codes in CI output
+var logANSIPattern = regexp.MustCompile(`\x1b\[[0-9;]*[A-Za-z]`)
+
+// logErrorPattern matches lines that report a failure
+var logErrorPattern = regexp.MustCompile(`(?i)(^##\[error\]|\berror\b|\bfailed\b|\bfailure\b|\bpanic\b|\bfatal\b|\bexception\b|^FAIL\b|✘|❌)`)
+
+// LogExcerpt is the bounded part of a failed check's log shown to the user
+type LogExcerpt struct {
+	CheckName  string
+	Lines      []string // Excerpt lines; error lines are prefixed with ">> "
+	TotalLines int      // Lines in the full log
+	ErrorLines int      // Lines in the full log that look like errors
+}
+
+// String renders the excerpt lines
+func (e *LogExcerpt) String() string {
+	if e == nil || len(e.Lines) == 0 {
+		return ""
+	}
+	return strings.Join(e.Lines, "\n")
+}
+
+// ExtractLogExcerpt reduces a check log to its last tail lines plus up to a
+// few earlier error lines, which usually name the first failure. gh prefixes,
+// color codes and blank lines are removed, error lines are marked with ">> "
+// and the result is capped in size.
+func ExtractLogExcerpt(log string, tail int) *LogExcerpt {
+	if tail <= 0 {
+		tail = DefaultLogExcerptLines
+	}
+
+	var lines []string
+	for _, line := range strings.Split(strings.ReplaceAll(log, "\r\n", "\n"), "\n") {
+		line = logANSIPattern.ReplaceAllString(ghLogPrefixPattern.ReplaceAllString(line, ""), "")
+		line = strings.TrimRight(line, " \t\r")
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		lines = append(lines, line)
+	}
+
+	excerpt := &LogExcerpt{TotalLines: len(lines)}
+	isError := make([]bool, len(lines))
+	for i, line := range lines {
+		if logErrorPattern.MatchString(strings.TrimSpace(line)) {
+			isError[i] = true
+			excerpt.ErrorLines++
+		}
+	}
+
+	start := len(lines) - tail
+	if start < 0 {
+		start = 0
+	}
+
+	// The first errors before the tail, in log order
+	var earlier []int
+	for i := 0; i < start && len(earlier) < maxEarlierErrorLines; i++ {
+		if isError[i] {
+			earlier = append(earlier, i)
+		}
+	}
+
+	previous := -1
+	add := func(i int) {
+		if previous >= 0 && i > previous+1 || previous < 0 && i > 0 {
+			excerpt.Lines = append(excerpt.Lines, fmt.Sprintf("   … %d line(s) omitted", i-previous-1))
+		}
+		marker := "   "
+		if isError[i] {
+			marker = ">> "
+		}
+		excerpt.Lines = append(excerpt.Lines, marker+truncateLogLine(lines[i]))
+		previous = i
+	}
+	for _, i := range earlier {
+		add(i)
+	}
+	for i := start; i < len(lines); i++ {
+		add(i)
+	}
+
+	// Drop the oldest tail lines until the excerpt fits
+	for len(excerpt.Lines) > 1 && utf8.RuneCountInString(excerpt.String()) > maxExcerptChars {
+		excerpt.Lines = excerpt.Lines[1:]
+	}
+	return excerpt
+}
+
+// truncateLogLine shortens very long log lines such as minified output
+func truncateLogLine(line string) string {
+	if utf8.RuneCountInString(line) <= maxExcerptLineChars {
+		return line
+	}
+	return string([]rune(line)[:maxExcerptLineChars]) + " …"
+}
+
+// checkLogArgs returns the gh arguments that print a failed check's log
+func checkLogArgs(check types.CheckRun) ([]string, error) {
+	if match := actionsJobURLPattern.FindStringSubmatch(check.URL); match != nil {
+		return []string{"run", "view", "--job", match[4], "--log-failed", "--repo", ghRepository(match[1], match[2])}, nil
+	}
+	if match := actionsRunURLPattern.FindStringSubmatch(check.URL); match != nil {
+		return []string{"run", "view", match[3], "--log-failed", "--repo", ghRepository(match[1], match[2])}, nil
+	}
+	return nil, fmt.Errorf("%s: %w (details: %s)", check.Name, ErrCheckLogsUnavailable, check.URL)
+}
+
+// GetCheckLogs fetches the failed steps' log of a check and returns a bounded
+// excerpt of it, ending with the last tail lines
+func (pm *PRManager) GetCheckLogs(check types.CheckRun, tail int) (*LogExcerpt, error) {
+	args, err := checkLogArgs(check)
+	if err != nil {
+		return nil, err
+	}
+
+	cmdCtx, cancel := pm.commandContext()
+	defer cancel()
+
+	output, err := github.NewGHCommandContext(cmdCtx, args...).Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch logs for check %s: %w", check.Name, err)
+	}
+
+	excerpt := ExtractLogExcerpt(string(output), tail)
+	excerpt.CheckName = check.Name
+	return excerpt, nil
+}
+
+// FormatCheckLogsComment renders failed check log excerpts as a PR comment
+func FormatCheckLogsComment(excerpts []*LogExcerpt) string {
+	var body strings.Builder
+	body.WriteString("### ❌ CI failure logs\n")
+	// A zero-width space keeps fences inside the log from closing the code block
+	for _, excerpt := range excerpts {
+		body.WriteString(fmt.Sprintf("\n<details><summary><b>%s</b> (%d error line(s) in %d log lines)</summary>\n\n```\n%s\n```\n\n</details>\n",
+			excerpt.CheckName, excerpt.ErrorLines, excerpt.TotalLines, strings.ReplaceAll(excerpt.String(), "```", "``​`")))
+	}
+	body.WriteString("\n_Excerpts of `gh run view --log-failed`, posted by ccw._\n")
+	return body.String()
+}
@@ -0,0 +1,107 @@
+package pr
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"ccw/types"
+)
+
+const sampleFailedLog = "build\tRun swift build\t2026-10-15T09:00:00.1234567Z Compiling FeLangCore\n" +
+	"build\tRun swift build\t2026-10-15T09:00:01.0000000Z \x1b[31merror: cannot find 'Tokenizer' in scope\x1b[0m\n" +
+	"build\tRun swift build\t2026-10-15T09:00:02.0000000Z \n" +
+	"build\tRun swift build\t2026-10-15T09:00:03.0000000Z Compiling FeLangKit\n" +
+	"build\tRun swift build\t2026-10-15T09:00:04.0000000Z ##[error]Process completed with exit code 1.\n"
+
+func TestExtractLogExcerpt(t *testing.T) {
+	t.Run("short log", func(t *testing.T) {
+		excerpt := ExtractLogExcerpt(sampleFailedLog, 10)
+		expected := []string{
+			"   Compiling FeLangCore",
+			">> error: cannot find 'Tokenizer' in scope",
+			"   Compiling FeLangKit",
+			">> ##[error]Process completed with exit code 1.",
+		}
+		if !reflect.DeepEqual(excerpt.Lines, expected) {
+			t.Errorf("Expected %q, got %q", expected, excerpt.Lines)
+		}
+		if excerpt.TotalLines != 4 || excerpt.ErrorLines != 2 {
+			t.Errorf("Expected 4 lines with 2 errors, got %d with %d", excerpt.TotalLines, excerpt.ErrorLines)
+		}
+	})
+
+	t.Run("tail keeps earlier errors", func(t *testing.T) {
+		var log strings.Builder
+		log.WriteString("step one\nerror: first failure\n")
+		for i := 1; i <= 50; i++ {
+			log.WriteString(fmt.Sprintf("line %d\n", i))
+		}
+		log.WriteString("FAIL: TestParser\n")
+
+		excerpt := ExtractLogExcerpt(log.String(), 3)
+		expected := []string{
+			"   … 1 line(s) omitted",
+			">> error: first failure",
+			"   … 48 line(s) omitted",
+			"   line 49",
+			"   line 50",
+			">> FAIL: TestParser",
+		}
+		if !reflect.DeepEqual(excerpt.Lines, expected) {
+			t.Errorf("Expected %q, got %q", expected, excerpt.Lines)
+		}
+	})
+
+	t.Run("bounded size", func(t *testing.T) {
+		log := strings.Repeat(strings.Repeat("x", 1000)+"\n", 100)
+		excerpt := ExtractLogExcerpt(log, 100)
+		if size := len([]rune(excerpt.String())); size > maxExcerptChars {
+			t.Errorf("Expected at most %d characters, got %d", maxExcerptChars, size)
+		}
+		if !strings.HasSuffix(excerpt.Lines[len(excerpt.Lines)-1], " …") {
+			t.Errorf("Expected long lines to be cut, got %q", excerpt.Lines[len(excerpt.Lines)-1])
+		}
+	})
+
+	t.Run("empty log", func(t *testing.T) {
+		if excerpt := ExtractLogExcerpt("\n\n", 10); len(excerpt.Lines) != 0 || excerpt.String() != "" {
+			t.Errorf("Expected an empty excerpt, got %q", excerpt.Lines)
+		}
+	})
+}
+
+func TestCheckLogArgs(t *testing.T) {
+	tests := []struct {
+		url      string
+		expected []string
+	}{
+		{"https://github.com/o/r/actions/runs/101/job/7", []string{"run", "view", "--job", "7", "--log-failed", "--repo", "o/r"}},
+		{"https://github.com/o/r/actions/runs/101", []string{"run", "view", "101", "--log-failed", "--repo", "o/r"}},
+		{"https://ghe.example.com/o/r/actions/runs/101/job/7", []string{"run", "view", "--job", "7", "--log-failed", "--repo", "ghe.example.com/o/r"}},
+	}
+	for _, tt := range tests {
+		args, err := checkLogArgs(types.CheckRun{Name: "build", URL: tt.url})
+		if err != nil || !reflect.DeepEqual(args, tt.expected) {
+			t.Errorf("%s: expected %q, got %q (%v)", tt.url, tt.expected, args, err)
+		}
+	}
+
+	if _, err := checkLogArgs(types.CheckRun{Name: "external", URL: "https://ci.example.com/build/7"}); !errors.Is(err, ErrCheckLogsUnavailable) {
+		t.Errorf("Expected ErrCheckLogsUnavailable, got %v", err)
+	}
+}
+
+func TestFormatCheckLogsComment(t *testing.T) {
+	excerpt := ExtractLogExcerpt("error: broken\n```\n", 10)
+	excerpt.CheckName = "build"
+	comment := FormatCheckLogsComment([]*LogExcerpt{excerpt})
+	if !strings.Contains(comment, "<b>build</b> (1 error line(s) in 2 log lines)") || !strings.Contains(comment, ">> error: broken") {
+		t.Errorf("Unexpected comment:\n%s", comment)
+	}
+	if strings.Count(comment, "```") != 2 {
+		t.Errorf("Expected log fences to be escaped, got:\n%s", comment)
+	}
+}
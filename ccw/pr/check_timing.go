@@ -0,0 +1,75 @@
+package pr
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"ccw/types"
+)
+
+// Per-check timing for CI summaries
+
+// CheckTiming is how long a single CI check ran
+type CheckTiming struct {
+	Name     string
+	Duration time.Duration
+}
+
+// String formats the timing as "name (8m12s)"
+func (t CheckTiming) String() string {
+	return fmt.Sprintf("%s (%s)", t.Name, t.Duration.Round(time.Second))
+}
+
+// CheckDuration returns how long check ran. It is false when either timestamp
+// is missing or the check completed before it started.
+func CheckDuration(check types.CheckRun) (time.Duration, bool) {
+	if check.StartedAt.IsZero() || check.CompletedAt.IsZero() {
+		return 0, false
+	}
+	if check.CompletedAt.Before(check.StartedAt) {
+		return 0, false
+	}
+	return check.CompletedAt.Sub(check.StartedAt), true
+}
+
+// SlowestChecks returns up to n checks with the longest durations, slowest
+// first. Checks without usable timestamps are skipped; ties sort by name.
+func (pm *PRManager) SlowestChecks(status *types.CIStatus, n int) []CheckTiming {
+	if status == nil || n <= 0 {
+		return nil
+	}
+
+	var timings []CheckTiming
+	for _, check := range status.Checks {
+		if duration, ok := CheckDuration(check); ok {
+			timings = append(timings, CheckTiming{Name: check.Name, Duration: duration})
+		}
+	}
+
+	sort.Slice(timings, func(i, j int) bool {
+		if timings[i].Duration != timings[j].Duration {
+			return timings[i].Duration > timings[j].Duration
+		}
+		return timings[i].Name < timings[j].Name
+	})
+
+	if len(timings) > n {
+		timings = timings[:n]
+	}
+	return timings
+}
+
+// FormatSlowestChecks renders timings as "slowest: a (8m12s), b (3m1s)", or "" when empty
+func FormatSlowestChecks(timings []CheckTiming) string {
+	if len(timings) == 0 {
+		return ""
+	}
+
+	parts := make([]string, len(timings))
+	for i, timing := range timings {
+		parts[i] = timing.String()
+	}
+	return "slowest: " + strings.Join(parts, ", ")
+}
@@ -0,0 +1,87 @@
+package pr
+
+import (
+	"testing"
+	"time"
+
+	"ccw/types"
+)
+
+func TestCheckDuration(t *testing.T) {
+	start := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		check    types.CheckRun
+		expected time.Duration
+		ok       bool
+	}{
+		{"completed check", types.CheckRun{StartedAt: start, CompletedAt: start.Add(8*time.Minute + 12*time.Second)}, 8*time.Minute + 12*time.Second, true},
+		{"missing start", types.CheckRun{CompletedAt: start}, 0, false},
+		{"still running", types.CheckRun{StartedAt: start}, 0, false},
+		{"completed before start", types.CheckRun{StartedAt: start, CompletedAt: start.Add(-time.Second)}, 0, false},
+		{"instant check", types.CheckRun{StartedAt: start, CompletedAt: start}, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			duration, ok := CheckDuration(tt.check)
+			if ok != tt.ok || duration != tt.expected {
+				t.Errorf("Expected (%v, %v), got (%v, %v)", tt.expected, tt.ok, duration, ok)
+			}
+		})
+	}
+}
+
+func TestSlowestChecks(t *testing.T) {
+	start := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	check := func(name string, d time.Duration) types.CheckRun {
+		return types.CheckRun{Name: name, StartedAt: start, CompletedAt: start.Add(d)}
+	}
+
+	status := &types.CIStatus{Checks: []types.CheckRun{
+		check("lint", 45*time.Second),
+		check("integration-tests", 8*time.Minute+12*time.Second),
+		{Name: "pending-deploy", StartedAt: start},
+		check("unit-tests", 3*time.Minute),
+		check("build", 3*time.Minute),
+	}}
+
+	pm := NewPRManager(time.Minute, 1, false)
+
+	slowest := pm.SlowestChecks(status, 3)
+	expected := []string{"integration-tests (8m12s)", "build (3m0s)", "unit-tests (3m0s)"}
+	if len(slowest) != len(expected) {
+		t.Fatalf("Expected %d checks, got %d: %v", len(expected), len(slowest), slowest)
+	}
+	for i, timing := range slowest {
+		if timing.String() != expected[i] {
+			t.Errorf("Position %d: expected '%s', got '%s'", i, expected[i], timing.String())
+		}
+	}
+
+	if all := pm.SlowestChecks(status, 10); len(all) != 4 {
+		t.Errorf("Expected 4 timed checks, got %d", len(all))
+	}
+	if none := pm.SlowestChecks(nil, 3); none != nil {
+		t.Errorf("Expected nil for nil status, got %v", none)
+	}
+	if none := pm.SlowestChecks(status, 0); none != nil {
+		t.Errorf("Expected nil for n=0, got %v", none)
+	}
+}
+
+func TestFormatSlowestChecks(t *testing.T) {
+	if got := FormatSlowestChecks(nil); got != "" {
+		t.Errorf("Expected empty string, got '%s'", got)
+	}
+
+	timings := []CheckTiming{
+		{Name: "integration-tests", Duration: 8*time.Minute + 12*time.Second},
+		{Name: "build", Duration: 3*time.Minute + 400*time.Millisecond},
+	}
+	expected := "slowest: integration-tests (8m12s), build (3m0s)"
+	if got := FormatSlowestChecks(timings); got != expected {
+		t.Errorf("Expected '%s', got '%s'", expected, got)
+	}
+}
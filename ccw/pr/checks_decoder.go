@@ -0,0 +1,85 @@
+package pr
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"ccw/types"
+)
+
+// Tolerant decoding of `gh pr checks --json` output
+
+// checkRunEntry is one loosely typed check entry. Timestamps stay strings so an
+// unparsable value costs only that field, not the whole check.
+type checkRunEntry struct {
+	Name        string `json:"name"`
+	State       string `json:"state"`
+	Conclusion  string `json:"conclusion"`
+	Link        string `json:"link"`
+	StartedAt   string `json:"startedAt"`
+	CompletedAt string `json:"completedAt"`
+	Description string `json:"description"`
+	Event       string `json:"event"`
+	Workflow    string `json:"workflow"`
+	Bucket      string `json:"bucket"`
+}
+
+// DecodeCheckRuns decodes a JSON array of checks. Entries that are not objects,
+// have mistyped fields or lack a name are skipped with a warning; invalid
+// timestamps are dropped with a warning. It fails only when data is not an array.
+func DecodeCheckRuns(data []byte) ([]types.CheckRun, []string, error) {
+	var entries []json.RawMessage
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, nil, fmt.Errorf("failed to decode checks array: %w", err)
+	}
+
+	checks := make([]types.CheckRun, 0, len(entries))
+	var warnings []string
+	for i, raw := range entries {
+		var entry checkRunEntry
+		if err := json.Unmarshal(raw, &entry); err != nil {
+			warnings = append(warnings, fmt.Sprintf("skipped malformed check entry %d: %v", i, err))
+			continue
+		}
+		if strings.TrimSpace(entry.Name) == "" {
+			warnings = append(warnings, fmt.Sprintf("skipped check entry %d: missing name", i))
+			continue
+		}
+
+		check := types.CheckRun{
+			Name:        entry.Name,
+			Status:      entry.State,
+			Conclusion:  entry.Conclusion,
+			URL:         entry.Link,
+			Description: entry.Description,
+			Event:       entry.Event,
+			Workflow:    entry.Workflow,
+			Bucket:      entry.Bucket,
+		}
+		var warning string
+		if check.StartedAt, warning = parseCheckTime(entry.Name, "startedAt", entry.StartedAt); warning != "" {
+			warnings = append(warnings, warning)
+		}
+		if check.CompletedAt, warning = parseCheckTime(entry.Name, "completedAt", entry.CompletedAt); warning != "" {
+			warnings = append(warnings, warning)
+		}
+		checks = append(checks, check)
+	}
+
+	return checks, warnings, nil
+}
+
+// parseCheckTime parses an RFC 3339 timestamp; empty values are a zero time and
+// invalid ones a zero time plus a warning
+func parseCheckTime(checkName, field, value string) (time.Time, string) {
+	if value == "" {
+		return time.Time{}, ""
+	}
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Sprintf("check %s: ignoring invalid %s %q", checkName, field, value)
+	}
+	return parsed, ""
+}
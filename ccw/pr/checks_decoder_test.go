@@ -0,0 +1,79 @@
+package pr
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDecodeCheckRunsSkipsMalformedEntries(t *testing.T) {
+	data := []byte(`[
+		{"name": "build", "state": "SUCCESS", "conclusion": "success", "link": "https://ci/build",
+		 "startedAt": "2024-05-01T09:00:00Z", "completedAt": "2024-05-01T09:03:00Z"},
+		"not an object",
+		{"name": 42, "state": "FAILURE"},
+		{"state": "PENDING"},
+		{"name": "lint", "conclusion": "failure", "unknownField": {"nested": true}},
+		{"name": "deploy", "startedAt": "yesterday", "completedAt": ""}
+	]`)
+
+	checks, warnings, err := DecodeCheckRuns(data)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	names := make([]string, len(checks))
+	for i, check := range checks {
+		names[i] = check.Name
+	}
+	if strings.Join(names, ",") != "build,lint,deploy" {
+		t.Fatalf("Expected checks build,lint,deploy, got %v", names)
+	}
+
+	build := checks[0]
+	if build.Status != "SUCCESS" || build.Conclusion != "success" || build.URL != "https://ci/build" {
+		t.Errorf("Unexpected build check fields: %+v", build)
+	}
+	if build.CompletedAt.Sub(build.StartedAt) != 3*time.Minute {
+		t.Errorf("Expected build timestamps 3m apart, got %v and %v", build.StartedAt, build.CompletedAt)
+	}
+	if !checks[2].StartedAt.IsZero() || !checks[2].CompletedAt.IsZero() {
+		t.Errorf("Expected invalid and empty timestamps to decode as zero, got %+v", checks[2])
+	}
+
+	expectedWarnings := []string{
+		"skipped malformed check entry 1",
+		"skipped malformed check entry 2",
+		"skipped check entry 3: missing name",
+		`check deploy: ignoring invalid startedAt "yesterday"`,
+	}
+	if len(warnings) != len(expectedWarnings) {
+		t.Fatalf("Expected %d warnings, got %d: %v", len(expectedWarnings), len(warnings), warnings)
+	}
+	for i, expected := range expectedWarnings {
+		if !strings.HasPrefix(warnings[i], expected) {
+			t.Errorf("Warning %d: expected prefix '%s', got '%s'", i, expected, warnings[i])
+		}
+	}
+}
+
+func TestDecodeCheckRunsRejectsNonArray(t *testing.T) {
+	for _, input := range []string{`{"name": "build"}`, `no checks reported on the 'main' branch`, ``} {
+		if _, _, err := DecodeCheckRuns([]byte(input)); err == nil {
+			t.Errorf("Expected error for %q", input)
+		}
+	}
+}
+
+func TestDecodeCheckRunsFeedsStatus(t *testing.T) {
+	checks, _, err := DecodeCheckRuns([]byte(`[{"name": "build", "conclusion": "success"}, null, {"name": "test"}]`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pm := NewPRManager(time.Minute, 1, false)
+	status := pm.buildCIStatusFromChecks(checks, "https://github.com/o/r/pull/1")
+	if status.TotalChecks != 2 || status.PassedChecks != 1 || status.PendingChecks != 1 {
+		t.Errorf("Expected 2 checks (1 passed, 1 pending), got %+v", status)
+	}
+}
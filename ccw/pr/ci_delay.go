@@ -0,0 +1,52 @@
+package pr
+
+import (
+	"context"
+	"math/rand"
+	"time"
+)
+
+// Delay before CI monitoring polls for the first time
+
+// SetInitialDelay sets how long monitoring waits before its first status poll,
+// plus a random extra wait of up to jitter
+func (pm *PRManager) SetInitialDelay(delay, jitter time.Duration) {
+	pm.initialDelay = delay
+	pm.initialDelayJitter = jitter
+}
+
+// InitialDelay returns the wait before the first status poll, including jitter
+func (pm *PRManager) InitialDelay() time.Duration {
+	return initialDelayWithJitter(pm.initialDelay, pm.initialDelayJitter, rand.Int63n)
+}
+
+// initialDelayWithJitter adds random(jitter) to delay; random returns a value in [0, n)
+func initialDelayWithJitter(delay, jitter time.Duration, random func(n int64) int64) time.Duration {
+	if delay < 0 {
+		delay = 0
+	}
+	if jitter <= 0 {
+		return delay
+	}
+	return delay + time.Duration(random(int64(jitter)))
+}
+
+// waitInitialDelay blocks for delay, returning false if ctx ends or a cancel
+// arrives first
+func waitInitialDelay(ctx context.Context, delay time.Duration, cancelChan <-chan struct{}) bool {
+	if delay <= 0 {
+		return true
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
+	case <-cancelChan:
+		return false
+	}
+}
@@ -0,0 +1,80 @@
+package pr
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestInitialDelayWithJitter(t *testing.T) {
+	maxRandom := func(n int64) int64 { return n - 1 }
+
+	tests := []struct {
+		name     string
+		delay    time.Duration
+		jitter   time.Duration
+		expected time.Duration
+	}{
+		{"no delay", 0, 0, 0},
+		{"configured delay", 30 * time.Second, 0, 30 * time.Second},
+		{"delay with jitter", 30 * time.Second, 10 * time.Second, 40*time.Second - 1},
+		{"jitter only", 0, time.Second, time.Second - 1},
+		{"negative delay", -time.Second, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := initialDelayWithJitter(tt.delay, tt.jitter, maxRandom); got != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestInitialDelayStaysWithinJitter(t *testing.T) {
+	pm := NewPRManager(time.Minute, 1, false)
+	pm.SetInitialDelay(5*time.Second, 2*time.Second)
+
+	for i := 0; i < 20; i++ {
+		delay := pm.InitialDelay()
+		if delay < 5*time.Second || delay >= 7*time.Second {
+			t.Fatalf("Expected delay in [5s, 7s), got %v", delay)
+		}
+	}
+}
+
+func TestWaitInitialDelay(t *testing.T) {
+	t.Run("applies configured delay", func(t *testing.T) {
+		start := time.Now()
+		if !waitInitialDelay(context.Background(), 50*time.Millisecond, nil) {
+			t.Fatal("Expected wait to complete")
+		}
+		if elapsed := time.Since(start); elapsed < 50*time.Millisecond {
+			t.Errorf("Expected to wait at least 50ms, waited %v", elapsed)
+		}
+	})
+
+	t.Run("zero delay returns immediately", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		if !waitInitialDelay(ctx, 0, nil) {
+			t.Error("Expected zero delay to proceed even with a cancelled context")
+		}
+	})
+
+	t.Run("context cancellation", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		if waitInitialDelay(ctx, time.Minute, nil) {
+			t.Error("Expected wait to stop when the context ends")
+		}
+	})
+
+	t.Run("cancel signal", func(t *testing.T) {
+		cancelChan := make(chan struct{}, 1)
+		cancelChan <- struct{}{}
+		if waitInitialDelay(context.Background(), time.Minute, cancelChan) {
+			t.Error("Expected wait to stop on cancel")
+		}
+	})
+}
@@ -2,12 +2,11 @@ package pr
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"os/exec"
 	"strings"
 	"time"
 
+	"ccw/github"
 	"ccw/types"
 )
 
@@ -35,7 +34,7 @@ func (pm *PRManager) MonitorPRChecks(prURL string, timeout time.Duration) (*type
 	defer cancel()
 
 	// Use gh to check PR status
-	cmd := exec.CommandContext(cmdCtx, "gh", "pr", "checks", prURL)
+	cmd := github.NewGHCommandContext(cmdCtx, "pr", "checks", prURL)
 	output, err := cmd.CombinedOutput()
 	if err != nil {
 		return nil, fmt.Errorf("failed to check PR status: %w\nOutput: %s", err, string(output))
@@ -92,6 +91,21 @@ func (pm *PRManager) WatchPRChecksWithGoroutine(ctx context.Context, prURL strin
 			Timestamp: time.Now(),
 		}
 
+		// Give checks time to register before the first poll
+		if delay := pm.InitialDelay(); delay > 0 {
+			updatesChan <- types.CIWatchUpdate{
+				EventType: "initial_delay",
+				Message:   fmt.Sprintf("Waiting %s for CI checks to register", delay.Round(time.Second)),
+				Timestamp: time.Now(),
+			}
+			if !waitInitialDelay(ctx, delay, cancelChan) {
+				result.Error = ctx.Err()
+				result.Duration = time.Since(startTime)
+				completionChan <- *result
+				return
+			}
+		}
+
 		// Monitor CI checks continuously
 		pm.monitorChecksLoop(ctx, prURL, updatesChan, result, cancelChan)
 
@@ -112,6 +126,7 @@ func (pm *PRManager) monitorChecksLoop(ctx context.Context, prURL string, update
 	defer ticker.Stop()
 
 	var lastStatus *types.CIStatus
+	reportedWarnings := make(map[string]bool)
 
 	for {
 		select {
@@ -121,7 +136,7 @@ func (pm *PRManager) monitorChecksLoop(ctx context.Context, prURL string, update
 		case <-cancelChan:
 			return
 		case <-ticker.C:
-			currentStatus, err := pm.fetchCurrentCIStatus(ctx, prURL)
+			currentStatus, warnings, err := pm.fetchCurrentCIStatus(ctx, prURL)
 			if err != nil {
 				updatesChan <- types.CIWatchUpdate{
 					EventType: "error",
@@ -131,6 +146,19 @@ func (pm *PRManager) monitorChecksLoop(ctx context.Context, prURL string, update
 				continue
 			}
 
+			// Report each skipped or partially decoded check once
+			for _, warning := range warnings {
+				if reportedWarnings[warning] {
+					continue
+				}
+				reportedWarnings[warning] = true
+				updatesChan <- types.CIWatchUpdate{
+					EventType: "warning",
+					Message:   warning,
+					Timestamp: time.Now(),
+				}
+			}
+
 			// Check for status changes
 			if pm.hasStatusChanged(lastStatus, currentStatus) {
 				update := types.CIWatchUpdate{
@@ -160,21 +188,23 @@ func (pm *PRManager) monitorChecksLoop(ctx context.Context, prURL string, update
 	}
 }
 
-// fetchCurrentCIStatus fetches current CI status using gh CLI
-func (pm *PRManager) fetchCurrentCIStatus(ctx context.Context, prURL string) (*types.CIStatus, error) {
-	cmd := exec.CommandContext(ctx, "gh", "pr", "checks", prURL, "--json", "name,state,conclusion,link,startedAt,completedAt")
+// fetchCurrentCIStatus fetches current CI status using gh CLI. Warnings describe
+// check entries that were skipped or partially decoded.
+func (pm *PRManager) fetchCurrentCIStatus(ctx context.Context, prURL string) (*types.CIStatus, []string, error) {
+	cmd := github.NewGHCommandContext(ctx, "pr", "checks", prURL, "--json", "name,state,conclusion,link,startedAt,completedAt")
 	output, err := cmd.CombinedOutput()
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch CI status: %w\nOutput: %s", err, string(output))
+		return nil, nil, fmt.Errorf("failed to fetch CI status: %w\nOutput: %s", err, string(output))
 	}
 
-	var checks []types.CheckRun
-	if err := json.Unmarshal(output, &checks); err != nil {
-		// Fallback to basic parsing if JSON fails
-		return pm.parseBasicCIStatus(string(output), prURL)
+	checks, warnings, err := DecodeCheckRuns(output)
+	if err != nil {
+		// Fallback to basic parsing if the output is not a JSON array at all
+		status, err := pm.parseBasicCIStatus(string(output), prURL)
+		return status, nil, err
 	}
 
-	return pm.buildCIStatusFromChecks(checks, prURL), nil
+	return pm.buildCIStatusFromChecks(checks, prURL), warnings, nil
 }
 
 // buildCIStatusFromChecks constructs CIStatus from CheckRun array
@@ -253,7 +283,8 @@ func (pm *PRManager) hasStatusChanged(last, current *types.CIStatus) bool {
 
 // isAllChecksComplete determines if all CI checks have completed
 func (pm *PRManager) isAllChecksComplete(status *types.CIStatus) bool {
-	return status.PendingChecks == 0 && status.TotalChecks > 0
+	complete, _ := ExplainChecksComplete(status)
+	return complete
 }
 
 // formatStatusMessage creates a human-readable status message
@@ -277,21 +308,9 @@ func (pm *PRManager) AnalyzeCIFailures(status *types.CIStatus) []types.CIFailure
 				DetailsURL: check.URL,
 			}
 
-			// Analyze failure type
-			checkNameLower := strings.ToLower(check.Name)
-			if strings.Contains(checkNameLower, "build") {
-				failure.Type = types.CIFailureBuild
-				failure.Recoverable = true
-			} else if strings.Contains(checkNameLower, "lint") {
-				failure.Type = types.CIFailureLint
-				failure.Recoverable = true
-			} else if strings.Contains(checkNameLower, "test") {
-				failure.Type = types.CIFailureTest
-				failure.Recoverable = true
-			} else {
-				failure.Type = types.CIFailureUnknown
-				failure.Recoverable = false
-			}
+			// Analyze failure type, honouring configured check name aliases
+			failure.Type = ClassifyCheck(check.Name, pm.checkAliases)
+			failure.Recoverable = failure.Type != types.CIFailureUnknown
 
 			failure.FailureText = check.Description
 
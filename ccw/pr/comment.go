@@ -0,0 +1,18 @@
+package pr
+
+import (
+	"fmt"
+
+	"ccw/github"
+)
+
+// PostComment adds body as a top-level comment on the PR at prURL
+func (pm *PRManager) PostComment(prURL, body string) error {
+	cmdCtx, cancel := pm.commandContext()
+	defer cancel()
+
+	if output, err := github.NewGHCommandContext(cmdCtx, "pr", "comment", prURL, "--body", body).CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to comment on %s: %w\nOutput: %s", prURL, err, string(output))
+	}
+	return nil
+}
@@ -3,15 +3,16 @@ package pr
 import (
 	"encoding/json"
 	"fmt"
-	"os/exec"
 	"strings"
 
+	"ccw/github"
 	"ccw/types"
 )
 
 // GetPRComments retrieves all comments for a PR
 func (pm *PRManager) GetPRComments(prURL string) ([]types.PRComment, error) {
-	cmd := exec.Command("gh", "pr", "view", prURL, "--json", "comments")
+	cmd, cancel := github.NewTimedGHCommand("pr", "view", prURL, "--json", "comments")
+	defer cancel()
 	output, err := cmd.CombinedOutput()
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch PR comments: %w\nOutput: %s", err, string(output))
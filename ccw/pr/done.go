@@ -0,0 +1,143 @@
+package pr
+
+import (
+	"fmt"
+
+	"ccw/types"
+)
+
+// Definition of done: validation, CI and review comments combined
+
+// DonePolicy configures what a run must satisfy before it counts as done
+type DonePolicy struct {
+	RequireValidation       bool
+	RequireCI               bool
+	RequiredChecks          []string // Check name patterns that must pass; empty requires every check
+	MaxHighPriorityComments int      // Unaddressed high-priority comments tolerated
+	BlockOnChangesRequested bool     // A "changes requested" review blocks regardless of CI
+}
+
+// DefaultDonePolicy requires validation, all checks green, no high-priority
+// comments and no outstanding request for changes
+func DefaultDonePolicy() DonePolicy {
+	return DonePolicy{RequireValidation: true, RequireCI: true, BlockOnChangesRequested: true}
+}
+
+// DoneDecision is the outcome of evaluating a DonePolicy
+type DoneDecision struct {
+	Done    bool
+	Reasons []string // Why the run is not done; empty when Done
+}
+
+// DoneEvaluator decides whether a run is done, e.g. before marking a PR
+// ready or merging it
+type DoneEvaluator struct {
+	policy DonePolicy
+}
+
+// NewDoneEvaluator creates an evaluator for policy
+func NewDoneEvaluator(policy DonePolicy) *DoneEvaluator {
+	return &DoneEvaluator{policy: policy}
+}
+
+// Evaluate applies the policy to the latest validation result, CI status and
+// comment analysis. Missing inputs count as unsatisfied when the policy needs them.
+func (de *DoneEvaluator) Evaluate(validation *types.ValidationResult, ci *types.CIStatus, comments *types.PRCommentAnalysis) DoneDecision {
+	return de.EvaluateWithReview(validation, ci, comments, ReviewDecisionNone)
+}
+
+// EvaluateWithReview is Evaluate that also applies the PR's review decision
+func (de *DoneEvaluator) EvaluateWithReview(validation *types.ValidationResult, ci *types.CIStatus, comments *types.PRCommentAnalysis, review ReviewDecision) DoneDecision {
+	var reasons []string
+
+	if de.policy.RequireValidation {
+		switch {
+		case validation == nil:
+			reasons = append(reasons, "validation has not run")
+		case !validation.Success:
+			reasons = append(reasons, fmt.Sprintf("validation failed with %d error(s)", len(validation.Errors)))
+		}
+	}
+
+	if de.policy.RequireCI {
+		reasons = append(reasons, de.ciReasons(ci)...)
+	}
+
+	if comments == nil {
+		reasons = append(reasons, "PR comments have not been checked")
+	} else if high := countHighPriorityComments(comments); high > de.policy.MaxHighPriorityComments {
+		reasons = append(reasons, fmt.Sprintf("%d unaddressed high-priority comment(s)", high))
+	}
+
+	if de.policy.BlockOnChangesRequested && review == ReviewDecisionChangesRequested {
+		reasons = append(reasons, "a reviewer requested changes")
+	}
+
+	return DoneDecision{Done: len(reasons) == 0, Reasons: reasons}
+}
+
+// ciReasons explains why ci does not satisfy the policy's check requirements
+func (de *DoneEvaluator) ciReasons(ci *types.CIStatus) []string {
+	if ci == nil {
+		return []string{"CI status is unavailable"}
+	}
+
+	if len(de.policy.RequiredChecks) == 0 {
+		switch ci.Conclusion {
+		case "success":
+			return nil
+		case "pending":
+			return []string{fmt.Sprintf("%d CI check(s) still pending", ci.PendingChecks)}
+		default:
+			return []string{fmt.Sprintf("%d CI check(s) failed", ci.FailedChecks)}
+		}
+	}
+
+	var reasons []string
+	for _, pattern := range de.policy.RequiredChecks {
+		alias := CheckAlias{Pattern: pattern}
+		matched := false
+		for _, check := range ci.Checks {
+			if !alias.Matches(check.Name) {
+				continue
+			}
+			matched = true
+			if !checkPassed(check) {
+				reasons = append(reasons, fmt.Sprintf("required check %s is %s", check.Name, checkState(check)))
+			}
+		}
+		if !matched {
+			reasons = append(reasons, fmt.Sprintf("required check %s did not run", pattern))
+		}
+	}
+	return reasons
+}
+
+// checkPassed reports whether a check finished without blocking the PR
+func checkPassed(check types.CheckRun) bool {
+	switch check.Conclusion {
+	case "success", "skipped", "neutral":
+		return true
+	default:
+		return false
+	}
+}
+
+// checkState describes a check's conclusion, treating an empty one as pending
+func checkState(check types.CheckRun) string {
+	if check.Conclusion == "" {
+		return "pending"
+	}
+	return check.Conclusion
+}
+
+// countHighPriorityComments counts actionable high-priority comments in analysis
+func countHighPriorityComments(analysis *types.PRCommentAnalysis) int {
+	count := 0
+	for _, actionable := range analysis.ActionableComments {
+		if actionable.Actionable && actionable.Priority == types.CommentPriorityHigh {
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,106 @@
+package pr
+
+import (
+	"reflect"
+	"testing"
+
+	"ccw/types"
+)
+
+func TestDoneEvaluator(t *testing.T) {
+	passed := &types.ValidationResult{Success: true}
+	failed := &types.ValidationResult{Success: false, Errors: []types.ValidationError{{Type: "build"}, {Type: "test"}}}
+	green := &types.CIStatus{Conclusion: "success", TotalChecks: 2, PassedChecks: 2, Checks: []types.CheckRun{
+		{Name: "build", Conclusion: "success"},
+		{Name: "unit-tests", Conclusion: "success"},
+	}}
+	mixed := &types.CIStatus{Conclusion: "failure", TotalChecks: 3, PassedChecks: 1, FailedChecks: 1, PendingChecks: 1, Checks: []types.CheckRun{
+		{Name: "build", Conclusion: "success"},
+		{Name: "lint", Conclusion: "failure"},
+		{Name: "deploy-preview"},
+	}}
+	pending := &types.CIStatus{Conclusion: "pending", PendingChecks: 2}
+	noComments := &types.PRCommentAnalysis{}
+	highComment := &types.PRCommentAnalysis{ActionableComments: []types.ActionableComment{
+		{Priority: types.CommentPriorityHigh, Actionable: true},
+		{Priority: types.CommentPriorityMedium, Actionable: true},
+	}}
+
+	tests := []struct {
+		name       string
+		policy     DonePolicy
+		validation *types.ValidationResult
+		ci         *types.CIStatus
+		comments   *types.PRCommentAnalysis
+		expected   []string
+	}{
+		{"everything satisfied", DefaultDonePolicy(), passed, green, noComments, nil},
+		{"validation failed", DefaultDonePolicy(), failed, green, noComments, []string{"validation failed with 2 error(s)"}},
+		{"validation missing", DefaultDonePolicy(), nil, green, noComments, []string{"validation has not run"}},
+		{"validation not required", DonePolicy{RequireCI: true}, failed, green, noComments, nil},
+		{"checks pending", DefaultDonePolicy(), passed, pending, noComments, []string{"2 CI check(s) still pending"}},
+		{"checks failed", DefaultDonePolicy(), passed, mixed, noComments, []string{"1 CI check(s) failed"}},
+		{"CI unavailable", DefaultDonePolicy(), passed, nil, noComments, []string{"CI status is unavailable"}},
+		{"CI not required", DonePolicy{RequireValidation: true}, passed, nil, noComments, nil},
+		{"required checks pass despite other failures", DonePolicy{RequireCI: true, RequiredChecks: []string{"build"}}, nil, mixed, noComments, nil},
+		{
+			"required checks failing, pending or missing",
+			DonePolicy{RequireCI: true, RequiredChecks: []string{"lint", "deploy-*", "e2e"}},
+			nil, mixed, noComments,
+			[]string{"required check lint is failure", "required check deploy-preview is pending", "required check e2e did not run"},
+		},
+		{"high-priority comment", DefaultDonePolicy(), passed, green, highComment, []string{"1 unaddressed high-priority comment(s)"}},
+		{"high-priority comment tolerated", DonePolicy{RequireValidation: true, RequireCI: true, MaxHighPriorityComments: 1}, passed, green, highComment, nil},
+		{"comments not checked", DefaultDonePolicy(), passed, green, nil, []string{"PR comments have not been checked"}},
+		{
+			"several failures",
+			DefaultDonePolicy(), failed, mixed, highComment,
+			[]string{"validation failed with 2 error(s)", "1 CI check(s) failed", "1 unaddressed high-priority comment(s)"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			decision := NewDoneEvaluator(tt.policy).Evaluate(tt.validation, tt.ci, tt.comments)
+			if decision.Done != (len(tt.expected) == 0) {
+				t.Errorf("Expected done=%v, got %v (reasons: %v)", len(tt.expected) == 0, decision.Done, decision.Reasons)
+			}
+			if !reflect.DeepEqual(decision.Reasons, tt.expected) {
+				t.Errorf("Expected reasons %v, got %v", tt.expected, decision.Reasons)
+			}
+		})
+	}
+}
+
+func TestDoneEvaluatorReviewDecision(t *testing.T) {
+	passed := &types.ValidationResult{Success: true}
+	green := &types.CIStatus{Conclusion: "success"}
+	noComments := &types.PRCommentAnalysis{}
+	noGate := DefaultDonePolicy()
+	noGate.BlockOnChangesRequested = false
+
+	tests := []struct {
+		name     string
+		policy   DonePolicy
+		review   ReviewDecision
+		expected []string
+	}{
+		{"changes requested blocks despite green CI", DefaultDonePolicy(), ReviewDecisionChangesRequested, []string{"a reviewer requested changes"}},
+		{"approved", DefaultDonePolicy(), ReviewDecisionApproved, nil},
+		{"review still required", DefaultDonePolicy(), ReviewDecisionReviewRequired, nil},
+		{"no decision", DefaultDonePolicy(), ReviewDecisionNone, nil},
+		{"gate disabled", noGate, ReviewDecisionChangesRequested, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			decision := NewDoneEvaluator(tt.policy).EvaluateWithReview(passed, green, noComments, tt.review)
+			if decision.Done != (len(tt.expected) == 0) {
+				t.Errorf("Expected done=%v, got %v (reasons: %v)", len(tt.expected) == 0, decision.Done, decision.Reasons)
+			}
+			if !reflect.DeepEqual(decision.Reasons, tt.expected) {
+				t.Errorf("Expected reasons %v, got %v", tt.expected, decision.Reasons)
+			}
+		})
+	}
+}
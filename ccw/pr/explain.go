@@ -0,0 +1,54 @@
+package pr
+
+import (
+	"fmt"
+	"strings"
+
+	"ccw/types"
+)
+
+// Rationale strings for --explain output
+
+// ExplainChecksComplete reports whether every CI check has finished and why
+func ExplainChecksComplete(status *types.CIStatus) (bool, string) {
+	switch {
+	case status == nil:
+		return false, "CI not complete: no status has been fetched yet"
+	case status.TotalChecks == 0:
+		return false, "CI not complete: no checks have been reported yet"
+	case status.PendingChecks > 0:
+		return false, fmt.Sprintf("CI not complete: %d of %d checks still pending",
+			status.PendingChecks, status.TotalChecks)
+	default:
+		return true, fmt.Sprintf("CI complete: all %d checks finished (%d passed, %d failed)",
+			status.TotalChecks, status.PassedChecks, status.FailedChecks)
+	}
+}
+
+// ExplainComment describes why a comment was or was not considered actionable
+func ExplainComment(actionable types.ActionableComment) string {
+	author := actionable.Comment.User.Login
+	if actionable.Actionable {
+		return fmt.Sprintf("comment by %s is actionable (%s priority): %s",
+			author, actionable.Priority, actionable.Suggestion)
+	}
+
+	var reason string
+	switch actionable.Category {
+	case types.CommentBotGenerated:
+		reason = "posted by a bot"
+	case types.CommentApproval:
+		reason = "it is an approval"
+	default:
+		reason = "no suggestion, question or request found"
+	}
+	return fmt.Sprintf("comment by %s is not actionable: %s", author, reason)
+}
+
+// Explanation summarizes the decision, listing every unmet criterion
+func (d DoneDecision) Explanation() string {
+	if d.Done {
+		return "done: every definition-of-done criterion is met"
+	}
+	return "not done: " + strings.Join(d.Reasons, "; ")
+}
@@ -0,0 +1,75 @@
+package pr
+
+import (
+	"testing"
+
+	"ccw/types"
+)
+
+func TestExplainChecksComplete(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   *types.CIStatus
+		complete bool
+		expected string
+	}{
+		{"no status", nil, false, "CI not complete: no status has been fetched yet"},
+		{"no checks", &types.CIStatus{}, false, "CI not complete: no checks have been reported yet"},
+		{"pending", &types.CIStatus{TotalChecks: 3, PassedChecks: 1, PendingChecks: 2}, false, "CI not complete: 2 of 3 checks still pending"},
+		{"finished", &types.CIStatus{TotalChecks: 3, PassedChecks: 2, FailedChecks: 1}, true, "CI complete: all 3 checks finished (2 passed, 1 failed)"},
+	}
+
+	pm := NewPRManager(0, 1, false)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			complete, reason := ExplainChecksComplete(tt.status)
+			if complete != tt.complete {
+				t.Errorf("Expected complete=%v, got %v", tt.complete, complete)
+			}
+			if reason != tt.expected {
+				t.Errorf("Expected '%s', got '%s'", tt.expected, reason)
+			}
+			if tt.status != nil && pm.isAllChecksComplete(tt.status) != tt.complete {
+				t.Errorf("isAllChecksComplete disagrees with explanation for %s", tt.name)
+			}
+		})
+	}
+}
+
+func TestExplainComment(t *testing.T) {
+	pm := NewPRManager(0, 1, false)
+	comment := func(login, body string) types.PRComment {
+		return types.PRComment{User: types.User{Login: login}, Body: body}
+	}
+
+	tests := []struct {
+		name     string
+		comment  types.PRComment
+		expected string
+	}{
+		{"request", comment("alice", "Please add a test for the empty case"), "comment by alice is actionable (high priority): Specific request or change needed"},
+		{"bot", comment("github-actions[bot]", "Please review the coverage report"), "comment by github-actions[bot] is not actionable: posted by a bot"},
+		{"approval", comment("bob", "LGTM"), "comment by bob is not actionable: it is an approval"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ExplainComment(pm.analyzeCommentContent(tt.comment)); got != tt.expected {
+				t.Errorf("Expected '%s', got '%s'", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestDoneDecisionExplanation(t *testing.T) {
+	done := DoneDecision{Done: true}
+	if got := done.Explanation(); got != "done: every definition-of-done criterion is met" {
+		t.Errorf("Unexpected explanation: %s", got)
+	}
+
+	notDone := NewDoneEvaluator(DefaultDonePolicy()).Evaluate(nil, &types.CIStatus{Conclusion: "pending", PendingChecks: 1}, &types.PRCommentAnalysis{})
+	expected := "not done: validation has not run; 1 CI check(s) still pending"
+	if got := notDone.Explanation(); got != expected {
+		t.Errorf("Expected '%s', got '%s'", expected, got)
+	}
+}
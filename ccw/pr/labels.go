@@ -0,0 +1,64 @@
+package pr
+
+import (
+	"fmt"
+	"strings"
+
+	"ccw/github"
+	"ccw/types"
+)
+
+// ResolvePRLabels returns the labels to add to a PR: the issue labels that
+// appear in allowlist (matched case-insensitively), followed by the always
+// labels. Duplicates and blank entries are dropped and first-seen order kept.
+func ResolvePRLabels(issueLabels []types.Label, allowlist, always []string) []string {
+	allowed := make(map[string]bool, len(allowlist))
+	for _, name := range allowlist {
+		allowed[strings.ToLower(strings.TrimSpace(name))] = true
+	}
+
+	var labels []string
+	seen := make(map[string]bool)
+	add := func(name string) {
+		name = strings.TrimSpace(name)
+		key := strings.ToLower(name)
+		if name == "" || seen[key] {
+			return
+		}
+		seen[key] = true
+		labels = append(labels, name)
+	}
+
+	for _, label := range issueLabels {
+		if allowed[strings.ToLower(label.Name)] {
+			add(label.Name)
+		}
+	}
+	for _, name := range always {
+		add(name)
+	}
+
+	return labels
+}
+
+// addLabelsArgs builds the gh arguments adding labels to the PR at prURL
+func addLabelsArgs(prURL string, labels []string) []string {
+	return []string{"pr", "edit", prURL, "--add-label", strings.Join(labels, ",")}
+}
+
+// AddLabels adds labels to the PR at prURL. It does nothing when labels is empty.
+func (pm *PRManager) AddLabels(prURL string, labels []string) error {
+	if len(labels) == 0 {
+		return nil
+	}
+
+	cmdCtx, cancel := pm.commandContext()
+	defer cancel()
+
+	cmd := github.NewGHCommandContext(cmdCtx, addLabelsArgs(prURL, labels)...)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to add labels to pull request: %w\nOutput: %s", err, string(output))
+	}
+
+	return nil
+}
@@ -0,0 +1,51 @@
+package pr
+
+import (
+	"reflect"
+	"testing"
+
+	"ccw/types"
+)
+
+func TestResolvePRLabels(t *testing.T) {
+	issueLabels := []types.Label{{Name: "bug"}, {Name: "Enhancement"}, {Name: "wontfix"}, {Name: "automated"}}
+
+	tests := []struct {
+		name      string
+		issue     []types.Label
+		allowlist []string
+		always    []string
+		expected  []string
+	}{
+		{"nothing configured", issueLabels, nil, nil, nil},
+		{"allowlisted issue labels only", issueLabels, []string{"bug", "documentation"}, nil, []string{"bug"}},
+		{"allowlist is case-insensitive", issueLabels, []string{" enhancement "}, nil, []string{"Enhancement"}},
+		{"always labels without issue labels", nil, []string{"bug"}, []string{"automated"}, []string{"automated"}},
+		{"issue labels before always labels", issueLabels, []string{"bug", "enhancement"}, []string{"automated", "ccw"}, []string{"bug", "Enhancement", "automated", "ccw"}},
+		{"duplicates and blanks dropped", issueLabels, []string{"automated"}, []string{"Automated", "", "ccw", "ccw"}, []string{"automated", "ccw"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ResolvePRLabels(tt.issue, tt.allowlist, tt.always)
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestAddLabelsArgs(t *testing.T) {
+	args := addLabelsArgs("https://github.com/o/r/pull/7", []string{"bug", "automated"})
+	expected := []string{"pr", "edit", "https://github.com/o/r/pull/7", "--add-label", "bug,automated"}
+	if !reflect.DeepEqual(args, expected) {
+		t.Errorf("Expected %v, got %v", expected, args)
+	}
+}
+
+func TestAddLabelsWithoutLabelsIsNoop(t *testing.T) {
+	pm := NewPRManager(0, 1, false)
+	if err := pm.AddLabels("https://github.com/o/r/pull/7", nil); err != nil {
+		t.Errorf("Expected no error without labels, got %v", err)
+	}
+}
@@ -0,0 +1,43 @@
+package pr
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"ccw/github"
+)
+
+// prMergeState is the part of `gh pr view --json state,mergedAt` used to detect merges
+type prMergeState struct {
+	State    string `json:"state"`
+	MergedAt string `json:"mergedAt"`
+}
+
+// parseMergeState reports whether the gh output describes a merged PR. gh
+// prints a null or zero mergedAt for PRs that are not merged.
+func parseMergeState(data []byte) (bool, error) {
+	var state prMergeState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return false, fmt.Errorf("failed to parse PR state: %w", err)
+	}
+
+	if strings.EqualFold(state.State, "MERGED") {
+		return true, nil
+	}
+	return state.MergedAt != "" && !strings.HasPrefix(state.MergedAt, "0001-01-01"), nil
+}
+
+// IsMerged reports whether the PR at prURL has been merged
+func (pm *PRManager) IsMerged(prURL string) (bool, error) {
+	cmdCtx, cancel := pm.commandContext()
+	defer cancel()
+
+	cmd := github.NewGHCommandContext(cmdCtx, "pr", "view", prURL, "--json", "state,mergedAt")
+	output, err := cmd.Output()
+	if err != nil {
+		return false, fmt.Errorf("failed to fetch PR state: %w", err)
+	}
+
+	return parseMergeState(output)
+}
@@ -0,0 +1,38 @@
+package pr
+
+import "testing"
+
+func TestParseMergeState(t *testing.T) {
+	tests := []struct {
+		name        string
+		output      string
+		expected    bool
+		expectError bool
+	}{
+		{"merged", `{"mergedAt":"2024-05-01T09:00:00Z","state":"MERGED"}`, true, false},
+		{"open with null mergedAt", `{"mergedAt":null,"state":"OPEN"}`, false, false},
+		{"open with zero mergedAt", `{"mergedAt":"0001-01-01T00:00:00Z","state":"OPEN"}`, false, false},
+		{"closed without merge", `{"mergedAt":"","state":"CLOSED"}`, false, false},
+		{"mergedAt without state", `{"mergedAt":"2024-05-01T09:00:00Z"}`, true, false},
+		{"lowercase state", `{"state":"merged"}`, true, false},
+		{"invalid output", `not json`, false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			merged, err := parseMergeState([]byte(tt.output))
+			if tt.expectError {
+				if err == nil {
+					t.Error("Expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if merged != tt.expected {
+				t.Errorf("Expected merged %v, got %v", tt.expected, merged)
+			}
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package pr
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"ccw/github"
+)
+
+// PR mergeability and how conflicts with the base branch are handled
+
+// Mergeable states reported by gh pr view --json mergeable
+const (
+	MergeableYes         = "MERGEABLE"
+	MergeableConflicting = "CONFLICTING"
+	MergeableUnknown     = "UNKNOWN" // GitHub has not computed it yet
+)
+
+// mergeStateDirty is the mergeStateStatus of a PR whose merge commit cannot
+// be created because of conflicts
+const mergeStateDirty = "DIRTY"
+
+// Conflict handling actions
+const (
+	ConflictNone   = "none"   // No conflicts, or mergeability unknown
+	ConflictRebase = "rebase" // Rebase onto the base branch and push
+	ConflictReport = "report" // Tell the user to resolve the conflicts
+)
+
+// Mergeability is the part of `gh pr view --json mergeable,mergeStateStatus,baseRefName` used to detect conflicts
+type Mergeability struct {
+	Mergeable        string `json:"mergeable"`
+	MergeStateStatus string `json:"mergeStateStatus"`
+	BaseRefName      string `json:"baseRefName"`
+}
+
+// HasConflicts reports whether the PR conflicts with its base branch
+func (m Mergeability) HasConflicts() bool {
+	return m.Mergeable == MergeableConflicting || m.MergeStateStatus == mergeStateDirty
+}
+
+// parseMergeability reads the gh output, normalizing states to upper case
+func parseMergeability(data []byte) (Mergeability, error) {
+	var m Mergeability
+	if err := json.Unmarshal(data, &m); err != nil {
+		return Mergeability{}, fmt.Errorf("failed to parse PR mergeability: %w", err)
+	}
+	m.Mergeable = strings.ToUpper(strings.TrimSpace(m.Mergeable))
+	m.MergeStateStatus = strings.ToUpper(strings.TrimSpace(m.MergeStateStatus))
+	if m.Mergeable == "" {
+		m.Mergeable = MergeableUnknown
+	}
+	return m, nil
+}
+
+// DecideConflictHandling chooses how to handle a PR's mergeability and why.
+// A conflicting PR is rebased once per run when rebaseOnConflict is set;
+// otherwise, or when the rebase already happened, the conflict is reported.
+func DecideConflictHandling(m Mergeability, rebaseOnConflict, alreadyRebased bool) (string, string) {
+	switch {
+	case !m.HasConflicts() && m.Mergeable == MergeableUnknown:
+		return ConflictNone, "mergeability not checked further: GitHub has not computed it yet"
+	case !m.HasConflicts():
+		return ConflictNone, fmt.Sprintf("PR has no conflicts with %s (mergeable %s, merge state %s)", m.BaseRefName, m.Mergeable, m.MergeStateStatus)
+	case m.BaseRefName == "":
+		return ConflictReport, "PR conflicts with its base branch, which gh did not report, so it cannot be rebased automatically"
+	case !rebaseOnConflict:
+		return ConflictReport, fmt.Sprintf("PR conflicts with %s and workflow.done.rebase_on_conflict is off", m.BaseRefName)
+	case alreadyRebased:
+		return ConflictReport, fmt.Sprintf("PR still conflicts with %s after being rebased once in this run", m.BaseRefName)
+	}
+	return ConflictRebase, fmt.Sprintf("PR conflicts with %s, so the branch is rebased onto it because workflow.done.rebase_on_conflict is on", m.BaseRefName)
+}
+
+// GetMergeability returns whether the PR at prURL can be merged into its base
+func (pm *PRManager) GetMergeability(prURL string) (Mergeability, error) {
+	cmdCtx, cancel := pm.commandContext()
+	defer cancel()
+
+	cmd := github.NewGHCommandContext(cmdCtx, "pr", "view", prURL, "--json", "mergeable,mergeStateStatus,baseRefName")
+	output, err := cmd.Output()
+	if err != nil {
+		return Mergeability{}, fmt.Errorf("failed to fetch PR mergeability: %w", err)
+	}
+
+	return parseMergeability(output)
+}
@@ -0,0 +1,68 @@
+package pr
+
+import "testing"
+
+func TestParseMergeability(t *testing.T) {
+	tests := []struct {
+		name      string
+		output    string
+		expected  Mergeability
+		conflicts bool
+	}{
+		{"clean", `{"mergeable":"MERGEABLE","mergeStateStatus":"CLEAN","baseRefName":"main"}`, Mergeability{MergeableYes, "CLEAN", "main"}, false},
+		{"conflicting", `{"mergeable":"CONFLICTING","mergeStateStatus":"DIRTY","baseRefName":"main"}`, Mergeability{MergeableConflicting, "DIRTY", "main"}, true},
+		{"dirty only", `{"mergeable":"UNKNOWN","mergeStateStatus":"dirty","baseRefName":"main"}`, Mergeability{MergeableUnknown, "DIRTY", "main"}, true},
+		{"behind", `{"mergeable":"MERGEABLE","mergeStateStatus":"BEHIND","baseRefName":"develop"}`, Mergeability{MergeableYes, "BEHIND", "develop"}, false},
+		{"not computed", `{"mergeable":"","mergeStateStatus":"UNKNOWN","baseRefName":"main"}`, Mergeability{MergeableUnknown, "UNKNOWN", "main"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := parseMergeability([]byte(tt.output))
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if m != tt.expected {
+				t.Errorf("Expected %+v, got %+v", tt.expected, m)
+			}
+			if m.HasConflicts() != tt.conflicts {
+				t.Errorf("Expected conflicts %v, got %v", tt.conflicts, m.HasConflicts())
+			}
+		})
+	}
+
+	if _, err := parseMergeability([]byte("not json")); err == nil {
+		t.Error("Expected an error for invalid output")
+	}
+}
+
+func TestDecideConflictHandling(t *testing.T) {
+	conflicting := Mergeability{Mergeable: MergeableConflicting, MergeStateStatus: "DIRTY", BaseRefName: "main"}
+
+	tests := []struct {
+		name             string
+		mergeability     Mergeability
+		rebaseOnConflict bool
+		alreadyRebased   bool
+		expected         string
+	}{
+		{"clean", Mergeability{Mergeable: MergeableYes, MergeStateStatus: "CLEAN", BaseRefName: "main"}, true, false, ConflictNone},
+		{"unknown", Mergeability{Mergeable: MergeableUnknown, BaseRefName: "main"}, true, false, ConflictNone},
+		{"rebase", conflicting, true, false, ConflictRebase},
+		{"rebase disabled", conflicting, false, false, ConflictReport},
+		{"already rebased", conflicting, true, true, ConflictReport},
+		{"no base", Mergeability{Mergeable: MergeableConflicting}, true, false, ConflictReport},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			action, reason := DecideConflictHandling(tt.mergeability, tt.rebaseOnConflict, tt.alreadyRebased)
+			if action != tt.expected {
+				t.Errorf("Expected '%s', got '%s' (%s)", tt.expected, action, reason)
+			}
+			if reason == "" {
+				t.Error("Expected a reason")
+			}
+		})
+	}
+}
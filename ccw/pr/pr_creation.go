@@ -1,11 +1,10 @@
 package pr
 
 import (
-	"context"
 	"fmt"
-	"os/exec"
 	"strings"
 
+	"ccw/github"
 	"ccw/types"
 )
 
@@ -26,19 +25,45 @@ func (pm *PRManager) CreatePullRequestAsync(req *types.PRRequest, worktreePath s
 	return resultChan
 }
 
-// CreatePullRequest creates a pull request synchronously
-func (pm *PRManager) CreatePullRequest(req *types.PRRequest, worktreePath string) (*types.PullRequest, error) {
-	// Create command with timeout
-	cmdCtx, cancel := context.WithTimeout(context.Background(), pm.timeout)
-	defer cancel()
-
-	// Build gh pr create command
+// createPRArgs builds the gh pr create arguments for req
+func createPRArgs(req *types.PRRequest) []string {
 	args := []string{"pr", "create", "--title", req.Title, "--body", req.Body}
 	if req.Base != "" {
 		args = append(args, "--base", req.Base)
 	}
+	if req.Head != "" {
+		args = append(args, "--head", req.Head)
+	}
+	if req.Repo != "" {
+		args = append(args, "--repo", req.Repo)
+	}
+	if len(req.Reviewers) > 0 {
+		args = append(args, "--reviewer", strings.Join(req.Reviewers, ","))
+	}
+	if len(req.Assignees) > 0 {
+		args = append(args, "--assignee", strings.Join(req.Assignees, ","))
+	}
+	// Maintainer edits can only be turned off for PRs opened from a fork
+	if !req.MaintainerCanModify && IsCrossRepositoryHead(req.Head) {
+		args = append(args, "--no-maintainer-edit")
+	}
+	return args
+}
+
+// IsCrossRepositoryHead reports whether head names a branch in another
+// repository, written as "owner:branch"
+func IsCrossRepositoryHead(head string) bool {
+	owner, branch, found := strings.Cut(head, ":")
+	return found && owner != "" && branch != ""
+}
+
+// CreatePullRequest creates a pull request synchronously
+func (pm *PRManager) CreatePullRequest(req *types.PRRequest, worktreePath string) (*types.PullRequest, error) {
+	// Create command with timeout
+	cmdCtx, cancel := pm.commandContext()
+	defer cancel()
 
-	cmd := exec.CommandContext(cmdCtx, "gh", args...)
+	cmd := github.NewGHCommandContext(cmdCtx, createPRArgs(req)...)
 	cmd.Dir = worktreePath
 
 	output, err := cmd.CombinedOutput()
@@ -0,0 +1,69 @@
+package pr
+
+import (
+	"reflect"
+	"testing"
+
+	"ccw/types"
+)
+
+func TestCreatePRArgs(t *testing.T) {
+	tests := []struct {
+		name     string
+		req      *types.PRRequest
+		expected []string
+	}{
+		{
+			"minimal",
+			&types.PRRequest{Title: "T", Body: "B"},
+			[]string{"pr", "create", "--title", "T", "--body", "B"},
+		},
+		{
+			"reviewers and assignees",
+			&types.PRRequest{Title: "T", Body: "B", Base: "main", Head: "issue-1", Reviewers: []string{"alice", "org/team"}, Assignees: []string{"carol"}},
+			[]string{"pr", "create", "--title", "T", "--body", "B", "--base", "main", "--head", "issue-1", "--reviewer", "alice,org/team", "--assignee", "carol"},
+		},
+		{
+			"fork without maintainer edits",
+			&types.PRRequest{Title: "T", Body: "B", Head: "me:issue-1", MaintainerCanModify: false},
+			[]string{"pr", "create", "--title", "T", "--body", "B", "--head", "me:issue-1", "--no-maintainer-edit"},
+		},
+		{
+			"fork with maintainer edits",
+			&types.PRRequest{Title: "T", Body: "B", Head: "me:issue-1", MaintainerCanModify: true},
+			[]string{"pr", "create", "--title", "T", "--body", "B", "--head", "me:issue-1"},
+		},
+		{
+			"same repository ignores maintainer edits",
+			&types.PRRequest{Title: "T", Body: "B", Head: "issue-1", MaintainerCanModify: false},
+			[]string{"pr", "create", "--title", "T", "--body", "B", "--head", "issue-1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if args := createPRArgs(tt.req); !reflect.DeepEqual(args, tt.expected) {
+				t.Errorf("Expected %q, got %q", tt.expected, args)
+			}
+		})
+	}
+}
+
+func TestIsCrossRepositoryHead(t *testing.T) {
+	tests := []struct {
+		head     string
+		expected bool
+	}{
+		{"me:issue-1", true},
+		{"issue-1", false},
+		{"", false},
+		{":issue-1", false},
+		{"me:", false},
+	}
+
+	for _, tt := range tests {
+		if got := IsCrossRepositoryHead(tt.head); got != tt.expected {
+			t.Errorf("IsCrossRepositoryHead(%q): expected %v, got %v", tt.head, tt.expected, got)
+		}
+	}
+}
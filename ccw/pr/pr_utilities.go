@@ -1,15 +1,20 @@
 package pr
 
 import (
+	"context"
 	"strconv"
 	"time"
 )
 
 // PRManager handles pull request operations with async support
 type PRManager struct {
-	timeout    time.Duration
-	maxRetries int
-	debugMode  bool
+	timeout      time.Duration
+	maxRetries   int
+	debugMode    bool
+	checkAliases []CheckAlias
+
+	initialDelay       time.Duration // Wait before the first CI status poll
+	initialDelayJitter time.Duration // Random extra wait added to initialDelay
 }
 
 // NewPRManager creates a new PR manager instance
@@ -21,6 +26,15 @@ func NewPRManager(timeout time.Duration, maxRetries int, debugMode bool) *PRMana
 	}
 }
 
+// commandContext bounds one gh command by the manager's timeout; a timeout of
+// 0 means no limit
+func (pm *PRManager) commandContext() (context.Context, context.CancelFunc) {
+	if pm.timeout > 0 {
+		return context.WithTimeout(context.Background(), pm.timeout)
+	}
+	return context.WithCancel(context.Background())
+}
+
 // Helper function to safely parse integers
 func parseInt(s string) int {
 	if result, err := strconv.Atoi(s); err == nil {
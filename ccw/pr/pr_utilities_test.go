@@ -0,0 +1,52 @@
+package pr
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"ccw/github"
+)
+
+func TestCommandContextUsesManagerTimeout(t *testing.T) {
+	ctx, cancel := NewPRManager(45*time.Second, 0, false).commandContext()
+	defer cancel()
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		t.Fatal("Expected a deadline from the GitHub timeout")
+	}
+	if remaining := time.Until(deadline); remaining <= 0 || remaining > 45*time.Second {
+		t.Errorf("Expected a deadline within 45s, got %v", remaining)
+	}
+
+	ctx, cancel = NewPRManager(0, 0, false).commandContext()
+	defer cancel()
+	if _, ok := ctx.Deadline(); ok {
+		t.Error("Expected no deadline for a 0 timeout")
+	}
+}
+
+func TestGetPRCommentsStopsAtGitHubTimeout(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a shell script as the gh executable")
+	}
+	defer github.SetTimeout(github.Timeout())
+	defer github.SetGHPath(github.GHPath())
+
+	slowGH := filepath.Join(t.TempDir(), "gh")
+	if err := os.WriteFile(slowGH, []byte("#!/bin/sh\nexec sleep 10\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	github.SetGHPath(slowGH)
+	github.SetTimeout(100 * time.Millisecond)
+
+	start := time.Now()
+	if _, err := NewPRManager(0, 0, false).GetPRComments("https://github.com/owner/repo/pull/1"); err == nil {
+		t.Fatal("Expected the hung gh call to fail")
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Expected github.timeout to stop the gh call, ran for %v", elapsed)
+	}
+}
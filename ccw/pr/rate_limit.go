@@ -0,0 +1,76 @@
+package pr
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Backoff for GitHub secondary rate limits
+
+const (
+	// MaxRateLimitRetries is how many times a rate-limited call is retried
+	MaxRateLimitRetries = 3
+
+	rateLimitBaseDelay = time.Minute      // First backoff; doubles on each retry
+	rateLimitMaxDelay  = 10 * time.Minute // Longest single wait
+)
+
+// secondaryRateLimitPatterns match the errors GitHub returns for its abuse and
+// secondary rate limits, as opposed to the primary hourly quota
+var secondaryRateLimitPatterns = []string{
+	"secondary rate limit",
+	"abuse detection",
+	"was submitted too quickly",
+}
+
+// retryAfterPattern matches a Retry-After header in gh output
+var retryAfterPattern = regexp.MustCompile(`(?i)retry-after:\s*(\d+)`)
+
+// IsSecondaryRateLimit reports whether err is a GitHub secondary rate limit
+func IsSecondaryRateLimit(err error) bool {
+	if err == nil {
+		return false
+	}
+	message := strings.ToLower(err.Error())
+	for _, pattern := range secondaryRateLimitPatterns {
+		if strings.Contains(message, pattern) {
+			return true
+		}
+	}
+	return false
+}
+
+// retryAfter extracts the Retry-After wait from gh output
+func retryAfter(output string) (time.Duration, bool) {
+	match := retryAfterPattern.FindStringSubmatch(output)
+	if match == nil {
+		return 0, false
+	}
+	seconds, err := strconv.Atoi(match[1])
+	if err != nil {
+		return 0, false
+	}
+	return time.Duration(seconds) * time.Second, true
+}
+
+// RateLimitBackoff decides whether to retry after err on the given 1-based
+// attempt and how long to wait first. Only secondary rate limits are retried,
+// at most MaxRateLimitRetries times. A Retry-After from GitHub takes precedence
+// over the exponential delay; one longer than the cap is not worth waiting for.
+func RateLimitBackoff(err error, attempt int) (time.Duration, bool) {
+	if !IsSecondaryRateLimit(err) || attempt < 1 || attempt > MaxRateLimitRetries {
+		return 0, false
+	}
+
+	if delay, ok := retryAfter(err.Error()); ok {
+		return delay, delay <= rateLimitMaxDelay
+	}
+
+	delay := rateLimitBaseDelay << (attempt - 1)
+	if delay > rateLimitMaxDelay {
+		delay = rateLimitMaxDelay
+	}
+	return delay, true
+}
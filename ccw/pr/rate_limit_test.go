@@ -0,0 +1,62 @@
+package pr
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestIsSecondaryRateLimit(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{"nil", nil, false},
+		{"secondary rate limit", errors.New("HTTP 403: You have exceeded a secondary rate limit"), true},
+		{"abuse detection", errors.New("You have triggered an abuse detection mechanism"), true},
+		{"submitted too quickly", errors.New("GraphQL: was submitted too quickly (createPullRequest)"), true},
+		{"primary rate limit", errors.New("API rate limit exceeded for user ID 1"), false},
+		{"other failure", errors.New("pull request already exists"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := IsSecondaryRateLimit(tt.err); result != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestRateLimitBackoff(t *testing.T) {
+	limited := errors.New("failed to create pull request: exit status 1\nOutput: You have exceeded a secondary rate limit")
+
+	tests := []struct {
+		name          string
+		err           error
+		attempt       int
+		expectedDelay time.Duration
+		expectedRetry bool
+	}{
+		{"first retry", limited, 1, time.Minute, true},
+		{"second retry doubles", limited, 2, 2 * time.Minute, true},
+		{"third retry doubles again", limited, 3, 4 * time.Minute, true},
+		{"retries exhausted", limited, 4, 0, false},
+		{"retry-after honored", errors.New("secondary rate limit\nRetry-After: 90"), 1, 90 * time.Second, true},
+		{"retry-after beyond cap", errors.New("secondary rate limit\nretry-after: 3600"), 1, time.Hour, false},
+		{"hard failure", errors.New("HTTP 422: Validation Failed"), 1, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			delay, retry := RateLimitBackoff(tt.err, tt.attempt)
+			if retry != tt.expectedRetry {
+				t.Errorf("Expected retry %v, got %v", tt.expectedRetry, retry)
+			}
+			if delay != tt.expectedDelay {
+				t.Errorf("Expected delay %v, got %v", tt.expectedDelay, delay)
+			}
+		})
+	}
+}
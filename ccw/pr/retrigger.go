@@ -0,0 +1,75 @@
+package pr
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"ccw/github"
+	"ccw/types"
+)
+
+// Re-triggering CI for failed checks
+
+// CI re-trigger strategies for ci.retrigger_strategy
+const (
+	RetriggerRerun       = "rerun"        // gh run rerun --failed for each failed Actions run
+	RetriggerEmptyCommit = "empty-commit" // Push an empty commit to the PR branch
+	RetriggerNone        = "none"         // Leave failed CI alone
+)
+
+// actionsRunURLPattern matches check URLs of GitHub Actions runs on github.com
+// or a GitHub Enterprise host, capturing the host, repository and run ID
+var actionsRunURLPattern = regexp.MustCompile(`^https://([^/]+)/([^/]+/[^/]+)/actions/runs/(\d+)`)
+
+// ghRepository returns the --repo value for gh: owner/repo on github.com, or
+// host/owner/repo on a GitHub Enterprise host
+func ghRepository(host, repository string) string {
+	if host = strings.ToLower(host); host == "github.com" || host == "www.github.com" {
+		return repository
+	}
+	return host + "/" + repository
+}
+
+// WorkflowRun identifies a GitHub Actions run
+type WorkflowRun struct {
+	Repository string // owner/repo, or host/owner/repo off github.com
+	ID         string
+}
+
+// FailedWorkflowRuns returns the distinct Actions runs behind the failed checks
+// in status, in check order. Checks from other CI systems are skipped.
+func FailedWorkflowRuns(status *types.CIStatus) []WorkflowRun {
+	if status == nil {
+		return nil
+	}
+
+	var runs []WorkflowRun
+	seen := make(map[WorkflowRun]bool)
+	for _, check := range status.Checks {
+		if check.Conclusion != "failure" && check.Conclusion != "error" {
+			continue
+		}
+		match := actionsRunURLPattern.FindStringSubmatch(check.URL)
+		if match == nil {
+			continue
+		}
+		run := WorkflowRun{Repository: ghRepository(match[1], match[2]), ID: match[3]}
+		if !seen[run] {
+			seen[run] = true
+			runs = append(runs, run)
+		}
+	}
+	return runs
+}
+
+// RerunFailedJobs re-runs the failed jobs of an Actions run
+func (pm *PRManager) RerunFailedJobs(run WorkflowRun) error {
+	cmdCtx, cancel := pm.commandContext()
+	defer cancel()
+
+	if output, err := github.NewGHCommandContext(cmdCtx, "run", "rerun", run.ID, "--failed", "--repo", run.Repository).CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to rerun workflow run %s in %s: %w\nOutput: %s", run.ID, run.Repository, err, string(output))
+	}
+	return nil
+}
@@ -0,0 +1,28 @@
+package pr
+
+import (
+	"reflect"
+	"testing"
+
+	"ccw/types"
+)
+
+func TestFailedWorkflowRuns(t *testing.T) {
+	status := &types.CIStatus{Checks: []types.CheckRun{
+		{Name: "build", Conclusion: "failure", URL: "https://github.com/o/r/actions/runs/101/job/1"},
+		{Name: "test", Conclusion: "failure", URL: "https://github.com/o/r/actions/runs/101/job/2"},
+		{Name: "lint", Conclusion: "success", URL: "https://github.com/o/r/actions/runs/102/job/3"},
+		{Name: "e2e", Conclusion: "error", URL: "https://github.com/o/r/actions/runs/103"},
+		{Name: "external", Conclusion: "failure", URL: "https://ci.example.com/build/7"},
+		{Name: "enterprise", Conclusion: "failure", URL: "https://ghe.example.com/o/r/actions/runs/104/job/5"},
+	}}
+
+	expected := []WorkflowRun{{Repository: "o/r", ID: "101"}, {Repository: "o/r", ID: "103"}, {Repository: "ghe.example.com/o/r", ID: "104"}}
+	if runs := FailedWorkflowRuns(status); !reflect.DeepEqual(runs, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, runs)
+	}
+
+	if runs := FailedWorkflowRuns(nil); runs != nil {
+		t.Errorf("Expected no runs without a status, got %+v", runs)
+	}
+}
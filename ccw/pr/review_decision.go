@@ -0,0 +1,52 @@
+package pr
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"ccw/github"
+)
+
+// ReviewDecision is GitHub's overall review state for a PR
+type ReviewDecision string
+
+const (
+	ReviewDecisionNone             ReviewDecision = ""                  // No review required or state unknown
+	ReviewDecisionApproved         ReviewDecision = "APPROVED"          // Required approvals given
+	ReviewDecisionChangesRequested ReviewDecision = "CHANGES_REQUESTED" // A reviewer requested changes
+	ReviewDecisionReviewRequired   ReviewDecision = "REVIEW_REQUIRED"   // Approval still needed
+)
+
+// parseReviewDecision reads the output of `gh pr view --json reviewDecision`.
+// gh prints an empty decision for repositories without required reviews.
+func parseReviewDecision(data []byte) (ReviewDecision, error) {
+	var view struct {
+		ReviewDecision string `json:"reviewDecision"`
+	}
+	if err := json.Unmarshal(data, &view); err != nil {
+		return ReviewDecisionNone, fmt.Errorf("failed to parse review decision: %w", err)
+	}
+
+	decision := ReviewDecision(strings.ToUpper(strings.TrimSpace(view.ReviewDecision)))
+	switch decision {
+	case ReviewDecisionNone, ReviewDecisionApproved, ReviewDecisionChangesRequested, ReviewDecisionReviewRequired:
+		return decision, nil
+	default:
+		return ReviewDecisionNone, fmt.Errorf("unknown review decision %q", view.ReviewDecision)
+	}
+}
+
+// GetReviewDecision returns the review decision of the PR at prURL
+func (pm *PRManager) GetReviewDecision(prURL string) (ReviewDecision, error) {
+	cmdCtx, cancel := pm.commandContext()
+	defer cancel()
+
+	cmd := github.NewGHCommandContext(cmdCtx, "pr", "view", prURL, "--json", "reviewDecision")
+	output, err := cmd.Output()
+	if err != nil {
+		return ReviewDecisionNone, fmt.Errorf("failed to fetch PR review decision: %w", err)
+	}
+
+	return parseReviewDecision(output)
+}
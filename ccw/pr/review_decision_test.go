@@ -0,0 +1,39 @@
+package pr
+
+import "testing"
+
+func TestParseReviewDecision(t *testing.T) {
+	tests := []struct {
+		name        string
+		output      string
+		expected    ReviewDecision
+		expectError bool
+	}{
+		{"changes requested", `{"reviewDecision":"CHANGES_REQUESTED"}`, ReviewDecisionChangesRequested, false},
+		{"approved", `{"reviewDecision":"APPROVED"}`, ReviewDecisionApproved, false},
+		{"review required", `{"reviewDecision":"REVIEW_REQUIRED"}`, ReviewDecisionReviewRequired, false},
+		{"no required reviews", `{"reviewDecision":""}`, ReviewDecisionNone, false},
+		{"missing field", `{}`, ReviewDecisionNone, false},
+		{"lowercase", `{"reviewDecision":"changes_requested"}`, ReviewDecisionChangesRequested, false},
+		{"unknown decision", `{"reviewDecision":"DISMISSED"}`, ReviewDecisionNone, true},
+		{"invalid output", `not json`, ReviewDecisionNone, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			decision, err := parseReviewDecision([]byte(tt.output))
+			if tt.expectError {
+				if err == nil {
+					t.Error("Expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if decision != tt.expected {
+				t.Errorf("Expected '%s', got '%s'", tt.expected, decision)
+			}
+		})
+	}
+}
@@ -0,0 +1,224 @@
+package pr
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"ccw/github"
+	"ccw/types"
+)
+
+// Replying to and resolving inline review threads
+
+// reviewThreadsQuery fetches a PR's review threads with their comments
+const reviewThreadsQuery = `query($owner: String!, $repo: String!, $number: Int!) {
+  repository(owner: $owner, name: $repo) {
+    pullRequest(number: $number) {
+      reviewThreads(first: 100) {
+        nodes {
+          id
+          isResolved
+          path
+          comments(first: 50) {
+            nodes { databaseId body url createdAt author { login } }
+          }
+        }
+      }
+    }
+  }
+}`
+
+// replyToThreadMutation adds a reply to a review thread
+const replyToThreadMutation = `mutation($threadId: ID!, $body: String!) {
+  addPullRequestReviewThreadReply(input: {pullRequestReviewThreadId: $threadId, body: $body}) {
+    comment { id }
+  }
+}`
+
+// resolveThreadMutation marks a review thread as resolved
+const resolveThreadMutation = `mutation($threadId: ID!) {
+  resolveReviewThread(input: {threadId: $threadId}) {
+    thread { isResolved }
+  }
+}`
+
+// ReviewThread is an inline review conversation on a PR
+type ReviewThread struct {
+	ID         string            // GraphQL node ID used to reply and resolve
+	IsResolved bool              // Already resolved threads need no reply
+	Path       string            // File the thread is attached to
+	Comments   []types.PRComment // Comments in the thread, oldest first
+}
+
+// reviewThreadsResponse is the part of the reviewThreadsQuery response used
+type reviewThreadsResponse struct {
+	Data struct {
+		Repository struct {
+			PullRequest struct {
+				ReviewThreads struct {
+					Nodes []struct {
+						ID         string `json:"id"`
+						IsResolved bool   `json:"isResolved"`
+						Path       string `json:"path"`
+						Comments   struct {
+							Nodes []struct {
+								DatabaseID int       `json:"databaseId"`
+								Body       string    `json:"body"`
+								URL        string    `json:"url"`
+								CreatedAt  time.Time `json:"createdAt"`
+								Author     struct {
+									Login string `json:"login"`
+								} `json:"author"`
+							} `json:"nodes"`
+						} `json:"comments"`
+					} `json:"nodes"`
+				} `json:"reviewThreads"`
+			} `json:"pullRequest"`
+		} `json:"repository"`
+	} `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+// reviewThreadsArgs builds the gh arguments fetching the review threads of a PR
+func reviewThreadsArgs(owner, repo string, prNumber int) []string {
+	return []string{"api", "graphql",
+		"-f", "query=" + reviewThreadsQuery,
+		"-f", "owner=" + owner,
+		"-f", "repo=" + repo,
+		"-F", fmt.Sprintf("number=%d", prNumber),
+	}
+}
+
+// replyToThreadArgs builds the gh arguments replying body to a review thread
+func replyToThreadArgs(threadID, body string) []string {
+	return []string{"api", "graphql",
+		"-f", "query=" + replyToThreadMutation,
+		"-f", "threadId=" + threadID,
+		"-f", "body=" + body,
+	}
+}
+
+// resolveThreadArgs builds the gh arguments resolving a review thread
+func resolveThreadArgs(threadID string) []string {
+	return []string{"api", "graphql",
+		"-f", "query=" + resolveThreadMutation,
+		"-f", "threadId=" + threadID,
+	}
+}
+
+// parseReviewThreads maps a reviewThreadsQuery response onto ReviewThreads
+func parseReviewThreads(data []byte) ([]ReviewThread, error) {
+	var resp reviewThreadsResponse
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse review threads: %w", err)
+	}
+	if len(resp.Errors) > 0 {
+		messages := make([]string, len(resp.Errors))
+		for i, e := range resp.Errors {
+			messages[i] = e.Message
+		}
+		return nil, fmt.Errorf("GraphQL errors: %s", strings.Join(messages, "; "))
+	}
+
+	var threads []ReviewThread
+	for _, node := range resp.Data.Repository.PullRequest.ReviewThreads.Nodes {
+		thread := ReviewThread{ID: node.ID, IsResolved: node.IsResolved, Path: node.Path}
+		for _, comment := range node.Comments.Nodes {
+			thread.Comments = append(thread.Comments, types.PRComment{
+				ID:        comment.DatabaseID,
+				Body:      comment.Body,
+				User:      types.User{Login: comment.Author.Login},
+				CreatedAt: comment.CreatedAt,
+				UpdatedAt: comment.CreatedAt,
+				HTMLURL:   comment.URL,
+			})
+		}
+		threads = append(threads, thread)
+	}
+	return threads, nil
+}
+
+// UnresolvedThreadComments returns the comments of unresolved threads, so
+// inline review feedback is analyzed alongside top-level comments
+func UnresolvedThreadComments(threads []ReviewThread) []types.PRComment {
+	var comments []types.PRComment
+	for _, thread := range threads {
+		if !thread.IsResolved {
+			comments = append(comments, thread.Comments...)
+		}
+	}
+	return comments
+}
+
+// ThreadsForComments returns the unresolved threads containing any of the
+// addressed comments, matched by comment ID or URL, each thread at most once
+func ThreadsForComments(threads []ReviewThread, addressed []types.ActionableComment) []ReviewThread {
+	ids := make(map[int]bool)
+	urls := make(map[string]bool)
+	for _, actionable := range addressed {
+		if actionable.Comment.ID != 0 {
+			ids[actionable.Comment.ID] = true
+		}
+		if actionable.Comment.HTMLURL != "" {
+			urls[actionable.Comment.HTMLURL] = true
+		}
+	}
+
+	var matched []ReviewThread
+	for _, thread := range threads {
+		if thread.IsResolved {
+			continue
+		}
+		for _, comment := range thread.Comments {
+			if (comment.ID != 0 && ids[comment.ID]) || (comment.HTMLURL != "" && urls[comment.HTMLURL]) {
+				matched = append(matched, thread)
+				break
+			}
+		}
+	}
+	return matched
+}
+
+// GetReviewThreads returns the review threads of the PR at prURL
+func (pm *PRManager) GetReviewThreads(prURL string) ([]ReviewThread, error) {
+	owner, repo, prNumber, err := github.ExtractPRInfo(prURL)
+	if err != nil {
+		return nil, err
+	}
+
+	cmdCtx, cancel := pm.commandContext()
+	defer cancel()
+
+	output, err := github.NewGHCommandContext(cmdCtx, reviewThreadsArgs(owner, repo, prNumber)...).Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch review threads: %w", err)
+	}
+	return parseReviewThreads(output)
+}
+
+// ReplyToReviewThread posts body as a reply in the review thread threadID of
+// the PR at prURL
+func (pm *PRManager) ReplyToReviewThread(prURL, threadID, body string) error {
+	cmdCtx, cancel := pm.commandContext()
+	defer cancel()
+
+	if output, err := github.NewGHCommandContext(cmdCtx, replyToThreadArgs(threadID, body)...).CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to reply to review thread %s on %s: %w\nOutput: %s", threadID, prURL, err, string(output))
+	}
+	return nil
+}
+
+// ResolveReviewThread marks the review thread threadID as resolved
+func (pm *PRManager) ResolveReviewThread(threadID string) error {
+	cmdCtx, cancel := pm.commandContext()
+	defer cancel()
+
+	if output, err := github.NewGHCommandContext(cmdCtx, resolveThreadArgs(threadID)...).CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to resolve review thread %s: %w\nOutput: %s", threadID, err, string(output))
+	}
+	return nil
+}
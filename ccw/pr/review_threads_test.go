@@ -0,0 +1,99 @@
+package pr
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"ccw/types"
+)
+
+func TestReviewThreadArgs(t *testing.T) {
+	args := replyToThreadArgs("PRRT_kwDO123", "Addressed in abc1234.")
+	expected := []string{"api", "graphql", "-f", "query=" + replyToThreadMutation, "-f", "threadId=PRRT_kwDO123", "-f", "body=Addressed in abc1234."}
+	if !reflect.DeepEqual(args, expected) {
+		t.Errorf("Expected %v, got %v", expected, args)
+	}
+	if !strings.Contains(replyToThreadMutation, "addPullRequestReviewThreadReply") {
+		t.Error("Expected reply mutation to add a thread reply")
+	}
+
+	args = resolveThreadArgs("PRRT_kwDO123")
+	expected = []string{"api", "graphql", "-f", "query=" + resolveThreadMutation, "-f", "threadId=PRRT_kwDO123"}
+	if !reflect.DeepEqual(args, expected) {
+		t.Errorf("Expected %v, got %v", expected, args)
+	}
+	if !strings.Contains(resolveThreadMutation, "resolveReviewThread") {
+		t.Error("Expected resolve mutation to resolve the thread")
+	}
+
+	args = reviewThreadsArgs("owner", "repo", 42)
+	expected = []string{"api", "graphql", "-f", "query=" + reviewThreadsQuery, "-f", "owner=owner", "-f", "repo=repo", "-F", "number=42"}
+	if !reflect.DeepEqual(args, expected) {
+		t.Errorf("Expected %v, got %v", expected, args)
+	}
+}
+
+func TestParseReviewThreads(t *testing.T) {
+	output := `{"data":{"repository":{"pullRequest":{"reviewThreads":{"nodes":[
+		{"id":"PRRT_1","isResolved":false,"path":"lexer.go","comments":{"nodes":[
+			{"databaseId":101,"body":"Please handle EOF","url":"https://github.com/o/r/pull/1#discussion_r101","createdAt":"2024-05-01T09:00:00Z","author":{"login":"alice"}},
+			{"databaseId":102,"body":"Agreed","url":"https://github.com/o/r/pull/1#discussion_r102","createdAt":"2024-05-01T10:00:00Z","author":{"login":"bob"}}
+		]}},
+		{"id":"PRRT_2","isResolved":true,"path":"parser.go","comments":{"nodes":[
+			{"databaseId":201,"body":"Nit","url":"https://github.com/o/r/pull/1#discussion_r201","createdAt":"2024-05-01T09:30:00Z","author":{"login":"alice"}}
+		]}}
+	]}}}}}`
+
+	threads, err := parseReviewThreads([]byte(output))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(threads) != 2 {
+		t.Fatalf("Expected 2 threads, got %d", len(threads))
+	}
+	if threads[0].ID != "PRRT_1" || threads[0].IsResolved || threads[0].Path != "lexer.go" {
+		t.Errorf("Unexpected first thread: %+v", threads[0])
+	}
+	first := threads[0].Comments[0]
+	if first.ID != 101 || first.User.Login != "alice" || first.Body != "Please handle EOF" || first.HTMLURL != "https://github.com/o/r/pull/1#discussion_r101" {
+		t.Errorf("Unexpected first comment: %+v", first)
+	}
+
+	unresolved := UnresolvedThreadComments(threads)
+	if len(unresolved) != 2 || unresolved[1].ID != 102 {
+		t.Errorf("Expected the 2 comments of the unresolved thread, got %+v", unresolved)
+	}
+
+	if _, err := parseReviewThreads([]byte(`{"errors":[{"message":"Could not resolve to a PullRequest"}]}`)); err == nil || !strings.Contains(err.Error(), "Could not resolve") {
+		t.Errorf("Expected GraphQL error, got %v", err)
+	}
+	if _, err := parseReviewThreads([]byte(`not json`)); err == nil {
+		t.Error("Expected error for invalid output")
+	}
+}
+
+func TestThreadsForComments(t *testing.T) {
+	threads := []ReviewThread{
+		{ID: "PRRT_1", Comments: []types.PRComment{{ID: 101}, {ID: 102}}},
+		{ID: "PRRT_2", IsResolved: true, Comments: []types.PRComment{{ID: 201}}},
+		{ID: "PRRT_3", Comments: []types.PRComment{{ID: 301, HTMLURL: "https://github.com/o/r/pull/1#discussion_r301"}}},
+		{ID: "PRRT_4", Comments: []types.PRComment{{ID: 401}}},
+	}
+	addressed := []types.ActionableComment{
+		{Comment: types.PRComment{ID: 101}},
+		{Comment: types.PRComment{ID: 102}},
+		{Comment: types.PRComment{ID: 201}},
+		{Comment: types.PRComment{HTMLURL: "https://github.com/o/r/pull/1#discussion_r301"}},
+		{Comment: types.PRComment{ID: 999}},
+	}
+
+	var ids []string
+	for _, thread := range ThreadsForComments(threads, addressed) {
+		ids = append(ids, thread.ID)
+	}
+	expected := []string{"PRRT_1", "PRRT_3"}
+	if !reflect.DeepEqual(ids, expected) {
+		t.Errorf("Expected threads %v, got %v", expected, ids)
+	}
+}
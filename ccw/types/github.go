@@ -15,10 +15,48 @@ type Issue struct {
 	HTMLURL    string                 `json:"html_url"`
 	Labels     []Label                `json:"labels"`
 	Assignees  []User                 `json:"assignees"`
+	Milestone  *Milestone             `json:"milestone"`
+	Comments   []IssueComment         `json:"recent_comments,omitempty"` // Most recent comments, oldest first; only filled by GraphQL fetches
 	CreatedAt  time.Time              `json:"created_at"`
 	UpdatedAt  time.Time              `json:"updated_at"`
 	Repository Repository             `json:"repository"`
 	Metadata   map[string]interface{} `json:"metadata"`
+	Reactions  *Reactions             `json:"reactions,omitempty"` // Reaction counts; filled by REST fetches
+}
+
+// Reactions holds the reaction counts GitHub rolls up on an issue
+type Reactions struct {
+	TotalCount int `json:"total_count"`
+	ThumbsUp   int `json:"+1"`
+	ThumbsDown int `json:"-1"`
+	Laugh      int `json:"laugh"`
+	Hooray     int `json:"hooray"`
+	Confused   int `json:"confused"`
+	Heart      int `json:"heart"`
+	Rocket     int `json:"rocket"`
+	Eyes       int `json:"eyes"`
+}
+
+// ThumbsUp returns the issue's 👍 count, or 0 when reactions were not fetched
+func (i *Issue) ThumbsUp() int {
+	if i.Reactions == nil {
+		return 0
+	}
+	return i.Reactions.ThumbsUp
+}
+
+type Milestone struct {
+	Number int        `json:"number"`
+	Title  string     `json:"title"`
+	State  string     `json:"state"`
+	DueOn  *time.Time `json:"due_on"`
+}
+
+type IssueComment struct {
+	Author    User      `json:"author"`
+	Body      string    `json:"body"`
+	CreatedAt time.Time `json:"created_at"`
+	URL       string    `json:"url"`
 }
 
 type Label struct {
@@ -42,7 +80,11 @@ type PRRequest struct {
 	Body                string `json:"body"`
 	Head                string `json:"head"`
 	Base                string `json:"base"`
+	Repo                string `json:"repo,omitempty"` // Base repository as owner/repo; gh infers it when empty
 	MaintainerCanModify bool   `json:"maintainer_can_modify"`
+
+	Reviewers []string `json:"reviewers,omitempty"` // Users or org/team slugs asked to review
+	Assignees []string `json:"assignees,omitempty"` // Users assigned to the PR; "@me" is the gh user
 }
 
 type PullRequest struct {
@@ -135,6 +177,7 @@ type ActionableComment struct {
 	Priority    CommentPriority
 	Actionable  bool
 	Suggestion  string
+	Guidance    string // Reviewer instructions added during --interactive-review
 }
 
 type CommentCategory string
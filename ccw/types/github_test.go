@@ -0,0 +1,62 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestIssueReactionsDecoding(t *testing.T) {
+	data := `{
+		"number": 12,
+		"title": "Support dark mode",
+		"reactions": {
+			"url": "https://api.github.com/repos/o/r/issues/12/reactions",
+			"total_count": 9,
+			"+1": 6,
+			"-1": 1,
+			"laugh": 0,
+			"hooray": 1,
+			"confused": 0,
+			"heart": 1,
+			"rocket": 0,
+			"eyes": 0
+		}
+	}`
+
+	var issue Issue
+	if err := json.Unmarshal([]byte(data), &issue); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if issue.Reactions == nil {
+		t.Fatal("Expected reactions to be decoded")
+	}
+	if issue.Reactions.TotalCount != 9 || issue.Reactions.ThumbsDown != 1 || issue.Reactions.Heart != 1 {
+		t.Errorf("Unexpected reaction counts: %+v", *issue.Reactions)
+	}
+	if issue.ThumbsUp() != 6 {
+		t.Errorf("Expected 6 thumbs up, got %d", issue.ThumbsUp())
+	}
+}
+
+func TestIssueThumbsUpWithoutReactions(t *testing.T) {
+	var issue Issue
+	if err := json.Unmarshal([]byte(`{"number": 3}`), &issue); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if issue.ThumbsUp() != 0 {
+		t.Errorf("Expected 0 thumbs up, got %d", issue.ThumbsUp())
+	}
+
+	// Issues without reactions keep the field out of JSON output
+	data, err := json.Marshal(&issue)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	var decoded map[string]interface{}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, ok := decoded["reactions"]; ok {
+		t.Error("Expected reactions to be omitted")
+	}
+}
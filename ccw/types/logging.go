@@ -33,6 +33,7 @@ type LogEntry struct {
 	Message   string                 `json:"message"`
 	SessionID string                 `json:"session_id"`
 	Component string                 `json:"component"`
+	Phase     string                 `json:"phase,omitempty"` // Workflow phase active when the entry was logged
 	Context   map[string]interface{} `json:"context,omitempty"`
 }
 
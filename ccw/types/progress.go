@@ -0,0 +1,105 @@
+package types
+
+import "time"
+
+// Workflow step status updates and substep roll-up
+
+// SetStatus updates the step status and records start and end times
+func (s *WorkflowStep) SetStatus(status string) {
+	s.Status = status
+	switch status {
+	case "in_progress":
+		s.StartTime = time.Now()
+	case "completed", "failed":
+		s.EndTime = time.Now()
+	}
+}
+
+// UpdateSubstep sets the status of the substep with substepID and rolls the
+// result up to this step. It returns false if no such substep exists.
+func (s *WorkflowStep) UpdateSubstep(substepID, status string) bool {
+	for i := range s.Substeps {
+		if s.Substeps[i].ID == substepID {
+			s.Substeps[i].SetStatus(status)
+			s.rollUpStatus()
+			return true
+		}
+	}
+	return false
+}
+
+// rollUpStatus derives the step status from its substeps: any failure fails the
+// step, all completed completes it, and any started substep marks it in progress
+func (s *WorkflowStep) rollUpStatus() {
+	if len(s.Substeps) == 0 {
+		return
+	}
+
+	completed, started := 0, 0
+	for _, substep := range s.Substeps {
+		switch substep.Status {
+		case "failed":
+			if s.Status != "failed" {
+				s.SetStatus("failed")
+			}
+			return
+		case "completed":
+			completed++
+			started++
+		case "in_progress":
+			started++
+		}
+	}
+
+	switch {
+	case completed == len(s.Substeps):
+		if s.Status != "completed" {
+			s.SetStatus("completed")
+		}
+	case started > 0 && s.Status != "in_progress":
+		s.SetStatus("in_progress")
+	}
+}
+
+// Progress returns how much of the step is done, from 0 to 1. Steps with
+// substeps report the weighted progress of their substeps.
+func (s *WorkflowStep) Progress() float64 {
+	if len(s.Substeps) == 0 {
+		if s.Status == "completed" {
+			return 1
+		}
+		return 0
+	}
+	if s.Status == "completed" {
+		return 1
+	}
+
+	totalWeight, done := 0.0, 0.0
+	for i := range s.Substeps {
+		weight := s.Substeps[i].Weight
+		if weight <= 0 {
+			weight = 1
+		}
+		totalWeight += weight
+		done += weight * s.Substeps[i].Progress()
+	}
+	return done / totalWeight
+}
+
+// UpdateSubstep sets a substep status within the step with stepID, making the
+// step current when it starts. It returns false if the step or substep is unknown.
+func (pt *ProgressTracker) UpdateSubstep(stepID, substepID, status string) bool {
+	for i := range pt.Steps {
+		if pt.Steps[i].ID != stepID {
+			continue
+		}
+		if !pt.Steps[i].UpdateSubstep(substepID, status) {
+			return false
+		}
+		if pt.Steps[i].Status == "in_progress" {
+			pt.CurrentStep = i
+		}
+		return true
+	}
+	return false
+}
@@ -0,0 +1,149 @@
+package types
+
+import (
+	"math"
+	"testing"
+)
+
+func newValidationStep() WorkflowStep {
+	return WorkflowStep{
+		ID:     "validation",
+		Status: "pending",
+		Substeps: []WorkflowStep{
+			{ID: "lint", Status: "pending", Weight: 1},
+			{ID: "build", Status: "pending", Weight: 2},
+			{ID: "test", Status: "pending", Weight: 3},
+		},
+	}
+}
+
+func TestWorkflowStepUpdateSubstep(t *testing.T) {
+	tests := []struct {
+		name           string
+		updates        [][2]string // substep ID, status
+		expectedParent string
+		expectedLint   string
+	}{
+		{"no updates", nil, "pending", "pending"},
+		{"first substep starts", [][2]string{{"lint", "in_progress"}}, "in_progress", "in_progress"},
+		{"partial completion", [][2]string{{"lint", "completed"}, {"build", "in_progress"}}, "in_progress", "completed"},
+		{"all completed", [][2]string{{"lint", "completed"}, {"build", "completed"}, {"test", "completed"}}, "completed", "completed"},
+		{"failure wins", [][2]string{{"lint", "completed"}, {"build", "failed"}, {"test", "completed"}}, "failed", "completed"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			step := newValidationStep()
+			for _, update := range tt.updates {
+				if !step.UpdateSubstep(update[0], update[1]) {
+					t.Fatalf("Expected substep %s to exist", update[0])
+				}
+			}
+
+			if step.Status != tt.expectedParent {
+				t.Errorf("Expected parent status '%s', got '%s'", tt.expectedParent, step.Status)
+			}
+			if step.Substeps[0].Status != tt.expectedLint {
+				t.Errorf("Expected lint status '%s', got '%s'", tt.expectedLint, step.Substeps[0].Status)
+			}
+		})
+	}
+}
+
+func TestWorkflowStepUpdateSubstepTimes(t *testing.T) {
+	step := newValidationStep()
+
+	step.UpdateSubstep("build", "in_progress")
+	if step.Substeps[1].StartTime.IsZero() || step.StartTime.IsZero() {
+		t.Error("Expected start times on substep and parent")
+	}
+
+	step.UpdateSubstep("build", "failed")
+	if step.Substeps[1].EndTime.IsZero() || step.EndTime.IsZero() {
+		t.Error("Expected end times on substep and parent")
+	}
+}
+
+func TestWorkflowStepUpdateUnknownSubstep(t *testing.T) {
+	step := newValidationStep()
+	if step.UpdateSubstep("deploy", "completed") {
+		t.Error("Expected unknown substep to be rejected")
+	}
+	if step.Status != "pending" {
+		t.Errorf("Expected parent to stay pending, got '%s'", step.Status)
+	}
+}
+
+func TestWorkflowStepProgress(t *testing.T) {
+	tests := []struct {
+		name      string
+		completed []string
+		expected  float64
+	}{
+		{"nothing done", nil, 0},
+		{"lint done", []string{"lint"}, 1.0 / 6},
+		{"lint and build done", []string{"lint", "build"}, 3.0 / 6},
+		{"test done", []string{"test"}, 3.0 / 6},
+		{"all done", []string{"lint", "build", "test"}, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			step := newValidationStep()
+			for _, id := range tt.completed {
+				step.UpdateSubstep(id, "completed")
+			}
+
+			if got := step.Progress(); math.Abs(got-tt.expected) > 1e-9 {
+				t.Errorf("Expected progress %.3f, got %.3f", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestWorkflowStepProgressDefaultWeights(t *testing.T) {
+	step := WorkflowStep{
+		ID: "ci",
+		Substeps: []WorkflowStep{
+			{ID: "lint", Status: "completed"},
+			{ID: "unit", Status: "in_progress"},
+			{ID: "ui", Status: "pending"},
+			{ID: "docs", Status: "completed"},
+		},
+	}
+
+	if got := step.Progress(); got != 0.5 {
+		t.Errorf("Expected equal weights to give 0.5, got %.3f", got)
+	}
+
+	leaf := WorkflowStep{ID: "commit", Status: "completed"}
+	if leaf.Progress() != 1 {
+		t.Errorf("Expected completed leaf step progress 1, got %.3f", leaf.Progress())
+	}
+}
+
+func TestProgressTrackerUpdateSubstep(t *testing.T) {
+	tracker := &ProgressTracker{
+		Steps: []WorkflowStep{
+			{ID: "setup", Status: "completed"},
+			newValidationStep(),
+		},
+	}
+
+	if !tracker.UpdateSubstep("validation", "lint", "in_progress") {
+		t.Fatal("Expected substep update to succeed")
+	}
+	if tracker.CurrentStep != 1 {
+		t.Errorf("Expected current step 1, got %d", tracker.CurrentStep)
+	}
+	if tracker.Steps[1].Status != "in_progress" {
+		t.Errorf("Expected validation in progress, got '%s'", tracker.Steps[1].Status)
+	}
+
+	if tracker.UpdateSubstep("setup", "lint", "completed") {
+		t.Error("Expected update of a step without that substep to fail")
+	}
+	if tracker.UpdateSubstep("missing", "lint", "completed") {
+		t.Error("Expected update of an unknown step to fail")
+	}
+}
@@ -3,19 +3,21 @@ package types
 import (
 	"fmt"
 	"os/exec"
+	"sort"
 	"time"
 )
 
 // Validation result models
 
 type ValidationResult struct {
-	Success     bool              `json:"success"`
-	LintResult  *LintResult       `json:"lint_result,omitempty"`
-	BuildResult *BuildResult      `json:"build_result,omitempty"`
-	TestResult  *TestResult       `json:"test_result,omitempty"`
-	Errors      []ValidationError `json:"errors,omitempty"`
-	Duration    time.Duration     `json:"duration"`
-	Timestamp   time.Time         `json:"timestamp"`
+	Success     bool                `json:"success"`
+	LintResult  *LintResult         `json:"lint_result,omitempty"`
+	BuildResult *BuildResult        `json:"build_result,omitempty"`
+	TestResult  *TestResult         `json:"test_result,omitempty"`
+	Errors      []ValidationError   `json:"errors,omitempty"`
+	Warnings    []ValidationWarning `json:"warnings,omitempty"` // Non-blocking warnings from every tool
+	Duration    time.Duration       `json:"duration"`
+	Timestamp   time.Time           `json:"timestamp"`
 }
 
 type LintResult struct {
@@ -27,9 +29,10 @@ type LintResult struct {
 }
 
 type BuildResult struct {
-	Success bool   `json:"success"`
-	Output  string `json:"output"`
-	Error   string `json:"error"`
+	Success  bool     `json:"success"`
+	Output   string   `json:"output"`
+	Error    string   `json:"error"`
+	Warnings []string `json:"warnings,omitempty"`
 }
 
 type TestResult struct {
@@ -38,6 +41,8 @@ type TestResult struct {
 	TestCount int    `json:"test_count"`
 	Passed    int    `json:"passed"`
 	Failed    int    `json:"failed"`
+
+	FailedTests []string `json:"failed_tests,omitempty"` // Names of the failing test cases
 }
 
 type ValidationError struct {
@@ -47,6 +52,80 @@ type ValidationError struct {
 	Line        int         `json:"line,omitempty"`
 	Recoverable bool        `json:"recoverable"`
 	Cause       *ErrorCause `json:"cause,omitempty"`
+	Count       int         `json:"count,omitempty"` // Occurrences merged by DedupeErrors
+}
+
+// Occurrences returns how many identical errors this entry represents
+func (ve ValidationError) Occurrences() int {
+	if ve.Count < 1 {
+		return 1
+	}
+	return ve.Count
+}
+
+// validationErrorKey identifies identical validation errors
+type validationErrorKey struct {
+	errorType string
+	file      string
+	line      int
+	message   string
+}
+
+// DedupeErrors merges errors with the same type, file, line and message, keeping
+// the first occurrence in its original position and summing their counts
+func DedupeErrors(errors []ValidationError) []ValidationError {
+	if len(errors) == 0 {
+		return errors
+	}
+
+	deduped := make([]ValidationError, 0, len(errors))
+	positions := make(map[validationErrorKey]int, len(errors))
+
+	for _, validationErr := range errors {
+		key := errorKey(validationErr)
+		if index, exists := positions[key]; exists {
+			deduped[index].Count = deduped[index].Occurrences() + validationErr.Occurrences()
+			continue
+		}
+
+		validationErr.Count = validationErr.Occurrences()
+		positions[key] = len(deduped)
+		deduped = append(deduped, validationErr)
+	}
+
+	return deduped
+}
+
+// ValidationErrorGroup holds the errors of one type
+type ValidationErrorGroup struct {
+	Type   string
+	Errors []ValidationError
+}
+
+// GroupErrorsByType groups errors by type for rendering. Groups are sorted by
+// type and errors within a group by file, line and message, so the same input
+// always renders the same way.
+func GroupErrorsByType(errors []ValidationError) []ValidationErrorGroup {
+	byType := make(map[string][]ValidationError)
+	for _, validationErr := range errors {
+		byType[validationErr.Type] = append(byType[validationErr.Type], validationErr)
+	}
+
+	groups := make([]ValidationErrorGroup, 0, len(byType))
+	for errorType, errs := range byType {
+		sort.SliceStable(errs, func(i, j int) bool {
+			if errs[i].File != errs[j].File {
+				return errs[i].File < errs[j].File
+			}
+			if errs[i].Line != errs[j].Line {
+				return errs[i].Line < errs[j].Line
+			}
+			return errs[i].Message < errs[j].Message
+		})
+		groups = append(groups, ValidationErrorGroup{Type: errorType, Errors: errs})
+	}
+	sort.Slice(groups, func(i, j int) bool { return groups[i].Type < groups[j].Type })
+	return groups
 }
 
 type ErrorCause struct {
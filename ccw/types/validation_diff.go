@@ -0,0 +1,73 @@
+package types
+
+import "fmt"
+
+// Comparing the errors of two validation runs
+
+// ErrorSetDiff is how the errors changed between two validation runs. Errors
+// are matched by type, file, line and message, so an error that only moved to
+// another line counts as resolved and introduced.
+type ErrorSetDiff struct {
+	Resolved   []ValidationError `json:"resolved,omitempty"`   // Failed before, gone after
+	Introduced []ValidationError `json:"introduced,omitempty"` // New after
+	Remaining  []ValidationError `json:"remaining,omitempty"`  // Failed both times
+}
+
+// DiffValidationErrors compares the errors of before and after. Each list is
+// deduplicated and keeps the order the errors were reported in; a nil result
+// counts as having no errors.
+func DiffValidationErrors(before, after *ValidationResult) ErrorSetDiff {
+	var beforeErrors, afterErrors []ValidationError
+	if before != nil {
+		beforeErrors = DedupeErrors(before.Errors)
+	}
+	if after != nil {
+		afterErrors = DedupeErrors(after.Errors)
+	}
+
+	inAfter := make(map[validationErrorKey]bool, len(afterErrors))
+	for _, validationErr := range afterErrors {
+		inAfter[errorKey(validationErr)] = true
+	}
+	inBefore := make(map[validationErrorKey]bool, len(beforeErrors))
+
+	var diff ErrorSetDiff
+	for _, validationErr := range beforeErrors {
+		key := errorKey(validationErr)
+		inBefore[key] = true
+		if inAfter[key] {
+			diff.Remaining = append(diff.Remaining, validationErr)
+		} else {
+			diff.Resolved = append(diff.Resolved, validationErr)
+		}
+	}
+	for _, validationErr := range afterErrors {
+		if !inBefore[errorKey(validationErr)] {
+			diff.Introduced = append(diff.Introduced, validationErr)
+		}
+	}
+	return diff
+}
+
+// String summarizes the diff as counts, e.g. "2 resolved, 1 introduced, 3 remaining"
+func (d ErrorSetDiff) String() string {
+	return fmt.Sprintf("%d resolved, %d introduced, %d remaining", len(d.Resolved), len(d.Introduced), len(d.Remaining))
+}
+
+// errorKey identifies err for deduplication and diffing
+func errorKey(err ValidationError) validationErrorKey {
+	return validationErrorKey{err.Type, err.File, err.Line, err.Message}
+}
+
+// DescribeValidationError renders err as "type: file:line: message", leaving out the
+// location parts that are unknown
+func DescribeValidationError(err ValidationError) string {
+	switch {
+	case err.File != "" && err.Line > 0:
+		return fmt.Sprintf("%s: %s:%d: %s", err.Type, err.File, err.Line, err.Message)
+	case err.File != "":
+		return fmt.Sprintf("%s: %s: %s", err.Type, err.File, err.Message)
+	default:
+		return fmt.Sprintf("%s: %s", err.Type, err.Message)
+	}
+}
@@ -0,0 +1,85 @@
+package types
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiffValidationErrors(t *testing.T) {
+	unused := ValidationError{Type: "lint", File: "a.swift", Line: 3, Message: "unused variable"}
+	missing := ValidationError{Type: "build", File: "b.swift", Line: 10, Message: "cannot find 'x' in scope"}
+	failing := ValidationError{Type: "test", Message: "testLexer failed"}
+	moved := ValidationError{Type: "lint", File: "a.swift", Line: 4, Message: "unused variable"}
+	newErr := ValidationError{Type: "build", File: "c.swift", Line: 1, Message: "type mismatch"}
+
+	describe := func(errors []ValidationError) []string {
+		var described []string
+		for _, err := range errors {
+			described = append(described, DescribeValidationError(err))
+		}
+		return described
+	}
+
+	tests := []struct {
+		name       string
+		before     *ValidationResult
+		after      *ValidationResult
+		resolved   []string
+		introduced []string
+		remaining  []string
+	}{
+		{
+			name:       "mixed changes",
+			before:     &ValidationResult{Errors: []ValidationError{unused, missing, failing}},
+			after:      &ValidationResult{Errors: []ValidationError{failing, newErr}},
+			resolved:   []string{"lint: a.swift:3: unused variable", "build: b.swift:10: cannot find 'x' in scope"},
+			introduced: []string{"build: c.swift:1: type mismatch"},
+			remaining:  []string{"test: testLexer failed"},
+		},
+		{
+			name:     "all fixed",
+			before:   &ValidationResult{Errors: []ValidationError{unused, failing}},
+			after:    &ValidationResult{Success: true},
+			resolved: []string{"lint: a.swift:3: unused variable", "test: testLexer failed"},
+		},
+		{
+			name:       "moved line counts as resolved and introduced",
+			before:     &ValidationResult{Errors: []ValidationError{unused}},
+			after:      &ValidationResult{Errors: []ValidationError{moved}},
+			resolved:   []string{"lint: a.swift:3: unused variable"},
+			introduced: []string{"lint: a.swift:4: unused variable"},
+		},
+		{
+			name:      "duplicates compared once",
+			before:    &ValidationResult{Errors: []ValidationError{failing, failing}},
+			after:     &ValidationResult{Errors: []ValidationError{failing}},
+			remaining: []string{"test: testLexer failed"},
+		},
+		{
+			name:       "nil before",
+			before:     nil,
+			after:      &ValidationResult{Errors: []ValidationError{missing}},
+			introduced: []string{"build: b.swift:10: cannot find 'x' in scope"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diff := DiffValidationErrors(tt.before, tt.after)
+			if got := describe(diff.Resolved); !reflect.DeepEqual(got, tt.resolved) {
+				t.Errorf("Expected resolved %v, got %v", tt.resolved, got)
+			}
+			if got := describe(diff.Introduced); !reflect.DeepEqual(got, tt.introduced) {
+				t.Errorf("Expected introduced %v, got %v", tt.introduced, got)
+			}
+			if got := describe(diff.Remaining); !reflect.DeepEqual(got, tt.remaining) {
+				t.Errorf("Expected remaining %v, got %v", tt.remaining, got)
+			}
+		})
+	}
+
+	diff := DiffValidationErrors(&ValidationResult{Errors: []ValidationError{unused, missing, failing}}, &ValidationResult{Errors: []ValidationError{failing, newErr}})
+	if diff.String() != "2 resolved, 1 introduced, 1 remaining" {
+		t.Errorf("Expected '2 resolved, 1 introduced, 1 remaining', got '%s'", diff.String())
+	}
+}
@@ -0,0 +1,119 @@
+package types
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestDedupeErrors(t *testing.T) {
+	errors := []ValidationError{
+		{Type: "build", File: "Sources/Parser.swift", Line: 10, Message: "cannot find 'token' in scope"},
+		{Type: "lint", File: "Sources/Lexer.swift", Line: 3, Message: "line too long"},
+		{Type: "build", File: "Sources/Parser.swift", Line: 10, Message: "cannot find 'token' in scope"},
+		{Type: "build", File: "Sources/Parser.swift", Line: 11, Message: "cannot find 'token' in scope"},
+		{Type: "lint", File: "Sources/Lexer.swift", Line: 3, Message: "line too long", Count: 2},
+		{Type: "build", File: "Sources/Parser.swift", Line: 10, Message: "cannot find 'token' in scope"},
+		{Type: "test", Message: "testParse failed"},
+	}
+
+	deduped := DedupeErrors(errors)
+
+	expected := []struct {
+		errorType string
+		line      int
+		count     int
+	}{
+		{"build", 10, 3},
+		{"lint", 3, 3},
+		{"build", 11, 1},
+		{"test", 0, 1},
+	}
+
+	if len(deduped) != len(expected) {
+		t.Fatalf("Expected %d errors, got %d: %+v", len(expected), len(deduped), deduped)
+	}
+	for i, want := range expected {
+		got := deduped[i]
+		if got.Type != want.errorType || got.Line != want.line {
+			t.Errorf("Error %d: expected %s line %d, got %s line %d", i, want.errorType, want.line, got.Type, got.Line)
+		}
+		if got.Count != want.count {
+			t.Errorf("Error %d: expected count %d, got %d", i, want.count, got.Count)
+		}
+	}
+
+	// The input slice must not be modified
+	if errors[0].Count != 0 {
+		t.Errorf("Expected input to be unchanged, got count %d", errors[0].Count)
+	}
+}
+
+func TestDedupeErrorsDistinguishesFields(t *testing.T) {
+	errors := []ValidationError{
+		{Type: "build", File: "a.swift", Line: 1, Message: "error"},
+		{Type: "lint", File: "a.swift", Line: 1, Message: "error"},
+		{Type: "build", File: "b.swift", Line: 1, Message: "error"},
+		{Type: "build", File: "a.swift", Line: 2, Message: "error"},
+		{Type: "build", File: "a.swift", Line: 1, Message: "other error"},
+	}
+
+	deduped := DedupeErrors(errors)
+	if len(deduped) != len(errors) {
+		t.Errorf("Expected %d distinct errors, got %d", len(errors), len(deduped))
+	}
+}
+
+func TestDedupeErrorsEmpty(t *testing.T) {
+	if deduped := DedupeErrors(nil); len(deduped) != 0 {
+		t.Errorf("Expected no errors, got %d", len(deduped))
+	}
+}
+
+func TestValidationErrorOccurrences(t *testing.T) {
+	tests := []struct {
+		count    int
+		expected int
+	}{
+		{0, 1},
+		{1, 1},
+		{4, 4},
+	}
+
+	for _, tt := range tests {
+		if got := (ValidationError{Count: tt.count}).Occurrences(); got != tt.expected {
+			t.Errorf("Count %d: expected %d occurrences, got %d", tt.count, tt.expected, got)
+		}
+	}
+}
+
+func TestGroupErrorsByType(t *testing.T) {
+	errors := []ValidationError{
+		{Type: "test", File: "b_test.go", Line: 3, Message: "fail"},
+		{Type: "build", File: "main.go", Line: 20, Message: "undefined: y"},
+		{Type: "lint", Message: "no file"},
+		{Type: "build", File: "main.go", Line: 4, Message: "undefined: x"},
+		{Type: "build", File: "a.go", Line: 9, Message: "syntax error"},
+		{Type: "test", File: "a_test.go", Line: 8, Message: "fail"},
+	}
+
+	groups := GroupErrorsByType(errors)
+
+	var got []string
+	for _, group := range groups {
+		for _, err := range group.Errors {
+			got = append(got, fmt.Sprintf("%s %s:%d", group.Type, err.File, err.Line))
+		}
+	}
+	expected := []string{
+		"build a.go:9", "build main.go:4", "build main.go:20",
+		"lint :0",
+		"test a_test.go:8", "test b_test.go:3",
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+	if errors[0].File != "b_test.go" {
+		t.Error("Expected the input slice to be left in its original order")
+	}
+}
@@ -0,0 +1,86 @@
+package types
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Non-blocking validation warnings
+
+// ValidationWarning is a warning reported by a validation tool. Warnings never
+// make validation fail.
+type ValidationWarning struct {
+	Type    string `json:"type"` // Tool that reported it: "lint" or "build"
+	Message string `json:"message"`
+	File    string `json:"file,omitempty"`
+	Line    int    `json:"line,omitempty"`
+}
+
+// String renders the warning as "file:line: message", or the message alone
+func (w ValidationWarning) String() string {
+	switch {
+	case w.File != "" && w.Line > 0:
+		return fmt.Sprintf("%s:%d: %s", w.File, w.Line, w.Message)
+	case w.File != "":
+		return fmt.Sprintf("%s: %s", w.File, w.Message)
+	default:
+		return w.Message
+	}
+}
+
+// toolWarningPattern matches "path:line[:column]: warning: message" lines
+// printed by SwiftLint and the Swift compiler
+var toolWarningPattern = regexp.MustCompile(`^(.+?):(\d+)(?::\d+)?: warning: (.+)$`)
+
+// ParseToolWarning turns one tool output line into a warning, keeping the
+// line as the message when it has no location
+func ParseToolWarning(tool, line string) ValidationWarning {
+	line = strings.TrimSpace(line)
+	if match := toolWarningPattern.FindStringSubmatch(line); match != nil {
+		lineNumber, _ := strconv.Atoi(match[2])
+		return ValidationWarning{Type: tool, File: match[1], Line: lineNumber, Message: strings.TrimSpace(match[3])}
+	}
+	if _, message, found := strings.Cut(line, "warning:"); found {
+		line = strings.TrimSpace(message)
+	}
+	return ValidationWarning{Type: tool, Message: line}
+}
+
+// CollectWarnings aggregates the warning lines each tool reported, keyed by
+// tool, in lint-then-build order with duplicates removed
+func CollectWarnings(lintWarnings, buildWarnings []string) []ValidationWarning {
+	var warnings []ValidationWarning
+	seen := make(map[ValidationWarning]bool)
+	for _, source := range []struct {
+		tool  string
+		lines []string
+	}{{"lint", lintWarnings}, {"build", buildWarnings}} {
+		for _, line := range source.lines {
+			if strings.TrimSpace(line) == "" {
+				continue
+			}
+			warning := ParseToolWarning(source.tool, line)
+			if !seen[warning] {
+				seen[warning] = true
+				warnings = append(warnings, warning)
+			}
+		}
+	}
+	return warnings
+}
+
+// FormatWarningSummary lists up to limit warnings, one per line prefixed with
+// indent, noting how many more were left out. limit <= 0 lists all of them.
+func FormatWarningSummary(warnings []ValidationWarning, limit int, indent string) string {
+	var b strings.Builder
+	for i, warning := range warnings {
+		if limit > 0 && i == limit {
+			b.WriteString(fmt.Sprintf("%s... and %d more\n", indent, len(warnings)-limit))
+			break
+		}
+		b.WriteString(fmt.Sprintf("%s%s (%s)\n", indent, warning, warning.Type))
+	}
+	return b.String()
+}
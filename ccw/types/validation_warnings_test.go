@@ -0,0 +1,63 @@
+package types
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseToolWarning(t *testing.T) {
+	tests := []struct {
+		name     string
+		line     string
+		expected ValidationWarning
+	}{
+		{"file line and column", "Sources/A.swift:12:5: warning: Unused variable", ValidationWarning{Type: "lint", File: "Sources/A.swift", Line: 12, Message: "Unused variable"}},
+		{"file and line", "Sources/A.swift:7: warning: Trailing whitespace", ValidationWarning{Type: "lint", File: "Sources/A.swift", Line: 7, Message: "Trailing whitespace"}},
+		{"no location", "  warning: deprecated flag  ", ValidationWarning{Type: "lint", Message: "deprecated flag"}},
+		{"plain line", "something odd", ValidationWarning{Type: "lint", Message: "something odd"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ParseToolWarning("lint", tt.line)
+			if result != tt.expected {
+				t.Errorf("Expected %+v, got %+v", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestCollectWarnings(t *testing.T) {
+	warnings := CollectWarnings(
+		[]string{"A.swift:1:1: warning: first", "", "A.swift:1:1: warning: first"},
+		[]string{"B.swift:2:3: warning: second", "A.swift:1:1: warning: first"},
+	)
+
+	expected := []string{"lint A.swift:1: first", "build B.swift:2: second", "build A.swift:1: first"}
+	if len(warnings) != len(expected) {
+		t.Fatalf("Expected %d warnings, got %+v", len(expected), warnings)
+	}
+	for i, warning := range warnings {
+		if got := warning.Type + " " + warning.String(); got != expected[i] {
+			t.Errorf("Expected '%s', got '%s'", expected[i], got)
+		}
+	}
+}
+
+func TestFormatWarningSummary(t *testing.T) {
+	warnings := []ValidationWarning{
+		{Type: "lint", File: "A.swift", Line: 1, Message: "one"},
+		{Type: "build", File: "B.swift", Message: "two"},
+		{Type: "build", Message: "three"},
+	}
+
+	summary := FormatWarningSummary(warnings, 2, "  ")
+	expected := "  A.swift:1: one (lint)\n  B.swift: two (build)\n  ... and 1 more\n"
+	if summary != expected {
+		t.Errorf("Expected %q, got %q", expected, summary)
+	}
+
+	if all := FormatWarningSummary(warnings, 0, ""); strings.Count(all, "\n") != 3 || strings.Contains(all, "more") {
+		t.Errorf("Expected all warnings without a limit, got %q", all)
+	}
+}
@@ -35,6 +35,7 @@ type PRDescriptionRequest struct {
 	WorktreeConfig        *WorktreeConfig   `json:"worktree_config"`
 	ValidationResult      *ValidationResult `json:"validation_result"`
 	ImplementationSummary string            `json:"implementation_summary"`
+	TemplateBody          string            `json:"template_body,omitempty"`
 }
 
 type Config struct {
@@ -57,6 +58,8 @@ type WorkflowStep struct {
 	Status      string `json:"status"` // "pending", "in_progress", "completed", "failed"
 	StartTime   time.Time
 	EndTime     time.Time
+	Weight      float64        `json:"weight,omitempty"`   // Share of the parent step's progress (default 1)
+	Substeps    []WorkflowStep `json:"substeps,omitempty"` // Nested steps whose status rolls up to this step
 }
 
 type ProgressTracker struct {
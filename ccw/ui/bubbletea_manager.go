@@ -111,6 +111,20 @@ func (btm *BubbleTeaManager) UpdateProgress(stepID, status string) {
 	}
 }
 
+// UpdateSubstepProgress sends a substep progress update to the running program
+func (btm *BubbleTeaManager) UpdateSubstepProgress(stepID, substepID, status string) {
+	if btm.program != nil {
+		btm.program.Send(ProgressUpdateMsg{StepID: stepID, SubstepID: substepID, Status: status})
+	}
+}
+
+// ShowToast displays a transient notification in the running program
+func (btm *BubbleTeaManager) ShowToast(text string, level ToastLevel) {
+	if btm.program != nil {
+		btm.program.Send(ToastMsg{Text: text, Level: level})
+	}
+}
+
 // CompleteProgress signals that the progress is complete
 func (btm *BubbleTeaManager) CompleteProgress() {
 	if btm.program != nil {
@@ -5,6 +5,8 @@ import (
 	"strings"
 	"time"
 
+	"ccw/config"
+	"ccw/history"
 	"ccw/types"
 	"github.com/charmbracelet/bubbles/list"
 	"github.com/charmbracelet/bubbles/progress"
@@ -24,6 +26,7 @@ const (
 	StateLogViewer
 	StateDoctorCheck
 	StateCompleted
+	StateSettings
 )
 
 // Main application model
@@ -34,6 +37,8 @@ type AppModel struct {
 	progressTracker ProgressModel
 	logViewer       LogViewerModel
 	doctorModel     DoctorModel
+	settings        SettingsModel
+	toasts          ToastQueue
 	windowSize      tea.WindowSizeMsg
 	ui              *UIManager
 	showLogs        bool
@@ -75,7 +80,11 @@ func (i IssueItem) Description() string {
 	for i, label := range i.issue.Labels {
 		labels[i] = label.Name
 	}
-	return fmt.Sprintf("State: %s | Labels: %s", i.issue.State, strings.Join(labels, ", "))
+	description := fmt.Sprintf("State: %s | Labels: %s", i.issue.State, strings.Join(labels, ", "))
+	if opened := issueOpenedLabel(i.issue.CreatedAt); opened != "" {
+		description += " | " + opened
+	}
+	return description
 }
 
 // Styles with improved visibility and contrast
@@ -143,6 +152,7 @@ func NewAppModel(ui *UIManager) AppModel {
 			"View Repository Issues",
 			"Start Workflow",
 			"Doctor (System Diagnostics)",
+			"Settings",
 			"Exit",
 		},
 	}
@@ -189,7 +199,11 @@ func NewAppModel(ui *UIManager) AppModel {
 	}
 
 	// Initialize log buffer and viewer
-	InitLogBuffer(1000) // Keep last 1000 log entries
+	if ui != nil {
+		InitLogBuffer(ui.logBufferSize)
+	} else {
+		InitLogBuffer(defaultLogBufferSize)
+	}
 	logViewer := NewLogViewerModel(80, 20, GetLogBuffer())
 
 	// Initialize doctor model
@@ -203,7 +217,7 @@ func NewAppModel(ui *UIManager) AppModel {
 		logViewer:       logViewer,
 		doctorModel:     doctorModel,
 		ui:              ui,
-		showLogs:        true,
+		showLogs:        ui == nil || !ui.hideLogsPanel,
 		logsPanelWidth:  40, // 40% of screen width for logs
 	}
 }
@@ -236,6 +250,27 @@ func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// Return to main menu from any sub-state
 		m.state = StateMainMenu
 
+	case OpenSettingsMsg:
+		ccwConfig, _ := config.LoadConfiguration()
+		m.settings = NewSettingsModel(ccwConfig.UI, config.ConfigFilePath())
+		m.state = StateSettings
+
+	case ToastMsg:
+		id, duration := m.toasts.Push(msg, time.Now())
+		return m, scheduleToastExpiry(id, duration)
+
+	case toastExpiredMsg:
+		m.toasts.Dismiss(msg.id)
+		m.toasts.Expire(time.Now())
+		return m, nil
+
+	case SettingsChangedMsg:
+		// Apply edited settings live; console mode takes effect on the next run
+		m.showLogs = msg.Settings.ShowLogs
+		if m.ui != nil {
+			m.ui.ApplySettings(msg.Settings)
+		}
+
 	case tea.WindowSizeMsg:
 		m.windowSize = msg
 
@@ -281,6 +316,8 @@ func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		updatedModel, doctorCmd := m.doctorModel.Update(msg)
 		m.doctorModel = updatedModel.(DoctorModel)
 		cmd = doctorCmd
+	case StateSettings:
+		m.settings, cmd = m.settings.Update(msg)
 	}
 
 	// Always update log viewer in background for live updates
@@ -306,12 +343,19 @@ func (m AppModel) View() string {
 		return m.logViewer.View()
 	case StateDoctorCheck:
 		return m.doctorModel.View()
+	case StateSettings:
+		mainContent = m.settings.View()
 	case StateCompleted:
 		mainContent = "Workflow completed! Press 'q' to quit.\n"
 	default:
 		mainContent = ""
 	}
 
+	// Transient toasts sit above the main content until they expire
+	if toasts := renderToasts(m.toasts.Visible(time.Now())); toasts != "" {
+		mainContent = toasts + "\n\n" + mainContent
+	}
+
 	// Show logs alongside main content if enabled
 	if m.showLogs && m.state != StateLogViewer {
 		return m.layoutWithLogs(mainContent)
@@ -376,6 +420,10 @@ func (m AppModel) createStatusBar() string {
 		m.getStateName(),
 		map[bool]string{true: "ON", false: "OFF"}[m.showLogs],
 	)
+	// Debug runs show whether the log buffer is losing or holding up entries
+	if m.ui != nil && m.ui.debugMode {
+		status += " | " + logBufferStatus(GetLogBuffer().Stats())
+	}
 
 	controlsText := subtleStyle.Render(strings.Join(controls, " • "))
 	statusText := subtleStyle.Render(status)
@@ -413,6 +461,8 @@ func (m AppModel) getStateName() string {
 		return "Logs"
 	case StateDoctorCheck:
 		return "Doctor"
+	case StateSettings:
+		return "Settings"
 	case StateCompleted:
 		return "Complete"
 	default:
@@ -446,7 +496,9 @@ func (m AppModel) updateMainMenu(msg tea.Msg) (MainMenuModel, tea.Cmd) {
 				// Initialize doctor model and start checks
 				m.doctorModel = NewDoctorModel()
 				return m.mainMenu, m.doctorModel.Init()
-			case 4: // Exit
+			case 4: // Settings
+				return m.mainMenu, func() tea.Msg { return OpenSettingsMsg{} }
+			case 5: // Exit
 				return m.mainMenu, tea.Quit
 			}
 		}
@@ -499,6 +551,8 @@ func (m AppModel) updateIssueSelection(msg tea.Msg) (IssueSelectionModel, tea.Cm
 
 // Progress Update
 func (m AppModel) updateProgress(msg tea.Msg) (ProgressModel, tea.Cmd) {
+	// Finished and failed steps are announced with a toast
+	var toastCmd tea.Cmd
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
 		switch msg.String() {
@@ -510,8 +564,15 @@ func (m AppModel) updateProgress(msg tea.Msg) (ProgressModel, tea.Cmd) {
 		// Update step status
 		for i, step := range m.progressTracker.steps {
 			if step.ID == msg.StepID {
-				m.progressTracker.steps[i].Status = msg.Status
-				if msg.Status == "in_progress" {
+				if msg.SubstepID != "" {
+					m.progressTracker.steps[i].UpdateSubstep(msg.SubstepID, msg.Status)
+				} else {
+					if step.Status != msg.Status {
+						toastCmd = stepToast(step.Name, msg.Status)
+					}
+					m.progressTracker.steps[i].Status = msg.Status
+				}
+				if m.progressTracker.steps[i].Status == "in_progress" {
 					m.progressTracker.currentStep = i
 				}
 				break
@@ -519,6 +580,7 @@ func (m AppModel) updateProgress(msg tea.Msg) (ProgressModel, tea.Cmd) {
 		}
 	case ProgressCompleteMsg:
 		m.state = StateCompleted
+		toastCmd = ShowToast("Workflow completed", ToastSuccess)
 	case HeaderUpdateMsg:
 		// Progress header updates are handled automatically by the main Update
 		// This ensures elapsed time and progress status stay current
@@ -527,11 +589,13 @@ func (m AppModel) updateProgress(msg tea.Msg) (ProgressModel, tea.Cmd) {
 	// Update progress bar
 	var cmd tea.Cmd
 	if m.progressTracker.currentStep < len(m.progressTracker.steps) {
-		percent := float64(m.progressTracker.currentStep) / float64(len(m.progressTracker.steps))
+		// Include weighted substep progress of the current step
+		current := m.progressTracker.steps[m.progressTracker.currentStep]
+		percent := (float64(m.progressTracker.currentStep) + current.Progress()) / float64(len(m.progressTracker.steps))
 		cmd = m.progressTracker.progress.SetPercent(percent)
 	}
 
-	return m.progressTracker, cmd
+	return m.progressTracker, tea.Batch(cmd, toastCmd)
 }
 
 // Main Menu View
@@ -580,23 +644,7 @@ func (m AppModel) viewProgress() string {
 
 	var stepsView strings.Builder
 	for i, step := range m.progressTracker.steps {
-		var icon string
-		var statusStyle lipgloss.Style
-
-		switch step.Status {
-		case "completed":
-			icon = "✅"
-			statusStyle = successStyle
-		case "in_progress":
-			icon = "🔄"
-			statusStyle = infoStyle
-		case "failed":
-			icon = "❌"
-			statusStyle = errorStyle
-		default:
-			icon = "⏳"
-			statusStyle = subtleStyle
-		}
+		icon, statusStyle := stepStatusDisplay(step.Status)
 
 		stepLine := fmt.Sprintf("%s %s %s - %s\n",
 			icon,
@@ -604,10 +652,20 @@ func (m AppModel) viewProgress() string {
 			statusStyle.Render(step.Name),
 			subtleStyle.Render(step.Description))
 		stepsView.WriteString(stepLine)
+
+		for _, substep := range step.Substeps {
+			substepIcon, substepStyle := stepStatusDisplay(substep.Status)
+			stepsView.WriteString(fmt.Sprintf("      %s %s\n", substepIcon, substepStyle.Render(substep.Name)))
+		}
 	}
 
 	elapsed := time.Since(m.progressTracker.startTime).Round(time.Second)
 	timeInfo := "\n" + infoStyle.Render("⏱ Elapsed: ") + subtleStyle.Render(elapsed.String())
+	if m.ui != nil {
+		if remaining, ok := m.ui.etaEstimator.Remaining(m.progressTracker.steps, time.Now()); ok {
+			timeInfo += "  " + infoStyle.Render("ETA: ") + subtleStyle.Render(history.FormatETA(remaining))
+		}
+	}
 
 	progressBar := m.progressTracker.progress.View()
 
@@ -617,10 +675,25 @@ func (m AppModel) viewProgress() string {
 		progressStyle.Render(stepsView.String()) + timeInfo + "\n\n" + footer
 }
 
+// stepStatusDisplay returns the icon and style for a workflow step status
+func stepStatusDisplay(status string) (string, lipgloss.Style) {
+	switch status {
+	case "completed":
+		return "✅", successStyle
+	case "in_progress":
+		return "🔄", infoStyle
+	case "failed":
+		return "❌", errorStyle
+	default:
+		return "⏳", subtleStyle
+	}
+}
+
 // Custom messages for progress updates
 type ProgressUpdateMsg struct {
-	StepID string
-	Status string
+	StepID    string
+	SubstepID string // Set to update a nested substep of StepID
+	Status    string
 }
 
 type ProgressCompleteMsg struct{}
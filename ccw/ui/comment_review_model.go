@@ -0,0 +1,203 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"ccw/types"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Interactive review of actionable PR comments before Claude addresses them
+
+// commentPreviewLength caps how much of a comment body one list row shows
+const commentPreviewLength = 72
+
+// CommentReviewModel lets the user pick which actionable comments Claude
+// addresses and attach guidance to each. Every comment starts selected.
+type CommentReviewModel struct {
+	analysis  *types.PRCommentAnalysis
+	comments  []types.ActionableComment
+	selected  []bool
+	guidance  []string
+	cursor    int
+	editing   bool
+	draft     string
+	confirmed bool
+	canceled  bool
+}
+
+// NewCommentReviewModel creates a review list for the actionable comments of analysis
+func NewCommentReviewModel(analysis *types.PRCommentAnalysis) CommentReviewModel {
+	m := CommentReviewModel{analysis: analysis}
+	if analysis != nil {
+		m.comments = analysis.ActionableComments
+	}
+	m.selected = make([]bool, len(m.comments))
+	m.guidance = make([]string, len(m.comments))
+	for i, comment := range m.comments {
+		m.selected[i] = true
+		m.guidance[i] = comment.Guidance
+	}
+	return m
+}
+
+// Init implements tea.Model
+func (m CommentReviewModel) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles navigation, toggling comments and editing guidance
+func (m CommentReviewModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	key, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+	if m.editing {
+		return m.updateGuidance(key), nil
+	}
+
+	switch key.String() {
+	case "up", "k":
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case "down", "j":
+		if m.cursor < len(m.comments)-1 {
+			m.cursor++
+		}
+	case " ", "x":
+		if len(m.comments) > 0 {
+			m.selected[m.cursor] = !m.selected[m.cursor]
+		}
+	case "a":
+		for i := range m.selected {
+			m.selected[i] = true
+		}
+	case "n":
+		for i := range m.selected {
+			m.selected[i] = false
+		}
+	case "e":
+		if len(m.comments) > 0 {
+			m.editing = true
+			m.draft = m.guidance[m.cursor]
+		}
+	case "enter":
+		m.confirmed = true
+		return m, tea.Quit
+	case "esc", "q", "ctrl+c":
+		m.canceled = true
+		return m, tea.Quit
+	}
+	return m, nil
+}
+
+// updateGuidance edits the guidance of the comment under the cursor. Enter
+// keeps the draft and selects the comment; Esc discards it.
+func (m CommentReviewModel) updateGuidance(key tea.KeyMsg) CommentReviewModel {
+	switch key.Type {
+	case tea.KeyEnter:
+		m.guidance[m.cursor] = strings.TrimSpace(m.draft)
+		if m.guidance[m.cursor] != "" {
+			m.selected[m.cursor] = true
+		}
+		m.editing = false
+	case tea.KeyEsc:
+		m.editing = false
+	case tea.KeyBackspace:
+		if runes := []rune(m.draft); len(runes) > 0 {
+			m.draft = string(runes[:len(runes)-1])
+		}
+	case tea.KeySpace:
+		m.draft += " "
+	case tea.KeyRunes:
+		m.draft += string(key.Runes)
+	}
+	return m
+}
+
+// Confirmed reports whether the user accepted the selection with Enter
+func (m CommentReviewModel) Confirmed() bool {
+	return m.confirmed && !m.canceled
+}
+
+// Result returns analysis narrowed to the selected comments, each carrying
+// its guidance. A canceled review selects nothing.
+func (m CommentReviewModel) Result() *types.PRCommentAnalysis {
+	result := &types.PRCommentAnalysis{}
+	if m.analysis != nil {
+		result.Comments = m.analysis.Comments
+		result.TotalComments = m.analysis.TotalComments
+	}
+	if !m.Confirmed() {
+		return result
+	}
+
+	for i, comment := range m.comments {
+		if !m.selected[i] {
+			continue
+		}
+		comment.Guidance = m.guidance[i]
+		result.ActionableComments = append(result.ActionableComments, comment)
+	}
+	result.HasUnaddressedComments = len(result.ActionableComments) > 0
+	return result
+}
+
+// commentPreview returns the first line of body, shortened for the list
+func commentPreview(body string) string {
+	line, _, _ := strings.Cut(strings.TrimSpace(body), "\n")
+	if runes := []rune(line); len(runes) > commentPreviewLength {
+		line = string(runes[:commentPreviewLength-1]) + "…"
+	}
+	return line
+}
+
+// View renders the comment list with each comment's decision and guidance
+func (m CommentReviewModel) View() string {
+	s := headerStyle.Render("💬 Review PR comments") + "\n\n"
+	if len(m.comments) == 0 {
+		return s + subtleStyle.Render("No actionable comments. Enter: continue") + "\n"
+	}
+
+	for i, comment := range m.comments {
+		decision := "[skip]   "
+		if m.selected[i] {
+			decision = "[address]"
+		}
+		line := fmt.Sprintf("%s %-6s @%s: %s", decision, comment.Priority, comment.Comment.User.Login, commentPreview(comment.Comment.Body))
+
+		cursor := " "
+		if m.cursor == i {
+			cursor = "▶"
+			line = selectedMenuItemStyle.Render(" " + line + " ")
+		} else {
+			line = menuItemStyle.Render(line)
+		}
+		s += fmt.Sprintf("%s %s\n", infoStyle.Render(cursor), line)
+
+		switch {
+		case m.editing && m.cursor == i:
+			s += "    " + warningStyle.Render("Guidance: "+m.draft+"█") + "\n"
+		case m.guidance[i] != "":
+			s += "    " + subtleStyle.Render("Guidance: "+m.guidance[i]) + "\n"
+		}
+	}
+
+	help := "Space: address/skip • e: add guidance • a/n: all/none • Enter: continue • Esc: address nothing"
+	if m.editing {
+		help = "Enter: save guidance • Esc: discard"
+	}
+	return s + "\n" + subtleStyle.Render(help)
+}
+
+// RunCommentReviewUI lets the user review the actionable comments of analysis
+// and returns the selected, annotated set to address
+func RunCommentReviewUI(analysis *types.PRCommentAnalysis) (*types.PRCommentAnalysis, error) {
+	final, err := tea.NewProgram(NewCommentReviewModel(analysis), tea.WithAltScreen()).Run()
+	if err != nil {
+		return nil, fmt.Errorf("comment review failed: %w", err)
+	}
+	return final.(CommentReviewModel).Result(), nil
+}
@@ -0,0 +1,129 @@
+package ui
+
+import (
+	"testing"
+
+	"ccw/types"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func reviewAnalysis() *types.PRCommentAnalysis {
+	comment := func(id int, body string) types.ActionableComment {
+		return types.ActionableComment{
+			Comment:    types.PRComment{ID: id, Body: body, User: types.User{Login: "reviewer"}},
+			Priority:   types.CommentPriorityHigh,
+			Actionable: true,
+		}
+	}
+	return &types.PRCommentAnalysis{
+		HasUnaddressedComments: true,
+		TotalComments:          4,
+		Comments:               []types.PRComment{{ID: 1}, {ID: 2}, {ID: 3}, {ID: 4}},
+		ActionableComments:     []types.ActionableComment{comment(1, "Rename this"), comment(2, "Add a test"), comment(3, "Fix the typo")},
+	}
+}
+
+// sendKeys feeds keys to model; strings are typed as runes, except " " which is a space
+func sendKeys(model CommentReviewModel, keys ...interface{}) CommentReviewModel {
+	for _, key := range keys {
+		var msg tea.KeyMsg
+		switch k := key.(type) {
+		case tea.KeyType:
+			msg = tea.KeyMsg{Type: k}
+		case string:
+			if k == " " {
+				msg = tea.KeyMsg{Type: tea.KeySpace}
+			} else {
+				msg = tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)}
+			}
+		}
+		updated, _ := model.Update(msg)
+		model = updated.(CommentReviewModel)
+	}
+	return model
+}
+
+// commentIDs returns the IDs of the actionable comments in analysis
+func commentIDs(analysis *types.PRCommentAnalysis) []int {
+	var ids []int
+	for _, comment := range analysis.ActionableComments {
+		ids = append(ids, comment.Comment.ID)
+	}
+	return ids
+}
+
+func TestCommentReviewModelSelectsAllByDefault(t *testing.T) {
+	model := sendKeys(NewCommentReviewModel(reviewAnalysis()), tea.KeyEnter)
+
+	result := model.Result()
+	if ids := commentIDs(result); len(ids) != 3 {
+		t.Errorf("Expected every comment selected, got %v", ids)
+	}
+	if !result.HasUnaddressedComments || result.TotalComments != 4 || len(result.Comments) != 4 {
+		t.Errorf("Expected comment counts preserved, got %+v", result)
+	}
+}
+
+func TestCommentReviewModelSkipAndAnnotate(t *testing.T) {
+	model := sendKeys(NewCommentReviewModel(reviewAnalysis()),
+		// Skip the first comment
+		" ",
+		// Annotate the second
+		tea.KeyDown, "e", "use", " ", "table", " ", "tests", tea.KeyBackspace, tea.KeyEnter,
+		// Annotate the third, then discard the draft
+		tea.KeyDown, "e", "ignored", tea.KeyEsc,
+		tea.KeyEnter,
+	)
+
+	result := model.Result()
+	ids := commentIDs(result)
+	if len(ids) != 2 || ids[0] != 2 || ids[1] != 3 {
+		t.Fatalf("Expected comments [2 3], got %v", ids)
+	}
+	if guidance := result.ActionableComments[0].Guidance; guidance != "use table test" {
+		t.Errorf("Expected 'use table test', got '%s'", guidance)
+	}
+	if guidance := result.ActionableComments[1].Guidance; guidance != "" {
+		t.Errorf("Expected discarded guidance, got '%s'", guidance)
+	}
+}
+
+func TestCommentReviewModelGuidanceSelectsComment(t *testing.T) {
+	model := sendKeys(NewCommentReviewModel(reviewAnalysis()), "n", tea.KeyDown, tea.KeyDown, "e", "keep it short", tea.KeyEnter, tea.KeyEnter)
+
+	result := model.Result()
+	if ids := commentIDs(result); len(ids) != 1 || ids[0] != 3 {
+		t.Fatalf("Expected only comment 3, got %v", ids)
+	}
+	if result.ActionableComments[0].Guidance != "keep it short" {
+		t.Errorf("Expected 'keep it short', got '%s'", result.ActionableComments[0].Guidance)
+	}
+}
+
+func TestCommentReviewModelCancel(t *testing.T) {
+	model := sendKeys(NewCommentReviewModel(reviewAnalysis()), tea.KeyEsc)
+
+	result := model.Result()
+	if model.Confirmed() || len(result.ActionableComments) != 0 || result.HasUnaddressedComments {
+		t.Errorf("Expected a canceled review to select nothing, got %+v", result)
+	}
+}
+
+func TestCommentReviewModelDoesNotModifyAnalysis(t *testing.T) {
+	analysis := reviewAnalysis()
+	sendKeys(NewCommentReviewModel(analysis), "e", "note", tea.KeyEnter, tea.KeyEnter).Result()
+
+	if analysis.ActionableComments[0].Guidance != "" {
+		t.Errorf("Expected the original analysis untouched, got '%s'", analysis.ActionableComments[0].Guidance)
+	}
+}
+
+func TestCommentPreview(t *testing.T) {
+	if preview := commentPreview("  First line\nsecond line"); preview != "First line" {
+		t.Errorf("Expected 'First line', got '%s'", preview)
+	}
+	long := commentPreview(string(make([]rune, 100)))
+	if n := len([]rune(long)); n != commentPreviewLength {
+		t.Errorf("Expected %d characters, got %d", commentPreviewLength, n)
+	}
+}
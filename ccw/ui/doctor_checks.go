@@ -55,8 +55,8 @@ func checkSystemDependencies() tea.Cmd {
 		}
 
 		// Check GitHub CLI
-		if checkCommandAvailable("gh") {
-			if version := getCommandVersion("gh", "--version"); version != "" {
+		if checkCommandAvailable(github.GHPath()) {
+			if version := getCommandVersion(github.GHPath(), "--version"); version != "" {
 				checks = append(checks, SystemCheck{
 					Name:        "GitHub CLI",
 					Description: "GitHub command line interface",
@@ -131,6 +131,29 @@ func checkSystemDependencies() tea.Cmd {
 			})
 		}
 
+		// Check Docker
+		if checkCommandAvailable("docker") {
+			details := "available"
+			if version := getCommandVersion("docker", "--version"); version != "" {
+				details = version
+			}
+			checks = append(checks, SystemCheck{
+				Name:        "Docker",
+				Description: "Container runtime for validation.container_image",
+				Status:      StatusPass,
+				Details:     details,
+				Critical:    false,
+			})
+		} else {
+			checks = append(checks, SystemCheck{
+				Name:        "Docker",
+				Description: "Container runtime - optional for containerized validation",
+				Status:      StatusWarn,
+				Details:     "not found",
+				Critical:    false,
+			})
+		}
+
 		return systemCheckResultMsg{
 			Section: SectionSystemDeps,
 			Checks:  checks,
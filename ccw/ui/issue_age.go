@@ -0,0 +1,43 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+)
+
+// humanizeAge renders how long ago t was, such as "3d ago". A zero time, as
+// left by fetches that return no timestamps, renders as an empty string.
+func humanizeAge(t time.Time) string {
+	return humanizeAgeAt(t, time.Now())
+}
+
+// humanizeAgeAt renders the age of t relative to now
+func humanizeAgeAt(t, now time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+
+	age := now.Sub(t)
+	switch {
+	case age < time.Minute:
+		return "just now"
+	case age < time.Hour:
+		return fmt.Sprintf("%dm ago", int(age.Minutes()))
+	case age < 24*time.Hour:
+		return fmt.Sprintf("%dh ago", int(age.Hours()))
+	case age < 30*24*time.Hour:
+		return fmt.Sprintf("%dd ago", int(age.Hours()/24))
+	case age < 365*24*time.Hour:
+		return fmt.Sprintf("%dmo ago", int(age.Hours()/(24*30)))
+	default:
+		return fmt.Sprintf("%dy ago", int(age.Hours()/(24*365)))
+	}
+}
+
+// issueOpenedLabel describes when an issue was opened, or "" when unknown
+func issueOpenedLabel(created time.Time) string {
+	if age := humanizeAge(created); age != "" {
+		return "opened " + age
+	}
+	return ""
+}
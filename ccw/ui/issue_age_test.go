@@ -0,0 +1,35 @@
+package ui
+
+import (
+	"testing"
+	"time"
+)
+
+func TestHumanizeAgeAt(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		created  time.Time
+		expected string
+	}{
+		{"zero time", time.Time{}, ""},
+		{"seconds", now.Add(-30 * time.Second), "just now"},
+		{"future clock skew", now.Add(time.Minute), "just now"},
+		{"minutes", now.Add(-5 * time.Minute), "5m ago"},
+		{"hours", now.Add(-3*time.Hour - 20*time.Minute), "3h ago"},
+		{"one day", now.Add(-24 * time.Hour), "1d ago"},
+		{"days", now.Add(-29 * 24 * time.Hour), "29d ago"},
+		{"one month", now.Add(-30 * 24 * time.Hour), "1mo ago"},
+		{"months", now.Add(-100 * 24 * time.Hour), "3mo ago"},
+		{"years", now.Add(-800 * 24 * time.Hour), "2y ago"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := humanizeAgeAt(tt.created, now); got != tt.expected {
+				t.Errorf("Expected '%s', got '%s'", tt.expected, got)
+			}
+		})
+	}
+}
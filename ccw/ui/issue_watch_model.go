@@ -0,0 +1,243 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	"ccw/types"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Issue selection that re-fetches the issue list on an interval (ccw list --watch)
+
+// IssueFetcher fetches the current issue list
+type IssueFetcher func() ([]*types.Issue, error)
+
+// issueRefreshTickMsg asks the watch model to re-fetch its issues
+type issueRefreshTickMsg struct{}
+
+// issuesRefreshedMsg carries the result of a re-fetch
+type issuesRefreshedMsg struct {
+	issues []*types.Issue
+	err    error
+}
+
+// IssueWatchModel is an issue selector whose list is refreshed every interval.
+// Selection and cursor follow issue numbers, so they survive refreshes.
+type IssueWatchModel struct {
+	issues      []*types.Issue
+	selected    map[int]bool // Selected issue numbers
+	added       map[int]bool // Issues that appeared in the latest refresh
+	cursor      int
+	fetch       IssueFetcher
+	interval    time.Duration
+	refreshing  bool
+	lastRefresh time.Time
+	refreshErr  error
+	confirmed   bool
+	canceled    bool
+}
+
+// NewIssueWatchModel creates a watching selector showing issues, re-fetched with
+// fetch every interval
+func NewIssueWatchModel(issues []*types.Issue, fetch IssueFetcher, interval time.Duration) IssueWatchModel {
+	return IssueWatchModel{
+		issues:      issues,
+		selected:    make(map[int]bool),
+		added:       make(map[int]bool),
+		fetch:       fetch,
+		interval:    interval,
+		lastRefresh: time.Now(),
+	}
+}
+
+// Init schedules the first refresh
+func (m IssueWatchModel) Init() tea.Cmd {
+	return m.scheduleRefresh()
+}
+
+// scheduleRefresh sends a refresh tick after the interval
+func (m IssueWatchModel) scheduleRefresh() tea.Cmd {
+	return tea.Tick(m.interval, func(time.Time) tea.Msg {
+		return issueRefreshTickMsg{}
+	})
+}
+
+// refresh re-fetches the issues in the background
+func (m IssueWatchModel) refresh() tea.Cmd {
+	fetch := m.fetch
+	return func() tea.Msg {
+		issues, err := fetch()
+		return issuesRefreshedMsg{issues: issues, err: err}
+	}
+}
+
+// Update handles refreshes, navigation and selection
+func (m IssueWatchModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case issueRefreshTickMsg:
+		if m.refreshing {
+			return m, nil
+		}
+		m.refreshing = true
+		return m, m.refresh()
+
+	case issuesRefreshedMsg:
+		m.refreshing = false
+		m.refreshErr = msg.err
+		if msg.err == nil {
+			m.issues, m.cursor, m.added = mergeIssueRefresh(m.issues, m.cursor, m.selected, msg.issues)
+			m.lastRefresh = time.Now()
+		}
+		// A failed refresh keeps the current list and tries again next interval
+		return m, m.scheduleRefresh()
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "up", "k":
+			if m.cursor > 0 {
+				m.cursor--
+			}
+		case "down", "j":
+			if m.cursor < len(m.issues)-1 {
+				m.cursor++
+			}
+		case " ", "x":
+			if len(m.issues) > 0 {
+				number := m.issues[m.cursor].Number
+				m.selected[number] = !m.selected[number]
+			}
+		case "a":
+			for _, issue := range m.issues {
+				m.selected[issue.Number] = true
+			}
+		case "n":
+			m.selected = make(map[int]bool)
+		case "r":
+			if !m.refreshing {
+				m.refreshing = true
+				return m, m.refresh()
+			}
+		case "enter":
+			m.confirmed = true
+			return m, tea.Quit
+		case "esc", "q", "ctrl+c":
+			m.canceled = true
+			return m, tea.Quit
+		}
+	}
+	return m, nil
+}
+
+// mergeIssueRefresh replaces current with fresh while keeping the user's place.
+// Selected issues missing from fresh, for example pushed out by --limit, stay
+// listed at the end so a refresh never drops a selection. The cursor stays on
+// the same issue, or at the same row if that issue is gone. It also returns the
+// issues in fresh that were not listed before.
+func mergeIssueRefresh(current []*types.Issue, cursor int, selected map[int]bool, fresh []*types.Issue) ([]*types.Issue, int, map[int]bool) {
+	cursorNumber := 0
+	if cursor >= 0 && cursor < len(current) {
+		cursorNumber = current[cursor].Number
+	}
+
+	listed := make(map[int]bool, len(current))
+	for _, issue := range current {
+		listed[issue.Number] = true
+	}
+
+	merged := make([]*types.Issue, 0, len(fresh))
+	inFresh := make(map[int]bool, len(fresh))
+	added := make(map[int]bool)
+	for _, issue := range fresh {
+		if inFresh[issue.Number] {
+			continue
+		}
+		inFresh[issue.Number] = true
+		merged = append(merged, issue)
+		if !listed[issue.Number] {
+			added[issue.Number] = true
+		}
+	}
+	for _, issue := range current {
+		if selected[issue.Number] && !inFresh[issue.Number] {
+			merged = append(merged, issue)
+		}
+	}
+
+	newCursor := cursor
+	for i, issue := range merged {
+		if issue.Number == cursorNumber {
+			newCursor = i
+			break
+		}
+	}
+	if newCursor >= len(merged) {
+		newCursor = len(merged) - 1
+	}
+	if newCursor < 0 {
+		newCursor = 0
+	}
+	return merged, newCursor, added
+}
+
+// Selected returns the selected issues in list order, or none when canceled
+func (m IssueWatchModel) Selected() []*types.Issue {
+	if !m.confirmed || m.canceled {
+		return nil
+	}
+	var selected []*types.Issue
+	for _, issue := range m.issues {
+		if m.selected[issue.Number] {
+			selected = append(selected, issue)
+		}
+	}
+	return selected
+}
+
+// View renders the issue list with selection marks and the refresh status
+func (m IssueWatchModel) View() string {
+	s := headerStyle.Render("👀 Select issues (watching)") + "\n\n"
+	if len(m.issues) == 0 {
+		s += subtleStyle.Render("No issues yet; new issues appear on the next refresh") + "\n"
+	}
+
+	for i, issue := range m.issues {
+		mark := "[ ]"
+		if m.selected[issue.Number] {
+			mark = "[x]"
+		}
+		line := fmt.Sprintf("%s #%d %s", mark, issue.Number, issue.Title)
+		if m.added[issue.Number] {
+			line += " (new)"
+		}
+
+		cursor := " "
+		if m.cursor == i {
+			cursor = "▶"
+			line = selectedMenuItemStyle.Render(" " + line + " ")
+		} else {
+			line = menuItemStyle.Render(line)
+		}
+		s += fmt.Sprintf("%s %s\n", infoStyle.Render(cursor), line)
+	}
+
+	status := fmt.Sprintf("Refreshed %s • every %s", m.lastRefresh.Format("15:04:05"), m.interval)
+	switch {
+	case m.refreshing:
+		status = "Refreshing..."
+	case m.refreshErr != nil:
+		status += " • " + warningStyle.Render(fmt.Sprintf("last refresh failed: %v", m.refreshErr))
+	}
+	help := "Space: select • a/n: all/none • r: refresh now • Enter: process selected • Esc: quit"
+	return s + "\n" + subtleStyle.Render(status) + "\n" + subtleStyle.Render(help)
+}
+
+// RunIssueWatchUI shows issues in a selector refreshed every interval and
+// returns the selected issues
+func RunIssueWatchUI(issues []*types.Issue, fetch IssueFetcher, interval time.Duration) ([]*types.Issue, error) {
+	final, err := tea.NewProgram(NewIssueWatchModel(issues, fetch, interval), tea.WithAltScreen()).Run()
+	if err != nil {
+		return nil, fmt.Errorf("issue selection failed: %w", err)
+	}
+	return final.(IssueWatchModel).Selected(), nil
+}
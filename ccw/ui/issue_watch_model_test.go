@@ -0,0 +1,127 @@
+package ui
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"ccw/types"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func watchIssues(numbers ...int) []*types.Issue {
+	issues := make([]*types.Issue, 0, len(numbers))
+	for _, number := range numbers {
+		issues = append(issues, &types.Issue{Number: number, Title: fmt.Sprintf("Issue %d", number)})
+	}
+	return issues
+}
+
+func issueNumbers(issues []*types.Issue) []int {
+	numbers := make([]int, 0, len(issues))
+	for _, issue := range issues {
+		numbers = append(numbers, issue.Number)
+	}
+	return numbers
+}
+
+func TestMergeIssueRefresh(t *testing.T) {
+	tests := []struct {
+		name          string
+		current       []int
+		cursor        int
+		selected      []int
+		fresh         []int
+		expected      []int
+		expectedCur   int
+		expectedAdded []int
+	}{
+		{"new issue keeps cursor on its issue", []int{1, 2, 3}, 1, nil, []int{4, 1, 2, 3}, []int{4, 1, 2, 3}, 2, []int{4}},
+		{"selected issue gone from refresh stays listed", []int{1, 2, 3}, 0, []int{2}, []int{1, 3}, []int{1, 3, 2}, 0, nil},
+		{"unselected issue gone from refresh is dropped", []int{1, 2, 3}, 0, nil, []int{1, 3}, []int{1, 3}, 0, nil},
+		{"cursor issue gone keeps the row", []int{1, 2, 3}, 1, nil, []int{1, 3}, []int{1, 3}, 1, nil},
+		{"cursor clamped to shorter list", []int{1, 2, 3}, 2, nil, []int{1}, []int{1}, 0, nil},
+		{"empty refresh", []int{1}, 0, nil, nil, []int{}, 0, nil},
+		{"duplicates in refresh are listed once", []int{1}, 0, nil, []int{2, 2, 1}, []int{2, 1}, 1, []int{2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			selected := make(map[int]bool)
+			for _, number := range tt.selected {
+				selected[number] = true
+			}
+			merged, cursor, added := mergeIssueRefresh(watchIssues(tt.current...), tt.cursor, selected, watchIssues(tt.fresh...))
+			if got := fmt.Sprint(issueNumbers(merged)); got != fmt.Sprint(tt.expected) {
+				t.Errorf("Expected issues %s, got %s", fmt.Sprint(tt.expected), got)
+			}
+			if cursor != tt.expectedCur {
+				t.Errorf("Expected cursor %d, got %d", tt.expectedCur, cursor)
+			}
+			if len(added) != len(tt.expectedAdded) {
+				t.Errorf("Expected %d new issues, got %d", len(tt.expectedAdded), len(added))
+			}
+			for _, number := range tt.expectedAdded {
+				if !added[number] {
+					t.Errorf("Expected #%d marked new", number)
+				}
+			}
+		})
+	}
+}
+
+func TestIssueWatchModelRefreshPreservesSelection(t *testing.T) {
+	fetched := watchIssues(5, 1, 3)
+	fetch := func() ([]*types.Issue, error) { return fetched, nil }
+	var model tea.Model = NewIssueWatchModel(watchIssues(1, 2, 3), fetch, time.Minute)
+
+	// Select #2 and #3, leaving the cursor on #3
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyDown})
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeySpace})
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyDown})
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeySpace})
+
+	// The tick starts a fetch, whose result is merged
+	model, cmd := model.Update(issueRefreshTickMsg{})
+	if cmd == nil {
+		t.Fatal("Expected a refresh command on tick")
+	}
+	model, cmd = model.Update(cmd())
+	if cmd == nil {
+		t.Error("Expected the next refresh to be scheduled")
+	}
+
+	watch := model.(IssueWatchModel)
+	if got := fmt.Sprint(issueNumbers(watch.issues)); got != "[5 1 3 2]" {
+		t.Errorf("Expected issues [5 1 3 2], got %s", got)
+	}
+	if watch.issues[watch.cursor].Number != 3 {
+		t.Errorf("Expected cursor on #3, got #%d", watch.issues[watch.cursor].Number)
+	}
+
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if got := fmt.Sprint(issueNumbers(model.(IssueWatchModel).Selected())); got != "[3 2]" {
+		t.Errorf("Expected selection [3 2], got %s", got)
+	}
+}
+
+func TestIssueWatchModelFailedRefreshKeepsList(t *testing.T) {
+	fetch := func() ([]*types.Issue, error) { return nil, fmt.Errorf("rate limited") }
+	var model tea.Model = NewIssueWatchModel(watchIssues(1, 2), fetch, time.Minute)
+
+	_, cmd := model.Update(issueRefreshTickMsg{})
+	model, cmd = model.Update(cmd())
+	if cmd == nil {
+		t.Error("Expected a retry to be scheduled after a failed refresh")
+	}
+
+	watch := model.(IssueWatchModel)
+	if len(watch.issues) != 2 || watch.refreshErr == nil {
+		t.Errorf("Expected the list kept with the error recorded, got %d issues and %v", len(watch.issues), watch.refreshErr)
+	}
+
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if model.(IssueWatchModel).Selected() != nil {
+		t.Error("Expected no selection after cancel")
+	}
+}
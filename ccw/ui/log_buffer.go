@@ -0,0 +1,221 @@
+package ui
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"ccw/types"
+)
+
+// In-memory log buffer shared by logging goroutines and the log viewer
+
+// OverflowPolicy decides what AddEntry does when the buffer is full
+type OverflowPolicy int
+
+const (
+	// OverflowDropOldest overwrites the oldest entry, so writers never wait
+	OverflowDropOldest OverflowPolicy = iota
+	// OverflowBlock makes writers wait until Drain, Clear or Close frees space.
+	// Use it only when a consumer drains the buffer.
+	OverflowBlock
+)
+
+// defaultLogBufferSize is the capacity of the global buffer when none was set
+const defaultLogBufferSize = 1000
+
+// LogBufferStats reports buffer usage
+type LogBufferStats struct {
+	Entries  int    // Entries currently held
+	Capacity int    // Maximum entries held
+	Added    uint64 // Entries accepted since creation
+	Dropped  uint64 // Entries overwritten or refused because the buffer was full
+	Blocked  uint64 // AddEntry calls that had to wait for space
+}
+
+// LogBuffer holds logs in memory for UI display. It is a fixed-size ring that
+// is safe for concurrent use.
+type LogBuffer struct {
+	entries []types.LogEntry // Ring storage of len maxSize
+	start   int              // Index of the oldest entry
+	count   int              // Entries held
+	maxSize int
+	policy  OverflowPolicy
+	closed  bool
+
+	added   uint64
+	dropped uint64
+	blocked uint64
+
+	mutex   sync.Mutex
+	notFull *sync.Cond
+}
+
+// NewLogBuffer creates a new log buffer that drops the oldest entry when full
+func NewLogBuffer(maxSize int) *LogBuffer {
+	return NewLogBufferWithPolicy(maxSize, OverflowDropOldest)
+}
+
+// NewLogBufferWithPolicy creates a log buffer with the given overflow policy.
+// A maxSize below 1 uses the default size.
+func NewLogBufferWithPolicy(maxSize int, policy OverflowPolicy) *LogBuffer {
+	if maxSize < 1 {
+		maxSize = defaultLogBufferSize
+	}
+	lb := &LogBuffer{
+		entries: make([]types.LogEntry, maxSize),
+		maxSize: maxSize,
+		policy:  policy,
+	}
+	lb.notFull = sync.NewCond(&lb.mutex)
+	return lb
+}
+
+// AddEntry adds a log entry to the buffer. When the buffer is full the oldest
+// entry is overwritten, or with OverflowBlock the call waits for space. Entries
+// added after Close are dropped.
+func (lb *LogBuffer) AddEntry(entry types.LogEntry) {
+	lb.mutex.Lock()
+	defer lb.mutex.Unlock()
+
+	if lb.policy == OverflowBlock && lb.count == lb.maxSize && !lb.closed {
+		lb.blocked++
+		for lb.count == lb.maxSize && !lb.closed {
+			lb.notFull.Wait()
+		}
+	}
+	if lb.closed {
+		lb.dropped++
+		return
+	}
+
+	if lb.count == lb.maxSize {
+		// Overwrite the oldest entry
+		lb.entries[lb.start] = entry
+		lb.start = (lb.start + 1) % lb.maxSize
+		lb.dropped++
+	} else {
+		lb.entries[(lb.start+lb.count)%lb.maxSize] = entry
+		lb.count++
+	}
+	lb.added++
+}
+
+// snapshot copies the held entries, oldest first; the caller holds the mutex
+func (lb *LogBuffer) snapshot() []types.LogEntry {
+	entries := make([]types.LogEntry, lb.count)
+	for i := 0; i < lb.count; i++ {
+		entries[i] = lb.entries[(lb.start+i)%lb.maxSize]
+	}
+	return entries
+}
+
+// reset empties the buffer and wakes blocked writers; the caller holds the mutex
+func (lb *LogBuffer) reset() {
+	for i := range lb.entries {
+		lb.entries[i] = types.LogEntry{}
+	}
+	lb.start = 0
+	lb.count = 0
+	lb.notFull.Broadcast()
+}
+
+// GetEntries returns all log entries, oldest first
+func (lb *LogBuffer) GetEntries() []types.LogEntry {
+	lb.mutex.Lock()
+	defer lb.mutex.Unlock()
+
+	// Return a copy to avoid race conditions
+	return lb.snapshot()
+}
+
+// GetEntriesAfter returns entries after a specific time
+func (lb *LogBuffer) GetEntriesAfter(after time.Time) []types.LogEntry {
+	lb.mutex.Lock()
+	defer lb.mutex.Unlock()
+
+	var filtered []types.LogEntry
+	for i := 0; i < lb.count; i++ {
+		if entry := lb.entries[(lb.start+i)%lb.maxSize]; entry.Timestamp.After(after) {
+			filtered = append(filtered, entry)
+		}
+	}
+	return filtered
+}
+
+// Drain removes and returns all entries, oldest first, waking blocked writers
+func (lb *LogBuffer) Drain() []types.LogEntry {
+	lb.mutex.Lock()
+	defer lb.mutex.Unlock()
+
+	entries := lb.snapshot()
+	lb.reset()
+	return entries
+}
+
+// Clear clears all entries
+func (lb *LogBuffer) Clear() {
+	lb.mutex.Lock()
+	defer lb.mutex.Unlock()
+	lb.reset()
+}
+
+// Close releases writers blocked on a full buffer; later entries are dropped
+func (lb *LogBuffer) Close() {
+	lb.mutex.Lock()
+	defer lb.mutex.Unlock()
+	lb.closed = true
+	lb.notFull.Broadcast()
+}
+
+// Stats returns the buffer's current usage counters
+func (lb *LogBuffer) Stats() LogBufferStats {
+	lb.mutex.Lock()
+	defer lb.mutex.Unlock()
+	return LogBufferStats{
+		Entries:  lb.count,
+		Capacity: lb.maxSize,
+		Added:    lb.added,
+		Dropped:  lb.dropped,
+		Blocked:  lb.blocked,
+	}
+}
+
+// logBufferStatus summarises stats for the debug status bar
+func logBufferStatus(stats LogBufferStats) string {
+	return fmt.Sprintf("Log buffer: %d/%d, %d dropped, %d blocked", stats.Entries, stats.Capacity, stats.Dropped, stats.Blocked)
+}
+
+// Global log buffer for the application
+var (
+	globalLogBuffer *LogBuffer
+	globalLogMutex  sync.Mutex
+)
+
+// InitLogBuffer initializes the global log buffer
+func InitLogBuffer(maxSize int) {
+	InitLogBufferWithPolicy(maxSize, OverflowDropOldest)
+}
+
+// InitLogBufferWithPolicy initializes the global log buffer with an overflow policy
+func InitLogBufferWithPolicy(maxSize int, policy OverflowPolicy) {
+	globalLogMutex.Lock()
+	defer globalLogMutex.Unlock()
+	globalLogBuffer = NewLogBufferWithPolicy(maxSize, policy)
+}
+
+// GetLogBuffer returns the global log buffer
+func GetLogBuffer() *LogBuffer {
+	globalLogMutex.Lock()
+	defer globalLogMutex.Unlock()
+	if globalLogBuffer == nil {
+		globalLogBuffer = NewLogBuffer(defaultLogBufferSize)
+	}
+	return globalLogBuffer
+}
+
+// AddLogToBuffer adds a log entry to the global buffer
+func AddLogToBuffer(entry types.LogEntry) {
+	buffer := GetLogBuffer()
+	buffer.AddEntry(entry)
+}
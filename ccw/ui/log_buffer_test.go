@@ -0,0 +1,232 @@
+package ui
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"ccw/types"
+)
+
+func logEntry(i int) types.LogEntry {
+	return types.LogEntry{
+		Timestamp: time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC).Add(time.Duration(i) * time.Second),
+		Level:     "INFO",
+		Message:   fmt.Sprintf("entry %d", i),
+	}
+}
+
+func TestLogBufferRingKeepsNewestEntries(t *testing.T) {
+	lb := NewLogBuffer(3)
+	for i := 1; i <= 5; i++ {
+		lb.AddEntry(logEntry(i))
+	}
+
+	entries := lb.GetEntries()
+	if len(entries) != 3 {
+		t.Fatalf("Expected 3 entries, got %d", len(entries))
+	}
+	for i, expected := range []string{"entry 3", "entry 4", "entry 5"} {
+		if entries[i].Message != expected {
+			t.Errorf("Expected '%s' at %d, got '%s'", expected, i, entries[i].Message)
+		}
+	}
+
+	after := lb.GetEntriesAfter(logEntry(3).Timestamp)
+	if len(after) != 2 || after[0].Message != "entry 4" {
+		t.Errorf("Expected entries 4 and 5 after entry 3, got %v", after)
+	}
+
+	stats := lb.Stats()
+	expected := LogBufferStats{Entries: 3, Capacity: 3, Added: 5, Dropped: 2}
+	if stats != expected {
+		t.Errorf("Expected stats %+v, got %+v", expected, stats)
+	}
+
+	lb.Clear()
+	if entries := lb.GetEntries(); len(entries) != 0 {
+		t.Errorf("Expected no entries after Clear, got %d", len(entries))
+	}
+	lb.AddEntry(logEntry(6))
+	if entries := lb.GetEntries(); len(entries) != 1 || entries[0].Message != "entry 6" {
+		t.Errorf("Expected only entry 6 after Clear, got %v", entries)
+	}
+}
+
+func TestLogBufferConcurrentWriters(t *testing.T) {
+	const writers, perWriter, capacity = 16, 500, 100
+	lb := NewLogBuffer(capacity)
+
+	var wg sync.WaitGroup
+	for w := 0; w < writers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < perWriter; i++ {
+				lb.AddEntry(types.LogEntry{Component: fmt.Sprintf("writer-%d", w), Message: fmt.Sprintf("%d", i)})
+			}
+		}(w)
+	}
+	// Readers run alongside the writers
+	for r := 0; r < 4; r++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 200; i++ {
+				if entries := lb.GetEntries(); len(entries) > capacity {
+					t.Errorf("Expected at most %d entries, got %d", capacity, len(entries))
+					return
+				}
+				lb.Stats()
+			}
+		}()
+	}
+	wg.Wait()
+
+	stats := lb.Stats()
+	total := uint64(writers * perWriter)
+	if stats.Added != total || stats.Entries != capacity || stats.Dropped != total-capacity {
+		t.Errorf("Expected %d added, %d held and %d dropped, got %+v", total, capacity, total-capacity, stats)
+	}
+
+	// Each writer's surviving entries keep their relative order
+	last := make(map[string]int)
+	for _, entry := range lb.GetEntries() {
+		var n int
+		fmt.Sscanf(entry.Message, "%d", &n)
+		if prev, ok := last[entry.Component]; ok && n <= prev {
+			t.Errorf("Expected %s entries in order, got %d after %d", entry.Component, n, prev)
+		}
+		last[entry.Component] = n
+	}
+}
+
+func TestLogBufferBlockPolicyAppliesBackpressure(t *testing.T) {
+	lb := NewLogBufferWithPolicy(2, OverflowBlock)
+	lb.AddEntry(logEntry(1))
+	lb.AddEntry(logEntry(2))
+
+	done := make(chan struct{})
+	go func() {
+		lb.AddEntry(logEntry(3))
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		t.Fatal("Expected AddEntry to block on a full buffer")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	drained := lb.Drain()
+	if len(drained) != 2 || drained[0].Message != "entry 1" || drained[1].Message != "entry 2" {
+		t.Errorf("Expected entries 1 and 2 drained, got %v", drained)
+	}
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Expected Drain to unblock the writer")
+	}
+	if entries := lb.GetEntries(); len(entries) != 1 || entries[0].Message != "entry 3" {
+		t.Errorf("Expected entry 3 after drain, got %v", entries)
+	}
+
+	stats := lb.Stats()
+	if stats.Blocked != 1 || stats.Dropped != 0 || stats.Added != 3 {
+		t.Errorf("Expected 1 blocked, 0 dropped, 3 added, got %+v", stats)
+	}
+}
+
+func TestLogBufferCloseReleasesBlockedWriters(t *testing.T) {
+	lb := NewLogBufferWithPolicy(1, OverflowBlock)
+	lb.AddEntry(logEntry(1))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			lb.AddEntry(logEntry(10 + i))
+		}(i)
+	}
+
+	time.Sleep(20 * time.Millisecond)
+	lb.Close()
+
+	released := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(released)
+	}()
+	select {
+	case <-released:
+	case <-time.After(time.Second):
+		t.Fatal("Expected Close to release blocked writers")
+	}
+
+	stats := lb.Stats()
+	if stats.Entries != 1 || stats.Dropped != 5 {
+		t.Errorf("Expected 1 entry kept and 5 dropped, got %+v", stats)
+	}
+}
+
+func TestGlobalLogBufferConcurrentAccess(t *testing.T) {
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			AddLogToBuffer(logEntry(i))
+		}(i)
+		go func() {
+			defer wg.Done()
+			GetLogBuffer().Stats()
+		}()
+	}
+	wg.Wait()
+
+	InitLogBuffer(10)
+	if capacity := GetLogBuffer().Stats().Capacity; capacity != 10 {
+		t.Errorf("Expected capacity 10 after InitLogBuffer, got %d", capacity)
+	}
+}
+
+func TestAppModelUsesConfiguredLogBuffer(t *testing.T) {
+	defer InitLogBuffer(defaultLogBufferSize)
+
+	ui := NewUIManager("default", false, false)
+	ui.SetLogBuffer(2)
+	NewAppModel(ui)
+
+	buffer := GetLogBuffer()
+	if capacity := buffer.Stats().Capacity; capacity != 2 {
+		t.Errorf("Expected ui.log_buffer_size to set the capacity, got %d", capacity)
+	}
+
+	// Nothing drains the buffer during a run, so logging past its capacity
+	// must drop entries rather than wait
+	done := make(chan struct{})
+	go func() {
+		for i := 0; i < 5; i++ {
+			AddLogToBuffer(logEntry(i))
+		}
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Expected logging past the buffer capacity not to block")
+	}
+	if stats := buffer.Stats(); stats.Dropped != 3 || stats.Blocked != 0 {
+		t.Errorf("Expected 3 dropped and 0 blocked entries, got %+v", stats)
+	}
+}
+
+func TestLogBufferStatus(t *testing.T) {
+	status := logBufferStatus(LogBufferStats{Entries: 3, Capacity: 5, Dropped: 2, Blocked: 1})
+	if status != "Log buffer: 3/5, 2 dropped, 1 blocked" {
+		t.Errorf("Unexpected status '%s'", status)
+	}
+}
@@ -3,7 +3,6 @@ package ui
 import (
 	"fmt"
 	"strings"
-	"sync"
 	"time"
 
 	"ccw/types"
@@ -12,66 +11,6 @@ import (
 	"github.com/charmbracelet/lipgloss"
 )
 
-// LogBuffer holds logs in memory for UI display
-type LogBuffer struct {
-	entries []types.LogEntry
-	maxSize int
-	mutex   sync.RWMutex
-}
-
-// NewLogBuffer creates a new log buffer
-func NewLogBuffer(maxSize int) *LogBuffer {
-	return &LogBuffer{
-		entries: make([]types.LogEntry, 0, maxSize),
-		maxSize: maxSize,
-	}
-}
-
-// AddEntry adds a log entry to the buffer
-func (lb *LogBuffer) AddEntry(entry types.LogEntry) {
-	lb.mutex.Lock()
-	defer lb.mutex.Unlock()
-
-	lb.entries = append(lb.entries, entry)
-
-	// Keep only the last maxSize entries
-	if len(lb.entries) > lb.maxSize {
-		lb.entries = lb.entries[len(lb.entries)-lb.maxSize:]
-	}
-}
-
-// GetEntries returns all log entries
-func (lb *LogBuffer) GetEntries() []types.LogEntry {
-	lb.mutex.RLock()
-	defer lb.mutex.RUnlock()
-
-	// Return a copy to avoid race conditions
-	entries := make([]types.LogEntry, len(lb.entries))
-	copy(entries, lb.entries)
-	return entries
-}
-
-// GetEntriesAfter returns entries after a specific time
-func (lb *LogBuffer) GetEntriesAfter(after time.Time) []types.LogEntry {
-	lb.mutex.RLock()
-	defer lb.mutex.RUnlock()
-
-	var filtered []types.LogEntry
-	for _, entry := range lb.entries {
-		if entry.Timestamp.After(after) {
-			filtered = append(filtered, entry)
-		}
-	}
-	return filtered
-}
-
-// Clear clears all entries
-func (lb *LogBuffer) Clear() {
-	lb.mutex.Lock()
-	defer lb.mutex.Unlock()
-	lb.entries = lb.entries[:0]
-}
-
 // LogViewerModel represents the log viewer component
 type LogViewerModel struct {
 	viewport   viewport.Model
@@ -314,9 +253,13 @@ func (m LogViewerModel) formatLogEntry(entry types.LogEntry) string {
 		levelStyle = subtleStyle
 	}
 
-	// Format: [15:04:05] LEVEL [component] message
+	// Format: [15:04:05] LEVEL [component/phase] message
 	levelText := levelStyle.Render(fmt.Sprintf("%-5s", entry.Level))
-	componentText := subtleStyle.Render(fmt.Sprintf("[%s]", entry.Component))
+	source := entry.Component
+	if entry.Phase != "" {
+		source += "/" + entry.Phase
+	}
+	componentText := subtleStyle.Render(fmt.Sprintf("[%s]", source))
 
 	// Truncate long messages to fit viewport
 	maxMessageWidth := m.viewport.Width - 25 // Account for timestamp, level, component
@@ -332,25 +275,3 @@ func (m LogViewerModel) formatLogEntry(entry types.LogEntry) string {
 		message,
 	)
 }
-
-// Global log buffer for the application
-var globalLogBuffer *LogBuffer
-
-// InitLogBuffer initializes the global log buffer
-func InitLogBuffer(maxSize int) {
-	globalLogBuffer = NewLogBuffer(maxSize)
-}
-
-// GetLogBuffer returns the global log buffer
-func GetLogBuffer() *LogBuffer {
-	if globalLogBuffer == nil {
-		InitLogBuffer(1000) // Default size
-	}
-	return globalLogBuffer
-}
-
-// AddLogToBuffer adds a log entry to the global buffer
-func AddLogToBuffer(entry types.LogEntry) {
-	buffer := GetLogBuffer()
-	buffer.AddEntry(entry)
-}
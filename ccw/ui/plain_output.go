@@ -0,0 +1,86 @@
+package ui
+
+import (
+	"os"
+	"regexp"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+// Plain output detection for non-TTY destinations
+
+// ansiPattern matches CSI and OSC escape sequences
+var ansiPattern = regexp.MustCompile(`\x1b\[[0-9;?]*[ -/]*[@-~]|\x1b\][^\x07\x1b]*(?:\x07|\x1b\\)`)
+
+// stdoutIsTerminal reports whether stdout is a terminal; replaced in tests
+var stdoutIsTerminal = func() bool {
+	return term.IsTerminal(int(os.Stdout.Fd()))
+}
+
+// isTTY reports whether output goes to an interactive terminal
+func isTTY() bool {
+	return stdoutIsTerminal()
+}
+
+// PlainOutput reports whether output must be plain text without colors, ANSI
+// escapes or emoji: when NO_COLOR is set, CCW_CONSOLE_MODE=true, or stdout is
+// not a terminal (e.g. piped to a file)
+func PlainOutput() bool {
+	if _, ok := os.LookupEnv("NO_COLOR"); ok {
+		return true
+	}
+	if os.Getenv("CCW_CONSOLE_MODE") == "true" {
+		return true
+	}
+	return !isTTY()
+}
+
+// StripANSI removes ANSI escape sequences from s
+func StripANSI(s string) string {
+	return ansiPattern.ReplaceAllString(s, "")
+}
+
+// StripEmoji removes emoji and pictographic symbols from s, along with the
+// space that usually follows a leading icon. Letters in any script are kept.
+func StripEmoji(s string) string {
+	var sb strings.Builder
+	removed := false
+	for _, r := range s {
+		if isEmojiRune(r) {
+			removed = true
+			continue
+		}
+		// Drop the separator left behind by a removed icon
+		if removed && r == ' ' && (sb.Len() == 0 || strings.HasSuffix(sb.String(), " ")) {
+			continue
+		}
+		removed = false
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
+
+// PlainText strips ANSI escapes and emoji from s
+func PlainText(s string) string {
+	return StripEmoji(StripANSI(s))
+}
+
+// isEmojiRune reports whether r is an emoji, pictograph or emoji modifier
+func isEmojiRune(r rune) bool {
+	switch {
+	case r == '\u200d', r == '\u20e3': // zero width joiner, combining keycap
+		return true
+	case r >= '\ufe00' && r <= '\ufe0f': // variation selectors
+		return true
+	case r >= 0x1f000 && r <= 0x1faff: // emoji and pictograph blocks
+		return true
+	case r >= 0x2600 && r <= 0x27bf: // miscellaneous symbols and dingbats
+		return true
+	case r >= 0x2300 && r <= 0x23ff: // miscellaneous technical (⏳, ⏱)
+		return true
+	case r >= 0x2b00 && r <= 0x2bff: // arrows and stars (⭐)
+		return true
+	}
+	return false
+}
@@ -0,0 +1,137 @@
+package ui
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+)
+
+func withTerminal(t *testing.T, isTerminal bool) {
+	original := stdoutIsTerminal
+	stdoutIsTerminal = func() bool { return isTerminal }
+	t.Cleanup(func() { stdoutIsTerminal = original })
+}
+
+func TestPlainOutputDetection(t *testing.T) {
+	tests := []struct {
+		name        string
+		isTerminal  bool
+		noColor     bool
+		consoleMode string
+		expected    bool
+	}{
+		{"terminal", true, false, "", false},
+		{"piped", false, false, "", true},
+		{"NO_COLOR on terminal", true, true, "", true},
+		{"console mode on terminal", true, false, "true", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			withTerminal(t, tt.isTerminal)
+			t.Setenv("CCW_CONSOLE_MODE", tt.consoleMode)
+			if tt.noColor {
+				t.Setenv("NO_COLOR", "1")
+			} else {
+				unsetEnv(t, "NO_COLOR")
+			}
+
+			if got := PlainOutput(); got != tt.expected {
+				t.Errorf("Expected PlainOutput %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestPlainText(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"ansi color", "\x1b[1;32mdone\x1b[0m", "done"},
+		{"leading emoji", "✅ Changes committed successfully!", "Changes committed successfully!"},
+		{"emoji with variation selector", "⚠️ Summary generation timed out", "Summary generation timed out"},
+		{"emoji in the middle", "Pushing 📤 changes", "Pushing changes"},
+		{"osc hyperlink", "\x1b]8;;https://example.com\x07link\x1b]8;;\x07", "link"},
+		{"non-latin text kept", "✅ 日本語のタイトル", "日本語のタイトル"},
+		{"plain text unchanged", "Processing issue #42", "Processing issue #42"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := PlainText(tt.input); got != tt.expected {
+				t.Errorf("Expected '%s', got '%s'", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestLoggingStripsStylingWhenNotTTY(t *testing.T) {
+	withTerminal(t, false)
+	unsetEnv(t, "NO_COLOR")
+	t.Setenv("CCW_CONSOLE_MODE", "")
+
+	ui := NewUIManager("default", true, true)
+	if !ui.plainOutput {
+		t.Fatal("Expected plain output when stdout is not a terminal")
+	}
+	if ui.GetAnimations() {
+		t.Error("Expected animations to be disabled for plain output")
+	}
+
+	var buf bytes.Buffer
+	ui.output = &buf
+
+	ui.Info("🚀 Starting \x1b[36mworkflow\x1b[0m")
+	ui.Success("✅ Changes committed")
+	ui.Warning("⚠️ Timed out")
+	ui.Error("❌ Push failed")
+	ui.Debug("🔍 Details")
+
+	expected := "[INFO] Starting workflow\n" +
+		"[SUCCESS] Changes committed\n" +
+		"[WARNING] Timed out\n" +
+		"[ERROR] Push failed\n" +
+		"[DEBUG] Details\n"
+	if buf.String() != expected {
+		t.Errorf("Expected plain output:\n%q\ngot:\n%q", expected, buf.String())
+	}
+	if strings.Contains(buf.String(), "\x1b") {
+		t.Error("Expected no ANSI escape sequences in plain output")
+	}
+	if got := ui.getConsoleChar("✅", "[OK]"); got != "[OK]" {
+		t.Errorf("Expected console character '[OK]', got '%s'", got)
+	}
+}
+
+func TestLoggingKeepsMessageOnTerminal(t *testing.T) {
+	withTerminal(t, true)
+	unsetEnv(t, "NO_COLOR")
+	t.Setenv("CCW_CONSOLE_MODE", "")
+	t.Setenv("CI", "")
+	t.Setenv("GITHUB_ACTIONS", "")
+
+	ui := NewUIManager("default", true, false)
+	if ui.plainOutput {
+		t.Fatal("Expected styled output on a terminal")
+	}
+
+	var buf bytes.Buffer
+	ui.output = &buf
+	ui.Success("✅ Changes committed")
+
+	if !strings.Contains(buf.String(), "✅ Changes committed") {
+		t.Errorf("Expected emoji to be kept on a terminal, got %q", buf.String())
+	}
+}
+
+// unsetEnv removes an environment variable for the duration of the test
+func unsetEnv(t *testing.T, key string) {
+	t.Helper()
+	t.Setenv(key, "")
+	if err := os.Unsetenv(key); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,59 @@
+package ui
+
+import (
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+)
+
+// Machine-readable progress events for wrapping UIs (--progress-fd)
+
+// ProgressEvent is one line of the progress stream
+type ProgressEvent struct {
+	Step      string    `json:"step"`
+	Substep   string    `json:"substep,omitempty"`
+	Status    string    `json:"status"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// ProgressStream writes progress events as newline-delimited JSON. A failed
+// write, e.g. after the reader went away, turns the stream off rather than
+// interrupting the workflow.
+type ProgressStream struct {
+	mu     sync.Mutex
+	out    io.Writer
+	failed bool
+	now    func() time.Time
+}
+
+// NewProgressStream returns a stream writing events to out
+func NewProgressStream(out io.Writer) *ProgressStream {
+	return &ProgressStream{out: out, now: time.Now}
+}
+
+// Emit writes one event; it is a no-op on a nil or failed stream
+func (ps *ProgressStream) Emit(step, substep, status string) {
+	if ps == nil {
+		return
+	}
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	if ps.failed {
+		return
+	}
+
+	line, err := json.Marshal(ProgressEvent{Step: step, Substep: substep, Status: status, Timestamp: ps.now().UTC()})
+	if err != nil {
+		return
+	}
+	if _, err := ps.out.Write(append(line, '\n')); err != nil {
+		ps.failed = true
+	}
+}
+
+// SetProgressStream sends every step and substep update to stream as well as
+// the terminal UI; nil turns the stream off
+func (ui *UIManager) SetProgressStream(stream *ProgressStream) {
+	ui.progressStream = stream
+}
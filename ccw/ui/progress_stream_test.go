@@ -0,0 +1,76 @@
+package ui
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"ccw/types"
+)
+
+func TestProgressStreamEvents(t *testing.T) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	ui := NewUIManager("default", false, false)
+	ui.SetQuiet(true)
+	stream := NewProgressStream(w)
+	fixed := time.Date(2026, 10, 15, 9, 30, 0, 0, time.FixedZone("JST", 9*60*60))
+	stream.now = func() time.Time { return fixed }
+	ui.SetProgressStream(stream)
+	ui.SetProgressSteps([]types.WorkflowStep{{ID: "validation", Name: "Validation", Status: "pending"}})
+
+	ui.UpdateProgress("validation", "in_progress")
+	ui.UpdateSubstepProgress("validation", "lint", "completed")
+	ui.UpdateProgress("unknown", "failed")
+	w.Close()
+
+	expected := []string{
+		`{"step":"validation","status":"in_progress","timestamp":"2026-10-15T00:30:00Z"}`,
+		`{"step":"validation","substep":"lint","status":"completed","timestamp":"2026-10-15T00:30:00Z"}`,
+		`{"step":"unknown","status":"failed","timestamp":"2026-10-15T00:30:00Z"}`,
+	}
+	scanner := bufio.NewScanner(r)
+	var lines []string
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	if len(lines) != len(expected) {
+		t.Fatalf("Expected %d events, got %d: %q", len(expected), len(lines), lines)
+	}
+	for i, line := range lines {
+		if line != expected[i] {
+			t.Errorf("Event %d: expected '%s', got '%s'", i, expected[i], line)
+		}
+		var event ProgressEvent
+		if err := json.Unmarshal([]byte(line), &event); err != nil || event.Step == "" || event.Timestamp.IsZero() {
+			t.Errorf("Event %d does not decode: %+v (%v)", i, event, err)
+		}
+	}
+}
+
+type failingWriter struct{ writes int }
+
+func (fw *failingWriter) Write(p []byte) (int, error) {
+	fw.writes++
+	return 0, errors.New("broken pipe")
+}
+
+func TestProgressStreamStopsAfterWriteError(t *testing.T) {
+	out := &failingWriter{}
+	stream := NewProgressStream(out)
+	stream.Emit("setup", "", "in_progress")
+	stream.Emit("setup", "", "completed")
+	if out.writes != 1 {
+		t.Errorf("Expected a single write attempt, got %d", out.writes)
+	}
+
+	var nilStream *ProgressStream
+	nilStream.Emit("setup", "", "completed")
+}
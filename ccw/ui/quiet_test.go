@@ -0,0 +1,37 @@
+package ui
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestQuietSuppressesAllButErrors(t *testing.T) {
+	withTerminal(t, false)
+	unsetEnv(t, "NO_COLOR")
+	t.Setenv("CCW_CONSOLE_MODE", "")
+
+	ui := NewUIManager("default", true, true)
+	var buf bytes.Buffer
+	ui.output = &buf
+	ui.SetQuiet(true)
+	if !ui.Quiet() {
+		t.Fatal("Expected quiet mode to be enabled")
+	}
+
+	ui.Info("Starting workflow")
+	ui.Success("Changes committed")
+	ui.Warning("Timed out")
+	ui.Debug("Details")
+	ui.Error("Push failed")
+
+	if expected := "[ERROR] Push failed\n"; buf.String() != expected {
+		t.Errorf("Expected only the error:\n%q\ngot:\n%q", expected, buf.String())
+	}
+
+	buf.Reset()
+	ui.SetQuiet(false)
+	ui.Info("Starting workflow")
+	if buf.String() != "[INFO] Starting workflow\n" {
+		t.Errorf("Expected info after leaving quiet mode, got %q", buf.String())
+	}
+}
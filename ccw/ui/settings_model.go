@@ -0,0 +1,208 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+
+	"ccw/config"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Settings screen for UI preferences persisted to the config file
+
+// settingItem identifies one row of the settings screen
+type settingItem int
+
+const (
+	settingAnimations settingItem = iota
+	settingShowLogs
+	settingTheme
+	settingConsoleMode
+)
+
+// settingItems is the display order of the settings screen
+var settingItems = []settingItem{settingAnimations, settingShowLogs, settingTheme, settingConsoleMode}
+
+// label returns the name shown for the setting
+func (item settingItem) label() string {
+	switch item {
+	case settingAnimations:
+		return "Animations"
+	case settingShowLogs:
+		return "Logs panel"
+	case settingTheme:
+		return "Theme"
+	case settingConsoleMode:
+		return "Console mode"
+	default:
+		return "Unknown"
+	}
+}
+
+// SettingsModel edits the ui section of the configuration
+type SettingsModel struct {
+	settings   config.UIConfiguration
+	configPath string
+	cursor     int
+	status     string
+}
+
+// OpenSettingsMsg switches the application to the settings screen
+type OpenSettingsMsg struct{}
+
+// SettingsChangedMsg carries edited settings so they can be applied live
+type SettingsChangedMsg struct {
+	Settings config.UIConfiguration
+}
+
+// settingsSavedMsg reports the result of writing settings to the config file
+type settingsSavedMsg struct {
+	path string
+	err  error
+}
+
+// NewSettingsModel creates a settings screen for settings stored at configPath
+func NewSettingsModel(settings config.UIConfiguration, configPath string) SettingsModel {
+	return SettingsModel{
+		settings:   settings,
+		configPath: configPath,
+	}
+}
+
+// applySetting returns settings with item toggled, or with the next theme selected
+func applySetting(settings config.UIConfiguration, item settingItem) config.UIConfiguration {
+	switch item {
+	case settingAnimations:
+		settings.Animations = !settings.Animations
+	case settingShowLogs:
+		settings.ShowLogs = !settings.ShowLogs
+	case settingTheme:
+		settings.Theme = nextTheme(settings.Theme)
+	case settingConsoleMode:
+		settings.ConsoleMode = !settings.ConsoleMode
+	}
+	return settings
+}
+
+// nextTheme cycles through config.UIThemes; unknown themes restart the cycle
+func nextTheme(current string) string {
+	for i, theme := range config.UIThemes {
+		if theme == current {
+			return config.UIThemes[(i+1)%len(config.UIThemes)]
+		}
+	}
+	return config.UIThemes[0]
+}
+
+// settingValue renders the current value of item
+func settingValue(settings config.UIConfiguration, item settingItem) string {
+	onOff := func(enabled bool) string {
+		if enabled {
+			return "on"
+		}
+		return "off"
+	}
+
+	switch item {
+	case settingAnimations:
+		return onOff(settings.Animations)
+	case settingShowLogs:
+		return onOff(settings.ShowLogs)
+	case settingTheme:
+		return settings.Theme
+	case settingConsoleMode:
+		return onOff(settings.ConsoleMode)
+	default:
+		return ""
+	}
+}
+
+// saveSettings writes settings to the config file in the background
+func saveSettings(path string, settings config.UIConfiguration) tea.Cmd {
+	return func() tea.Msg {
+		return settingsSavedMsg{path: path, err: config.UpdateUIConfiguration(path, settings)}
+	}
+}
+
+// Update handles navigation, changes every edited setting and saves it immediately
+func (m SettingsModel) Update(msg tea.Msg) (SettingsModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "up", "k":
+			if m.cursor > 0 {
+				m.cursor--
+			}
+		case "down", "j":
+			if m.cursor < len(settingItems)-1 {
+				m.cursor++
+			}
+		case "enter", " ":
+			m.settings = applySetting(m.settings, settingItems[m.cursor])
+			settings := m.settings
+			return m, tea.Batch(
+				func() tea.Msg { return SettingsChangedMsg{Settings: settings} },
+				saveSettings(m.configPath, settings),
+			)
+		case "esc":
+			return m, func() tea.Msg { return BackToMainMenuMsg{} }
+		}
+	case settingsSavedMsg:
+		if msg.err != nil {
+			m.status = errorStyle.Render(fmt.Sprintf("Failed to save: %v", msg.err))
+		} else {
+			m.status = successStyle.Render(fmt.Sprintf("Saved to %s", msg.path))
+		}
+	}
+	return m, nil
+}
+
+// View renders the settings list
+func (m SettingsModel) View() string {
+	s := headerStyle.Render("⚙ Settings") + "\n\n"
+
+	for i, item := range settingItems {
+		line := fmt.Sprintf("%-14s %s", item.label(), settingValue(m.settings, item))
+		cursor := " "
+		if m.cursor == i {
+			cursor = "▶"
+			line = selectedMenuItemStyle.Render(" " + line + " ")
+		} else {
+			line = menuItemStyle.Render(line)
+		}
+		s += fmt.Sprintf("%s %s\n", infoStyle.Render(cursor), line)
+	}
+
+	if m.status != "" {
+		s += "\n" + m.status + "\n"
+	}
+	s += "\n" + subtleStyle.Render("Enter/Space: change • Esc: back to main menu • Console mode applies to the next run")
+	return s
+}
+
+// ApplySettings applies edited UI settings to the running UI where possible
+func (ui *UIManager) ApplySettings(settings config.UIConfiguration) {
+	ui.theme = settings.Theme
+	ui.setTheme(settings.Theme)
+	if !ui.plainOutput {
+		ui.animations = settings.Animations
+	}
+	ui.hideLogsPanel = !settings.ShowLogs
+
+	if settings.ConsoleMode {
+		os.Setenv("CCW_CONSOLE_MODE", "true")
+	} else {
+		os.Unsetenv("CCW_CONSOLE_MODE")
+	}
+}
+
+// SetLogsPanelVisible sets whether the interactive UI starts with the logs panel shown
+func (ui *UIManager) SetLogsPanelVisible(visible bool) {
+	ui.hideLogsPanel = !visible
+}
+
+// SetLogBuffer sets the size of the buffer behind the logs panel
+// (ui.log_buffer_size)
+func (ui *UIManager) SetLogBuffer(size int) {
+	ui.logBufferSize = size
+}
@@ -0,0 +1,119 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"ccw/config"
+	tea "github.com/charmbracelet/bubbletea"
+	"gopkg.in/yaml.v3"
+)
+
+func TestApplySetting(t *testing.T) {
+	base := config.UIConfiguration{Theme: "default", Animations: true, ShowLogs: true}
+
+	tests := []struct {
+		name     string
+		item     settingItem
+		expected config.UIConfiguration
+	}{
+		{"animations", settingAnimations, config.UIConfiguration{Theme: "default", Animations: false, ShowLogs: true}},
+		{"logs panel", settingShowLogs, config.UIConfiguration{Theme: "default", Animations: true, ShowLogs: false}},
+		{"theme", settingTheme, config.UIConfiguration{Theme: "minimal", Animations: true, ShowLogs: true}},
+		{"console mode", settingConsoleMode, config.UIConfiguration{Theme: "default", Animations: true, ShowLogs: true, ConsoleMode: true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := applySetting(base, tt.item); got != tt.expected {
+				t.Errorf("Expected %+v, got %+v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestNextTheme(t *testing.T) {
+	tests := []struct {
+		current  string
+		expected string
+	}{
+		{"default", "minimal"},
+		{"modern", "compact"},
+		{"compact", "default"},
+		{"solarized", "default"},
+	}
+
+	for _, tt := range tests {
+		if got := nextTheme(tt.current); got != tt.expected {
+			t.Errorf("nextTheme(%s): expected '%s', got '%s'", tt.current, tt.expected, got)
+		}
+	}
+}
+
+func TestSettingsModelSavesChanges(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ccw.yaml")
+	model := NewSettingsModel(config.UIConfiguration{Theme: "default", Animations: true, ShowLogs: true}, path)
+
+	// Move to the logs panel row and toggle it
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyDown})
+	model, cmd := model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("Expected a command after changing a setting")
+	}
+
+	var changed *SettingsChangedMsg
+	var saved *settingsSavedMsg
+	batch, ok := cmd().(tea.BatchMsg)
+	if !ok {
+		t.Fatalf("Expected tea.BatchMsg, got %T", cmd())
+	}
+	for _, c := range batch {
+		switch msg := c().(type) {
+		case SettingsChangedMsg:
+			changed = &msg
+		case settingsSavedMsg:
+			saved = &msg
+		}
+	}
+
+	if changed == nil || changed.Settings.ShowLogs {
+		t.Fatalf("Expected SettingsChangedMsg with logs panel off, got %+v", changed)
+	}
+	if saved == nil || saved.err != nil {
+		t.Fatalf("Expected settings to be saved, got %+v", saved)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Expected config file to be written: %v", err)
+	}
+	var written config.CCWConfig
+	if err := yaml.Unmarshal(data, &written); err != nil {
+		t.Fatalf("Failed to parse written config: %v", err)
+	}
+	if written.UI.ShowLogs || !written.UI.Animations || written.UI.Theme != "default" {
+		t.Errorf("Unexpected ui section written: %+v", written.UI)
+	}
+
+	model, _ = model.Update(*saved)
+	if model.status == "" {
+		t.Error("Expected a status message after saving")
+	}
+}
+
+func TestApplySettingsUpdatesUIManager(t *testing.T) {
+	t.Setenv("CCW_CONSOLE_MODE", "")
+	ui := NewUIManager("default", true, false)
+
+	ui.ApplySettings(config.UIConfiguration{Theme: "compact", ShowLogs: false, ConsoleMode: true})
+	if ui.theme != "compact" || ui.currentTheme == nil || ui.currentTheme.Name != "compact" {
+		t.Errorf("Expected compact theme to be applied, got %s", ui.theme)
+	}
+	if !ui.hideLogsPanel {
+		t.Error("Expected logs panel to be hidden")
+	}
+	if os.Getenv("CCW_CONSOLE_MODE") != "true" {
+		t.Error("Expected console mode to be enabled")
+	}
+}
@@ -0,0 +1,160 @@
+package ui
+
+import (
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Transient toast notifications shown above the main content
+
+const (
+	// defaultToastDuration is how long a toast stays visible when ToastMsg has no Duration
+	defaultToastDuration = 4 * time.Second
+	// maxVisibleToasts limits how many toasts are stacked at once; older ones are hidden first
+	maxVisibleToasts = 3
+)
+
+// ToastLevel selects the styling of a toast
+type ToastLevel int
+
+const (
+	ToastInfo ToastLevel = iota
+	ToastSuccess
+	ToastWarning
+	ToastError
+)
+
+// ToastMsg asks the application to show a transient notification
+type ToastMsg struct {
+	Text     string
+	Level    ToastLevel
+	Duration time.Duration // Display window; defaultToastDuration when zero
+}
+
+// toastExpiredMsg is delivered by the timer started for a toast
+type toastExpiredMsg struct {
+	id int
+}
+
+// toast is a queued notification with its expiry time
+type toast struct {
+	id        int
+	text      string
+	level     ToastLevel
+	expiresAt time.Time
+}
+
+// ToastQueue holds the toasts that have not expired yet
+type ToastQueue struct {
+	toasts []toast
+	nextID int
+}
+
+// Push queues msg at now and returns the toast ID and how long it stays visible
+func (q *ToastQueue) Push(msg ToastMsg, now time.Time) (int, time.Duration) {
+	duration := msg.Duration
+	if duration <= 0 {
+		duration = defaultToastDuration
+	}
+
+	q.nextID++
+	q.toasts = append(q.toasts, toast{
+		id:        q.nextID,
+		text:      msg.Text,
+		level:     msg.Level,
+		expiresAt: now.Add(duration),
+	})
+	return q.nextID, duration
+}
+
+// Expire drops every toast whose display window has ended by now
+func (q *ToastQueue) Expire(now time.Time) {
+	remaining := make([]toast, 0, len(q.toasts))
+	for _, t := range q.toasts {
+		if now.Before(t.expiresAt) {
+			remaining = append(remaining, t)
+		}
+	}
+	q.toasts = remaining
+}
+
+// Dismiss removes the toast with id, whether or not it has expired
+func (q *ToastQueue) Dismiss(id int) {
+	remaining := make([]toast, 0, len(q.toasts))
+	for _, t := range q.toasts {
+		if t.id != id {
+			remaining = append(remaining, t)
+		}
+	}
+	q.toasts = remaining
+}
+
+// Visible returns the newest unexpired toasts at now, oldest first
+func (q ToastQueue) Visible(now time.Time) []toast {
+	var visible []toast
+	for _, t := range q.toasts {
+		if now.Before(t.expiresAt) {
+			visible = append(visible, t)
+		}
+	}
+	if len(visible) > maxVisibleToasts {
+		visible = visible[len(visible)-maxVisibleToasts:]
+	}
+	return visible
+}
+
+// Len returns the number of queued toasts, including expired ones not yet removed
+func (q ToastQueue) Len() int {
+	return len(q.toasts)
+}
+
+// scheduleToastExpiry returns a command that delivers toastExpiredMsg after duration
+func scheduleToastExpiry(id int, duration time.Duration) tea.Cmd {
+	return tea.Tick(duration, func(time.Time) tea.Msg {
+		return toastExpiredMsg{id: id}
+	})
+}
+
+// ShowToast returns a command that displays text as a toast
+func ShowToast(text string, level ToastLevel) tea.Cmd {
+	return func() tea.Msg {
+		return ToastMsg{Text: text, Level: level}
+	}
+}
+
+// stepToast returns a command announcing that the workflow step name reached
+// status, or nil for statuses that are not worth a toast
+func stepToast(name, status string) tea.Cmd {
+	switch status {
+	case "completed":
+		return ShowToast(name+" completed", ToastSuccess)
+	case "failed":
+		return ShowToast(name+" failed", ToastError)
+	default:
+		return nil
+	}
+}
+
+// renderToasts renders the visible toasts, one per line, or "" when there are none
+func renderToasts(toasts []toast) string {
+	if len(toasts) == 0 {
+		return ""
+	}
+
+	lines := make([]string, len(toasts))
+	for i, t := range toasts {
+		switch t.level {
+		case ToastSuccess:
+			lines[i] = successStyle.Render("✓ " + t.text)
+		case ToastWarning:
+			lines[i] = warningStyle.Render("⚠ " + t.text)
+		case ToastError:
+			lines[i] = errorStyle.Render("✗ " + t.text)
+		default:
+			lines[i] = infoStyle.Render("ℹ " + t.text)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,165 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"ccw/types"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestToastQueueLifecycle(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	var queue ToastQueue
+
+	id, duration := queue.Push(ToastMsg{Text: "PR created", Level: ToastSuccess}, now)
+	if duration != defaultToastDuration {
+		t.Errorf("Expected default duration %v, got %v", defaultToastDuration, duration)
+	}
+	_, shortDuration := queue.Push(ToastMsg{Text: "CI passed", Duration: time.Second}, now)
+	if shortDuration != time.Second {
+		t.Errorf("Expected 1s duration, got %v", shortDuration)
+	}
+
+	// Both toasts are shown within their display window
+	if visible := queue.Visible(now.Add(500 * time.Millisecond)); len(visible) != 2 {
+		t.Fatalf("Expected 2 visible toasts, got %d", len(visible))
+	}
+
+	// The short toast disappears after its window even before it is expired
+	visible := queue.Visible(now.Add(2 * time.Second))
+	if len(visible) != 1 || visible[0].id != id {
+		t.Fatalf("Expected only the first toast to be visible, got %+v", visible)
+	}
+
+	queue.Expire(now.Add(2 * time.Second))
+	if queue.Len() != 1 {
+		t.Errorf("Expected 1 queued toast after expiry, got %d", queue.Len())
+	}
+
+	queue.Expire(now.Add(defaultToastDuration))
+	if queue.Len() != 0 {
+		t.Errorf("Expected queue to be empty at the end of the display window, got %d", queue.Len())
+	}
+}
+
+func TestToastQueueLimitsVisibleToasts(t *testing.T) {
+	now := time.Now()
+	var queue ToastQueue
+	for i := 0; i < maxVisibleToasts+2; i++ {
+		queue.Push(ToastMsg{Text: string(rune('a' + i))}, now)
+	}
+
+	visible := queue.Visible(now)
+	if len(visible) != maxVisibleToasts {
+		t.Fatalf("Expected %d visible toasts, got %d", maxVisibleToasts, len(visible))
+	}
+	if visible[len(visible)-1].text != "e" {
+		t.Errorf("Expected newest toast last, got '%s'", visible[len(visible)-1].text)
+	}
+}
+
+func TestToastQueueDismiss(t *testing.T) {
+	now := time.Now()
+	var queue ToastQueue
+	first, _ := queue.Push(ToastMsg{Text: "first"}, now)
+	queue.Push(ToastMsg{Text: "second"}, now)
+
+	queue.Dismiss(first)
+	visible := queue.Visible(now)
+	if len(visible) != 1 || visible[0].text != "second" {
+		t.Errorf("Expected only 'second' to remain, got %+v", visible)
+	}
+}
+
+func TestAppModelShowsAndExpiresToasts(t *testing.T) {
+	model := NewAppModel(nil)
+
+	updated, cmd := model.Update(ToastMsg{Text: "PR created", Level: ToastSuccess, Duration: time.Millisecond})
+	if cmd == nil {
+		t.Fatal("Expected an expiry timer command")
+	}
+	model = updated.(AppModel)
+	if model.toasts.Len() != 1 {
+		t.Fatalf("Expected 1 queued toast, got %d", model.toasts.Len())
+	}
+
+	expired, ok := cmd().(toastExpiredMsg)
+	if !ok {
+		t.Fatalf("Expected toastExpiredMsg from timer, got %T", cmd())
+	}
+	updated, _ = model.Update(expired)
+	model = updated.(AppModel)
+	if model.toasts.Len() != 0 {
+		t.Errorf("Expected toast to be removed after expiry, got %d", model.toasts.Len())
+	}
+	if strings.Contains(model.View(), "PR created") {
+		t.Error("Expected expired toast not to be rendered")
+	}
+}
+
+func TestRenderToasts(t *testing.T) {
+	if renderToasts(nil) != "" {
+		t.Error("Expected no output without toasts")
+	}
+
+	rendered := renderToasts([]toast{{text: "CI passed", level: ToastSuccess}, {text: "CI failed", level: ToastError}})
+	if !strings.Contains(rendered, "CI passed") || !strings.Contains(rendered, "CI failed") {
+		t.Errorf("Expected both toasts in output, got '%s'", rendered)
+	}
+	if len(strings.Split(rendered, "\n")) != 2 {
+		t.Errorf("Expected one line per toast, got '%s'", rendered)
+	}
+}
+
+func TestAppModelToastsWorkflowStepResults(t *testing.T) {
+	model := NewAppModel(nil)
+	model.SetProgressSteps([]types.WorkflowStep{
+		{ID: "pr_creation", Name: "Create PR", Status: "in_progress"},
+		{ID: "ci", Name: "Monitor CI", Status: "pending"},
+	})
+	model.state = StateProgressTracking
+
+	// Only a top-level step finishing is announced
+	updated, cmd := model.Update(ProgressUpdateMsg{StepID: "pr_creation", Status: "completed"})
+	model = updated.(AppModel)
+	model = runToastCmds(t, model, cmd)
+	updated, cmd = model.Update(ProgressUpdateMsg{StepID: "ci", Status: "failed"})
+	model = updated.(AppModel)
+	model = runToastCmds(t, model, cmd)
+
+	view := model.View()
+	if !strings.Contains(view, "Create PR completed") || !strings.Contains(view, "Monitor CI failed") {
+		t.Errorf("Expected toasts for the finished steps, got '%s'", view)
+	}
+	if model.toasts.Len() != 2 {
+		t.Errorf("Expected 2 toasts, got %d", model.toasts.Len())
+	}
+
+	// Repeating a status is not a new event
+	updated, cmd = model.Update(ProgressUpdateMsg{StepID: "ci", Status: "failed"})
+	model = runToastCmds(t, updated.(AppModel), cmd)
+	if model.toasts.Len() != 2 {
+		t.Errorf("Expected no toast for an unchanged status, got %d", model.toasts.Len())
+	}
+}
+
+// runToastCmds feeds the ToastMsgs produced by cmd back into model, skipping
+// timers so the test does not wait for them
+func runToastCmds(t *testing.T, model AppModel, cmd tea.Cmd) AppModel {
+	t.Helper()
+	if cmd == nil {
+		return model
+	}
+	switch msg := cmd().(type) {
+	case tea.BatchMsg:
+		for _, c := range msg {
+			model = runToastCmds(t, model, c)
+		}
+	case ToastMsg:
+		updated, _ := model.Update(msg)
+		model = updated.(AppModel)
+	}
+	return model
+}
@@ -62,6 +62,7 @@ func (ui *UIManager) setTheme(themeName string) {
 
 // Update progress step
 func (ui *UIManager) UpdateProgress(stepID string, status string) {
+	ui.progressStream.Emit(stepID, "", status)
 	if ui.progressTracker == nil {
 		return
 	}
@@ -84,8 +85,47 @@ func (ui *UIManager) UpdateProgress(stepID string, status string) {
 	}
 }
 
+// UpdateSubstepProgress updates a nested substep; the parent step status is rolled up from its substeps
+func (ui *UIManager) UpdateSubstepProgress(stepID, substepID, status string) {
+	ui.progressStream.Emit(stepID, substepID, status)
+	if ui.progressTracker == nil {
+		return
+	}
+
+	if !ui.progressTracker.UpdateSubstep(stepID, substepID, status) {
+		return
+	}
+
+	if ui.animations {
+		ui.displayProgressHeader()
+	}
+}
+
+// substepLines renders the substeps of a step indented below it, boxed to the header width when boxed is set
+func (ui *UIManager) substepLines(step types.WorkflowStep, boxed bool) []string {
+	lines := make([]string, 0, len(step.Substeps))
+	for _, substep := range step.Substeps {
+		statusColor := ui.getStepColor(substep.Status)
+		if !boxed {
+			lines = append(lines, fmt.Sprintf("      - %s %s", ui.getStepIconConsole(substep.Status), statusColor(substep.Name)))
+			continue
+		}
+
+		line := fmt.Sprintf("│       %s %s", ui.getStepIcon(substep.Status), statusColor(substep.Name))
+		padding := 62 - len([]rune(stripAnsiCodes(line)))
+		if padding > 0 {
+			line += strings.Repeat(" ", padding)
+		}
+		lines = append(lines, line+" │")
+	}
+	return lines
+}
+
 // Display dynamic header with progress
 func (ui *UIManager) displayProgressHeader() {
+	if ui.quiet {
+		return
+	}
 	if ui.progressTracker == nil {
 		ui.DisplayHeader()
 		return
@@ -107,11 +147,14 @@ func (ui *UIManager) displayProgressHeader() {
 				icon,
 				step.Name,
 				statusColor(step.Description))
+			for _, line := range ui.substepLines(step, false) {
+				fmt.Println(line)
+			}
 		}
 		
 		// Display elapsed time
 		elapsed := time.Since(ui.progressTracker.StartTime).Round(time.Second)
-		fmt.Printf("Elapsed: %s\n", elapsed.String())
+		fmt.Printf("Elapsed: %s%s\n", elapsed.String(), ui.etaSuffix())
 		fmt.Println(ui.accentColor("========================"))
 		fmt.Println()
 	} else {
@@ -144,11 +187,14 @@ func (ui *UIManager) displayProgressHeader() {
 			line += " │"
 			
 			fmt.Println(ui.accentColor(line))
+			for _, substepLine := range ui.substepLines(step, true) {
+				fmt.Println(ui.accentColor(substepLine))
+			}
 		}
 		
 		// Display elapsed time
 		elapsed := time.Since(ui.progressTracker.StartTime).Round(time.Second)
-		timeLine := fmt.Sprintf("│ Elapsed: %-49s │", elapsed.String())
+		timeLine := fmt.Sprintf("│ Elapsed: %-49s │", elapsed.String()+ui.etaSuffix())
 		fmt.Println(ui.accentColor(timeLine))
 		
 		fmt.Println(ui.accentColor("└─────────────────────────────────────────────────────────────┘"))
@@ -318,11 +364,14 @@ func (ui *UIManager) generateHeaderContent() string {
 		line += " │\n"
 		
 		content.WriteString(ui.accentColor(line))
+		for _, substepLine := range ui.substepLines(step, true) {
+			content.WriteString(ui.accentColor(substepLine + "\n"))
+		}
 	}
 	
 	// Elapsed time
 	elapsed := time.Since(ui.progressTracker.StartTime).Round(time.Second)
-	timeLine := fmt.Sprintf("│ Elapsed: %-49s │\n", elapsed.String())
+	timeLine := fmt.Sprintf("│ Elapsed: %-49s │\n", elapsed.String()+ui.etaSuffix())
 	content.WriteString(ui.accentColor(timeLine))
 	
 	content.WriteString(ui.accentColor("└─────────────────────────────────────────────────────────────┘\n"))
@@ -461,11 +510,12 @@ func (ui *UIManager) displayIssueSelectionLineMode(issues []*types.Issue) ([]*ty
 			stateColor = ui.infoColor
 		}
 		
-		fmt.Printf("  %d) #%-4d %s %s\n", 
+		fmt.Printf("  %d) #%-4d %s %-60s %s\n", 
 			i+1,
 			issue.Number, 
 			stateColor(fmt.Sprintf("%-6s", issue.State)),
-			title)
+			title,
+			ui.infoColor(issueOpenedLabel(issue.CreatedAt)))
 	}
 	
 	fmt.Println()
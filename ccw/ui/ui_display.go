@@ -4,6 +4,9 @@ import "fmt"
 
 // DisplayHeader displays the static application header
 func (ui *UIManager) DisplayHeader() {
+	if ui.quiet {
+		return
+	}
 	fmt.Print("\n")
 	
 	if ui.isConsoleMode() {
@@ -6,27 +6,55 @@ import "fmt"
 
 // Info displays an informational message
 func (ui *UIManager) Info(msg string) {
-	fmt.Printf("%s %s\n", ui.infoColor("[INFO]"), msg)
+	if ui.quiet {
+		return
+	}
+	ui.printMessage(ui.infoColor, "[INFO]", msg)
 }
 
 // Success displays a success message
 func (ui *UIManager) Success(msg string) {
-	fmt.Printf("%s %s\n", ui.successColor("[SUCCESS]"), msg)
+	if ui.quiet {
+		return
+	}
+	ui.printMessage(ui.successColor, "[SUCCESS]", msg)
 }
 
 // Warning displays a warning message
 func (ui *UIManager) Warning(msg string) {
-	fmt.Printf("%s %s\n", ui.warningColor("[WARNING]"), msg)
+	if ui.quiet {
+		return
+	}
+	ui.printMessage(ui.warningColor, "[WARNING]", msg)
 }
 
 // Error displays an error message
 func (ui *UIManager) Error(msg string) {
-	fmt.Printf("%s %s\n", ui.errorColorFunc("[ERROR]"), msg)
+	ui.printMessage(ui.errorColorFunc, "[ERROR]", msg)
 }
 
 // Debug displays a debug message if debug mode is enabled
 func (ui *UIManager) Debug(msg string) {
-	if ui.debugMode {
-		fmt.Printf("%s %s\n", ui.accentColor("[DEBUG]"), msg)
+	if ui.debugMode && !ui.quiet {
+		ui.printMessage(ui.accentColor, "[DEBUG]", msg)
 	}
-}
\ No newline at end of file
+}
+
+// SetQuiet enables quiet mode, which suppresses everything but errors
+func (ui *UIManager) SetQuiet(quiet bool) {
+	ui.quiet = quiet
+}
+
+// Quiet reports whether quiet mode is enabled
+func (ui *UIManager) Quiet() bool {
+	return ui.quiet
+}
+
+// printMessage writes a labelled line, stripping styling and emoji in plain output mode
+func (ui *UIManager) printMessage(colorize func(...interface{}) string, label, msg string) {
+	if ui.plainOutput {
+		fmt.Fprintf(ui.output, "%s %s\n", label, PlainText(msg))
+		return
+	}
+	fmt.Fprintf(ui.output, "%s %s\n", colorize(label), msg)
+}
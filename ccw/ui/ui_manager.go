@@ -2,10 +2,13 @@ package ui
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"sync"
 	"time"
 
+	"ccw/consoleui"
+	"ccw/history"
 	"ccw/platform"
 	"ccw/types"
 	"github.com/fatih/color"
@@ -16,6 +19,13 @@ type UIManager struct {
 	theme      string
 	animations bool
 	debugMode  bool
+
+	// Plain output (no colors, ANSI escapes or emoji) for non-TTY destinations
+	plainOutput bool
+	output      io.Writer
+
+	// Quiet mode shows only errors: no header, progress, info, success or warnings
+	quiet bool
 	
 	// Color functions
 	primaryColor   func(...interface{}) string
@@ -28,6 +38,10 @@ type UIManager struct {
 	// Progress tracking
 	progressTracker *types.ProgressTracker
 	currentTheme    *types.ThemeConfig
+	etaEstimator    *history.ETAEstimator
+	hideLogsPanel   bool            // Start the interactive UI without the logs panel
+	progressStream  *ProgressStream // JSON progress events for --progress-fd, independent of the display
+	logBufferSize   int             // Entries kept for the logs panel, 0 = default
 	
 	// Animation control
 	animationRunning bool
@@ -48,13 +62,22 @@ type UIManager struct {
 // NewUIManager creates a new UI manager with specified configuration
 func NewUIManager(theme string, animations bool, debugMode bool) *UIManager {
 	ui := &UIManager{
-		theme:      theme,
-		animations: animations,
-		debugMode:  debugMode,
+		theme:       theme,
+		animations:  animations,
+		debugMode:   debugMode,
+		plainOutput: PlainOutput(),
+		output:      os.Stdout,
 	}
 	
 	ui.initializeColors()
 	ui.InitializeProgress()
+
+	// Piped output, NO_COLOR and console mode get no styling or animations
+	if ui.plainOutput {
+		color.NoColor = true
+		ui.initializeColorsPlain()
+		ui.animations = false
+	}
 	
 	return ui
 }
@@ -220,16 +243,12 @@ func (ui *UIManager) ShouldUseBubbleTea() bool {
 
 // isConsoleMode checks if we're running in console mode (CI-friendly)
 func (ui *UIManager) isConsoleMode() bool {
-	return os.Getenv("CCW_CONSOLE_MODE") == "true" || 
-		   os.Getenv("CI") == "true" || 
-		   os.Getenv("GITHUB_ACTIONS") == "true" ||
-		   os.Getenv("GITLAB_CI") == "true" ||
-		   os.Getenv("JENKINS_URL") != ""
+	return ui.plainOutput || consoleui.ConsoleMode()
 }
 
 // getConsoleChar returns console-safe characters based on mode
 func (ui *UIManager) getConsoleChar(fancy, simple string) string {
-	if ui.isConsoleMode() {
+	if ui.isConsoleMode() || consoleui.NoEmoji() {
 		return simple
 	}
 	return fancy
@@ -4,31 +4,91 @@ import (
 	"fmt"
 	"time"
 
+	"ccw/history"
 	"ccw/types"
 )
 
+// SetETAEstimator installs the estimator used to show time remaining; nil disables the ETA
+func (ui *UIManager) SetETAEstimator(estimator *history.ETAEstimator) {
+	ui.etaEstimator = estimator
+}
+
+// ProgressSteps returns a snapshot of the tracked workflow steps
+func (ui *UIManager) ProgressSteps() []types.WorkflowStep {
+	if ui.progressTracker == nil {
+		return nil
+	}
+	steps := make([]types.WorkflowStep, len(ui.progressTracker.Steps))
+	copy(steps, ui.progressTracker.Steps)
+	return steps
+}
+
+// etaSuffix formats the estimated time remaining for the elapsed line, or "" without history
+func (ui *UIManager) etaSuffix() string {
+	if ui.progressTracker == nil {
+		return ""
+	}
+	remaining, ok := ui.etaEstimator.Remaining(ui.progressTracker.Steps, time.Now())
+	if !ok {
+		return ""
+	}
+	return " | ETA: " + history.FormatETA(remaining)
+}
+
+// DefaultProgressSteps returns the built-in workflow steps, all pending
+func DefaultProgressSteps() []types.WorkflowStep {
+	return []types.WorkflowStep{
+		{ID: "setup", Name: "Setting up worktree", Description: "Creating isolated development environment", Status: "pending"},
+		{ID: "fetch", Name: "Fetching issue data", Description: "Retrieving GitHub issue information", Status: "pending"},
+		{ID: "analysis", Name: "Generating analysis", Description: "Preparing implementation context", Status: "pending"},
+		{ID: "implementation", Name: "Running Claude Code", Description: "Automated implementation process", Status: "pending"},
+		{ID: "validation", Name: "Validating implementation", Description: "Running quality checks", Status: "pending",
+			Substeps: []types.WorkflowStep{
+				{ID: "lint", Name: "Lint", Description: "SwiftLint checks", Status: "pending", Weight: 1},
+				{ID: "build", Name: "Build", Description: "swift build", Status: "pending", Weight: 2},
+				{ID: "test", Name: "Test", Description: "swift test", Status: "pending", Weight: 3},
+			}},
+		{ID: "commit", Name: "Committing changes", Description: "Creating git commit with all changes", Status: "pending"},
+		{ID: "pr_generation", Name: "Generating PR description", Description: "Creating comprehensive PR description", Status: "pending"},
+		{ID: "pr_creation", Name: "Creating pull request", Description: "Submitting PR to GitHub", Status: "pending"},
+		{ID: "complete", Name: "Workflow complete", Description: "Process finished successfully", Status: "pending"},
+	}
+}
+
 // InitializeProgress initializes the progress tracker with workflow steps
 func (ui *UIManager) InitializeProgress() {
+	ui.SetProgressSteps(DefaultProgressSteps())
+}
+
+// SetProgressSteps replaces the tracked workflow steps, e.g. with the steps
+// from workflow.steps, and restarts progress tracking
+func (ui *UIManager) SetProgressSteps(steps []types.WorkflowStep) {
 	ui.progressTracker = &types.ProgressTracker{
-		Steps: []types.WorkflowStep{
-			{ID: "setup", Name: "Setting up worktree", Description: "Creating isolated development environment", Status: "pending"},
-			{ID: "fetch", Name: "Fetching issue data", Description: "Retrieving GitHub issue information", Status: "pending"},
-			{ID: "analysis", Name: "Generating analysis", Description: "Preparing implementation context", Status: "pending"},
-			{ID: "implementation", Name: "Running Claude Code", Description: "Automated implementation process", Status: "pending"},
-			{ID: "validation", Name: "Validating implementation", Description: "Running quality checks", Status: "pending"},
-			{ID: "commit", Name: "Committing changes", Description: "Creating git commit with all changes", Status: "pending"},
-			{ID: "pr_generation", Name: "Generating PR description", Description: "Creating comprehensive PR description", Status: "pending"},
-			{ID: "pr_creation", Name: "Creating pull request", Description: "Submitting PR to GitHub", Status: "pending"},
-			{ID: "complete", Name: "Workflow complete", Description: "Process finished successfully", Status: "pending"},
-		},
+		Steps:       steps,
 		CurrentStep: 0,
 		StartTime:   time.Now(),
-		TotalSteps:  9,
+		TotalSteps:  len(steps),
 	}
 }
 
+// HasProgressStep reports whether stepID is one of the tracked steps
+func (ui *UIManager) HasProgressStep(stepID string) bool {
+	if ui.progressTracker == nil {
+		return false
+	}
+	for _, step := range ui.progressTracker.Steps {
+		if step.ID == stepID {
+			return true
+		}
+	}
+	return false
+}
+
 // DisplayProgressHeaderWithBackground displays enhanced progress header with background updates
 func (ui *UIManager) DisplayProgressHeaderWithBackground() {
+	if ui.quiet {
+		return
+	}
 	if ui.progressTracker == nil {
 		ui.DisplayHeader()
 		return